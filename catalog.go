@@ -0,0 +1,186 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+func init() {
+	register(&Command{
+		Path:    []string{"catalog", "list"},
+		Summary: "list the services and endpoints in the catalog of the token",
+		FlagValues: map[string]Completer{
+			"type":      completeServiceTypes,
+			"interface": globalFlagValues["os-interface"],
+		},
+		Setup: func(flags *flag.FlagSet) Runner {
+			filter := catalogFlags(flags)
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 0 {
+					return fmt.Errorf("usage: %s catalog list [flags]", sdk.program)
+				}
+				services, err := filter.apply(sdk)
+				if err != nil {
+					return err
+				}
+				rows := [][]string{}
+				for _, service := range services {
+					rows = append(rows, []string{value(service.Name), value(service.Type), endpoints(service)})
+				}
+				printTable(sdk.out, []string{"Name", "Type", "Endpoints"}, rows)
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:    []string{"catalog", "show"},
+		Usage:   "<service>",
+		Summary: "show the endpoints of a service in the catalog, by type or name",
+		Args:    []Completer{completeServiceTypes},
+		FlagValues: map[string]Completer{
+			"interface": globalFlagValues["os-interface"],
+		},
+		Setup: func(flags *flag.FlagSet) Runner {
+			filter := catalogFlags(flags)
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 1 {
+					return fmt.Errorf("usage: %s catalog show [flags] <service>", sdk.program)
+				}
+				services, err := filter.apply(sdk)
+				if err != nil {
+					return err
+				}
+				found := false
+				for _, service := range services {
+					if value(service.Type) != args[0] && value(service.Name) != args[0] {
+						continue
+					}
+					found = true
+					printFields(sdk.out, [][]string{
+						{"ID", value(service.ID)},
+						{"Name", value(service.Name)},
+						{"Type", value(service.Type)},
+						{"Endpoints", endpoints(service)},
+					})
+				}
+				if !found {
+					return fmt.Errorf("no service with type or name %q in the catalog", args[0])
+				}
+				return nil
+			}
+		},
+	})
+}
+
+// catalogFilter selects the services of a given type, and their endpoints in a
+// given region and with a given interface; empty fields match any value.
+type catalogFilter struct {
+	Type      string
+	Region    string
+	Interface string
+}
+
+// catalogFlags registers the flags of a catalog filter on the given flag set.
+func catalogFlags(flags *flag.FlagSet) *catalogFilter {
+	filter := &catalogFilter{}
+	flags.StringVar(&filter.Type, "type", "", "only show the services of the given type (e.g. compute)")
+	flags.StringVar(&filter.Region, "region", "", "only show the endpoints in the given region")
+	flags.StringVar(&filter.Interface, "interface", "", "only show the endpoints with the given interface (public, internal or admin)")
+	return filter
+}
+
+// apply returns the services and endpoints selected by the filter in the
+// catalog of the token of the given session.
+func (f *catalogFilter) apply(sdk *OpenStack) ([]openstack.Service, error) {
+	catalog := sdk.client.Authenticator.GetCatalog()
+	if catalog == nil {
+		return nil, fmt.Errorf("the token has no catalog: is it scoped to a project?")
+	}
+	return f.filter(*catalog), nil
+}
+
+// filter returns the services and endpoints in the given catalog selected by
+// the filter, sorted by type; services left without endpoints by the region
+// and interface filters are dropped.
+func (f *catalogFilter) filter(catalog []openstack.Service) []openstack.Service {
+	services := []openstack.Service{}
+	for _, service := range catalog {
+		if f.Type != "" && value(service.Type) != f.Type {
+			continue
+		}
+		selected := []openstack.Endpoint{}
+		if service.Endpoints != nil {
+			for _, endpoint := range *service.Endpoints {
+				if f.Region != "" && value(endpoint.Region) != f.Region {
+					continue
+				}
+				if f.Interface != "" && interfaceName(endpoint.Interface) != strings.TrimSuffix(f.Interface, "URL") {
+					continue
+				}
+				selected = append(selected, endpoint)
+			}
+		}
+		if len(selected) == 0 && (f.Region != "" || f.Interface != "") {
+			continue
+		}
+		service.Endpoints = &selected
+		services = append(services, service)
+	}
+	sort.SliceStable(services, func(i, j int) bool {
+		return value(services[i].Type) < value(services[j].Type)
+	})
+	return services
+}
+
+// endpoints returns the endpoints of the given service grouped by region, as
+// in the OpenStack command line client, e.g.:
+//
+//	RegionOne
+//	  public: https://cloud:8774/v2.1
+//	  internal: http://controller:8774/v2.1
+func endpoints(service openstack.Service) string {
+	if service.Endpoints == nil {
+		return ""
+	}
+	regions := []string{}
+	byRegion := map[string][]string{}
+	for _, endpoint := range *service.Endpoints {
+		region := value(endpoint.Region)
+		if _, ok := byRegion[region]; !ok {
+			regions = append(regions, region)
+		}
+		byRegion[region] = append(byRegion[region], fmt.Sprintf("  %s: %s", interfaceName(endpoint.Interface), value(endpoint.URL)))
+	}
+	lines := []string{}
+	for _, region := range regions {
+		lines = append(lines, region)
+		lines = append(lines, byRegion[region]...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// completeServiceTypes returns the types of the services in the catalog.
+func completeServiceTypes(sdk *OpenStack) []string {
+	if err := sdk.login(); err != nil {
+		return nil
+	}
+	catalog := sdk.client.Authenticator.GetCatalog()
+	if catalog == nil {
+		return nil
+	}
+	types := []string{}
+	for _, service := range *catalog {
+		types = append(types, value(service.Type))
+	}
+	sort.Strings(types)
+	return types
+}
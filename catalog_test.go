@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+func TestCatalogFilter(t *testing.T) {
+	catalog := []openstack.Service{
+		{
+			Type: openstack.String("identity"),
+			Name: openstack.String("keystone"),
+			Endpoints: &[]openstack.Endpoint{
+				{Interface: openstack.InterfacePublic.Ptr(), Region: openstack.String("RegionOne"), URL: openstack.String("https://cloud/identity")},
+			},
+		},
+		{
+			Type: openstack.String("compute"),
+			Name: openstack.String("nova"),
+			Endpoints: &[]openstack.Endpoint{
+				{Interface: openstack.InterfacePublic.Ptr(), Region: openstack.String("RegionOne"), URL: openstack.String("https://cloud:8774/v2.1")},
+				{Interface: openstack.InterfaceInternal.Ptr(), Region: openstack.String("RegionOne"), URL: openstack.String("http://controller:8774/v2.1")},
+				{Interface: openstack.InterfacePublic.Ptr(), Region: openstack.String("RegionTwo"), URL: openstack.String("https://cloud2:8774/v2.1")},
+			},
+		},
+	}
+	tests := []struct {
+		filter   catalogFilter
+		expected string
+	}{
+		{catalogFilter{}, "compute:3 identity:1"},
+		{catalogFilter{Type: "compute"}, "compute:3"},
+		{catalogFilter{Region: "RegionTwo"}, "compute:1"},
+		{catalogFilter{Interface: "publicURL"}, "compute:2 identity:1"},
+		{catalogFilter{Type: "compute", Interface: "internal"}, "compute:1"},
+		{catalogFilter{Type: "image"}, ""},
+	}
+	for _, test := range tests {
+		services := []string{}
+		for _, service := range test.filter.filter(catalog) {
+			services = append(services, fmt.Sprintf("%s:%d", *service.Type, len(*service.Endpoints)))
+		}
+		actual := strings.Join(services, " ")
+		if actual != test.expected {
+			t.Errorf("Catalog.TestCatalogFilter: expected %q for %+v, got %q", test.expected, test.filter, actual)
+		}
+	}
+
+	expected := "RegionOne\n  public: https://cloud:8774/v2.1\n  internal: http://controller:8774/v2.1\nRegionTwo\n  public: https://cloud2:8774/v2.1"
+	if actual := endpoints(catalog[1]); actual != expected {
+		t.Errorf("Catalog.TestCatalogFilter: expected endpoints\n%s\ngot\n%s", expected, actual)
+	}
+}
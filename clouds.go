@@ -0,0 +1,280 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// cloudsFiles returns the paths where clouds.yaml (or secure.yaml, with the
+// secrets of the clouds) is looked for, in order of precedence: the current
+// directory, the user configuration directory and /etc/openstack; the first
+// file found is used.
+func cloudsFiles(name string) []string {
+	paths := []string{name}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "openstack", name))
+	}
+	return append(paths, filepath.Join("/etc", "openstack", name))
+}
+
+// LoadCloud returns the entry of the cloud with the given name in clouds.yaml,
+// merged with the one in secure.yaml, if any; clouds.yaml is read from the
+// path in $OS_CLIENT_CONFIG_FILE if set.
+func LoadCloud(name string) (map[string]interface{}, error) {
+	paths := cloudsFiles("clouds.yaml")
+	if path := os.Getenv("OS_CLIENT_CONFIG_FILE"); path != "" {
+		paths = []string{path}
+	}
+	clouds, path, err := loadClouds(paths)
+	if err != nil {
+		return nil, err
+	}
+	if clouds == nil {
+		return nil, fmt.Errorf("cloud %q not found: no clouds.yaml in %s", name, strings.Join(paths, ", "))
+	}
+	cloud, ok := clouds[name].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cloud %q not found in %s", name, path)
+	}
+	log.Debugf("cloud %q loaded from %s", name, path)
+
+	secure, path, err := loadClouds(cloudsFiles("secure.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	if secrets, ok := secure[name].(map[string]interface{}); ok {
+		log.Debugf("secrets of cloud %q loaded from %s", name, path)
+		merge(cloud, secrets)
+	}
+	return cloud, nil
+}
+
+// loadClouds returns the "clouds" section of the first of the given files that
+// exists, along with its path; it returns nil if none exists.
+func loadClouds(paths []string) (map[string]interface{}, string, error) {
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			log.Errorf("error reading %s: %v", path, err)
+			return nil, path, err
+		}
+		document, err := parseYAML(string(data))
+		if err != nil {
+			log.Errorf("error parsing %s: %v", path, err)
+			return nil, path, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+		clouds, _ := document["clouds"].(map[string]interface{})
+		if clouds == nil {
+			clouds = map[string]interface{}{}
+		}
+		return clouds, path, nil
+	}
+	return nil, "", nil
+}
+
+// merge recursively copies the values in source into target.
+func merge(target map[string]interface{}, source map[string]interface{}) {
+	for key, value := range source {
+		if child, ok := value.(map[string]interface{}); ok {
+			if existing, ok := target[key].(map[string]interface{}); ok {
+				merge(existing, child)
+				continue
+			}
+		}
+		target[key] = value
+	}
+}
+
+// lookupString returns the string at the given dot-separated path (e.g.
+// "auth.auth_url") in the given document, or an empty string if there is none.
+func lookupString(document map[string]interface{}, path string) string {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := document[key].(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		document = child
+	}
+	value, _ := document[keys[len(keys)-1]].(string)
+	return value
+}
+
+// yamlLine is a significant line of a YAML document, with its indentation.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// parseYAML parses the subset of YAML used in clouds.yaml: nested block
+// mappings and sequences with plain or quoted scalars, and comments; flow
+// collections (e.g. "[a, b]") are returned as plain strings.
+func parseYAML(data string) (map[string]interface{}, error) {
+	lines := []*yamlLine{}
+	for i, text := range strings.Split(strings.Replace(data, "\t", "  ", -1), "\n") {
+		trimmed := strings.TrimSpace(stripComment(text))
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		lines = append(lines, &yamlLine{
+			number: i + 1,
+			indent: len(text) - len(strings.TrimLeft(text, " ")),
+			text:   trimmed,
+		})
+	}
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	parser := &yamlParser{lines: lines}
+	value, err := parser.block(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if parser.next < len(lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", lines[parser.next].number)
+	}
+	document, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("line %d: the document is not a mapping", lines[0].number)
+	}
+	return document, nil
+}
+
+// yamlParser parses a block of lines at a time.
+type yamlParser struct {
+	lines []*yamlLine
+	next  int
+}
+
+// block parses the mapping or sequence starting at the next line, which has
+// the given indentation.
+func (p *yamlParser) block(indent int) (interface{}, error) {
+	if isSequenceItem(p.lines[p.next].text) {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent)
+}
+
+// mapping parses the mapping starting at the next line.
+func (p *yamlParser) mapping(indent int) (map[string]interface{}, error) {
+	mapping := map[string]interface{}{}
+	for p.next < len(p.lines) && p.lines[p.next].indent == indent && !isSequenceItem(p.lines[p.next].text) {
+		line := p.lines[p.next]
+		index := strings.Index(line.text, ":")
+		if index <= 0 || (index < len(line.text)-1 && line.text[index+1] != ' ') {
+			return nil, fmt.Errorf("line %d: expected a key", line.number)
+		}
+		key := unquote(strings.TrimSpace(line.text[:index]))
+		value := strings.TrimSpace(line.text[index+1:])
+		p.next++
+		if value != "" {
+			mapping[key] = unquote(value)
+			continue
+		}
+		switch {
+		case p.next < len(p.lines) && p.lines[p.next].indent > indent:
+			child, err := p.block(p.lines[p.next].indent)
+			if err != nil {
+				return nil, err
+			}
+			mapping[key] = child
+		case p.next < len(p.lines) && p.lines[p.next].indent == indent && isSequenceItem(p.lines[p.next].text):
+			// sequences can be at the same indentation as their key
+			child, err := p.sequence(indent)
+			if err != nil {
+				return nil, err
+			}
+			mapping[key] = child
+		default:
+			mapping[key] = nil
+		}
+	}
+	return mapping, nil
+}
+
+// sequence parses the sequence starting at the next line.
+func (p *yamlParser) sequence(indent int) ([]interface{}, error) {
+	sequence := []interface{}{}
+	for p.next < len(p.lines) && p.lines[p.next].indent == indent && isSequenceItem(p.lines[p.next].text) {
+		line := p.lines[p.next]
+		item := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		switch {
+		case item == "":
+			p.next++
+			if p.next >= len(p.lines) || p.lines[p.next].indent <= indent {
+				sequence = append(sequence, nil)
+				continue
+			}
+			child, err := p.block(p.lines[p.next].indent)
+			if err != nil {
+				return nil, err
+			}
+			sequence = append(sequence, child)
+		case strings.Contains(item, ": ") || strings.HasSuffix(item, ":"):
+			// a mapping starting on the line of the item: parse the line again as
+			// the first key of a mapping, indented as the text after the dash
+			line.indent += len(line.text) - len(item)
+			line.text = item
+			child, err := p.mapping(line.indent)
+			if err != nil {
+				return nil, err
+			}
+			sequence = append(sequence, child)
+		default:
+			p.next++
+			sequence = append(sequence, unquote(item))
+		}
+	}
+	return sequence, nil
+}
+
+// isSequenceItem checks whether the given line is an item of a sequence.
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// stripComment removes the comment, if any, from the given line.
+func stripComment(text string) string {
+	quote := rune(0)
+	for i, c := range text {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' '):
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// unquote removes the quotes, if any, around the given scalar.
+func unquote(value string) string {
+	if len(value) >= 2 {
+		switch {
+		case value[0] == '"' && value[len(value)-1] == '"':
+			value = value[1 : len(value)-1]
+			replacer := strings.NewReplacer(`\"`, `"`, `\\`, `\`, `\n`, "\n", `\t`, "\t")
+			return replacer.Replace(value)
+		case value[0] == '\'' && value[len(value)-1] == '\'':
+			return strings.Replace(value[1:len(value)-1], "''", "'", -1)
+		}
+	}
+	return value
+}
@@ -0,0 +1,155 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Runner runs a command with the given positional arguments, once its flags
+// have been parsed.
+type Runner func(sdk *OpenStack, args []string) error
+
+// Completer returns the candidate values of an argument or a flag value; it
+// is given the session of the command line being completed, so that it can
+// query the cloud (e.g. for the names of the projects).
+type Completer func(sdk *OpenStack) []string
+
+// Command is a command of the CLI, e.g. "token issue".
+type Command struct {
+	// Path is the sequence of words invoking the command, e.g. "token" and
+	// "issue".
+	Path []string
+	// Usage describes the positional arguments, e.g. "<token>".
+	Usage string
+	// Summary is a one-line description of the command.
+	Summary string
+	// Setup registers the flags of the command on the given flag set and
+	// returns the function running it, which sees the parsed flags.
+	Setup func(flags *flag.FlagSet) Runner
+	// Args are the completers of the positional arguments, if any.
+	Args []Completer
+	// FlagValues are the completers of the values of the flags, if any.
+	FlagValues map[string]Completer
+	// Offline commands do not need to log in to the cloud.
+	Offline bool
+	// Watchable commands (those listing or showing resources) can be run in
+	// watch mode, with the --watch flag.
+	Watchable bool
+}
+
+// Name returns the words invoking the command.
+func (c *Command) Name() string {
+	return strings.Join(c.Path, " ")
+}
+
+// commands is the registry of all the commands of the CLI.
+var commands = []*Command{}
+
+// register adds the given command to the registry; it is meant to be called
+// from the init functions of the files implementing the commands.
+func register(command *Command) {
+	commands = append(commands, command)
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Name() < commands[j].Name()
+	})
+}
+
+// findCommand returns the command invoked by the given words, along with the
+// number of words naming it, or nil if there is none.
+func findCommand(words []string) (*Command, int) {
+	for _, command := range commands {
+		if len(words) >= len(command.Path) && strings.Join(words[:len(command.Path)], " ") == command.Name() {
+			return command, len(command.Path)
+		}
+	}
+	return nil, 0
+}
+
+// parseFlags parses the given arguments with the given flag set, allowing
+// flags to follow the positional arguments (e.g. "server show <id> --wait");
+// the arguments after a "--" are all positional.
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	rest := []string{}
+	for i, arg := range args {
+		if arg == "--" {
+			args, rest = args[:i], args[i+1:]
+			break
+		}
+	}
+	positional := []string{}
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// runCommand runs the command invoked by the given words with the given
+// session.
+func runCommand(sdk *OpenStack, words []string) error {
+	command, n := findCommand(words)
+	if command == nil {
+		return fmt.Errorf("unknown command %q, see \"help\" for the available commands", strings.Join(words, " "))
+	}
+	flags := flag.NewFlagSet(command.Name(), flag.ContinueOnError)
+	run, watch := setupCommand(command, flags)
+	args, err := parseFlags(flags, words[n:])
+	if err != nil {
+		return err
+	}
+	if !command.Offline {
+		if err := sdk.login(); err != nil {
+			return err
+		}
+	}
+	if watch != nil && watch.Watch {
+		return watchCommand(sdk, watch, strings.Join(words, " "), run, args)
+	}
+	return run(sdk, args)
+}
+
+// setupCommand registers the flags of the given command on the given flag set,
+// including those of watch mode if it supports it.
+func setupCommand(command *Command, flags *flag.FlagSet) (Runner, *watchOptions) {
+	run := command.Setup(flags)
+	if command.Watchable {
+		return run, watchFlags(flags)
+	}
+	return run, nil
+}
+
+// printUsage prints the list of the commands, with their summaries.
+func printUsage(out io.Writer, program string) {
+	fmt.Fprintf(out, "usage: %s [global flags] <command> [flags] [arguments]\n\ncommands:\n", program)
+	for _, command := range commands {
+		fmt.Fprintf(out, "  %-36s %s\n", strings.TrimSpace(command.Name()+" "+command.Usage), command.Summary)
+	}
+	fmt.Fprintf(out, "\nrun \"%s <command> -h\" for the flags of a command, \"%s -h\" for the global flags\n", program, program)
+}
+
+func init() {
+	register(&Command{
+		Path:    []string{"help"},
+		Summary: "show the available commands",
+		Offline: true,
+		Setup: func(flags *flag.FlagSet) Runner {
+			return func(sdk *OpenStack, args []string) error {
+				printUsage(sdk.out, sdk.program)
+				return nil
+			}
+		},
+	})
+}
@@ -0,0 +1,293 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+// completeCommand is the hidden command the completion scripts invoke with
+// the words of the command line, the last one being the word to complete.
+const completeCommand = "__complete"
+
+// completionTimeout is the timeout of the calls to the cloud performed to
+// complete the names of its resources: the shell waits for them.
+const completionTimeout = 3 * time.Second
+
+// completionScripts are the completion scripts for the supported shells; %[1]s
+// is the name of the program.
+var completionScripts = map[string]string{
+	"bash": `# bash completion for %[1]s; load it with:
+#   source <(%[1]s completion bash)
+_%[2]s() {
+    local IFS=$'\n'
+    COMPREPLY=($(%[1]s __complete "${COMP_WORDS[@]:1:$COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _%[2]s %[1]s
+`,
+	"zsh": `#compdef %[1]s
+# zsh completion for %[1]s; load it with:
+#   source <(%[1]s completion zsh)
+_%[2]s() {
+    local -a candidates
+    candidates=(${(f)"$(%[1]s __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+    compadd -a candidates
+}
+compdef _%[2]s %[1]s
+`,
+	"fish": `# fish completion for %[1]s; load it with:
+#   %[1]s completion fish | source
+complete -c %[1]s -f -a '(%[1]s __complete (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null)'
+`,
+}
+
+func init() {
+	register(&Command{
+		Path:    []string{"completion"},
+		Usage:   "<bash|zsh|fish>",
+		Summary: "print the shell completion script",
+		Offline: true,
+		Args: []Completer{
+			func(sdk *OpenStack) []string {
+				return []string{"bash", "fish", "zsh"}
+			},
+		},
+		Setup: func(flags *flag.FlagSet) Runner {
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 1 {
+					return fmt.Errorf("usage: %s completion <bash|zsh|fish>", sdk.program)
+				}
+				script, ok := completionScripts[args[0]]
+				if !ok {
+					return fmt.Errorf("unsupported shell %q", args[0])
+				}
+				function := strings.NewReplacer("-", "_", ".", "_").Replace(sdk.program)
+				_, err := fmt.Fprintf(sdk.out, script, sdk.program, function)
+				return err
+			}
+		},
+	})
+}
+
+// complete prints the candidates for the last of the given words, one per
+// line: command names, flags, or values of arguments and flags, which may be
+// queried from the cloud using the global flags among the words.
+func complete(out io.Writer, words []string) {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+	for _, candidate := range candidates(words[:len(words)-1], current) {
+		if strings.HasPrefix(candidate, current) {
+			fmt.Fprintln(out, candidate)
+		}
+	}
+}
+
+// candidates returns all the candidates for the word following the given
+// ones, whatever its prefix.
+func candidates(words []string, current string) []string {
+	flags, parsed, _ := globalFlags("")
+	global, rest, pending := splitFlags(flags, words)
+	session := func() *OpenStack {
+		flags.Parse(global)
+		config, err := ResolveConfig(flags, parsed)
+		if err != nil {
+			config = parsed
+		}
+		sdk := newClient(config)
+		sdk.timeout = completionTimeout
+		return sdk
+	}
+	if pending != "" {
+		if completer, ok := globalFlagValues[pending]; ok {
+			return completer(session())
+		}
+		return nil
+	}
+
+	command, n := findCommand(rest)
+	if command == nil {
+		if strings.HasPrefix(current, "-") {
+			return flagNames(flags)
+		}
+		return subcommands(rest)
+	}
+
+	local := flag.NewFlagSet(command.Name(), flag.ContinueOnError)
+	setupCommand(command, local)
+	_, args, pending := splitFlags(local, rest[n:])
+	switch {
+	case pending != "":
+		if completer, ok := command.FlagValues[pending]; ok {
+			return completer(session())
+		}
+		return nil
+	case strings.HasPrefix(current, "-"):
+		return flagNames(local)
+	case len(args) < len(command.Args) && command.Args[len(args)] != nil:
+		return command.Args[len(args)](session())
+	}
+	return nil
+}
+
+// splitFlags splits the given words into those belonging to the flags in the
+// given flag set (with their values) and the others; if the last word is a
+// flag expecting a value, its name is returned as well.
+func splitFlags(flags *flag.FlagSet, words []string) ([]string, []string, string) {
+	matched, others := []string{}, []string{}
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if !strings.HasPrefix(word, "-") || word == "-" || word == "--" {
+			others = append(others, word)
+			continue
+		}
+		name := strings.TrimLeft(word, "-")
+		f := flags.Lookup(strings.SplitN(name, "=", 2)[0])
+		if f == nil {
+			others = append(others, word)
+			continue
+		}
+		matched = append(matched, word)
+		if strings.Contains(name, "=") || isBoolFlag(f) {
+			continue
+		}
+		if i == len(words)-1 {
+			return matched, others, f.Name
+		}
+		i++
+		matched = append(matched, words[i])
+	}
+	return matched, others, ""
+}
+
+// isBoolFlag checks whether the given flag takes no value.
+func isBoolFlag(f *flag.Flag) bool {
+	value, ok := f.Value.(interface {
+		IsBoolFlag() bool
+	})
+	return ok && value.IsBoolFlag()
+}
+
+// flagNames returns the names of the flags in the given flag set, prefixed
+// with "--".
+func flagNames(flags *flag.FlagSet) []string {
+	names := []string{}
+	flags.VisitAll(func(f *flag.Flag) {
+		names = append(names, "--"+f.Name)
+	})
+	return names
+}
+
+// subcommands returns the words that can follow the given ones in the names
+// of the commands.
+func subcommands(words []string) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, command := range commands {
+		if len(command.Path) <= len(words) || strings.Join(command.Path[:len(words)], " ") != strings.Join(words, " ") {
+			continue
+		}
+		if name := command.Path[len(words)]; !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// globalFlagValues are the completers of the values of the global flags.
+var globalFlagValues = map[string]Completer{
+	"os-cloud":        completeClouds,
+	"os-interface":    func(sdk *OpenStack) []string { return []string{"admin", "internal", "public"} },
+	"os-project-name": completeProjects,
+}
+
+// completeClouds returns the names of the clouds in clouds.yaml.
+func completeClouds(sdk *OpenStack) []string {
+	clouds, _, err := loadClouds(cloudsFiles("clouds.yaml"))
+	if err != nil {
+		return nil
+	}
+	names := []string{}
+	for name := range clouds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// completeProjects returns the names of the projects of the user.
+func completeProjects(sdk *OpenStack) []string {
+	if err := sdk.login(); err != nil {
+		return nil
+	}
+	token := sdk.client.Authenticator.GetToken()
+	if token == nil || token.User == nil || token.User.ID == nil {
+		return nil
+	}
+	projects, _, _ := sdk.client.IdentityV3().ListUserProjects(*token.User.ID)
+	names := []string{}
+	if projects != nil {
+		for _, project := range *projects {
+			if project.Name != nil {
+				names = append(names, *project.Name)
+			}
+		}
+	}
+	return names
+}
+
+// completeServers returns the IDs of the servers of the current project.
+func completeServers(sdk *OpenStack) []string {
+	compute := computeV2(sdk)
+	if compute == nil {
+		return nil
+	}
+	servers, _, _ := compute.ListServers(&openstack.ListServersOptions{})
+	ids := []string{}
+	if servers != nil {
+		for _, server := range *servers {
+			if server.ID != nil {
+				ids = append(ids, *server.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// completeFlavors returns the names of the flavors available to the current
+// project.
+func completeFlavors(sdk *OpenStack) []string {
+	compute := computeV2(sdk)
+	if compute == nil {
+		return nil
+	}
+	flavors, _, _ := compute.ListFlavors(nil)
+	names := []string{}
+	if flavors != nil {
+		for _, flavor := range *flavors {
+			if flavor.Name != nil {
+				names = append(names, *flavor.Name)
+			}
+		}
+	}
+	return names
+}
+
+// computeV2 logs in and returns the Compute service, or nil if unavailable.
+func computeV2(sdk *OpenStack) *openstack.ComputeV2API {
+	if err := sdk.login(); err != nil {
+		return nil
+	}
+	return sdk.client.ComputeV2()
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	tests := map[string]string{
+		"comp":                      "completion",
+		"completion ":               "bash fish zsh",
+		"completion z":              "zsh",
+		"--debug --os-interface ":   "admin internal public",
+		"--os-region-name r1 help ": "",
+		"--os-pro":                  "--os-project-domain-id --os-project-domain-name --os-project-id --os-project-name",
+	}
+	for line, expected := range tests {
+		words := strings.Split(line, " ")
+		out := &bytes.Buffer{}
+		complete(out, words)
+		if actual := strings.Join(strings.Fields(out.String()), " "); actual != expected {
+			t.Errorf("CLI.TestComplete: expected %q for %q, got %q", expected, line, actual)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dihedron/go-log"
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+// Config is the set of settings used to connect to a cloud; each of them can
+// be given as a command line flag (e.g. --os-auth-url), as an environment
+// variable (e.g. $OS_AUTH_URL) or in the entry of a cloud in clouds.yaml
+// (e.g. auth.auth_url), in this order of precedence, as in the OpenStack
+// command line client.
+type Config struct {
+	Cloud               string
+	AuthURL             string
+	UserID              string
+	Username            string
+	Password            string
+	UserDomainID        string
+	UserDomainName      string
+	ProjectID           string
+	ProjectName         string
+	ProjectDomainID     string
+	ProjectDomainName   string
+	DomainID            string
+	DomainName          string
+	Token               string
+	AppCredentialID     string
+	AppCredentialSecret string
+	RegionName          string
+	Interface           string
+	EndpointProfile     string
+}
+
+// setting binds a field of the configuration to its command line flag, to its
+// environment variable and to its key in the entry of a cloud in clouds.yaml
+// (a dot-separated path, e.g. "auth.auth_url").
+type setting struct {
+	flag  string
+	env   string
+	key   string
+	usage string
+	value *string
+}
+
+// settings returns the bindings of all the fields of the configuration.
+func (c *Config) settings() []setting {
+	return []setting{
+		{"os-auth-url", "OS_AUTH_URL", "auth.auth_url", "the URL of the Identity service", &c.AuthURL},
+		{"os-user-id", "OS_USER_ID", "auth.user_id", "the ID of the user", &c.UserID},
+		{"os-username", "OS_USERNAME", "auth.username", "the name of the user", &c.Username},
+		{"os-password", "OS_PASSWORD", "auth.password", "the password of the user", &c.Password},
+		{"os-user-domain-id", "OS_USER_DOMAIN_ID", "auth.user_domain_id", "the ID of the domain of the user", &c.UserDomainID},
+		{"os-user-domain-name", "OS_USER_DOMAIN_NAME", "auth.user_domain_name", "the name of the domain of the user", &c.UserDomainName},
+		{"os-project-id", "OS_PROJECT_ID", "auth.project_id", "the ID of the project to scope to", &c.ProjectID},
+		{"os-project-name", "OS_PROJECT_NAME", "auth.project_name", "the name of the project to scope to", &c.ProjectName},
+		{"os-project-domain-id", "OS_PROJECT_DOMAIN_ID", "auth.project_domain_id", "the ID of the domain of the project", &c.ProjectDomainID},
+		{"os-project-domain-name", "OS_PROJECT_DOMAIN_NAME", "auth.project_domain_name", "the name of the domain of the project", &c.ProjectDomainName},
+		{"os-domain-id", "OS_DOMAIN_ID", "auth.domain_id", "the ID of the domain to scope to", &c.DomainID},
+		{"os-domain-name", "OS_DOMAIN_NAME", "auth.domain_name", "the name of the domain to scope to", &c.DomainName},
+		{"os-token", "OS_TOKEN", "auth.token", "an existing token to authenticate with", &c.Token},
+		{"os-application-credential-id", "OS_APPLICATION_CREDENTIAL_ID", "auth.application_credential_id", "the ID of the application credential", &c.AppCredentialID},
+		{"os-application-credential-secret", "OS_APPLICATION_CREDENTIAL_SECRET", "auth.application_credential_secret", "the secret of the application credential", &c.AppCredentialSecret},
+		{"os-region-name", "OS_REGION_NAME", "region_name", "the region of the endpoints to use", &c.RegionName},
+		{"os-interface", "OS_INTERFACE", "interface", "the interface of the endpoints to use (public, internal or admin)", &c.Interface},
+		{"os-endpoint-profile", "OS_ENDPOINT_PROFILE", "endpoint_profile", "the endpoint filter profile to use instead of the applied one (see \"profile\")", &c.EndpointProfile},
+	}
+}
+
+// RegisterFlags registers the command line flags of all the settings, plus
+// --os-cloud, on the given flag set, binding them to the receiver.
+func (c *Config) RegisterFlags(flags *flag.FlagSet) {
+	flags.StringVar(&c.Cloud, "os-cloud", "", "the name of the cloud in clouds.yaml")
+	for _, s := range c.settings() {
+		flags.StringVar(s.value, s.flag, "", s.usage+" (env: $"+s.env+")")
+	}
+}
+
+// ResolveConfig returns the configuration resulting from the command line
+// flags set on the given (already parsed) flag set and bound to the given
+// configuration, from the environment variables and from the cloud selected
+// by --os-cloud or $OS_CLOUD in clouds.yaml: flags take precedence over
+// environment variables, which take precedence over clouds.yaml.
+func ResolveConfig(flags *flag.FlagSet, parsed *Config) (*Config, error) {
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	config := &Config{
+		Cloud: parsed.Cloud,
+	}
+	if !set["os-cloud"] {
+		config.Cloud = os.Getenv("OS_CLOUD")
+	}
+
+	var cloud map[string]interface{}
+	if config.Cloud != "" {
+		var err error
+		if cloud, err = LoadCloud(config.Cloud); err != nil {
+			return nil, err
+		}
+	}
+
+	values := parsed.settings()
+	for i, s := range config.settings() {
+		switch {
+		case set[s.flag]:
+			*s.value = *values[i].value
+		case os.Getenv(s.env) != "":
+			*s.value = os.Getenv(s.env)
+		case cloud != nil:
+			*s.value = lookupString(cloud, s.key)
+		}
+	}
+	return config, nil
+}
+
+// LoginOptions returns the options to log in with the configuration: an
+// application credential, a token or a password, in this order, scoped to
+// the project or domain, if any.
+func (c *Config) LoginOptions() (*openstack.LoginOptions, error) {
+	opts := &openstack.LoginOptions{
+		UserID:         optional(c.UserID),
+		UserName:       optional(c.Username),
+		UserDomainID:   optional(c.UserDomainID),
+		UserDomainName: optional(c.UserDomainName),
+	}
+	switch {
+	case c.AppCredentialID != "":
+		opts.AppCredentialID = optional(c.AppCredentialID)
+		opts.Secret = optional(c.AppCredentialSecret)
+		// application credentials are already scoped
+		return opts, nil
+	case c.Token != "":
+		opts.TokenID = optional(c.Token)
+	case c.Password != "":
+		opts.UserPassword = optional(c.Password)
+	default:
+		return nil, fmt.Errorf("no credentials given: a password, a token or an application credential is needed")
+	}
+
+	switch {
+	case c.ProjectID != "":
+		opts.ScopeProjectID = optional(c.ProjectID)
+	case c.ProjectName != "":
+		opts.ScopeProjectName = optional(c.ProjectName)
+		opts.ScopeDomainID = optional(c.ProjectDomainID)
+		opts.ScopeDomainName = optional(c.ProjectDomainName)
+		if opts.ScopeDomainID == nil && opts.ScopeDomainName == nil {
+			// the project is assumed to be in the same domain as the user
+			opts.ScopeDomainID = opts.UserDomainID
+			opts.ScopeDomainName = opts.UserDomainName
+		}
+	case c.DomainID != "" || c.DomainName != "":
+		opts.ScopeDomainID = optional(c.DomainID)
+		opts.ScopeDomainName = optional(c.DomainName)
+	default:
+		opts.UnscopedLogin = openstack.Bool(true)
+	}
+	return opts, nil
+}
+
+// NewClient returns a client for the Identity service of the configuration,
+// restricted to the endpoints in its region and with its interface, if any,
+// and to those selected by its endpoint filter profile or, if it has none, by
+// the applied one.
+func (c *Config) NewClient() (*openstack.Client, error) {
+	if c.AuthURL == "" {
+		return nil, fmt.Errorf("no Identity service URL given: use --os-auth-url, $OS_AUTH_URL or --os-cloud")
+	}
+	client := openstack.NewDefaultClient(c.AuthURL)
+	client.Region = c.RegionName
+	client.Interface = openstack.InterfaceType(strings.TrimSuffix(c.Interface, "URL"))
+
+	path, explicit := c.EndpointProfile, true
+	if path == "" {
+		path, explicit = appliedProfilePath(), false
+	}
+	if path == "" {
+		return client, nil
+	}
+	profile, err := readProfile(path)
+	if os.IsNotExist(err) && !explicit {
+		return client, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !profileAppliesTo(profile, c.AuthURL) {
+		if explicit {
+			return nil, fmt.Errorf("profile %s is for Identity service %s, not %s", path, value(profile.AuthURL), c.AuthURL)
+		}
+		// the applied profile is for another cloud
+		log.Debugf("ignoring profile %s, which is for Identity service %s", path, value(profile.AuthURL))
+		return client, nil
+	}
+	log.Debugf("using profile %s", path)
+	client.Profile = profile
+	return client, nil
+}
+
+// optional returns a pointer to the given value, or nil if it is empty.
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return openstack.String(value)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const clouds = `
+# a typical clouds.yaml
+clouds:
+  devstack:
+    auth:
+      auth_url: "http://192.168.56.101/identity"
+      username: 'admin'
+      project_name: admin   # the admin project
+      user_domain_name: Default
+      project_domain_name: Default
+    region_name: RegionOne
+    interface: public
+    regions:
+      - RegionOne
+      - name: RegionTwo
+        values:
+          interface: internal
+`
+
+func TestParseYAML(t *testing.T) {
+	document, err := parseYAML(clouds)
+	if err != nil {
+		t.Fatalf("CLI.TestParseYAML: error parsing clouds.yaml: %v", err)
+	}
+	cloud := document["clouds"].(map[string]interface{})["devstack"].(map[string]interface{})
+	values := map[string]string{
+		"auth.auth_url":     "http://192.168.56.101/identity",
+		"auth.username":     "admin",
+		"auth.project_name": "admin",
+		"region_name":       "RegionOne",
+		"auth.password":     "",
+	}
+	for key, expected := range values {
+		if actual := lookupString(cloud, key); actual != expected {
+			t.Errorf("CLI.TestParseYAML: expected %q for %q, got %q", expected, key, actual)
+		}
+	}
+	regions := cloud["regions"].([]interface{})
+	if len(regions) != 2 || regions[0] != "RegionOne" || lookupString(regions[1].(map[string]interface{}), "values.interface") != "internal" {
+		t.Errorf("CLI.TestParseYAML: unexpected regions %v", regions)
+	}
+}
+
+func TestResolveConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clouds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "clouds.yaml")
+	if err := ioutil.WriteFile(path, []byte(clouds), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range (&Config{}).settings() {
+		defer os.Setenv(s.env, os.Getenv(s.env))
+		os.Unsetenv(s.env)
+	}
+	for key, value := range map[string]string{"OS_CLIENT_CONFIG_FILE": path, "OS_CLOUD": "devstack", "OS_USERNAME": "demo", "OS_PROJECT_NAME": "demo"} {
+		defer os.Setenv(key, os.Getenv(key))
+		os.Setenv(key, value)
+	}
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	parsed := &Config{}
+	parsed.RegisterFlags(flags)
+	if err := flags.Parse([]string{"--os-project-name", "alt", "--os-password", "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	config, err := ResolveConfig(flags, parsed)
+	if err != nil {
+		t.Fatalf("CLI.TestResolveConfig: error resolving configuration: %v", err)
+	}
+	// flags override the environment, which overrides clouds.yaml
+	if config.AuthURL != "http://192.168.56.101/identity" || config.Username != "demo" || config.ProjectName != "alt" || config.Password != "secret" {
+		t.Errorf("CLI.TestResolveConfig: unexpected configuration %+v", config)
+	}
+}
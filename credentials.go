@@ -0,0 +1,299 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// ErrCredentialNotFound is returned by a CredentialStore that has no secret
+// for the given account.
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialStore keeps the secrets used to log in (passwords and application
+// credential secrets) out of the command line, the environment and the
+// configuration files; each secret is identified by an account, e.g. the user
+// and the Identity service it is valid for.
+type CredentialStore interface {
+	// Get returns the secret of the given account, or ErrCredentialNotFound.
+	Get(account string) (string, error)
+	// Set stores the secret of the given account, replacing any existing one.
+	Set(account string, secret string) error
+	// Delete removes the secret of the given account, if any.
+	Delete(account string) error
+}
+
+// keyringService is the service the secrets are filed under in the keyring.
+const keyringService = "go-openstack"
+
+// keyringStore is a CredentialStore backed by the keyring of the user session,
+// through its command line tool: secret-tool (libsecret, e.g. GNOME Keyring
+// or KWallet) on Linux and the BSDs, security (the Keychain) on macOS.
+type keyringStore struct {
+	tool string
+	// run runs the given command with the given standard input, and returns
+	// its standard output.
+	run func(stdin string, name string, args ...string) (string, error)
+}
+
+// NewKeyringStore returns a CredentialStore backed by the keyring of the user
+// session; it fails if the keyring tool of the platform is not installed.
+func NewKeyringStore() (CredentialStore, error) {
+	tool := "secret-tool"
+	if runtime.GOOS == "darwin" {
+		tool = "security"
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil, fmt.Errorf("no keyring available: %s not found", tool)
+	}
+	return &keyringStore{tool: tool, run: runTool}, nil
+}
+
+// Get returns the secret of the given account in the keyring.
+func (s *keyringStore) Get(account string) (string, error) {
+	var output string
+	var err error
+	if s.tool == "security" {
+		output, err = s.run("", s.tool, "find-generic-password", "-s", keyringService, "-a", account, "-w")
+	} else {
+		output, err = s.run("", s.tool, "lookup", "service", keyringService, "account", account)
+	}
+	if _, ok := err.(*exec.ExitError); ok {
+		// both tools fail when there is no such secret
+		return "", ErrCredentialNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if s.tool == "security" {
+		// security terminates the secret with a newline, secret-tool does not
+		output = strings.TrimSuffix(output, "\n")
+	}
+	if output == "" {
+		return "", ErrCredentialNotFound
+	}
+	return output, nil
+}
+
+// Set stores the secret of the given account in the keyring.
+func (s *keyringStore) Set(account string, secret string) error {
+	if s.tool == "security" {
+		// security only takes the secret as an argument, which would make it
+		// visible in the list of processes: the command is read from the
+		// standard input instead (interactive mode), with the secret hex
+		// encoded so that it needs no quoting
+		command := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", quoteCommandArg(keyringService), quoteCommandArg(account), hex.EncodeToString([]byte(secret)))
+		_, err := s.run(command, s.tool, "-i")
+		return err
+	}
+	_, err := s.run(secret, s.tool, "store", "--label", "OpenStack credential for "+account, "service", keyringService, "account", account)
+	return err
+}
+
+// Delete removes the secret of the given account from the keyring.
+func (s *keyringStore) Delete(account string) error {
+	var err error
+	if s.tool == "security" {
+		_, err = s.run("", s.tool, "delete-generic-password", "-s", keyringService, "-a", account)
+	} else {
+		_, err = s.run("", s.tool, "clear", "service", keyringService, "account", account)
+	}
+	if _, ok := err.(*exec.ExitError); ok {
+		// there was no such secret
+		return nil
+	}
+	return err
+}
+
+// quoteCommandArg quotes the given argument of a command read by security in
+// interactive mode.
+func quoteCommandArg(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
+
+// runTool runs the given command with the given standard input, and returns
+// its standard output; its standard error is logged.
+func runTool(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	err := cmd.Run()
+	if stderr.Len() > 0 {
+		log.Debugf("%s: %s", name, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), err
+}
+
+// secret describes the secret the configuration needs to log in and lacks,
+// if any: a password, or the secret of an application credential.
+type secret struct {
+	// account identifies the secret in a CredentialStore.
+	account string
+	// description names the secret when prompting for it.
+	description string
+	// value is the field of the configuration the secret goes into.
+	value *string
+}
+
+// missingSecret returns the secret the configuration lacks, or nil if it has
+// all it needs to log in (or too little to log in anyway, i.e. no user).
+func (c *Config) missingSecret() *secret {
+	switch {
+	case c.AppCredentialID != "":
+		if c.AppCredentialSecret != "" {
+			return nil
+		}
+		return &secret{
+			account:     "application-credential:" + c.AppCredentialID + "@" + c.AuthURL,
+			description: fmt.Sprintf("secret of application credential %s", c.AppCredentialID),
+			value:       &c.AppCredentialSecret,
+		}
+	case c.Token != "" || c.Password != "":
+		return nil
+	case c.UserID != "":
+		return &secret{
+			account:     "password:" + c.UserID + "@" + c.AuthURL,
+			description: fmt.Sprintf("password of user %s", c.UserID),
+			value:       &c.Password,
+		}
+	case c.Username != "":
+		domain := c.UserDomainName
+		if domain == "" {
+			domain = c.UserDomainID
+		}
+		return &secret{
+			account:     "password:" + domain + "/" + c.Username + "@" + c.AuthURL,
+			description: fmt.Sprintf("password of user %s", c.Username),
+			value:       &c.Password,
+		}
+	}
+	return nil
+}
+
+// completeSecret fills in the secret the configuration lacks to log in, if
+// any, from the credential store or else by prompting for it if the session
+// is interactive; it returns the secret if it was prompted for, so that it can
+// be offered to store it once it is known to be valid.
+func completeSecret(sdk *OpenStack) (*secret, error) {
+	s := sdk.config.missingSecret()
+	if s == nil {
+		return nil, nil
+	}
+	if sdk.credentials != nil {
+		value, err := sdk.credentials.Get(s.account)
+		if err == nil {
+			log.Debugf("%s read from the credential store", s.description)
+			*s.value = value
+			return nil, nil
+		}
+		if err != ErrCredentialNotFound {
+			log.Warnf("error reading the %s from the credential store: %v", s.description, err)
+		}
+	}
+	if !sdk.interactive {
+		// logging in will fail for lack of credentials
+		return nil, nil
+	}
+	value, err := promptPassword(fmt.Sprintf("Enter the %s at %s: ", s.description, sdk.config.AuthURL))
+	if err != nil {
+		return nil, err
+	}
+	*s.value = value
+	return s, nil
+}
+
+// offerToStore offers to store the given secret, just prompted for and found
+// valid, in the credential store.
+func offerToStore(sdk *OpenStack, s *secret) {
+	if s == nil || sdk.credentials == nil {
+		return
+	}
+	if !promptYesNo(fmt.Sprintf("Store the %s in the keyring, so as not to be asked again? [y/N] ", s.description)) {
+		return
+	}
+	if err := sdk.credentials.Set(s.account, *s.value); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: the %s could not be stored: %v\n", s.description, err)
+	}
+}
+
+func init() {
+	register(&Command{
+		Path:    []string{"credential", "store"},
+		Summary: "prompt for the password (or secret) of the configured user and store it in the keyring",
+		Offline: true,
+		Setup: func(flags *flag.FlagSet) Runner {
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 0 {
+					return fmt.Errorf("usage: %s credential store", sdk.program)
+				}
+				s, err := keyringSecret(sdk)
+				if err != nil {
+					return err
+				}
+				value, err := promptPassword(fmt.Sprintf("Enter the %s at %s: ", s.description, sdk.config.AuthURL))
+				if err != nil {
+					return err
+				}
+				if err := sdk.credentials.Set(s.account, value); err != nil {
+					return fmt.Errorf("error storing the %s: %v", s.description, err)
+				}
+				fmt.Fprintf(sdk.out, "%s stored in the keyring\n", s.description)
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:    []string{"credential", "delete"},
+		Summary: "remove the password (or secret) of the configured user from the keyring",
+		Offline: true,
+		Setup: func(flags *flag.FlagSet) Runner {
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 0 {
+					return fmt.Errorf("usage: %s credential delete", sdk.program)
+				}
+				s, err := keyringSecret(sdk)
+				if err != nil {
+					return err
+				}
+				if err := sdk.credentials.Delete(s.account); err != nil {
+					return fmt.Errorf("error removing the %s: %v", s.description, err)
+				}
+				fmt.Fprintf(sdk.out, "%s removed from the keyring\n", s.description)
+				return nil
+			}
+		},
+	})
+}
+
+// keyringSecret returns the secret of the configured user, whether given or
+// not, for the credential commands.
+func keyringSecret(sdk *OpenStack) (*secret, error) {
+	if sdk.credentials == nil {
+		return nil, fmt.Errorf("no keyring available")
+	}
+	if sdk.config.AuthURL == "" {
+		return nil, fmt.Errorf("no Identity service URL given: use --os-auth-url, $OS_AUTH_URL or --os-cloud")
+	}
+	// look at the configuration as if it had no secrets
+	config := *sdk.config
+	config.Password, config.AppCredentialSecret, config.Token = "", "", ""
+	s := config.missingSecret()
+	if s == nil {
+		return nil, fmt.Errorf("no user or application credential given")
+	}
+	return s, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// memoryStore is a CredentialStore keeping the secrets in memory.
+type memoryStore map[string]string
+
+func (m memoryStore) Get(account string) (string, error) {
+	if secret, ok := m[account]; ok {
+		return secret, nil
+	}
+	return "", ErrCredentialNotFound
+}
+
+func (m memoryStore) Set(account string, secret string) error {
+	m[account] = secret
+	return nil
+}
+
+func (m memoryStore) Delete(account string) error {
+	delete(m, account)
+	return nil
+}
+
+func TestKeyringStore(t *testing.T) {
+	commands := []string{}
+	secrets := map[string]string{}
+	store := &keyringStore{
+		tool: "secret-tool",
+		run: func(stdin string, name string, args ...string) (string, error) {
+			commands = append(commands, name+" "+strings.Join(args, " "))
+			account := args[len(args)-1]
+			switch args[0] {
+			case "store":
+				secrets[account] = stdin
+			case "lookup":
+				if secret, ok := secrets[account]; ok {
+					return secret, nil
+				}
+				return "", &exec.ExitError{}
+			}
+			return "", nil
+		},
+	}
+	if _, err := store.Get("password:Default/admin@http://cloud"); err != ErrCredentialNotFound {
+		t.Errorf("Credentials.TestKeyringStore: expected ErrCredentialNotFound, got %v", err)
+	}
+	if err := store.Set("password:Default/admin@http://cloud", "s3cr3t"); err != nil {
+		t.Errorf("Credentials.TestKeyringStore: unexpected error %v", err)
+	}
+	if secret, err := store.Get("password:Default/admin@http://cloud"); err != nil || secret != "s3cr3t" {
+		t.Errorf("Credentials.TestKeyringStore: expected \"s3cr3t\", got %q (%v)", secret, err)
+	}
+	expected := "secret-tool store --label OpenStack credential for password:Default/admin@http://cloud service go-openstack account password:Default/admin@http://cloud"
+	if len(commands) != 3 || commands[1] != expected {
+		t.Errorf("Credentials.TestKeyringStore: expected %q, got %q", expected, commands)
+	}
+	for _, command := range commands {
+		if strings.Contains(command, "s3cr3t") {
+			t.Errorf("Credentials.TestKeyringStore: secret on the command line %q", command)
+		}
+	}
+
+	// the Keychain tool gets the command, secret included, on its standard input
+	store = &keyringStore{
+		tool: "security",
+		run: func(stdin string, name string, args ...string) (string, error) {
+			commands = append(commands, name+" "+strings.Join(args, " "))
+			secrets["stdin"] = stdin
+			return "", nil
+		},
+	}
+	if err := store.Set("password:Default/admin@http://cloud", "s3cr3t"); err != nil {
+		t.Errorf("Credentials.TestKeyringStore: unexpected error %v", err)
+	}
+	expected = "add-generic-password -U -s \"go-openstack\" -a \"password:Default/admin@http://cloud\" -X 733363723374\n"
+	if command := commands[len(commands)-1]; command != "security -i" || secrets["stdin"] != expected {
+		t.Errorf("Credentials.TestKeyringStore: expected %q on the standard input of \"security -i\", got %q on that of %q", expected, secrets["stdin"], command)
+	}
+}
+
+func TestCompleteSecret(t *testing.T) {
+	tests := []struct {
+		config   Config
+		expected string
+	}{
+		{Config{AuthURL: "http://cloud", Username: "admin", UserDomainName: "Default"}, "password:Default/admin@http://cloud"},
+		{Config{AuthURL: "http://cloud", UserID: "u1"}, "password:u1@http://cloud"},
+		{Config{AuthURL: "http://cloud", AppCredentialID: "ac1", Username: "admin"}, "application-credential:ac1@http://cloud"},
+		{Config{AuthURL: "http://cloud", Username: "admin", Password: "given"}, ""},
+		{Config{AuthURL: "http://cloud", Token: "t1"}, ""},
+		{Config{AuthURL: "http://cloud"}, ""},
+	}
+	for _, test := range tests {
+		config := test.config
+		s := config.missingSecret()
+		account := ""
+		if s != nil {
+			account = s.account
+		}
+		if account != test.expected {
+			t.Errorf("Credentials.TestCompleteSecret: expected account %q for %+v, got %q", test.expected, test.config, account)
+		}
+	}
+
+	config := &Config{AuthURL: "http://cloud", AppCredentialID: "ac1"}
+	sdk := newClient(config)
+	sdk.credentials = memoryStore{"application-credential:ac1@http://cloud": "s3cr3t"}
+	if prompted, err := completeSecret(sdk); prompted != nil || err != nil {
+		t.Errorf("Credentials.TestCompleteSecret: unexpected prompt (%v)", err)
+	}
+	if config.AppCredentialSecret != "s3cr3t" {
+		t.Errorf("Credentials.TestCompleteSecret: expected the secret from the store, got %q", config.AppCredentialSecret)
+	}
+
+	// non-interactive sessions do not prompt for missing secrets
+	config = &Config{AuthURL: "http://cloud", Username: "admin"}
+	sdk = newClient(config)
+	sdk.credentials = memoryStore{}
+	if prompted, err := completeSecret(sdk); prompted != nil || err != nil || config.Password != "" {
+		t.Errorf("Credentials.TestCompleteSecret: unexpected prompt (%v)", err)
+	}
+}
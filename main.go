@@ -5,31 +5,44 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/dihedron/go-log"
 	"github.com/dihedron/go-openstack/openstack"
 )
 
-// OpenStack represents the OpenStack client.
+// OpenStack represents the OpenStack client, and the session of a command:
+// the client is only created and logged in when a command needs it.
 type OpenStack struct {
 	client *openstack.Client
+	config *Config
+
+	// timeout, if not zero, is the timeout of each call to the cloud.
+	timeout time.Duration
+	// out is where commands print their output.
+	out io.Writer
+	// program is the name the CLI was invoked with.
+	program string
+	// credentials, if not nil, is where the secrets missing from the
+	// configuration are looked for before prompting for them.
+	credentials CredentialStore
+	// interactive sessions can prompt the user for the secrets missing from
+	// the configuration.
+	interactive bool
 }
 
-func newClient() *OpenStack {
-	endpoint := os.Getenv("OS_AUTH_URL")
-	if endpoint == "" {
-		if len(os.Args) >= 2 {
-			endpoint = os.Args[1]
-		} else {
-			//endpoint = "http://192.168.56.101/identity/" // my shiny devstack :-)
-			endpoint = "http://10.114.10.1"
-		}
-	}
-	client := &OpenStack{
-		client: openstack.NewDefaultClient(endpoint),
+func newClient(config *Config) *OpenStack {
+	return &OpenStack{
+		config:  config,
+		out:     os.Stdout,
+		program: filepath.Base(os.Args[0]),
 	}
-	return client
 }
 
 func (os *OpenStack) close() error {
@@ -39,152 +52,41 @@ func (os *OpenStack) close() error {
 	return nil
 }
 
-func (os *OpenStack) doScopedLoginTo(project string) {
-	log.Debugf("+-------------------------------------------------------------------+")
-	log.Debugf("|                         SCOPED LOGIN                              |")
-	log.Debugf("+-------------------------------------------------------------------+")
-
-	opts := &openstack.LoginOptions{
-		UserName:         openstack.String("admin"),
-		UserDomainName:   openstack.String("Default"),
-		UserPassword:     openstack.String("password"),
-		ScopeProjectName: openstack.String(project),
-		ScopeDomainName:  openstack.String("Default"),
-	}
-	os.client.Connect(opts)
-}
-
-func (os *OpenStack) doUnscopedLogin() {
-	log.Debugf("+-------------------------------------------------------------------+")
-	log.Debugf("|                        UNSCOPED LOGIN                             |")
-	log.Debugf("+-------------------------------------------------------------------+")
-
-	opts := &openstack.LoginOptions{
-		UserName:       openstack.String("admin"),
-		UserDomainName: openstack.String("Default"),
-		UserPassword:   openstack.String("password"),
-		UnscopedLogin:  openstack.Bool(true),
-	}
-	os.client.Connect(opts)
-}
-
-func (os *OpenStack) createToken() *openstack.Token {
-	log.Debugf("+-------------------------------------------------------------------+")
-	log.Debugf("|                         CREATE TOKEN                              |")
-	log.Debugf("+-------------------------------------------------------------------+")
-
-	opts := &openstack.CreateTokenOptions{
-		NoCatalog:        openstack.Bool(true),
-		Authenticated:    true,
-		ScopeProjectName: openstack.String("admin"),
-		ScopeDomainName:  openstack.String("Default"),
-		UserName:         openstack.String("admin"),
-		UserDomainName:   openstack.String("Default"),
-		UserPassword:     openstack.String("password"),
-	}
-
-	token, result, err := os.client.IdentityV3().CreateToken(opts)
-	log.Debugf("token is %q\n", *token.Value)
-	log.Debugf("token is\n%s\n", log.ToJSON(token))
-	log.Debugf("result is %d (%s)\n", result.Code, result.Status)
-	if err != nil {
-		log.Debugf("call resulted in %v\n", err)
-	}
-	return token
-}
-
-func (os *OpenStack) readToken(tokenValue string) *openstack.Token {
-	log.Debugf("+-------------------------------------------------------------------+")
-	log.Debugf("|                          READ TOKEN                               |")
-	log.Debugf("+-------------------------------------------------------------------+")
-
-	opts := &openstack.RetrieveTokenOptions{
-		AllowExpired: openstack.Bool(true),
-		NoCatalog:    openstack.Bool(false),
-		SubjectToken: tokenValue,
+// login creates the client and logs in to the cloud, unless already done.
+func (os *OpenStack) login() error {
+	if os.client != nil {
+		return nil
 	}
-	token, result, err := os.client.IdentityV3().RetrieveToken(opts)
-	log.Debugf("token is %q\n", *token.Value)
-	log.Debugf("token is\n%s\n", log.ToJSON(token))
-	log.Debugf("result is %d (%s)\n", result.Code, result.Status)
+	client, err := os.config.NewClient()
 	if err != nil {
-		log.Debugf("call resulted in %v\n", err)
+		return err
 	}
-	return token
-}
-
-func (os *OpenStack) checkToken(tokenValue string) bool {
-	log.Debugf("+-------------------------------------------------------------------+")
-	log.Debugf("|                          CHECK TOKEN                              |")
-	log.Debugf("+-------------------------------------------------------------------+")
-
-	opts := &openstack.CheckTokenOptions{
-		AllowExpired: openstack.Bool(true),
-		SubjectToken: tokenValue,
+	if os.timeout > 0 {
+		client.HTTPClient.Timeout = os.timeout
 	}
-	ok, result, err := os.client.IdentityV3().CheckToken(opts)
-	log.Debugf("token valid: %t\n", ok)
-	log.Debugf("result is %d (%s)\n", result.Code, result.Status)
+	prompted, err := completeSecret(os)
 	if err != nil {
-		log.Debugf("call resulted in %v\n", err)
-	}
-	return ok
-}
-
-func (os *OpenStack) deleteToken(tokenValue string) bool {
-	log.Debugf("+-------------------------------------------------------------------+")
-	log.Debugf("|                          DELETE TOKEN                             |")
-	log.Debugf("+-------------------------------------------------------------------+")
-
-	opts := &openstack.DeleteTokenOptions{
-		SubjectToken: tokenValue,
+		return err
 	}
-	ok, result, err := os.client.IdentityV3().DeleteToken(opts)
-	log.Debugf("token valid: %t\n", ok)
-	log.Debugf("result is %d (%s)\n", result.Code, result.Status)
-	if err != nil {
-		log.Debugf("call resulted in %v\n", err)
+	os.client = client
+	if err := os.doLogin(); err != nil {
+		os.client = nil
+		return err
 	}
-	return ok
-}
-
-func (os *OpenStack) createAppCredential() *openstack.Token {
-	log.Debugf("+-------------------------------------------------------------------+")
-	log.Debugf("|                        APP CREDENTIALS                            |")
-	log.Debugf("+-------------------------------------------------------------------+")
-
-	opts := &openstack.CreateTokenOptions{
-		//NoCatalog:        openstack.Bool(false),
-		Authenticated:    true,
-		ScopeProjectName: openstack.String("admin"),
-		ScopeDomainName:  openstack.String("Default"),
-		UserName:         openstack.String("admin"),
-		UserDomainName:   openstack.String("Default"),
-		UserPassword:     openstack.String("password"),
-	}
-
-	token, result, err := os.client.IdentityV3().CreateToken(opts)
-	log.Debugf("token is %q\n", *token.Value)
-	log.Debugf("token is\n%s\n", log.ToJSON(token))
-	log.Debugf("result is %d (%s)\n", result.Code, result.Status)
-	if err != nil {
-		log.Debugf("call resulted in %v\n", err)
-	}
-	return token
+	offerToStore(os, prompted)
+	return nil
 }
 
-func (os *OpenStack) getCatalog() *[]openstack.Service {
+func (os *OpenStack) doLogin() error {
 	log.Debugf("+-------------------------------------------------------------------+")
-	log.Debugf("|                          GET CATALOG                              |")
+	log.Debugf("|                             LOGIN                                 |")
 	log.Debugf("+-------------------------------------------------------------------+")
 
-	catalog, result, err := os.client.IdentityV3().RetrieveCatalog()
-	log.Debugf("catalog is:\n%s\n", log.ToJSON(catalog))
-	log.Debugf("result is %d (%s)\n", result.Code, result.Status)
+	opts, err := os.config.LoginOptions()
 	if err != nil {
-		log.Debugf("call resulted in %v\n", err)
+		return err
 	}
-	return catalog
+	return os.client.Connect(opts)
 }
 
 func (os *OpenStack) listProjects() *[]openstack.Project {
@@ -314,48 +216,89 @@ func (os *OpenStack) listUserGroups(userid string) *[]openstack.Group {
 // 	}
 // }
 
-// https://developer.openstack.org/sdks/python/openstacksdk/users/profile.html#openstack.profile.Profile
-func main() {
+// globalFlags returns the flag set of the global flags, bound to the returned
+// configuration and debug switch.
+func globalFlags(program string) (*flag.FlagSet, *Config, *bool) {
+	flags := flag.NewFlagSet(program, flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	parsed := &Config{}
+	parsed.RegisterFlags(flags)
+	debug := flags.Bool("debug", false, "print the debug log of the calls to the cloud")
+	return flags, parsed, debug
+}
 
+// demo runs the sequence of Identity calls exercising the SDK, with all its
+// debug output.
+func demo(sdk *OpenStack) error {
 	log.SetLevel(log.DBG)
-	log.SetStream(os.Stdout, true)
-	log.SetTimeFormat("15:04:05.000")
 
-	// s1 := "a string"
-	// s2 := &s1
+	if err := sdk.login(); err != nil {
+		return err
+	}
+	sdk.listProjects()
+	sdk.listDomains()
+	// TODO: the following requires queens
+	sdk.listSystems()
 
-	// test(s1)
-	// test(s2)
+	sdk.listUsers()
 
-	// log.Debugf("new value: %q", *s2)
+	if token := sdk.client.Authenticator.GetToken(); token.User != nil && token.User.ID != nil {
+		user := sdk.readUser(*token.User.ID)
+		sdk.listUserGroups(*user.ID)
+	}
 
-	// os.Exit(0)
+	// time.Sleep(10 * time.Second)
+	return nil
+}
 
-	sdk := newClient()
-	//defer sdk.close()
+// https://developer.openstack.org/sdks/python/openstacksdk/users/profile.html#openstack.profile.Profile
+func main() {
 
-	//client.LoadProfileFrom("./my-profile.json")
+	log.SetLevel(log.NUL)
+	log.SetStream(os.Stderr, true)
+	log.SetTimeFormat("15:04:05.000")
 
-	sdk.doScopedLoginTo("admin")
-	token1 := sdk.createToken()
-	token2 := sdk.readToken(*token1.Value)
-	if sdk.checkToken(*token2.Value) {
-		log.Debugf("token is OK")
-	} else {
-		log.Debugf("token is KO")
+	program := filepath.Base(os.Args[0])
+	if len(os.Args) > 1 && os.Args[1] == completeCommand {
+		complete(os.Stdout, os.Args[2:])
+		return
 	}
-	sdk.listProjects()
-	sdk.listDomains()
-	// TODO: the following requires queens
-	sdk.listSystems()
 
-	sdk.listUsers()
+	flags, parsed, debug := globalFlags(program)
+	flags.SetOutput(os.Stderr)
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return
+		}
+		os.Exit(2)
+	}
+	if *debug {
+		log.SetLevel(log.DBG)
+	}
 
-	user := sdk.readUser("a744cae9f0f7490d98e127b851c80857")
-	sdk.listUserGroups(*user.ID)
+	config, err := ResolveConfig(flags, parsed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
 
-	// token2 is a copy of token1
-	// sdk.deleteToken(*token1.Value)
+	sdk := newClient(config)
+	defer sdk.close()
+	sdk.interactive = isTerminal(int(os.Stdin.Fd()))
+	if credentials, err := NewKeyringStore(); err == nil {
+		sdk.credentials = credentials
+	} else {
+		log.Debugf("passwords will not be stored: %v", err)
+	}
 
-	// time.Sleep(10 * time.Second)
+	if flags.NArg() == 0 {
+		err = demo(sdk)
+	} else {
+		err = runCommand(sdk, flags.Args())
+	}
+	if err != nil && err != flag.ErrHelp {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		sdk.close()
+		os.Exit(1)
+	}
 }
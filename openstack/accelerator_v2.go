@@ -0,0 +1,75 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+)
+
+// AcceleratorV2API represents the Accelerator API ver. 2 (Cyborg), providing
+// support for the management of the accelerators (e.g. GPUs and FPGAs) found
+// on the compute hosts, of the device profiles describing the accelerators a
+// server needs, and of the accelerator requests (ARQs) binding them to a
+// server.
+// Servers usually get their accelerators through Nova, from a flavor naming
+// a device profile in its extra specs (see DeviceProfileExtraSpecs): Nova
+// then creates and binds the ARQs itself.
+// Cyborg uses microversions: by default requests are served with the base
+// version 2.0, a later one can be requested for all calls through
+// WithMicroversion, or the latest one supported by the server through
+// NegotiateMicroversion.
+// See https://docs.openstack.org/api-ref/accelerator/
+type AcceleratorV2API struct {
+	API
+}
+
+// acceleratorMicroversion is the header through which Cyborg is told the
+// microversion requested.
+var acceleratorMicroversion = microversionHeader{name: "OpenStack-API-Version", service: "accelerator", base: "2.0"}
+
+// WithMicroversion returns a copy of the API that requests the given
+// microversion (e.g. "2.2") in all its calls, through the
+// OpenStack-API-Version header; the receiver is left unchanged.
+func (api AcceleratorV2API) WithMicroversion(microversion string) *AcceleratorV2API {
+	return &AcceleratorV2API{API: api.withMicroversion(acceleratorMicroversion, microversion)}
+}
+
+// NegotiateMicroversion returns a copy of the API that requests, in all its
+// calls, the microversion pinned for its endpoint in the client profile, or
+// the latest one supported by the server, as advertised in its version
+// document (see SelectMicroversion); the receiver is left unchanged.
+func (api AcceleratorV2API) NegotiateMicroversion() (*AcceleratorV2API, *Result, error) {
+	microversion, result, err := api.negotiateMicroversion()
+	if err != nil {
+		return nil, result, err
+	}
+	return api.WithMicroversion(microversion), result, nil
+}
+
+// Microversion returns the microversion requested in all calls, or "2.0" if
+// none was set.
+func (api *AcceleratorV2API) Microversion() string {
+	return api.requestedMicroversion(acceleratorMicroversion)
+}
+
+// acceleratorEntity is a single resource in a Cyborg response, which some
+// calls wrap in an object having the resource name as its only key and others
+// return as is, depending on the release.
+type acceleratorEntity struct {
+	name string
+	body interface{}
+}
+
+// UnmarshalJSON decodes the resource, whether wrapped or not, into the body.
+func (e *acceleratorEntity) UnmarshalJSON(data []byte) error {
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if value, ok := values[e.name]; ok && len(values) == 1 {
+		return json.Unmarshal(value, e.body)
+	}
+	return json.Unmarshal(data, e.body)
+}
@@ -0,0 +1,74 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST DEVICES
+ */
+
+// ListAcceleratorDevicesOptions provides the options available for listing the
+// accelerator devices: Type is e.g. "GPU" or "FPGA", Vendor the PCI vendor ID
+// (e.g. "10de" for NVIDIA).
+type ListAcceleratorDevicesOptions struct {
+	Type     *string `parameter:"type,omitempty" header:"-" json:"-"`
+	Vendor   *string `parameter:"vendor,omitempty" header:"-" json:"-"`
+	Hostname *string `parameter:"hostname,omitempty" header:"-" json:"-"`
+}
+
+// ListAcceleratorDevices returns the list of accelerator devices found on the
+// compute hosts; opts can be nil; see also
+// https://docs.openstack.org/api-ref/accelerator/#list-devices
+func (api *AcceleratorV2API) ListAcceleratorDevices(opts *ListAcceleratorDevicesOptions) (*[]AcceleratorDevice, *Result, error) {
+	if opts == nil {
+		opts = &ListAcceleratorDevicesOptions{}
+	}
+	devices := []AcceleratorDevice{}
+	result, err := api.Invoke(http.MethodGet, "./devices", true, StatusCodeIn(200), opts, &envelope{Name: "devices", Body: &devices}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &devices, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE DEVICE
+ */
+
+// RetrieveAcceleratorDevice retrieves the accelerator device with the given
+// ID; see also
+// https://docs.openstack.org/api-ref/accelerator/#get-one-device
+func (api *AcceleratorV2API) RetrieveAcceleratorDevice(id string) (*AcceleratorDevice, *Result, error) {
+	device := &AcceleratorDevice{}
+	result, err := api.Invoke(http.MethodGet, "./devices/{id}", true, StatusCodeIn(200), &envelope{ID: id}, &acceleratorEntity{name: "device", body: device}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return device, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * LIST DEPLOYABLES
+ */
+
+// ListAcceleratorDeployables returns the list of deployables, i.e. the units
+// of the accelerator devices that can be assigned to servers; see also
+// https://docs.openstack.org/api-ref/accelerator/#list-deployables
+func (api *AcceleratorV2API) ListAcceleratorDeployables() (*[]AcceleratorDeployable, *Result, error) {
+	deployables := []AcceleratorDeployable{}
+	result, err := api.Invoke(http.MethodGet, "./deployables", true, StatusCodeIn(200), nil, &envelope{Name: "deployables", Body: &deployables}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &deployables, result, err
+	}
+	return nil, result, err
+}
@@ -0,0 +1,108 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// DeviceProfileExtraSpec is the flavor extra spec naming the device profile
+// that Nova uses to request accelerators for the servers of the flavor.
+const DeviceProfileExtraSpec = "accel:device_profile"
+
+// DeviceProfileExtraSpecs returns the flavor extra specs requesting the
+// accelerators described by the device profile with the given name, to be
+// set on a flavor (e.g. through the Compute flavor extra specs API): Nova and
+// Cyborg then create, bind and attach the accelerators of each server built
+// from it.
+func DeviceProfileExtraSpecs(profile string) map[string]string {
+	return map[string]string{
+		DeviceProfileExtraSpec: profile,
+	}
+}
+
+/*
+ * LIST DEVICE PROFILES
+ */
+
+// ListDeviceProfiles returns the list of device profiles; see also
+// https://docs.openstack.org/api-ref/accelerator/#list-device-profiles
+func (api *AcceleratorV2API) ListDeviceProfiles() (*[]DeviceProfile, *Result, error) {
+	profiles := []DeviceProfile{}
+	result, err := api.Invoke(http.MethodGet, "./device_profiles", true, StatusCodeIn(200), nil, &envelope{Name: "device_profiles", Body: &profiles}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &profiles, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE DEVICE PROFILE
+ */
+
+// CreateDeviceProfileOptions provides the options available for creating a
+// device profile: each of the Groups requests one accelerator, and must
+// include a "resources:<class>" key (e.g. "resources:PGPU": "1").
+type CreateDeviceProfileOptions struct {
+	Name        string              `parameter:"-" header:"-" json:"name"`
+	Groups      []map[string]string `parameter:"-" header:"-" json:"groups"`
+	Description *string             `parameter:"-" header:"-" json:"description,omitempty"`
+}
+
+// MarshalJSON encodes the options as a list holding a single device profile,
+// as expected by Cyborg.
+func (opts CreateDeviceProfileOptions) MarshalJSON() ([]byte, error) {
+	type profile CreateDeviceProfileOptions
+	return json.Marshal([]profile{profile(opts)})
+}
+
+// CreateDeviceProfile creates a device profile; device profiles cannot be
+// updated afterwards; see also
+// https://docs.openstack.org/api-ref/accelerator/#create-device-profile
+func (api *AcceleratorV2API) CreateDeviceProfile(opts *CreateDeviceProfileOptions) (*DeviceProfile, *Result, error) {
+	profile := &DeviceProfile{}
+	result, err := api.Invoke(http.MethodPost, "./device_profiles", true, StatusCodeIn(201), opts, &acceleratorEntity{name: "device_profile", body: profile}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return profile, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE DEVICE PROFILE
+ */
+
+// RetrieveDeviceProfile retrieves the device profile with the given ID; see
+// also
+// https://docs.openstack.org/api-ref/accelerator/#get-one-device-profile
+func (api *AcceleratorV2API) RetrieveDeviceProfile(id string) (*DeviceProfile, *Result, error) {
+	profile := &DeviceProfile{}
+	result, err := api.Invoke(http.MethodGet, "./device_profiles/{id}", true, StatusCodeIn(200), &envelope{ID: id}, &acceleratorEntity{name: "device_profile", body: profile}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return profile, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE DEVICE PROFILE
+ */
+
+// DeleteDeviceProfile deletes the device profile with the given ID; see also
+// https://docs.openstack.org/api-ref/accelerator/#delete-one-device-profile
+func (api *AcceleratorV2API) DeleteDeviceProfile(id string) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodDelete, "./device_profiles/{id}", true, StatusCodeIn(204), &envelope{ID: id}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
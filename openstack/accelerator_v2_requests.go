@@ -0,0 +1,230 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Accelerator request state values.
+const (
+	AcceleratorRequestStateInitial     string = "Initial"
+	AcceleratorRequestStateBindStarted string = "BindStarted"
+	AcceleratorRequestStateBound       string = "Bound"
+	AcceleratorRequestStateUnbound     string = "Unbound"
+	AcceleratorRequestStateBindFailed  string = "BindFailed"
+	AcceleratorRequestStateDeleting    string = "Deleting"
+)
+
+/*
+ * LIST ACCELERATOR REQUESTS
+ */
+
+// ListAcceleratorRequestsOptions provides the options available for listing
+// the accelerator requests: Instance filters them by the server they are bound
+// to; BindState "resolved" only lists the requests of the server if they are
+// all either bound or failed.
+type ListAcceleratorRequestsOptions struct {
+	Instance  *string `parameter:"instance,omitempty" header:"-" json:"-"`
+	BindState *string `parameter:"bind_state,omitempty" header:"-" json:"-"`
+}
+
+// ListAcceleratorRequests returns the list of accelerator requests; opts can
+// be nil; see also
+// https://docs.openstack.org/api-ref/accelerator/#list-accelerator-requests
+func (api *AcceleratorV2API) ListAcceleratorRequests(opts *ListAcceleratorRequestsOptions) (*[]AcceleratorRequest, *Result, error) {
+	if opts == nil {
+		opts = &ListAcceleratorRequestsOptions{}
+	}
+	requests := []AcceleratorRequest{}
+	result, err := api.Invoke(http.MethodGet, "./accelerator_requests", true, StatusCodeIn(200), opts, &envelope{Name: "arqs", Body: &requests}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &requests, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE ACCELERATOR REQUESTS
+ */
+
+// CreateAcceleratorRequests creates an accelerator request for each group of
+// the device profile with the given name; the requests are not bound to any
+// server yet (see BindAcceleratorRequest); see also
+// https://docs.openstack.org/api-ref/accelerator/#create-accelerator-requests
+func (api *AcceleratorV2API) CreateAcceleratorRequests(profile string) (*[]AcceleratorRequest, *Result, error) {
+	input := &struct {
+		DeviceProfileName string `parameter:"-" header:"-" json:"device_profile_name"`
+	}{
+		DeviceProfileName: profile,
+	}
+	requests := []AcceleratorRequest{}
+	result, err := api.Invoke(http.MethodPost, "./accelerator_requests", true, StatusCodeIn(201), input, &envelope{Name: "arqs", Body: &requests}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return &requests, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE ACCELERATOR REQUEST
+ */
+
+// RetrieveAcceleratorRequest retrieves the accelerator request with the given
+// ID; see also
+// https://docs.openstack.org/api-ref/accelerator/#get-one-accelerator-request
+func (api *AcceleratorV2API) RetrieveAcceleratorRequest(id string) (*AcceleratorRequest, *Result, error) {
+	request := &AcceleratorRequest{}
+	result, err := api.Invoke(http.MethodGet, "./accelerator_requests/{id}", true, StatusCodeIn(200), &envelope{ID: id}, &acceleratorEntity{name: "arq", body: request}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return request, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * BIND AND UNBIND ACCELERATOR REQUESTS
+ */
+
+// BindAcceleratorRequestOptions provides the options available for binding an
+// accelerator request: the server it is for, the host the server runs on and
+// the resource provider of the device allocated to it in Placement.
+type BindAcceleratorRequestOptions struct {
+	InstanceID string
+	Hostname   string
+	DeviceRPID string
+}
+
+// acceleratorRequestPatch is the JSON Patch updating a set of accelerator
+// requests, keyed by their IDs.
+type acceleratorRequestPatch struct {
+	Operations map[string][]map[string]interface{} `parameter:"-" header:"-" json:"-"`
+}
+
+// MarshalJSON encodes the operations as an object keyed by request ID.
+func (p acceleratorRequestPatch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Operations)
+}
+
+// BindAcceleratorRequest binds the accelerator request with the given ID to a
+// server; the binding is asynchronous: the request is BindStarted until it is
+// Bound (see WaitForAcceleratorRequestsBound); see also
+// https://docs.openstack.org/api-ref/accelerator/#update-accelerator-requests
+func (api *AcceleratorV2API) BindAcceleratorRequest(id string, opts *BindAcceleratorRequestOptions) (bool, *Result, error) {
+	return api.patchAcceleratorRequest(id, []map[string]interface{}{
+		{"path": "/hostname", "op": "add", "value": opts.Hostname},
+		{"path": "/device_rp_uuid", "op": "add", "value": opts.DeviceRPID},
+		{"path": "/instance_uuid", "op": "add", "value": opts.InstanceID},
+	})
+}
+
+// UnbindAcceleratorRequest unbinds the accelerator request with the given ID
+// from its server; see also
+// https://docs.openstack.org/api-ref/accelerator/#update-accelerator-requests
+func (api *AcceleratorV2API) UnbindAcceleratorRequest(id string) (bool, *Result, error) {
+	return api.patchAcceleratorRequest(id, []map[string]interface{}{
+		{"path": "/hostname", "op": "remove"},
+		{"path": "/device_rp_uuid", "op": "remove"},
+		{"path": "/instance_uuid", "op": "remove"},
+	})
+}
+
+// patchAcceleratorRequest applies the given operations to the accelerator
+// request with the given ID.
+func (api *AcceleratorV2API) patchAcceleratorRequest(id string, operations []map[string]interface{}) (bool, *Result, error) {
+	input := &acceleratorRequestPatch{
+		Operations: map[string][]map[string]interface{}{
+			id: operations,
+		},
+	}
+	result, err := api.Invoke(http.MethodPatch, "./accelerator_requests", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * DELETE ACCELERATOR REQUESTS
+ */
+
+// DeleteAcceleratorRequests deletes the accelerator requests with the given
+// IDs; see also
+// https://docs.openstack.org/api-ref/accelerator/#delete-accelerator-requests
+func (api *AcceleratorV2API) DeleteAcceleratorRequests(ids ...string) (bool, *Result, error) {
+	arqs := CommaSeparatedList(ids)
+	input := &struct {
+		ARQs *CommaSeparatedList `parameter:"arqs" header:"-" json:"-"`
+	}{
+		ARQs: &arqs,
+	}
+	return api.deleteAcceleratorRequests(input)
+}
+
+// DeleteInstanceAcceleratorRequests deletes all the accelerator requests bound
+// to the server with the given ID; see also
+// https://docs.openstack.org/api-ref/accelerator/#delete-accelerator-requests
+func (api *AcceleratorV2API) DeleteInstanceAcceleratorRequests(instance string) (bool, *Result, error) {
+	input := &struct {
+		Instance string `parameter:"instance" header:"-" json:"-"`
+	}{
+		Instance: instance,
+	}
+	return api.deleteAcceleratorRequests(input)
+}
+
+// deleteAcceleratorRequests deletes the accelerator requests selected by the
+// query parameters in the given input.
+func (api *AcceleratorV2API) deleteAcceleratorRequests(input interface{}) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodDelete, "./accelerator_requests", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * WAIT FOR ACCELERATOR REQUESTS
+ */
+
+// WaitForAcceleratorRequestsBound waits until all the accelerator requests of
+// the server with the given ID are Bound, polling them with the given backoff
+// policy; the wait fails if any of them fails to bind.
+func (api *AcceleratorV2API) WaitForAcceleratorRequestsBound(ctx context.Context, instance string, backoff Backoff) (*[]AcceleratorRequest, error) {
+	var current *[]AcceleratorRequest
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var result *Result
+		var err error
+		current, result, err = api.ListAcceleratorRequests(&ListAcceleratorRequestsOptions{Instance: String(instance)})
+		if err != nil {
+			return false, err
+		}
+		if current == nil {
+			return false, fmt.Errorf("error retrieving accelerator requests of server %q: %v", instance, result)
+		}
+		bound := 0
+		for _, request := range *current {
+			switch stringValue(request.State) {
+			case AcceleratorRequestStateBound:
+				bound++
+			case AcceleratorRequestStateBindFailed:
+				return false, fmt.Errorf("accelerator request %q of server %q failed to bind", stringValue(request.ID), instance)
+			}
+		}
+		log.Debugf("%d of %d accelerator requests of server %q are bound", bound, len(*current), instance)
+		return len(*current) > 0 && bound == len(*current), nil
+	})
+	return current, err
+}
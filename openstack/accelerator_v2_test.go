@@ -0,0 +1,21 @@
+package openstack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAcceleratorEntity(t *testing.T) {
+	for _, data := range []string{
+		`{"device_profile": {"uuid": "p1", "name": "gpu"}}`,
+		`{"uuid": "p1", "name": "gpu"}`,
+	} {
+		profile := &DeviceProfile{}
+		if err := json.Unmarshal([]byte(data), &acceleratorEntity{name: "device_profile", body: profile}); err != nil {
+			t.Fatalf("Accelerator.TestAcceleratorEntity: error decoding %s: %v", data, err)
+		}
+		if stringValue(profile.ID) != "p1" || stringValue(profile.Name) != "gpu" {
+			t.Errorf("Accelerator.TestAcceleratorEntity: unexpected device profile %+v from %s", profile, data)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * DEVICE PROFILES
+ */
+
+// DeviceProfile describes the accelerators a server needs: each group is a
+// set of Placement-style constraints on a single accelerator, e.g.
+// "resources:FPGA": "1" and "trait:CUSTOM_FPGA_INTEL_PAC_ARRIA10": "required".
+type DeviceProfile struct {
+	ID          *string              `json:"uuid,omitempty"`
+	Name        *string              `json:"name,omitempty"`
+	Description *string              `json:"description,omitempty"`
+	Groups      *[]map[string]string `json:"groups,omitempty"`
+	CreatedAt   *string              `json:"created_at,omitempty"`
+	UpdatedAt   *string              `json:"updated_at,omitempty"`
+}
+
+/*
+ * ACCELERATOR REQUESTS
+ */
+
+// AcceleratorRequest (ARQ) is the request for the accelerator described by a
+// group of a device profile: State is one of the AcceleratorRequestState*
+// constants; once bound, the request is tied to a server (InstanceID) on a
+// host, and AttachHandleInfo describes how to attach the accelerator to it
+// (e.g. the PCI address of the device).
+type AcceleratorRequest struct {
+	ID                   *string            `json:"uuid,omitempty"`
+	State                *string            `json:"state,omitempty"`
+	DeviceProfileName    *string            `json:"device_profile_name,omitempty"`
+	DeviceProfileGroupID *int               `json:"device_profile_group_id,omitempty"`
+	Hostname             *string            `json:"hostname,omitempty"`
+	DeviceRPID           *string            `json:"device_rp_uuid,omitempty"`
+	InstanceID           *string            `json:"instance_uuid,omitempty"`
+	ProjectID            *string            `json:"project_id,omitempty"`
+	AttachHandleType     *string            `json:"attach_handle_type,omitempty"`
+	AttachHandleInfo     *map[string]string `json:"attach_handle_info,omitempty"`
+}
+
+/*
+ * DEVICES AND DEPLOYABLES
+ */
+
+// AcceleratorDevice is a physical accelerator card on a compute host: Type is
+// e.g. "GPU" or "FPGA"; the board information is vendor specific.
+type AcceleratorDevice struct {
+	ID              *string `json:"uuid,omitempty"`
+	Type            *string `json:"type,omitempty"`
+	Vendor          *string `json:"vendor,omitempty"`
+	Model           *string `json:"model,omitempty"`
+	StdBoardInfo    *string `json:"std_board_info,omitempty"`
+	VendorBoardInfo *string `json:"vendor_board_info,omitempty"`
+	Hostname        *string `json:"hostname,omitempty"`
+	Status          *string `json:"status,omitempty"`
+	CreatedAt       *string `json:"created_at,omitempty"`
+	UpdatedAt       *string `json:"updated_at,omitempty"`
+}
+
+// AcceleratorDeployable is the unit of a device that can be assigned to a
+// server (e.g. a virtual function or an FPGA region), exposed as a resource
+// provider (RPID) in Placement.
+type AcceleratorDeployable struct {
+	ID              *string `json:"uuid,omitempty"`
+	Name            *string `json:"name,omitempty"`
+	ParentID        *int    `json:"parent_id,omitempty"`
+	RootID          *int    `json:"root_id,omitempty"`
+	NumAccelerators *int    `json:"num_accelerators,omitempty"`
+	DeviceID        *int    `json:"device_id,omitempty"`
+	RPID            *string `json:"rp_uuid,omitempty"`
+	DriverName      *string `json:"driver_name,omitempty"`
+	BitstreamID     *string `json:"bitstream_id,omitempty"`
+	CreatedAt       *string `json:"created_at,omitempty"`
+	UpdatedAt       *string `json:"updated_at,omitempty"`
+}
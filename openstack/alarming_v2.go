@@ -0,0 +1,32 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+)
+
+// AlarmingV2API represents the Alarming API ver. 2 (Aodh), providing support
+// for the management of alarms, of their state and of their history; alarms
+// evaluate Gnocchi metrics (threshold alarms) or combinations of other rules
+// (composite alarms) and call their actions (e.g. a Heat or Senlin webhook)
+// on state transitions, which is the building block of auto-healing and
+// auto-scaling pipelines.
+// See https://docs.openstack.org/aodh/latest/
+type AlarmingV2API struct {
+	API
+}
+
+// bareList is the response entity of the APIs that return a JSON array as is,
+// instead of wrapping it in an object; the array is decoded into the items,
+// which must be a pointer to a slice.
+type bareList struct {
+	items interface{}
+}
+
+// UnmarshalJSON decodes the array into the items.
+func (l *bareList) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, l.items)
+}
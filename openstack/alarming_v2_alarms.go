@@ -0,0 +1,262 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Alarm types: threshold alarms evaluate Gnocchi measures, composite alarms
+// combine other rules.
+const (
+	AlarmTypeGnocchiResourcesThreshold              string = "gnocchi_resources_threshold"
+	AlarmTypeGnocchiAggregationByMetricsThreshold   string = "gnocchi_aggregation_by_metrics_threshold"
+	AlarmTypeGnocchiAggregationByResourcesThreshold string = "gnocchi_aggregation_by_resources_threshold"
+	AlarmTypeComposite                              string = "composite"
+)
+
+// Alarm states.
+const (
+	AlarmStateOK               string = "ok"
+	AlarmStateAlarm            string = "alarm"
+	AlarmStateInsufficientData string = "insufficient data"
+)
+
+// Alarm severities.
+const (
+	AlarmSeverityLow      string = "low"
+	AlarmSeverityModerate string = "moderate"
+	AlarmSeverityCritical string = "critical"
+)
+
+/*
+ * LIST ALARMS
+ */
+
+// ListAlarmsOptions provides the options available for listing the alarms;
+// Limit sets the page size, all pages are retrieved anyway; Sort is a
+// "key:direction" pair (e.g. "name:asc").
+type ListAlarmsOptions struct {
+	Sort   *string `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit  *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListAlarms returns the list of alarms of the current project; opts can be
+// nil; see also
+// https://docs.openstack.org/aodh/latest/contributor/webapi/v2.html#alarms
+func (api *AlarmingV2API) ListAlarms(opts *ListAlarmsOptions) (*[]Alarm, *Result, error) {
+	if opts == nil {
+		opts = &ListAlarmsOptions{}
+	}
+	input := *opts
+
+	alarms := []Alarm{}
+	for {
+		page := []Alarm{}
+		result, err := api.Invoke(http.MethodGet, "./v2/alarms", true, StatusCodeIn(200), &input, &bareList{items: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		alarms = append(alarms, page...)
+		if input.Limit == nil || len(page) < *input.Limit || len(page) == 0 {
+			return &alarms, result, err
+		}
+		// the next page starts after the last alarm of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE ALARM
+ */
+
+// AlarmOptions provides the definition of an alarm, for creating or updating
+// it: Name and Type are required, along with the rule matching the type (e.g.
+// GnocchiResourcesThresholdRule or CompositeRule).
+type AlarmOptions struct {
+	Name                                       *string                `parameter:"-" header:"-" json:"name,omitempty"`
+	Description                                *string                `parameter:"-" header:"-" json:"description,omitempty"`
+	Type                                       *string                `parameter:"-" header:"-" json:"type,omitempty"`
+	Enabled                                    *bool                  `parameter:"-" header:"-" json:"enabled,omitempty"`
+	State                                      *string                `parameter:"-" header:"-" json:"state,omitempty"`
+	Severity                                   *string                `parameter:"-" header:"-" json:"severity,omitempty"`
+	RepeatActions                              *bool                  `parameter:"-" header:"-" json:"repeat_actions,omitempty"`
+	AlarmActions                               *[]string              `parameter:"-" header:"-" json:"alarm_actions,omitempty"`
+	OKActions                                  *[]string              `parameter:"-" header:"-" json:"ok_actions,omitempty"`
+	InsufficientDataActions                    *[]string              `parameter:"-" header:"-" json:"insufficient_data_actions,omitempty"`
+	TimeConstraints                            *[]AlarmTimeConstraint `parameter:"-" header:"-" json:"time_constraints,omitempty"`
+	GnocchiResourcesThresholdRule              *AlarmThresholdRule    `parameter:"-" header:"-" json:"gnocchi_resources_threshold_rule,omitempty"`
+	GnocchiAggregationByMetricsThresholdRule   *AlarmThresholdRule    `parameter:"-" header:"-" json:"gnocchi_aggregation_by_metrics_threshold_rule,omitempty"`
+	GnocchiAggregationByResourcesThresholdRule *AlarmThresholdRule    `parameter:"-" header:"-" json:"gnocchi_aggregation_by_resources_threshold_rule,omitempty"`
+	CompositeRule                              *AlarmCompositeRule    `parameter:"-" header:"-" json:"composite_rule,omitempty"`
+}
+
+// CreateAlarm creates an alarm; see also
+// https://docs.openstack.org/aodh/latest/contributor/webapi/v2.html#alarms
+func (api *AlarmingV2API) CreateAlarm(opts *AlarmOptions) (*Alarm, *Result, error) {
+	alarm := &Alarm{}
+	result, err := api.Invoke(http.MethodPost, "./v2/alarms", true, StatusCodeIn(201), opts, alarm, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return alarm, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE ALARM
+ */
+
+// RetrieveAlarm retrieves the alarm with the given ID; see also
+// https://docs.openstack.org/aodh/latest/contributor/webapi/v2.html#alarms
+func (api *AlarmingV2API) RetrieveAlarm(alarmid string) (*Alarm, *Result, error) {
+	alarm := &Alarm{}
+	result, err := api.Invoke(http.MethodGet, "./v2/alarms/{id}", true, StatusCodeIn(200), &envelope{ID: alarmid}, alarm, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return alarm, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE ALARM
+ */
+
+// UpdateAlarm replaces the definition of the alarm with the given ID: the
+// attributes that are not given are reset, so the whole definition must be
+// provided, not only the changes; see also
+// https://docs.openstack.org/aodh/latest/contributor/webapi/v2.html#alarms
+func (api *AlarmingV2API) UpdateAlarm(alarmid string, opts *AlarmOptions) (*Alarm, *Result, error) {
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		AlarmOptions
+	}{
+		ID:           alarmid,
+		AlarmOptions: *opts,
+	}
+	alarm := &Alarm{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2/alarms/{id}", true, StatusCodeIn(200), input, alarm, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return alarm, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE ALARM
+ */
+
+// DeleteAlarm deletes the alarm with the given ID; see also
+// https://docs.openstack.org/aodh/latest/contributor/webapi/v2.html#alarms
+func (api *AlarmingV2API) DeleteAlarm(alarmid string) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodDelete, "./v2/alarms/{id}", true, StatusCodeIn(204), &envelope{ID: alarmid}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * ALARM STATE
+ */
+
+// alarmState is the request entity of the alarm state update, which is the
+// state as a bare JSON string.
+type alarmState struct {
+	ID    string `parameter:"-" header:"-" variable:"id" json:"-"`
+	State string `parameter:"-" header:"-" variable:"-" json:"-"`
+}
+
+// MarshalJSON encodes the state as a JSON string.
+func (s alarmState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.State)
+}
+
+// RetrieveAlarmState returns the current state of the alarm with the given ID
+// (one of the AlarmState* constants); see also
+// https://docs.openstack.org/aodh/latest/contributor/webapi/v2.html#alarms
+func (api *AlarmingV2API) RetrieveAlarmState(alarmid string) (*string, *Result, error) {
+	return api.alarmState(http.MethodGet, &alarmState{ID: alarmid})
+}
+
+// SetAlarmState forces the state of the alarm with the given ID (one of the
+// AlarmState* constants), e.g. to reset it after a manual intervention; the
+// state is re-evaluated at the next evaluation period; see also
+// https://docs.openstack.org/aodh/latest/contributor/webapi/v2.html#alarms
+func (api *AlarmingV2API) SetAlarmState(alarmid string, state string) (*string, *Result, error) {
+	return api.alarmState(http.MethodPut, &alarmState{ID: alarmid, State: state})
+}
+
+// alarmState retrieves or sets the state of an alarm, which is returned as a
+// bare JSON string in both cases.
+func (api *AlarmingV2API) alarmState(method string, input *alarmState) (*string, *Result, error) {
+	data := ""
+	result, err := api.Invoke(method, "./v2/alarms/{id}/state", true, StatusCodeIn(200), input, &data, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		state := ""
+		if err := json.Unmarshal([]byte(data), &state); err != nil {
+			log.Errorf("error decoding alarm state %q: %v", data, err)
+			return nil, result, err
+		}
+		return &state, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * ALARM HISTORY
+ */
+
+// ListAlarmHistoryOptions provides the options available for listing the
+// history of an alarm; Limit sets the page size, all pages are retrieved
+// anyway; Sort is a "key:direction" pair (e.g. "timestamp:desc").
+type ListAlarmHistoryOptions struct {
+	Sort   *string `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit  *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListAlarmHistory returns the history of the alarm with the given ID, i.e.
+// its creation, its rule changes and its state transitions; opts can be nil;
+// see also
+// https://docs.openstack.org/aodh/latest/contributor/webapi/v2.html#alarms
+func (api *AlarmingV2API) ListAlarmHistory(alarmid string, opts *ListAlarmHistoryOptions) (*[]AlarmChange, *Result, error) {
+	if opts == nil {
+		opts = &ListAlarmHistoryOptions{}
+	}
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		ListAlarmHistoryOptions
+	}{
+		ID:                      alarmid,
+		ListAlarmHistoryOptions: *opts,
+	}
+
+	changes := []AlarmChange{}
+	for {
+		page := []AlarmChange{}
+		result, err := api.Invoke(http.MethodGet, "./v2/alarms/{id}/history", true, StatusCodeIn(200), input, &bareList{items: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		changes = append(changes, page...)
+		if input.Limit == nil || len(page) < *input.Limit || len(page) == 0 {
+			return &changes, result, err
+		}
+		// the next page starts after the last change of this one
+		input.Marker = page[len(page)-1].EventID
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * ALARMS
+ */
+
+// Alarm is an Aodh alarm: its rule, under the attribute matching its Type, is
+// evaluated periodically and State moves between "ok", "alarm" and
+// "insufficient data"; on each transition the corresponding actions (URLs,
+// e.g. webhooks) are called, and repeatedly while in the state if
+// RepeatActions is set.
+type Alarm struct {
+	ID                                         *string                `json:"alarm_id,omitempty"`
+	Name                                       *string                `json:"name,omitempty"`
+	Description                                *string                `json:"description,omitempty"`
+	Type                                       *string                `json:"type,omitempty"`
+	Enabled                                    *bool                  `json:"enabled,omitempty"`
+	State                                      *string                `json:"state,omitempty"`
+	StateReason                                *string                `json:"state_reason,omitempty"`
+	Severity                                   *string                `json:"severity,omitempty"`
+	RepeatActions                              *bool                  `json:"repeat_actions,omitempty"`
+	AlarmActions                               *[]string              `json:"alarm_actions,omitempty"`
+	OKActions                                  *[]string              `json:"ok_actions,omitempty"`
+	InsufficientDataActions                    *[]string              `json:"insufficient_data_actions,omitempty"`
+	TimeConstraints                            *[]AlarmTimeConstraint `json:"time_constraints,omitempty"`
+	GnocchiResourcesThresholdRule              *AlarmThresholdRule    `json:"gnocchi_resources_threshold_rule,omitempty"`
+	GnocchiAggregationByMetricsThresholdRule   *AlarmThresholdRule    `json:"gnocchi_aggregation_by_metrics_threshold_rule,omitempty"`
+	GnocchiAggregationByResourcesThresholdRule *AlarmThresholdRule    `json:"gnocchi_aggregation_by_resources_threshold_rule,omitempty"`
+	CompositeRule                              *AlarmCompositeRule    `json:"composite_rule,omitempty"`
+	ProjectID                                  *string                `json:"project_id,omitempty"`
+	UserID                                     *string                `json:"user_id,omitempty"`
+	Timestamp                                  *string                `json:"timestamp,omitempty"`
+	StateTimestamp                             *string                `json:"state_timestamp,omitempty"`
+}
+
+// AlarmThresholdRule is the rule of a threshold alarm: the alarm fires when
+// the measures of the metric, aggregated with AggregationMethod (e.g. "mean")
+// over Granularity seconds, compare to Threshold according to
+// ComparisonOperator (e.g. "gt") for EvaluationPeriods consecutive periods.
+// The metric is identified by Metric and by ResourceType and ResourceID for
+// the gnocchi_resources_threshold type, by Metrics (IDs) for the
+// gnocchi_aggregation_by_metrics_threshold type, by Metric, ResourceType and
+// Query (a Gnocchi search filter, as JSON) for the
+// gnocchi_aggregation_by_resources_threshold type; Type is only set when the
+// rule is part of a composite rule.
+type AlarmThresholdRule struct {
+	Type               *string   `json:"type,omitempty"`
+	Threshold          *float64  `json:"threshold,omitempty"`
+	ComparisonOperator *string   `json:"comparison_operator,omitempty"`
+	EvaluationPeriods  *int      `json:"evaluation_periods,omitempty"`
+	Granularity        *int      `json:"granularity,omitempty"`
+	AggregationMethod  *string   `json:"aggregation_method,omitempty"`
+	Metric             *string   `json:"metric,omitempty"`
+	Metrics            *[]string `json:"metrics,omitempty"`
+	ResourceType       *string   `json:"resource_type,omitempty"`
+	ResourceID         *string   `json:"resource_id,omitempty"`
+	Query              *string   `json:"query,omitempty"`
+}
+
+// AlarmCompositeRule is the rule of a composite alarm: either a combination
+// of other rules with And or Or, which can be nested, or a threshold rule
+// (with its Type set) as a leaf.
+type AlarmCompositeRule struct {
+	And *[]AlarmCompositeRule `json:"and,omitempty"`
+	Or  *[]AlarmCompositeRule `json:"or,omitempty"`
+	*AlarmThresholdRule
+}
+
+// AlarmTimeConstraint restricts the evaluation of an alarm to the periods
+// starting as per the Start cron expression and lasting Duration seconds.
+type AlarmTimeConstraint struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Start       *string `json:"start,omitempty"`
+	Duration    *int    `json:"duration,omitempty"`
+	Timezone    *string `json:"timezone,omitempty"`
+}
+
+// AlarmChange is an entry in the history of an alarm: Type is "creation",
+// "rule change", "state transition" or "deletion", Detail the JSON encoding
+// of the changed attributes (e.g. the new state).
+type AlarmChange struct {
+	EventID    *string `json:"event_id,omitempty"`
+	AlarmID    *string `json:"alarm_id,omitempty"`
+	Type       *string `json:"type,omitempty"`
+	Detail     *string `json:"detail,omitempty"`
+	Severity   *string `json:"severity,omitempty"`
+	ProjectID  *string `json:"project_id,omitempty"`
+	UserID     *string `json:"user_id,omitempty"`
+	OnBehalfOf *string `json:"on_behalf_of,omitempty"`
+	Timestamp  *string `json:"timestamp,omitempty"`
+}
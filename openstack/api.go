@@ -8,9 +8,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dihedron/go-log"
@@ -32,6 +35,10 @@ type API struct {
 	// own sub-builders by specifying a different and more specific path, plus
 	// their own sets of headers and query parameters.
 	builder *request.Builder
+
+	// microversion is the microversion requested in all calls, if any, for the
+	// services supporting microversions (see withMicroversion).
+	microversion string
 }
 
 // Checker is used internally by the Invoke method to decide whether the call was
@@ -72,6 +79,135 @@ func (api *API) Invoke(method string, url string, authenticated bool, checker Ch
 		return nil, err
 	}
 
+	return api.send(request, checker, output, failure)
+}
+
+// InvokeStream calls an API endpoint like Invoke, but the request entity is
+// read from the given reader and sent as is, with the given content type (if
+// any) and length, or using chunked transfer encoding if the length is unknown
+// (i.e. negative), so that large payloads (e.g. image data) are never buffered
+// in memory; "input" is only used for query parameters, headers and path
+// variables. If progress is not nil, it is called as the entity is sent.
+func (api *API) InvokeStream(method string, url string, authenticated bool, checker Checker, input interface{}, entity io.Reader, length int64, contentType string, progress ProgressFunc, output interface{}, failure interface{}) (*Result, error) {
+
+	request, err := api.PrepareRequest(method, url, authenticated, input)
+	if err != nil {
+		log.Errorf("error creating request: %v", err)
+		return nil, err
+	}
+
+	if progress != nil {
+		entity = &progressReader{reader: entity, progress: progress}
+	}
+	request.Body = ioutil.NopCloser(entity)
+	request.GetBody = nil
+	// an unknown length makes the transport use chunked transfer encoding
+	request.ContentLength = -1
+	if length >= 0 {
+		request.ContentLength = length
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	} else {
+		request.Header.Del("Content-Type")
+	}
+
+	return api.send(request, checker, output, failure)
+}
+
+// InvokeDownload calls an API endpoint like Invoke, but on success the response
+// entity is copied as is into the given writer as it is received, so that large
+// payloads (e.g. image data) are never buffered in memory; on failure, the
+// response is handled into the failure struct as usual. If progress is not nil,
+// it is called as the entity is received.
+func (api *API) InvokeDownload(method string, url string, authenticated bool, checker Checker, input interface{}, entity io.Writer, progress ProgressFunc, failure interface{}) (*Result, error) {
+
+	request, err := api.PrepareRequest(method, url, authenticated, input)
+	if err != nil {
+		log.Errorf("error creating request: %v", err)
+		return nil, err
+	}
+
+	log.Debugf("sending request to %q...", request.URL.EscapedPath())
+	t0 := time.Now()
+	response, err := api.client.HTTPClient.Do(request)
+	if err != nil {
+		log.Errorf("error sending request: %v", err)
+		return nil, err
+	}
+	log.Debugf("response received in %v", time.Now().Sub(t0))
+
+	defer response.Body.Close()
+
+	if checker == nil || !checker(response) {
+		log.Debugf("handling response as failure")
+		result, err := api.HandleResponse(response, failure)
+		if err != nil {
+			log.Errorf("error handling response: %v", err)
+		}
+		return result, err
+	}
+
+	log.Debugf("handling response as success, streaming entity")
+	var body io.Reader = response.Body
+	if progress != nil {
+		body = &progressReader{reader: body, progress: progress}
+	}
+	result := NewResult(response, nil)
+	result.OK = true
+	n, err := io.Copy(entity, body)
+	log.Debugf("%d bytes received in %v", n, time.Now().Sub(t0))
+	if err != nil {
+		log.Errorf("error streaming response entity: %v", err)
+	}
+	return result, err
+}
+
+// InvokeReader calls an API endpoint like Invoke, but on success the response
+// entity is not read: the output struct is only populated from the response
+// headers and the response body is returned as is, for the caller to read it
+// as it is received and to close it; on failure, the response is handled into
+// the failure struct as usual and no body is returned.
+func (api *API) InvokeReader(method string, url string, authenticated bool, checker Checker, input interface{}, output interface{}, failure interface{}) (io.ReadCloser, *Result, error) {
+
+	request, err := api.PrepareRequest(method, url, authenticated, input)
+	if err != nil {
+		log.Errorf("error creating request: %v", err)
+		return nil, nil, err
+	}
+
+	log.Debugf("sending request to %q...", request.URL.EscapedPath())
+	t0 := time.Now()
+	response, err := api.client.HTTPClient.Do(request)
+	if err != nil {
+		log.Errorf("error sending request: %v", err)
+		return nil, nil, err
+	}
+	log.Debugf("response received in %v", time.Now().Sub(t0))
+
+	if checker == nil || !checker(response) {
+		defer response.Body.Close()
+		log.Debugf("handling response as failure")
+		result, err := api.HandleResponse(response, failure)
+		if err != nil {
+			log.Errorf("error handling response: %v", err)
+		}
+		return nil, result, err
+	}
+
+	log.Debugf("handling response as success, returning entity")
+	if output != nil {
+		handleHeaders(response, output)
+	}
+	result := NewResult(response, nil)
+	result.OK = true
+	return response.Body, result, nil
+}
+
+// send sends the request and handles the response, according to the checker,
+// into either the output or the failure struct.
+func (api *API) send(request *http.Request, checker Checker, output interface{}, failure interface{}) (*Result, error) {
+
 	log.Debugf("sending request to %q...", request.URL.EscapedPath())
 	t0 := time.Now()
 	response, err := api.client.HTTPClient.Do(request)
@@ -98,11 +234,45 @@ func (api *API) Invoke(method string, url string, authenticated bool, checker Ch
 	return result, err
 }
 
+// headersOnly is the type of a marker field (conventionally named NoEntity) of
+// input structs that are sent without any entity, e.g. when all the information
+// is conveyed in headers.
+type headersOnly struct{}
+
+// hasNoEntity returns whether the given input struct has a headersOnly field.
+func hasNoEntity(input interface{}) bool {
+	return hasMarker(input, reflect.TypeOf(headersOnly{}))
+}
+
+// secrets is the type of a marker field (conventionally named Secrets) of input
+// structs whose entity carries secrets (e.g. passwords), which must never be
+// logged.
+type secrets struct{}
+
+// hasSecrets returns whether the given input struct has a secrets field.
+func hasSecrets(input interface{}) bool {
+	return hasMarker(input, reflect.TypeOf(secrets{}))
+}
+
+// hasMarker returns whether the given input struct has a field of the given
+// marker type.
+func hasMarker(input interface{}, marker reflect.Type) bool {
+	t := reflect.TypeOf(input)
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type == marker {
+			return true
+		}
+	}
+	return false
+}
+
 // PrepareRequest uses information in the input struct to populate HTTP query
 // parameters (any field that is tagged with `parameter` will become a parameter),
-// headers (fields tagged with `header` will be used to populate request headers)
-// and the entity in the request body (fields tagged with `json`). All three are
-// optional; if this is the case, pass nil for "input".
+// headers (fields tagged with `header` will be used to populate request headers,
+// maps tagged with `meta` a set of headers with the tag value as their prefix)
+// and the entity in the request body (fields tagged with `json`, unless it
+// has a headersOnly field). All three are optional; if this is the case, pass nil for
+// "input"; a nil pointer to a struct (e.g. nil options) is treated the same way.
 func (api *API) PrepareRequest(method string, url string, authenticated bool, input interface{}) (*http.Request, error) {
 
 	builder := api.builder.New(method, url)
@@ -117,6 +287,11 @@ func (api *API) PrepareRequest(method string, url string, authenticated bool, in
 		builder.Add().Header("X-Auth-Token", *token.Value)
 	}
 
+	// a nil pointer (e.g. nil options) carries no parameters, headers or entity
+	if value := reflect.ValueOf(input); value.Kind() == reflect.Ptr && value.IsNil() {
+		input = nil
+	}
+
 	if input != nil {
 		switch reflect.ValueOf(input).Kind() {
 		case reflect.Struct:
@@ -132,24 +307,39 @@ func (api *API) PrepareRequest(method string, url string, authenticated bool, in
 			panic("only structs can be passed as API input")
 		}
 
-		// add query parameters, headers and request entity
+		// add query parameters, headers and request entity; the headers in the
+		// input replace those set for all calls (e.g. a microversion requested
+		// for a single call)
 		builder.
 			Add().
 			QueryParametersFrom(input).
 			VariablesFrom(input).
-			HeadersFrom(input).
-			WithJSONEntity(input)
+			Set().
+			HeadersFrom(input)
+		if !hasNoEntity(input) {
+			builder.WithJSONEntity(input)
+		}
+
+		// add the headers sharing a common prefix (e.g. metadata) from maps
+		for key, value := range metaHeadersFrom(input) {
+			builder.Add().Header(key, value)
+		}
 
-		log.Infof("request:\n%v\nwith entity:\n%s", builder, log.ToJSON(input))
+		if hasSecrets(input) {
+			log.Infof("request: %s %s, carrying secrets (not logged)", method, url)
+		} else {
+			log.Infof("request:\n%v\nwith entity:\n%s", builder, log.ToJSON(input))
+		}
 	}
 	return builder.Make()
 }
 
 // HandleResponse parses the HTTP response to an API call and populates the
 // "output" struct fields; fields tagged with `header` will be populated using
-// the corresponding header value(s) if present; fields tagged with `json` will
-// be populated by unmarshalling JSON values in the response. Both are optional;
-// if so, pass in nil for "output".
+// the corresponding header value(s) if present (strings, numbers and booleans),
+// maps tagged with `meta` using the headers having the tag value as their prefix;
+// fields tagged with `json` will be populated by unmarshalling JSON values in the
+// response. Both are optional; if so, pass in nil for "output".
 func (api *API) HandleResponse(response *http.Response, output interface{}) (*Result, error) {
 
 	log.Infof("status code: %q", response.Status)
@@ -180,25 +370,7 @@ func (api *API) HandleResponse(response *http.Response, output interface{}) (*Re
 
 		// extract headers into the output struct fields tagged with `header` and
 		// the response entity into the struct fields tagged with `json`
-		t := reflect.TypeOf(output).Elem()
-		v := reflect.ValueOf(output).Elem()
-		//log.Debugf("%T, %T, %d", t, v, v.Kind)
-		for i := 0; i < t.NumField(); i++ {
-			if tag := t.Field(i).Tag.Get("header"); tag != "" && tag != "-" {
-				value := v.Field(i)
-				if value.Kind() == reflect.Ptr {
-					value.Set(reflect.New(value.Type().Elem()))
-					value.Elem().SetString(response.Header.Get(tag))
-				} else if value.Kind() == reflect.String {
-					// TODO: test
-					value.SetString(response.Header.Get(tag))
-				} else {
-					// there is an error????
-					log.Warnf("invalid field type in output struct: %q", t.Field(i).Name)
-				}
-				log.Infof("header: %q => %q", t.Field(i).Name, response.Header.Get(tag))
-			}
-		}
+		handleHeaders(response, output)
 
 		if len(data) > 0 {
 			buffer := bytes.NewBuffer(data)
@@ -211,3 +383,135 @@ func (api *API) HandleResponse(response *http.Response, output interface{}) (*Re
 	}
 	return NewResult(response, data), nil
 }
+
+// handleHeaders populates the fields of the output struct that are tagged with
+// `header` (strings, numbers and booleans) and the maps tagged with `meta` from
+// the headers of the given response.
+func handleHeaders(response *http.Response, output interface{}) {
+	t := reflect.TypeOf(output).Elem()
+	v := reflect.ValueOf(output).Elem()
+	//log.Debugf("%T, %T, %d", t, v, v.Kind)
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("header"); tag != "" && tag != "-" {
+			value := v.Field(i)
+			if value.Kind() == reflect.Ptr && value.Type().Elem().Kind() == reflect.String {
+				value.Set(reflect.New(value.Type().Elem()))
+				value.Elem().SetString(response.Header.Get(tag))
+			} else if value.Kind() == reflect.String {
+				// TODO: test
+				value.SetString(response.Header.Get(tag))
+			} else if value.Kind() == reflect.Ptr {
+				// numeric and boolean headers are only set if present
+				if err := setHeaderValue(value, response.Header.Get(tag)); err != nil {
+					log.Warnf("invalid value for header %q: %v", tag, err)
+				}
+			} else {
+				// there is an error????
+				log.Warnf("invalid field type in output struct: %q", t.Field(i).Name)
+			}
+			log.Infof("header: %q => %q", t.Field(i).Name, response.Header.Get(tag))
+		}
+		if prefix := t.Field(i).Tag.Get("meta"); prefix != "" && prefix != "-" {
+			if values := metaHeaders(response.Header, prefix); values != nil {
+				v.Field(i).Set(reflect.ValueOf(values))
+			}
+		}
+	}
+}
+
+// metaHeadersFrom returns the headers sharing a common prefix from the fields
+// of the given struct that are tagged with `meta` (e.g. `meta:"X-Object-Meta-"`):
+// such fields must be of type map[string]string or *map[string]string, and each
+// of their keys is sent as a separate header, prefixed with the tag value, e.g.
+// the metadata of Object Storage accounts, containers and objects.
+func metaHeadersFrom(input interface{}) map[string]string {
+	headers := map[string]string{}
+	v := reflect.Indirect(reflect.ValueOf(input))
+	if v.Kind() != reflect.Struct {
+		return headers
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if t.Field(i).Anonymous && reflect.Indirect(field).Kind() == reflect.Struct {
+			if field.Kind() != reflect.Ptr || !field.IsNil() {
+				for key, value := range metaHeadersFrom(field.Interface()) {
+					headers[key] = value
+				}
+			}
+			continue
+		}
+		prefix := t.Field(i).Tag.Get("meta")
+		if prefix == "" || prefix == "-" {
+			continue
+		}
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		if values, ok := field.Interface().(map[string]string); ok {
+			for key, value := range values {
+				headers[prefix+key] = value
+			}
+		}
+	}
+	return headers
+}
+
+// metaHeaders returns the values of the headers having the given prefix, by
+// their (lowercase) name without the prefix, or nil if there are none.
+func metaHeaders(headers http.Header, prefix string) map[string]string {
+	var values map[string]string
+	prefix = http.CanonicalHeaderKey(prefix)
+	for key := range headers {
+		if strings.HasPrefix(http.CanonicalHeaderKey(key), prefix) && len(key) > len(prefix) {
+			if values == nil {
+				values = map[string]string{}
+			}
+			values[strings.ToLower(key[len(prefix):])] = headers.Get(key)
+		}
+	}
+	return values
+}
+
+// setHeaderValue parses the given header value into the given pointer field,
+// according to the type it points to (integers, unsigned integers, floats and
+// booleans); the field is left nil if the value is empty.
+func setHeaderValue(field reflect.Value, value string) error {
+	if value == "" {
+		return nil
+	}
+	target := reflect.New(field.Type().Elem())
+	switch target.Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		target.Elem().SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		target.Elem().SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		target.Elem().SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		target.Elem().SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %v", field.Type())
+	}
+	field.Set(target)
+	return nil
+}
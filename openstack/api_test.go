@@ -0,0 +1,33 @@
+package openstack
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPrepareRequestNilInput(t *testing.T) {
+	client := NewDefaultClient("http://localhost/v3")
+	api := client.Authenticator.Identity.API
+	tests := []struct {
+		name  string
+		input interface{}
+		query string
+		empty bool
+	}{
+		{"no input", nil, "", true},
+		{"nil options", (*ListUsersOptions)(nil), "", true},
+		{"nil entity", (*CreateUserOptions)(nil), "", true},
+		{"options", &ListUsersOptions{Name: String("alice")}, "name=alice", false},
+		{"entity", &CreateUserOptions{User: &User{Name: String("alice")}}, "", false},
+	}
+	for _, test := range tests {
+		request, err := api.PrepareRequest(http.MethodGet, "./users", false, test.input)
+		if err != nil {
+			t.Errorf("API.TestPrepareRequestNilInput: %s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if request.URL.RawQuery != test.query || (test.empty && request.Body != nil) {
+			t.Errorf("API.TestPrepareRequestNilInput: %s: unexpected request %v", test.name, request)
+		}
+	}
+}
@@ -5,6 +5,7 @@
 package openstack
 
 import (
+	"fmt"
 	"strings"
 	"sync"
 	"time"
@@ -43,11 +44,11 @@ type Authenticator struct {
 	// accesses, e.g. when a token is being reissued by the background goroutine.
 	mutex sync.RWMutex
 
-	// tokenTimer is a timer that is set to file a few seconds before the
-	// current tokenValue expires (as per the information in tokenInfo); a
-	// goroutine is set to listen on it and to have the token reissued by the
-	// identity server automatically via the Login() method and the current
-	// token value; the scope is unchanged.
+	// timer is a timer that is set to fire a few seconds before the current
+	// token expires (as per the information in token); when it fires, a
+	// goroutine has the token reissued by the identity server automatically via
+	// the Login() method and the original credentials; the scope (including the
+	// trust, if any) is unchanged.
 	timer *time.Timer
 }
 
@@ -72,6 +73,12 @@ type LoginOptions struct {
 	ScopeDomainName  *string
 	UnscopedLogin    *bool
 
+	// TrustID is the ID of a trust delegating roles to the logging in user (the
+	// trustee); when it is set, the session token is scoped to the trust and all
+	// the API calls are performed on behalf of the trustor; it takes precedence
+	// over the other scope parameters.
+	TrustID *string
+
 	// UserPassword is used for password-based authentication.
 	UserPassword *string
 
@@ -91,7 +98,10 @@ type LoginOptions struct {
 // set as a request header ("X-Auth-Token") in the following protected API calls;
 // moreover this method parses the catalog and initialises all the other available
 // service API references using the information about services, their versions and
-// available endpoints fo the current token.
+// available endpoints fo the current token. If a TrustID is provided, the session
+// is scoped to the trust (see GetTrust) and all calls are performed on behalf of
+// the trustor. When password or application credentials are used, the token is
+// automatically reissued shortly before it expires.
 func (auth *Authenticator) Login(opts *LoginOptions) error {
 	var err error
 
@@ -107,6 +117,7 @@ func (auth *Authenticator) Login(opts *LoginOptions) error {
 			ScopeDomainName:  opts.ScopeDomainName,
 			UnscopedToken:    opts.UnscopedLogin,
 			TokenID:          opts.TokenID,
+			ScopeTrustID:     opts.TrustID,
 		}
 		log.Debugf("performing token-based authentication (%s)", ZipString(*opts.TokenID, 10))
 	} else if opts.UserPassword != nil && len(strings.TrimSpace(*opts.UserPassword)) > 0 {
@@ -118,10 +129,12 @@ func (auth *Authenticator) Login(opts *LoginOptions) error {
 			UnscopedToken:    opts.UnscopedLogin,
 			UserID:           opts.UserID,
 			UserName:         opts.UserName,
+			UserDomainID:     opts.UserDomainID,
 			UserDomainName:   opts.UserDomainName,
 			UserPassword:     opts.UserPassword,
+			ScopeTrustID:     opts.TrustID,
 		}
-		log.Debugf("performing password-based authentication (%s\\%s:%s)", *opts.UserDomainName, *opts.UserName, *opts.UserPassword)
+		log.Debugf("performing password-based authentication (user: %s/%s, domain: %s/%s)", stringValue(opts.UserID), stringValue(opts.UserName), stringValue(opts.UserDomainID), stringValue(opts.UserDomainName))
 	} else if opts.AppCredentialID != nil && len(strings.TrimSpace(*opts.AppCredentialID)) > 0 && opts.Secret != nil && len(strings.TrimSpace(*opts.Secret)) > 0 {
 		cto = &CreateTokenOptions{
 			ScopeProjectID:   opts.ScopeProjectID,
@@ -131,51 +144,41 @@ func (auth *Authenticator) Login(opts *LoginOptions) error {
 			UnscopedToken:    opts.UnscopedLogin,
 			UserID:           opts.UserID,
 			UserName:         opts.UserName,
+			UserDomainID:     opts.UserDomainID,
 			UserDomainName:   opts.UserDomainName,
 			AppCredentialID:  opts.AppCredentialID,
 			Secret:           opts.Secret,
+			ScopeTrustID:     opts.TrustID,
 		}
-		log.Debugf("performing app-credential-based authentication (%s:%s)", *opts.AppCredentialID, *opts.Secret)
+		log.Debugf("performing app-credential-based authentication (%s)", *opts.AppCredentialID)
 	}
 
-	token, _, err := auth.Identity.CreateToken(cto)
+	token, result, err := auth.Identity.CreateToken(cto)
 	if err != nil {
 		log.Errorf("login failed: %v", err)
 		return err
 	}
+	if token == nil && result != nil {
+		// e.g. wrong credentials
+		log.Errorf("login failed: %s", result.Status)
+		return fmt.Errorf("login failed: %s", result.Status)
+	}
 
 	if token != nil {
-		log.Debugf("token value is %s, token info is:\n%s\n", *token.Value, log.ToJSON(token))
+		log.Debugf("token value is %s, token info is:\n%s\n", stringValue(token.Value), log.ToJSON(token))
 
 		// now store that info inside the current authenticator and start the
 		// background goroutine that will automatically reissue the token when it
 		// is about to expire.
 		auth.SetToken(token)
+		if token.Trust != nil {
+			log.Debugf("session is scoped to trust %q", stringValue(token.Trust.ID))
+		}
+		auth.scheduleRenewal(opts)
 
 		log.Debugf("done logging in")
-	} else {
-
 	}
 
-	// // this timer will start 30 seconds before the token is expected to expire
-	// if auth.token.ExpiresAt != nil {
-	// 	log.Debugf("setting timer for token refresh")
-	// 	if expiryDate, err := time.Parse(ISO8601, *auth.token.ExpiresAt); err == nil {
-	// 		when := expiryDate.Sub(time.Now().Add(30 * time.Second))
-	// 		log.Debugf("re-authentication timer will fire in %v", when)
-	// 		//auth.timer = time.NewTimer(5 * time.Second)
-	// 		auth.timer = time.NewTimer(when)
-	// 		go func(lo *LoginOptions) {
-	// 			log.Debugf("starting re-authentication timer...")
-	// 			<-auth.timer.C
-	// 			log.Debugf("re-authentication timer logging in again...")
-	// 			auth.Login(lo)
-	// 		}(opts)
-	// 	} else {
-	// 		log.Errorf("error parsing date: %v", err)
-	// 	}
-	// }
-
 	return nil
 }
 
@@ -193,15 +196,7 @@ func (auth *Authenticator) Logout() error {
 		log.Debugf("invalidating authentication token %s", ZipString(*value, 16))
 		// auth.mutex.Lock()
 		// defer auth.mutex.Unlock()
-		if auth.timer != nil {
-			log.Debugf("stopping timer")
-			if !auth.timer.Stop() {
-				// drain the timer, as per the docs
-				log.Debugf("draining the timer...")
-				<-auth.timer.C
-				log.Debugf("timer drained")
-			}
-		}
+		auth.stopRenewal()
 		opts := &DeleteTokenOptions{
 			SubjectToken: *(auth.GetToken().Value),
 		}
@@ -241,3 +236,66 @@ func (auth *Authenticator) GetCatalog() *[]Service {
 	}
 	return nil
 }
+
+// GetTrust returns the trust to which the current session is scoped, including
+// the trustor and trustee information, or nil if the session is not trust-scoped.
+func (auth *Authenticator) GetTrust() *Trust {
+	auth.mutex.RLock()
+	defer auth.mutex.RUnlock()
+	if auth.token != nil {
+		return auth.token.Trust
+	}
+	return nil
+}
+
+// scheduleRenewal sets a timer that will fire 30 seconds before the current
+// token is expected to expire and log in again with the same options; this is
+// only possible when the options contain replayable credentials (password or
+// application credential): a token cannot be used to reissue itself past its
+// expiration, and trust-scoped tokens cannot be used to get new tokens at all.
+func (auth *Authenticator) scheduleRenewal(opts *LoginOptions) {
+	auth.stopRenewal()
+
+	renewable := (opts.UserPassword != nil && len(strings.TrimSpace(*opts.UserPassword)) > 0) ||
+		(opts.AppCredentialID != nil && len(strings.TrimSpace(*opts.AppCredentialID)) > 0)
+	if !renewable {
+		log.Debugf("token cannot be automatically renewed")
+		return
+	}
+
+	token := auth.GetToken()
+	if token == nil || token.ExpiresAt == nil {
+		return
+	}
+	expiryDate, err := time.Parse(ISO8601, *token.ExpiresAt)
+	if err != nil {
+		log.Errorf("error parsing token expiry date: %v", err)
+		return
+	}
+	when := expiryDate.Sub(time.Now().Add(30 * time.Second))
+	if when <= 0 {
+		log.Warnf("token is about to expire, no renewal scheduled")
+		return
+	}
+	log.Debugf("re-authentication timer will fire in %v", when)
+
+	auth.mutex.Lock()
+	defer auth.mutex.Unlock()
+	auth.timer = time.AfterFunc(when, func() {
+		log.Debugf("re-authentication timer logging in again...")
+		if err := auth.Login(opts); err != nil {
+			log.Errorf("error renewing token: %v", err)
+		}
+	})
+}
+
+// stopRenewal stops the token renewal timer, if any.
+func (auth *Authenticator) stopRenewal() {
+	auth.mutex.Lock()
+	defer auth.mutex.Unlock()
+	if auth.timer != nil {
+		log.Debugf("stopping timer")
+		auth.timer.Stop()
+		auth.timer = nil
+	}
+}
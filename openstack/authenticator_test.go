@@ -0,0 +1,89 @@
+package openstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/dihedron/go-log"
+)
+
+func TestLoginWithIDs(t *testing.T) {
+	tests := []struct {
+		opts     *LoginOptions
+		method   string
+		expected map[string]interface{}
+	}{
+		{
+			opts:     &LoginOptions{UserID: String("u1"), UserPassword: String("pa55w0rd")},
+			method:   "password",
+			expected: map[string]interface{}{"id": "u1", "password": "pa55w0rd"},
+		},
+		{
+			opts:     &LoginOptions{UserName: String("alice"), UserDomainID: String("d1"), UserPassword: String("pa55w0rd")},
+			method:   "password",
+			expected: map[string]interface{}{"name": "alice", "password": "pa55w0rd", "domain": map[string]interface{}{"id": "d1"}},
+		},
+		{
+			opts:     &LoginOptions{UserName: String("alice"), UserDomainID: String("d1"), AppCredentialID: String("a1"), Secret: String("pa55w0rd")},
+			method:   "application_credential",
+			expected: map[string]interface{}{"name": "alice", "domain": map[string]interface{}{"id": "d1"}},
+		},
+	}
+	// the logs are written to a file, as the logger only accepts files as streams
+	logs, err := ioutil.TempFile("", "go-openstack-test")
+	if err != nil {
+		t.Fatalf("Authenticator.TestLoginWithIDs: cannot create log file: %v", err)
+	}
+	defer os.Remove(logs.Name())
+	defer logs.Close()
+	defer log.SetStream(os.Stdout, true)
+	defer log.SetLevel(log.GetLevel())
+	log.SetLevel(log.DBG)
+	log.SetStream(logs, false)
+
+	for i, test := range tests {
+		var user interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/v3/auth/tokens" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			request := struct {
+				Auth struct {
+					Identity map[string]interface{} `json:"identity"`
+				} `json:"auth"`
+			}{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err == nil {
+				if method, ok := request.Auth.Identity[test.method].(map[string]interface{}); ok {
+					user = method["user"]
+				}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Subject-Token", "token")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"token": {"methods": ["` + test.method + `"]}}`))
+		}))
+
+		client := NewDefaultClient(server.URL)
+		err := client.Authenticator.Login(test.opts)
+		server.Close()
+		if err != nil || client.Authenticator.GetToken() == nil || stringValue(client.Authenticator.GetToken().Value) != "token" {
+			t.Errorf("Authenticator.TestLoginWithIDs: case %d: login failed: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(user, test.expected) {
+			t.Errorf("Authenticator.TestLoginWithIDs: case %d: expected user %v, got %v", i, test.expected, user)
+		}
+		if data, _ := ioutil.ReadFile(logs.Name()); !bytes.Contains(data, []byte("performing")) || bytes.Contains(data, []byte("pa55w0rd")) {
+			t.Errorf("Authenticator.TestLoginWithIDs: case %d: secret leaked into the logs:\n%s", i, data)
+		}
+		logs.Truncate(0)
+		logs.Seek(0, 0)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+// BareMetalV1API represents the bare metal API ver. 1 (Ironic), providing
+// support for the inventory of nodes and of their ports, for the discovery of
+// drivers and for driving nodes through the provisioning state machine.
+// Ironic uses microversions extensively: without one, requests are served with
+// the oldest version, 1.1, which lacks most features (e.g. field selection,
+// node names, the "manage" and "provide" transitions); a later one can be
+// requested for all calls through WithMicroversion, or the latest one supported
+// by the server through NegotiateMicroversion.
+// See https://developer.openstack.org/api-ref/baremetal/
+type BareMetalV1API struct {
+	API
+}
+
+// bareMetalMicroversion is the header through which Ironic is told the
+// microversion requested.
+var bareMetalMicroversion = microversionHeader{name: "X-OpenStack-Ironic-API-Version", base: "1.1"}
+
+// WithMicroversion returns a copy of the API that requests the given
+// microversion (e.g. "1.58") in all its calls, through the
+// X-OpenStack-Ironic-API-Version header; the receiver is left unchanged.
+func (api BareMetalV1API) WithMicroversion(microversion string) *BareMetalV1API {
+	return &BareMetalV1API{API: api.withMicroversion(bareMetalMicroversion, microversion)}
+}
+
+// NegotiateMicroversion returns a copy of the API that requests, in all its
+// calls, the microversion pinned for its endpoint in the client profile, or
+// the latest one supported by the server, as advertised in its version
+// document (see SelectMicroversion); the receiver is left unchanged.
+func (api BareMetalV1API) NegotiateMicroversion() (*BareMetalV1API, *Result, error) {
+	microversion, result, err := api.negotiateMicroversion()
+	if err != nil {
+		return nil, result, err
+	}
+	return api.WithMicroversion(microversion), result, nil
+}
+
+// Microversion returns the microversion requested in all calls, or "1.1" if
+// none was set.
+func (api *BareMetalV1API) Microversion() string {
+	return api.requestedMicroversion(bareMetalMicroversion)
+}
+
+// requireMicroversion checks that the microversion requested in all calls is
+// at least the given one, which is needed by the given feature.
+func (api *BareMetalV1API) requireMicroversion(minimum string, feature string) error {
+	return api.API.requireMicroversion(bareMetalMicroversion, minimum, feature)
+}
@@ -0,0 +1,55 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * DRIVERS
+ */
+
+// ListBareMetalDrivers returns the list of the drivers enabled on the
+// conductors; see also
+// https://developer.openstack.org/api-ref/baremetal/#list-drivers
+func (api *BareMetalV1API) ListBareMetalDrivers() (*[]BareMetalDriver, *Result, error) {
+	drivers := &[]BareMetalDriver{}
+	result, err := api.Invoke(http.MethodGet, "./v1/drivers", true, StatusCodeIn(200), nil, &envelope{Name: "drivers", Body: drivers}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return drivers, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveBareMetalDriver retrieves the driver with the given name; see also
+// https://developer.openstack.org/api-ref/baremetal/#show-driver-details
+func (api *BareMetalV1API) RetrieveBareMetalDriver(driver string) (*BareMetalDriver, *Result, error) {
+	output := &BareMetalDriver{}
+	result, err := api.Invoke(http.MethodGet, "./v1/drivers/{id}", true, StatusCodeIn(200), &envelope{ID: driver}, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveBareMetalDriverProperties returns the properties that can be set in
+// the driver info of the nodes using the driver with the given name (e.g.
+// "ipmi_address"), with their descriptions, which also state whether they are
+// required; see also
+// https://developer.openstack.org/api-ref/baremetal/#show-driver-properties
+func (api *BareMetalV1API) RetrieveBareMetalDriverProperties(driver string) (*map[string]string, *Result, error) {
+	properties := &map[string]string{}
+	result, err := api.Invoke(http.MethodGet, "./v1/drivers/{id}/properties", true, StatusCodeIn(200), &envelope{ID: driver}, properties, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return properties, result, err
+	}
+	return nil, result, err
+}
@@ -0,0 +1,155 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST NODES
+ */
+
+// ListNodesOptions provides the options available for filtering the list of
+// nodes: Detail returns all the fields of the nodes, Fields only the given ones
+// (microversion 1.8), otherwise only a few basic fields are returned; filtering
+// by Driver requires microversion 1.16, by ResourceClass 1.21, by Fault 1.42,
+// by ConductorGroup 1.46 and by Owner 1.50.
+type ListNodesOptions struct {
+	Detail         bool                `parameter:"-" header:"-" json:"-"`
+	Fields         *CommaSeparatedList `parameter:"fields,omitempty" header:"-" json:"-"`
+	InstanceUUID   *string             `parameter:"instance_uuid,omitempty" header:"-" json:"-"`
+	Associated     *bool               `parameter:"associated,omitempty" header:"-" json:"-"`
+	Maintenance    *bool               `parameter:"maintenance,omitempty" header:"-" json:"-"`
+	ProvisionState *string             `parameter:"provision_state,omitempty" header:"-" json:"-"`
+	Driver         *string             `parameter:"driver,omitempty" header:"-" json:"-"`
+	ResourceClass  *string             `parameter:"resource_class,omitempty" header:"-" json:"-"`
+	Fault          *string             `parameter:"fault,omitempty" header:"-" json:"-"`
+	ConductorGroup *string             `parameter:"conductor_group,omitempty" header:"-" json:"-"`
+	Owner          *string             `parameter:"owner,omitempty" header:"-" json:"-"`
+	SortKey        *string             `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir        *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit          *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker         *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// check verifies that the options are consistent and supported by the
+// microversion in use.
+func (opts *ListNodesOptions) check(api *BareMetalV1API) error {
+	if opts.Detail && opts.Fields != nil {
+		return errors.New("fields cannot be selected when listing nodes with details")
+	}
+	requirements := []struct {
+		set     bool
+		minimum string
+		feature string
+	}{
+		{opts.Fields != nil, "1.8", "field selection"},
+		{opts.Driver != nil, "1.16", "filtering nodes by driver"},
+		{opts.ResourceClass != nil, "1.21", "filtering nodes by resource class"},
+		{opts.Fault != nil, "1.42", "filtering nodes by fault"},
+		{opts.ConductorGroup != nil, "1.46", "filtering nodes by conductor group"},
+		{opts.Owner != nil, "1.50", "filtering nodes by owner"},
+	}
+	for _, requirement := range requirements {
+		if requirement.set {
+			if err := api.requireMicroversion(requirement.minimum, requirement.feature); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ListNodes returns the list of nodes; opts can be nil; see also
+// https://developer.openstack.org/api-ref/baremetal/#list-nodes
+func (api *BareMetalV1API) ListNodes(opts *ListNodesOptions) (*[]Node, *Result, error) {
+	if opts == nil {
+		opts = &ListNodesOptions{}
+	}
+	if err := opts.check(api); err != nil {
+		log.Errorf("invalid options for listing nodes: %v", err)
+		return nil, nil, err
+	}
+	path := "./v1/nodes"
+	if opts.Detail {
+		path = "./v1/nodes/detail"
+	}
+
+	nodes := []Node{}
+	result, err := api.listResources(path, "nodes", opts, nextField,
+		func() interface{} { return &[]Node{} },
+		func(page interface{}) { nodes = append(nodes, *page.(*[]Node)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &nodes, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE NODE
+ */
+
+// RetrieveNode retrieves the node with the given UUID or name (microversion
+// 1.5); if any fields are given, only those are returned (microversion 1.8);
+// see also https://developer.openstack.org/api-ref/baremetal/#show-node-details
+func (api *BareMetalV1API) RetrieveNode(node string, fields ...string) (*Node, *Result, error) {
+	input := &struct {
+		Node   string              `parameter:"-" header:"-" variable:"node" json:"-"`
+		Fields *CommaSeparatedList `parameter:"fields,omitempty" header:"-" json:"-"`
+	}{
+		Node: node,
+	}
+	if len(fields) > 0 {
+		if err := api.requireMicroversion("1.8", "field selection"); err != nil {
+			log.Errorf("cannot retrieve node %q: %v", node, err)
+			return nil, nil, err
+		}
+		list := CommaSeparatedList(fields)
+		input.Fields = &list
+	}
+	output := &Node{}
+
+	result, err := api.Invoke(http.MethodGet, "./v1/nodes/{node}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * MAINTENANCE
+ */
+
+// SetNodeMaintenance puts the node with the given UUID or name in maintenance
+// mode, with the given reason (which can be empty), or takes it out of it;
+// while in maintenance, Ironic stops managing the node (e.g. power state sync);
+// see also
+// https://developer.openstack.org/api-ref/baremetal/#set-maintenance-flag
+func (api *BareMetalV1API) SetNodeMaintenance(node string, maintenance bool, reason string) (bool, *Result, error) {
+	input := &struct {
+		Node   string `parameter:"-" header:"-" variable:"node" json:"-"`
+		Reason string `parameter:"-" header:"-" json:"reason,omitempty"`
+	}{
+		Node:   node,
+		Reason: reason,
+	}
+	method := http.MethodPut
+	if !maintenance {
+		method = http.MethodDelete
+	}
+
+	result, err := api.Invoke(method, "./v1/nodes/{node}/maintenance", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
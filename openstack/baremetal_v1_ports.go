@@ -0,0 +1,94 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST PORTS
+ */
+
+// ListBareMetalPortsOptions provides the options available for filtering the
+// list of ports: Detail returns all the fields of the ports, Fields only the
+// given ones (microversion 1.8).
+type ListBareMetalPortsOptions struct {
+	Detail   bool                `parameter:"-" header:"-" json:"-"`
+	Fields   *CommaSeparatedList `parameter:"fields,omitempty" header:"-" json:"-"`
+	NodeUUID *string             `parameter:"node_uuid,omitempty" header:"-" json:"-"`
+	Address  *string             `parameter:"address,omitempty" header:"-" json:"-"`
+	SortKey  *string             `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir  *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit    *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker   *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListBareMetalPorts returns the list of ports; opts can be nil; see also
+// https://developer.openstack.org/api-ref/baremetal/#list-ports
+func (api *BareMetalV1API) ListBareMetalPorts(opts *ListBareMetalPortsOptions) (*[]BareMetalPort, *Result, error) {
+	if opts == nil {
+		opts = &ListBareMetalPortsOptions{}
+	}
+	if opts.Fields != nil {
+		if err := api.requireMicroversion("1.8", "field selection"); err != nil {
+			log.Errorf("invalid options for listing ports: %v", err)
+			return nil, nil, err
+		}
+	}
+	path := "./v1/ports"
+	if opts.Detail {
+		path = "./v1/ports/detail"
+	}
+
+	ports := []BareMetalPort{}
+	result, err := api.listResources(path, "ports", opts, nextField,
+		func() interface{} { return &[]BareMetalPort{} },
+		func(page interface{}) { ports = append(ports, *page.(*[]BareMetalPort)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &ports, result, err
+	}
+	return nil, result, err
+}
+
+// ListNodePorts returns the ports of the node with the given UUID or name, with
+// all their fields; see also
+// https://developer.openstack.org/api-ref/baremetal/#list-ports-by-node
+func (api *BareMetalV1API) ListNodePorts(node string) (*[]BareMetalPort, *Result, error) {
+	input := &struct {
+		Node string `parameter:"-" header:"-" variable:"node" json:"-"`
+	}{
+		Node: node,
+	}
+
+	ports := []BareMetalPort{}
+	result, err := api.listResources("./v1/nodes/{node}/ports/detail", "ports", input, nextField,
+		func() interface{} { return &[]BareMetalPort{} },
+		func(page interface{}) { ports = append(ports, *page.(*[]BareMetalPort)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &ports, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE PORT
+ */
+
+// RetrieveBareMetalPort retrieves the port with the given UUID; see also
+// https://developer.openstack.org/api-ref/baremetal/#show-port-details
+func (api *BareMetalV1API) RetrieveBareMetalPort(portid string) (*BareMetalPort, *Result, error) {
+	port := &BareMetalPort{}
+	result, err := api.Invoke(http.MethodGet, "./v1/ports/{id}", true, StatusCodeIn(200), &envelope{ID: portid}, port, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return port, result, err
+	}
+	return nil, result, err
+}
@@ -0,0 +1,243 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// Node provision states: stable states, in which a node stays until requested
+// to move, and the transient states it goes through in between; failed states
+// are stable, the reason of the failure is in the last error of the node.
+const (
+	NodeStateEnroll        string = "enroll"
+	NodeStateVerifying     string = "verifying"
+	NodeStateManageable    string = "manageable"
+	NodeStateCleaning      string = "cleaning"
+	NodeStateCleanWait     string = "clean wait"
+	NodeStateCleanFailed   string = "clean failed"
+	NodeStateAvailable     string = "available"
+	NodeStateDeploying     string = "deploying"
+	NodeStateDeployWait    string = "wait call-back"
+	NodeStateDeployFailed  string = "deploy failed"
+	NodeStateActive        string = "active"
+	NodeStateDeleting      string = "deleting"
+	NodeStateError         string = "error"
+	NodeStateInspecting    string = "inspecting"
+	NodeStateInspectFailed string = "inspect failed"
+	NodeStateAdoptFailed   string = "adopt failed"
+)
+
+// Node provision verbs, i.e. the targets of the provision state endpoint.
+const (
+	// NodeProvisionManage moves a node to manageable, verifying its driver info
+	// when enrolled or stopping to offer it when available.
+	NodeProvisionManage string = "manage"
+	// NodeProvisionProvide makes a manageable node available for deployment,
+	// after cleaning it.
+	NodeProvisionProvide string = "provide"
+	// NodeProvisionDeploy deploys an available node, making it active.
+	NodeProvisionDeploy string = "active"
+	// NodeProvisionRebuild redeploys an active node.
+	NodeProvisionRebuild string = "rebuild"
+	// NodeProvisionUndeploy tears down an active node, which is then cleaned and
+	// made available again.
+	NodeProvisionUndeploy string = "deleted"
+)
+
+// nodeProvisionTransition describes a provision verb: the stable states it can
+// be requested in, the stable state it leads to when successful and the
+// microversion it requires.
+type nodeProvisionTransition struct {
+	from         []string
+	to           string
+	microversion string
+}
+
+// nodeProvisionTransitions are the supported provision verbs, as per the Ironic
+// state machine.
+var nodeProvisionTransitions = map[string]nodeProvisionTransition{
+	NodeProvisionManage: {
+		from:         []string{NodeStateEnroll, NodeStateAvailable, NodeStateCleanFailed, NodeStateInspectFailed, NodeStateAdoptFailed},
+		to:           NodeStateManageable,
+		microversion: "1.4",
+	},
+	NodeProvisionProvide: {
+		from:         []string{NodeStateManageable},
+		to:           NodeStateAvailable,
+		microversion: "1.4",
+	},
+	NodeProvisionDeploy: {
+		from:         []string{NodeStateAvailable, NodeStateDeployFailed},
+		to:           NodeStateActive,
+		microversion: "1.1",
+	},
+	NodeProvisionRebuild: {
+		from:         []string{NodeStateActive, NodeStateDeployFailed, NodeStateError},
+		to:           NodeStateActive,
+		microversion: "1.1",
+	},
+	NodeProvisionUndeploy: {
+		from:         []string{NodeStateActive, NodeStateDeployFailed, NodeStateError, NodeStateDeployWait},
+		to:           NodeStateAvailable,
+		microversion: "1.1",
+	},
+}
+
+// NodeProvisionTarget returns the stable provision state a node reaches when
+// the given provision verb is successful (e.g. "active" for "rebuild"), to be
+// waited for with WaitForNodeProvisionState.
+func NodeProvisionTarget(verb string) (string, error) {
+	transition, ok := nodeProvisionTransitions[verb]
+	if !ok {
+		return "", fmt.Errorf("unsupported provision verb %q", verb)
+	}
+	return transition.to, nil
+}
+
+// ValidateNodeProvisionTransition checks that the given provision verb can be
+// requested for a node in the given provision state.
+func ValidateNodeProvisionTransition(state string, verb string) error {
+	transition, ok := nodeProvisionTransitions[verb]
+	if !ok {
+		return fmt.Errorf("unsupported provision verb %q", verb)
+	}
+	for _, from := range transition.from {
+		if state == from {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot %q a node in %q state (valid states: %s)", verb, state, strings.Join(transition.from, ", "))
+}
+
+// NodeProvisionError is returned when a node fails to reach the provision state
+// it was waited for: State is the state it ended up in, LastError the reason
+// reported by Ironic.
+type NodeProvisionError struct {
+	Node      string
+	State     string
+	LastError string
+}
+
+// Error returns a description of the failure.
+func (e *NodeProvisionError) Error() string {
+	return fmt.Sprintf("node %q is in %q state: %s", e.Node, e.State, e.LastError)
+}
+
+/*
+ * SET PROVISION STATE
+ */
+
+// SetNodeProvisionStateOptions provides the options available for changing the
+// provision state of a node: ConfigDrive is the config drive to be written when
+// deploying or rebuilding it, either as a gzipped and base64-encoded ISO 9660
+// image, a URL to one, or (microversion 1.56) a map with the "meta_data",
+// "user_data" and "network_data" to build one from.
+type SetNodeProvisionStateOptions struct {
+	ConfigDrive interface{} `parameter:"-" header:"-" json:"configdrive,omitempty"`
+}
+
+// SetNodeProvisionState requests the node with the given UUID or name to move
+// through the state machine according to the given verb (one of the
+// NodeProvision* constants); the transition is validated against the current
+// state of the node beforehand, and fails if the node is already moving; the
+// transition is asynchronous (see WaitForNodeProvisionState); opts can be nil;
+// see also
+// https://developer.openstack.org/api-ref/baremetal/#change-node-provision-state
+func (api *BareMetalV1API) SetNodeProvisionState(node string, verb string, opts *SetNodeProvisionStateOptions) (bool, *Result, error) {
+	if opts == nil {
+		opts = &SetNodeProvisionStateOptions{}
+	}
+	transition, ok := nodeProvisionTransitions[verb]
+	if !ok {
+		log.Errorf("unsupported provision verb %q", verb)
+		return false, nil, fmt.Errorf("unsupported provision verb %q", verb)
+	}
+	if err := api.requireMicroversion(transition.microversion, fmt.Sprintf("the %q provision verb", verb)); err != nil {
+		log.Errorf("cannot change provision state of node %q: %v", node, err)
+		return false, nil, err
+	}
+
+	current, result, err := api.RetrieveNode(node)
+	if current == nil {
+		return false, result, err
+	}
+	if stringValue(current.TargetProvisionState) != "" {
+		log.Errorf("node %q is already moving from %q to %q", node, stringValue(current.ProvisionState), *current.TargetProvisionState)
+		return false, result, fmt.Errorf("node %q is already moving from %q to %q", node, stringValue(current.ProvisionState), *current.TargetProvisionState)
+	}
+	if err := ValidateNodeProvisionTransition(stringValue(current.ProvisionState), verb); err != nil {
+		log.Errorf("invalid provision state transition for node %q: %v", node, err)
+		return false, result, err
+	}
+
+	input := &struct {
+		Node   string `parameter:"-" header:"-" variable:"node" json:"-"`
+		Target string `parameter:"-" header:"-" json:"target"`
+		SetNodeProvisionStateOptions
+	}{
+		Node:                         node,
+		Target:                       verb,
+		SetNodeProvisionStateOptions: *opts,
+	}
+
+	result, err = api.Invoke(http.MethodPut, "./v1/nodes/{node}/states/provision", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * WAIT FOR PROVISION STATE
+ */
+
+// WaitForNodeProvisionState waits until the node with the given UUID or name
+// settles in the given provision state (see NodeProvisionTarget), polling it
+// with the given backoff policy; the wait fails with a *NodeProvisionError,
+// carrying the last error of the node, if it settles in a failed state instead
+// (e.g. "deploy failed"), or back in "enroll" because its verification failed.
+func (api *BareMetalV1API) WaitForNodeProvisionState(ctx context.Context, node string, state string, backoff Backoff) (*Node, error) {
+	var current *Node
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var result *Result
+		var err error
+		current, result, err = api.RetrieveNode(node)
+		if err != nil {
+			return false, err
+		}
+		if current == nil || current.ProvisionState == nil {
+			return false, fmt.Errorf("error retrieving node %q: %v", node, result)
+		}
+		log.Debugf("node %q is %q (target: %q), waiting for %q", node, *current.ProvisionState, stringValue(current.TargetProvisionState), state)
+		if stringValue(current.TargetProvisionState) != "" {
+			// still moving
+			return false, nil
+		}
+		if *current.ProvisionState == state {
+			return true, nil
+		}
+		if isNodeStateFailed(*current.ProvisionState) || (*current.ProvisionState == NodeStateEnroll && stringValue(current.LastError) != "") {
+			return false, &NodeProvisionError{
+				Node:      node,
+				State:     *current.ProvisionState,
+				LastError: stringValue(current.LastError),
+			}
+		}
+		return false, nil
+	})
+	return current, err
+}
+
+// isNodeStateFailed returns whether the given provision state is a failed one.
+func isNodeStateFailed(state string) bool {
+	return state == NodeStateError || strings.HasSuffix(state, " failed")
+}
@@ -0,0 +1,61 @@
+package openstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidateNodeProvisionTransition(t *testing.T) {
+	valid := map[string]string{
+		NodeStateEnroll:       NodeProvisionManage,
+		NodeStateManageable:   NodeProvisionProvide,
+		NodeStateAvailable:    NodeProvisionDeploy,
+		NodeStateActive:       NodeProvisionRebuild,
+		NodeStateDeployFailed: NodeProvisionUndeploy,
+	}
+	for state, verb := range valid {
+		if err := ValidateNodeProvisionTransition(state, verb); err != nil {
+			t.Errorf("BareMetal.TestValidateNodeProvisionTransition: expected %q to be valid in %q state, got %v", verb, state, err)
+		}
+	}
+	invalid := map[string]string{
+		NodeStateEnroll:     NodeProvisionProvide,
+		NodeStateManageable: NodeProvisionDeploy,
+		NodeStateAvailable:  NodeProvisionUndeploy,
+		NodeStateActive:     "bogus",
+	}
+	for state, verb := range invalid {
+		if err := ValidateNodeProvisionTransition(state, verb); err == nil {
+			t.Errorf("BareMetal.TestValidateNodeProvisionTransition: expected %q to be invalid in %q state", verb, state)
+		}
+	}
+}
+
+func TestWaitForNodeProvisionState(t *testing.T) {
+	states := []string{
+		`{"provision_state": "available", "target_provision_state": "active"}`,
+		`{"provision_state": "wait call-back", "target_provision_state": "active"}`,
+		`{"provision_state": "deploy failed", "target_provision_state": null, "last_error": "timeout"}`,
+	}
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(states[polls]))
+		if polls < len(states)-1 {
+			polls++
+		}
+	}))
+	defer server.Close()
+
+	client := NewDefaultClient(server.URL)
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+	api := &BareMetalV1API{API: client.Authenticator.Identity.API}
+
+	_, err := api.WaitForNodeProvisionState(context.Background(), "n1", NodeStateActive, Backoff{Initial: time.Millisecond})
+	if err, ok := err.(*NodeProvisionError); !ok || err.State != NodeStateDeployFailed || err.LastError != "timeout" {
+		t.Fatalf("BareMetal.TestWaitForNodeProvisionState: expected a deploy failure, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * NODES
+ */
+
+// Node is a bare metal server managed by Ironic: ProvisionState is its state in
+// the provisioning state machine (e.g. "manageable", "available", "active"),
+// TargetProvisionState the one it is moving to, if any, and LastError the
+// reason of the latest failure; when fields are selected, only those are set.
+type Node struct {
+	UUID                 *string                 `json:"uuid,omitempty"`
+	Name                 *string                 `json:"name,omitempty"`
+	InstanceUUID         *string                 `json:"instance_uuid,omitempty"`
+	PowerState           *string                 `json:"power_state,omitempty"`
+	TargetPowerState     *string                 `json:"target_power_state,omitempty"`
+	ProvisionState       *string                 `json:"provision_state,omitempty"`
+	TargetProvisionState *string                 `json:"target_provision_state,omitempty"`
+	ProvisionUpdatedAt   *string                 `json:"provision_updated_at,omitempty"`
+	Maintenance          *bool                   `json:"maintenance,omitempty"`
+	MaintenanceReason    *string                 `json:"maintenance_reason,omitempty"`
+	Fault                *string                 `json:"fault,omitempty"`
+	LastError            *string                 `json:"last_error,omitempty"`
+	Reservation          *string                 `json:"reservation,omitempty"`
+	Driver               *string                 `json:"driver,omitempty"`
+	DriverInfo           *map[string]interface{} `json:"driver_info,omitempty"`
+	Properties           *map[string]interface{} `json:"properties,omitempty"`
+	InstanceInfo         *map[string]interface{} `json:"instance_info,omitempty"`
+	Extra                *map[string]interface{} `json:"extra,omitempty"`
+	ResourceClass        *string                 `json:"resource_class,omitempty"`
+	ConductorGroup       *string                 `json:"conductor_group,omitempty"`
+	Conductor            *string                 `json:"conductor,omitempty"`
+	Owner                *string                 `json:"owner,omitempty"`
+	Lessee               *string                 `json:"lessee,omitempty"`
+	ConsoleEnabled       *bool                   `json:"console_enabled,omitempty"`
+	Protected            *bool                   `json:"protected,omitempty"`
+	Retired              *bool                   `json:"retired,omitempty"`
+	CreatedAt            *string                 `json:"created_at,omitempty"`
+	UpdatedAt            *string                 `json:"updated_at,omitempty"`
+}
+
+/*
+ * PORTS
+ */
+
+// BareMetalPort is a physical network interface of a node, identified by its
+// MAC address; LocalLinkConnection describes the switch port it is plugged in
+// (e.g. "switch_id", "port_id").
+type BareMetalPort struct {
+	UUID                *string                 `json:"uuid,omitempty"`
+	Address             *string                 `json:"address,omitempty"`
+	NodeUUID            *string                 `json:"node_uuid,omitempty"`
+	PortGroupUUID       *string                 `json:"portgroup_uuid,omitempty"`
+	LocalLinkConnection *map[string]interface{} `json:"local_link_connection,omitempty"`
+	PXEEnabled          *bool                   `json:"pxe_enabled,omitempty"`
+	PhysicalNetwork     *string                 `json:"physical_network,omitempty"`
+	IsSmartNIC          *bool                   `json:"is_smartnic,omitempty"`
+	Extra               *map[string]interface{} `json:"extra,omitempty"`
+	CreatedAt           *string                 `json:"created_at,omitempty"`
+	UpdatedAt           *string                 `json:"updated_at,omitempty"`
+}
+
+/*
+ * DRIVERS
+ */
+
+// BareMetalDriver is a hardware type (or classic driver) enabled on the
+// conductors listed in Hosts; the Enabled* and Default* fields list the
+// hardware interfaces it supports (e.g. "ipmitool", "redfish" for power).
+type BareMetalDriver struct {
+	Name                       *string   `json:"name,omitempty"`
+	Type                       *string   `json:"type,omitempty"`
+	Hosts                      *[]string `json:"hosts,omitempty"`
+	DefaultBootInterface       *string   `json:"default_boot_interface,omitempty"`
+	DefaultDeployInterface     *string   `json:"default_deploy_interface,omitempty"`
+	DefaultManagementInterface *string   `json:"default_management_interface,omitempty"`
+	DefaultPowerInterface      *string   `json:"default_power_interface,omitempty"`
+	EnabledBootInterfaces      *[]string `json:"enabled_boot_interfaces,omitempty"`
+	EnabledDeployInterfaces    *[]string `json:"enabled_deploy_interfaces,omitempty"`
+	EnabledPowerInterfaces     *[]string `json:"enabled_power_interfaces,omitempty"`
+}
@@ -0,0 +1,51 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+// BlockStorageV3API represents the block storage API ver. 3 (Cinder), providing
+// support for the management of volumes, snapshots, backups and attachments.
+// Cinder uses microversions to add features to the API: by default requests are
+// served with the base version 3.0, a later one can be requested for all calls
+// through WithMicroversion, or the latest one supported by the server through
+// NegotiateMicroversion.
+// See https://developer.openstack.org/api-ref/block-storage/v3/
+type BlockStorageV3API struct {
+	API
+}
+
+// blockStorageMicroversion is the header through which Cinder is told the
+// microversion requested.
+var blockStorageMicroversion = microversionHeader{name: "OpenStack-API-Version", service: "volume", base: "3.0"}
+
+// WithMicroversion returns a copy of the API that requests the given
+// microversion (e.g. "3.64") in all its calls, through the OpenStack-API-Version
+// header; the receiver is left unchanged.
+func (api BlockStorageV3API) WithMicroversion(microversion string) *BlockStorageV3API {
+	return &BlockStorageV3API{API: api.withMicroversion(blockStorageMicroversion, microversion)}
+}
+
+// NegotiateMicroversion returns a copy of the API that requests, in all its
+// calls, the microversion pinned for its endpoint in the client profile, or
+// the latest one supported by the server, as advertised in its version
+// document (see SelectMicroversion); the receiver is left unchanged.
+func (api BlockStorageV3API) NegotiateMicroversion() (*BlockStorageV3API, *Result, error) {
+	microversion, result, err := api.negotiateMicroversion()
+	if err != nil {
+		return nil, result, err
+	}
+	return api.WithMicroversion(microversion), result, nil
+}
+
+// Microversion returns the microversion requested in all calls, or "3.0" if
+// none was set.
+func (api *BlockStorageV3API) Microversion() string {
+	return api.requestedMicroversion(blockStorageMicroversion)
+}
+
+// requireMicroversion checks that the microversion requested in all calls is
+// at least the given one, which is needed by the given feature.
+func (api *BlockStorageV3API) requireMicroversion(minimum string, feature string) error {
+	return api.API.requireMicroversion(blockStorageMicroversion, minimum, feature)
+}
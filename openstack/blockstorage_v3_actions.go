@@ -0,0 +1,166 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// invokeVolumeAction performs the given action on the volume identified by the
+// given id: all volume actions share the same endpoint (/volumes/{id}/action)
+// and differ in the name of the single key of the request entity (e.g.
+// "os-extend") and in its value; the response entity, if any, is stored into
+// output.
+func (api *BlockStorageV3API) invokeVolumeAction(volumeid string, name string, body interface{}, checker Checker, output interface{}) (*Result, error) {
+	input := &envelope{ID: volumeid, Name: name, Body: body}
+	log.Debugf("invoking action %q on volume %q", name, volumeid)
+	return api.Invoke(http.MethodPost, "./volumes/{id}/action", true, checker, input, output, nil)
+}
+
+/*
+ * EXTEND VOLUME
+ */
+
+// ExtendVolume extends the volume identified by the given id to the given size
+// (in GiB), which must be larger than the current one; volumes are normally
+// extended while "available", extending "in-use" volumes requires microversion
+// 3.42 and support in the volume and compute drivers; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#extend-a-volume-size
+func (api *BlockStorageV3API) ExtendVolume(volumeid string, size int) (bool, *Result, error) {
+	body := &struct {
+		NewSize int `json:"new_size"`
+	}{
+		NewSize: size,
+	}
+	result, err := api.invokeVolumeAction(volumeid, "os-extend", body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * RETYPE VOLUME
+ */
+
+const (
+	// RetypeMigrationNever is the migration policy refusing to migrate volumes
+	// to another back-end when changing their type.
+	RetypeMigrationNever = "never"
+	// RetypeMigrationOnDemand is the migration policy allowing to migrate
+	// volumes to another back-end when changing their type, if needed.
+	RetypeMigrationOnDemand = "on-demand"
+)
+
+// RetypeVolume changes the type of the volume identified by the given id; if
+// the new type is served by another back-end, the volume is migrated only if
+// the migration policy (one of the RetypeMigration* values) allows it; retyping
+// "in-use" volumes requires support in the compute driver; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#retype-a-volume
+func (api *BlockStorageV3API) RetypeVolume(volumeid string, volumetype string, policy *string) (bool, *Result, error) {
+	body := &struct {
+		NewType         string  `json:"new_type"`
+		MigrationPolicy *string `json:"migration_policy,omitempty"`
+	}{
+		NewType:         volumetype,
+		MigrationPolicy: policy,
+	}
+	result, err := api.invokeVolumeAction(volumeid, "os-retype", body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * SET VOLUME BOOTABLE
+ */
+
+// SetVolumeBootable sets or clears the bootable flag of the volume identified
+// by the given id; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#update-a-volume-s-bootable-status
+func (api *BlockStorageV3API) SetVolumeBootable(volumeid string, bootable bool) (bool, *Result, error) {
+	body := &struct {
+		Bootable bool `json:"bootable"`
+	}{
+		Bootable: bootable,
+	}
+	result, err := api.invokeVolumeAction(volumeid, "os-set_bootable", body, StatusCodeIn(200), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * RESET VOLUME STATUS
+ */
+
+// ResetVolumeStatusOptions provides the options available for resetting the
+// status of a volume: Status is the new status (e.g. VolumeStatusAvailable),
+// AttachStatus ("attached" or "detached") and MigrationStatus the new values of
+// the corresponding attributes; only the given ones are modified.
+type ResetVolumeStatusOptions struct {
+	Status          *VolumeStatus `json:"status,omitempty"`
+	AttachStatus    *string       `json:"attach_status,omitempty"`
+	MigrationStatus *string       `json:"migration_status,omitempty"`
+}
+
+// ResetVolumeStatus resets the status of the volume identified by the given id
+// in the database, without touching the back-end, e.g. to recover a volume
+// stuck in a transitional status; it is an administrative operation by default;
+// see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#reset-a-volume-s-statuses
+func (api *BlockStorageV3API) ResetVolumeStatus(volumeid string, opts *ResetVolumeStatusOptions) (bool, *Result, error) {
+	result, err := api.invokeVolumeAction(volumeid, "os-reset_status", opts, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * UPLOAD VOLUME TO IMAGE
+ */
+
+// UploadVolumeToImageOptions provides the options available for uploading a
+// volume to an image: ImageName is the name of the new image; Force allows to
+// upload "in-use" volumes; Visibility and Protected require microversion 3.1.
+type UploadVolumeToImageOptions struct {
+	ImageName       string           `json:"image_name"`
+	DiskFormat      *string          `json:"disk_format,omitempty"`
+	ContainerFormat *string          `json:"container_format,omitempty"`
+	Force           *bool            `json:"force,omitempty"`
+	Visibility      *ImageVisibility `json:"visibility,omitempty"`
+	Protected       *bool            `json:"protected,omitempty"`
+}
+
+// UploadVolumeToImage creates a new image in the image service (see
+// ImageV2API) from the data of the volume identified by the given id; the data
+// is uploaded asynchronously, while the volume is VolumeStatusUploading and the
+// image is "saving"; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#upload-volume-to-image
+func (api *BlockStorageV3API) UploadVolumeToImage(volumeid string, opts *UploadVolumeToImageOptions) (*VolumeImageUpload, *Result, error) {
+	if opts.Visibility != nil || opts.Protected != nil {
+		if err := api.requireMicroversion("3.1", "the image visibility and protection"); err != nil {
+			return nil, nil, err
+		}
+	}
+	upload := &VolumeImageUpload{}
+	output := &envelope{Name: "os-volume_upload_image", Body: upload}
+
+	result, err := api.invokeVolumeAction(volumeid, "os-volume_upload_image", opts, StatusCodeIn(202), output)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return upload, result, err
+	}
+	return nil, result, err
+}
@@ -0,0 +1,183 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// The attachments API is the way volumes are attached since microversion 3.27:
+// the consumer first reserves the volume by creating an attachment without a
+// connector, then updates it with the connector of its host, which returns the
+// connection info, connects the volume and finally marks the attachment as
+// complete; deleting the attachment detaches the volume.
+
+const (
+	// AttachmentModeReadWrite is the attach mode of read-write attachments.
+	AttachmentModeReadWrite = "rw"
+	// AttachmentModeReadOnly is the attach mode of read-only attachments.
+	AttachmentModeReadOnly = "ro"
+)
+
+/*
+ * LIST ATTACHMENTS
+ */
+
+// ListAttachmentsOptions provides the options available for filtering the list
+// of attachments; Limit sets the page size, all pages are retrieved anyway.
+type ListAttachmentsOptions struct {
+	AllProjects *bool   `parameter:"all_tenants,omitempty" header:"-" json:"-"`
+	ProjectID   *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	VolumeID    *string `parameter:"volume_id,omitempty" header:"-" json:"-"`
+	InstanceID  *string `parameter:"instance_id,omitempty" header:"-" json:"-"`
+	Status      *string `parameter:"status,omitempty" header:"-" json:"-"`
+	Sort        *string `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit       *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker      *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListAttachments returns the list of attachments, with their details; it
+// requires microversion 3.27; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#list-attachments-with-details
+func (api *BlockStorageV3API) ListAttachments(opts *ListAttachmentsOptions) (*[]Attachment, *Result, error) {
+	if err := api.requireMicroversion("3.27", "the attachments API"); err != nil {
+		return nil, nil, err
+	}
+	attachments := []Attachment{}
+	result, err := api.listResources("./attachments/detail", "attachments", opts, collectionLinks,
+		func() interface{} { return &[]Attachment{} },
+		func(page interface{}) { attachments = append(attachments, *page.(*[]Attachment)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &attachments, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE ATTACHMENT
+ */
+
+// CreateAttachmentOptions provides the options available for creating an
+// attachment: InstanceID is the server (or other consumer) the volume is
+// attached to; without a Connector the volume is only reserved; Mode (one of
+// the AttachmentMode* values) requires microversion 3.54.
+type CreateAttachmentOptions struct {
+	VolumeID   string               `json:"volume_uuid"`
+	InstanceID *string              `json:"instance_uuid,omitempty"`
+	Connector  *AttachmentConnector `json:"connector,omitempty"`
+	Mode       *string              `json:"mode,omitempty"`
+}
+
+// CreateAttachment creates an attachment, reserving the volume and, if a
+// connector is given, returning the connection info; it requires microversion
+// 3.27; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#create-attachment
+func (api *BlockStorageV3API) CreateAttachment(opts *CreateAttachmentOptions) (*Attachment, *Result, error) {
+	if err := api.requireMicroversion("3.27", "the attachments API"); err != nil {
+		return nil, nil, err
+	}
+	if opts.Mode != nil {
+		if err := api.requireMicroversion("3.54", "the attach mode"); err != nil {
+			return nil, nil, err
+		}
+	}
+	attachment := &Attachment{}
+	result, err := api.createResource("./attachments", "attachment", opts, attachment, 200, 202)
+	if result != nil && result.Code == 200 {
+		return attachment, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE ATTACHMENT
+ */
+
+// RetrieveAttachment retrieves the details of the attachment identified by the
+// given id; it requires microversion 3.27; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#show-attachment-details
+func (api *BlockStorageV3API) RetrieveAttachment(attachmentid string) (*Attachment, *Result, error) {
+	if err := api.requireMicroversion("3.27", "the attachments API"); err != nil {
+		return nil, nil, err
+	}
+	attachment := &Attachment{}
+	result, err := api.retrieveResource("./attachments/{id}", attachmentid, "attachment", attachment)
+	if result != nil && result.Code == 200 {
+		return attachment, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE ATTACHMENT
+ */
+
+// UpdateAttachment gives the connector of the host to the attachment identified
+// by the given id, which was created without one, and returns the updated
+// attachment, with the connection info; it requires microversion 3.27; see
+// also https://developer.openstack.org/api-ref/block-storage/v3/#update-an-attachment
+func (api *BlockStorageV3API) UpdateAttachment(attachmentid string, connector *AttachmentConnector) (*Attachment, *Result, error) {
+	if err := api.requireMicroversion("3.27", "the attachments API"); err != nil {
+		return nil, nil, err
+	}
+	body := &struct {
+		Connector *AttachmentConnector `json:"connector"`
+	}{
+		Connector: connector,
+	}
+	attachment := &Attachment{}
+	result, err := api.updateResource("./attachments/{id}", attachmentid, "attachment", body, attachment)
+	if result != nil && result.Code == 200 {
+		return attachment, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * COMPLETE ATTACHMENT
+ */
+
+// CompleteAttachment marks the attachment identified by the given id as
+// complete, once the host has connected the volume, which then becomes
+// "in-use"; it requires microversion 3.44; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#complete-attachment
+func (api *BlockStorageV3API) CompleteAttachment(attachmentid string) (bool, *Result, error) {
+	if err := api.requireMicroversion("3.44", "completing attachments"); err != nil {
+		return false, nil, err
+	}
+	input := &envelope{ID: attachmentid, Name: "os-complete"}
+
+	result, err := api.Invoke(http.MethodPost, "./attachments/{id}/action", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * DELETE ATTACHMENT
+ */
+
+// DeleteAttachment deletes the attachment identified by the given id, which
+// detaches the volume (after the host has disconnected it); it requires
+// microversion 3.27; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#delete-attachment
+func (api *BlockStorageV3API) DeleteAttachment(attachmentid string) (bool, *Result, error) {
+	if err := api.requireMicroversion("3.27", "the attachments API"); err != nil {
+		return false, nil, err
+	}
+	input := &envelope{ID: attachmentid}
+
+	result, err := api.Invoke(http.MethodDelete, "./attachments/{id}", true, StatusCodeIn(200), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,126 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * VOLUMES
+ */
+
+// Volume is a block storage volume; Bootable is reported as a string ("true" or
+// "false"); Attachments lists the servers the volume is attached to; the
+// attributes of the image the volume was created from are in
+// VolumeImageMetadata; ProjectID, Host and the migration attributes are only
+// visible to administrators by default.
+type Volume struct {
+	ID                  *string                   `json:"id,omitempty"`
+	Name                *string                   `json:"name,omitempty"`
+	Description         *string                   `json:"description,omitempty"`
+	Status              *VolumeStatus             `json:"status,omitempty"`
+	Size                *int                      `json:"size,omitempty"`
+	VolumeType          *string                   `json:"volume_type,omitempty"`
+	AvailabilityZone    *string                   `json:"availability_zone,omitempty"`
+	Bootable            *string                   `json:"bootable,omitempty"`
+	Encrypted           *bool                     `json:"encrypted,omitempty"`
+	Multiattach         *bool                     `json:"multiattach,omitempty"`
+	SnapshotID          *string                   `json:"snapshot_id,omitempty"`
+	SourceVolumeID      *string                   `json:"source_volid,omitempty"`
+	BackupID            *string                   `json:"backup_id,omitempty"`
+	GroupID             *string                   `json:"group_id,omitempty"`
+	ConsistencyGroupID  *string                   `json:"consistencygroup_id,omitempty"`
+	ReplicationStatus   *string                   `json:"replication_status,omitempty"`
+	MigrationStatus     *string                   `json:"migration_status,omitempty"`
+	Metadata            *map[string]string        `json:"metadata,omitempty"`
+	VolumeImageMetadata *map[string]string        `json:"volume_image_metadata,omitempty"`
+	Attachments         *[]VolumeServerAttachment `json:"attachments,omitempty"`
+	UserID              *string                   `json:"user_id,omitempty"`
+	ProjectID           *string                   `json:"os-vol-tenant-attr:tenant_id,omitempty"`
+	Host                *string                   `json:"os-vol-host-attr:host,omitempty"`
+	MigrationID         *string                   `json:"os-vol-mig-status-attr:name_id,omitempty"`
+	CreatedAt           *string                   `json:"created_at,omitempty"`
+	UpdatedAt           *string                   `json:"updated_at,omitempty"`
+	Links               *[]Link                   `json:"links,omitempty"`
+}
+
+// VolumeServerAttachment describes the attachment of a volume to a server.
+type VolumeServerAttachment struct {
+	ID           *string `json:"id,omitempty"`
+	AttachmentID *string `json:"attachment_id,omitempty"`
+	VolumeID     *string `json:"volume_id,omitempty"`
+	ServerID     *string `json:"server_id,omitempty"`
+	HostName     *string `json:"host_name,omitempty"`
+	Device       *string `json:"device,omitempty"`
+	AttachedAt   *string `json:"attached_at,omitempty"`
+}
+
+/*
+ * ATTACHMENTS
+ */
+
+// Attachment is the attachment of a volume to a server (or to any other
+// consumer, in standalone deployments); it is created in the "reserved" status
+// and becomes "attaching" once the connector of the host is given, then
+// "attached" when completed; ConnectionInfo is what the host needs in order to
+// connect to the volume (e.g. the iSCSI target).
+type Attachment struct {
+	ID             *string                 `json:"id,omitempty"`
+	VolumeID       *string                 `json:"volume_id,omitempty"`
+	InstanceID     *string                 `json:"instance,omitempty"`
+	Status         *string                 `json:"status,omitempty"`
+	AttachMode     *string                 `json:"attach_mode,omitempty"`
+	AttachedAt     *string                 `json:"attached_at,omitempty"`
+	DetachedAt     *string                 `json:"detached_at,omitempty"`
+	ConnectionInfo *map[string]interface{} `json:"connection_info,omitempty"`
+}
+
+// AttachmentConnector describes the host a volume is connected to, and how:
+// Initiator is its iSCSI initiator name, WWPNs and WWNNs its Fibre Channel
+// ports, NQN its NVMe qualified name; Mountpoint is the device on the server.
+type AttachmentConnector struct {
+	Host       *string   `json:"host,omitempty"`
+	IP         *string   `json:"ip,omitempty"`
+	Platform   *string   `json:"platform,omitempty"`
+	OSType     *string   `json:"os_type,omitempty"`
+	Initiator  *string   `json:"initiator,omitempty"`
+	WWPNs      *[]string `json:"wwpns,omitempty"`
+	WWNNs      *[]string `json:"wwnns,omitempty"`
+	NQN        *string   `json:"nqn,omitempty"`
+	Multipath  *bool     `json:"multipath,omitempty"`
+	Mountpoint *string   `json:"mountpoint,omitempty"`
+	Mode       *string   `json:"mode,omitempty"`
+}
+
+// VolumeImageUpload describes the upload of a volume to an image: ImageID
+// identifies the image being created, whose data is uploaded asynchronously;
+// VolumeType holds the details of the type of the volume, if it has one.
+type VolumeImageUpload struct {
+	ID              *string                 `json:"id,omitempty"`
+	Status          *string                 `json:"status,omitempty"`
+	Size            *int                    `json:"size,omitempty"`
+	VolumeType      *map[string]interface{} `json:"volume_type,omitempty"`
+	ImageID         *string                 `json:"image_id,omitempty"`
+	ImageName       *string                 `json:"image_name,omitempty"`
+	DiskFormat      *string                 `json:"disk_format,omitempty"`
+	ContainerFormat *string                 `json:"container_format,omitempty"`
+	Description     *string                 `json:"display_description,omitempty"`
+	UpdatedAt       *string                 `json:"updated_at,omitempty"`
+}
+
+/*
+ * MESSAGES
+ */
+
+// UserMessage is a message recorded by the block storage service for the user
+// when an asynchronous operation on a resource fails, e.g. "schedule allocate
+// volume: Could not find any available weighted backend".
+type UserMessage struct {
+	ID           *string `json:"id,omitempty"`
+	UserMessage  *string `json:"user_message,omitempty"`
+	MessageLevel *string `json:"message_level,omitempty"`
+	ResourceType *string `json:"resource_type,omitempty"`
+	ResourceID   *string `json:"resource_uuid,omitempty"`
+	EventID      *string `json:"event_id,omitempty"`
+	RequestID    *string `json:"request_id,omitempty"`
+	CreatedAt    *string `json:"created_at,omitempty"`
+}
@@ -0,0 +1,216 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// VolumeStatus is the status of a volume, as reported by the Block Storage
+// service; see https://docs.openstack.org/api-ref/block-storage/v3/#volumes-volumes.
+type VolumeStatus string
+
+const (
+	// VolumeStatusCreating is the status of volumes being created.
+	VolumeStatusCreating VolumeStatus = "creating"
+	// VolumeStatusAvailable is the status of volumes ready to be attached.
+	VolumeStatusAvailable VolumeStatus = "available"
+	// VolumeStatusReserved is the status of volumes reserved for attachment.
+	VolumeStatusReserved VolumeStatus = "reserved"
+	// VolumeStatusAttaching is the status of volumes being attached.
+	VolumeStatusAttaching VolumeStatus = "attaching"
+	// VolumeStatusDetaching is the status of volumes being detached.
+	VolumeStatusDetaching VolumeStatus = "detaching"
+	// VolumeStatusInUse is the status of volumes attached to a server.
+	VolumeStatusInUse VolumeStatus = "in-use"
+	// VolumeStatusMaintenance is the status of volumes locked for maintenance
+	// (e.g. migration).
+	VolumeStatusMaintenance VolumeStatus = "maintenance"
+	// VolumeStatusDeleting is the status of volumes being deleted.
+	VolumeStatusDeleting VolumeStatus = "deleting"
+	// VolumeStatusError is the status of volumes whose creation failed.
+	VolumeStatusError VolumeStatus = "error"
+	// VolumeStatusErrorDeleting is the status of volumes whose deletion failed.
+	VolumeStatusErrorDeleting VolumeStatus = "error_deleting"
+	// VolumeStatusErrorExtending is the status of volumes whose extension failed.
+	VolumeStatusErrorExtending VolumeStatus = "error_extending"
+	// VolumeStatusBackingUp is the status of volumes being backed up.
+	VolumeStatusBackingUp VolumeStatus = "backing-up"
+	// VolumeStatusRestoringBackup is the status of volumes a backup is being
+	// restored into.
+	VolumeStatusRestoringBackup VolumeStatus = "restoring-backup"
+	// VolumeStatusDownloading is the status of volumes an image is being
+	// downloaded into.
+	VolumeStatusDownloading VolumeStatus = "downloading"
+	// VolumeStatusUploading is the status of volumes being uploaded to an image.
+	VolumeStatusUploading VolumeStatus = "uploading"
+	// VolumeStatusRetyping is the status of volumes changing type.
+	VolumeStatusRetyping VolumeStatus = "retyping"
+	// VolumeStatusExtending is the status of volumes being extended.
+	VolumeStatusExtending VolumeStatus = "extending"
+)
+
+// Ptr returns a pointer to the volume status, to be used in OpenStack API
+// structs.
+func (s VolumeStatus) Ptr() *VolumeStatus {
+	return &s
+}
+
+// MetadataFilter is a set of metadata key/value pairs used to filter a list of
+// resources: it is sent as a single query parameter, as a JSON object (e.g.
+// "metadata={"tier":"gold"}").
+type MetadataFilter map[string]string
+
+// String returns the filter as a JSON object.
+func (f MetadataFilter) String() string {
+	data, _ := json.Marshal(map[string]string(f))
+	return string(data)
+}
+
+/*
+ * LIST VOLUMES
+ */
+
+// ListVolumesOptions provides the options available for filtering the list of
+// volumes: AllProjects (administrators only) lists the volumes of all projects,
+// or of ProjectID; Sort is a comma-separated list of keys, each optionally
+// followed by a direction (e.g. "size:desc,name"); Limit sets the page size,
+// all pages are retrieved anyway.
+type ListVolumesOptions struct {
+	AllProjects      *bool           `parameter:"all_tenants,omitempty" header:"-" json:"-"`
+	ProjectID        *string         `parameter:"project_id,omitempty" header:"-" json:"-"`
+	Name             *string         `parameter:"name,omitempty" header:"-" json:"-"`
+	Status           *VolumeStatus   `parameter:"status,omitempty" header:"-" json:"-"`
+	AvailabilityZone *string         `parameter:"availability_zone,omitempty" header:"-" json:"-"`
+	Bootable         *bool           `parameter:"bootable,omitempty" header:"-" json:"-"`
+	Metadata         *MetadataFilter `parameter:"metadata,omitempty" header:"-" json:"-"`
+	Sort             *string         `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit            *int            `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker           *string         `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListVolumes returns the list of volumes, with their details; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#list-accessible-volumes-with-details
+func (api *BlockStorageV3API) ListVolumes(opts *ListVolumesOptions) (*[]Volume, *Result, error) {
+	volumes := []Volume{}
+	result, err := api.listResources("./volumes/detail", "volumes", opts, collectionLinks,
+		func() interface{} { return &[]Volume{} },
+		func(page interface{}) { volumes = append(volumes, *page.(*[]Volume)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &volumes, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE VOLUME
+ */
+
+// CreateVolumeOptions provides the options available for creating a volume: the
+// volume is empty unless it is created from ImageID, SnapshotID, SourceVolumeID
+// or BackupID (which requires microversion 3.47); Size (in GiB) is required,
+// unless the volume has the same size as its source.
+type CreateVolumeOptions struct {
+	Size               *int               `json:"size,omitempty"`
+	Name               *string            `json:"name,omitempty"`
+	Description        *string            `json:"description,omitempty"`
+	VolumeType         *string            `json:"volume_type,omitempty"`
+	AvailabilityZone   *string            `json:"availability_zone,omitempty"`
+	ImageID            *string            `json:"imageRef,omitempty"`
+	SnapshotID         *string            `json:"snapshot_id,omitempty"`
+	SourceVolumeID     *string            `json:"source_volid,omitempty"`
+	BackupID           *string            `json:"backup_id,omitempty"`
+	ConsistencyGroupID *string            `json:"consistencygroup_id,omitempty"`
+	Metadata           *map[string]string `json:"metadata,omitempty"`
+}
+
+// CreateVolume creates a new volume; the volume is created asynchronously, in
+// VolumeStatusCreating and then VolumeStatusAvailable when done; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#create-a-volume
+func (api *BlockStorageV3API) CreateVolume(opts *CreateVolumeOptions) (*Volume, *Result, error) {
+	volume := &Volume{}
+	result, err := api.createResource("./volumes", "volume", opts, volume, 200, 202)
+	if result != nil && result.Code == 202 {
+		return volume, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE VOLUME
+ */
+
+// RetrieveVolume retrieves the details of the volume identified by the given
+// id; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#show-a-volume-s-details
+func (api *BlockStorageV3API) RetrieveVolume(volumeid string) (*Volume, *Result, error) {
+	volume := &Volume{}
+	result, err := api.retrieveResource("./volumes/{id}", volumeid, "volume", volume)
+	if result != nil && result.Code == 200 {
+		return volume, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE VOLUME
+ */
+
+// UpdateVolumeOptions provides the options available for updating a volume;
+// only the given attributes are modified, the given Metadata replaces the
+// existing one.
+type UpdateVolumeOptions struct {
+	Name        *string            `json:"name,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	Metadata    *map[string]string `json:"metadata,omitempty"`
+}
+
+// UpdateVolume updates the volume identified by the given id; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#update-a-volume
+func (api *BlockStorageV3API) UpdateVolume(volumeid string, opts *UpdateVolumeOptions) (*Volume, *Result, error) {
+	volume := &Volume{}
+	result, err := api.updateResource("./volumes/{id}", volumeid, "volume", opts, volume)
+	if result != nil && result.Code == 200 {
+		return volume, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE VOLUME
+ */
+
+// DeleteVolumeOptions provides the options available for deleting a volume:
+// Cascade deletes its snapshots as well; Force (administrators only) deletes it
+// regardless of its status.
+type DeleteVolumeOptions struct {
+	Cascade *bool `parameter:"cascade,omitempty" header:"-" json:"-"`
+	Force   *bool `parameter:"force,omitempty" header:"-" json:"-"`
+}
+
+// DeleteVolume deletes the volume identified by the given id; the volume is
+// deleted asynchronously; it must not be attached nor have snapshots, unless
+// opts.Cascade is set; opts can be nil; see also
+// https://developer.openstack.org/api-ref/block-storage/v3/#delete-a-volume
+func (api *BlockStorageV3API) DeleteVolume(volumeid string, opts *DeleteVolumeOptions) (bool, *Result, error) {
+	input := &struct {
+		VolumeID string `parameter:"-" header:"-" variable:"volumeid" json:"-"`
+		*DeleteVolumeOptions
+	}{
+		VolumeID:            volumeid,
+		DeleteVolumeOptions: opts,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./volumes/{volumeid}", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
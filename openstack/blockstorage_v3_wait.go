@@ -0,0 +1,125 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// VolumeStatusDeleted is a pseudo-status that can be passed to
+// WaitForVolumeStatus to wait until the volume no longer exists.
+const VolumeStatusDeleted VolumeStatus = "deleted"
+
+// BlockStorageStatusError is returned when a block storage resource (e.g. a
+// volume) goes into an error status while waiting for it to reach another one;
+// Message is the reason of the failure, as recorded by the service in the user
+// messages (which requires microversion 3.3), if available.
+type BlockStorageStatusError struct {
+	ResourceType string
+	ResourceID   string
+	Status       string
+	Message      string
+}
+
+// Error returns a description of the failure.
+func (e *BlockStorageStatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s %q is in %s status: %s", e.ResourceType, e.ResourceID, e.Status, e.Message)
+	}
+	return fmt.Sprintf("%s %q is in %s status", e.ResourceType, e.ResourceID, e.Status)
+}
+
+/*
+ * WAIT FOR VOLUME STATUS
+ */
+
+// WaitForVolumeStatus waits until the volume reaches the given status (e.g.
+// VolumeStatusAvailable, VolumeStatusInUse), polling it with the given backoff
+// policy; the wait fails with a *BlockStorageStatusError if the volume goes
+// into an error status (unless that is the expected status), if it disappears
+// (unless the expected status is VolumeStatusDeleted, in which case nil is
+// returned) or if the context is done.
+func (api *BlockStorageV3API) WaitForVolumeStatus(ctx context.Context, volumeid string, status VolumeStatus, backoff Backoff) (*Volume, error) {
+	var volume *Volume
+	err := api.waitForStatus(ctx, "volume", volumeid, string(status), backoff, func() (*string, *Result, error) {
+		var result *Result
+		var err error
+		volume, result, err = api.RetrieveVolume(volumeid)
+		if volume == nil {
+			return nil, result, err
+		}
+		return (*string)(volume.Status), result, err
+	})
+	return volume, err
+}
+
+// waitForStatus waits until the resource of the given type and id, whose
+// status is returned by retrieve, reaches the given status; all the block
+// storage resources (volumes, snapshots, backups) share the same conventions:
+// error statuses start with "error", and deleted resources are not found.
+func (api *BlockStorageV3API) waitForStatus(ctx context.Context, resourcetype string, resourceid string, status string, backoff Backoff, retrieve func() (*string, *Result, error)) error {
+	return WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		current, result, err := retrieve()
+		if result != nil && result.Code == http.StatusNotFound {
+			if status == string(VolumeStatusDeleted) {
+				return true, nil
+			}
+			return false, fmt.Errorf("%s %q not found", resourcetype, resourceid)
+		}
+		if err != nil {
+			return false, err
+		}
+		if current == nil {
+			return false, fmt.Errorf("error retrieving %s %q: %v", resourcetype, resourceid, result)
+		}
+		log.Debugf("%s %q is %s, waiting for %s", resourcetype, resourceid, *current, status)
+		if *current == status {
+			return true, nil
+		}
+		if strings.HasPrefix(*current, "error") {
+			return false, &BlockStorageStatusError{
+				ResourceType: resourcetype,
+				ResourceID:   resourceid,
+				Status:       *current,
+				Message:      api.latestUserMessage(resourceid),
+			}
+		}
+		return false, nil
+	})
+}
+
+// latestUserMessage returns the latest user message about the resource with the
+// given id, which usually explains why an operation on it failed, or the empty
+// string if there is none or messages are not available (before microversion
+// 3.3).
+func (api *BlockStorageV3API) latestUserMessage(resourceid string) string {
+	if api.requireMicroversion("3.3", "user messages") != nil {
+		return ""
+	}
+	input := &struct {
+		ResourceID string `parameter:"resource_uuid" header:"-" json:"-"`
+		Sort       string `parameter:"sort" header:"-" json:"-"`
+		Limit      int    `parameter:"limit" header:"-" json:"-"`
+	}{
+		ResourceID: resourceid,
+		Sort:       "created_at:desc",
+		Limit:      1,
+	}
+	output := &struct {
+		Messages *[]UserMessage `header:"-" json:"messages,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./messages", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if err != nil || output.Messages == nil || len(*output.Messages) == 0 {
+		return ""
+	}
+	return stringValue((*output.Messages)[0].UserMessage)
+}
@@ -0,0 +1,51 @@
+package openstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWaitForVolumeStatus(t *testing.T) {
+	statuses := []string{"creating", "creating", "error"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v3/p1/volumes/v1":
+			status := statuses[0]
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			w.Write([]byte(`{"volume": {"id": "v1", "status": "` + status + `"}}`))
+		case "/v3/p1/messages":
+			if r.URL.Query().Get("resource_uuid") != "v1" {
+				t.Errorf("Wait.TestWaitForVolumeStatus: unexpected messages query: %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"messages": [{"resource_uuid": "v1", "user_message": "no valid backend"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDefaultClient(server.URL + "/v3/p1")
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+	api := (&BlockStorageV3API{API: client.Authenticator.Identity.API}).WithMicroversion("3.3")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := api.WaitForVolumeStatus(ctx, "v1", VolumeStatusAvailable, Backoff{Initial: time.Millisecond})
+	failure, ok := err.(*BlockStorageStatusError)
+	if !ok {
+		t.Fatalf("Wait.TestWaitForVolumeStatus: expected status error, got %v", err)
+	}
+	if failure.Status != "error" || failure.Message != "no valid backend" {
+		t.Errorf("Wait.TestWaitForVolumeStatus: unexpected error: %v", failure)
+	}
+
+	if _, err := api.WaitForVolumeStatus(ctx, "v2", VolumeStatusDeleted, Backoff{Initial: time.Millisecond}); err != nil {
+		t.Errorf("Wait.TestWaitForVolumeStatus: expected deleted volume, got %v", err)
+	}
+}
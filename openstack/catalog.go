@@ -0,0 +1,166 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CatalogDiff reports the differences between two catalogs, e.g. before and
+// after re-authentication, or as seen from two different regions.
+type CatalogDiff struct {
+	AddedServices   []Service     `json:"added_services,omitempty"`
+	RemovedServices []Service     `json:"removed_services,omitempty"`
+	ChangedServices []ServiceDiff `json:"changed_services,omitempty"`
+}
+
+// ServiceDiff reports the differences in the set of endpoints of a service that
+// is present in both catalogs.
+type ServiceDiff struct {
+	Before           Service          `json:"before"`
+	After            Service          `json:"after"`
+	AddedEndpoints   []Endpoint       `json:"added_endpoints,omitempty"`
+	RemovedEndpoints []Endpoint       `json:"removed_endpoints,omitempty"`
+	ChangedEndpoints []EndpointChange `json:"changed_endpoints,omitempty"`
+}
+
+// EndpointChange reports an endpoint whose attributes (e.g. its URL) differ
+// between the two catalogs.
+type EndpointChange struct {
+	Before Endpoint `json:"before"`
+	After  Endpoint `json:"after"`
+}
+
+// IsEmpty returns whether the two catalogs are equivalent.
+func (d *CatalogDiff) IsEmpty() bool {
+	return len(d.AddedServices) == 0 && len(d.RemovedServices) == 0 && len(d.ChangedServices) == 0
+}
+
+// DiffCatalogs compares two catalogs and reports the services and endpoints that
+// have been added, removed or changed going from "before" to "after"; services
+// are matched by ID (or by type and name if the ID is not available), endpoints
+// by ID (or by interface and region); either catalog can be nil.
+func DiffCatalogs(before, after *[]Service) *CatalogDiff {
+	diff := &CatalogDiff{}
+
+	previousIndex := indexServices(before)
+	currentIndex := indexServices(after)
+
+	for _, key := range serviceKeys(currentIndex) {
+		service := currentIndex[key]
+		if previous, ok := previousIndex[key]; ok {
+			if changes := diffService(previous, service); changes != nil {
+				diff.ChangedServices = append(diff.ChangedServices, *changes)
+			}
+		} else {
+			diff.AddedServices = append(diff.AddedServices, service)
+		}
+	}
+	for _, key := range serviceKeys(previousIndex) {
+		if _, ok := currentIndex[key]; !ok {
+			diff.RemovedServices = append(diff.RemovedServices, previousIndex[key])
+		}
+	}
+	return diff
+}
+
+// diffService compares the endpoints of two versions of the same service; it
+// returns nil if there are no differences.
+func diffService(before, after Service) *ServiceDiff {
+	diff := &ServiceDiff{
+		Before: before,
+		After:  after,
+	}
+
+	previousIndex := indexEndpoints(before.Endpoints)
+	currentIndex := indexEndpoints(after.Endpoints)
+
+	for _, key := range endpointKeys(currentIndex) {
+		endpoint := currentIndex[key]
+		if previous, ok := previousIndex[key]; ok {
+			if !sameEndpoint(previous, endpoint) {
+				diff.ChangedEndpoints = append(diff.ChangedEndpoints, EndpointChange{Before: previous, After: endpoint})
+			}
+		} else {
+			diff.AddedEndpoints = append(diff.AddedEndpoints, endpoint)
+		}
+	}
+	for _, key := range endpointKeys(previousIndex) {
+		if _, ok := currentIndex[key]; !ok {
+			diff.RemovedEndpoints = append(diff.RemovedEndpoints, previousIndex[key])
+		}
+	}
+
+	if len(diff.AddedEndpoints) == 0 && len(diff.RemovedEndpoints) == 0 && len(diff.ChangedEndpoints) == 0 &&
+		stringValue(before.Name) == stringValue(after.Name) && stringValue(before.Type) == stringValue(after.Type) {
+		return nil
+	}
+	return diff
+}
+
+// sameEndpoint compares all the attributes of two endpoints.
+func sameEndpoint(a, b Endpoint) bool {
+	return stringValue(a.ID) == stringValue(b.ID) &&
+		interfaceValue(a.Interface) == interfaceValue(b.Interface) &&
+		stringValue(a.Region) == stringValue(b.Region) &&
+		stringValue(a.RegionID) == stringValue(b.RegionID) &&
+		stringValue(a.URL) == stringValue(b.URL)
+}
+
+// indexServices builds a map of the services in the catalog by their identity.
+func indexServices(catalog *[]Service) map[string]Service {
+	index := map[string]Service{}
+	if catalog != nil {
+		for _, service := range *catalog {
+			key := stringValue(service.ID)
+			if key == "" {
+				key = fmt.Sprintf("%s/%s", stringValue(service.Type), stringValue(service.Name))
+			}
+			index[key] = service
+		}
+	}
+	return index
+}
+
+// indexEndpoints builds a map of the endpoints by their identity.
+func indexEndpoints(endpoints *[]Endpoint) map[string]Endpoint {
+	index := map[string]Endpoint{}
+	if endpoints != nil {
+		for _, endpoint := range *endpoints {
+			key := stringValue(endpoint.ID)
+			if key == "" {
+				region := stringValue(endpoint.RegionID)
+				if region == "" {
+					region = stringValue(endpoint.Region)
+				}
+				key = fmt.Sprintf("%s/%s", interfaceValue(endpoint.Interface), region)
+			}
+			index[key] = endpoint
+		}
+	}
+	return index
+}
+
+// serviceKeys returns the keys of the services index in lexicographic order,
+// so that diffs are reported in a stable order.
+func serviceKeys(index map[string]Service) []string {
+	keys := []string{}
+	for key := range index {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// endpointKeys returns the keys of the endpoints index in lexicographic order.
+func endpointKeys(index map[string]Endpoint) []string {
+	keys := []string{}
+	for key := range index {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
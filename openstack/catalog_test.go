@@ -0,0 +1,71 @@
+package openstack
+
+import (
+	"testing"
+)
+
+func TestDiffCatalogs(t *testing.T) {
+	before := &[]Service{
+		{
+			ID:   String("s1"),
+			Type: String("identity"),
+			Name: String("keystone"),
+			Endpoints: &[]Endpoint{
+				{ID: String("e1"), Interface: InterfacePublic.Ptr(), Region: String("RegionOne"), URL: String("http://old/identity")},
+				{ID: String("e2"), Interface: InterfaceAdmin.Ptr(), Region: String("RegionOne"), URL: String("http://admin/identity")},
+			},
+		},
+		{
+			ID:   String("s2"),
+			Type: String("image"),
+			Name: String("glance"),
+		},
+	}
+	after := &[]Service{
+		{
+			ID:   String("s1"),
+			Type: String("identity"),
+			Name: String("keystone"),
+			Endpoints: &[]Endpoint{
+				{ID: String("e1"), Interface: InterfacePublic.Ptr(), Region: String("RegionOne"), URL: String("http://new/identity")},
+				{ID: String("e3"), Interface: InterfaceInternal.Ptr(), Region: String("RegionOne"), URL: String("http://internal/identity")},
+			},
+		},
+		{
+			ID:   String("s3"),
+			Type: String("compute"),
+			Name: String("nova"),
+		},
+	}
+
+	diff := DiffCatalogs(before, after)
+	if diff.IsEmpty() {
+		t.Fatalf("Catalog.TestDiffCatalogs: expected differences")
+	}
+	if len(diff.AddedServices) != 1 || *diff.AddedServices[0].ID != "s3" {
+		t.Errorf("Catalog.TestDiffCatalogs: expected service s3 to be added")
+	}
+	if len(diff.RemovedServices) != 1 || *diff.RemovedServices[0].ID != "s2" {
+		t.Errorf("Catalog.TestDiffCatalogs: expected service s2 to be removed")
+	}
+	if len(diff.ChangedServices) != 1 {
+		t.Fatalf("Catalog.TestDiffCatalogs: expected 1 changed service, got %d", len(diff.ChangedServices))
+	}
+	changes := diff.ChangedServices[0]
+	if len(changes.AddedEndpoints) != 1 || *changes.AddedEndpoints[0].ID != "e3" {
+		t.Errorf("Catalog.TestDiffCatalogs: expected endpoint e3 to be added")
+	}
+	if len(changes.RemovedEndpoints) != 1 || *changes.RemovedEndpoints[0].ID != "e2" {
+		t.Errorf("Catalog.TestDiffCatalogs: expected endpoint e2 to be removed")
+	}
+	if len(changes.ChangedEndpoints) != 1 || *changes.ChangedEndpoints[0].After.URL != "http://new/identity" {
+		t.Errorf("Catalog.TestDiffCatalogs: expected endpoint e1 to be changed")
+	}
+
+	if !DiffCatalogs(before, before).IsEmpty() {
+		t.Errorf("Catalog.TestDiffCatalogs: a catalog should not differ from itself")
+	}
+	if len(DiffCatalogs(nil, after).AddedServices) != 2 {
+		t.Errorf("Catalog.TestDiffCatalogs: all services should be added to an empty catalog")
+	}
+}
@@ -45,6 +45,13 @@ type Client struct {
 	// the set of services and endpoints in the catalog.
 	Profile *Profile
 
+	// Region and Interface, when set, restrict the endpoints taken from the
+	// catalog to those in the given region (e.g. "RegionOne") and with the given
+	// interface (e.g. "public"); this is needed on multi-region clouds, where
+	// each service has an endpoint per region.
+	Region    string
+	Interface InterfaceType
+
 	// This is the set of available services; it is populated as soon as the
 	// client performs a logon to the identity service and retrieves the catalog.
 	Services map[string]interface{}
@@ -149,32 +156,16 @@ func (c *Client) Connect(opts *LoginOptions) error {
 	for _, service := range *c.Authenticator.GetCatalog() {
 		// log.Debugf("checking service %q (%q)\n", *service.Type, *service.Name)
 
-		//outer:
 		for _, endpoint := range *service.Endpoints {
-			// log.Debugf("checking endpoint, interface %q, region %q, URL %q\n", *endpoint.Interface, *endpoint.Region, *endpoint.URL)
-			// inner:
-			if c.Profile != nil {
-				// look for a match between a service and a filter before proceeding
-				log.Debugln("applying filters to catalog")
-
-			inner:
-				for _, filter := range c.Profile.Filters {
-					// log.Debugf("does filter type %q, interface %q, region %q, URL %q match?\n", *filter.Type, *endpoint.Interface, *endpoint.Region, *endpoint.URL)
-					if *service.Type != *filter.Type {
-						continue inner
-					}
-					if *endpoint.Interface != *filter.Interface {
-						continue inner
-					}
-					if *endpoint.Region != *filter.Region {
-						continue inner
-					}
-					if *endpoint.URL != *filter.EndpointURL {
-						continue inner
-					}
-
-					log.Debugf("service %q (type: %q, interface %q, region %q, URL %q) matches filter, adding to catalog\n", *service.Name, *service.Type, *endpoint.Interface, *endpoint.Region, *endpoint.URL)
-				}
+			if c.Region != "" && (endpoint.Region == nil || *endpoint.Region != c.Region) {
+				continue
+			}
+			if c.Interface != "" && (endpoint.Interface == nil || *endpoint.Interface != c.Interface) {
+				continue
+			}
+			if c.Profile != nil && !c.Profile.Matches(service, endpoint) {
+				log.Debugf("service %q (interface %q, region %q, URL %q) does not match any filter in profile, skipping", stringValue(service.Type), interfaceValue(endpoint.Interface), stringValue(endpoint.Region), stringValue(endpoint.URL))
+				continue
 			}
 
 			switch *service.Type {
@@ -185,6 +176,151 @@ func (c *Client) Connect(opts *LoginOptions) error {
 						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
 					},
 				}
+			case "compute":
+				c.Services[*service.Type] = ComputeV2API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "network":
+				c.Services[*service.Type] = NetworkV2API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "image":
+				c.Services[*service.Type] = ImageV2API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "volumev3", "block-storage":
+				c.Services["block-storage"] = BlockStorageV3API{
+					API: API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "object-store":
+				c.Services[*service.Type] = ObjectStoreV1API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "orchestration":
+				projectid := ""
+				if token := c.Authenticator.GetToken(); token != nil && token.Project != nil && token.Project.ID != nil {
+					projectid = *token.Project.ID
+				}
+				c.Services[*service.Type] = OrchestrationV1API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(orchestrationURL(*endpoint.URL, projectid))).UserAgent(c.UserAgent),
+					},
+				}
+			case "key-manager":
+				c.Services[*service.Type] = KeyManagerV1API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "load-balancer":
+				c.Services[*service.Type] = LoadBalancerV2API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "dns":
+				c.Services[*service.Type] = DNSV2API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "baremetal":
+				c.Services[*service.Type] = BareMetalV1API{
+					API: API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "container-infra":
+				c.Services[*service.Type] = ContainerInfraV1API{
+					API: API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "sharev2", "shared-file-system":
+				projectid := ""
+				if token := c.Authenticator.GetToken(); token != nil && token.Project != nil && token.Project.ID != nil {
+					projectid = *token.Project.ID
+				}
+				c.Services["shared-file-system"] = SharedFileSystemV2API{
+					API: API{
+						client:  c,
+						builder: request.New(NormaliseURL(sharedFileSystemURL(*endpoint.URL, projectid))).UserAgent(c.UserAgent),
+					},
+				}
+			case "alarming":
+				c.Services[*service.Type] = AlarmingV2API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "metric":
+				c.Services[*service.Type] = MetricV1API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "container":
+				c.Services[*service.Type] = ContainerV1API{
+					API: API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "workflowv2":
+				c.Services[*service.Type] = WorkflowV2API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "clustering":
+				c.Services[*service.Type] = ClusteringV1API{
+					API: API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
+			case "database":
+				projectid := ""
+				if token := c.Authenticator.GetToken(); token != nil && token.Project != nil && token.Project.ID != nil {
+					projectid = *token.Project.ID
+				}
+				c.Services[*service.Type] = DatabaseV1API{
+					API{
+						client:  c,
+						builder: request.New(NormaliseURL(databaseURL(*endpoint.URL, projectid))).UserAgent(c.UserAgent),
+					},
+				}
+			case "accelerator":
+				c.Services[*service.Type] = AcceleratorV2API{
+					API: API{
+						client:  c,
+						builder: request.New(NormaliseURL(*endpoint.URL)).UserAgent(c.UserAgent),
+					},
+				}
 			default:
 				log.Debugf("unsupported service %q (type: %q)\n", *service.Name, *service.Type)
 			}
@@ -220,3 +356,215 @@ func (c *Client) IdentityV3() *IdentityV3API {
 	}
 	return nil
 }
+
+// ComputeV2 returns a ComputeV2API service reference.
+func (c *Client) ComputeV2() *ComputeV2API {
+	for k, v := range c.Services {
+		if k == "compute" {
+			api := v.(ComputeV2API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// NetworkV2 returns a NetworkV2API service reference.
+func (c *Client) NetworkV2() *NetworkV2API {
+	for k, v := range c.Services {
+		if k == "network" {
+			api := v.(NetworkV2API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// ImageV2 returns an ImageV2API service reference.
+func (c *Client) ImageV2() *ImageV2API {
+	for k, v := range c.Services {
+		if k == "image" {
+			api := v.(ImageV2API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// BlockStorageV3 returns a BlockStorageV3API service reference; the service is
+// registered in the catalog either as "volumev3" or as "block-storage".
+func (c *Client) BlockStorageV3() *BlockStorageV3API {
+	for k, v := range c.Services {
+		if k == "block-storage" {
+			api := v.(BlockStorageV3API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// ObjectStoreV1 returns an ObjectStoreV1API service reference.
+func (c *Client) ObjectStoreV1() *ObjectStoreV1API {
+	for k, v := range c.Services {
+		if k == "object-store" {
+			api := v.(ObjectStoreV1API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// OrchestrationV1 returns an OrchestrationV1API service reference.
+func (c *Client) OrchestrationV1() *OrchestrationV1API {
+	for k, v := range c.Services {
+		if k == "orchestration" {
+			api := v.(OrchestrationV1API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// KeyManagerV1 returns a KeyManagerV1API service reference.
+func (c *Client) KeyManagerV1() *KeyManagerV1API {
+	for k, v := range c.Services {
+		if k == "key-manager" {
+			api := v.(KeyManagerV1API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// LoadBalancerV2 returns a LoadBalancerV2API service reference.
+func (c *Client) LoadBalancerV2() *LoadBalancerV2API {
+	for k, v := range c.Services {
+		if k == "load-balancer" {
+			api := v.(LoadBalancerV2API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// DNSV2 returns a DNSV2API service reference.
+func (c *Client) DNSV2() *DNSV2API {
+	for k, v := range c.Services {
+		if k == "dns" {
+			api := v.(DNSV2API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// BareMetalV1 returns a BareMetalV1API service reference.
+func (c *Client) BareMetalV1() *BareMetalV1API {
+	for k, v := range c.Services {
+		if k == "baremetal" {
+			api := v.(BareMetalV1API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// ContainerInfraV1 returns a ContainerInfraV1API service reference.
+func (c *Client) ContainerInfraV1() *ContainerInfraV1API {
+	for k, v := range c.Services {
+		if k == "container-infra" {
+			api := v.(ContainerInfraV1API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// SharedFileSystemV2 returns a SharedFileSystemV2API service reference; the
+// service is registered in the catalog either as "sharev2" or as
+// "shared-file-system".
+func (c *Client) SharedFileSystemV2() *SharedFileSystemV2API {
+	for k, v := range c.Services {
+		if k == "shared-file-system" {
+			api := v.(SharedFileSystemV2API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// AlarmingV2 returns an AlarmingV2API service reference.
+func (c *Client) AlarmingV2() *AlarmingV2API {
+	for k, v := range c.Services {
+		if k == "alarming" {
+			api := v.(AlarmingV2API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// MetricV1 returns a MetricV1API service reference.
+func (c *Client) MetricV1() *MetricV1API {
+	for k, v := range c.Services {
+		if k == "metric" {
+			api := v.(MetricV1API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// ContainerV1 returns a ContainerV1API service reference.
+func (c *Client) ContainerV1() *ContainerV1API {
+	for k, v := range c.Services {
+		if k == "container" {
+			api := v.(ContainerV1API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// WorkflowV2 returns a WorkflowV2API service reference.
+func (c *Client) WorkflowV2() *WorkflowV2API {
+	for k, v := range c.Services {
+		if k == "workflowv2" {
+			api := v.(WorkflowV2API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// ClusteringV1 returns a ClusteringV1API service reference.
+func (c *Client) ClusteringV1() *ClusteringV1API {
+	for k, v := range c.Services {
+		if k == "clustering" {
+			api := v.(ClusteringV1API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// DatabaseV1 returns a DatabaseV1API service reference.
+func (c *Client) DatabaseV1() *DatabaseV1API {
+	for k, v := range c.Services {
+		if k == "database" {
+			api := v.(DatabaseV1API)
+			return &api
+		}
+	}
+	return nil
+}
+
+// AcceleratorV2 returns an AcceleratorV2API service reference.
+func (c *Client) AcceleratorV2() *AcceleratorV2API {
+	for k, v := range c.Services {
+		if k == "accelerator" {
+			api := v.(AcceleratorV2API)
+			return &api
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+// ClusteringV1API represents the Clustering API ver. 1 (Senlin), providing
+// support for the management of profiles (the specification of the nodes,
+// e.g. of the servers to create), of clusters of homogeneous nodes built from
+// them, of their scaling and of the receivers (webhooks) triggering it, e.g.
+// from Aodh alarms (see AlarmingV2API).
+// Senlin works asynchronously: most changes to clusters and nodes are carried
+// out by actions (see WaitForClusteringAction); its types are prefixed with
+// Clustering to tell them from those of other services (e.g. the Magnum
+// clusters).
+// Senlin uses microversions: by default requests are served with the base
+// version 1.0, a later one can be requested for all calls through
+// WithMicroversion, or the latest one supported by the server through
+// NegotiateMicroversion.
+// See https://developer.openstack.org/api-ref/clustering/
+type ClusteringV1API struct {
+	API
+}
+
+// clusteringMicroversion is the header through which Senlin is told the
+// microversion requested.
+var clusteringMicroversion = microversionHeader{name: "OpenStack-API-Version", service: "clustering", base: "1.0"}
+
+// WithMicroversion returns a copy of the API that requests the given
+// microversion (e.g. "1.10") in all its calls, through the
+// OpenStack-API-Version header; the receiver is left unchanged.
+func (api ClusteringV1API) WithMicroversion(microversion string) *ClusteringV1API {
+	return &ClusteringV1API{API: api.withMicroversion(clusteringMicroversion, microversion)}
+}
+
+// NegotiateMicroversion returns a copy of the API that requests, in all its
+// calls, the microversion pinned for its endpoint in the client profile, or
+// the latest one supported by the server, as advertised in its version
+// document (see SelectMicroversion); the receiver is left unchanged.
+func (api ClusteringV1API) NegotiateMicroversion() (*ClusteringV1API, *Result, error) {
+	microversion, result, err := api.negotiateMicroversion()
+	if err != nil {
+		return nil, result, err
+	}
+	return api.WithMicroversion(microversion), result, nil
+}
+
+// Microversion returns the microversion requested in all calls, or "1.0" if
+// none was set.
+func (api *ClusteringV1API) Microversion() string {
+	return api.requestedMicroversion(clusteringMicroversion)
+}
@@ -0,0 +1,319 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Cluster and node status values.
+const (
+	ClusteringStatusInit       string = "INIT"
+	ClusteringStatusActive     string = "ACTIVE"
+	ClusteringStatusCreating   string = "CREATING"
+	ClusteringStatusUpdating   string = "UPDATING"
+	ClusteringStatusResizing   string = "RESIZING"
+	ClusteringStatusDeleting   string = "DELETING"
+	ClusteringStatusRecovering string = "RECOVERING"
+	ClusteringStatusWarning    string = "WARNING"
+	ClusteringStatusCritical   string = "CRITICAL"
+	ClusteringStatusError      string = "ERROR"
+)
+
+// Action status values.
+const (
+	ClusteringActionStatusInit      string = "INIT"
+	ClusteringActionStatusWaiting   string = "WAITING"
+	ClusteringActionStatusReady     string = "READY"
+	ClusteringActionStatusRunning   string = "RUNNING"
+	ClusteringActionStatusSuspended string = "SUSPENDED"
+	ClusteringActionStatusSucceeded string = "SUCCEEDED"
+	ClusteringActionStatusFailed    string = "FAILED"
+	ClusteringActionStatusCancelled string = "CANCELLED"
+)
+
+// Adjustment types of the cluster resize action.
+const (
+	ClusteringAdjustmentExactCapacity      string = "EXACT_CAPACITY"
+	ClusteringAdjustmentChangeInCapacity   string = "CHANGE_IN_CAPACITY"
+	ClusteringAdjustmentChangeInPercentage string = "CHANGE_IN_PERCENTAGE"
+)
+
+/*
+ * LIST CLUSTERS
+ */
+
+// ListClusteringClustersOptions provides the options available for listing
+// the clusters; Limit sets the page size, all pages are retrieved anyway; Sort
+// is a list of "key:direction" pairs (e.g. "name:asc").
+type ListClusteringClustersOptions struct {
+	Name          *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Status        *string `parameter:"status,omitempty" header:"-" json:"-"`
+	GlobalProject *bool   `parameter:"global_project,omitempty" header:"-" json:"-"`
+	Sort          *string `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit         *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker        *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListClusteringClusters returns the list of clusters of the current project;
+// opts can be nil; see also
+// https://developer.openstack.org/api-ref/clustering/#list-clusters
+func (api *ClusteringV1API) ListClusteringClusters(opts *ListClusteringClustersOptions) (*[]ClusteringCluster, *Result, error) {
+	if opts == nil {
+		opts = &ListClusteringClustersOptions{}
+	}
+	input := *opts
+
+	clusters := []ClusteringCluster{}
+	for {
+		page := []ClusteringCluster{}
+		result, err := api.Invoke(http.MethodGet, "./v1/clusters", true, StatusCodeIn(200), &input, &envelope{Name: "clusters", Body: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		clusters = append(clusters, page...)
+		if input.Limit == nil || len(page) < *input.Limit || len(page) == 0 {
+			return &clusters, result, err
+		}
+		// the next page starts after the last cluster of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE CLUSTER
+ */
+
+// CreateClusteringClusterOptions provides the options available for creating a
+// cluster: ProfileID (or name) is required; DesiredCapacity nodes are created
+// along with the cluster; MaxSize is -1 for no limit; Timeout is in seconds.
+type CreateClusteringClusterOptions struct {
+	Name            string                  `json:"name"`
+	ProfileID       string                  `json:"profile_id"`
+	DesiredCapacity *int                    `json:"desired_capacity,omitempty"`
+	MinSize         *int                    `json:"min_size,omitempty"`
+	MaxSize         *int                    `json:"max_size,omitempty"`
+	Timeout         *int                    `json:"timeout,omitempty"`
+	Metadata        *map[string]string      `json:"metadata,omitempty"`
+	Config          *map[string]interface{} `json:"config,omitempty"`
+}
+
+// CreateClusteringCluster creates a cluster; the creation is asynchronous: the
+// cluster is CREATING until it is ACTIVE (see WaitForClusteringClusterStatus);
+// see also
+// https://developer.openstack.org/api-ref/clustering/#create-cluster
+func (api *ClusteringV1API) CreateClusteringCluster(opts *CreateClusteringClusterOptions) (*ClusteringCluster, *Result, error) {
+	cluster := &ClusteringCluster{}
+	result, err := api.createResource("./v1/clusters", "cluster", opts, cluster, 201, 202)
+	if result != nil && result.OK {
+		return cluster, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE CLUSTER
+ */
+
+// RetrieveClusteringCluster retrieves the cluster with the given ID or name;
+// see also
+// https://developer.openstack.org/api-ref/clustering/#show-cluster-details
+func (api *ClusteringV1API) RetrieveClusteringCluster(cluster string) (*ClusteringCluster, *Result, error) {
+	output := &ClusteringCluster{}
+	result, err := api.retrieveResource("./v1/clusters/{id}", cluster, "cluster", output)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE CLUSTER
+ */
+
+// UpdateClusteringClusterOptions provides the options available for updating a
+// cluster; setting ProfileID updates all its nodes to the new profile (e.g. to
+// a new image), unless ProfileOnly is set, in which case only the nodes
+// created afterwards use it.
+type UpdateClusteringClusterOptions struct {
+	Name        *string                 `json:"name,omitempty"`
+	ProfileID   *string                 `json:"profile_id,omitempty"`
+	ProfileOnly *bool                   `json:"profile_only,omitempty"`
+	Timeout     *int                    `json:"timeout,omitempty"`
+	Metadata    *map[string]string      `json:"metadata,omitempty"`
+	Config      *map[string]interface{} `json:"config,omitempty"`
+}
+
+// UpdateClusteringCluster updates the cluster with the given ID or name; the
+// update is asynchronous; see also
+// https://developer.openstack.org/api-ref/clustering/#update-cluster
+func (api *ClusteringV1API) UpdateClusteringCluster(cluster string, opts *UpdateClusteringClusterOptions) (*ClusteringCluster, *Result, error) {
+	output := &ClusteringCluster{}
+	result, err := api.patchResource("./v1/clusters/{id}", cluster, "cluster", opts, output, 200, 202)
+	if result != nil && result.OK {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE CLUSTER
+ */
+
+// DeleteClusteringCluster deletes the cluster with the given ID or name, along
+// with its nodes; the deletion is asynchronous; see also
+// https://developer.openstack.org/api-ref/clustering/#delete-cluster
+func (api *ClusteringV1API) DeleteClusteringCluster(cluster string) (bool, *Result, error) {
+	return api.deleteResource("./v1/clusters/{id}", cluster, 202, 204)
+}
+
+/*
+ * CLUSTER ACTIONS
+ */
+
+// ScaleOutClusteringCluster adds the given number of nodes to the cluster with
+// the given ID or name (0 to let the scaling policies of the cluster decide,
+// one node if there are none) and returns the ID of the action; see also
+// https://developer.openstack.org/api-ref/clustering/#scale-out-a-cluster
+func (api *ClusteringV1API) ScaleOutClusteringCluster(cluster string, count int) (*string, *Result, error) {
+	return api.clusterAction(cluster, "scale_out", scaleCount(count))
+}
+
+// ScaleInClusteringCluster removes the given number of nodes from the cluster
+// with the given ID or name (0 to let the scaling policies of the cluster
+// decide, one node if there are none) and returns the ID of the action; see
+// also
+// https://developer.openstack.org/api-ref/clustering/#scale-in-a-cluster
+func (api *ClusteringV1API) ScaleInClusteringCluster(cluster string, count int) (*string, *Result, error) {
+	return api.clusterAction(cluster, "scale_in", scaleCount(count))
+}
+
+// scaleCount returns the body of the scaling actions for the given count.
+func scaleCount(count int) map[string]interface{} {
+	body := map[string]interface{}{}
+	if count > 0 {
+		body["count"] = count
+	}
+	return body
+}
+
+// ResizeClusteringClusterOptions provides the options available for resizing a
+// cluster: Number is the new size, or the change in size, as per
+// AdjustmentType (one of the ClusteringAdjustment* constants); MinStep is the
+// minimum number of nodes to add or remove on percentage changes; if Strict is
+// set, the resize fails instead of being limited to the cluster size bounds,
+// which can be changed at the same time through MinSize and MaxSize.
+type ResizeClusteringClusterOptions struct {
+	AdjustmentType *string  `json:"adjustment_type,omitempty"`
+	Number         *float64 `json:"number,omitempty"`
+	MinSize        *int     `json:"min_size,omitempty"`
+	MaxSize        *int     `json:"max_size,omitempty"`
+	MinStep        *int     `json:"min_step,omitempty"`
+	Strict         *bool    `json:"strict,omitempty"`
+}
+
+// ResizeClusteringCluster resizes the cluster with the given ID or name and
+// returns the ID of the action; see also
+// https://developer.openstack.org/api-ref/clustering/#resize-a-cluster
+func (api *ClusteringV1API) ResizeClusteringCluster(cluster string, opts *ResizeClusteringClusterOptions) (*string, *Result, error) {
+	return api.clusterAction(cluster, "resize", opts)
+}
+
+// clusterAction triggers the given action on the cluster and returns the ID of
+// the action carrying it out.
+func (api *ClusteringV1API) clusterAction(cluster string, action string, body interface{}) (*string, *Result, error) {
+	input := &envelope{ID: cluster, Name: action, Body: body}
+	output := &struct {
+		Action *string `json:"action,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPost, "./v1/clusters/{id}/actions", true, StatusCodeIn(202), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return output.Action, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * ACTIONS
+ */
+
+// RetrieveClusteringAction retrieves the action with the given ID; see also
+// https://developer.openstack.org/api-ref/clustering/#show-action-details
+func (api *ClusteringV1API) RetrieveClusteringAction(actionid string) (*ClusteringAction, *Result, error) {
+	action := &ClusteringAction{}
+	result, err := api.retrieveResource("./v1/actions/{id}", actionid, "action", action)
+	if result != nil && result.Code == 200 {
+		return action, result, err
+	}
+	return nil, result, err
+}
+
+// WaitForClusteringAction waits until the action with the given ID has
+// SUCCEEDED, polling it with the given backoff policy; the wait fails if the
+// action FAILED or was CANCELLED.
+func (api *ClusteringV1API) WaitForClusteringAction(ctx context.Context, actionid string, backoff Backoff) (*ClusteringAction, error) {
+	var current *ClusteringAction
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var result *Result
+		var err error
+		current, result, err = api.RetrieveClusteringAction(actionid)
+		if result != nil && result.Code == http.StatusNotFound {
+			return false, fmt.Errorf("action %q not found", actionid)
+		}
+		if err != nil {
+			return false, err
+		}
+		if current == nil || current.Status == nil {
+			return false, fmt.Errorf("error retrieving action %q: %v", actionid, result)
+		}
+		log.Debugf("action %q (%s) is %s", actionid, stringValue(current.Action), *current.Status)
+		switch *current.Status {
+		case ClusteringActionStatusSucceeded:
+			return true, nil
+		case ClusteringActionStatusFailed, ClusteringActionStatusCancelled:
+			return false, fmt.Errorf("action %q (%s) is %s: %s", actionid, stringValue(current.Action), *current.Status, stringValue(current.StatusReason))
+		}
+		return false, nil
+	})
+	return current, err
+}
+
+// WaitForClusteringClusterStatus waits until the cluster with the given ID or
+// name is in the given status (e.g. ACTIVE after its creation), polling it
+// with the given backoff policy; the wait fails if the cluster goes into the
+// ERROR status or disappears.
+func (api *ClusteringV1API) WaitForClusteringClusterStatus(ctx context.Context, cluster string, status string, backoff Backoff) (*ClusteringCluster, error) {
+	var current *ClusteringCluster
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var result *Result
+		var err error
+		current, result, err = api.RetrieveClusteringCluster(cluster)
+		if result != nil && result.Code == http.StatusNotFound {
+			return false, fmt.Errorf("cluster %q not found", cluster)
+		}
+		if err != nil {
+			return false, err
+		}
+		if current == nil || current.Status == nil {
+			return false, fmt.Errorf("error retrieving cluster %q: %v", cluster, result)
+		}
+		log.Debugf("cluster %q is %s", cluster, *current.Status)
+		switch *current.Status {
+		case status:
+			return true, nil
+		case ClusteringStatusError:
+			return false, fmt.Errorf("cluster %q is in %s status: %s", cluster, *current.Status, stringValue(current.StatusReason))
+		}
+		return false, nil
+	})
+	return current, err
+}
@@ -0,0 +1,107 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST NODES
+ */
+
+// ListClusteringNodesOptions provides the options available for listing the
+// nodes; Limit sets the page size, all pages are retrieved anyway; Sort is a
+// list of "key:direction" pairs (e.g. "index:asc").
+type ListClusteringNodesOptions struct {
+	ClusterID     *string `parameter:"cluster_id,omitempty" header:"-" json:"-"`
+	Name          *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Status        *string `parameter:"status,omitempty" header:"-" json:"-"`
+	GlobalProject *bool   `parameter:"global_project,omitempty" header:"-" json:"-"`
+	Sort          *string `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit         *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker        *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListClusteringNodes returns the list of nodes of the current project, or of
+// the given cluster; opts can be nil; see also
+// https://developer.openstack.org/api-ref/clustering/#list-nodes
+func (api *ClusteringV1API) ListClusteringNodes(opts *ListClusteringNodesOptions) (*[]ClusteringNode, *Result, error) {
+	if opts == nil {
+		opts = &ListClusteringNodesOptions{}
+	}
+	input := *opts
+
+	nodes := []ClusteringNode{}
+	for {
+		page := []ClusteringNode{}
+		result, err := api.Invoke(http.MethodGet, "./v1/nodes", true, StatusCodeIn(200), &input, &envelope{Name: "nodes", Body: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		nodes = append(nodes, page...)
+		if input.Limit == nil || len(page) < *input.Limit || len(page) == 0 {
+			return &nodes, result, err
+		}
+		// the next page starts after the last node of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE NODE
+ */
+
+// CreateClusteringNodeOptions provides the options available for creating a
+// node: ProfileID (or name) is required; the node is added to the cluster with
+// ClusterID, if given, which must use a profile of the same type.
+type CreateClusteringNodeOptions struct {
+	Name      string             `json:"name"`
+	ProfileID string             `json:"profile_id"`
+	ClusterID *string            `json:"cluster_id,omitempty"`
+	Role      *string            `json:"role,omitempty"`
+	Metadata  *map[string]string `json:"metadata,omitempty"`
+}
+
+// CreateClusteringNode creates a node; the creation is asynchronous; see also
+// https://developer.openstack.org/api-ref/clustering/#create-node
+func (api *ClusteringV1API) CreateClusteringNode(opts *CreateClusteringNodeOptions) (*ClusteringNode, *Result, error) {
+	node := &ClusteringNode{}
+	result, err := api.createResource("./v1/nodes", "node", opts, node, 201, 202)
+	if result != nil && result.OK {
+		return node, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE NODE
+ */
+
+// RetrieveClusteringNode retrieves the node with the given ID or name; see also
+// https://developer.openstack.org/api-ref/clustering/#show-node-details
+func (api *ClusteringV1API) RetrieveClusteringNode(node string) (*ClusteringNode, *Result, error) {
+	output := &ClusteringNode{}
+	result, err := api.retrieveResource("./v1/nodes/{id}", node, "node", output)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE NODE
+ */
+
+// DeleteClusteringNode deletes the node with the given ID or name, along with
+// the resource created from its profile (e.g. the server); the deletion is
+// asynchronous; see also
+// https://developer.openstack.org/api-ref/clustering/#delete-node
+func (api *ClusteringV1API) DeleteClusteringNode(node string) (bool, *Result, error) {
+	return api.deleteResource("./v1/nodes/{id}", node, 202, 204)
+}
@@ -0,0 +1,129 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST PROFILES
+ */
+
+// ListClusteringProfilesOptions provides the options available for listing the
+// profiles; Limit sets the page size, all pages are retrieved anyway; Sort is
+// a list of "key:direction" pairs (e.g. "name:asc").
+type ListClusteringProfilesOptions struct {
+	Name          *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Type          *string `parameter:"type,omitempty" header:"-" json:"-"`
+	GlobalProject *bool   `parameter:"global_project,omitempty" header:"-" json:"-"`
+	Sort          *string `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit         *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker        *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListClusteringProfiles returns the list of profiles of the current project;
+// opts can be nil; see also
+// https://developer.openstack.org/api-ref/clustering/#list-profiles
+func (api *ClusteringV1API) ListClusteringProfiles(opts *ListClusteringProfilesOptions) (*[]ClusteringProfile, *Result, error) {
+	if opts == nil {
+		opts = &ListClusteringProfilesOptions{}
+	}
+	input := *opts
+
+	profiles := []ClusteringProfile{}
+	for {
+		page := []ClusteringProfile{}
+		result, err := api.Invoke(http.MethodGet, "./v1/profiles", true, StatusCodeIn(200), &input, &envelope{Name: "profiles", Body: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		profiles = append(profiles, page...)
+		if input.Limit == nil || len(page) < *input.Limit || len(page) == 0 {
+			return &profiles, result, err
+		}
+		// the next page starts after the last profile of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE PROFILE
+ */
+
+// CreateClusteringProfileOptions provides the options available for creating a
+// profile: Spec is the profile specification, having the "type" (e.g.
+// "os.nova.server"), "version" (e.g. "1.0") and "properties" (e.g. the flavor,
+// image and networks of the servers) keys.
+type CreateClusteringProfileOptions struct {
+	Name     string                 `json:"name"`
+	Spec     map[string]interface{} `json:"spec"`
+	Metadata *map[string]string     `json:"metadata,omitempty"`
+}
+
+// CreateClusteringProfile creates a profile; see also
+// https://developer.openstack.org/api-ref/clustering/#create-profile
+func (api *ClusteringV1API) CreateClusteringProfile(opts *CreateClusteringProfileOptions) (*ClusteringProfile, *Result, error) {
+	profile := &ClusteringProfile{}
+	result, err := api.createResource("./v1/profiles", "profile", opts, profile, 201, 202)
+	if result != nil && result.OK {
+		return profile, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE PROFILE
+ */
+
+// RetrieveClusteringProfile retrieves the profile with the given ID or name;
+// see also
+// https://developer.openstack.org/api-ref/clustering/#show-profile-details
+func (api *ClusteringV1API) RetrieveClusteringProfile(profile string) (*ClusteringProfile, *Result, error) {
+	output := &ClusteringProfile{}
+	result, err := api.retrieveResource("./v1/profiles/{id}", profile, "profile", output)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE PROFILE
+ */
+
+// UpdateClusteringProfileOptions provides the options available for updating a
+// profile; the specification of a profile cannot be changed: a new profile
+// must be created instead, and the cluster updated to use it.
+type UpdateClusteringProfileOptions struct {
+	Name     *string            `json:"name,omitempty"`
+	Metadata *map[string]string `json:"metadata,omitempty"`
+}
+
+// UpdateClusteringProfile updates the profile with the given ID or name; see
+// also
+// https://developer.openstack.org/api-ref/clustering/#update-profile
+func (api *ClusteringV1API) UpdateClusteringProfile(profile string, opts *UpdateClusteringProfileOptions) (*ClusteringProfile, *Result, error) {
+	output := &ClusteringProfile{}
+	result, err := api.patchResource("./v1/profiles/{id}", profile, "profile", opts, output, 200, 202)
+	if result != nil && result.OK {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE PROFILE
+ */
+
+// DeleteClusteringProfile deletes the profile with the given ID or name, which
+// must not be in use by any cluster or node; see also
+// https://developer.openstack.org/api-ref/clustering/#delete-profile
+func (api *ClusteringV1API) DeleteClusteringProfile(profile string) (bool, *Result, error) {
+	return api.deleteResource("./v1/profiles/{id}", profile, 202, 204)
+}
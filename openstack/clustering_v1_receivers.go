@@ -0,0 +1,132 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Receiver types and the cluster actions they are usually bound to.
+const (
+	ClusteringReceiverTypeWebhook string = "webhook"
+	ClusteringReceiverTypeMessage string = "message"
+
+	ClusteringActionScaleOut string = "CLUSTER_SCALE_OUT"
+	ClusteringActionScaleIn  string = "CLUSTER_SCALE_IN"
+	ClusteringActionResize   string = "CLUSTER_RESIZE"
+)
+
+/*
+ * LIST RECEIVERS
+ */
+
+// ListClusteringReceiversOptions provides the options available for listing
+// the receivers; Limit sets the page size, all pages are retrieved anyway.
+type ListClusteringReceiversOptions struct {
+	Name          *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Type          *string `parameter:"type,omitempty" header:"-" json:"-"`
+	ClusterID     *string `parameter:"cluster_id,omitempty" header:"-" json:"-"`
+	Action        *string `parameter:"action,omitempty" header:"-" json:"-"`
+	GlobalProject *bool   `parameter:"global_project,omitempty" header:"-" json:"-"`
+	Sort          *string `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit         *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker        *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListClusteringReceivers returns the list of receivers of the current
+// project; opts can be nil; see also
+// https://developer.openstack.org/api-ref/clustering/#list-receivers
+func (api *ClusteringV1API) ListClusteringReceivers(opts *ListClusteringReceiversOptions) (*[]ClusteringReceiver, *Result, error) {
+	if opts == nil {
+		opts = &ListClusteringReceiversOptions{}
+	}
+	input := *opts
+
+	receivers := []ClusteringReceiver{}
+	for {
+		page := []ClusteringReceiver{}
+		result, err := api.Invoke(http.MethodGet, "./v1/receivers", true, StatusCodeIn(200), &input, &envelope{Name: "receivers", Body: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		receivers = append(receivers, page...)
+		if input.Limit == nil || len(page) < *input.Limit || len(page) == 0 {
+			return &receivers, result, err
+		}
+		// the next page starts after the last receiver of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE RECEIVER
+ */
+
+// CreateClusteringReceiverOptions provides the options available for creating
+// a receiver: webhook receivers (the default type) need the ClusterID and the
+// Action to trigger on it (one of the ClusteringAction* constants), with the
+// given Params (e.g. {"count": 2}).
+type CreateClusteringReceiverOptions struct {
+	Name      string                  `json:"name"`
+	Type      *string                 `json:"type,omitempty"`
+	ClusterID *string                 `json:"cluster_id,omitempty"`
+	Action    *string                 `json:"action,omitempty"`
+	Params    *map[string]interface{} `json:"params,omitempty"`
+}
+
+// CreateClusteringReceiver creates a receiver; the URL of a webhook receiver is
+// returned in its channel, under "alarm_url"; see also
+// https://developer.openstack.org/api-ref/clustering/#create-receiver
+func (api *ClusteringV1API) CreateClusteringReceiver(opts *CreateClusteringReceiverOptions) (*ClusteringReceiver, *Result, error) {
+	receiver := &ClusteringReceiver{}
+	result, err := api.createResource("./v1/receivers", "receiver", opts, receiver, 201, 202)
+	if result != nil && result.OK {
+		return receiver, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE RECEIVER
+ */
+
+// RetrieveClusteringReceiver retrieves the receiver with the given ID or name;
+// see also
+// https://developer.openstack.org/api-ref/clustering/#show-receiver-details
+func (api *ClusteringV1API) RetrieveClusteringReceiver(receiver string) (*ClusteringReceiver, *Result, error) {
+	output := &ClusteringReceiver{}
+	result, err := api.retrieveResource("./v1/receivers/{id}", receiver, "receiver", output)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE RECEIVER
+ */
+
+// DeleteClusteringReceiver deletes the receiver with the given ID or name; see
+// also
+// https://developer.openstack.org/api-ref/clustering/#delete-receiver
+func (api *ClusteringV1API) DeleteClusteringReceiver(receiver string) (bool, *Result, error) {
+	return api.deleteResource("./v1/receivers/{id}", receiver, 202, 204)
+}
+
+// ClusteringWebhookURL returns the URL of the given webhook receiver, to be
+// called (e.g. as an alarm action) to trigger its action, or an empty string if
+// the receiver has none.
+func ClusteringWebhookURL(receiver *ClusteringReceiver) string {
+	if receiver == nil || receiver.Channel == nil {
+		return ""
+	}
+	if url, ok := (*receiver.Channel)["alarm_url"].(string); ok {
+		return url
+	}
+	return ""
+}
@@ -0,0 +1,22 @@
+package openstack
+
+import (
+	"testing"
+)
+
+func TestClusteringWebhookURL(t *testing.T) {
+	receiver := &ClusteringReceiver{
+		Channel: &map[string]interface{}{
+			"alarm_url": "https://senlin:8778/v1/webhooks/abc/trigger?V=2",
+		},
+	}
+	if actual := ClusteringWebhookURL(receiver); actual != "https://senlin:8778/v1/webhooks/abc/trigger?V=2" {
+		t.Errorf("Clustering.TestClusteringWebhookURL: unexpected URL %q", actual)
+	}
+	if actual := ClusteringWebhookURL(&ClusteringReceiver{}); actual != "" {
+		t.Errorf("Clustering.TestClusteringWebhookURL: expected no URL without a channel, got %q", actual)
+	}
+	if actual := ClusteringWebhookURL(nil); actual != "" {
+		t.Errorf("Clustering.TestClusteringWebhookURL: expected no URL for a nil receiver, got %q", actual)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * PROFILES
+ */
+
+// ClusteringProfile is the specification of the nodes of a cluster: Type is
+// the profile type (e.g. "os.nova.server-1.0"), Spec its properties (e.g. the
+// flavor, image and networks of the servers).
+type ClusteringProfile struct {
+	ID        *string                 `json:"id,omitempty"`
+	Name      *string                 `json:"name,omitempty"`
+	Type      *string                 `json:"type,omitempty"`
+	Spec      *map[string]interface{} `json:"spec,omitempty"`
+	Metadata  *map[string]string      `json:"metadata,omitempty"`
+	ProjectID *string                 `json:"project,omitempty"`
+	UserID    *string                 `json:"user,omitempty"`
+	Domain    *string                 `json:"domain,omitempty"`
+	CreatedAt *string                 `json:"created_at,omitempty"`
+	UpdatedAt *string                 `json:"updated_at,omitempty"`
+}
+
+/*
+ * CLUSTERS AND NODES
+ */
+
+// ClusteringCluster is a Senlin cluster, i.e. a group of homogeneous nodes
+// built from the same profile, whose number is kept between MinSize and
+// MaxSize (-1 for no limit); Status is one of the ClusteringStatus*
+// constants.
+type ClusteringCluster struct {
+	ID              *string                 `json:"id,omitempty"`
+	Name            *string                 `json:"name,omitempty"`
+	ProfileID       *string                 `json:"profile_id,omitempty"`
+	ProfileName     *string                 `json:"profile_name,omitempty"`
+	DesiredCapacity *int                    `json:"desired_capacity,omitempty"`
+	MinSize         *int                    `json:"min_size,omitempty"`
+	MaxSize         *int                    `json:"max_size,omitempty"`
+	Timeout         *int                    `json:"timeout,omitempty"`
+	Nodes           *[]string               `json:"nodes,omitempty"`
+	Policies        *[]string               `json:"policies,omitempty"`
+	Status          *string                 `json:"status,omitempty"`
+	StatusReason    *string                 `json:"status_reason,omitempty"`
+	Metadata        *map[string]string      `json:"metadata,omitempty"`
+	Config          *map[string]interface{} `json:"config,omitempty"`
+	Data            *map[string]interface{} `json:"data,omitempty"`
+	Dependents      *map[string]interface{} `json:"dependents,omitempty"`
+	ProjectID       *string                 `json:"project,omitempty"`
+	UserID          *string                 `json:"user,omitempty"`
+	Domain          *string                 `json:"domain,omitempty"`
+	InitAt          *string                 `json:"init_at,omitempty"`
+	CreatedAt       *string                 `json:"created_at,omitempty"`
+	UpdatedAt       *string                 `json:"updated_at,omitempty"`
+}
+
+// ClusteringNode is a member of a cluster (or an orphan node, not in any
+// cluster); PhysicalID is the ID of the resource (e.g. of the server) created
+// from the profile, Index its position in the cluster.
+type ClusteringNode struct {
+	ID           *string                 `json:"id,omitempty"`
+	Name         *string                 `json:"name,omitempty"`
+	ClusterID    *string                 `json:"cluster_id,omitempty"`
+	ProfileID    *string                 `json:"profile_id,omitempty"`
+	ProfileName  *string                 `json:"profile_name,omitempty"`
+	PhysicalID   *string                 `json:"physical_id,omitempty"`
+	Index        *int                    `json:"index,omitempty"`
+	Role         *string                 `json:"role,omitempty"`
+	Status       *string                 `json:"status,omitempty"`
+	StatusReason *string                 `json:"status_reason,omitempty"`
+	Metadata     *map[string]string      `json:"metadata,omitempty"`
+	Data         *map[string]interface{} `json:"data,omitempty"`
+	Dependents   *map[string]interface{} `json:"dependents,omitempty"`
+	ProjectID    *string                 `json:"project,omitempty"`
+	UserID       *string                 `json:"user,omitempty"`
+	Domain       *string                 `json:"domain,omitempty"`
+	InitAt       *string                 `json:"init_at,omitempty"`
+	CreatedAt    *string                 `json:"created_at,omitempty"`
+	UpdatedAt    *string                 `json:"updated_at,omitempty"`
+}
+
+/*
+ * ACTIONS
+ */
+
+// ClusteringAction is an operation carried out asynchronously by Senlin on a
+// cluster or a node (the Target): Action is its type (e.g.
+// "CLUSTER_SCALE_OUT"), Status one of the ClusteringActionStatus* constants.
+type ClusteringAction struct {
+	ID           *string                 `json:"id,omitempty"`
+	Name         *string                 `json:"name,omitempty"`
+	Action       *string                 `json:"action,omitempty"`
+	Target       *string                 `json:"target,omitempty"`
+	Cause        *string                 `json:"cause,omitempty"`
+	Owner        *string                 `json:"owner,omitempty"`
+	Status       *string                 `json:"status,omitempty"`
+	StatusReason *string                 `json:"status_reason,omitempty"`
+	Inputs       *map[string]interface{} `json:"inputs,omitempty"`
+	Outputs      *map[string]interface{} `json:"outputs,omitempty"`
+	DependsOn    *[]string               `json:"depends_on,omitempty"`
+	DependedBy   *[]string               `json:"depended_by,omitempty"`
+	Interval     *int                    `json:"interval,omitempty"`
+	Timeout      *int                    `json:"timeout,omitempty"`
+	StartTime    *float64                `json:"start_time,omitempty"`
+	EndTime      *float64                `json:"end_time,omitempty"`
+	ProjectID    *string                 `json:"project,omitempty"`
+	UserID       *string                 `json:"user,omitempty"`
+	CreatedAt    *string                 `json:"created_at,omitempty"`
+	UpdatedAt    *string                 `json:"updated_at,omitempty"`
+}
+
+/*
+ * RECEIVERS
+ */
+
+// ClusteringReceiver triggers an action (e.g. "CLUSTER_SCALE_OUT") on a
+// cluster when it receives a request: for webhook receivers, Channel holds the
+// URL to call ("alarm_url"), which needs no authentication.
+type ClusteringReceiver struct {
+	ID        *string                 `json:"id,omitempty"`
+	Name      *string                 `json:"name,omitempty"`
+	Type      *string                 `json:"type,omitempty"`
+	ClusterID *string                 `json:"cluster_id,omitempty"`
+	Action    *string                 `json:"action,omitempty"`
+	Actor     *map[string]interface{} `json:"actor,omitempty"`
+	Params    *map[string]interface{} `json:"params,omitempty"`
+	Channel   *map[string]interface{} `json:"channel,omitempty"`
+	ProjectID *string                 `json:"project,omitempty"`
+	UserID    *string                 `json:"user,omitempty"`
+	Domain    *string                 `json:"domain,omitempty"`
+	CreatedAt *string                 `json:"created_at,omitempty"`
+	UpdatedAt *string                 `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,228 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package compose provides high-level helpers for common provisioning
+// workflows that span several OpenStack services (e.g. launching a server on
+// a new network and making it reachable through a floating IP); each helper
+// sequences the Identity, Compute, Network and Block Storage calls, rolls back
+// the resources it created if a step fails, and returns a Report listing all
+// the steps and their outcome.
+package compose
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dihedron/go-log"
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+/*
+ * REPORT
+ */
+
+// StepStatus is the outcome of a step of a workflow.
+type StepStatus string
+
+const (
+	// StepDone means that the step completed successfully.
+	StepDone StepStatus = "done"
+	// StepFailed means that the step failed, and the workflow was aborted.
+	StepFailed StepStatus = "failed"
+	// StepRolledBack means that the step completed, but it was undone because
+	// a later step failed.
+	StepRolledBack StepStatus = "rolled back"
+	// StepRollbackFailed means that the step completed, but undoing it after
+	// a later step failed did not succeed, so the resource must be cleaned up
+	// by hand.
+	StepRollbackFailed StepStatus = "rollback failed"
+)
+
+// Step describes a step of a workflow: the action performed (e.g. "create"),
+// the kind of resource (e.g. "network"), its ID and name (if any), the outcome
+// and the error, if the step or its rollback failed.
+type Step struct {
+	Action   string     `json:"action"`
+	Resource string     `json:"resource"`
+	ID       string     `json:"id,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	Status   StepStatus `json:"status"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// String returns a one-line description of the step.
+func (s Step) String() string {
+	line := s.Action + " " + s.Resource
+	if s.Name != "" {
+		line += " " + s.Name
+	}
+	if s.ID != "" {
+		line += " (" + s.ID + ")"
+	}
+	line += ": " + string(s.Status)
+	if s.Error != "" {
+		line += ": " + s.Error
+	}
+	return line
+}
+
+// Report is the structured account of a workflow: the steps in the order they
+// were performed and whether the workflow was rolled back because of a failure.
+type Report struct {
+	Steps      []Step `json:"steps"`
+	RolledBack bool   `json:"rolled_back"`
+}
+
+// String returns the steps of the report, one per line.
+func (r *Report) String() string {
+	lines := make([]string, 0, len(r.Steps))
+	for _, step := range r.Steps {
+		lines = append(lines, step.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Failed returns the steps that failed, or whose rollback failed; the latter
+// left resources behind.
+func (r *Report) Failed() []Step {
+	steps := []Step{}
+	for _, step := range r.Steps {
+		if step.Status == StepFailed || step.Status == StepRollbackFailed {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+/*
+ * WORKFLOW
+ */
+
+// Options provides the options common to all workflows: DisableRollback keeps
+// the resources created before a failure (e.g. to inspect them), Backoff is the
+// policy used to poll asynchronous operations (e.g. a server build) and
+// RollbackTimeout bounds the time spent undoing the steps, since the context of
+// the workflow may already be done when the rollback starts.
+type Options struct {
+	DisableRollback bool
+	Backoff         *openstack.Backoff
+	RollbackTimeout time.Duration
+}
+
+// DefaultBackoff is the policy used to poll asynchronous operations when none
+// is given.
+var DefaultBackoff = openstack.Backoff{Initial: 2 * time.Second, Max: 15 * time.Second, Factor: 1.5}
+
+// DefaultRollbackTimeout is the time allowed for undoing the steps of a failed
+// workflow when none is given.
+const DefaultRollbackTimeout = 5 * time.Minute
+
+// backoff returns the polling policy in the options, or the default one.
+func (o Options) backoff() openstack.Backoff {
+	if o.Backoff != nil {
+		return *o.Backoff
+	}
+	return DefaultBackoff
+}
+
+// undo is the action that reverts a step; it is given a context bounded by the
+// rollback timeout.
+type undo func(ctx context.Context) error
+
+// workflow runs the steps of a provisioning workflow, recording them in the
+// report and keeping the actions that undo them.
+type workflow struct {
+	options Options
+	report  *Report
+	undos   map[int]undo
+}
+
+// newWorkflow returns a new workflow with the given options.
+func newWorkflow(options Options) *workflow {
+	return &workflow{
+		options: options,
+		report:  &Report{Steps: []Step{}},
+		undos:   map[int]undo{},
+	}
+}
+
+// step runs an action on a resource: do performs it and returns the ID of the
+// resource; if it succeeds, the step is recorded as done, and the action
+// returned by revert (which can be nil if there is nothing to undo) will be
+// used to roll it back; if it fails, the step is recorded as failed.
+func (w *workflow) step(action string, resource string, name string, do func() (string, error), revert func(id string) undo) (string, error) {
+	log.Debugf("%s %s %q", action, resource, name)
+	id, err := do()
+	step := Step{Action: action, Resource: resource, ID: id, Name: name, Status: StepDone}
+	if err != nil {
+		log.Errorf("error trying to %s %s %q: %v", action, resource, name, err)
+		step.Status = StepFailed
+		step.Error = err.Error()
+	} else if revert != nil {
+		w.undos[len(w.report.Steps)] = revert(id)
+	}
+	w.report.Steps = append(w.report.Steps, step)
+	return id, err
+}
+
+// fail aborts the workflow because of the given error: unless disabled, the
+// completed steps are undone in reverse order; it returns the error.
+func (w *workflow) fail(err error) error {
+	if w.options.DisableRollback {
+		log.Warnf("workflow failed, rollback disabled: %v", err)
+		return err
+	}
+	timeout := w.options.RollbackTimeout
+	if timeout <= 0 {
+		timeout = DefaultRollbackTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	log.Infof("workflow failed, rolling back: %v", err)
+	for i := len(w.report.Steps) - 1; i >= 0; i-- {
+		revert, ok := w.undos[i]
+		if !ok {
+			continue
+		}
+		step := &w.report.Steps[i]
+		if e := revert(ctx); e != nil {
+			log.Errorf("error rolling back %s %s %q (%s): %v", step.Action, step.Resource, step.Name, step.ID, e)
+			step.Status = StepRollbackFailed
+			step.Error = e.Error()
+		} else {
+			step.Status = StepRolledBack
+		}
+	}
+	w.report.RolledBack = true
+	return err
+}
+
+// check turns the outcome of an API call into an error: the call failed if
+// it returned an error or if it was not successful (ok is false), in which case
+// the result tells why.
+func check(ok bool, result *openstack.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if result != nil {
+			return fmt.Errorf("unexpected result: %v", result)
+		}
+		return fmt.Errorf("unexpected result")
+	}
+	return nil
+}
+
+// services returns the Identity, Compute, Network and Block Storage APIs of the
+// client, or nil for the services not in the catalog; the Identity API is the
+// one used for authentication if the catalog does not list it.
+func services(client *openstack.Client) (*openstack.IdentityV3API, *openstack.ComputeV2API, *openstack.NetworkV2API, *openstack.BlockStorageV3API) {
+	identity := client.IdentityV3()
+	if identity == nil && client.Authenticator != nil {
+		identity = client.Authenticator.Identity
+	}
+	return identity, client.ComputeV2(), client.NetworkV2(), client.BlockStorageV3()
+}
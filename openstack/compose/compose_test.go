@@ -0,0 +1,201 @@
+package compose
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+// fakeCloud serves canned responses to the requests of the workflows, keyed by
+// method and path; the failing request gets a 409 instead, and the resources
+// deleted are reported as not found afterwards; it records the requests.
+type fakeCloud struct {
+	responses map[string]string
+	failing   string
+	deleted   map[string]bool
+	calls     []string
+}
+
+func (c *fakeCloud) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	call := r.Method + " " + r.URL.Path
+	c.calls = append(c.calls, call)
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case call == c.failing:
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{}`))
+	case r.Method == http.MethodDelete:
+		c.deleted[r.URL.Path] = true
+		switch {
+		case r.URL.Path == "/compute/servers/vm1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/compute/os-quota-sets/p1":
+			w.WriteHeader(http.StatusAccepted)
+		case r.URL.Path == "/volume/os-quota-sets/p1":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case r.Method == http.MethodGet && c.deleted[r.URL.Path]:
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		body, ok := c.responses[call]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPost && r.URL.Path == "/compute/servers" {
+			w.WriteHeader(http.StatusAccepted)
+		} else if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		} else if r.Method == http.MethodPut && body == "" {
+			w.WriteHeader(http.StatusNoContent)
+		}
+		w.Write([]byte(body))
+	}
+}
+
+// newTestClient returns a client whose Identity, Compute, Network and Block
+// Storage services are served at the /identity, /compute, /network and /volume
+// paths of the given URL respectively.
+func newTestClient(url string) *openstack.Client {
+	api := func(path string) openstack.API {
+		client := openstack.NewDefaultClient(url + path)
+		client.Authenticator.SetToken(&openstack.Token{Value: openstack.String("token")})
+		return client.Authenticator.Identity.API
+	}
+	client := openstack.NewDefaultClient(url + "/identity")
+	client.Authenticator.SetToken(&openstack.Token{Value: openstack.String("token")})
+	client.Services["compute"] = openstack.ComputeV2API{API: api("/compute")}
+	client.Services["network"] = openstack.NetworkV2API{API: api("/network")}
+	client.Services["block-storage"] = openstack.BlockStorageV3API{API: api("/volume")}
+	return client
+}
+
+// statuses returns the status of each step in the report.
+func statuses(report *Report) []StepStatus {
+	statuses := []StepStatus{}
+	for _, step := range report.Steps {
+		statuses = append(statuses, step.Status)
+	}
+	return statuses
+}
+
+var testOptions = Options{Backoff: &openstack.Backoff{Initial: time.Millisecond}}
+
+func TestLaunchServerWithNetworkAndFloatingIP(t *testing.T) {
+	responses := map[string]string{
+		"POST /network/v2.0/networks":                          `{"network": {"id": "n1"}}`,
+		"POST /network/v2.0/subnets":                           `{"subnet": {"id": "s1"}}`,
+		"POST /network/v2.0/routers":                           `{"router": {"id": "r1"}}`,
+		"PUT /network/v2.0/routers/r1/add_router_interface":    `{"id": "r1", "subnet_id": "s1", "port_id": "p0"}`,
+		"PUT /network/v2.0/routers/r1/remove_router_interface": `{"id": "r1", "subnet_id": "s1", "port_id": "p0"}`,
+		"POST /compute/servers":                                `{"server": {"id": "vm1"}}`,
+		"GET /compute/servers/vm1":                             `{"server": {"id": "vm1", "status": "ACTIVE"}}`,
+		"GET /compute/servers/vm1/os-interface":                `{"interfaceAttachments": [{"port_id": "p1", "fixed_ips": [{"ip_address": "192.168.0.5"}]}]}`,
+		"POST /network/v2.0/floatingips":                       `{"floatingip": {"id": "f1", "floating_ip_address": "10.0.0.5"}}`,
+		"PUT /network/v2.0/floatingips/f1":                     `{"floatingip": {"id": "f1", "floating_ip_address": "10.0.0.5", "port_id": "p1"}}`,
+	}
+	tests := []struct {
+		failing  string
+		statuses []StepStatus
+		undone   []string
+	}{
+		{
+			statuses: []StepStatus{StepDone, StepDone, StepDone, StepDone, StepDone, StepDone, StepDone, StepDone},
+			undone:   []string{},
+		},
+		{
+			failing:  "POST /network/v2.0/floatingips",
+			statuses: []StepStatus{StepRolledBack, StepRolledBack, StepRolledBack, StepRolledBack, StepRolledBack, StepDone, StepFailed},
+			undone: []string{
+				"DELETE /compute/servers/vm1",
+				"GET /compute/servers/vm1",
+				"PUT /network/v2.0/routers/r1/remove_router_interface",
+				"DELETE /network/v2.0/routers/r1",
+				"DELETE /network/v2.0/subnets/s1",
+				"DELETE /network/v2.0/networks/n1",
+			},
+		},
+	}
+	for _, test := range tests {
+		cloud := &fakeCloud{responses: responses, failing: test.failing, deleted: map[string]bool{}}
+		server := httptest.NewServer(cloud)
+
+		launched, report, err := LaunchServerWithNetworkAndFloatingIP(context.Background(), newTestClient(server.URL), &LaunchServerOptions{
+			Options: testOptions,
+			Server: openstack.CreateServerOptions{
+				Name:      openstack.String("web"),
+				ImageRef:  openstack.String("image"),
+				FlavorRef: openstack.String("flavor"),
+			},
+			Network: NetworkOptions{ExternalNetworkID: "public"},
+		})
+		server.Close()
+
+		if (err == nil) != (test.failing == "") || (launched == nil) != (test.failing != "") {
+			t.Errorf("Compose.TestLaunchServerWithNetworkAndFloatingIP: unexpected outcome failing %q: %v", test.failing, err)
+			continue
+		}
+		if actual := statuses(report); !reflect.DeepEqual(actual, test.statuses) || report.RolledBack != (test.failing != "") {
+			t.Errorf("Compose.TestLaunchServerWithNetworkAndFloatingIP: unexpected report failing %q:\n%v", test.failing, report)
+		}
+		if launched != nil && (stringValue(launched.FloatingIP.PortID) != "p1" || stringValue(launched.Router.ID) != "r1") {
+			t.Errorf("Compose.TestLaunchServerWithNetworkAndFloatingIP: unexpected resources %+v", launched)
+		}
+		undone := cloud.calls[len(cloud.calls)-len(test.undone):]
+		if !reflect.DeepEqual(undone, test.undone) {
+			t.Errorf("Compose.TestLaunchServerWithNetworkAndFloatingIP: unexpected rollback failing %q: %v", test.failing, undone)
+		}
+	}
+}
+
+func TestCreateProjectWithDefaultNetworkAndQuota(t *testing.T) {
+	cloud := &fakeCloud{
+		responses: map[string]string{
+			"POST /identity/v3/projects":                        `{"project": {"id": "p1", "name": "acme"}}`,
+			"GET /identity/v3/roles":                            `{"roles": [{"id": "role1", "name": "member"}]}`,
+			"PUT /identity/v3/projects/p1/users/u1/roles/role1": ``,
+			"POST /network/v2.0/networks":                       `{"network": {"id": "n1"}}`,
+			"POST /network/v2.0/subnets":                        `{"subnet": {"id": "s1"}}`,
+			"PUT /compute/os-quota-sets/p1":                     `{"quota_set": {"instances": 20}}`,
+			"PUT /network/v2.0/quotas/p1":                       `{"quota": {"floatingip": 2}}`,
+		},
+		failing: "PUT /volume/os-quota-sets/p1",
+		deleted: map[string]bool{},
+	}
+	server := httptest.NewServer(cloud)
+	defer server.Close()
+
+	project, report, err := CreateProjectWithDefaultNetworkAndQuota(context.Background(), newTestClient(server.URL), &CreateProjectOptions{
+		Options:           testOptions,
+		Project:           openstack.CreateProjectOptions{Name: openstack.String("acme")},
+		Members:           []Member{{UserID: "u1", RoleName: "member"}},
+		ComputeQuota:      &openstack.UpdateComputeQuotaOptions{Instances: openstack.Int(20)},
+		NetworkQuota:      &openstack.UpdateNetworkQuotaOptions{FloatingIP: openstack.Int(2)},
+		BlockStorageQuota: &openstack.UpdateBlockStorageQuotaOptions{Gigabytes: openstack.Int(500)},
+	})
+	if err == nil || project != nil {
+		t.Fatalf("Compose.TestCreateProjectWithDefaultNetworkAndQuota: expected failure, got %+v", project)
+	}
+	expected := []StepStatus{StepRolledBack, StepRolledBack, StepRolledBack, StepRolledBack, StepRolledBack, StepRolledBack, StepFailed}
+	if actual := statuses(report); !reflect.DeepEqual(actual, expected) || report.Steps[1].ID != "role1" || len(report.Failed()) != 1 {
+		t.Errorf("Compose.TestCreateProjectWithDefaultNetworkAndQuota: unexpected report:\n%v", report)
+	}
+	undone := []string{
+		"DELETE /network/v2.0/quotas/p1",
+		"DELETE /compute/os-quota-sets/p1",
+		"DELETE /network/v2.0/subnets/s1",
+		"DELETE /network/v2.0/networks/n1",
+		"DELETE /identity/v3/projects/p1/users/u1/roles/role1",
+		"DELETE /identity/v3/projects/p1",
+	}
+	if actual := cloud.calls[len(cloud.calls)-len(undone):]; !reflect.DeepEqual(actual, undone) {
+		t.Errorf("Compose.TestCreateProjectWithDefaultNetworkAndQuota: unexpected rollback %v", actual)
+	}
+}
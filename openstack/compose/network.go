@@ -0,0 +1,145 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+// DefaultCIDR is the address range of the subnets created by the workflows
+// when none is given.
+const DefaultCIDR = "192.168.0.0/24"
+
+// NetworkOptions describes the tenant network created by a workflow: a network
+// with an IPv4 subnet on CIDR (DefaultCIDR if empty) using the given DNS name
+// servers, and, if ExternalNetworkID is not empty, a router connecting the
+// subnet to that external network.
+type NetworkOptions struct {
+	CIDR              string
+	DNSNameservers    []string
+	ExternalNetworkID string
+}
+
+// networkResources are the resources created by createNetwork; Router is nil if
+// no external network was given.
+type networkResources struct {
+	Network *openstack.Network
+	Subnet  *openstack.Subnet
+	Router  *openstack.Router
+}
+
+// createNetwork creates a network, its subnet and the router to the external
+// network (if any), named after the given prefix and owned by the given project
+// (if not nil, which requires administrative privileges); the resources created
+// before a failure are returned anyway, and will be removed by the rollback.
+func (w *workflow) createNetwork(api *openstack.NetworkV2API, prefix string, projectid *string, opts NetworkOptions) (*networkResources, error) {
+	resources := &networkResources{}
+	cidr := opts.CIDR
+	if cidr == "" {
+		cidr = DefaultCIDR
+	}
+
+	name := prefix + "-net"
+	networkid, err := w.step("create", "network", name, func() (string, error) {
+		network, result, err := api.CreateNetwork(&openstack.CreateNetworkOptions{
+			Name:      openstack.String(name),
+			ProjectID: projectid,
+		})
+		if err := check(network != nil, result, err); err != nil {
+			return "", err
+		}
+		resources.Network = network
+		return *network.ID, nil
+	}, func(id string) undo {
+		return func(ctx context.Context) error {
+			ok, result, err := api.DeleteNetwork(id)
+			return check(ok, result, err)
+		}
+	})
+	if err != nil {
+		return resources, err
+	}
+
+	name = prefix + "-subnet"
+	subnetid, err := w.step("create", "subnet", name, func() (string, error) {
+		subnet, result, err := api.CreateSubnet(&openstack.CreateSubnetOptions{
+			Name:           openstack.String(name),
+			NetworkID:      openstack.String(networkid),
+			IPVersion:      openstack.Int(4),
+			CIDR:           openstack.String(cidr),
+			DNSNameservers: stringSlice(opts.DNSNameservers),
+			ProjectID:      projectid,
+		})
+		if err := check(subnet != nil, result, err); err != nil {
+			return "", err
+		}
+		resources.Subnet = subnet
+		return *subnet.ID, nil
+	}, func(id string) undo {
+		return func(ctx context.Context) error {
+			ok, result, err := api.DeleteSubnet(id)
+			return check(ok, result, err)
+		}
+	})
+	if err != nil || opts.ExternalNetworkID == "" {
+		return resources, err
+	}
+
+	name = prefix + "-router"
+	routerid, err := w.step("create", "router", name, func() (string, error) {
+		router, result, err := api.CreateRouter(&openstack.CreateRouterOptions{
+			Name:                openstack.String(name),
+			ProjectID:           projectid,
+			ExternalGatewayInfo: &openstack.RouterGatewayInfo{NetworkID: openstack.String(opts.ExternalNetworkID)},
+		})
+		if err := check(router != nil, result, err); err != nil {
+			return "", err
+		}
+		resources.Router = router
+		return *router.ID, nil
+	}, func(id string) undo {
+		return func(ctx context.Context) error {
+			ok, result, err := api.DeleteRouter(id)
+			return check(ok, result, err)
+		}
+	})
+	if err != nil {
+		return resources, err
+	}
+
+	_, err = w.step("add", "router interface", fmt.Sprintf("%s:%s", routerid, subnetid), func() (string, error) {
+		iface, result, err := api.AddRouterInterface(routerid, openstack.String(subnetid), nil)
+		if err := check(iface != nil, result, err); err != nil {
+			return "", err
+		}
+		return stringValue(iface.PortID), nil
+	}, func(id string) undo {
+		return func(ctx context.Context) error {
+			iface, result, err := api.RemoveRouterInterface(routerid, openstack.String(subnetid), nil)
+			return check(iface != nil, result, err)
+		}
+	})
+	return resources, err
+}
+
+// stringSlice returns a pointer to the given slice, or nil if it is empty.
+func stringSlice(values []string) *[]string {
+	if len(values) == 0 {
+		return nil
+	}
+	return &values
+}
+
+// stringValue returns the value of the given string pointer, or the empty
+// string if it is nil.
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
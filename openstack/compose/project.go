@@ -0,0 +1,186 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+/*
+ * CREATE PROJECT WITH DEFAULT NETWORK AND QUOTA
+ */
+
+// Member is a user to be granted a role on a new project; the role is given
+// either by ID or by name, in which case it is looked up among the global roles.
+type Member struct {
+	UserID   string
+	RoleID   string
+	RoleName string
+}
+
+// CreateProjectOptions provides the options for creating a project ready for
+// use: Project describes the project to create (Name is mandatory); Members are
+// the users to be granted roles on it; Network describes its default network,
+// which is created on its behalf (if ExternalNetworkID is empty, the network
+// is not routed); the quotas, if not nil, are applied to the project in the
+// Compute, Network and Block Storage services. The workflow requires
+// administrative privileges.
+type CreateProjectOptions struct {
+	Options
+	Project           openstack.CreateProjectOptions
+	Members           []Member
+	Network           NetworkOptions
+	ComputeQuota      *openstack.UpdateComputeQuotaOptions
+	NetworkQuota      *openstack.UpdateNetworkQuotaOptions
+	BlockStorageQuota *openstack.UpdateBlockStorageQuotaOptions
+}
+
+// ProvisionedProject holds the resources created by CreateProjectWithDefaultNetworkAndQuota;
+// Router is nil if no external network was given, and the quotas are nil if
+// they were not set.
+type ProvisionedProject struct {
+	Project           *openstack.Project
+	Network           *openstack.Network
+	Subnet            *openstack.Subnet
+	Router            *openstack.Router
+	ComputeQuota      *openstack.ComputeQuota
+	NetworkQuota      *openstack.NetworkQuota
+	BlockStorageQuota *openstack.BlockStorageQuota
+}
+
+// CreateProjectWithDefaultNetworkAndQuota creates a project, grants roles on it
+// to the given users, creates its default network and sets its quotas; if any
+// step fails, the changes made so far are reverted (unless rollback is
+// disabled): resources are deleted, roles unassigned and quotas reset to the
+// defaults; the error is returned along with the report of the steps performed.
+func CreateProjectWithDefaultNetworkAndQuota(ctx context.Context, client *openstack.Client, opts *CreateProjectOptions) (*ProvisionedProject, *Report, error) {
+	if opts == nil {
+		opts = &CreateProjectOptions{}
+	}
+	w := newWorkflow(opts.Options)
+	identity, compute, network, blockstorage := services(client)
+	switch {
+	case identity == nil || network == nil:
+		return nil, w.report, fmt.Errorf("the Identity and Network services are required")
+	case opts.ComputeQuota != nil && compute == nil:
+		return nil, w.report, fmt.Errorf("the Compute service is required to set the Compute quota")
+	case opts.BlockStorageQuota != nil && blockstorage == nil:
+		return nil, w.report, fmt.Errorf("the Block Storage service is required to set the Block Storage quota")
+	case opts.Project.Name == nil:
+		return nil, w.report, fmt.Errorf("the project name is mandatory")
+	}
+	provisioned := &ProvisionedProject{}
+
+	name := *opts.Project.Name
+	projectid, err := w.step("create", "project", name, func() (string, error) {
+		project, result, err := identity.CreateProject(&opts.Project)
+		if err := check(project != nil, result, err); err != nil {
+			return "", err
+		}
+		provisioned.Project = project
+		return *project.ID, nil
+	}, func(id string) undo {
+		return func(ctx context.Context) error {
+			ok, result, err := identity.DeleteProject(id)
+			return check(ok, result, err)
+		}
+	})
+	if err != nil {
+		return nil, w.report, w.fail(err)
+	}
+
+	for _, member := range opts.Members {
+		member := member
+		_, err = w.step("assign", "role", member.RoleID+member.RoleName, func() (string, error) {
+			if member.RoleID == "" {
+				roles, result, err := identity.ListRoles(&openstack.ListRolesOptions{Name: openstack.String(member.RoleName)})
+				if err := check(roles != nil, result, err); err != nil {
+					return "", err
+				}
+				if len(*roles) != 1 {
+					return "", fmt.Errorf("found %d roles named %q", len(*roles), member.RoleName)
+				}
+				member.RoleID = *(*roles)[0].ID
+			}
+			ok, result, err := identity.AssignProjectUserRole(projectid, member.UserID, member.RoleID)
+			return member.RoleID, check(ok, result, err)
+		}, func(id string) undo {
+			return func(ctx context.Context) error {
+				ok, result, err := identity.UnassignProjectUserRole(projectid, member.UserID, id)
+				return check(ok, result, err)
+			}
+		})
+		if err != nil {
+			return nil, w.report, w.fail(err)
+		}
+	}
+
+	resources, err := w.createNetwork(network, name, openstack.String(projectid), opts.Network)
+	provisioned.Network, provisioned.Subnet, provisioned.Router = resources.Network, resources.Subnet, resources.Router
+	if err != nil {
+		return nil, w.report, w.fail(err)
+	}
+
+	if opts.ComputeQuota != nil {
+		_, err = w.step("set", "compute quota", name, func() (string, error) {
+			quota, result, err := compute.UpdateComputeQuota(projectid, opts.ComputeQuota)
+			if err := check(quota != nil, result, err); err != nil {
+				return projectid, err
+			}
+			provisioned.ComputeQuota = quota
+			return projectid, nil
+		}, func(id string) undo {
+			return func(ctx context.Context) error {
+				ok, result, err := compute.ResetComputeQuota(id)
+				return check(ok, result, err)
+			}
+		})
+		if err != nil {
+			return nil, w.report, w.fail(err)
+		}
+	}
+
+	if opts.NetworkQuota != nil {
+		_, err = w.step("set", "network quota", name, func() (string, error) {
+			quota, result, err := network.UpdateNetworkQuota(projectid, opts.NetworkQuota)
+			if err := check(quota != nil, result, err); err != nil {
+				return projectid, err
+			}
+			provisioned.NetworkQuota = quota
+			return projectid, nil
+		}, func(id string) undo {
+			return func(ctx context.Context) error {
+				ok, result, err := network.ResetNetworkQuota(id)
+				return check(ok, result, err)
+			}
+		})
+		if err != nil {
+			return nil, w.report, w.fail(err)
+		}
+	}
+
+	if opts.BlockStorageQuota != nil {
+		_, err = w.step("set", "block storage quota", name, func() (string, error) {
+			quota, result, err := blockstorage.UpdateBlockStorageQuota(projectid, opts.BlockStorageQuota)
+			if err := check(quota != nil, result, err); err != nil {
+				return projectid, err
+			}
+			provisioned.BlockStorageQuota = quota
+			return projectid, nil
+		}, func(id string) undo {
+			return func(ctx context.Context) error {
+				ok, result, err := blockstorage.ResetBlockStorageQuota(id)
+				return check(ok, result, err)
+			}
+		})
+		if err != nil {
+			return nil, w.report, w.fail(err)
+		}
+	}
+	return provisioned, w.report, nil
+}
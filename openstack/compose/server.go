@@ -0,0 +1,142 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+/*
+ * LAUNCH SERVER WITH NETWORK AND FLOATING IP
+ */
+
+// LaunchServerOptions provides the options for launching a server reachable
+// from outside: Server describes the server to create (Name, ImageRef and
+// FlavorRef are mandatory, Networks is replaced by the network the server is
+// attached to); NetworkID is the network to attach the server to: if empty, a
+// new one is created as described by Network, named after the server;
+// Network.ExternalNetworkID is mandatory, as the floating IP is allocated from
+// it.
+type LaunchServerOptions struct {
+	Options
+	Server    openstack.CreateServerOptions
+	NetworkID string
+	Network   NetworkOptions
+}
+
+// LaunchedServer holds the resources created by LaunchServerWithNetworkAndFloatingIP;
+// Network, Subnet and Router are nil if the server was attached to an existing
+// network.
+type LaunchedServer struct {
+	Server     *openstack.Server
+	Network    *openstack.Network
+	Subnet     *openstack.Subnet
+	Router     *openstack.Router
+	FloatingIP *openstack.FloatingIP
+}
+
+// LaunchServerWithNetworkAndFloatingIP creates a server, optionally on a new
+// network routed to the external network, waits for it to become active and
+// associates it with a new floating IP from the external network; if any step
+// fails, the resources created so far are removed (unless rollback is disabled)
+// and the error is returned along with the report of the steps performed.
+func LaunchServerWithNetworkAndFloatingIP(ctx context.Context, client *openstack.Client, opts *LaunchServerOptions) (*LaunchedServer, *Report, error) {
+	if opts == nil {
+		opts = &LaunchServerOptions{}
+	}
+	w := newWorkflow(opts.Options)
+	_, compute, network, _ := services(client)
+	switch {
+	case compute == nil || network == nil:
+		return nil, w.report, fmt.Errorf("the Compute and Network services are required")
+	case opts.Server.Name == nil || opts.Server.ImageRef == nil || opts.Server.FlavorRef == nil:
+		return nil, w.report, fmt.Errorf("server name, image and flavor are mandatory")
+	case opts.Network.ExternalNetworkID == "":
+		return nil, w.report, fmt.Errorf("the external network is mandatory")
+	}
+	launched := &LaunchedServer{}
+
+	networkid := opts.NetworkID
+	if networkid == "" {
+		resources, err := w.createNetwork(network, *opts.Server.Name, nil, opts.Network)
+		launched.Network, launched.Subnet, launched.Router = resources.Network, resources.Subnet, resources.Router
+		if err != nil {
+			return nil, w.report, w.fail(err)
+		}
+		networkid = *resources.Network.ID
+	}
+
+	backoff := opts.backoff()
+	server := opts.Server
+	server.Networks = &[]openstack.ServerNetwork{{UUID: openstack.String(networkid)}}
+	serverid, err := w.step("create", "server", *server.Name, func() (string, error) {
+		created, result, err := compute.CreateServer(&server)
+		if err := check(created != nil, result, err); err != nil {
+			return "", err
+		}
+		launched.Server = created
+		return *created.ID, nil
+	}, func(id string) undo {
+		return func(ctx context.Context) error {
+			ok, result, err := compute.DeleteServer(id)
+			if err := check(ok, result, err); err != nil {
+				return err
+			}
+			// the ports of the server must be gone before its network can be removed
+			_, err = compute.WaitForServerStatus(ctx, id, openstack.ServerStatusDeleted, backoff)
+			return err
+		}
+	})
+	if err != nil {
+		return nil, w.report, w.fail(err)
+	}
+
+	_, err = w.step("wait for", "server", *server.Name, func() (string, error) {
+		active, err := compute.WaitForServerStatus(ctx, serverid, openstack.ServerStatusActive, backoff)
+		if err != nil {
+			return serverid, err
+		}
+		launched.Server = active
+		return serverid, nil
+	}, nil)
+	if err != nil {
+		return nil, w.report, w.fail(err)
+	}
+
+	fipid, err := w.step("create", "floating IP", "", func() (string, error) {
+		fip, result, err := network.CreateFloatingIP(&openstack.CreateFloatingIPOptions{
+			FloatingNetworkID: openstack.String(opts.Network.ExternalNetworkID),
+		})
+		if err := check(fip != nil, result, err); err != nil {
+			return "", err
+		}
+		launched.FloatingIP = fip
+		return *fip.ID, nil
+	}, func(id string) undo {
+		return func(ctx context.Context) error {
+			ok, result, err := network.DeleteFloatingIP(id)
+			return check(ok, result, err)
+		}
+	})
+	if err != nil {
+		return nil, w.report, w.fail(err)
+	}
+
+	_, err = w.step("associate", "floating IP", stringValue(launched.FloatingIP.FloatingIPAddress), func() (string, error) {
+		fip, result, err := network.AssociateFloatingIPWithServer(compute, fipid, serverid, nil)
+		if err := check(fip != nil, result, err); err != nil {
+			return fipid, err
+		}
+		launched.FloatingIP = fip
+		return fipid, nil
+	}, nil)
+	if err != nil {
+		return nil, w.report, w.fail(err)
+	}
+	return launched, w.report, nil
+}
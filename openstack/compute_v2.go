@@ -0,0 +1,46 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+// ComputeV2API represents the compute API ver. 2.1 (Nova), providing support
+// for the management of servers, flavors, keypairs and of the hypervisors.
+// Nova uses microversions: by default requests are served with the base
+// version 2.1, a later one can be requested for all calls through
+// WithMicroversion, or the latest one supported by the server through
+// NegotiateMicroversion; the calls whose options have a Microversion field
+// can also request one for that call only, which takes precedence.
+// See https://developer.openstack.org/api-ref/compute/
+type ComputeV2API struct {
+	API
+}
+
+// computeMicroversion is the header through which Nova is told the
+// microversion requested.
+var computeMicroversion = microversionHeader{name: "X-OpenStack-Nova-API-Version", base: "2.1"}
+
+// WithMicroversion returns a copy of the API that requests the given
+// microversion (e.g. "2.79") in all its calls, through the
+// X-OpenStack-Nova-API-Version header; the receiver is left unchanged.
+func (api ComputeV2API) WithMicroversion(microversion string) *ComputeV2API {
+	return &ComputeV2API{API: api.withMicroversion(computeMicroversion, microversion)}
+}
+
+// NegotiateMicroversion returns a copy of the API that requests, in all its
+// calls, the microversion pinned for its endpoint in the client profile, or
+// the latest one supported by the server, as advertised in its version
+// document (see SelectMicroversion); the receiver is left unchanged.
+func (api ComputeV2API) NegotiateMicroversion() (*ComputeV2API, *Result, error) {
+	microversion, result, err := api.negotiateMicroversion()
+	if err != nil {
+		return nil, result, err
+	}
+	return api.WithMicroversion(microversion), result, nil
+}
+
+// Microversion returns the microversion requested in all calls, or "2.1" if
+// none was set.
+func (api *ComputeV2API) Microversion() string {
+	return api.requestedMicroversion(computeMicroversion)
+}
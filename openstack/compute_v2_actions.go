@@ -0,0 +1,552 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dihedron/go-log"
+)
+
+// serverAction is the input to all the server actions: they all share the same
+// endpoint (/servers/{id}/action) and differ in the name of the single key of
+// the request entity (e.g. "os-start") and in its value.
+type serverAction struct {
+	ServerID     string      `parameter:"-" header:"-" variable:"serverid" json:"-"`
+	Microversion *string     `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" variable:"-" json:"-"`
+	Name         string      `parameter:"-" header:"-" variable:"-" json:"-"`
+	Body         interface{} `parameter:"-" header:"-" variable:"-" json:"-"`
+}
+
+// MarshalJSON encodes the action as a JSON object having the action name as its
+// only key and the action body (or null) as the value.
+func (a serverAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		a.Name: a.Body,
+	})
+}
+
+// invokeServerAction performs the given action on the server identified by the
+// given server id, optionally with a specific microversion; the action body can
+// be nil for actions that take no parameters; the response entity, if any, is
+// stored into output.
+func (api *ComputeV2API) invokeServerAction(serverid string, microversion *string, name string, body interface{}, checker Checker, output interface{}) (*Result, error) {
+	input := &serverAction{
+		ServerID:     serverid,
+		Microversion: microversion,
+		Name:         name,
+		Body:         body,
+	}
+	log.Debugf("invoking action %q on server %q", name, serverid)
+	return api.Invoke(http.MethodPost, "./servers/{serverid}/action", true, checker, input, output, nil)
+}
+
+/*
+ * START SERVER
+ */
+
+// StartServer starts a stopped server and changes its status to ACTIVE; see
+// also https://developer.openstack.org/api-ref/compute/#start-server-os-start-action
+func (api *ComputeV2API) StartServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "os-start", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * STOP SERVER
+ */
+
+// StopServer stops a running server and changes its status to SHUTOFF; see
+// also https://developer.openstack.org/api-ref/compute/#stop-server-os-stop-action
+func (api *ComputeV2API) StopServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "os-stop", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * REBOOT SERVER
+ */
+
+const (
+	// RebootSoft asks the guest operating system to restart gracefully.
+	RebootSoft = "SOFT"
+	// RebootHard power cycles the server, like pulling the power plug.
+	RebootHard = "HARD"
+)
+
+// RebootServer reboots a server; the reboot type is either RebootSoft or
+// RebootHard; see also https://developer.openstack.org/api-ref/compute/#reboot-server-reboot-action
+func (api *ComputeV2API) RebootServer(serverid string, rebootType string) (bool, *Result, error) {
+	body := &struct {
+		Type string `json:"type"`
+	}{
+		Type: rebootType,
+	}
+	result, err := api.invokeServerAction(serverid, nil, "reboot", body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * RESIZE SERVER
+ */
+
+// ResizeServer resizes a server to the given flavor; once the resize is done,
+// the server goes into the VERIFY_RESIZE status (see WaitForResize) and must be
+// either confirmed (ConfirmResize) or reverted (RevertResize); see also
+// https://developer.openstack.org/api-ref/compute/#resize-server-resize-action
+func (api *ComputeV2API) ResizeServer(serverid string, flavorid string) (bool, *Result, error) {
+	body := &struct {
+		FlavorRef string `json:"flavorRef"`
+	}{
+		FlavorRef: flavorid,
+	}
+	result, err := api.invokeServerAction(serverid, nil, "resize", body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * CONFIRM RESIZED SERVER
+ */
+
+// ConfirmResize confirms a pending resize action, after which the server goes
+// back into the ACTIVE status; see also
+// https://developer.openstack.org/api-ref/compute/#confirm-resized-server-confirmresize-action
+func (api *ComputeV2API) ConfirmResize(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "confirmResize", nil, StatusCodeIn(204), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * REVERT RESIZED SERVER
+ */
+
+// RevertResize cancels and reverts a pending resize action, restoring the
+// original flavor; see also
+// https://developer.openstack.org/api-ref/compute/#revert-resized-server-revertresize-action
+func (api *ComputeV2API) RevertResize(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "revertResize", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// WaitForResize waits until a resize started with ResizeServer is over and the
+// server is in the VERIFY_RESIZE status, ready to be confirmed or reverted; on
+// clouds that automatically confirm resizes the server may go back to ACTIVE
+// directly, which is also regarded as a success (the returned server status
+// tells the two cases apart); the wait fails if the server goes into ERROR.
+func (api *ComputeV2API) WaitForResize(ctx context.Context, serverid string, interval time.Duration) (*Server, error) {
+	var server *Server
+	err := WaitFor(ctx, interval, func() (bool, error) {
+		var result *Result
+		var err error
+		server, result, err = api.RetrieveServer(serverid)
+		if err != nil {
+			return false, err
+		}
+		if server == nil {
+			return false, fmt.Errorf("error retrieving server %q: %v", serverid, result)
+		}
+		if server.Status == nil {
+			return false, nil
+		}
+		switch *server.Status {
+		case ServerStatusVerifyResize:
+			return true, nil
+		case ServerStatusActive:
+			// the resize starts with the server ACTIVE: wait for the task to be over
+			return server.TaskState == nil, nil
+		case ServerStatusError:
+			if server.Fault != nil {
+				return false, fmt.Errorf("server %q resize failed: %s", serverid, stringValue(server.Fault.Message))
+			}
+			return false, fmt.Errorf("server %q resize failed", serverid)
+		}
+		return false, nil
+	})
+	return server, err
+}
+
+/*
+ * REBUILD SERVER
+ */
+
+// RebuildServerOptions provides all the options available for rebuilding a
+// server; ImageRef is mandatory, all other values are optional and retain the
+// previous value if omitted; KeyName requires microversion 2.54; see also
+// https://developer.openstack.org/api-ref/compute/#rebuild-server-rebuild-action
+type RebuildServerOptions struct {
+	ImageRef          *string           `json:"imageRef,omitempty"`
+	Name              *string           `json:"name,omitempty"`
+	Description       *string           `json:"description,omitempty"`
+	AdminPass         *string           `json:"adminPass,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	KeyName           *string           `json:"key_name,omitempty"`
+	Personality       *[]Personality    `json:"-"`
+	PreserveEphemeral *bool             `json:"preserve_ephemeral,omitempty"`
+	AccessIPv4        *string           `json:"accessIPv4,omitempty"`
+	AccessIPv6        *string           `json:"accessIPv6,omitempty"`
+	DiskConfig        *string           `json:"OS-DCF:diskConfig,omitempty"`
+	Microversion      *string           `json:"-"`
+}
+
+// RebuildServer rebuilds a server with a new image, wiping its local disks; the
+// returned Server contains the new administrative password, unless one was
+// explicitly provided; see also
+// https://developer.openstack.org/api-ref/compute/#rebuild-server-rebuild-action
+func (api *ComputeV2API) RebuildServer(serverid string, opts *RebuildServerOptions) (*Server, *Result, error) {
+	body := &struct {
+		*RebuildServerOptions
+		Personality *[]Personality `json:"personality,omitempty"`
+	}{
+		RebuildServerOptions: opts,
+	}
+	if opts.Personality != nil {
+		files := []Personality{}
+		for _, file := range *opts.Personality {
+			encoded := Personality{
+				Path: file.Path,
+			}
+			if file.Contents != nil {
+				encoded.Contents = String(base64.StdEncoding.EncodeToString([]byte(*file.Contents)))
+			}
+			files = append(files, encoded)
+		}
+		body.Personality = &files
+	}
+	output := &struct {
+		Server *Server `header:"-" json:"server,omitempty"`
+	}{}
+	result, err := api.invokeServerAction(serverid, opts.Microversion, "rebuild", body, StatusCodeIn(202), output)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return output.Server, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE IMAGE
+ */
+
+// CreateServerImageOptions provides the options for creating an image (a
+// snapshot) of a server.
+type CreateServerImageOptions struct {
+	Name         *string           `json:"name,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Microversion *string           `json:"-"`
+}
+
+// CreateServerImage creates an image (a snapshot) of the server and returns the
+// ID of the new image; the ID is extracted from the Location header or, since
+// microversion 2.45, from the response entity; the image is created
+// asynchronously and must be polled in the Image service until it is active; see also
+// https://developer.openstack.org/api-ref/compute/#create-image-createimage-action
+func (api *ComputeV2API) CreateServerImage(serverid string, opts *CreateServerImageOptions) (*string, *Result, error) {
+	output := &struct {
+		ImageID *string `header:"-" json:"image_id,omitempty"`
+	}{}
+	result, err := api.invokeServerAction(serverid, opts.Microversion, "createImage", opts, StatusCodeIn(202), output)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		if output.ImageID == nil {
+			if location := result.Headers.Get("Location"); location != "" {
+				output.ImageID = String(lastPathSegment(location))
+			}
+		}
+		return output.ImageID, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * ADD FLOATING IP
+ */
+
+// AddFloatingIP associates the given floating IP address to the server; if the
+// server has more than one fixed IP address, fixedaddress (which can be nil)
+// selects the one the floating IP is mapped to; this action is deprecated since
+// microversion 2.44, after which floating IPs must be associated to the server
+// port through the Networking service (see FindServerPort); see also
+// https://developer.openstack.org/api-ref/compute/#add-associate-floating-ip-addfloatingip-action-deprecated
+func (api *ComputeV2API) AddFloatingIP(serverid string, address string, fixedaddress *string) (bool, *Result, error) {
+	body := &struct {
+		Address      string  `json:"address"`
+		FixedAddress *string `json:"fixed_address,omitempty"`
+	}{
+		Address:      address,
+		FixedAddress: fixedaddress,
+	}
+	result, err := api.invokeServerAction(serverid, nil, "addFloatingIp", body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * REMOVE FLOATING IP
+ */
+
+// RemoveFloatingIP disassociates the given floating IP address from the server;
+// this action is deprecated since microversion 2.44; see also
+// https://developer.openstack.org/api-ref/compute/#remove-disassociate-floating-ip-removefloatingip-action-deprecated
+func (api *ComputeV2API) RemoveFloatingIP(serverid string, address string) (bool, *Result, error) {
+	body := &struct {
+		Address string `json:"address"`
+	}{
+		Address: address,
+	}
+	result, err := api.invokeServerAction(serverid, nil, "removeFloatingIp", body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * LOCK SERVER
+ */
+
+// LockServer locks the server, so that non-administrative users cannot perform
+// actions on it; a reason can be given starting with microversion 2.73, which is
+// sent by default if reason is not nil; see also
+// https://developer.openstack.org/api-ref/compute/#lock-server-lock-action
+func (api *ComputeV2API) LockServer(serverid string, reason *string) (bool, *Result, error) {
+	var body interface{}
+	var microversion *string
+	if reason != nil {
+		body = &struct {
+			LockedReason *string `json:"locked_reason"`
+		}{
+			LockedReason: reason,
+		}
+		microversion = String("2.73")
+	}
+	result, err := api.invokeServerAction(serverid, microversion, "lock", body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * UNLOCK SERVER
+ */
+
+// UnlockServer unlocks a locked server; see also
+// https://developer.openstack.org/api-ref/compute/#unlock-server-unlock-action
+func (api *ComputeV2API) UnlockServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "unlock", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * PAUSE SERVER
+ */
+
+// PauseServer pauses the server, keeping its state in the memory of the
+// host; see also https://developer.openstack.org/api-ref/compute/#pause-server-pause-action
+func (api *ComputeV2API) PauseServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "pause", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * UNPAUSE SERVER
+ */
+
+// UnpauseServer unpauses a paused server and changes its status to ACTIVE;
+// see also https://developer.openstack.org/api-ref/compute/#unpause-server-unpause-action
+func (api *ComputeV2API) UnpauseServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "unpause", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * SUSPEND SERVER
+ */
+
+// SuspendServer suspends the server, saving its state to disk and freeing
+// the host memory; see also https://developer.openstack.org/api-ref/compute/#suspend-server-suspend-action
+func (api *ComputeV2API) SuspendServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "suspend", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * RESUME SERVER
+ */
+
+// ResumeServer resumes a suspended server and changes its status to ACTIVE;
+// see also https://developer.openstack.org/api-ref/compute/#resume-suspended-server-resume-action
+func (api *ComputeV2API) ResumeServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "resume", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * SHELVE SERVER
+ */
+
+// ShelveServer shelves the server: it is stopped, a snapshot is taken (for
+// servers not booted from volume) and, depending on the cloud configuration, it
+// is offloaded from its host; see also
+// https://developer.openstack.org/api-ref/compute/#shelve-server-shelve-action
+func (api *ComputeV2API) ShelveServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "shelve", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * SHELVE-OFFLOAD SERVER
+ */
+
+// ShelveOffloadServer offloads a shelved server from its host, releasing all
+// its resources there; see also
+// https://developer.openstack.org/api-ref/compute/#shelf-offload-remove-server-shelveoffload-action
+func (api *ComputeV2API) ShelveOffloadServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "shelveOffload", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * UNSHELVE SERVER
+ */
+
+// UnshelveServerOptions provides the options available for unshelving a server;
+// AvailabilityZone requires microversion 2.77, Host microversion 2.91.
+type UnshelveServerOptions struct {
+	AvailabilityZone *string `json:"availability_zone,omitempty"`
+	Host             *string `json:"host,omitempty"`
+	Microversion     *string `json:"-"`
+}
+
+// UnshelveServer restores a shelved server, optionally targeting a specific
+// availability zone or host if the server was offloaded; opts can be nil; see
+// also https://developer.openstack.org/api-ref/compute/#unshelve-restore-shelved-server-unshelve-action
+func (api *ComputeV2API) UnshelveServer(serverid string, opts *UnshelveServerOptions) (bool, *Result, error) {
+	var body interface{}
+	var microversion *string
+	if opts != nil {
+		microversion = opts.Microversion
+		if opts.AvailabilityZone != nil || opts.Host != nil {
+			body = opts
+		}
+	}
+	result, err := api.invokeServerAction(serverid, microversion, "unshelve", body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * RESCUE SERVER
+ */
+
+// RescueServerOptions provides the options available for rescuing a server;
+// if AdminPass is nil a password is generated; if RescueImageRef is nil the
+// image the server was booted from is used.
+type RescueServerOptions struct {
+	AdminPass      *string `json:"adminPass,omitempty"`
+	RescueImageRef *string `json:"rescue_image_ref,omitempty"`
+	Microversion   *string `json:"-"`
+}
+
+// RescueServer puts the server in rescue mode, booting it from the rescue image
+// with the original disk attached as a secondary device; it returns the
+// administrative password of the rescued server, unless the cloud is configured
+// not to; opts can be nil; see also
+// https://developer.openstack.org/api-ref/compute/#rescue-server-rescue-action
+func (api *ComputeV2API) RescueServer(serverid string, opts *RescueServerOptions) (*string, *Result, error) {
+	if opts == nil {
+		opts = &RescueServerOptions{}
+	}
+	output := &struct {
+		AdminPass *string `header:"-" json:"adminPass,omitempty"`
+	}{}
+
+	result, err := api.invokeServerAction(serverid, opts.Microversion, "rescue", opts, StatusCodeIn(200), output)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.AdminPass, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UNRESCUE SERVER
+ */
+
+// UnrescueServer restores a server in rescue mode to its original state; see
+// also https://developer.openstack.org/api-ref/compute/#unrescue-server-unrescue-action
+func (api *ComputeV2API) UnrescueServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "unrescue", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,241 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"fmt"
+)
+
+const (
+	// BlockDeviceSourceImage is the source type of block devices created from an image.
+	BlockDeviceSourceImage = "image"
+	// BlockDeviceSourceVolume is the source type of existing volumes.
+	BlockDeviceSourceVolume = "volume"
+	// BlockDeviceSourceSnapshot is the source type of block devices created from a
+	// volume snapshot.
+	BlockDeviceSourceSnapshot = "snapshot"
+	// BlockDeviceSourceBlank is the source type of empty block devices.
+	BlockDeviceSourceBlank = "blank"
+)
+
+const (
+	// BlockDeviceDestinationVolume is the destination type of block devices that
+	// are Block Storage volumes.
+	BlockDeviceDestinationVolume = "volume"
+	// BlockDeviceDestinationLocal is the destination type of block devices that
+	// are local to the hypervisor (ephemeral and swap disks).
+	BlockDeviceDestinationLocal = "local"
+)
+
+// ServerBuilder assembles the CreateServerOptions for booting a server from an
+// image, from an existing volume or snapshot, or from a new volume created from
+// an image, with additional volumes, ephemeral and swap disks, so that the block
+// device mappings are consistent; errors (e.g. two boot sources) are recorded
+// and reported by Build.
+type ServerBuilder struct {
+	opts     *CreateServerOptions
+	boot     *BlockDeviceMapping
+	image    *string
+	devices  []BlockDeviceMapping
+	hasSwap  bool
+	problems []string
+}
+
+// NewServerBuilder returns a builder for a server with the given name and flavor;
+// all other server options can be set on the value returned by Build.
+func NewServerBuilder(name string, flavorRef string) *ServerBuilder {
+	return &ServerBuilder{
+		opts: &CreateServerOptions{
+			Name:      String(name),
+			FlavorRef: String(flavorRef),
+		},
+	}
+}
+
+// BootFromImage boots the server from the given image, on the local disk of the
+// hypervisor.
+func (b *ServerBuilder) BootFromImage(imageRef string) *ServerBuilder {
+	if b.checkBootSource() {
+		b.image = String(imageRef)
+	}
+	return b
+}
+
+// BootFromVolume boots the server from an existing bootable volume, which is
+// deleted along with the server if deleteOnTermination is true.
+func (b *ServerBuilder) BootFromVolume(volumeID string, deleteOnTermination bool) *ServerBuilder {
+	if b.checkBootSource() {
+		b.boot = &BlockDeviceMapping{
+			BootIndex:           Int(0),
+			UUID:                String(volumeID),
+			SourceType:          String(BlockDeviceSourceVolume),
+			DestinationType:     String(BlockDeviceDestinationVolume),
+			DeleteOnTermination: Bool(deleteOnTermination),
+		}
+	}
+	return b
+}
+
+// BootFromSnapshot boots the server from a new volume created from the given
+// volume snapshot; the volume size defaults to that of the snapshot if sizeGB is
+// zero.
+func (b *ServerBuilder) BootFromSnapshot(snapshotID string, sizeGB int, deleteOnTermination bool) *ServerBuilder {
+	if sizeGB < 0 {
+		b.problems = append(b.problems, fmt.Sprintf("invalid volume size for snapshot %q: %d", snapshotID, sizeGB))
+		return b
+	}
+	if b.checkBootSource() {
+		b.boot = &BlockDeviceMapping{
+			BootIndex:           Int(0),
+			UUID:                String(snapshotID),
+			SourceType:          String(BlockDeviceSourceSnapshot),
+			DestinationType:     String(BlockDeviceDestinationVolume),
+			DeleteOnTermination: Bool(deleteOnTermination),
+		}
+		if sizeGB > 0 {
+			b.boot.VolumeSize = Int(sizeGB)
+		}
+	}
+	return b
+}
+
+// BootFromImageToVolume boots the server from a new volume of the given size
+// (which is mandatory), optionally of the given volume type, created from the
+// given image.
+func (b *ServerBuilder) BootFromImageToVolume(imageRef string, sizeGB int, volumeType *string, deleteOnTermination bool) *ServerBuilder {
+	if sizeGB <= 0 {
+		b.problems = append(b.problems, fmt.Sprintf("invalid volume size for image %q: %d", imageRef, sizeGB))
+		return b
+	}
+	if b.checkBootSource() {
+		b.boot = &BlockDeviceMapping{
+			BootIndex:           Int(0),
+			UUID:                String(imageRef),
+			SourceType:          String(BlockDeviceSourceImage),
+			DestinationType:     String(BlockDeviceDestinationVolume),
+			VolumeSize:          Int(sizeGB),
+			VolumeType:          volumeType,
+			DeleteOnTermination: Bool(deleteOnTermination),
+		}
+	}
+	return b
+}
+
+// AttachVolume attaches an additional, existing volume to the server.
+func (b *ServerBuilder) AttachVolume(volumeID string, deleteOnTermination bool) *ServerBuilder {
+	b.devices = append(b.devices, BlockDeviceMapping{
+		BootIndex:           Int(-1),
+		UUID:                String(volumeID),
+		SourceType:          String(BlockDeviceSourceVolume),
+		DestinationType:     String(BlockDeviceDestinationVolume),
+		DeleteOnTermination: Bool(deleteOnTermination),
+	})
+	return b
+}
+
+// AttachBlankVolume creates a new, empty volume of the given size and attaches
+// it to the server.
+func (b *ServerBuilder) AttachBlankVolume(sizeGB int, volumeType *string, deleteOnTermination bool) *ServerBuilder {
+	if sizeGB <= 0 {
+		b.problems = append(b.problems, fmt.Sprintf("invalid blank volume size: %d", sizeGB))
+		return b
+	}
+	b.devices = append(b.devices, BlockDeviceMapping{
+		BootIndex:           Int(-1),
+		SourceType:          String(BlockDeviceSourceBlank),
+		DestinationType:     String(BlockDeviceDestinationVolume),
+		VolumeSize:          Int(sizeGB),
+		VolumeType:          volumeType,
+		DeleteOnTermination: Bool(deleteOnTermination),
+	})
+	return b
+}
+
+// AddEphemeral adds a local ephemeral disk of the given size, formatted with the
+// given file system (e.g. "ext4"; it can be empty to use the default); the
+// total size of the ephemeral disks cannot exceed that of the flavor.
+func (b *ServerBuilder) AddEphemeral(sizeGB int, format string) *ServerBuilder {
+	if sizeGB <= 0 {
+		b.problems = append(b.problems, fmt.Sprintf("invalid ephemeral disk size: %d", sizeGB))
+		return b
+	}
+	device := BlockDeviceMapping{
+		BootIndex:           Int(-1),
+		SourceType:          String(BlockDeviceSourceBlank),
+		DestinationType:     String(BlockDeviceDestinationLocal),
+		VolumeSize:          Int(sizeGB),
+		DeleteOnTermination: Bool(true),
+	}
+	if format != "" {
+		device.GuestFormat = String(format)
+	}
+	b.devices = append(b.devices, device)
+	return b
+}
+
+// AddSwap adds a local swap disk of the given size in MiB, which cannot exceed
+// the swap size of the flavor; only one swap disk is allowed.
+func (b *ServerBuilder) AddSwap(sizeMB int) *ServerBuilder {
+	if sizeMB <= 0 {
+		b.problems = append(b.problems, fmt.Sprintf("invalid swap size: %d", sizeMB))
+		return b
+	}
+	if b.hasSwap {
+		b.problems = append(b.problems, "only one swap disk is allowed")
+		return b
+	}
+	b.hasSwap = true
+	b.devices = append(b.devices, BlockDeviceMapping{
+		BootIndex:           Int(-1),
+		SourceType:          String(BlockDeviceSourceBlank),
+		DestinationType:     String(BlockDeviceDestinationLocal),
+		VolumeSize:          Int(sizeMB),
+		GuestFormat:         String("swap"),
+		DeleteOnTermination: Bool(true),
+	})
+	return b
+}
+
+// Build validates the configuration and returns the options to be passed to
+// CreateServer; other options (networks, key name, user data...) can be set on
+// the returned value.
+func (b *ServerBuilder) Build() (*CreateServerOptions, error) {
+	if b.image == nil && b.boot == nil && len(b.problems) == 0 {
+		b.problems = append(b.problems, "no boot source specified")
+	}
+	if len(b.problems) > 0 {
+		return nil, fmt.Errorf("invalid server configuration: %v", b.problems)
+	}
+
+	opts := *b.opts
+	opts.ImageRef = b.image
+	devices := []BlockDeviceMapping{}
+	if b.boot != nil {
+		devices = append(devices, *b.boot)
+	} else if len(b.devices) > 0 {
+		// when block device mappings are given, the image must be mapped too
+		devices = append(devices, BlockDeviceMapping{
+			BootIndex:           Int(0),
+			UUID:                b.image,
+			SourceType:          String(BlockDeviceSourceImage),
+			DestinationType:     String(BlockDeviceDestinationLocal),
+			DeleteOnTermination: Bool(true),
+		})
+	}
+	devices = append(devices, b.devices...)
+	if len(devices) > 0 {
+		opts.BlockDeviceMappings = &devices
+	}
+	return &opts, nil
+}
+
+// checkBootSource records an error if a boot source has already been set.
+func (b *ServerBuilder) checkBootSource() bool {
+	if b.image != nil || b.boot != nil {
+		b.problems = append(b.problems, "multiple boot sources specified")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package openstack
+
+import (
+	"testing"
+)
+
+func TestServerBuilder(t *testing.T) {
+	opts, err := NewServerBuilder("test", "m1.small").
+		BootFromImageToVolume("image-id", 20, nil, true).
+		AttachVolume("volume-id", false).
+		AddEphemeral(10, "ext4").
+		AddSwap(512).
+		Build()
+	if err != nil {
+		t.Fatalf("Compute.TestServerBuilder: unexpected error: %v", err)
+	}
+	if opts.ImageRef != nil {
+		t.Fatalf("Compute.TestServerBuilder: expected no image reference when booting from volume")
+	}
+	devices := *opts.BlockDeviceMappings
+	if len(devices) != 4 {
+		t.Fatalf("Compute.TestServerBuilder: expected 4 block devices, got %d", len(devices))
+	}
+	if *devices[0].BootIndex != 0 || *devices[0].SourceType != BlockDeviceSourceImage || *devices[0].DestinationType != BlockDeviceDestinationVolume || *devices[0].VolumeSize != 20 {
+		t.Fatalf("Compute.TestServerBuilder: invalid boot device %+v", devices[0])
+	}
+	if *devices[3].GuestFormat != "swap" || *devices[3].DestinationType != BlockDeviceDestinationLocal {
+		t.Fatalf("Compute.TestServerBuilder: invalid swap device %+v", devices[3])
+	}
+
+	opts, err = NewServerBuilder("test", "m1.small").BootFromImage("image-id").AddSwap(512).Build()
+	if err != nil {
+		t.Fatalf("Compute.TestServerBuilder: unexpected error: %v", err)
+	}
+	if *opts.ImageRef != "image-id" || len(*opts.BlockDeviceMappings) != 2 || *(*opts.BlockDeviceMappings)[0].UUID != "image-id" {
+		t.Fatalf("Compute.TestServerBuilder: the image must be mapped as boot device")
+	}
+
+	if _, err = NewServerBuilder("test", "m1.small").BootFromImage("image-id").BootFromVolume("volume-id", true).Build(); err == nil {
+		t.Fatalf("Compute.TestServerBuilder: expected error on multiple boot sources")
+	}
+	if _, err = NewServerBuilder("test", "m1.small").AttachVolume("volume-id", true).Build(); err == nil {
+		t.Fatalf("Compute.TestServerBuilder: expected error on missing boot source")
+	}
+	if _, err = NewServerBuilder("test", "m1.small").BootFromImage("image-id").AddSwap(512).AddSwap(512).Build(); err == nil {
+		t.Fatalf("Compute.TestServerBuilder: expected error on multiple swap disks")
+	}
+	if _, err = NewServerBuilder("test", "m1.small").BootFromImageToVolume("image-id", 0, nil, true).Build(); err == nil {
+		t.Fatalf("Compute.TestServerBuilder: expected error on missing volume size")
+	}
+}
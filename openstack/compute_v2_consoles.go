@@ -0,0 +1,147 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// ConsoleProtocolVNC is the VNC remote console protocol.
+	ConsoleProtocolVNC = "vnc"
+	// ConsoleProtocolSPICE is the SPICE remote console protocol.
+	ConsoleProtocolSPICE = "spice"
+	// ConsoleProtocolSerial is the serial remote console protocol.
+	ConsoleProtocolSerial = "serial"
+	// ConsoleProtocolRDP is the RDP remote console protocol.
+	ConsoleProtocolRDP = "rdp"
+	// ConsoleProtocolMKS is the WebMKS remote console protocol (microversion 2.8).
+	ConsoleProtocolMKS = "mks"
+)
+
+const (
+	// ConsoleTypeNoVNC is the noVNC (HTML5) console type, for the VNC protocol.
+	ConsoleTypeNoVNC = "novnc"
+	// ConsoleTypeXVPVNC is the Java VNC console type, for the VNC protocol.
+	ConsoleTypeXVPVNC = "xvpvnc"
+	// ConsoleTypeSPICEHTML5 is the HTML5 console type, for the SPICE protocol.
+	ConsoleTypeSPICEHTML5 = "spice-html5"
+	// ConsoleTypeSerial is the console type for the serial protocol.
+	ConsoleTypeSerial = "serial"
+	// ConsoleTypeRDPHTML5 is the HTML5 console type, for the RDP protocol.
+	ConsoleTypeRDPHTML5 = "rdp-html5"
+	// ConsoleTypeWebMKS is the console type for the MKS protocol.
+	ConsoleTypeWebMKS = "webmks"
+)
+
+/*
+ * CREATE REMOTE CONSOLE
+ */
+
+// CreateRemoteConsole returns the URL of a remote console to the server, with
+// the given protocol (e.g. ConsoleProtocolVNC) and type (e.g. ConsoleTypeNoVNC);
+// it requires microversion 2.6 or later, which is sent by default if none is
+// given; see also https://developer.openstack.org/api-ref/compute/#create-remote-console
+func (api *ComputeV2API) CreateRemoteConsole(serverid string, protocol string, consoletype string, microversion *string) (*RemoteConsole, *Result, error) {
+	if microversion == nil {
+		microversion = String("2.6")
+	}
+	input := &struct {
+		ServerID      string  `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		Microversion  *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+		RemoteConsole struct {
+			Protocol string `json:"protocol"`
+			Type     string `json:"type"`
+		} `parameter:"-" header:"-" json:"remote_console"`
+	}{
+		ServerID:     serverid,
+		Microversion: microversion,
+	}
+	input.RemoteConsole.Protocol = protocol
+	input.RemoteConsole.Type = consoletype
+	output := &struct {
+		RemoteConsole *RemoteConsole `header:"-" json:"remote_console,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPost, "./servers/{serverid}/remote-consoles", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.RemoteConsole, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * GET CONSOLE (LEGACY ACTIONS)
+ */
+
+// GetVNCConsole returns the URL of a VNC console to the server, of the given
+// type (ConsoleTypeNoVNC or ConsoleTypeXVPVNC); this action is superseded by
+// CreateRemoteConsole since microversion 2.6; see also
+// https://developer.openstack.org/api-ref/compute/#get-vnc-console-os-getvncconsole-action-deprecated
+func (api *ComputeV2API) GetVNCConsole(serverid string, consoletype string) (*RemoteConsole, *Result, error) {
+	return api.getConsole(serverid, "os-getVNCConsole", consoletype)
+}
+
+// GetSPICEConsole returns the URL of a SPICE console to the server; this action
+// is superseded by CreateRemoteConsole since microversion 2.6; see also
+// https://developer.openstack.org/api-ref/compute/#get-spice-console-os-getspiceconsole-action-deprecated
+func (api *ComputeV2API) GetSPICEConsole(serverid string) (*RemoteConsole, *Result, error) {
+	return api.getConsole(serverid, "os-getSPICEConsole", ConsoleTypeSPICEHTML5)
+}
+
+// GetSerialConsole returns the URL of a serial console to the server; this
+// action is superseded by CreateRemoteConsole since microversion 2.6; see also
+// https://developer.openstack.org/api-ref/compute/#get-serial-console-os-getserialconsole-action-deprecated
+func (api *ComputeV2API) GetSerialConsole(serverid string) (*RemoteConsole, *Result, error) {
+	return api.getConsole(serverid, "os-getSerialConsole", ConsoleTypeSerial)
+}
+
+// getConsole invokes one of the legacy console actions, which all share the
+// same request and response format.
+func (api *ComputeV2API) getConsole(serverid string, action string, consoletype string) (*RemoteConsole, *Result, error) {
+	body := &struct {
+		Type string `json:"type"`
+	}{
+		Type: consoletype,
+	}
+	output := &struct {
+		Console *RemoteConsole `header:"-" json:"console,omitempty"`
+	}{}
+
+	result, err := api.invokeServerAction(serverid, nil, action, body, StatusCodeIn(200), output)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Console, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * SHOW CONSOLE OUTPUT
+ */
+
+// GetConsoleOutput returns the console output (the boot log) of the server; if
+// length is not nil, only the given number of trailing lines is returned; see
+// also https://developer.openstack.org/api-ref/compute/#show-console-output-os-getconsoleoutput-action
+func (api *ComputeV2API) GetConsoleOutput(serverid string, length *int) (*string, *Result, error) {
+	body := &struct {
+		Length *int `json:"length,omitempty"`
+	}{
+		Length: length,
+	}
+	output := &struct {
+		Output *string `header:"-" json:"output,omitempty"`
+	}{}
+
+	result, err := api.invokeServerAction(serverid, nil, "os-getConsoleOutput", body, StatusCodeIn(200), output)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Output, result, err
+	}
+	return nil, result, err
+}
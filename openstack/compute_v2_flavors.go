@@ -0,0 +1,72 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST FLAVORS
+ */
+
+// ListFlavorsOptions provides the options available for listing the flavors;
+// IsPublic is "true", "false" or "none" (both public and private flavors, for
+// administrators); MinDisk (in GiB) and MinRAM (in MiB) filter out the
+// smaller flavors.
+type ListFlavorsOptions struct {
+	IsPublic *string `parameter:"is_public,omitempty" header:"-" json:"-"`
+	MinDisk  *int    `parameter:"minDisk,omitempty" header:"-" json:"-"`
+	MinRAM   *int    `parameter:"minRam,omitempty" header:"-" json:"-"`
+	SortKey  *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir  *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit    *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker   *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListFlavors returns the list of flavors with all their details; opts can be
+// nil; see also
+// https://developer.openstack.org/api-ref/compute/#list-flavors-with-details
+func (api *ComputeV2API) ListFlavors(opts *ListFlavorsOptions) (*[]Flavor, *Result, error) {
+	if opts == nil {
+		opts = &ListFlavorsOptions{}
+	}
+	output := &struct {
+		Flavors *[]Flavor `header:"-" json:"flavors,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./flavors/detail", true, StatusCodeIn(200), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Flavors, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE FLAVOR
+ */
+
+// RetrieveFlavor retrieves the flavor with the given ID; see also
+// https://developer.openstack.org/api-ref/compute/#show-flavor-details
+func (api *ComputeV2API) RetrieveFlavor(flavorid string) (*Flavor, *Result, error) {
+	input := &struct {
+		FlavorID string `parameter:"-" header:"-" variable:"flavorid" json:"-"`
+	}{
+		FlavorID: flavorid,
+	}
+	output := &struct {
+		Flavor *Flavor `header:"-" json:"flavor,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./flavors/{flavorid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Flavor, result, err
+	}
+	return nil, result, err
+}
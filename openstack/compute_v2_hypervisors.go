@@ -0,0 +1,148 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST HYPERVISORS
+ */
+
+// ListHypervisorsOptions provides the options available for listing hypervisors;
+// Limit and Marker require microversion 2.33, HostnamePattern and WithServers
+// microversion 2.53.
+type ListHypervisorsOptions struct {
+	Limit           *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker          *string `parameter:"marker,omitempty" header:"-" json:"-"`
+	HostnamePattern *string `parameter:"hypervisor_hostname_pattern,omitempty" header:"-" json:"-"`
+	WithServers     *bool   `parameter:"with_servers,omitempty" header:"-" json:"-"`
+	Microversion    *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+}
+
+// ListHypervisors returns the list of hypervisors, with their IDs, host names,
+// state and status only; see also
+// https://developer.openstack.org/api-ref/compute/#list-hypervisors
+func (api *ComputeV2API) ListHypervisors(opts *ListHypervisorsOptions) (*[]Hypervisor, *Result, error) {
+	return api.listHypervisors("./os-hypervisors", opts)
+}
+
+/*
+ * LIST HYPERVISORS DETAILED
+ */
+
+// ListHypervisorsDetail returns the list of hypervisors with all their details,
+// including resource usage; see also
+// https://developer.openstack.org/api-ref/compute/#list-hypervisors-details
+func (api *ComputeV2API) ListHypervisorsDetail(opts *ListHypervisorsOptions) (*[]Hypervisor, *Result, error) {
+	return api.listHypervisors("./os-hypervisors/detail", opts)
+}
+
+// listHypervisors lists hypervisors at the given path (summary or detail).
+func (api *ComputeV2API) listHypervisors(path string, opts *ListHypervisorsOptions) (*[]Hypervisor, *Result, error) {
+	output := &struct {
+		Hypervisors *[]Hypervisor `header:"-" json:"hypervisors,omitempty"`
+		Links       *[]Link       `header:"-" json:"hypervisors_links,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, path, true, StatusCodeIn(200), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Hypervisors, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE HYPERVISOR
+ */
+
+// RetrieveHypervisorOptions provides the options available for retrieving a
+// hypervisor; WithServers requires microversion 2.53.
+type RetrieveHypervisorOptions struct {
+	WithServers  *bool   `parameter:"with_servers,omitempty" header:"-" json:"-"`
+	Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+}
+
+// RetrieveHypervisor retrieves the details of the hypervisor identified by the
+// given id; opts can be nil; see also
+// https://developer.openstack.org/api-ref/compute/#show-hypervisor-details
+func (api *ComputeV2API) RetrieveHypervisor(hypervisorid string, opts *RetrieveHypervisorOptions) (*Hypervisor, *Result, error) {
+	if opts == nil {
+		opts = &RetrieveHypervisorOptions{}
+	}
+	input := &struct {
+		HypervisorID string `parameter:"-" header:"-" variable:"hypervisorid" json:"-"`
+		*RetrieveHypervisorOptions
+	}{
+		HypervisorID:              hypervisorid,
+		RetrieveHypervisorOptions: opts,
+	}
+	output := &struct {
+		Hypervisor *Hypervisor `header:"-" json:"hypervisor,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./os-hypervisors/{hypervisorid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Hypervisor, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE HYPERVISOR UPTIME
+ */
+
+// RetrieveHypervisorUptime retrieves the hypervisor with its uptime (as reported
+// by the uptime command on the host); it is deprecated since microversion 2.88,
+// which reports the uptime in RetrieveHypervisor; see also
+// https://developer.openstack.org/api-ref/compute/#show-hypervisor-uptime-deprecated
+func (api *ComputeV2API) RetrieveHypervisorUptime(hypervisorid string) (*Hypervisor, *Result, error) {
+	input := &struct {
+		HypervisorID string `parameter:"-" header:"-" variable:"hypervisorid" json:"-"`
+	}{
+		HypervisorID: hypervisorid,
+	}
+	output := &struct {
+		Hypervisor *Hypervisor `header:"-" json:"hypervisor,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./os-hypervisors/{hypervisorid}/uptime", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Hypervisor, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * LIST HYPERVISOR SERVERS
+ */
+
+// ListHypervisorServers returns the hypervisors whose host name matches the
+// given pattern, each with the list of servers it runs; it is deprecated since
+// microversion 2.53, which provides the WithServers option to ListHypervisors;
+// see also https://developer.openstack.org/api-ref/compute/#list-hypervisor-servers-deprecated
+func (api *ComputeV2API) ListHypervisorServers(pattern string) (*[]Hypervisor, *Result, error) {
+	input := &struct {
+		Pattern string `parameter:"-" header:"-" variable:"pattern" json:"-"`
+	}{
+		Pattern: pattern,
+	}
+	output := &struct {
+		Hypervisors *[]Hypervisor `header:"-" json:"hypervisors,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./os-hypervisors/{pattern}/servers", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Hypervisors, result, err
+	}
+	return nil, result, err
+}
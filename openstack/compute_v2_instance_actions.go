@@ -0,0 +1,83 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST INSTANCE ACTIONS
+ */
+
+// ListInstanceActionsOptions provides the options available for listing the
+// actions performed on a server; Limit, Marker and ChangesSince require
+// microversion 2.58, ChangesBefore microversion 2.66.
+type ListInstanceActionsOptions struct {
+	Limit         *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker        *string `parameter:"marker,omitempty" header:"-" json:"-"`
+	ChangesSince  *string `parameter:"changes-since,omitempty" header:"-" json:"-"`
+	ChangesBefore *string `parameter:"changes-before,omitempty" header:"-" json:"-"`
+	Microversion  *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+}
+
+// ListInstanceActions returns the actions performed on the server identified by
+// the given server id, most recent first, with the user and project that
+// requested them; opts can be nil; see also
+// https://developer.openstack.org/api-ref/compute/#list-actions-for-server
+func (api *ComputeV2API) ListInstanceActions(serverid string, opts *ListInstanceActionsOptions) (*[]InstanceAction, *Result, error) {
+	if opts == nil {
+		opts = &ListInstanceActionsOptions{}
+	}
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		*ListInstanceActionsOptions
+	}{
+		ServerID:                   serverid,
+		ListInstanceActionsOptions: opts,
+	}
+	output := &struct {
+		Actions *[]InstanceAction `header:"-" json:"instanceActions,omitempty"`
+		Links   *[]Link           `header:"-" json:"links,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/os-instance-actions", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Actions, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE INSTANCE ACTION
+ */
+
+// RetrieveInstanceAction retrieves the action identified by the given request
+// id, with its events, performed on the given server; microversion can be nil;
+// see also https://developer.openstack.org/api-ref/compute/#show-server-action-details
+func (api *ComputeV2API) RetrieveInstanceAction(serverid string, requestid string, microversion *string) (*InstanceAction, *Result, error) {
+	input := &struct {
+		ServerID     string  `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		RequestID    string  `parameter:"-" header:"-" variable:"requestid" json:"-"`
+		Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+	}{
+		ServerID:     serverid,
+		RequestID:    requestid,
+		Microversion: microversion,
+	}
+	output := &struct {
+		Action *InstanceAction `header:"-" json:"instanceAction,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/os-instance-actions/{requestid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Action, result, err
+	}
+	return nil, result, err
+}
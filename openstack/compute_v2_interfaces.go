@@ -0,0 +1,161 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST INTERFACE ATTACHMENTS
+ */
+
+// ListInterfaceAttachments returns the network interfaces attached to the server
+// identified by the given server id; see also
+// https://developer.openstack.org/api-ref/compute/#list-port-interfaces
+func (api *ComputeV2API) ListInterfaceAttachments(serverid string) (*[]InterfaceAttachment, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+	}{
+		ServerID: serverid,
+	}
+	output := &struct {
+		Attachments *[]InterfaceAttachment `header:"-" json:"interfaceAttachments,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/os-interface", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Attachments, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * ATTACH INTERFACE
+ */
+
+// AttachInterfaceOptions provides the options available for attaching a network
+// interface to a server: either an existing port (PortID) or a network (NetworkID)
+// on which a new port is created, optionally with the given fixed IP addresses;
+// Tag requires microversion 2.49.
+type AttachInterfaceOptions struct {
+	PortID       *string                       `json:"port_id,omitempty"`
+	NetworkID    *string                       `json:"net_id,omitempty"`
+	FixedIPs     *[]InterfaceAttachmentFixedIP `json:"fixed_ips,omitempty"`
+	Tag          *string                       `json:"tag,omitempty"`
+	Microversion *string                       `json:"-"`
+}
+
+// AttachInterface attaches a port, or a new port on the given network, to the
+// server identified by the given server id; see also
+// https://developer.openstack.org/api-ref/compute/#create-interface
+func (api *ComputeV2API) AttachInterface(serverid string, opts *AttachInterfaceOptions) (*InterfaceAttachment, *Result, error) {
+	input := &struct {
+		ServerID     string                  `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		Microversion *string                 `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+		Attachment   *AttachInterfaceOptions `parameter:"-" header:"-" json:"interfaceAttachment"`
+	}{
+		ServerID:     serverid,
+		Microversion: opts.Microversion,
+		Attachment:   opts,
+	}
+	output := &struct {
+		Attachment *InterfaceAttachment `header:"-" json:"interfaceAttachment,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPost, "./servers/{serverid}/os-interface", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Attachment, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE INTERFACE ATTACHMENT
+ */
+
+// RetrieveInterfaceAttachment retrieves the network interface identified by the
+// given port id attached to the given server; see also
+// https://developer.openstack.org/api-ref/compute/#show-port-interface-details
+func (api *ComputeV2API) RetrieveInterfaceAttachment(serverid string, portid string) (*InterfaceAttachment, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		PortID   string `parameter:"-" header:"-" variable:"portid" json:"-"`
+	}{
+		ServerID: serverid,
+		PortID:   portid,
+	}
+	output := &struct {
+		Attachment *InterfaceAttachment `header:"-" json:"interfaceAttachment,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/os-interface/{portid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Attachment, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DETACH INTERFACE
+ */
+
+// DetachInterface detaches the network interface identified by the given port id
+// from the given server; ports created by AttachInterface on a network are
+// deleted, pre-existing ports are only unbound; see also
+// https://developer.openstack.org/api-ref/compute/#detach-interface
+func (api *ComputeV2API) DetachInterface(serverid string, portid string) (bool, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		PortID   string `parameter:"-" header:"-" variable:"portid" json:"-"`
+	}{
+		ServerID: serverid,
+		PortID:   portid,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./servers/{serverid}/os-interface/{portid}", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// FindServerPort returns the network interface of the server that carries the
+// given fixed IP address or, if fixedaddress is nil, the first interface with a
+// fixed IP address; the PortID of the returned interface is what the Networking
+// service needs in order to associate a floating IP to the server, which is the
+// only supported way since Compute microversion 2.44; it returns nil if there is
+// no matching interface.
+func (api *ComputeV2API) FindServerPort(serverid string, fixedaddress *string) (*InterfaceAttachment, error) {
+	attachments, result, err := api.ListInterfaceAttachments(serverid)
+	if err != nil {
+		log.Errorf("error listing interfaces of server %q: %v", serverid, err)
+		return nil, err
+	}
+	if attachments == nil {
+		log.Errorf("error listing interfaces of server %q: %v", serverid, result)
+		return nil, fmt.Errorf("error listing interfaces of server %q: %v", serverid, result)
+	}
+	for _, attachment := range *attachments {
+		if attachment.FixedIPs == nil {
+			continue
+		}
+		for _, ip := range *attachment.FixedIPs {
+			if fixedaddress == nil || stringValue(ip.IPAddress) == *fixedaddress {
+				attachment := attachment
+				return &attachment, nil
+			}
+		}
+	}
+	log.Debugf("no interface found on server %q for address %q", serverid, stringValue(fixedaddress))
+	return nil, nil
+}
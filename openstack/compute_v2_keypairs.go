@@ -0,0 +1,163 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// KeypairTypeSSH is the type of SSH keypairs (the default).
+	KeypairTypeSSH = "ssh"
+	// KeypairTypeX509 is the type of x509 certificates (microversion 2.2).
+	KeypairTypeX509 = "x509"
+)
+
+// KeypairOptions provides the options shared by the keypair retrieval and
+// deletion calls; UserID allows administrators to manage the keypairs of other
+// users and requires microversion 2.10 or later.
+type KeypairOptions struct {
+	UserID       *string `parameter:"user_id,omitempty" header:"-" json:"-"`
+	Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+}
+
+/*
+ * LIST KEYPAIRS
+ */
+
+// ListKeypairsOptions provides the options available for listing keypairs;
+// UserID requires microversion 2.10, Limit and Marker microversion 2.35.
+type ListKeypairsOptions struct {
+	UserID       *string `parameter:"user_id,omitempty" header:"-" json:"-"`
+	Limit        *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker       *string `parameter:"marker,omitempty" header:"-" json:"-"`
+	Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+}
+
+// ListKeypairs returns the keypairs of the current user (or of the given user,
+// for administrators); see also
+// https://developer.openstack.org/api-ref/compute/#list-keypairs
+func (api *ComputeV2API) ListKeypairs(opts *ListKeypairsOptions) (*[]Keypair, *Result, error) {
+	output := &struct {
+		Keypairs *[]struct {
+			Keypair *Keypair `json:"keypair,omitempty"`
+		} `header:"-" json:"keypairs,omitempty"`
+		Links *[]Link `header:"-" json:"keypairs_links,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./os-keypairs", true, StatusCodeIn(200), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		keypairs := []Keypair{}
+		if output.Keypairs != nil {
+			for _, item := range *output.Keypairs {
+				if item.Keypair != nil {
+					keypairs = append(keypairs, *item.Keypair)
+				}
+			}
+		}
+		return &keypairs, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE OR IMPORT KEYPAIR
+ */
+
+// CreateKeypairOptions provides the options available for creating a keypair;
+// if PublicKey is given the key is imported, otherwise a new keypair is
+// generated and its private key returned; Type requires microversion 2.2,
+// UserID microversion 2.10.
+type CreateKeypairOptions struct {
+	Name         *string `json:"name,omitempty"`
+	PublicKey    *string `json:"public_key,omitempty"`
+	Type         *string `json:"type,omitempty"`
+	UserID       *string `json:"user_id,omitempty"`
+	Microversion *string `json:"-"`
+}
+
+// CreateKeypair generates a new keypair or imports an existing public key; when
+// the keypair is generated, the returned Keypair carries the private key, which
+// cannot be retrieved afterwards; see also
+// https://developer.openstack.org/api-ref/compute/#create-or-import-keypair
+func (api *ComputeV2API) CreateKeypair(opts *CreateKeypairOptions) (*Keypair, *Result, error) {
+	input := &struct {
+		Microversion *string               `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+		Keypair      *CreateKeypairOptions `parameter:"-" header:"-" json:"keypair"`
+	}{
+		Microversion: opts.Microversion,
+		Keypair:      opts,
+	}
+	output := &struct {
+		Keypair *Keypair `header:"-" json:"keypair,omitempty"`
+	}{}
+
+	// microversion 2.2 changed the status code from 200 to 201
+	result, err := api.Invoke(http.MethodPost, "./os-keypairs", true, StatusCodeIn(200, 201), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && (result.Code == 200 || result.Code == 201) {
+		return output.Keypair, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE KEYPAIR
+ */
+
+// RetrieveKeypair retrieves the keypair with the given name; opts can be nil;
+// see also https://developer.openstack.org/api-ref/compute/#show-keypair-details
+func (api *ComputeV2API) RetrieveKeypair(name string, opts *KeypairOptions) (*Keypair, *Result, error) {
+	if opts == nil {
+		opts = &KeypairOptions{}
+	}
+	input := &struct {
+		Name string `parameter:"-" header:"-" variable:"keypairname" json:"-"`
+		*KeypairOptions
+	}{
+		Name:           name,
+		KeypairOptions: opts,
+	}
+	output := &struct {
+		Keypair *Keypair `header:"-" json:"keypair,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./os-keypairs/{keypairname}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Keypair, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE KEYPAIR
+ */
+
+// DeleteKeypair deletes the keypair with the given name; opts can be nil; see
+// also https://developer.openstack.org/api-ref/compute/#delete-keypair
+func (api *ComputeV2API) DeleteKeypair(name string, opts *KeypairOptions) (bool, *Result, error) {
+	if opts == nil {
+		opts = &KeypairOptions{}
+	}
+	input := &struct {
+		Name string `parameter:"-" header:"-" variable:"keypairname" json:"-"`
+		*KeypairOptions
+	}{
+		Name:           name,
+		KeypairOptions: opts,
+	}
+
+	// microversion 2.2 changed the status code from 202 to 204
+	result, err := api.Invoke(http.MethodDelete, "./os-keypairs/{keypairname}", true, StatusCodeIn(202, 204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && (result.Code == 202 || result.Code == 204) {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,219 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dihedron/go-log"
+)
+
+// DrainAction is the action used to move servers away from a compute host.
+type DrainAction int8
+
+const (
+	// DrainLiveMigrate live-migrates the servers, with no downtime; it requires
+	// the compute service on the host to be up.
+	DrainLiveMigrate DrainAction = iota
+	// DrainMigrate cold-migrates the servers; migrated servers must then be
+	// confirmed with ConfirmResize.
+	DrainMigrate
+	// DrainEvacuate evacuates the servers; it requires the compute service on
+	// the host to be down.
+	DrainEvacuate
+)
+
+// String returns a human-readable name of the drain action.
+func (a DrainAction) String() string {
+	switch a {
+	case DrainLiveMigrate:
+		return "live-migrate"
+	case DrainMigrate:
+		return "migrate"
+	case DrainEvacuate:
+		return "evacuate"
+	}
+	return fmt.Sprintf("DrainAction(%d)", a)
+}
+
+// DrainHostOptions provides the options available for draining a compute host;
+// TargetHost is the destination host (nil lets the scheduler choose, which is
+// recommended); Concurrency is the maximum number of servers being moved at the
+// same time (defaults to 1); Interval is the interval at which each server is
+// polled until it has been moved (defaults to DefaultDrainInterval); Progress,
+// if not nil, is invoked (sequentially) as soon as each server has been moved,
+// or its move has failed.
+type DrainHostOptions struct {
+	Action       DrainAction
+	TargetHost   *string
+	Concurrency  int
+	Microversion *string
+	Interval     time.Duration
+	Progress     func(status DrainStatus)
+}
+
+// DefaultDrainInterval is the interval at which the servers being moved away
+// from a host are polled when none is given.
+const DefaultDrainInterval = 5 * time.Second
+
+// drainStartPolls is the number of times a server whose move has been accepted
+// is polled before giving up, if no task is ever seen on it and it is still on
+// the host (e.g. because the move was rejected by the scheduler between polls).
+const drainStartPolls = 5
+
+// DrainStatus reports the outcome of moving a server away from the host being
+// drained; a nil Error means that the server has left the host (a cold-migrated
+// server must still be confirmed with ConfirmResize); Result is the outcome of
+// the request to move the server.
+type DrainStatus struct {
+	Server Server
+	Result *Result
+	Error  error
+}
+
+// DrainHost moves all the servers on the given compute host away, by live or
+// cold migration or by evacuation, according to the options; each server is
+// polled until it has left the host, and no more than Concurrency servers are
+// being moved at any time; it returns the status of each server once all of
+// them have been moved, their move has failed or the context is done; an error
+// is returned only if the servers on the host cannot be listed. Listing the
+// servers on a host is reserved to administrators by default.
+func (api *ComputeV2API) DrainHost(ctx context.Context, host string, opts *DrainHostOptions) ([]DrainStatus, error) {
+	if opts == nil {
+		opts = &DrainHostOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	servers, result, err := api.ListServersDetail(&ListServersOptions{
+		Host:       String(host),
+		AllTenants: Bool(true),
+	})
+	if err != nil {
+		log.Errorf("error listing servers on host %q: %v", host, err)
+		return nil, err
+	}
+	if servers == nil {
+		log.Errorf("error listing servers on host %q: %v", host, result)
+		return nil, fmt.Errorf("error listing servers on host %q: %v", host, result)
+	}
+	log.Debugf("draining %d servers from host %q (%v, concurrency %d)", len(*servers), host, opts.Action, concurrency)
+
+	statuses := make([]DrainStatus, len(*servers))
+	semaphore := make(chan struct{}, concurrency)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for i, server := range *servers {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, server Server) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			status := api.drainServer(ctx, host, server, opts)
+			mutex.Lock()
+			defer mutex.Unlock()
+			statuses[i] = status
+			if opts.Progress != nil {
+				opts.Progress(status)
+			}
+		}(i, server)
+	}
+	wg.Wait()
+	return statuses, nil
+}
+
+// drainServer applies the drain action to a single server and waits until it
+// has left the host.
+func (api *ComputeV2API) drainServer(ctx context.Context, host string, server Server, opts *DrainHostOptions) DrainStatus {
+	status := DrainStatus{
+		Server: server,
+	}
+	serverid := stringValue(server.ID)
+	var ok bool
+	switch opts.Action {
+	case DrainLiveMigrate:
+		ok, status.Result, status.Error = api.LiveMigrateServer(serverid, &LiveMigrateServerOptions{
+			Host:         opts.TargetHost,
+			Microversion: opts.Microversion,
+		})
+	case DrainMigrate:
+		ok, status.Result, status.Error = api.MigrateServer(serverid, opts.TargetHost, opts.Microversion)
+	case DrainEvacuate:
+		_, status.Result, status.Error = api.EvacuateServer(serverid, &EvacuateServerOptions{
+			Host:         opts.TargetHost,
+			Microversion: opts.Microversion,
+		})
+		ok = status.Error == nil && status.Result != nil && status.Result.Code == 200
+	default:
+		status.Error = fmt.Errorf("unsupported drain action: %v", opts.Action)
+		return status
+	}
+	if status.Error == nil && !ok {
+		status.Error = fmt.Errorf("error performing %v on server %q: %v", opts.Action, serverid, status.Result)
+	}
+	if status.Error == nil {
+		log.Debugf("server %q: %v accepted, waiting for the server to leave host %q", serverid, opts.Action, host)
+		interval := opts.Interval
+		if interval <= 0 {
+			interval = DefaultDrainInterval
+		}
+		status.Error = api.waitForServerMove(ctx, serverid, host, interval)
+	}
+	if status.Error != nil {
+		log.Errorf("error draining server %q: %v", serverid, status.Error)
+	} else {
+		log.Debugf("server %q: %v complete", serverid, opts.Action)
+	}
+	return status
+}
+
+// waitForServerMove waits until the server has left the given host and no task
+// is pending on it; the move failed if the server goes into the ERROR status,
+// if its pending task completes with the server still on the host (e.g.
+// because no other host was available), or if no task is seen on the server
+// within the first drainStartPolls polls.
+func (api *ComputeV2API) waitForServerMove(ctx context.Context, serverid string, host string, interval time.Duration) error {
+	moving := false
+	polls := 0
+	return WaitFor(ctx, interval, func() (bool, error) {
+		server, result, err := api.RetrieveServer(serverid)
+		if err != nil {
+			return false, err
+		}
+		if server == nil {
+			return false, fmt.Errorf("error retrieving server %q: %v", serverid, result)
+		}
+		log.Debugf("server %q is on host %q (status: %s, task: %s)", serverid, stringValue(server.Host), stringValue((*string)(server.Status)), stringValue(server.TaskState))
+		if server.Status != nil && *server.Status == ServerStatusError {
+			if server.Fault != nil {
+				return false, fmt.Errorf("server %q is in ERROR status: %s", serverid, stringValue(server.Fault.Message))
+			}
+			return false, fmt.Errorf("server %q is in ERROR status", serverid)
+		}
+		if server.TaskState != nil {
+			moving = true
+			return false, nil
+		}
+		if stringValue(server.Host) != host {
+			return true, nil
+		}
+		if moving {
+			return false, fmt.Errorf("server %q is still on host %q", serverid, host)
+		}
+		// the task may not have started yet, unless it was rejected in between
+		polls++
+		if polls >= drainStartPolls {
+			return false, fmt.Errorf("server %q has not started moving away from host %q", serverid, host)
+		}
+		return false, nil
+	})
+}
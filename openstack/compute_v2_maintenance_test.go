@@ -0,0 +1,75 @@
+package openstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDrainHost(t *testing.T) {
+	var mutex sync.Mutex
+	polls := map[string]int{}
+	moving, peak := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/servers/"), "/action")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/servers/detail":
+			w.Write([]byte(`{"servers": [{"id": "vm1"}, {"id": "vm2"}, {"id": "vm3"}, {"id": "vm4"}, {"id": "vm5"}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/action"):
+			moving++
+			if moving > peak {
+				peak = moving
+			}
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodGet && id == "vm5":
+			// the move is rejected before any task is seen on the server
+			if polls[id]++; polls[id] == drainStartPolls {
+				moving--
+			}
+			w.Write([]byte(`{"server": {"id": "vm5", "status": "ACTIVE", "OS-EXT-SRV-ATTR:host": "h1"}}`))
+		case r.Method == http.MethodGet:
+			polls[id]++
+			switch {
+			case polls[id] < 3:
+				w.Write([]byte(`{"server": {"id": "` + id + `", "status": "MIGRATING", "OS-EXT-SRV-ATTR:host": "h1", "OS-EXT-STS:task_state": "migrating"}}`))
+			case id == "vm4":
+				// no host available: the task completes with the server on the host
+				moving--
+				w.Write([]byte(`{"server": {"id": "` + id + `", "status": "ACTIVE", "OS-EXT-SRV-ATTR:host": "h1"}}`))
+			default:
+				moving--
+				w.Write([]byte(`{"server": {"id": "` + id + `", "status": "ACTIVE", "OS-EXT-SRV-ATTR:host": "h2"}}`))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := NewDefaultClient(server.URL)
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+	api := &ComputeV2API{client.Authenticator.Identity.API}
+
+	statuses, err := api.DrainHost(context.Background(), "h1", &DrainHostOptions{
+		Concurrency: 2,
+		Interval:    time.Millisecond,
+	})
+	if err != nil || len(statuses) != 5 {
+		t.Fatalf("Compute.TestDrainHost: unexpected result %v (%v)", statuses, err)
+	}
+	for _, status := range statuses {
+		id := stringValue(status.Server.ID)
+		if failed := status.Error != nil; failed != (id == "vm4" || id == "vm5") {
+			t.Errorf("Compute.TestDrainHost: unexpected outcome for server %s: %v", id, status.Error)
+		}
+	}
+	if peak != 2 {
+		t.Errorf("Compute.TestDrainHost: expected at most 2 servers moving at the same time, got %d", peak)
+	}
+}
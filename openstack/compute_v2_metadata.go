@@ -0,0 +1,162 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * RETRIEVE SERVER METADATA
+ */
+
+// RetrieveServerMetadata returns all the metadata items of the server identified
+// by the given server id; see also
+// https://developer.openstack.org/api-ref/compute/#list-all-metadata
+func (api *ComputeV2API) RetrieveServerMetadata(serverid string) (map[string]string, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+	}{
+		ServerID: serverid,
+	}
+	output := &struct {
+		Metadata map[string]string `header:"-" json:"metadata,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/metadata", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Metadata, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * SET (REPLACE) SERVER METADATA
+ */
+
+// SetServerMetadata replaces all the metadata items of the server with the given
+// ones: items not in the map are removed; it returns the resulting metadata; see
+// also https://developer.openstack.org/api-ref/compute/#replace-metadata-items
+func (api *ComputeV2API) SetServerMetadata(serverid string, metadata map[string]string) (map[string]string, *Result, error) {
+	return api.writeServerMetadata(http.MethodPut, serverid, metadata)
+}
+
+/*
+ * UPDATE SERVER METADATA
+ */
+
+// UpdateServerMetadata creates or updates the given metadata items, leaving the
+// others untouched; it returns the resulting metadata; see also
+// https://developer.openstack.org/api-ref/compute/#create-or-update-metadata-items
+func (api *ComputeV2API) UpdateServerMetadata(serverid string, metadata map[string]string) (map[string]string, *Result, error) {
+	return api.writeServerMetadata(http.MethodPost, serverid, metadata)
+}
+
+// writeServerMetadata sends the metadata map with the given method: PUT replaces
+// the whole map, POST merges the items into the existing ones.
+func (api *ComputeV2API) writeServerMetadata(method string, serverid string, metadata map[string]string) (map[string]string, *Result, error) {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	input := &struct {
+		ServerID string            `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		Metadata map[string]string `parameter:"-" header:"-" json:"metadata"`
+	}{
+		ServerID: serverid,
+		Metadata: metadata,
+	}
+	output := &struct {
+		Metadata map[string]string `header:"-" json:"metadata,omitempty"`
+	}{}
+
+	result, err := api.Invoke(method, "./servers/{serverid}/metadata", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Metadata, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SERVER METADATA ITEM
+ */
+
+// RetrieveServerMetadataItem returns the value of the metadata item with the
+// given key; see also
+// https://developer.openstack.org/api-ref/compute/#show-metadata-item-details
+func (api *ComputeV2API) RetrieveServerMetadataItem(serverid string, key string) (*string, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		Key      string `parameter:"-" header:"-" variable:"key" json:"-"`
+	}{
+		ServerID: serverid,
+		Key:      key,
+	}
+	output := &struct {
+		Meta map[string]string `header:"-" json:"meta,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/metadata/{key}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		if value, ok := output.Meta[key]; ok {
+			return &value, result, err
+		}
+	}
+	return nil, result, err
+}
+
+/*
+ * SET SERVER METADATA ITEM
+ */
+
+// SetServerMetadataItem creates or replaces the metadata item with the given key;
+// see also https://developer.openstack.org/api-ref/compute/#create-or-update-metadata-item
+func (api *ComputeV2API) SetServerMetadataItem(serverid string, key string, value string) (bool, *Result, error) {
+	input := &struct {
+		ServerID string            `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		Key      string            `parameter:"-" header:"-" variable:"key" json:"-"`
+		Meta     map[string]string `parameter:"-" header:"-" json:"meta"`
+	}{
+		ServerID: serverid,
+		Key:      key,
+		Meta: map[string]string{
+			key: value,
+		},
+	}
+
+	result, err := api.Invoke(http.MethodPut, "./servers/{serverid}/metadata/{key}", true, StatusCodeIn(200), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * DELETE SERVER METADATA ITEM
+ */
+
+// DeleteServerMetadataItem deletes the metadata item with the given key; see also
+// https://developer.openstack.org/api-ref/compute/#delete-metadata-item
+func (api *ComputeV2API) DeleteServerMetadataItem(serverid string, key string) (bool, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		Key      string `parameter:"-" header:"-" variable:"key" json:"-"`
+	}{
+		ServerID: serverid,
+		Key:      key,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./servers/{serverid}/metadata/{key}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,295 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// MigrationTypeLive is the type of live migrations.
+	MigrationTypeLive = "live-migration"
+	// MigrationTypeCold is the type of cold migrations.
+	MigrationTypeCold = "migration"
+	// MigrationTypeResize is the type of resizes.
+	MigrationTypeResize = "resize"
+	// MigrationTypeEvacuation is the type of evacuations.
+	MigrationTypeEvacuation = "evacuation"
+)
+
+// BlockMigrationAuto lets the Compute service decide whether a live migration
+// must copy the server disks, depending on whether they are on shared storage
+// (microversion 2.25).
+const BlockMigrationAuto = "auto"
+
+/*
+ * LIST MIGRATIONS
+ */
+
+// ListMigrationsOptions provides the options available for filtering the list
+// of migrations; MigrationType requires microversion 2.23, Limit, Marker and
+// ChangesSince microversion 2.59, ChangesBefore microversion 2.66, UserID and
+// ProjectID microversion 2.80.
+type ListMigrationsOptions struct {
+	Hidden        *bool   `parameter:"hidden,omitempty" header:"-" json:"-"`
+	Host          *string `parameter:"host,omitempty" header:"-" json:"-"`
+	InstanceUUID  *string `parameter:"instance_uuid,omitempty" header:"-" json:"-"`
+	MigrationType *string `parameter:"migration_type,omitempty" header:"-" json:"-"`
+	SourceCompute *string `parameter:"source_compute,omitempty" header:"-" json:"-"`
+	Status        *string `parameter:"status,omitempty" header:"-" json:"-"`
+	ChangesSince  *string `parameter:"changes-since,omitempty" header:"-" json:"-"`
+	ChangesBefore *string `parameter:"changes-before,omitempty" header:"-" json:"-"`
+	UserID        *string `parameter:"user_id,omitempty" header:"-" json:"-"`
+	ProjectID     *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	Limit         *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker        *string `parameter:"marker,omitempty" header:"-" json:"-"`
+	Microversion  *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+}
+
+// ListMigrations returns the list of migrations in the cloud, which is reserved
+// to administrators by default; see also
+// https://developer.openstack.org/api-ref/compute/#list-migrations
+func (api *ComputeV2API) ListMigrations(opts *ListMigrationsOptions) (*[]Migration, *Result, error) {
+	output := &struct {
+		Migrations *[]Migration `header:"-" json:"migrations,omitempty"`
+		Links      *[]Link      `header:"-" json:"migrations_links,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./os-migrations", true, StatusCodeIn(200), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Migrations, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * LIVE-MIGRATE SERVER
+ */
+
+// LiveMigrateServerOptions provides the options available for live-migrating a
+// server; if Host is nil the scheduler picks the destination host; if
+// BlockMigration is nil it defaults to BlockMigrationAuto, which requires
+// microversion 2.25 (use a boolean for earlier microversions); Force requires
+// microversions 2.30 to 2.67.
+type LiveMigrateServerOptions struct {
+	Host           *string     `json:"host"`
+	BlockMigration interface{} `json:"block_migration"`
+	Force          *bool       `json:"force,omitempty"`
+	Microversion   *string     `json:"-"`
+}
+
+// LiveMigrateServer live-migrates the server to another host, with no downtime;
+// the migration can be followed with ListServerMigrations; see also
+// https://developer.openstack.org/api-ref/compute/#live-migrate-server-os-migratelive-action
+func (api *ComputeV2API) LiveMigrateServer(serverid string, opts *LiveMigrateServerOptions) (bool, *Result, error) {
+	body := LiveMigrateServerOptions{}
+	if opts != nil {
+		body = *opts
+	}
+	if body.BlockMigration == nil {
+		body.BlockMigration = BlockMigrationAuto
+		if body.Microversion == nil {
+			body.Microversion = String("2.25")
+		}
+	}
+	result, err := api.invokeServerAction(serverid, body.Microversion, "os-migrateLive", &body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * MIGRATE SERVER
+ */
+
+// MigrateServer cold-migrates the server to another host; the server is stopped
+// during the migration and goes into the VERIFY_RESIZE status at the end, so the
+// migration must be confirmed (ConfirmResize) or reverted (RevertResize); a
+// specific destination host can be given starting with microversion 2.56, which
+// is sent by default if host is not nil and microversion is; see also
+// https://developer.openstack.org/api-ref/compute/#migrate-server-migrate-action
+func (api *ComputeV2API) MigrateServer(serverid string, host *string, microversion *string) (bool, *Result, error) {
+	var body interface{}
+	if host != nil {
+		body = &struct {
+			Host *string `json:"host"`
+		}{
+			Host: host,
+		}
+		if microversion == nil {
+			microversion = String("2.56")
+		}
+	}
+	result, err := api.invokeServerAction(serverid, microversion, "migrate", body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * LIST SERVER MIGRATIONS
+ */
+
+// ListServerMigrations returns the in-progress live migrations of the server;
+// it requires microversion 2.23 or later, which is sent by default if none is
+// given; see also https://developer.openstack.org/api-ref/compute/#list-migrations-server
+func (api *ComputeV2API) ListServerMigrations(serverid string, microversion *string) (*[]Migration, *Result, error) {
+	if microversion == nil {
+		microversion = String("2.23")
+	}
+	input := &struct {
+		ServerID     string  `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+	}{
+		ServerID:     serverid,
+		Microversion: microversion,
+	}
+	output := &struct {
+		Migrations *[]Migration `header:"-" json:"migrations,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/migrations", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Migrations, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SERVER MIGRATION
+ */
+
+// RetrieveServerMigration retrieves an in-progress live migration of the server;
+// it requires microversion 2.23 or later, which is sent by default if none is
+// given; see also https://developer.openstack.org/api-ref/compute/#show-migration-details
+func (api *ComputeV2API) RetrieveServerMigration(serverid string, migrationid string, microversion *string) (*Migration, *Result, error) {
+	if microversion == nil {
+		microversion = String("2.23")
+	}
+	input := &struct {
+		ServerID     string  `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		MigrationID  string  `parameter:"-" header:"-" variable:"migrationid" json:"-"`
+		Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+	}{
+		ServerID:     serverid,
+		MigrationID:  migrationid,
+		Microversion: microversion,
+	}
+	output := &struct {
+		Migration *Migration `header:"-" json:"migration,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/migrations/{migrationid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Migration, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * FORCE MIGRATION COMPLETE
+ */
+
+// ForceCompleteMigration forces an in-progress live migration to complete, by
+// pausing the server (or switching to post-copy, if enabled); it requires
+// microversion 2.22 or later, which is sent by default if none is given; see
+// also https://developer.openstack.org/api-ref/compute/#force-migration-complete-action-force-complete-action
+func (api *ComputeV2API) ForceCompleteMigration(serverid string, migrationid string, microversion *string) (bool, *Result, error) {
+	if microversion == nil {
+		microversion = String("2.22")
+	}
+	input := &struct {
+		ServerID      string    `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		MigrationID   string    `parameter:"-" header:"-" variable:"migrationid" json:"-"`
+		Microversion  *string   `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+		ForceComplete *struct{} `parameter:"-" header:"-" json:"force_complete"`
+	}{
+		ServerID:     serverid,
+		MigrationID:  migrationid,
+		Microversion: microversion,
+	}
+
+	result, err := api.Invoke(http.MethodPost, "./servers/{serverid}/migrations/{migrationid}/action", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * ABORT MIGRATION
+ */
+
+// AbortMigration aborts an in-progress live migration; it requires microversion
+// 2.24 or later, which is sent by default if none is given; see also
+// https://developer.openstack.org/api-ref/compute/#delete-abort-migration
+func (api *ComputeV2API) AbortMigration(serverid string, migrationid string, microversion *string) (bool, *Result, error) {
+	if microversion == nil {
+		microversion = String("2.24")
+	}
+	input := &struct {
+		ServerID     string  `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		MigrationID  string  `parameter:"-" header:"-" variable:"migrationid" json:"-"`
+		Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+	}{
+		ServerID:     serverid,
+		MigrationID:  migrationid,
+		Microversion: microversion,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./servers/{serverid}/migrations/{migrationid}", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * EVACUATE SERVER
+ */
+
+// EvacuateServerOptions provides the options available for evacuating a server;
+// if Host is nil the scheduler picks the destination host; OnSharedStorage is
+// only accepted before microversion 2.14, which detects it automatically; Force
+// requires microversions 2.29 to 2.67.
+type EvacuateServerOptions struct {
+	Host            *string `json:"host,omitempty"`
+	AdminPass       *string `json:"adminPass,omitempty"`
+	OnSharedStorage *bool   `json:"onSharedStorage,omitempty"`
+	Force           *bool   `json:"force,omitempty"`
+	Microversion    *string `json:"-"`
+}
+
+// EvacuateServer rebuilds the server on another host when its current host is
+// down (its compute service must be reported as down or forced down); before
+// microversion 2.14 the administrative password of the rebuilt server is
+// returned, otherwise the returned value is nil; opts can be nil; see also
+// https://developer.openstack.org/api-ref/compute/#evacuate-server-evacuate-action
+func (api *ComputeV2API) EvacuateServer(serverid string, opts *EvacuateServerOptions) (*string, *Result, error) {
+	if opts == nil {
+		opts = &EvacuateServerOptions{}
+	}
+	output := &struct {
+		AdminPass *string `header:"-" json:"adminPass,omitempty"`
+	}{}
+
+	result, err := api.invokeServerAction(serverid, opts.Microversion, "evacuate", opts, StatusCodeIn(200), output)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.AdminPass, result, err
+	}
+	return nil, result, err
+}
@@ -0,0 +1,140 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * SHOW SERVER PASSWORD
+ */
+
+// RetrieveServerPassword retrieves the encrypted administrative password of the
+// server, as set by the guest (e.g. by cloudbase-init on Windows guests), which
+// encrypts it with the public key of the server keypair; use DecryptPassword to
+// recover the clear-text password; an empty string is returned if the password
+// has not been set yet; see also
+// https://developer.openstack.org/api-ref/compute/#show-server-password
+func (api *ComputeV2API) RetrieveServerPassword(serverid string) (*string, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+	}{
+		ServerID: serverid,
+	}
+	output := &struct {
+		Password *string `header:"-" json:"password,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/os-server-password", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		if output.Password == nil {
+			output.Password = String("")
+		}
+		return output.Password, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CLEAR SERVER PASSWORD
+ */
+
+// ClearServerPassword clears the encrypted administrative password of the server
+// from the metadata service; it does not change the password on the server; see
+// also https://developer.openstack.org/api-ref/compute/#clear-admin-password
+func (api *ComputeV2API) ClearServerPassword(serverid string) (bool, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+	}{
+		ServerID: serverid,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./servers/{serverid}/os-server-password", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * CHANGE ADMINISTRATIVE PASSWORD
+ */
+
+// ChangeServerPassword changes the administrative password of the server, if
+// supported by the hypervisor and the guest agent; see also
+// https://developer.openstack.org/api-ref/compute/#change-administrative-password-changepassword-action
+func (api *ComputeV2API) ChangeServerPassword(serverid string, password string) (bool, *Result, error) {
+	body := &struct {
+		AdminPass string `json:"adminPass"`
+	}{
+		AdminPass: password,
+	}
+	result, err := api.invokeServerAction(serverid, nil, "changePassword", body, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// DecryptPassword decrypts a password as returned by RetrieveServerPassword
+// (base64-encoded, RSA PKCS#1 v1.5-encrypted) using the PEM-encoded RSA private
+// key of the server keypair, in PKCS#1 ("RSA PRIVATE KEY") or PKCS#8 ("PRIVATE
+// KEY") format; keys in the OpenSSH format must be converted first (e.g. with
+// "ssh-keygen -p -m PEM").
+func DecryptPassword(encrypted string, key []byte) (string, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		log.Errorf("invalid private key: no PEM data found")
+		return "", fmt.Errorf("invalid private key: no PEM data found")
+	}
+	var private *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			log.Errorf("error parsing PKCS#1 private key: %v", err)
+			return "", err
+		}
+		private = k
+	case "PRIVATE KEY":
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			log.Errorf("error parsing PKCS#8 private key: %v", err)
+			return "", err
+		}
+		var ok bool
+		if private, ok = k.(*rsa.PrivateKey); !ok {
+			log.Errorf("unsupported private key type: %T", k)
+			return "", fmt.Errorf("unsupported private key type: %T", k)
+		}
+	default:
+		log.Errorf("unsupported private key format: %q", block.Type)
+		return "", fmt.Errorf("unsupported private key format: %q", block.Type)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		log.Errorf("error decoding encrypted password: %v", err)
+		return "", err
+	}
+	plaintext, err := rsa.DecryptPKCS1v15(rand.Reader, private, ciphertext)
+	if err != nil {
+		log.Errorf("error decrypting password: %v", err)
+		return "", err
+	}
+	return string(plaintext), nil
+}
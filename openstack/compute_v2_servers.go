@@ -0,0 +1,333 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST SERVERS
+ */
+
+// ListServersOptions provides all the options available for filtering the list
+// of servers; filters on the same list are combined with a logical AND; some of
+// them (e.g. Host and AllTenants) are reserved to administrators by default;
+// see https://developer.openstack.org/api-ref/compute/#list-servers.
+type ListServersOptions struct {
+	Name             *string             `parameter:"name,omitempty" header:"-" json:"-"`
+	Status           *ServerStatus       `parameter:"status,omitempty" header:"-" json:"-"`
+	Flavor           *string             `parameter:"flavor,omitempty" header:"-" json:"-"`
+	Image            *string             `parameter:"image,omitempty" header:"-" json:"-"`
+	Host             *string             `parameter:"host,omitempty" header:"-" json:"-"`
+	IP               *string             `parameter:"ip,omitempty" header:"-" json:"-"`
+	IP6              *string             `parameter:"ip6,omitempty" header:"-" json:"-"`
+	AvailabilityZone *string             `parameter:"availability_zone,omitempty" header:"-" json:"-"`
+	ReservationID    *string             `parameter:"reservation_id,omitempty" header:"-" json:"-"`
+	AllTenants       *bool               `parameter:"all_tenants,omitempty" header:"-" json:"-"`
+	ProjectID        *string             `parameter:"project_id,omitempty" header:"-" json:"-"`
+	UserID           *string             `parameter:"user_id,omitempty" header:"-" json:"-"`
+	Deleted          *bool               `parameter:"deleted,omitempty" header:"-" json:"-"`
+	Tags             *CommaSeparatedList `parameter:"tags,omitempty" header:"-" json:"-"`
+	TagsAny          *CommaSeparatedList `parameter:"tags-any,omitempty" header:"-" json:"-"`
+	NotTags          *CommaSeparatedList `parameter:"not-tags,omitempty" header:"-" json:"-"`
+	NotTagsAny       *CommaSeparatedList `parameter:"not-tags-any,omitempty" header:"-" json:"-"`
+	ChangesSince     *string             `parameter:"changes-since,omitempty" header:"-" json:"-"`
+	SortKey          *string             `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir          *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit            *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker           *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListServers returns the list of servers (with their IDs, names and links
+// only); see also https://developer.openstack.org/api-ref/compute/#list-servers
+func (api *ComputeV2API) ListServers(opts *ListServersOptions) (*[]Server, *Result, error) {
+	output := &struct {
+		Servers *[]Server `header:"-" json:"servers,omitempty"`
+		Links   *[]Link   `header:"-" json:"servers_links,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers", true, StatusCodeIn(200), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Servers, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * LIST SERVERS DETAILED
+ */
+
+// ListServersDetail returns the list of servers with all their details; it
+// accepts the same filters as ListServers; see also
+// https://developer.openstack.org/api-ref/compute/#list-servers-detailed
+func (api *ComputeV2API) ListServersDetail(opts *ListServersOptions) (*[]Server, *Result, error) {
+	output := &struct {
+		Servers *[]Server `header:"-" json:"servers,omitempty"`
+		Links   *[]Link   `header:"-" json:"servers_links,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/detail", true, StatusCodeIn(200), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Servers, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SERVER
+ */
+
+// RetrieveServer retrieves the details of the server identified by the given
+// server id; see also https://developer.openstack.org/api-ref/compute/#show-server-details
+func (api *ComputeV2API) RetrieveServer(serverid string) (*Server, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+	}{
+		ServerID: serverid,
+	}
+	output := &struct {
+		Server *Server `header:"-" json:"server,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Server, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE SERVER
+ */
+
+const (
+	// NetworkAllocationAuto asks the Compute service to automatically allocate
+	// a network to the server, creating it if needed (microversion 2.37).
+	NetworkAllocationAuto = "auto"
+	// NetworkAllocationNone asks the Compute service not to attach the server to
+	// any network (microversion 2.37).
+	NetworkAllocationNone = "none"
+)
+
+// CreateServerOptions provides all the options available for creating a new
+// server; either ImageRef or a bootable BlockDeviceMappings entry must be given;
+// networks can be specified as a list of ports, networks and fixed IPs or,
+// starting with microversion 2.37, as an allocation policy (NetworkAllocationAuto
+// or NetworkAllocationNone); UserData is provided in clear and is base64-encoded
+// by CreateServer; see https://developer.openstack.org/api-ref/compute/#create-server.
+type CreateServerOptions struct {
+	Name                *string               `json:"name,omitempty"`
+	Description         *string               `json:"description,omitempty"`
+	ImageRef            *string               `json:"imageRef,omitempty"`
+	FlavorRef           *string               `json:"flavorRef,omitempty"`
+	Networks            *[]ServerNetwork      `json:"-"`
+	NetworkAllocation   *string               `json:"-"`
+	KeyName             *string               `json:"key_name,omitempty"`
+	SecurityGroups      *[]string             `json:"-"`
+	UserData            *string               `json:"-"`
+	Metadata            map[string]string     `json:"metadata,omitempty"`
+	AvailabilityZone    *string               `json:"availability_zone,omitempty"`
+	BlockDeviceMappings *[]BlockDeviceMapping `json:"block_device_mapping_v2,omitempty"`
+	ConfigDrive         *bool                 `json:"config_drive,omitempty"`
+	AdminPass           *string               `json:"adminPass,omitempty"`
+	AccessIPv4          *string               `json:"accessIPv4,omitempty"`
+	AccessIPv6          *string               `json:"accessIPv6,omitempty"`
+	DiskConfig          *string               `json:"OS-DCF:diskConfig,omitempty"`
+	MinCount            *int                  `json:"min_count,omitempty"`
+	MaxCount            *int                  `json:"max_count,omitempty"`
+	Tags                *[]string             `json:"tags,omitempty"`
+	SchedulerHints      map[string]string     `json:"-"`
+	Microversion        *string               `json:"-"`
+}
+
+// createServerEntity is the "server" entity in the create server request; it
+// adds to the options those fields whose format differs from what users would
+// naturally provide.
+type createServerEntity struct {
+	*CreateServerOptions
+	Networks       interface{}            `json:"networks,omitempty"`
+	SecurityGroups *[]ServerSecurityGroup `json:"security_groups,omitempty"`
+	UserData       *string                `json:"user_data,omitempty"`
+}
+
+// CreateServer creates a new server (boots an instance); the server is created
+// asynchronously, so the returned Server only contains its ID, its links and
+// the administrative password, if generated; use RetrieveServer to follow the
+// build progress; see also https://developer.openstack.org/api-ref/compute/#create-server
+func (api *ComputeV2API) CreateServer(opts *CreateServerOptions) (*Server, *Result, error) {
+	input := &struct {
+		Microversion   *string             `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+		Server         *createServerEntity `parameter:"-" header:"-" json:"server"`
+		SchedulerHints map[string]string   `parameter:"-" header:"-" json:"os:scheduler_hints,omitempty"`
+	}{
+		Microversion: opts.Microversion,
+		Server: &createServerEntity{
+			CreateServerOptions: opts,
+		},
+		SchedulerHints: opts.SchedulerHints,
+	}
+	if opts.NetworkAllocation != nil {
+		input.Server.Networks = *opts.NetworkAllocation
+	} else if opts.Networks != nil {
+		input.Server.Networks = opts.Networks
+	}
+	if opts.SecurityGroups != nil {
+		groups := []ServerSecurityGroup{}
+		for _, name := range *opts.SecurityGroups {
+			groups = append(groups, ServerSecurityGroup{Name: String(name)})
+		}
+		input.Server.SecurityGroups = &groups
+	}
+	if opts.UserData != nil {
+		input.Server.UserData = String(base64.StdEncoding.EncodeToString([]byte(*opts.UserData)))
+	}
+
+	output := &struct {
+		Server *Server `header:"-" json:"server,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPost, "./servers", true, StatusCodeIn(202), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return output.Server, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE SERVER
+ */
+
+// DeleteServer deletes the server identified by the given server id; on clouds
+// configured with deferred delete, the server is only soft-deleted and can be
+// restored (see RestoreServer) until it is reclaimed; see also
+// https://developer.openstack.org/api-ref/compute/#delete-server
+func (api *ComputeV2API) DeleteServer(serverid string) (bool, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+	}{
+		ServerID: serverid,
+	}
+	result, err := api.Invoke(http.MethodDelete, "./servers/{serverid}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * FORCE DELETE SERVER
+ */
+
+// ForceDeleteServer immediately deletes a soft-deleted server, without waiting
+// for it to be reclaimed; see also
+// https://developer.openstack.org/api-ref/compute/#force-delete-server-forcedelete-action
+func (api *ComputeV2API) ForceDeleteServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "forceDelete", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * RESTORE SOFT-DELETED SERVER
+ */
+
+// RestoreServer restores a soft-deleted server, on clouds configured with
+// deferred delete; see also
+// https://developer.openstack.org/api-ref/compute/#restore-soft-deleted-instance-restore-action
+func (api *ComputeV2API) RestoreServer(serverid string) (bool, *Result, error) {
+	result, err := api.invokeServerAction(serverid, nil, "restore", nil, StatusCodeIn(202), nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * SHOW SERVER DIAGNOSTICS
+ */
+
+// RetrieveServerDiagnostics retrieves the diagnostics of the server, which are
+// reserved to administrators by default; request microversion 2.48 or later to
+// get the standardised format; see also
+// https://developer.openstack.org/api-ref/compute/#show-server-diagnostics
+func (api *ComputeV2API) RetrieveServerDiagnostics(serverid string, microversion *string) (*ServerDiagnostics, *Result, error) {
+	input := &struct {
+		ServerID     string  `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+	}{
+		ServerID:     serverid,
+		Microversion: microversion,
+	}
+	output := &ServerDiagnostics{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/diagnostics", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * WAIT FOR SERVER STATUS
+ */
+
+// WaitForServerStatus waits until the server reaches the given status (e.g.
+// ServerStatusActive, ServerStatusShutoff) with no pending task, polling it with
+// the given backoff policy; the wait fails if the server goes into the ERROR
+// status (unless that is the expected status), if it disappears (unless the
+// expected status is ServerStatusDeleted, in which case nil is returned) or if
+// the context is done.
+func (api *ComputeV2API) WaitForServerStatus(ctx context.Context, serverid string, status ServerStatus, backoff Backoff) (*Server, error) {
+	var server *Server
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var result *Result
+		var err error
+		server, result, err = api.RetrieveServer(serverid)
+		if result != nil && result.Code == http.StatusNotFound {
+			if status == ServerStatusDeleted {
+				return true, nil
+			}
+			return false, fmt.Errorf("server %q not found", serverid)
+		}
+		if err != nil {
+			return false, err
+		}
+		if server == nil {
+			return false, fmt.Errorf("error retrieving server %q: %v", serverid, result)
+		}
+		var current ServerStatus
+		if server.Status != nil {
+			current = *server.Status
+		}
+		log.Debugf("server %q is %s (task: %s), waiting for %s", serverid, current, stringValue(server.TaskState), status)
+		if current == status {
+			return server.TaskState == nil, nil
+		}
+		if current == ServerStatusError {
+			if server.Fault != nil {
+				return false, fmt.Errorf("server %q is in ERROR status: %s", serverid, stringValue(server.Fault.Message))
+			}
+			return false, fmt.Errorf("server %q is in ERROR status", serverid)
+		}
+		return false, nil
+	})
+	return server, err
+}
@@ -0,0 +1,188 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// ComputeServiceEnabled is the status of enabled compute services.
+	ComputeServiceEnabled = "enabled"
+	// ComputeServiceDisabled is the status of disabled compute services, on
+	// which the scheduler does not place new servers.
+	ComputeServiceDisabled = "disabled"
+)
+
+/*
+ * LIST COMPUTE SERVICES
+ */
+
+// ListComputeServicesOptions provides the options available for filtering the
+// list of compute services.
+type ListComputeServicesOptions struct {
+	Binary       *string `parameter:"binary,omitempty" header:"-" json:"-"`
+	Host         *string `parameter:"host,omitempty" header:"-" json:"-"`
+	Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+}
+
+// ListComputeServices returns the list of compute services, with their status
+// (enabled or disabled) and state (up or down); see also
+// https://developer.openstack.org/api-ref/compute/#list-compute-services
+func (api *ComputeV2API) ListComputeServices(opts *ListComputeServicesOptions) (*[]ComputeService, *Result, error) {
+	output := &struct {
+		Services *[]ComputeService `header:"-" json:"services,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./os-services", true, StatusCodeIn(200), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Services, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE COMPUTE SERVICE
+ */
+
+// UpdateComputeServiceOptions provides the options available for updating a
+// compute service: Status is either ComputeServiceEnabled or
+// ComputeServiceDisabled, DisabledReason can only be given when disabling.
+type UpdateComputeServiceOptions struct {
+	Status         *string `json:"status,omitempty"`
+	DisabledReason *string `json:"disabled_reason,omitempty"`
+	ForcedDown     *bool   `json:"forced_down,omitempty"`
+	Microversion   *string `json:"-"`
+}
+
+// UpdateComputeService enables, disables or forces down the compute service
+// identified by the given UUID; it requires microversion 2.53 or later, which
+// is sent by default if none is given (see EnableComputeService and the other
+// legacy calls for earlier microversions); see also
+// https://developer.openstack.org/api-ref/compute/#update-compute-service
+func (api *ComputeV2API) UpdateComputeService(serviceid string, opts *UpdateComputeServiceOptions) (*ComputeService, *Result, error) {
+	microversion := opts.Microversion
+	if microversion == nil {
+		microversion = String("2.53")
+	}
+	input := &struct {
+		ServiceID    string  `parameter:"-" header:"-" variable:"serviceid" json:"-"`
+		Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+		*UpdateComputeServiceOptions
+	}{
+		ServiceID:                   serviceid,
+		Microversion:                microversion,
+		UpdateComputeServiceOptions: opts,
+	}
+	output := &struct {
+		Service *ComputeService `header:"-" json:"service,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPut, "./os-services/{serviceid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Service, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * ENABLE/DISABLE COMPUTE SERVICE (LEGACY)
+ */
+
+// EnableComputeService enables the compute service with the given binary (e.g.
+// "nova-compute") on the given host; it is only available up to microversion
+// 2.52; see also
+// https://developer.openstack.org/api-ref/compute/#enable-scheduling-for-a-compute-service-maximum-microversion-2-52
+func (api *ComputeV2API) EnableComputeService(host string, binary string) (*ComputeService, *Result, error) {
+	return api.updateComputeServiceLegacy("enable", &legacyComputeServiceUpdate{
+		Host:   host,
+		Binary: binary,
+	})
+}
+
+// DisableComputeService disables the compute service with the given binary on
+// the given host, optionally logging the reason; it is only available up to
+// microversion 2.52; see also
+// https://developer.openstack.org/api-ref/compute/#disable-scheduling-for-a-compute-service-maximum-microversion-2-52
+func (api *ComputeV2API) DisableComputeService(host string, binary string, reason *string) (*ComputeService, *Result, error) {
+	if reason != nil {
+		return api.updateComputeServiceLegacy("disable-log-reason", &legacyComputeServiceUpdate{
+			Host:           host,
+			Binary:         binary,
+			DisabledReason: reason,
+		})
+	}
+	return api.updateComputeServiceLegacy("disable", &legacyComputeServiceUpdate{
+		Host:   host,
+		Binary: binary,
+	})
+}
+
+// ForceDownComputeService marks (or unmarks) the compute service with the given
+// binary on the given host as down, e.g. to allow evacuations before the service
+// is detected as down; it requires microversions 2.11 to 2.52; see also
+// https://developer.openstack.org/api-ref/compute/#update-forced-down-maximum-microversion-2-52
+func (api *ComputeV2API) ForceDownComputeService(host string, binary string, forced bool) (*ComputeService, *Result, error) {
+	return api.updateComputeServiceLegacy("force-down", &legacyComputeServiceUpdate{
+		Host:         host,
+		Binary:       binary,
+		ForcedDown:   Bool(forced),
+		Microversion: String("2.11"),
+	})
+}
+
+// legacyComputeServiceUpdate is the request entity of the legacy (up to
+// microversion 2.52) compute service update calls.
+type legacyComputeServiceUpdate struct {
+	Host           string  `parameter:"-" header:"-" json:"host"`
+	Binary         string  `parameter:"-" header:"-" json:"binary"`
+	DisabledReason *string `parameter:"-" header:"-" json:"disabled_reason,omitempty"`
+	ForcedDown     *bool   `parameter:"-" header:"-" json:"forced_down,omitempty"`
+	Microversion   *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+}
+
+// updateComputeServiceLegacy invokes one of the legacy compute service update
+// calls (e.g. "enable", "force-down").
+func (api *ComputeV2API) updateComputeServiceLegacy(action string, input *legacyComputeServiceUpdate) (*ComputeService, *Result, error) {
+	output := &struct {
+		Service *ComputeService `header:"-" json:"service,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPut, "./os-services/"+action, true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Service, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE COMPUTE SERVICE
+ */
+
+// DeleteComputeService deletes the compute service identified by the given id
+// (an integer before microversion 2.53, a UUID after), e.g. after a compute
+// host has been decommissioned; see also
+// https://developer.openstack.org/api-ref/compute/#delete-compute-service
+func (api *ComputeV2API) DeleteComputeService(serviceid string, microversion *string) (bool, *Result, error) {
+	input := &struct {
+		ServiceID    string  `parameter:"-" header:"-" variable:"serviceid" json:"-"`
+		Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+	}{
+		ServiceID:    serviceid,
+		Microversion: microversion,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./os-services/{serviceid}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,477 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+)
+
+// BlockDeviceMapping describes a block device to be attached to a server at
+// creation time (block_device_mapping_v2); the SourceType is one of "image",
+// "volume", "snapshot" or "blank", the DestinationType is either "volume" or
+// "local"; the device with BootIndex 0 is the boot device, while a nil or
+// negative BootIndex marks a non-bootable device.
+type BlockDeviceMapping struct {
+	BootIndex           *int    `json:"boot_index,omitempty"`
+	UUID                *string `json:"uuid,omitempty"`
+	SourceType          *string `json:"source_type,omitempty"`
+	DestinationType     *string `json:"destination_type,omitempty"`
+	VolumeSize          *int    `json:"volume_size,omitempty"`
+	VolumeType          *string `json:"volume_type,omitempty"`
+	DeleteOnTermination *bool   `json:"delete_on_termination,omitempty"`
+	DeviceName          *string `json:"device_name,omitempty"`
+	DeviceType          *string `json:"device_type,omitempty"`
+	DiskBus             *string `json:"disk_bus,omitempty"`
+	GuestFormat         *string `json:"guest_format,omitempty"`
+	NoDevice            *bool   `json:"no_device,omitempty"`
+	Tag                 *string `json:"tag,omitempty"`
+}
+
+// Flavor represents the hardware configuration of a server: the amount of
+// memory, the number of virtual CPUs and the size of the disks; servers report
+// either a reference to the flavor (ID and links) or, since microversion 2.47,
+// the embedded flavor details (OriginalName, ExtraSpecs...).
+type Flavor struct {
+	ID           *string           `json:"id,omitempty"`
+	Name         *string           `json:"name,omitempty"`
+	OriginalName *string           `json:"original_name,omitempty"`
+	RAM          *int              `json:"ram,omitempty"`
+	VCPUs        *int              `json:"vcpus,omitempty"`
+	Disk         *int              `json:"disk,omitempty"`
+	Ephemeral    *int              `json:"ephemeral,omitempty"`
+	Swap         *int              `json:"swap,omitempty"`
+	ExtraSpecs   map[string]string `json:"extra_specs,omitempty"`
+	Links        *[]Link           `json:"links,omitempty"`
+}
+
+/*
+ * KEYPAIRS
+ */
+
+// Keypair is an SSH (or x509, since microversion 2.2) keypair whose public key
+// is injected into servers at boot time; the PrivateKey is only returned when
+// the keypair is generated by the Compute service and cannot be retrieved
+// afterwards.
+type Keypair struct {
+	ID          *int    `json:"id,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Type        *string `json:"type,omitempty"`
+	PublicKey   *string `json:"public_key,omitempty"`
+	PrivateKey  *string `json:"private_key,omitempty"`
+	Fingerprint *string `json:"fingerprint,omitempty"`
+	UserID      *string `json:"user_id,omitempty"`
+	CreatedAt   *string `json:"created_at,omitempty"`
+	Deleted     *bool   `json:"deleted,omitempty"`
+}
+
+/*
+ * SERVERS
+ */
+
+// Personality is a file to be injected into the server file system at rebuild
+// time; the Contents are provided in clear and base64-encoded by the SDK;
+// personality files are deprecated since microversion 2.57 in favour of user
+// data.
+type Personality struct {
+	Path     *string `json:"path,omitempty"`
+	Contents *string `json:"contents,omitempty"`
+}
+
+// ServerStatus is the status of a server, as reported by the Compute service;
+// see https://docs.openstack.org/api-guide/compute/server_concepts.html.
+type ServerStatus string
+
+const (
+	ServerStatusActive           ServerStatus = "ACTIVE"
+	ServerStatusBuild            ServerStatus = "BUILD"
+	ServerStatusError            ServerStatus = "ERROR"
+	ServerStatusHardReboot       ServerStatus = "HARD_REBOOT"
+	ServerStatusMigrating        ServerStatus = "MIGRATING"
+	ServerStatusPassword         ServerStatus = "PASSWORD"
+	ServerStatusPaused           ServerStatus = "PAUSED"
+	ServerStatusReboot           ServerStatus = "REBOOT"
+	ServerStatusRebuild          ServerStatus = "REBUILD"
+	ServerStatusRescue           ServerStatus = "RESCUE"
+	ServerStatusResize           ServerStatus = "RESIZE"
+	ServerStatusRevertResize     ServerStatus = "REVERT_RESIZE"
+	ServerStatusShelved          ServerStatus = "SHELVED"
+	ServerStatusShelvedOffloaded ServerStatus = "SHELVED_OFFLOADED"
+	ServerStatusShutoff          ServerStatus = "SHUTOFF"
+	ServerStatusSoftDeleted      ServerStatus = "SOFT_DELETED"
+	ServerStatusSuspended        ServerStatus = "SUSPENDED"
+	ServerStatusUnknown          ServerStatus = "UNKNOWN"
+	ServerStatusVerifyResize     ServerStatus = "VERIFY_RESIZE"
+	// ServerStatusDeleted is reported for deleted servers only when listing
+	// them with ChangesSince; it is also the pseudo-status that can be passed
+	// to WaitForServerStatus to wait until the server no longer exists.
+	ServerStatusDeleted ServerStatus = "DELETED"
+)
+
+// Ptr returns a pointer to the server status, to be used in OpenStack API
+// structs.
+func (s ServerStatus) Ptr() *ServerStatus {
+	return &s
+}
+
+// Server represents a virtual machine instance managed by the Compute service;
+// attributes prefixed by "OS-EXT-" are only reported to administrators (or as
+// per the cloud policies).
+type Server struct {
+	ID                 *string                    `json:"id,omitempty"`
+	Name               *string                    `json:"name,omitempty"`
+	Description        *string                    `json:"description,omitempty"`
+	Status             *ServerStatus              `json:"status,omitempty"`
+	TenantID           *string                    `json:"tenant_id,omitempty"`
+	UserID             *string                    `json:"user_id,omitempty"`
+	HostID             *string                    `json:"hostId,omitempty"`
+	Created            *string                    `json:"created,omitempty"`
+	Updated            *string                    `json:"updated,omitempty"`
+	AccessIPv4         *string                    `json:"accessIPv4,omitempty"`
+	AccessIPv6         *string                    `json:"accessIPv6,omitempty"`
+	Progress           *int                       `json:"progress,omitempty"`
+	KeyName            *string                    `json:"key_name,omitempty"`
+	ConfigDrive        *string                    `json:"config_drive,omitempty"`
+	Locked             *bool                      `json:"locked,omitempty"`
+	AdminPass          *string                    `json:"adminPass,omitempty"`
+	Flavor             *Flavor                    `json:"flavor,omitempty"`
+	Image              *ServerImage               `json:"image,omitempty"`
+	Addresses          map[string][]ServerAddress `json:"addresses,omitempty"`
+	Metadata           map[string]string          `json:"metadata,omitempty"`
+	SecurityGroups     *[]ServerSecurityGroup     `json:"security_groups,omitempty"`
+	VolumesAttached    *[]ServerVolume            `json:"os-extended-volumes:volumes_attached,omitempty"`
+	Tags               *[]string                  `json:"tags,omitempty"`
+	Fault              *ServerFault               `json:"fault,omitempty"`
+	AvailabilityZone   *string                    `json:"OS-EXT-AZ:availability_zone,omitempty"`
+	Host               *string                    `json:"OS-EXT-SRV-ATTR:host,omitempty"`
+	HypervisorHostname *string                    `json:"OS-EXT-SRV-ATTR:hypervisor_hostname,omitempty"`
+	InstanceName       *string                    `json:"OS-EXT-SRV-ATTR:instance_name,omitempty"`
+	PowerState         *int                       `json:"OS-EXT-STS:power_state,omitempty"`
+	TaskState          *string                    `json:"OS-EXT-STS:task_state,omitempty"`
+	VMState            *string                    `json:"OS-EXT-STS:vm_state,omitempty"`
+	DiskConfig         *string                    `json:"OS-DCF:diskConfig,omitempty"`
+	LaunchedAt         *string                    `json:"OS-SRV-USG:launched_at,omitempty"`
+	TerminatedAt       *string                    `json:"OS-SRV-USG:terminated_at,omitempty"`
+	Links              *[]Link                    `json:"links,omitempty"`
+}
+
+// ServerAddress is an IP address assigned to a server on a given network; the
+// type is either "fixed" or "floating".
+type ServerAddress struct {
+	Address *string `json:"addr,omitempty"`
+	Version *int    `json:"version,omitempty"`
+	Type    *string `json:"OS-EXT-IPS:type,omitempty"`
+	MACAddr *string `json:"OS-EXT-IPS-MAC:mac_addr,omitempty"`
+}
+
+// ServerFault contains the details of the failure that put the server in the
+// ERROR status.
+type ServerFault struct {
+	Code    *int    `json:"code,omitempty"`
+	Created *string `json:"created,omitempty"`
+	Message *string `json:"message,omitempty"`
+	Details *string `json:"details,omitempty"`
+}
+
+// ServerImage is the reference to the image a server was booted from; servers
+// booted from volume report an empty string instead of an image reference, in
+// which case all fields are nil.
+type ServerImage struct {
+	ID    *string `json:"id,omitempty"`
+	Links *[]Link `json:"links,omitempty"`
+}
+
+// UnmarshalJSON unmarshals an image reference, tolerating the empty string that
+// is returned for servers booted from volume.
+func (i *ServerImage) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*i = ServerImage{}
+		return nil
+	}
+	type image ServerImage
+	return json.Unmarshal(data, (*image)(i))
+}
+
+// ServerNetwork describes a network interface to be created on a server: it
+// can reference an existing Neutron port or a network (UUID), optionally with
+// a fixed IP address to be assigned on it.
+type ServerNetwork struct {
+	UUID    *string `json:"uuid,omitempty"`
+	Port    *string `json:"port,omitempty"`
+	FixedIP *string `json:"fixed_ip,omitempty"`
+	Tag     *string `json:"tag,omitempty"`
+}
+
+// ServerSecurityGroup is the reference to a security group applied to a server.
+type ServerSecurityGroup struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// ServerVolume is the reference to a volume attached to a server.
+type ServerVolume struct {
+	ID                  *string `json:"id,omitempty"`
+	DeleteOnTermination *bool   `json:"delete_on_termination,omitempty"`
+}
+
+/*
+ * VOLUME ATTACHMENTS
+ */
+
+// VolumeAttachment is the attachment of a Block Storage volume to a server;
+// Tag requires microversion 2.70, DeleteOnTermination microversion 2.79,
+// AttachmentID and BDMUUID microversion 2.89 (which also drops ID).
+type VolumeAttachment struct {
+	ID                  *string `json:"id,omitempty"`
+	ServerID            *string `json:"serverId,omitempty"`
+	VolumeID            *string `json:"volumeId,omitempty"`
+	Device              *string `json:"device,omitempty"`
+	Tag                 *string `json:"tag,omitempty"`
+	DeleteOnTermination *bool   `json:"delete_on_termination,omitempty"`
+	AttachmentID        *string `json:"attachment_id,omitempty"`
+	BDMUUID             *string `json:"bdm_uuid,omitempty"`
+}
+
+/*
+ * COMPUTE SERVICES
+ */
+
+// ComputeService is one of the services making up the Compute service (e.g.
+// "nova-compute" or "nova-scheduler") running on a host; its ID is an integer
+// before microversion 2.53 and a UUID after; ForcedDown requires microversion
+// 2.11.
+type ComputeService struct {
+	ID             *FlexibleID `json:"id,omitempty"`
+	Binary         *string     `json:"binary,omitempty"`
+	Host           *string     `json:"host,omitempty"`
+	Zone           *string     `json:"zone,omitempty"`
+	Status         *string     `json:"status,omitempty"`
+	State          *string     `json:"state,omitempty"`
+	DisabledReason *string     `json:"disabled_reason,omitempty"`
+	ForcedDown     *bool       `json:"forced_down,omitempty"`
+	UpdatedAt      *string     `json:"updated_at,omitempty"`
+}
+
+/*
+ * DIAGNOSTICS
+ */
+
+// ServerDiagnostics contains the diagnostics of a server; before microversion
+// 2.48 the format is hypervisor-specific and only available in Raw; starting
+// with microversion 2.48 it is standardised and the typed fields are populated
+// too (fields the hypervisor does not support are nil).
+type ServerDiagnostics struct {
+	State         *string                  `json:"state,omitempty"`
+	Driver        *string                  `json:"driver,omitempty"`
+	Hypervisor    *string                  `json:"hypervisor,omitempty"`
+	HypervisorOS  *string                  `json:"hypervisor_os,omitempty"`
+	Uptime        *int64                   `json:"uptime,omitempty"`
+	ConfigDrive   *bool                    `json:"config_drive,omitempty"`
+	NumCPUs       *int                     `json:"num_cpus,omitempty"`
+	NumNICs       *int                     `json:"num_nics,omitempty"`
+	NumDisks      *int                     `json:"num_disks,omitempty"`
+	MemoryDetails *ServerMemoryDiagnostics `json:"memory_details,omitempty"`
+	CPUDetails    *[]ServerCPUDiagnostics  `json:"cpu_details,omitempty"`
+	NICDetails    *[]ServerNICDiagnostics  `json:"nic_details,omitempty"`
+	DiskDetails   *[]ServerDiskDiagnostics `json:"disk_details,omitempty"`
+	Raw           map[string]interface{}   `json:"-"`
+}
+
+// UnmarshalJSON unmarshals the diagnostics both into the typed fields and into
+// the free-form Raw map.
+func (d *ServerDiagnostics) UnmarshalJSON(data []byte) error {
+	type diagnostics ServerDiagnostics
+	if err := json.Unmarshal(data, (*diagnostics)(d)); err != nil {
+		// legacy formats may use different types for the same names
+		*d = ServerDiagnostics{}
+	}
+	return json.Unmarshal(data, &d.Raw)
+}
+
+// ServerMemoryDiagnostics contains the memory statistics of a server, in MiB.
+type ServerMemoryDiagnostics struct {
+	MaxMem *int64 `json:"maxmem,omitempty"`
+	Used   *int64 `json:"used,omitempty"`
+}
+
+// ServerCPUDiagnostics contains the statistics of a virtual CPU; Time is in
+// nanoseconds, Utilisation in percent.
+type ServerCPUDiagnostics struct {
+	ID          *int   `json:"id,omitempty"`
+	Time        *int64 `json:"time,omitempty"`
+	Utilisation *int   `json:"utilisation,omitempty"`
+}
+
+// ServerNICDiagnostics contains the statistics of a network interface.
+type ServerNICDiagnostics struct {
+	MACAddress *string `json:"mac_address,omitempty"`
+	RxOctets   *int64  `json:"rx_octets,omitempty"`
+	RxErrors   *int64  `json:"rx_errors,omitempty"`
+	RxDrop     *int64  `json:"rx_drop,omitempty"`
+	RxPackets  *int64  `json:"rx_packets,omitempty"`
+	RxRate     *int64  `json:"rx_rate,omitempty"`
+	TxOctets   *int64  `json:"tx_octets,omitempty"`
+	TxErrors   *int64  `json:"tx_errors,omitempty"`
+	TxDrop     *int64  `json:"tx_drop,omitempty"`
+	TxPackets  *int64  `json:"tx_packets,omitempty"`
+	TxRate     *int64  `json:"tx_rate,omitempty"`
+}
+
+// ServerDiskDiagnostics contains the statistics of a disk.
+type ServerDiskDiagnostics struct {
+	ReadBytes     *int64 `json:"read_bytes,omitempty"`
+	ReadRequests  *int64 `json:"read_requests,omitempty"`
+	WriteBytes    *int64 `json:"write_bytes,omitempty"`
+	WriteRequests *int64 `json:"write_requests,omitempty"`
+	ErrorsCount   *int64 `json:"errors_count,omitempty"`
+}
+
+/*
+ * HYPERVISORS
+ */
+
+// Hypervisor is a compute node as seen by the Compute service; its ID is an
+// integer before microversion 2.53 and a UUID after; the resource usage fields
+// are not reported any more since microversion 2.88, in favour of the Placement
+// service; CPUInfo is a JSON-encoded string before microversion 2.28 and an
+// object after.
+type Hypervisor struct {
+	ID                 *FlexibleID         `json:"id,omitempty"`
+	HypervisorHostname *string             `json:"hypervisor_hostname,omitempty"`
+	HypervisorType     *string             `json:"hypervisor_type,omitempty"`
+	HypervisorVersion  *int                `json:"hypervisor_version,omitempty"`
+	HostIP             *string             `json:"host_ip,omitempty"`
+	State              *string             `json:"state,omitempty"`
+	Status             *string             `json:"status,omitempty"`
+	VCPUs              *int                `json:"vcpus,omitempty"`
+	VCPUsUsed          *int                `json:"vcpus_used,omitempty"`
+	MemoryMB           *int                `json:"memory_mb,omitempty"`
+	MemoryMBUsed       *int                `json:"memory_mb_used,omitempty"`
+	FreeRAMMB          *int                `json:"free_ram_mb,omitempty"`
+	LocalGB            *int                `json:"local_gb,omitempty"`
+	LocalGBUsed        *int                `json:"local_gb_used,omitempty"`
+	FreeDiskGB         *int                `json:"free_disk_gb,omitempty"`
+	DiskAvailableLeast *int                `json:"disk_available_least,omitempty"`
+	RunningVMs         *int                `json:"running_vms,omitempty"`
+	CurrentWorkload    *int                `json:"current_workload,omitempty"`
+	CPUInfo            interface{}         `json:"cpu_info,omitempty"`
+	Uptime             *string             `json:"uptime,omitempty"`
+	Service            *HypervisorService  `json:"service,omitempty"`
+	Servers            *[]HypervisorServer `json:"servers,omitempty"`
+}
+
+// HypervisorService is the compute service running on a hypervisor.
+type HypervisorService struct {
+	ID             *FlexibleID `json:"id,omitempty"`
+	Host           *string     `json:"host,omitempty"`
+	DisabledReason *string     `json:"disabled_reason,omitempty"`
+}
+
+// HypervisorServer is the reference to a server running on a hypervisor.
+type HypervisorServer struct {
+	UUID *string `json:"uuid,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+/*
+ * INSTANCE ACTIONS
+ */
+
+// InstanceAction is an action performed on a server (e.g. "create", "reboot"),
+// identified by the ID of the request that started it; the Events are only
+// reported when retrieving a single action; UpdatedAt requires microversion
+// 2.58.
+type InstanceAction struct {
+	Action       *string                `json:"action,omitempty"`
+	InstanceUUID *string                `json:"instance_uuid,omitempty"`
+	RequestID    *string                `json:"request_id,omitempty"`
+	UserID       *string                `json:"user_id,omitempty"`
+	ProjectID    *string                `json:"project_id,omitempty"`
+	Message      *string                `json:"message,omitempty"`
+	StartTime    *string                `json:"start_time,omitempty"`
+	UpdatedAt    *string                `json:"updated_at,omitempty"`
+	Events       *[]InstanceActionEvent `json:"events,omitempty"`
+}
+
+// InstanceActionEvent is one of the steps of an instance action; Traceback is
+// only reported to administrators; Host and HostID require microversion 2.62,
+// Details microversion 2.84.
+type InstanceActionEvent struct {
+	Event      *string `json:"event,omitempty"`
+	StartTime  *string `json:"start_time,omitempty"`
+	FinishTime *string `json:"finish_time,omitempty"`
+	Result     *string `json:"result,omitempty"`
+	Traceback  *string `json:"traceback,omitempty"`
+	Host       *string `json:"host,omitempty"`
+	HostID     *string `json:"hostId,omitempty"`
+	Details    *string `json:"details,omitempty"`
+}
+
+/*
+ * INTERFACE ATTACHMENTS
+ */
+
+// InterfaceAttachment is a network interface (a Networking service port)
+// attached to a server; the Tag requires microversion 2.70.
+type InterfaceAttachment struct {
+	PortID    *string                       `json:"port_id,omitempty"`
+	NetworkID *string                       `json:"net_id,omitempty"`
+	MACAddr   *string                       `json:"mac_addr,omitempty"`
+	PortState *string                       `json:"port_state,omitempty"`
+	FixedIPs  *[]InterfaceAttachmentFixedIP `json:"fixed_ips,omitempty"`
+	Tag       *string                       `json:"tag,omitempty"`
+}
+
+// InterfaceAttachmentFixedIP is an IP address assigned to an interface on one of
+// the subnets of its network.
+type InterfaceAttachmentFixedIP struct {
+	SubnetID  *string `json:"subnet_id,omitempty"`
+	IPAddress *string `json:"ip_address,omitempty"`
+}
+
+/*
+ * MIGRATIONS
+ */
+
+// Migration is a cold or live migration, or a resize, of a server between two
+// compute hosts; the UUID requires microversion 2.59, UserID and ProjectID
+// microversion 2.80; the memory and disk progress fields are only reported for
+// in-progress live migrations of a server.
+type Migration struct {
+	ID               *int    `json:"id,omitempty"`
+	UUID             *string `json:"uuid,omitempty"`
+	ServerUUID       *string `json:"server_uuid,omitempty"`
+	InstanceUUID     *string `json:"instance_uuid,omitempty"`
+	MigrationType    *string `json:"migration_type,omitempty"`
+	Status           *string `json:"status,omitempty"`
+	SourceCompute    *string `json:"source_compute,omitempty"`
+	SourceNode       *string `json:"source_node,omitempty"`
+	DestCompute      *string `json:"dest_compute,omitempty"`
+	DestNode         *string `json:"dest_node,omitempty"`
+	DestHost         *string `json:"dest_host,omitempty"`
+	OldFlavorID      *int    `json:"old_instance_type_id,omitempty"`
+	NewFlavorID      *int    `json:"new_instance_type_id,omitempty"`
+	MemoryTotalBytes *int64  `json:"memory_total_bytes,omitempty"`
+	MemoryProcessed  *int64  `json:"memory_processed_bytes,omitempty"`
+	MemoryRemaining  *int64  `json:"memory_remaining_bytes,omitempty"`
+	DiskTotalBytes   *int64  `json:"disk_total_bytes,omitempty"`
+	DiskProcessed    *int64  `json:"disk_processed_bytes,omitempty"`
+	DiskRemaining    *int64  `json:"disk_remaining_bytes,omitempty"`
+	UserID           *string `json:"user_id,omitempty"`
+	ProjectID        *string `json:"project_id,omitempty"`
+	CreatedAt        *string `json:"created_at,omitempty"`
+	UpdatedAt        *string `json:"updated_at,omitempty"`
+	Links            *[]Link `json:"links,omitempty"`
+}
+
+/*
+ * REMOTE CONSOLES
+ */
+
+// RemoteConsole is the URL of a remote console to a server; the Protocol is not
+// reported by the legacy console actions.
+type RemoteConsole struct {
+	Protocol *string `json:"protocol,omitempty"`
+	Type     *string `json:"type,omitempty"`
+	URL      *string `json:"url,omitempty"`
+}
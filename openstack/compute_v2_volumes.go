@@ -0,0 +1,193 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST VOLUME ATTACHMENTS
+ */
+
+// ListVolumeAttachmentsOptions provides the options available for listing the
+// volumes attached to a server.
+type ListVolumeAttachmentsOptions struct {
+	Limit        *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Offset       *int    `parameter:"offset,omitempty" header:"-" json:"-"`
+	Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+}
+
+// ListVolumeAttachments returns the volumes attached to the server identified by
+// the given server id; opts can be nil; see also
+// https://developer.openstack.org/api-ref/compute/#list-volume-attachments-for-an-instance
+func (api *ComputeV2API) ListVolumeAttachments(serverid string, opts *ListVolumeAttachmentsOptions) (*[]VolumeAttachment, *Result, error) {
+	if opts == nil {
+		opts = &ListVolumeAttachmentsOptions{}
+	}
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		*ListVolumeAttachmentsOptions
+	}{
+		ServerID:                     serverid,
+		ListVolumeAttachmentsOptions: opts,
+	}
+	output := &struct {
+		Attachments *[]VolumeAttachment `header:"-" json:"volumeAttachments,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/os-volume_attachments", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Attachments, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * ATTACH VOLUME
+ */
+
+// AttachVolumeOptions provides the options available for attaching a volume to
+// a server; Device is only a hint and may be ignored by the hypervisor; Tag
+// requires microversion 2.49, DeleteOnTermination microversion 2.79.
+type AttachVolumeOptions struct {
+	VolumeID            *string `json:"volumeId,omitempty"`
+	Device              *string `json:"device,omitempty"`
+	Tag                 *string `json:"tag,omitempty"`
+	DeleteOnTermination *bool   `json:"delete_on_termination,omitempty"`
+	Microversion        *string `json:"-"`
+}
+
+// AttachVolume attaches a volume to the server identified by the given server
+// id; the attachment completes asynchronously, the volume becoming "in-use" in
+// the Block Storage service; see also
+// https://developer.openstack.org/api-ref/compute/#attach-a-volume-to-an-instance
+func (api *ComputeV2API) AttachVolume(serverid string, opts *AttachVolumeOptions) (*VolumeAttachment, *Result, error) {
+	input := &struct {
+		ServerID     string               `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		Microversion *string              `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+		Attachment   *AttachVolumeOptions `parameter:"-" header:"-" json:"volumeAttachment"`
+	}{
+		ServerID:     serverid,
+		Microversion: opts.Microversion,
+		Attachment:   opts,
+	}
+	output := &struct {
+		Attachment *VolumeAttachment `header:"-" json:"volumeAttachment,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPost, "./servers/{serverid}/os-volume_attachments", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Attachment, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE VOLUME ATTACHMENT
+ */
+
+// RetrieveVolumeAttachment retrieves the attachment of the given volume to the
+// given server; see also
+// https://developer.openstack.org/api-ref/compute/#show-a-detail-of-a-volume-attachment
+func (api *ComputeV2API) RetrieveVolumeAttachment(serverid string, volumeid string) (*VolumeAttachment, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		VolumeID string `parameter:"-" header:"-" variable:"volumeid" json:"-"`
+	}{
+		ServerID: serverid,
+		VolumeID: volumeid,
+	}
+	output := &struct {
+		Attachment *VolumeAttachment `header:"-" json:"volumeAttachment,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./servers/{serverid}/os-volume_attachments/{volumeid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Attachment, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * SWAP VOLUME
+ */
+
+// SwapVolume replaces the attached volume identified by volumeid with the one
+// identified by newvolumeid, migrating the data; this is an administrative
+// operation, usually triggered by the Block Storage service on volume migration
+// or retype; see also
+// https://developer.openstack.org/api-ref/compute/#update-a-volume-attachment
+func (api *ComputeV2API) SwapVolume(serverid string, volumeid string, newvolumeid string) (bool, *Result, error) {
+	input := &struct {
+		ServerID   string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		VolumeID   string `parameter:"-" header:"-" variable:"volumeid" json:"-"`
+		Attachment struct {
+			VolumeID string `json:"volumeId"`
+		} `parameter:"-" header:"-" json:"volumeAttachment"`
+	}{
+		ServerID: serverid,
+		VolumeID: volumeid,
+	}
+	input.Attachment.VolumeID = newvolumeid
+
+	result, err := api.Invoke(http.MethodPut, "./servers/{serverid}/os-volume_attachments/{volumeid}", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * DETACH VOLUME
+ */
+
+// DetachVolume detaches the given volume from the given server; the detachment
+// completes asynchronously (see WaitForVolumeDetachment); see also
+// https://developer.openstack.org/api-ref/compute/#detach-a-volume-from-an-instance
+func (api *ComputeV2API) DetachVolume(serverid string, volumeid string) (bool, *Result, error) {
+	input := &struct {
+		ServerID string `parameter:"-" header:"-" variable:"serverid" json:"-"`
+		VolumeID string `parameter:"-" header:"-" variable:"volumeid" json:"-"`
+	}{
+		ServerID: serverid,
+		VolumeID: volumeid,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./servers/{serverid}/os-volume_attachments/{volumeid}", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// WaitForVolumeDetachment waits until the given volume is no longer attached to
+// the given server, as seen by the Compute service; the volume status in the
+// Block Storage service ("available") may be updated slightly later.
+func (api *ComputeV2API) WaitForVolumeDetachment(ctx context.Context, serverid string, volumeid string, interval time.Duration) error {
+	return WaitFor(ctx, interval, func() (bool, error) {
+		attachment, result, err := api.RetrieveVolumeAttachment(serverid, volumeid)
+		if result != nil && result.Code == http.StatusNotFound {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if attachment == nil {
+			return false, fmt.Errorf("error retrieving attachment of volume %q: %v", volumeid, result)
+		}
+		return false, nil
+	})
+}
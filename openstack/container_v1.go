@@ -0,0 +1,48 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+// ContainerV1API represents the Container API ver. 1 (Zun), providing support
+// for the management of containers run directly on the cloud, without a
+// container orchestration cluster (see ContainerInfraV1API for those); they
+// are called application containers (AppContainer) to tell them from the
+// object storage containers.
+// Zun uses microversions: by default requests are served with the base version
+// 1.1, a later one can be requested for all calls through WithMicroversion (or
+// NegotiateMicroversion, for the latest one supported by the server), which is
+// needed by the most recent container attributes.
+// See https://developer.openstack.org/api-ref/application-container/
+type ContainerV1API struct {
+	API
+}
+
+// containerMicroversion is the header through which Zun is told the
+// microversion requested.
+var containerMicroversion = microversionHeader{name: "OpenStack-API-Version", service: "container", base: "1.1"}
+
+// WithMicroversion returns a copy of the API that requests the given
+// microversion (e.g. "1.12") in all its calls, through the
+// OpenStack-API-Version header; the receiver is left unchanged.
+func (api ContainerV1API) WithMicroversion(microversion string) *ContainerV1API {
+	return &ContainerV1API{API: api.withMicroversion(containerMicroversion, microversion)}
+}
+
+// NegotiateMicroversion returns a copy of the API that requests, in all its
+// calls, the microversion pinned for its endpoint in the client profile, or
+// the latest one supported by the server, as advertised in its version
+// document (see SelectMicroversion); the receiver is left unchanged.
+func (api ContainerV1API) NegotiateMicroversion() (*ContainerV1API, *Result, error) {
+	microversion, result, err := api.negotiateMicroversion()
+	if err != nil {
+		return nil, result, err
+	}
+	return api.WithMicroversion(microversion), result, nil
+}
+
+// Microversion returns the microversion requested in all calls, or "1.1" if
+// none was set.
+func (api *ContainerV1API) Microversion() string {
+	return api.requestedMicroversion(containerMicroversion)
+}
@@ -0,0 +1,333 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Application container status values.
+const (
+	AppContainerStatusCreating   string = "Creating"
+	AppContainerStatusCreated    string = "Created"
+	AppContainerStatusRunning    string = "Running"
+	AppContainerStatusStopped    string = "Stopped"
+	AppContainerStatusPaused     string = "Paused"
+	AppContainerStatusRestarting string = "Restarting"
+	AppContainerStatusDeleting   string = "Deleting"
+	AppContainerStatusDeleted    string = "Deleted"
+	AppContainerStatusError      string = "Error"
+	AppContainerStatusUnknown    string = "Unknown"
+)
+
+/*
+ * LIST CONTAINERS
+ */
+
+// ListAppContainersOptions provides the options available for listing the
+// containers; Limit sets the page size, all pages are retrieved anyway.
+type ListAppContainersOptions struct {
+	Name       *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Image      *string `parameter:"image,omitempty" header:"-" json:"-"`
+	Status     *string `parameter:"status,omitempty" header:"-" json:"-"`
+	Host       *string `parameter:"host,omitempty" header:"-" json:"-"`
+	AllTenants *bool   `parameter:"all_projects,omitempty" header:"-" json:"-"`
+	SortKey    *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir    *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit      *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker     *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListAppContainers returns the list of containers of the current project; opts
+// can be nil; see also
+// https://developer.openstack.org/api-ref/application-container/#list-all-containers
+func (api *ContainerV1API) ListAppContainers(opts *ListAppContainersOptions) (*[]AppContainer, *Result, error) {
+	if opts == nil {
+		opts = &ListAppContainersOptions{}
+	}
+	containers := []AppContainer{}
+	result, err := api.listResources("./containers", "containers", opts, nextField,
+		func() interface{} { return &[]AppContainer{} },
+		func(page interface{}) { containers = append(containers, *page.(*[]AppContainer)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &containers, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE CONTAINER
+ */
+
+// CreateAppContainerOptions provides the options available for creating a
+// container: Image is required; Command overrides the one of the image; CPU is
+// the number of virtual CPUs, Memory the memory limit (in MiB, e.g. "512");
+// Nets selects the networks to attach the container to (e.g.
+// {"network": "<id>"}), by default it is attached to the project network.
+type CreateAppContainerOptions struct {
+	Name             *string                    `parameter:"-" header:"-" json:"name,omitempty"`
+	Image            string                     `parameter:"-" header:"-" json:"image"`
+	ImageDriver      *string                    `parameter:"-" header:"-" json:"image_driver,omitempty"`
+	ImagePullPolicy  *string                    `parameter:"-" header:"-" json:"image_pull_policy,omitempty"`
+	Command          *string                    `parameter:"-" header:"-" json:"command,omitempty"`
+	CPU              *float64                   `parameter:"-" header:"-" json:"cpu,omitempty"`
+	Memory           *string                    `parameter:"-" header:"-" json:"memory,omitempty"`
+	Environment      *map[string]string         `parameter:"-" header:"-" json:"environment,omitempty"`
+	Workdir          *string                    `parameter:"-" header:"-" json:"workdir,omitempty"`
+	Labels           *map[string]string         `parameter:"-" header:"-" json:"labels,omitempty"`
+	Nets             *[]map[string]string       `parameter:"-" header:"-" json:"nets,omitempty"`
+	SecurityGroups   *[]string                  `parameter:"-" header:"-" json:"security_groups,omitempty"`
+	RestartPolicy    *AppContainerRestartPolicy `parameter:"-" header:"-" json:"restart_policy,omitempty"`
+	Interactive      *bool                      `parameter:"-" header:"-" json:"interactive,omitempty"`
+	AutoRemove       *bool                      `parameter:"-" header:"-" json:"auto_remove,omitempty"`
+	Runtime          *string                    `parameter:"-" header:"-" json:"runtime,omitempty"`
+	Hostname         *string                    `parameter:"-" header:"-" json:"hostname,omitempty"`
+	AvailabilityZone *string                    `parameter:"-" header:"-" json:"availability_zone,omitempty"`
+	Hints            *map[string]string         `parameter:"-" header:"-" json:"hints,omitempty"`
+}
+
+// CreateAppContainer creates a container, without starting it; the creation is
+// asynchronous: the container is Creating until it is Created (see
+// WaitForAppContainerStatus); see also
+// https://developer.openstack.org/api-ref/application-container/#create-new-container
+func (api *ContainerV1API) CreateAppContainer(opts *CreateAppContainerOptions) (*AppContainer, *Result, error) {
+	return api.createAppContainer(opts, false)
+}
+
+// RunAppContainer creates a container and starts it as soon as it is created;
+// see also
+// https://developer.openstack.org/api-ref/application-container/#create-new-container
+func (api *ContainerV1API) RunAppContainer(opts *CreateAppContainerOptions) (*AppContainer, *Result, error) {
+	return api.createAppContainer(opts, true)
+}
+
+// createAppContainer creates a container, starting it if run is set.
+func (api *ContainerV1API) createAppContainer(opts *CreateAppContainerOptions, run bool) (*AppContainer, *Result, error) {
+	input := &struct {
+		Run *bool `parameter:"run,omitempty" header:"-" json:"-"`
+		CreateAppContainerOptions
+	}{
+		CreateAppContainerOptions: *opts,
+	}
+	if run {
+		input.Run = Bool(true)
+	}
+	container := &AppContainer{}
+
+	result, err := api.Invoke(http.MethodPost, "./containers", true, StatusCodeIn(202), input, container, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return container, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE CONTAINER
+ */
+
+// RetrieveAppContainer retrieves the container with the given UUID or name; see
+// also
+// https://developer.openstack.org/api-ref/application-container/#show-container-details
+func (api *ContainerV1API) RetrieveAppContainer(container string) (*AppContainer, *Result, error) {
+	output := &AppContainer{}
+	result, err := api.Invoke(http.MethodGet, "./containers/{id}", true, StatusCodeIn(200), &envelope{ID: container}, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE CONTAINER
+ */
+
+// DeleteAppContainerOptions provides the options available for deleting a
+// container: a running container is only deleted if Force is set, or if Stop
+// is set, in which case it is stopped first.
+type DeleteAppContainerOptions struct {
+	Force *bool `parameter:"force,omitempty" header:"-" json:"-"`
+	Stop  *bool `parameter:"stop,omitempty" header:"-" json:"-"`
+}
+
+// DeleteAppContainer deletes the container with the given UUID or name; opts
+// can be nil; see also
+// https://developer.openstack.org/api-ref/application-container/#delete-container
+func (api *ContainerV1API) DeleteAppContainer(container string, opts *DeleteAppContainerOptions) (bool, *Result, error) {
+	if opts == nil {
+		opts = &DeleteAppContainerOptions{}
+	}
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		DeleteAppContainerOptions
+	}{
+		ID:                        container,
+		DeleteAppContainerOptions: *opts,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./containers/{id}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * CONTAINER ACTIONS
+ */
+
+// StartAppContainer starts the container with the given UUID or name; see also
+// https://developer.openstack.org/api-ref/application-container/#start-a-container
+func (api *ContainerV1API) StartAppContainer(container string) (bool, *Result, error) {
+	return api.appContainerAction(container, "start", &envelope{ID: container})
+}
+
+// StopAppContainer stops the container with the given UUID or name, killing it
+// if it has not stopped after the given timeout (in seconds, 0 for the default
+// one); see also
+// https://developer.openstack.org/api-ref/application-container/#stop-a-container
+func (api *ContainerV1API) StopAppContainer(container string, timeout int) (bool, *Result, error) {
+	input := &struct {
+		ID      string `parameter:"-" header:"-" variable:"id" json:"-"`
+		Timeout *int   `parameter:"timeout,omitempty" header:"-" json:"-"`
+	}{
+		ID: container,
+	}
+	if timeout > 0 {
+		input.Timeout = Int(timeout)
+	}
+	return api.appContainerAction(container, "stop", input)
+}
+
+// appContainerAction performs the given action on the container, which is
+// accepted for asynchronous processing.
+func (api *ContainerV1API) appContainerAction(container string, action string, input interface{}) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodPost, "./containers/{id}/"+action, true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * CONTAINER LOGS
+ */
+
+// RetrieveAppContainerLogsOptions provides the options available for retrieving
+// the logs of a container: both the standard output and the standard error are
+// returned unless only one of them is selected; Tail is the number of lines to
+// return from the end (e.g. "100", or "all"), Since a UNIX timestamp.
+type RetrieveAppContainerLogsOptions struct {
+	Stdout     *bool   `parameter:"stdout,omitempty" header:"-" json:"-"`
+	Stderr     *bool   `parameter:"stderr,omitempty" header:"-" json:"-"`
+	Timestamps *bool   `parameter:"timestamps,omitempty" header:"-" json:"-"`
+	Tail       *string `parameter:"tail,omitempty" header:"-" json:"-"`
+	Since      *string `parameter:"since,omitempty" header:"-" json:"-"`
+}
+
+// RetrieveAppContainerLogs returns the logs of the container with the given
+// UUID or name; opts can be nil; see also
+// https://developer.openstack.org/api-ref/application-container/#retrieve-logs-of-a-container
+func (api *ContainerV1API) RetrieveAppContainerLogs(container string, opts *RetrieveAppContainerLogsOptions) (*string, *Result, error) {
+	if opts == nil {
+		opts = &RetrieveAppContainerLogsOptions{}
+	}
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		RetrieveAppContainerLogsOptions
+	}{
+		ID:                              container,
+		RetrieveAppContainerLogsOptions: *opts,
+	}
+	data := ""
+
+	result, err := api.Invoke(http.MethodGet, "./containers/{id}/logs", true, StatusCodeIn(200), input, &data, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		// the logs are returned as a JSON string
+		logs := ""
+		if err := json.Unmarshal([]byte(data), &logs); err != nil {
+			logs = data
+		}
+		return &logs, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * EXECUTE COMMAND
+ */
+
+// ExecuteAppContainerCommand executes the given command line in the container
+// with the given UUID or name and returns its output and exit code; if
+// interactive is set, the command is not run but an interactive session is
+// set up instead, and the URL to attach to it is returned; see also
+// https://developer.openstack.org/api-ref/application-container/#execute-command-in-a-running-container
+func (api *ContainerV1API) ExecuteAppContainerCommand(container string, command string, interactive bool) (*AppContainerExecution, *Result, error) {
+	input := &struct {
+		ID          string `parameter:"-" header:"-" variable:"id" json:"-"`
+		Command     string `parameter:"command" header:"-" json:"-"`
+		Run         bool   `parameter:"run" header:"-" json:"-"`
+		Interactive bool   `parameter:"interactive" header:"-" json:"-"`
+	}{
+		ID:          container,
+		Command:     command,
+		Run:         !interactive,
+		Interactive: interactive,
+	}
+	execution := &AppContainerExecution{}
+
+	result, err := api.Invoke(http.MethodPost, "./containers/{id}/execute", true, StatusCodeIn(200), input, execution, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return execution, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * WAIT FOR CONTAINER STATUS
+ */
+
+// WaitForAppContainerStatus waits until the container with the given UUID or
+// name is in the given status (e.g. Created after CreateAppContainer, Running
+// after RunAppContainer or StartAppContainer), polling it with the given
+// backoff policy; the wait fails if the container goes into the Error status or
+// disappears.
+func (api *ContainerV1API) WaitForAppContainerStatus(ctx context.Context, container string, status string, backoff Backoff) (*AppContainer, error) {
+	var current *AppContainer
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var result *Result
+		var err error
+		current, result, err = api.RetrieveAppContainer(container)
+		if result != nil && result.Code == http.StatusNotFound {
+			return false, fmt.Errorf("container %q not found", container)
+		}
+		if err != nil {
+			return false, err
+		}
+		if current == nil || current.Status == nil {
+			return false, fmt.Errorf("error retrieving container %q: %v", container, result)
+		}
+		log.Debugf("container %q is %s (task: %s)", container, *current.Status, stringValue(current.TaskState))
+		switch *current.Status {
+		case status:
+			return true, nil
+		case AppContainerStatusError:
+			return false, fmt.Errorf("container %q is in %s status: %s", container, *current.Status, stringValue(current.StatusReason))
+		}
+		return false, nil
+	})
+	return current, err
+}
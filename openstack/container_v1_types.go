@@ -0,0 +1,76 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * CONTAINERS
+ */
+
+// AppContainer is a Zun container: Status is its lifecycle status (see the
+// AppContainerStatus* constants), TaskState the operation in progress on it, if
+// any; CPU is the number of virtual CPUs, Memory the memory limit (in MiB);
+// Command is a string or, in later microversions, a list of arguments.
+type AppContainer struct {
+	UUID            *string                            `json:"uuid,omitempty"`
+	Name            *string                            `json:"name,omitempty"`
+	Image           *string                            `json:"image,omitempty"`
+	ImageDriver     *string                            `json:"image_driver,omitempty"`
+	ImagePullPolicy *string                            `json:"image_pull_policy,omitempty"`
+	Command         interface{}                        `json:"command,omitempty"`
+	Status          *string                            `json:"status,omitempty"`
+	StatusReason    *string                            `json:"status_reason,omitempty"`
+	StatusDetail    *string                            `json:"status_detail,omitempty"`
+	TaskState       *string                            `json:"task_state,omitempty"`
+	CPU             *float64                           `json:"cpu,omitempty"`
+	Memory          *string                            `json:"memory,omitempty"`
+	Disk            *int                               `json:"disk,omitempty"`
+	Environment     *map[string]string                 `json:"environment,omitempty"`
+	Workdir         *string                            `json:"workdir,omitempty"`
+	Labels          *map[string]string                 `json:"labels,omitempty"`
+	Addresses       *map[string][]AppContainerAddress  `json:"addresses,omitempty"`
+	Ports           *[]int                             `json:"ports,omitempty"`
+	SecurityGroups  *[]string                          `json:"security_groups,omitempty"`
+	RestartPolicy   *AppContainerRestartPolicy         `json:"restart_policy,omitempty"`
+	Interactive     *bool                              `json:"interactive,omitempty"`
+	TTY             *bool                              `json:"tty,omitempty"`
+	AutoRemove      *bool                              `json:"auto_remove,omitempty"`
+	AutoHeal        *bool                              `json:"auto_heal,omitempty"`
+	Privileged      *bool                              `json:"privileged,omitempty"`
+	Runtime         *string                            `json:"runtime,omitempty"`
+	Hostname        *string                            `json:"hostname,omitempty"`
+	Host            *string                            `json:"host,omitempty"`
+	Healthcheck     *map[string]interface{}            `json:"healthcheck,omitempty"`
+	ExposedPorts    *map[string]map[string]interface{} `json:"exposed_ports,omitempty"`
+	ProjectID       *string                            `json:"project_id,omitempty"`
+	UserID          *string                            `json:"user_id,omitempty"`
+	CreatedAt       *string                            `json:"created_at,omitempty"`
+	UpdatedAt       *string                            `json:"updated_at,omitempty"`
+}
+
+// AppContainerAddress is an IP address of a container on a network.
+type AppContainerAddress struct {
+	Addr     *string `json:"addr,omitempty"`
+	Version  *int    `json:"version,omitempty"`
+	Port     *string `json:"port,omitempty"`
+	SubnetID *string `json:"subnet_id,omitempty"`
+}
+
+// AppContainerRestartPolicy is the policy applied when a container exits: Name
+// is "no", "on-failure", "always" or "unless-stopped", MaximumRetryCount the
+// maximum number of restarts on failure.
+type AppContainerRestartPolicy struct {
+	Name              *string `json:"Name,omitempty"`
+	MaximumRetryCount *string `json:"MaximumRetryCount,omitempty"`
+}
+
+// AppContainerExecution is the outcome of a command executed in a container:
+// Output and ExitCode if it was run to completion, otherwise the ID and the
+// URL (a websocket) of the interactive session.
+type AppContainerExecution struct {
+	Output   *string `json:"output,omitempty"`
+	ExitCode *int    `json:"exit_code,omitempty"`
+	ExecID   *string `json:"exec_id,omitempty"`
+	URL      *string `json:"url,omitempty"`
+}
@@ -0,0 +1,104 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ContainerInfraV1API represents the container infrastructure management API
+// ver. 1 (Magnum), providing support for the management of cluster templates
+// and of the container orchestration clusters (e.g. Kubernetes) built from
+// them, and for the retrieval of the credentials to access them.
+// Magnum uses microversions: by default requests are served with the base
+// version 1.1, a later one can be requested for all calls through
+// WithMicroversion (e.g. resizing requires 1.7, upgrading 1.8), or the latest
+// one supported by the server through NegotiateMicroversion.
+// See https://developer.openstack.org/api-ref/container-infrastructure-management/
+type ContainerInfraV1API struct {
+	API
+}
+
+// containerInfraMicroversion is the header through which Magnum is told the
+// microversion requested.
+var containerInfraMicroversion = microversionHeader{name: "OpenStack-API-Version", service: "container-infra", base: "1.1"}
+
+// WithMicroversion returns a copy of the API that requests the given
+// microversion (e.g. "1.8") in all its calls, through the OpenStack-API-Version
+// header; the receiver is left unchanged.
+func (api ContainerInfraV1API) WithMicroversion(microversion string) *ContainerInfraV1API {
+	return &ContainerInfraV1API{API: api.withMicroversion(containerInfraMicroversion, microversion)}
+}
+
+// NegotiateMicroversion returns a copy of the API that requests, in all its
+// calls, the microversion pinned for its endpoint in the client profile, or
+// the latest one supported by the server, as advertised in its version
+// document (see SelectMicroversion); the receiver is left unchanged.
+func (api ContainerInfraV1API) NegotiateMicroversion() (*ContainerInfraV1API, *Result, error) {
+	microversion, result, err := api.negotiateMicroversion()
+	if err != nil {
+		return nil, result, err
+	}
+	return api.WithMicroversion(microversion), result, nil
+}
+
+// Microversion returns the microversion requested in all calls, or "1.1" if
+// none was set.
+func (api *ContainerInfraV1API) Microversion() string {
+	return api.requestedMicroversion(containerInfraMicroversion)
+}
+
+// requireMicroversion checks that the microversion requested in all calls is
+// at least the given one, which is needed by the given feature.
+func (api *ContainerInfraV1API) requireMicroversion(minimum string, feature string) error {
+	return api.API.requireMicroversion(containerInfraMicroversion, minimum, feature)
+}
+
+const (
+	// ContainerInfraPatchAdd is the JSON Patch operation adding an attribute.
+	ContainerInfraPatchAdd = "add"
+	// ContainerInfraPatchReplace is the JSON Patch operation replacing the value
+	// of an attribute.
+	ContainerInfraPatchReplace = "replace"
+	// ContainerInfraPatchRemove is the JSON Patch operation removing an
+	// attribute, i.e. resetting it to its default.
+	ContainerInfraPatchRemove = "remove"
+)
+
+// ContainerInfraPatchOperation is a JSON Patch (RFC 6902) operation on a
+// cluster or cluster template; Path is a JSON pointer to the attribute (e.g.
+// "/node_count", "/labels"); see the ContainerInfraPatch helper.
+type ContainerInfraPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ContainerInfraPatch returns the given operation (one of the
+// ContainerInfraPatch* constants) on the given attribute, e.g.
+// ContainerInfraPatch(ContainerInfraPatchReplace, "node_count", 3); the value is
+// ignored when removing.
+func ContainerInfraPatch(op string, attribute string, value interface{}) ContainerInfraPatchOperation {
+	if op == ContainerInfraPatchRemove {
+		value = nil
+	}
+	return ContainerInfraPatchOperation{Op: op, Path: "/" + strings.TrimPrefix(attribute, "/"), Value: value}
+}
+
+// containerInfraPatch is the request entity of the update calls: it is sent as
+// a bare array of operations.
+type containerInfraPatch struct {
+	ID         string                         `parameter:"-" header:"-" variable:"id" json:"-"`
+	Operations []ContainerInfraPatchOperation `parameter:"-" header:"-" json:"-"`
+}
+
+// MarshalJSON encodes the patch as the array of its operations.
+func (patch containerInfraPatch) MarshalJSON() ([]byte, error) {
+	if patch.Operations == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(patch.Operations)
+}
@@ -0,0 +1,157 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * CERTIFICATES
+ */
+
+// RetrieveClusterCA retrieves the certificate of the CA of the cluster with the
+// given UUID or name, which signs the certificates of its components and of its
+// clients; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#show-details-about-the-ca-for-a-cluster
+func (api *ContainerInfraV1API) RetrieveClusterCA(cluster string) (*ClusterCertificate, *Result, error) {
+	output := &ClusterCertificate{}
+	result, err := api.Invoke(http.MethodGet, "./certificates/{id}", true, StatusCodeIn(200), &envelope{ID: cluster}, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// SignClusterCertificate has the CA of the cluster with the given UUID or name
+// sign the given PEM-encoded certificate signing request, and returns the signed
+// certificate; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#generate-the-ca-certificate-for-a-cluster
+func (api *ContainerInfraV1API) SignClusterCertificate(cluster string, csr string) (*ClusterCertificate, *Result, error) {
+	input := &struct {
+		ClusterUUID string `parameter:"-" header:"-" json:"cluster_uuid"`
+		CSR         string `parameter:"-" header:"-" json:"csr"`
+	}{
+		ClusterUUID: cluster,
+		CSR:         csr,
+	}
+	output := &ClusterCertificate{}
+
+	result, err := api.Invoke(http.MethodPost, "./certificates", true, StatusCodeIn(201), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * KUBECONFIG
+ */
+
+// RetrieveClusterKubeconfig returns a kubeconfig file granting administrative
+// access to the Kubernetes cluster with the given UUID or name: a new private
+// key is generated, and a client certificate for it (user "admin", group
+// "system:masters") is signed by the CA of the cluster; the key never leaves
+// the client, and is only stored in the returned configuration, which must be
+// kept secret. If TLS is disabled in the cluster template, no credentials are
+// needed and none is included.
+func (api *ContainerInfraV1API) RetrieveClusterKubeconfig(cluster string) ([]byte, *Result, error) {
+	current, result, err := api.RetrieveCluster(cluster)
+	if current == nil {
+		return nil, result, err
+	}
+	if stringValue(current.APIAddress) == "" {
+		log.Errorf("cluster %q has no API address (status: %s)", cluster, stringValue(current.Status))
+		return nil, result, fmt.Errorf("cluster %q has no API address (status: %s)", cluster, stringValue(current.Status))
+	}
+	name := stringValue(current.Name)
+	if name == "" {
+		name = stringValue(current.UUID)
+	}
+
+	template, result, err := api.RetrieveClusterTemplate(stringValue(current.ClusterTemplateID))
+	if template == nil {
+		return nil, result, err
+	}
+	if template.TLSDisabled != nil && *template.TLSDisabled {
+		return kubeconfig(name, *current.APIAddress, nil, nil, nil), result, nil
+	}
+
+	ca, result, err := api.RetrieveClusterCA(stringValue(current.UUID))
+	if ca == nil {
+		return nil, result, err
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Errorf("error generating private key: %v", err)
+		return nil, nil, err
+	}
+	request, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   "admin",
+			Organization: []string{"system:masters"},
+		},
+	}, key)
+	if err != nil {
+		log.Errorf("error creating certificate signing request: %v", err)
+		return nil, nil, err
+	}
+	csr := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: request})
+
+	certificate, result, err := api.SignClusterCertificate(stringValue(current.UUID), string(csr))
+	if certificate == nil {
+		return nil, result, err
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return kubeconfig(name, *current.APIAddress, []byte(stringValue(ca.PEM)), []byte(stringValue(certificate.PEM)), keyPEM), result, nil
+}
+
+// kubeconfig returns a kubeconfig file for the cluster with the given name and
+// API address, with the given PEM-encoded CA certificate and client credentials,
+// if any.
+func kubeconfig(name string, server string, ca []byte, certificate []byte, key []byte) []byte {
+	encode := base64.StdEncoding.EncodeToString
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "apiVersion: v1\n")
+	fmt.Fprintf(buffer, "kind: Config\n")
+	fmt.Fprintf(buffer, "clusters:\n")
+	fmt.Fprintf(buffer, "- name: %q\n", name)
+	fmt.Fprintf(buffer, "  cluster:\n")
+	fmt.Fprintf(buffer, "    server: %q\n", server)
+	if ca != nil {
+		fmt.Fprintf(buffer, "    certificate-authority-data: %s\n", encode(ca))
+	}
+	fmt.Fprintf(buffer, "users:\n")
+	fmt.Fprintf(buffer, "- name: admin\n")
+	if certificate != nil && key != nil {
+		fmt.Fprintf(buffer, "  user:\n")
+		fmt.Fprintf(buffer, "    client-certificate-data: %s\n", encode(certificate))
+		fmt.Fprintf(buffer, "    client-key-data: %s\n", encode(key))
+	} else {
+		fmt.Fprintf(buffer, "  user: {}\n")
+	}
+	fmt.Fprintf(buffer, "contexts:\n")
+	fmt.Fprintf(buffer, "- name: default\n")
+	fmt.Fprintf(buffer, "  context:\n")
+	fmt.Fprintf(buffer, "    cluster: %q\n", name)
+	fmt.Fprintf(buffer, "    user: admin\n")
+	fmt.Fprintf(buffer, "current-context: default\n")
+	fmt.Fprintf(buffer, "preferences: {}\n")
+	return buffer.Bytes()
+}
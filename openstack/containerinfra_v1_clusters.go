@@ -0,0 +1,231 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Cluster status values; they follow the conventions of the Heat stacks the
+// clusters are built with.
+const (
+	ClusterStatusCreateInProgress string = "CREATE_IN_PROGRESS"
+	ClusterStatusCreateComplete   string = "CREATE_COMPLETE"
+	ClusterStatusCreateFailed     string = "CREATE_FAILED"
+	ClusterStatusUpdateInProgress string = "UPDATE_IN_PROGRESS"
+	ClusterStatusUpdateComplete   string = "UPDATE_COMPLETE"
+	ClusterStatusUpdateFailed     string = "UPDATE_FAILED"
+	ClusterStatusDeleteInProgress string = "DELETE_IN_PROGRESS"
+	ClusterStatusDeleteFailed     string = "DELETE_FAILED"
+)
+
+// clusterReference is the response entity of the asynchronous cluster calls,
+// carrying the UUID of the cluster only.
+type clusterReference struct {
+	UUID *string `json:"uuid,omitempty"`
+}
+
+/*
+ * LIST CLUSTERS
+ */
+
+// ListClustersOptions provides the options available for listing the
+// clusters; Limit sets the page size, all pages are retrieved anyway.
+type ListClustersOptions struct {
+	SortKey *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit   *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker  *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListClusters returns the list of clusters of the current project, with a
+// subset of their attributes (see RetrieveCluster for all of them); opts can be
+// nil; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#list-all-clusters
+func (api *ContainerInfraV1API) ListClusters(opts *ListClustersOptions) (*[]Cluster, *Result, error) {
+	clusters := []Cluster{}
+	result, err := api.listResources("./clusters", "clusters", opts, nextField,
+		func() interface{} { return &[]Cluster{} },
+		func(page interface{}) { clusters = append(clusters, *page.(*[]Cluster)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &clusters, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE CLUSTER
+ */
+
+// CreateClusterOptions provides the options available for creating a cluster:
+// ClusterTemplateID is required, the other attributes override the ones in the
+// template; CreateTimeout is in minutes; Labels replace the ones in the template
+// unless MergeLabels is set (microversion 1.9).
+type CreateClusterOptions struct {
+	Name              *string            `parameter:"-" header:"-" json:"name,omitempty"`
+	ClusterTemplateID string             `parameter:"-" header:"-" json:"cluster_template_id"`
+	Keypair           *string            `parameter:"-" header:"-" json:"keypair,omitempty"`
+	MasterCount       *int               `parameter:"-" header:"-" json:"master_count,omitempty"`
+	NodeCount         *int               `parameter:"-" header:"-" json:"node_count,omitempty"`
+	CreateTimeout     *int               `parameter:"-" header:"-" json:"create_timeout,omitempty"`
+	DiscoveryURL      *string            `parameter:"-" header:"-" json:"discovery_url,omitempty"`
+	FlavorID          *string            `parameter:"-" header:"-" json:"flavor_id,omitempty"`
+	MasterFlavorID    *string            `parameter:"-" header:"-" json:"master_flavor_id,omitempty"`
+	FixedNetwork      *string            `parameter:"-" header:"-" json:"fixed_network,omitempty"`
+	FixedSubnet       *string            `parameter:"-" header:"-" json:"fixed_subnet,omitempty"`
+	FloatingIPEnabled *bool              `parameter:"-" header:"-" json:"floating_ip_enabled,omitempty"`
+	MasterLBEnabled   *bool              `parameter:"-" header:"-" json:"master_lb_enabled,omitempty"`
+	Labels            *map[string]string `parameter:"-" header:"-" json:"labels,omitempty"`
+	MergeLabels       *bool              `parameter:"-" header:"-" json:"merge_labels,omitempty"`
+}
+
+// CreateCluster creates a cluster and returns its UUID; the creation is
+// asynchronous: the cluster is in the CREATE_IN_PROGRESS status until its
+// servers are up and its COE is configured; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#create-new-cluster
+func (api *ContainerInfraV1API) CreateCluster(opts *CreateClusterOptions) (*string, *Result, error) {
+	if opts != nil && opts.MergeLabels != nil {
+		if err := api.requireMicroversion("1.9", "merging labels"); err != nil {
+			log.Errorf("cannot create cluster: %v", err)
+			return nil, nil, err
+		}
+	}
+	output := &clusterReference{}
+	result, err := api.Invoke(http.MethodPost, "./clusters", true, StatusCodeIn(202), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return output.UUID, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE CLUSTER
+ */
+
+// RetrieveCluster retrieves the cluster with the given UUID or name; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#show-details-of-a-cluster
+func (api *ContainerInfraV1API) RetrieveCluster(cluster string) (*Cluster, *Result, error) {
+	output := &Cluster{}
+	result, err := api.Invoke(http.MethodGet, "./clusters/{id}", true, StatusCodeIn(200), &envelope{ID: cluster}, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE CLUSTER
+ */
+
+// UpdateCluster applies the given operations, in order, to the cluster with the
+// given UUID or name (e.g. replacing "/node_count"); the update is
+// asynchronous; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#update-information-of-cluster
+func (api *ContainerInfraV1API) UpdateCluster(cluster string, operations ...ContainerInfraPatchOperation) (bool, *Result, error) {
+	input := &containerInfraPatch{
+		ID:         cluster,
+		Operations: operations,
+	}
+
+	result, err := api.Invoke(http.MethodPatch, "./clusters/{id}", true, StatusCodeIn(202), input, &clusterReference{}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * DELETE CLUSTER
+ */
+
+// DeleteCluster deletes the cluster with the given UUID or name, along with its
+// servers; the deletion is asynchronous; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#delete-a-cluster
+func (api *ContainerInfraV1API) DeleteCluster(cluster string) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodDelete, "./clusters/{id}", true, StatusCodeIn(204), &envelope{ID: cluster}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * CLUSTER ACTIONS
+ */
+
+// ResizeClusterOptions provides the options available for resizing a cluster:
+// NodeCount is the new number of worker nodes; when shrinking, NodesToRemove
+// lists the servers (by UUID or name) to be removed first; NodeGroup is the
+// node group to resize, the default worker one if not given.
+type ResizeClusterOptions struct {
+	NodeCount     int       `parameter:"-" header:"-" json:"node_count"`
+	NodesToRemove *[]string `parameter:"-" header:"-" json:"nodes_to_remove,omitempty"`
+	NodeGroup     *string   `parameter:"-" header:"-" json:"nodegroup,omitempty"`
+}
+
+// ResizeCluster resizes the cluster with the given UUID or name; it requires
+// microversion 1.7; the resize is asynchronous; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#resize-a-cluster
+func (api *ContainerInfraV1API) ResizeCluster(cluster string, opts *ResizeClusterOptions) (bool, *Result, error) {
+	if err := api.requireMicroversion("1.7", "resizing clusters"); err != nil {
+		log.Errorf("cannot resize cluster %q: %v", cluster, err)
+		return false, nil, err
+	}
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		ResizeClusterOptions
+	}{
+		ID:                   cluster,
+		ResizeClusterOptions: *opts,
+	}
+	return api.clusterAction("./clusters/{id}/actions/resize", input)
+}
+
+// UpgradeClusterOptions provides the options available for upgrading a cluster:
+// ClusterTemplate is the UUID or name of the template with the new versions
+// (e.g. a newer "kube_tag" label); MaxBatchSize is the number of nodes upgraded
+// at once.
+type UpgradeClusterOptions struct {
+	ClusterTemplate string  `parameter:"-" header:"-" json:"cluster_template"`
+	MaxBatchSize    *int    `parameter:"-" header:"-" json:"max_batch_size,omitempty"`
+	NodeGroup       *string `parameter:"-" header:"-" json:"nodegroup,omitempty"`
+}
+
+// UpgradeCluster upgrades the cluster with the given UUID or name to a new
+// cluster template; it requires microversion 1.8; the upgrade is asynchronous;
+// see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#upgrade-a-cluster
+func (api *ContainerInfraV1API) UpgradeCluster(cluster string, opts *UpgradeClusterOptions) (bool, *Result, error) {
+	if err := api.requireMicroversion("1.8", "upgrading clusters"); err != nil {
+		log.Errorf("cannot upgrade cluster %q: %v", cluster, err)
+		return false, nil, err
+	}
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		UpgradeClusterOptions
+	}{
+		ID:                    cluster,
+		UpgradeClusterOptions: *opts,
+	}
+	return api.clusterAction("./clusters/{id}/actions/upgrade", input)
+}
+
+// clusterAction requests the action at the given path, which must contain the
+// {id} variable.
+func (api *ContainerInfraV1API) clusterAction(path string, input interface{}) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodPost, path, true, StatusCodeIn(202), input, &clusterReference{}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,138 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST CLUSTER TEMPLATES
+ */
+
+// ListClusterTemplatesOptions provides the options available for listing the
+// cluster templates; Limit sets the page size, all pages are retrieved anyway.
+type ListClusterTemplatesOptions struct {
+	SortKey *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit   *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker  *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListClusterTemplates returns the list of cluster templates visible to the
+// current project, including public ones; opts can be nil; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#list-all-clustertemplates
+func (api *ContainerInfraV1API) ListClusterTemplates(opts *ListClusterTemplatesOptions) (*[]ClusterTemplate, *Result, error) {
+	templates := []ClusterTemplate{}
+	result, err := api.listResources("./clustertemplates", "clustertemplates", opts, nextField,
+		func() interface{} { return &[]ClusterTemplate{} },
+		func(page interface{}) { templates = append(templates, *page.(*[]ClusterTemplate)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &templates, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE CLUSTER TEMPLATE
+ */
+
+// CreateClusterTemplateOptions provides the options available for creating a
+// cluster template: COE, ImageID and ExternalNetworkID are required.
+type CreateClusterTemplateOptions struct {
+	Name                *string            `parameter:"-" header:"-" json:"name,omitempty"`
+	COE                 string             `parameter:"-" header:"-" json:"coe"`
+	ImageID             string             `parameter:"-" header:"-" json:"image_id"`
+	ExternalNetworkID   string             `parameter:"-" header:"-" json:"external_network_id"`
+	KeypairID           *string            `parameter:"-" header:"-" json:"keypair_id,omitempty"`
+	FlavorID            *string            `parameter:"-" header:"-" json:"flavor_id,omitempty"`
+	MasterFlavorID      *string            `parameter:"-" header:"-" json:"master_flavor_id,omitempty"`
+	FixedNetwork        *string            `parameter:"-" header:"-" json:"fixed_network,omitempty"`
+	FixedSubnet         *string            `parameter:"-" header:"-" json:"fixed_subnet,omitempty"`
+	NetworkDriver       *string            `parameter:"-" header:"-" json:"network_driver,omitempty"`
+	VolumeDriver        *string            `parameter:"-" header:"-" json:"volume_driver,omitempty"`
+	DockerVolumeSize    *int               `parameter:"-" header:"-" json:"docker_volume_size,omitempty"`
+	DockerStorageDriver *string            `parameter:"-" header:"-" json:"docker_storage_driver,omitempty"`
+	DNSNameserver       *string            `parameter:"-" header:"-" json:"dns_nameserver,omitempty"`
+	ServerType          *string            `parameter:"-" header:"-" json:"server_type,omitempty"`
+	Labels              *map[string]string `parameter:"-" header:"-" json:"labels,omitempty"`
+	TLSDisabled         *bool              `parameter:"-" header:"-" json:"tls_disabled,omitempty"`
+	Public              *bool              `parameter:"-" header:"-" json:"public,omitempty"`
+	Hidden              *bool              `parameter:"-" header:"-" json:"hidden,omitempty"`
+	RegistryEnabled     *bool              `parameter:"-" header:"-" json:"registry_enabled,omitempty"`
+	MasterLBEnabled     *bool              `parameter:"-" header:"-" json:"master_lb_enabled,omitempty"`
+	FloatingIPEnabled   *bool              `parameter:"-" header:"-" json:"floating_ip_enabled,omitempty"`
+}
+
+// CreateClusterTemplate creates a cluster template; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#create-a-cluster-template
+func (api *ContainerInfraV1API) CreateClusterTemplate(opts *CreateClusterTemplateOptions) (*ClusterTemplate, *Result, error) {
+	template := &ClusterTemplate{}
+	result, err := api.Invoke(http.MethodPost, "./clustertemplates", true, StatusCodeIn(201), opts, template, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return template, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE CLUSTER TEMPLATE
+ */
+
+// RetrieveClusterTemplate retrieves the cluster template with the given UUID or
+// name; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#show-details-of-a-cluster-template
+func (api *ContainerInfraV1API) RetrieveClusterTemplate(template string) (*ClusterTemplate, *Result, error) {
+	output := &ClusterTemplate{}
+	result, err := api.Invoke(http.MethodGet, "./clustertemplates/{id}", true, StatusCodeIn(200), &envelope{ID: template}, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE CLUSTER TEMPLATE
+ */
+
+// UpdateClusterTemplate applies the given operations, in order, to the cluster
+// template with the given UUID or name and returns the updated template; only
+// templates not in use by any cluster can be updated; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#update-information-of-cluster-template
+func (api *ContainerInfraV1API) UpdateClusterTemplate(template string, operations ...ContainerInfraPatchOperation) (*ClusterTemplate, *Result, error) {
+	input := &containerInfraPatch{
+		ID:         template,
+		Operations: operations,
+	}
+	output := &ClusterTemplate{}
+
+	result, err := api.Invoke(http.MethodPatch, "./clustertemplates/{id}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE CLUSTER TEMPLATE
+ */
+
+// DeleteClusterTemplate deletes the cluster template with the given UUID or
+// name, which must not be in use by any cluster; see also
+// https://developer.openstack.org/api-ref/container-infrastructure-management/#delete-a-cluster-template
+func (api *ContainerInfraV1API) DeleteClusterTemplate(template string) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodDelete, "./clustertemplates/{id}", true, StatusCodeIn(204), &envelope{ID: template}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
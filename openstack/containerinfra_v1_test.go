@@ -0,0 +1,58 @@
+package openstack
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRetrieveClusterKubeconfig(t *testing.T) {
+	var csr *x509.CertificateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/clusters/k8s":
+			w.Write([]byte(`{"uuid": "c1", "name": "k8s", "cluster_template_id": "t1", "api_address": "https://10.0.0.1:6443"}`))
+		case r.URL.Path == "/clustertemplates/t1":
+			w.Write([]byte(`{"uuid": "t1", "tls_disabled": false}`))
+		case r.URL.Path == "/certificates/c1":
+			w.Write([]byte(`{"cluster_uuid": "c1", "pem": "CA"}`))
+		case r.URL.Path == "/certificates" && r.Method == http.MethodPost:
+			body, _ := ioutil.ReadAll(r.Body)
+			request := struct {
+				CSR string `json:"csr"`
+			}{}
+			json.Unmarshal(body, &request)
+			if block, _ := pem.Decode([]byte(request.CSR)); block != nil {
+				csr, _ = x509.ParseCertificateRequest(block.Bytes)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"cluster_uuid": "c1", "pem": "CERT"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDefaultClient(server.URL)
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+	api := &ContainerInfraV1API{API: client.Authenticator.Identity.API}
+
+	config, _, err := api.RetrieveClusterKubeconfig("k8s")
+	if err != nil {
+		t.Fatalf("ContainerInfra.TestRetrieveClusterKubeconfig: unexpected error: %v", err)
+	}
+	if csr == nil || csr.Subject.CommonName != "admin" || len(csr.Subject.Organization) != 1 || csr.Subject.Organization[0] != "system:masters" {
+		t.Errorf("ContainerInfra.TestRetrieveClusterKubeconfig: invalid certificate signing request: %v", csr)
+	}
+	for _, expected := range []string{`server: "https://10.0.0.1:6443"`, "certificate-authority-data: Q0E=", "client-certificate-data: Q0VSVA==", "client-key-data: "} {
+		if !strings.Contains(string(config), expected) {
+			t.Errorf("ContainerInfra.TestRetrieveClusterKubeconfig: expected %q in kubeconfig:\n%s", expected, config)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * CLUSTER TEMPLATES
+ */
+
+// ClusterTemplate describes how the clusters built from it are laid out: the
+// container orchestration engine (COE, e.g. "kubernetes"), the image, flavors
+// and networks of the servers, and the COE-specific Labels (e.g.
+// "kube_tag").
+type ClusterTemplate struct {
+	UUID                *string            `json:"uuid,omitempty"`
+	Name                *string            `json:"name,omitempty"`
+	COE                 *string            `json:"coe,omitempty"`
+	ImageID             *string            `json:"image_id,omitempty"`
+	KeypairID           *string            `json:"keypair_id,omitempty"`
+	FlavorID            *string            `json:"flavor_id,omitempty"`
+	MasterFlavorID      *string            `json:"master_flavor_id,omitempty"`
+	ExternalNetworkID   *string            `json:"external_network_id,omitempty"`
+	FixedNetwork        *string            `json:"fixed_network,omitempty"`
+	FixedSubnet         *string            `json:"fixed_subnet,omitempty"`
+	NetworkDriver       *string            `json:"network_driver,omitempty"`
+	VolumeDriver        *string            `json:"volume_driver,omitempty"`
+	DockerVolumeSize    *int               `json:"docker_volume_size,omitempty"`
+	DockerStorageDriver *string            `json:"docker_storage_driver,omitempty"`
+	DNSNameserver       *string            `json:"dns_nameserver,omitempty"`
+	ServerType          *string            `json:"server_type,omitempty"`
+	Labels              *map[string]string `json:"labels,omitempty"`
+	TLSDisabled         *bool              `json:"tls_disabled,omitempty"`
+	Public              *bool              `json:"public,omitempty"`
+	Hidden              *bool              `json:"hidden,omitempty"`
+	RegistryEnabled     *bool              `json:"registry_enabled,omitempty"`
+	MasterLBEnabled     *bool              `json:"master_lb_enabled,omitempty"`
+	FloatingIPEnabled   *bool              `json:"floating_ip_enabled,omitempty"`
+	ProjectID           *string            `json:"project_id,omitempty"`
+	UserID              *string            `json:"user_id,omitempty"`
+	CreatedAt           *string            `json:"created_at,omitempty"`
+	UpdatedAt           *string            `json:"updated_at,omitempty"`
+}
+
+/*
+ * CLUSTERS
+ */
+
+// Cluster is a container orchestration cluster built from a cluster template,
+// through a Heat stack (StackID); Status follows the Heat conventions (e.g.
+// "CREATE_IN_PROGRESS", "CREATE_COMPLETE"), APIAddress is the URL of the COE
+// API once the cluster is complete.
+type Cluster struct {
+	UUID              *string            `json:"uuid,omitempty"`
+	Name              *string            `json:"name,omitempty"`
+	ClusterTemplateID *string            `json:"cluster_template_id,omitempty"`
+	Keypair           *string            `json:"keypair,omitempty"`
+	MasterCount       *int               `json:"master_count,omitempty"`
+	NodeCount         *int               `json:"node_count,omitempty"`
+	CreateTimeout     *int               `json:"create_timeout,omitempty"`
+	DiscoveryURL      *string            `json:"discovery_url,omitempty"`
+	Status            *string            `json:"status,omitempty"`
+	StatusReason      *string            `json:"status_reason,omitempty"`
+	HealthStatus      *string            `json:"health_status,omitempty"`
+	APIAddress        *string            `json:"api_address,omitempty"`
+	COEVersion        *string            `json:"coe_version,omitempty"`
+	ContainerVersion  *string            `json:"container_version,omitempty"`
+	MasterAddresses   *[]string          `json:"master_addresses,omitempty"`
+	NodeAddresses     *[]string          `json:"node_addresses,omitempty"`
+	StackID           *string            `json:"stack_id,omitempty"`
+	Labels            *map[string]string `json:"labels,omitempty"`
+	FlavorID          *string            `json:"flavor_id,omitempty"`
+	MasterFlavorID    *string            `json:"master_flavor_id,omitempty"`
+	FixedNetwork      *string            `json:"fixed_network,omitempty"`
+	FixedSubnet       *string            `json:"fixed_subnet,omitempty"`
+	FloatingIPEnabled *bool              `json:"floating_ip_enabled,omitempty"`
+	MasterLBEnabled   *bool              `json:"master_lb_enabled,omitempty"`
+	ProjectID         *string            `json:"project_id,omitempty"`
+	UserID            *string            `json:"user_id,omitempty"`
+	CreatedAt         *string            `json:"created_at,omitempty"`
+	UpdatedAt         *string            `json:"updated_at,omitempty"`
+}
+
+// ClusterCertificate is a certificate of a cluster: either the certificate of
+// its CA, or one signed by it for the given certificate signing request (CSR),
+// both PEM-encoded.
+type ClusterCertificate struct {
+	ClusterUUID *string `json:"cluster_uuid,omitempty"`
+	PEM         *string `json:"pem,omitempty"`
+	CSR         *string `json:"csr,omitempty"`
+}
@@ -0,0 +1,98 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// DatabaseV1API represents the Database API ver. 1.0 (Trove), providing
+// support for the provisioning of managed database instances (e.g. MySQL,
+// PostgreSQL), for the management of their databases and users, and for their
+// backup and restore.
+// Trove works asynchronously: instances are BUILD until they are ACTIVE (see
+// WaitForDatabaseInstanceStatus), and database and user changes are accepted
+// for later processing.
+// See https://developer.openstack.org/api-ref/database/
+type DatabaseV1API struct {
+	API
+}
+
+// databaseURL returns the project-scoped Trove endpoint URL from the one in
+// the catalog, which may lack the project id or contain a placeholder for it.
+func databaseURL(endpoint string, projectid string) string {
+	for _, placeholder := range orchestrationProjectPlaceholders {
+		endpoint = strings.Replace(endpoint, placeholder, projectid, -1)
+	}
+	if projectid != "" && strings.HasSuffix(strings.TrimSuffix(endpoint, "/"), "/v1.0") {
+		endpoint = strings.TrimSuffix(endpoint, "/") + "/" + projectid
+	}
+	return endpoint
+}
+
+// databasePage is a page of a Trove listing: the items are under the given
+// name, and there are more pages if the links include a "next" one; Trove
+// pages listings even when no limit is requested.
+type databasePage struct {
+	name  string
+	items interface{}
+	more  bool
+}
+
+// UnmarshalJSON decodes both the items and the links to the other pages.
+func (p *databasePage) UnmarshalJSON(data []byte) error {
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if value, ok := values[p.name]; ok {
+		if err := json.Unmarshal(value, p.items); err != nil {
+			return err
+		}
+	}
+	if value, ok := values["links"]; ok {
+		links := []Link{}
+		if err := json.Unmarshal(value, &links); err != nil {
+			return err
+		}
+		for _, link := range links {
+			if link.Rel != nil && *link.Rel == "next" {
+				p.more = true
+			}
+		}
+	}
+	return nil
+}
+
+// databaseReference identifies a database or a user of an instance, and the
+// database a user is granted access to, in the path; Databases is the body of
+// the calls creating databases or granting access to them.
+type databaseReference struct {
+	InstanceID string      `parameter:"-" header:"-" variable:"instanceid" json:"-"`
+	Name       string      `parameter:"-" header:"-" variable:"name" json:"-"`
+	Database   string      `parameter:"-" header:"-" variable:"database" json:"-"`
+	Databases  *[]Database `parameter:"-" header:"-" variable:"-" json:"databases,omitempty"`
+}
+
+// acceptResource sends the given input to the given path with the given
+// method, expecting Trove to accept it for asynchronous processing.
+func (api *DatabaseV1API) acceptResource(method string, path string, input interface{}) (bool, *Result, error) {
+	result, err := api.Invoke(method, path, true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// deleteResource deletes the resource with the given input, bound to the
+// variables in the given path.
+func (api *DatabaseV1API) deleteResource(path string, input interface{}) (bool, *Result, error) {
+	return api.acceptResource(http.MethodDelete, path, input)
+}
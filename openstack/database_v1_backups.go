@@ -0,0 +1,120 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Backup status values.
+const (
+	DatabaseBackupStatusNew          string = "NEW"
+	DatabaseBackupStatusBuilding     string = "BUILDING"
+	DatabaseBackupStatusSaving       string = "SAVING"
+	DatabaseBackupStatusCompleted    string = "COMPLETED"
+	DatabaseBackupStatusDeleteFailed string = "DELETE_FAILED"
+	DatabaseBackupStatusFailed       string = "FAILED"
+)
+
+/*
+ * LIST BACKUPS
+ */
+
+// ListDatabaseBackupsOptions provides the options available for listing the
+// backups; Datastore filters them by the type of their datastore (e.g.
+// "mysql"); Limit sets the page size, all pages are retrieved anyway.
+type ListDatabaseBackupsOptions struct {
+	Datastore  *string `parameter:"datastore,omitempty" header:"-" variable:"-" json:"-"`
+	InstanceID *string `parameter:"instance_id,omitempty" header:"-" variable:"-" json:"-"`
+	Limit      *int    `parameter:"limit,omitempty" header:"-" variable:"-" json:"-"`
+	Marker     *string `parameter:"marker,omitempty" header:"-" variable:"-" json:"-"`
+}
+
+// ListDatabaseBackups returns the list of backups of the current project;
+// opts can be nil; see also
+// https://developer.openstack.org/api-ref/database/#list-backups
+func (api *DatabaseV1API) ListDatabaseBackups(opts *ListDatabaseBackupsOptions) (*[]DatabaseBackup, *Result, error) {
+	if opts == nil {
+		opts = &ListDatabaseBackupsOptions{}
+	}
+	input := *opts
+
+	backups := []DatabaseBackup{}
+	for {
+		page := []DatabaseBackup{}
+		output := &databasePage{name: "backups", items: &page}
+		result, err := api.Invoke(http.MethodGet, "./backups", true, StatusCodeIn(200), &input, output, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		backups = append(backups, page...)
+		if !output.more || len(page) == 0 {
+			return &backups, result, err
+		}
+		// the next page starts after the last backup of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE BACKUP
+ */
+
+// CreateDatabaseBackupOptions provides the options available for creating a
+// backup: Instance is the ID of the instance to back up; Incremental creates
+// an incremental backup based on the last one of the instance, or on
+// ParentID if set.
+type CreateDatabaseBackupOptions struct {
+	Name        string  `json:"name"`
+	Instance    string  `json:"instance"`
+	Description *string `json:"description,omitempty"`
+	Incremental *bool   `json:"incremental,omitempty"`
+	ParentID    *string `json:"parent_id,omitempty"`
+}
+
+// CreateDatabaseBackup creates a backup of an instance; the backup is
+// asynchronous: the instance is in BACKUP status and the backup is NEW, then
+// BUILDING, until it is COMPLETED; see also
+// https://developer.openstack.org/api-ref/database/#create-backup
+func (api *DatabaseV1API) CreateDatabaseBackup(opts *CreateDatabaseBackupOptions) (*DatabaseBackup, *Result, error) {
+	backup := &DatabaseBackup{}
+	input := &envelope{Name: "backup", Body: opts}
+	result, err := api.Invoke(http.MethodPost, "./backups", true, StatusCodeIn(202), input, &envelope{Name: "backup", Body: backup}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return backup, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE BACKUP
+ */
+
+// RetrieveDatabaseBackup retrieves the backup with the given ID; see also
+// https://developer.openstack.org/api-ref/database/#show-backup-details
+func (api *DatabaseV1API) RetrieveDatabaseBackup(id string) (*DatabaseBackup, *Result, error) {
+	backup := &DatabaseBackup{}
+	result, err := api.Invoke(http.MethodGet, "./backups/{id}", true, StatusCodeIn(200), &envelope{ID: id}, &envelope{Name: "backup", Body: backup}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return backup, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE BACKUP
+ */
+
+// DeleteDatabaseBackup deletes the backup with the given ID, along with the
+// incremental backups based on it; see also
+// https://developer.openstack.org/api-ref/database/#delete-backup
+func (api *DatabaseV1API) DeleteDatabaseBackup(id string) (bool, *Result, error) {
+	return api.deleteResource("./backups/{id}", &envelope{ID: id})
+}
@@ -0,0 +1,144 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * DATABASES
+ */
+
+// ListDatabases returns the list of databases of the instance with the given
+// ID; the system databases of the engine are not listed; see also
+// https://developer.openstack.org/api-ref/database/#list-instance-databases
+func (api *DatabaseV1API) ListDatabases(instance string) (*[]Database, *Result, error) {
+	databases := []Database{}
+	input := &struct {
+		InstanceID string  `parameter:"-" header:"-" variable:"instanceid" json:"-"`
+		Marker     *string `parameter:"marker,omitempty" header:"-" variable:"-" json:"-"`
+	}{InstanceID: instance}
+	for {
+		page := []Database{}
+		output := &databasePage{name: "databases", items: &page}
+		result, err := api.Invoke(http.MethodGet, "./instances/{instanceid}/databases", true, StatusCodeIn(200), input, output, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		databases = append(databases, page...)
+		if !output.more || len(page) == 0 {
+			return &databases, result, err
+		}
+		// the next page starts after the last database of this one
+		input.Marker = &page[len(page)-1].Name
+	}
+}
+
+// CreateDatabases creates the given databases in the instance with the given
+// ID; see also
+// https://developer.openstack.org/api-ref/database/#create-database
+func (api *DatabaseV1API) CreateDatabases(instance string, databases []Database) (bool, *Result, error) {
+	input := &databaseReference{InstanceID: instance, Databases: &databases}
+	return api.acceptResource(http.MethodPost, "./instances/{instanceid}/databases", input)
+}
+
+// DeleteDatabase deletes the database with the given name from the instance
+// with the given ID, along with its data; see also
+// https://developer.openstack.org/api-ref/database/#delete-database
+func (api *DatabaseV1API) DeleteDatabase(instance string, name string) (bool, *Result, error) {
+	return api.deleteResource("./instances/{instanceid}/databases/{name}", &databaseReference{InstanceID: instance, Name: name})
+}
+
+/*
+ * USERS
+ */
+
+// ListDatabaseUsers returns the list of users of the instance with the given
+// ID, along with the databases they can access; the system users of the
+// engine are not listed; see also
+// https://developer.openstack.org/api-ref/database/#list-database-instance-users
+func (api *DatabaseV1API) ListDatabaseUsers(instance string) (*[]DatabaseUser, *Result, error) {
+	users := []DatabaseUser{}
+	input := &struct {
+		InstanceID string  `parameter:"-" header:"-" variable:"instanceid" json:"-"`
+		Marker     *string `parameter:"marker,omitempty" header:"-" variable:"-" json:"-"`
+	}{InstanceID: instance}
+	for {
+		page := []DatabaseUser{}
+		output := &databasePage{name: "users", items: &page}
+		result, err := api.Invoke(http.MethodGet, "./instances/{instanceid}/users", true, StatusCodeIn(200), input, output, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		users = append(users, page...)
+		if !output.more || len(page) == 0 {
+			return &users, result, err
+		}
+		// the next page starts after the last user of this one
+		input.Marker = &page[len(page)-1].Name
+	}
+}
+
+// CreateDatabaseUsers creates the given users in the instance with the given
+// ID, each with its password and granted access to its databases; see also
+// https://developer.openstack.org/api-ref/database/#create-user
+func (api *DatabaseV1API) CreateDatabaseUsers(instance string, users []DatabaseUser) (bool, *Result, error) {
+	input := &struct {
+		InstanceID string         `parameter:"-" header:"-" variable:"instanceid" json:"-"`
+		Users      []DatabaseUser `parameter:"-" header:"-" variable:"-" json:"users"`
+	}{InstanceID: instance, Users: users}
+	return api.acceptResource(http.MethodPost, "./instances/{instanceid}/users", input)
+}
+
+// DeleteDatabaseUser deletes the user with the given name from the instance
+// with the given ID; see also
+// https://developer.openstack.org/api-ref/database/#delete-user
+func (api *DatabaseV1API) DeleteDatabaseUser(instance string, name string) (bool, *Result, error) {
+	return api.deleteResource("./instances/{instanceid}/users/{name}", &databaseReference{InstanceID: instance, Name: name})
+}
+
+/*
+ * USER ACCESS
+ */
+
+// ListDatabaseUserAccess returns the list of databases the user with the given
+// name can access in the instance with the given ID; see also
+// https://developer.openstack.org/api-ref/database/#show-user-access
+func (api *DatabaseV1API) ListDatabaseUserAccess(instance string, name string) (*[]Database, *Result, error) {
+	databases := []Database{}
+	input := &databaseReference{InstanceID: instance, Name: name}
+	result, err := api.Invoke(http.MethodGet, "./instances/{instanceid}/users/{name}/databases", true, StatusCodeIn(200), input, &envelope{Name: "databases", Body: &databases}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &databases, result, err
+	}
+	return nil, result, err
+}
+
+// GrantDatabaseUserAccess grants the user with the given name access to the
+// databases with the given names in the instance with the given ID; see also
+// https://developer.openstack.org/api-ref/database/#grant-user-access
+func (api *DatabaseV1API) GrantDatabaseUserAccess(instance string, name string, databases ...string) (bool, *Result, error) {
+	access := []Database{}
+	for _, database := range databases {
+		access = append(access, Database{Name: database})
+	}
+	input := &databaseReference{InstanceID: instance, Name: name, Databases: &access}
+	return api.acceptResource(http.MethodPut, "./instances/{instanceid}/users/{name}/databases", input)
+}
+
+// RevokeDatabaseUserAccess revokes the access of the user with the given name
+// to the database with the given name in the instance with the given ID; see
+// also
+// https://developer.openstack.org/api-ref/database/#revoke-user-access
+func (api *DatabaseV1API) RevokeDatabaseUserAccess(instance string, name string, database string) (bool, *Result, error) {
+	input := &databaseReference{InstanceID: instance, Name: name, Database: database}
+	return api.acceptResource(http.MethodDelete, "./instances/{instanceid}/users/{name}/databases/{database}", input)
+}
@@ -0,0 +1,237 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Instance status values.
+const (
+	DatabaseInstanceStatusBuild    string = "BUILD"
+	DatabaseInstanceStatusActive   string = "ACTIVE"
+	DatabaseInstanceStatusReboot   string = "REBOOT"
+	DatabaseInstanceStatusRestart  string = "RESTART_REQUIRED"
+	DatabaseInstanceStatusResize   string = "RESIZE"
+	DatabaseInstanceStatusBackup   string = "BACKUP"
+	DatabaseInstanceStatusShutdown string = "SHUTDOWN"
+	DatabaseInstanceStatusBlocked  string = "BLOCKED"
+	DatabaseInstanceStatusError    string = "ERROR"
+)
+
+/*
+ * LIST INSTANCES
+ */
+
+// ListDatabaseInstancesOptions provides the options available for listing the
+// instances; Limit sets the page size, all pages are retrieved anyway;
+// IncludeClustered also lists the instances belonging to clusters.
+type ListDatabaseInstancesOptions struct {
+	IncludeClustered *bool   `parameter:"include_clustered,omitempty" header:"-" json:"-"`
+	Limit            *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker           *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListDatabaseInstances returns the list of instances of the current project;
+// opts can be nil; see also
+// https://developer.openstack.org/api-ref/database/#list-database-instances
+func (api *DatabaseV1API) ListDatabaseInstances(opts *ListDatabaseInstancesOptions) (*[]DatabaseInstance, *Result, error) {
+	if opts == nil {
+		opts = &ListDatabaseInstancesOptions{}
+	}
+	input := *opts
+
+	instances := []DatabaseInstance{}
+	for {
+		page := []DatabaseInstance{}
+		output := &databasePage{name: "instances", items: &page}
+		result, err := api.Invoke(http.MethodGet, "./instances", true, StatusCodeIn(200), &input, output, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		instances = append(instances, page...)
+		if !output.more || len(page) == 0 {
+			return &instances, result, err
+		}
+		// the next page starts after the last instance of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE INSTANCE
+ */
+
+// CreateDatabaseInstanceOptions provides the options available for creating an
+// instance: FlavorRef (the ID of a compute flavor) and, unless the datastore
+// uses ephemeral storage, Volume (with its Size) are required; Datastore
+// defaults to the default datastore of the cloud; Databases and Users are
+// created along with the instance; RestorePoint creates the instance from a
+// backup; ReplicaOf creates a replica of another instance.
+type CreateDatabaseInstanceOptions struct {
+	Name             string                    `json:"name"`
+	FlavorRef        string                    `json:"flavorRef"`
+	Volume           *DatabaseVolume           `json:"volume,omitempty"`
+	Datastore        *DatabaseDatastore        `json:"datastore,omitempty"`
+	Databases        *[]Database               `json:"databases,omitempty"`
+	Users            *[]DatabaseUser           `json:"users,omitempty"`
+	RestorePoint     *DatabaseRestorePoint     `json:"restorePoint,omitempty"`
+	NICs             *[]DatabaseInstanceNIC    `json:"nics,omitempty"`
+	AvailabilityZone *string                   `json:"availability_zone,omitempty"`
+	Configuration    *string                   `json:"configuration,omitempty"`
+	ReplicaOf        *string                   `json:"replica_of,omitempty"`
+	ReplicaCount     *int                      `json:"replica_count,omitempty"`
+	Locality         *string                   `json:"locality,omitempty"`
+	Modules          *[]DatabaseInstanceModule `json:"modules,omitempty"`
+}
+
+// DatabaseRestorePoint is the backup an instance is restored from.
+type DatabaseRestorePoint struct {
+	BackupRef string `json:"backupRef"`
+}
+
+// DatabaseInstanceNIC is a network an instance is attached to.
+type DatabaseInstanceNIC struct {
+	NetID   *string `json:"net-id,omitempty"`
+	PortID  *string `json:"port-id,omitempty"`
+	FixedIP *string `json:"v4-fixed-ip,omitempty"`
+}
+
+// DatabaseInstanceModule is a module applied to an instance.
+type DatabaseInstanceModule struct {
+	ID string `json:"id"`
+}
+
+// CreateDatabaseInstance creates an instance; the creation is asynchronous:
+// the instance is BUILD until it is ACTIVE (see WaitForDatabaseInstanceStatus);
+// see also
+// https://developer.openstack.org/api-ref/database/#create-database-instance
+func (api *DatabaseV1API) CreateDatabaseInstance(opts *CreateDatabaseInstanceOptions) (*DatabaseInstance, *Result, error) {
+	instance := &DatabaseInstance{}
+	input := &envelope{Name: "instance", Body: opts}
+	result, err := api.Invoke(http.MethodPost, "./instances", true, StatusCodeIn(200), input, &envelope{Name: "instance", Body: instance}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return instance, result, err
+	}
+	return nil, result, err
+}
+
+// RestoreDatabaseInstance creates an instance from the backup with the given
+// ID; it is a shortcut for CreateDatabaseInstance with a RestorePoint, whose
+// Volume must be at least as large as the backup; see also
+// https://developer.openstack.org/api-ref/database/#create-database-instance
+func (api *DatabaseV1API) RestoreDatabaseInstance(backup string, opts *CreateDatabaseInstanceOptions) (*DatabaseInstance, *Result, error) {
+	input := *opts
+	input.RestorePoint = &DatabaseRestorePoint{BackupRef: backup}
+	return api.CreateDatabaseInstance(&input)
+}
+
+/*
+ * RETRIEVE INSTANCE
+ */
+
+// RetrieveDatabaseInstance retrieves the instance with the given ID; see also
+// https://developer.openstack.org/api-ref/database/#show-database-instance-details
+func (api *DatabaseV1API) RetrieveDatabaseInstance(id string) (*DatabaseInstance, *Result, error) {
+	instance := &DatabaseInstance{}
+	result, err := api.Invoke(http.MethodGet, "./instances/{id}", true, StatusCodeIn(200), &envelope{ID: id}, &envelope{Name: "instance", Body: instance}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return instance, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE INSTANCE
+ */
+
+// DeleteDatabaseInstance deletes the instance with the given ID, along with
+// its data; its backups are kept; see also
+// https://developer.openstack.org/api-ref/database/#delete-database-instance
+func (api *DatabaseV1API) DeleteDatabaseInstance(id string) (bool, *Result, error) {
+	return api.deleteResource("./instances/{id}", &envelope{ID: id})
+}
+
+/*
+ * INSTANCE ACTIONS
+ */
+
+// RestartDatabaseInstance restarts the database engine of the instance with
+// the given ID (e.g. after a configuration change); see also
+// https://developer.openstack.org/api-ref/database/#restart-instance
+func (api *DatabaseV1API) RestartDatabaseInstance(id string) (bool, *Result, error) {
+	return api.instanceAction(id, "restart", map[string]interface{}{})
+}
+
+// ResizeDatabaseInstanceFlavor moves the instance with the given ID to the
+// compute flavor with the given ID; see also
+// https://developer.openstack.org/api-ref/database/#resize-instance-flavor
+func (api *DatabaseV1API) ResizeDatabaseInstanceFlavor(id string, flavor string) (bool, *Result, error) {
+	return api.instanceAction(id, "resize", map[string]interface{}{
+		"flavorRef": flavor,
+	})
+}
+
+// ResizeDatabaseInstanceVolume grows the volume of the instance with the given
+// ID to the given size in GiB; volumes cannot shrink; see also
+// https://developer.openstack.org/api-ref/database/#resize-instance-volume
+func (api *DatabaseV1API) ResizeDatabaseInstanceVolume(id string, size int) (bool, *Result, error) {
+	return api.instanceAction(id, "resize", map[string]interface{}{
+		"volume": map[string]interface{}{
+			"size": size,
+		},
+	})
+}
+
+// instanceAction performs the given action on the instance with the given ID.
+func (api *DatabaseV1API) instanceAction(id string, action string, body interface{}) (bool, *Result, error) {
+	return api.acceptResource(http.MethodPost, "./instances/{id}/action", &envelope{ID: id, Name: action, Body: body})
+}
+
+/*
+ * WAIT FOR INSTANCE
+ */
+
+// WaitForDatabaseInstanceStatus waits until the instance with the given ID is
+// in the given status (e.g. ACTIVE after its creation or resize), polling it
+// with the given backoff policy; the wait fails if the instance goes into the
+// ERROR status or disappears.
+func (api *DatabaseV1API) WaitForDatabaseInstanceStatus(ctx context.Context, id string, status string, backoff Backoff) (*DatabaseInstance, error) {
+	var current *DatabaseInstance
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var result *Result
+		var err error
+		current, result, err = api.RetrieveDatabaseInstance(id)
+		if result != nil && result.Code == http.StatusNotFound {
+			return false, fmt.Errorf("instance %q not found", id)
+		}
+		if err != nil {
+			return false, err
+		}
+		if current == nil || current.Status == nil {
+			return false, fmt.Errorf("error retrieving instance %q: %v", id, result)
+		}
+		log.Debugf("instance %q is %s", id, *current.Status)
+		switch *current.Status {
+		case status:
+			return true, nil
+		case DatabaseInstanceStatusError:
+			message := ""
+			if current.Fault != nil {
+				message = stringValue(current.Fault.Message)
+			}
+			return false, fmt.Errorf("instance %q is in %s status: %s", id, *current.Status, message)
+		}
+		return false, nil
+	})
+	return current, err
+}
@@ -0,0 +1,41 @@
+package openstack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDatabaseURL(t *testing.T) {
+	urls := map[string]string{
+		"https://trove:8779/v1.0/p1":            "https://trove:8779/v1.0/p1",
+		"https://trove:8779/v1.0/%(tenant_id)s": "https://trove:8779/v1.0/p1",
+		"https://trove:8779/v1.0":               "https://trove:8779/v1.0/p1",
+		"https://trove:8779/v1.0/":              "https://trove:8779/v1.0/p1",
+	}
+	for endpoint, expected := range urls {
+		if actual := databaseURL(endpoint, "p1"); actual != expected {
+			t.Errorf("Database.TestDatabaseURL: expected %q for %q, got %q", expected, endpoint, actual)
+		}
+	}
+}
+
+func TestDatabasePage(t *testing.T) {
+	pages := map[string]bool{
+		`{"databases": [{"name": "db1"}], "links": [{"href": "https://trove/next", "rel": "next"}]}`: true,
+		`{"databases": [{"name": "db1"}], "links": []}`:                                              false,
+		`{"databases": [{"name": "db1"}]}`:                                                           false,
+	}
+	for data, more := range pages {
+		databases := []Database{}
+		page := &databasePage{name: "databases", items: &databases}
+		if err := json.Unmarshal([]byte(data), page); err != nil {
+			t.Fatalf("Database.TestDatabasePage: error decoding %s: %v", data, err)
+		}
+		if len(databases) != 1 || databases[0].Name != "db1" {
+			t.Errorf("Database.TestDatabasePage: unexpected databases %v in %s", databases, data)
+		}
+		if page.more != more {
+			t.Errorf("Database.TestDatabasePage: expected more to be %v in %s", more, data)
+		}
+	}
+}
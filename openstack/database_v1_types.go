@@ -0,0 +1,108 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * INSTANCES
+ */
+
+// DatabaseInstance is a Trove instance, i.e. a server running a database
+// engine (the datastore): Status is one of the DatabaseInstanceStatus*
+// constants, Fault the reason of the failure, if any.
+type DatabaseInstance struct {
+	ID            *string                    `json:"id,omitempty"`
+	Name          *string                    `json:"name,omitempty"`
+	Status        *string                    `json:"status,omitempty"`
+	Flavor        *DatabaseFlavor            `json:"flavor,omitempty"`
+	Volume        *DatabaseVolume            `json:"volume,omitempty"`
+	Datastore     *DatabaseDatastore         `json:"datastore,omitempty"`
+	Hostname      *string                    `json:"hostname,omitempty"`
+	IP            *[]string                  `json:"ip,omitempty"`
+	Addresses     *[]DatabaseInstanceAddress `json:"addresses,omitempty"`
+	Region        *string                    `json:"region,omitempty"`
+	Configuration *map[string]interface{}    `json:"configuration,omitempty"`
+	ReplicaOf     *map[string]interface{}    `json:"replica_of,omitempty"`
+	Replicas      *[]map[string]interface{}  `json:"replicas,omitempty"`
+	Fault         *DatabaseInstanceFault     `json:"fault,omitempty"`
+	Created       *string                    `json:"created,omitempty"`
+	Updated       *string                    `json:"updated,omitempty"`
+}
+
+// DatabaseFlavor is the flavor of the server of an instance.
+type DatabaseFlavor struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// DatabaseVolume is the volume holding the data of an instance: Size is in
+// GiB, Used (in GiB) is only returned for the details of an instance.
+type DatabaseVolume struct {
+	Size *int     `json:"size,omitempty"`
+	Type *string  `json:"type,omitempty"`
+	Used *float64 `json:"used,omitempty"`
+}
+
+// DatabaseDatastore is the database engine of an instance (e.g. "mysql") and
+// its version (e.g. "5.7").
+type DatabaseDatastore struct {
+	Type    *string `json:"type,omitempty"`
+	Version *string `json:"version,omitempty"`
+}
+
+// DatabaseInstanceAddress is an IP address of an instance: Type is "private"
+// or "public".
+type DatabaseInstanceAddress struct {
+	Address *string `json:"address,omitempty"`
+	Type    *string `json:"type,omitempty"`
+	Network *string `json:"network,omitempty"`
+}
+
+// DatabaseInstanceFault is the reason of the failure of an instance.
+type DatabaseInstanceFault struct {
+	Message *string `json:"message,omitempty"`
+	Details *string `json:"details,omitempty"`
+	Created *string `json:"created,omitempty"`
+}
+
+/*
+ * DATABASES AND USERS
+ */
+
+// Database is a database (schema) of an instance.
+type Database struct {
+	Name         string  `json:"name"`
+	CharacterSet *string `json:"character_set,omitempty"`
+	Collate      *string `json:"collate,omitempty"`
+}
+
+// DatabaseUser is a user of an instance: Host is the host the user can connect
+// from ("%" for any host), Databases the databases the user can access; the
+// password is only given on creation.
+type DatabaseUser struct {
+	Name      string      `json:"name"`
+	Password  *string     `json:"password,omitempty"`
+	Host      *string     `json:"host,omitempty"`
+	Databases *[]Database `json:"databases,omitempty"`
+}
+
+/*
+ * BACKUPS
+ */
+
+// DatabaseBackup is a backup of an instance, stored in the object store
+// (LocationRef): Size is in GB, ParentID is the backup an incremental backup
+// is based on.
+type DatabaseBackup struct {
+	ID          *string            `json:"id,omitempty"`
+	Name        *string            `json:"name,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	InstanceID  *string            `json:"instance_id,omitempty"`
+	Status      *string            `json:"status,omitempty"`
+	Size        *float64           `json:"size,omitempty"`
+	LocationRef *string            `json:"locationRef,omitempty"`
+	ParentID    *string            `json:"parent_id,omitempty"`
+	Datastore   *DatabaseDatastore `json:"datastore,omitempty"`
+	Created     *string            `json:"created,omitempty"`
+	Updated     *string            `json:"updated,omitempty"`
+}
@@ -0,0 +1,45 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// DNSV2API represents the DNS API ver. 2 (Designate), providing support for
+// the management of zones, of their record sets and of the transfer of zones
+// between projects.
+// Designate works asynchronously: changes are accepted (202) in the PENDING
+// status and applied to the DNS servers afterwards (see WaitForZoneActive);
+// all names are fully qualified, i.e. they end with a dot (see FQDN).
+// See https://developer.openstack.org/api-ref/dns/
+type DNSV2API struct {
+	API
+}
+
+// FQDN returns the given name as a fully qualified domain name, i.e. with a
+// trailing dot, as required by Designate.
+func FQDN(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// dnsLinks returns the link to the next page under "links", which in Designate
+// also holds the link to the page itself.
+func dnsLinks(values map[string]json.RawMessage, collection string) (string, error) {
+	links := &struct {
+		Self *string `json:"self,omitempty"`
+		Next *string `json:"next,omitempty"`
+	}{}
+	if value, ok := values["links"]; ok {
+		if err := json.Unmarshal(value, links); err != nil {
+			return "", err
+		}
+	}
+	return stringValue(links.Next), nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Record set types.
+const (
+	RecordTypeA     string = "A"
+	RecordTypeAAAA  string = "AAAA"
+	RecordTypeCNAME string = "CNAME"
+	RecordTypeTXT   string = "TXT"
+	RecordTypePTR   string = "PTR"
+	RecordTypeMX    string = "MX"
+	RecordTypeSRV   string = "SRV"
+	RecordTypeNS    string = "NS"
+	RecordTypeSOA   string = "SOA"
+)
+
+// recordSetRequest is the request entity of the calls on a record set; the
+// zone and record set IDs are bound to the {zoneid} and {id} variables.
+type recordSetRequest struct {
+	ZoneID      string `parameter:"-" header:"-" variable:"zoneid" json:"-"`
+	RecordSetID string `parameter:"-" header:"-" variable:"id" json:"-"`
+}
+
+/*
+ * LIST RECORD SETS
+ */
+
+// ListRecordSetsOptions provides the options available for filtering the list
+// of record sets.
+type ListRecordSetsOptions struct {
+	Name        *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Type        *string `parameter:"type,omitempty" header:"-" json:"-"`
+	Data        *string `parameter:"data,omitempty" header:"-" json:"-"`
+	Status      *string `parameter:"status,omitempty" header:"-" json:"-"`
+	Description *string `parameter:"description,omitempty" header:"-" json:"-"`
+	TTL         *int    `parameter:"ttl,omitempty" header:"-" json:"-"`
+	SortKey     *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir     *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit       *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker      *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListRecordSets returns the list of record sets in the zone with the given
+// ID, or in all the zones of the current project if zoneid is empty; see also
+// https://developer.openstack.org/api-ref/dns/#list-recordsets-in-a-zone
+func (api *DNSV2API) ListRecordSets(zoneid string, opts *ListRecordSetsOptions) (*[]RecordSet, *Result, error) {
+	if opts == nil {
+		opts = &ListRecordSetsOptions{}
+	}
+	path := "./v2/recordsets"
+	var input interface{} = opts
+	if zoneid != "" {
+		path = "./v2/zones/{zoneid}/recordsets"
+		input = &struct {
+			ZoneID string `parameter:"-" header:"-" variable:"zoneid" json:"-"`
+			ListRecordSetsOptions
+		}{
+			ZoneID:                zoneid,
+			ListRecordSetsOptions: *opts,
+		}
+	}
+
+	recordsets := []RecordSet{}
+	result, err := api.listResources(path, "recordsets", input, dnsLinks,
+		func() interface{} { return &[]RecordSet{} },
+		func(page interface{}) { recordsets = append(recordsets, *page.(*[]RecordSet)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &recordsets, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE RECORD SET
+ */
+
+// CreateRecordSetOptions provides the options available for creating a record
+// set: Name must be fully qualified and within the zone (see FQDN), Type one of
+// the RecordType* constants, Records the data of the records (e.g. IP addresses
+// for A and AAAA records, fully qualified names for CNAME and PTR records).
+type CreateRecordSetOptions struct {
+	Name        string   `parameter:"-" header:"-" json:"name"`
+	Type        string   `parameter:"-" header:"-" json:"type"`
+	Records     []string `parameter:"-" header:"-" json:"records"`
+	TTL         *int     `parameter:"-" header:"-" json:"ttl,omitempty"`
+	Description *string  `parameter:"-" header:"-" json:"description,omitempty"`
+}
+
+// CreateRecordSet creates a record set in the zone with the given ID; the change
+// is applied asynchronously; see also
+// https://developer.openstack.org/api-ref/dns/#create-recordset
+func (api *DNSV2API) CreateRecordSet(zoneid string, opts *CreateRecordSetOptions) (*RecordSet, *Result, error) {
+	input := &struct {
+		ZoneID string `parameter:"-" header:"-" variable:"zoneid" json:"-"`
+		CreateRecordSetOptions
+	}{
+		ZoneID:                 zoneid,
+		CreateRecordSetOptions: *opts,
+	}
+	recordset := &RecordSet{}
+
+	result, err := api.Invoke(http.MethodPost, "./v2/zones/{zoneid}/recordsets", true, StatusCodeIn(201, 202), input, recordset, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return recordset, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE RECORD SET
+ */
+
+// RetrieveRecordSet retrieves the record set with the given ID in the zone with
+// the given ID; see also
+// https://developer.openstack.org/api-ref/dns/#show-a-recordset
+func (api *DNSV2API) RetrieveRecordSet(zoneid string, recordsetid string) (*RecordSet, *Result, error) {
+	input := &recordSetRequest{
+		ZoneID:      zoneid,
+		RecordSetID: recordsetid,
+	}
+	recordset := &RecordSet{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/zones/{zoneid}/recordsets/{id}", true, StatusCodeIn(200), input, recordset, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return recordset, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE RECORD SET
+ */
+
+// UpdateRecordSetOptions provides the options available for updating a record
+// set; only the given attributes are modified, Records replaces all the records.
+type UpdateRecordSetOptions struct {
+	Records     *[]string `parameter:"-" header:"-" json:"records,omitempty"`
+	TTL         *int      `parameter:"-" header:"-" json:"ttl,omitempty"`
+	Description *string   `parameter:"-" header:"-" json:"description,omitempty"`
+}
+
+// UpdateRecordSet updates the record set with the given ID in the zone with the
+// given ID; the change is applied asynchronously; see also
+// https://developer.openstack.org/api-ref/dns/#update-a-recordset
+func (api *DNSV2API) UpdateRecordSet(zoneid string, recordsetid string, opts *UpdateRecordSetOptions) (*RecordSet, *Result, error) {
+	input := &struct {
+		ZoneID      string `parameter:"-" header:"-" variable:"zoneid" json:"-"`
+		RecordSetID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		UpdateRecordSetOptions
+	}{
+		ZoneID:                 zoneid,
+		RecordSetID:            recordsetid,
+		UpdateRecordSetOptions: *opts,
+	}
+	recordset := &RecordSet{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2/zones/{zoneid}/recordsets/{id}", true, StatusCodeIn(200, 202), input, recordset, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return recordset, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE RECORD SET
+ */
+
+// DeleteRecordSet deletes the record set with the given ID in the zone with the
+// given ID; the deletion is applied asynchronously; see also
+// https://developer.openstack.org/api-ref/dns/#delete-a-recordset
+func (api *DNSV2API) DeleteRecordSet(zoneid string, recordsetid string) (bool, *Result, error) {
+	input := &recordSetRequest{
+		ZoneID:      zoneid,
+		RecordSetID: recordsetid,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v2/zones/{zoneid}/recordsets/{id}", true, StatusCodeIn(202, 204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return true, result, err
+	}
+	return false, result, err
+}
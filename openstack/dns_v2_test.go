@@ -0,0 +1,18 @@
+package openstack
+
+import (
+	"testing"
+)
+
+func TestFQDN(t *testing.T) {
+	names := map[string]string{
+		"example.com":      "example.com.",
+		"example.com.":     "example.com.",
+		"www.example.com.": "www.example.com.",
+	}
+	for name, expected := range names {
+		if actual := FQDN(name); actual != expected {
+			t.Errorf("DNS.TestFQDN: expected %q for %q, got %q", expected, name, actual)
+		}
+	}
+}
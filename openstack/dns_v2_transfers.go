@@ -0,0 +1,127 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * ZONE TRANSFER REQUESTS
+ */
+
+// CreateZoneTransferRequestOptions provides the options available for offering
+// a zone to another project: if TargetProjectID is not set, any project knowing
+// the ID and key of the request can accept it.
+type CreateZoneTransferRequestOptions struct {
+	TargetProjectID *string `parameter:"-" header:"-" json:"target_project_id,omitempty"`
+	Description     *string `parameter:"-" header:"-" json:"description,omitempty"`
+}
+
+// CreateZoneTransferRequest offers the zone with the given ID to another
+// project; the returned request carries the key to be given to the receiving
+// project; opts can be nil; see also
+// https://developer.openstack.org/api-ref/dns/#create-zone-transfer-request
+func (api *DNSV2API) CreateZoneTransferRequest(zoneid string, opts *CreateZoneTransferRequestOptions) (*ZoneTransferRequest, *Result, error) {
+	if opts == nil {
+		opts = &CreateZoneTransferRequestOptions{}
+	}
+	input := &struct {
+		ZoneID string `parameter:"-" header:"-" variable:"zoneid" json:"-"`
+		CreateZoneTransferRequestOptions
+	}{
+		ZoneID:                           zoneid,
+		CreateZoneTransferRequestOptions: *opts,
+	}
+	request := &ZoneTransferRequest{}
+
+	result, err := api.Invoke(http.MethodPost, "./v2/zones/{zoneid}/tasks/transfer_requests", true, StatusCodeIn(201), input, request, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return request, result, err
+	}
+	return nil, result, err
+}
+
+// ListZoneTransferRequests returns the zone transfer requests made by, or
+// targeted to, the current project; see also
+// https://developer.openstack.org/api-ref/dns/#list-zone-transfer-requests
+func (api *DNSV2API) ListZoneTransferRequests() (*[]ZoneTransferRequest, *Result, error) {
+	requests := []ZoneTransferRequest{}
+	result, err := api.listResources("./v2/zones/tasks/transfer_requests", "transfer_requests", nil, dnsLinks,
+		func() interface{} { return &[]ZoneTransferRequest{} },
+		func(page interface{}) { requests = append(requests, *page.(*[]ZoneTransferRequest)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &requests, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveZoneTransferRequest retrieves the zone transfer request with the given
+// ID; see also
+// https://developer.openstack.org/api-ref/dns/#show-a-zone-transfer-request
+func (api *DNSV2API) RetrieveZoneTransferRequest(requestid string) (*ZoneTransferRequest, *Result, error) {
+	request := &ZoneTransferRequest{}
+	result, err := api.Invoke(http.MethodGet, "./v2/zones/tasks/transfer_requests/{id}", true, StatusCodeIn(200), &envelope{ID: requestid}, request, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return request, result, err
+	}
+	return nil, result, err
+}
+
+// DeleteZoneTransferRequest withdraws the zone transfer request with the given
+// ID; see also
+// https://developer.openstack.org/api-ref/dns/#delete-a-zone-transfer-request
+func (api *DNSV2API) DeleteZoneTransferRequest(requestid string) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodDelete, "./v2/zones/tasks/transfer_requests/{id}", true, StatusCodeIn(204), &envelope{ID: requestid}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * ZONE TRANSFER ACCEPTS
+ */
+
+// AcceptZoneTransferRequest accepts the zone transfer request with the given ID,
+// using the key obtained from the offering project, and moves the zone to the
+// current project; see also
+// https://developer.openstack.org/api-ref/dns/#create-zone-transfer-accept
+func (api *DNSV2API) AcceptZoneTransferRequest(requestid string, key string) (*ZoneTransferAccept, *Result, error) {
+	input := &struct {
+		Key       string `parameter:"-" header:"-" json:"key"`
+		RequestID string `parameter:"-" header:"-" json:"zone_transfer_request_id"`
+	}{
+		Key:       key,
+		RequestID: requestid,
+	}
+	accept := &ZoneTransferAccept{}
+
+	result, err := api.Invoke(http.MethodPost, "./v2/zones/tasks/transfer_accepts", true, StatusCodeIn(201), input, accept, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return accept, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveZoneTransferAccept retrieves the zone transfer acceptance with the
+// given ID; see also
+// https://developer.openstack.org/api-ref/dns/#show-zone-transfer-accept
+func (api *DNSV2API) RetrieveZoneTransferAccept(acceptid string) (*ZoneTransferAccept, *Result, error) {
+	accept := &ZoneTransferAccept{}
+	result, err := api.Invoke(http.MethodGet, "./v2/zones/tasks/transfer_accepts/{id}", true, StatusCodeIn(200), &envelope{ID: acceptid}, accept, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return accept, result, err
+	}
+	return nil, result, err
+}
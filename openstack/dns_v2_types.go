@@ -0,0 +1,86 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * ZONES
+ */
+
+// Zone is a DNS zone (e.g. "example.com."): Status is ACTIVE once the zone has
+// been propagated to the DNS servers, PENDING while a change (Action) is being
+// applied, ERROR if it failed; secondary zones are transferred from Masters.
+type Zone struct {
+	ID            *string   `json:"id,omitempty"`
+	Name          *string   `json:"name,omitempty"`
+	Email         *string   `json:"email,omitempty"`
+	TTL           *int      `json:"ttl,omitempty"`
+	Serial        *int      `json:"serial,omitempty"`
+	Status        *string   `json:"status,omitempty"`
+	Action        *string   `json:"action,omitempty"`
+	Description   *string   `json:"description,omitempty"`
+	Type          *string   `json:"type,omitempty"`
+	Masters       *[]string `json:"masters,omitempty"`
+	PoolID        *string   `json:"pool_id,omitempty"`
+	ProjectID     *string   `json:"project_id,omitempty"`
+	Version       *int      `json:"version,omitempty"`
+	CreatedAt     *string   `json:"created_at,omitempty"`
+	UpdatedAt     *string   `json:"updated_at,omitempty"`
+	TransferredAt *string   `json:"transferred_at,omitempty"`
+}
+
+/*
+ * RECORD SETS
+ */
+
+// RecordSet is the set of the records of a given type (e.g. all the A records)
+// for a name in a zone.
+type RecordSet struct {
+	ID          *string   `json:"id,omitempty"`
+	ZoneID      *string   `json:"zone_id,omitempty"`
+	ZoneName    *string   `json:"zone_name,omitempty"`
+	Name        *string   `json:"name,omitempty"`
+	Type        *string   `json:"type,omitempty"`
+	Records     *[]string `json:"records,omitempty"`
+	TTL         *int      `json:"ttl,omitempty"`
+	Status      *string   `json:"status,omitempty"`
+	Action      *string   `json:"action,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	ProjectID   *string   `json:"project_id,omitempty"`
+	Version     *int      `json:"version,omitempty"`
+	CreatedAt   *string   `json:"created_at,omitempty"`
+	UpdatedAt   *string   `json:"updated_at,omitempty"`
+}
+
+/*
+ * ZONE TRANSFERS
+ */
+
+// ZoneTransferRequest is an offer to transfer a zone to another project (or to
+// any, if TargetProjectID is not set); the receiving project accepts it with
+// the ID and the Key of the request.
+type ZoneTransferRequest struct {
+	ID              *string `json:"id,omitempty"`
+	Key             *string `json:"key,omitempty"`
+	ZoneID          *string `json:"zone_id,omitempty"`
+	ZoneName        *string `json:"zone_name,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	TargetProjectID *string `json:"target_project_id,omitempty"`
+	ProjectID       *string `json:"project_id,omitempty"`
+	Status          *string `json:"status,omitempty"`
+	CreatedAt       *string `json:"created_at,omitempty"`
+	UpdatedAt       *string `json:"updated_at,omitempty"`
+}
+
+// ZoneTransferAccept is the acceptance of a zone transfer request; the zone is
+// moved to the accepting project once Status is COMPLETE.
+type ZoneTransferAccept struct {
+	ID                    *string `json:"id,omitempty"`
+	ZoneID                *string `json:"zone_id,omitempty"`
+	ZoneTransferRequestID *string `json:"zone_transfer_request_id,omitempty"`
+	ProjectID             *string `json:"project_id,omitempty"`
+	Status                *string `json:"status,omitempty"`
+	CreatedAt             *string `json:"created_at,omitempty"`
+	UpdatedAt             *string `json:"updated_at,omitempty"`
+}
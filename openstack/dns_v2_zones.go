@@ -0,0 +1,194 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Zone types: primary zones are managed through Designate, secondary zones are
+// transferred from external master servers.
+const (
+	ZoneTypePrimary   string = "PRIMARY"
+	ZoneTypeSecondary string = "SECONDARY"
+)
+
+// Zone and record set status values.
+const (
+	DNSStatusActive  string = "ACTIVE"
+	DNSStatusPending string = "PENDING"
+	DNSStatusError   string = "ERROR"
+)
+
+/*
+ * LIST ZONES
+ */
+
+// ListZonesOptions provides the options available for filtering the list of
+// zones.
+type ListZonesOptions struct {
+	Name        *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Email       *string `parameter:"email,omitempty" header:"-" json:"-"`
+	Type        *string `parameter:"type,omitempty" header:"-" json:"-"`
+	Status      *string `parameter:"status,omitempty" header:"-" json:"-"`
+	Description *string `parameter:"description,omitempty" header:"-" json:"-"`
+	TTL         *int    `parameter:"ttl,omitempty" header:"-" json:"-"`
+	SortKey     *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir     *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit       *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker      *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListZones returns the list of zones of the current project; see also
+// https://developer.openstack.org/api-ref/dns/#list-zones
+func (api *DNSV2API) ListZones(opts *ListZonesOptions) (*[]Zone, *Result, error) {
+	zones := []Zone{}
+	result, err := api.listResources("./v2/zones", "zones", opts, dnsLinks,
+		func() interface{} { return &[]Zone{} },
+		func(page interface{}) { zones = append(zones, *page.(*[]Zone)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &zones, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE ZONE
+ */
+
+// CreateZoneOptions provides the options available for creating a zone: Name
+// must be fully qualified (see FQDN); Email is the contact of the zone
+// administrator, required for primary zones, while Masters, the addresses of
+// the servers to transfer the zone from, is required for secondary ones.
+type CreateZoneOptions struct {
+	Name        string            `parameter:"-" header:"-" json:"name"`
+	Email       *string           `parameter:"-" header:"-" json:"email,omitempty"`
+	TTL         *int              `parameter:"-" header:"-" json:"ttl,omitempty"`
+	Description *string           `parameter:"-" header:"-" json:"description,omitempty"`
+	Type        *string           `parameter:"-" header:"-" json:"type,omitempty"`
+	Masters     *[]string         `parameter:"-" header:"-" json:"masters,omitempty"`
+	Attributes  map[string]string `parameter:"-" header:"-" json:"attributes,omitempty"`
+}
+
+// CreateZone creates a zone; the zone is returned in the PENDING status, and
+// becomes ACTIVE once propagated (see WaitForZoneActive); see also
+// https://developer.openstack.org/api-ref/dns/#create-zone
+func (api *DNSV2API) CreateZone(opts *CreateZoneOptions) (*Zone, *Result, error) {
+	zone := &Zone{}
+	result, err := api.Invoke(http.MethodPost, "./v2/zones", true, StatusCodeIn(201, 202), opts, zone, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return zone, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE ZONE
+ */
+
+// RetrieveZone retrieves the zone with the given ID; see also
+// https://developer.openstack.org/api-ref/dns/#show-a-zone
+func (api *DNSV2API) RetrieveZone(zoneid string) (*Zone, *Result, error) {
+	zone := &Zone{}
+	result, err := api.Invoke(http.MethodGet, "./v2/zones/{id}", true, StatusCodeIn(200), &envelope{ID: zoneid}, zone, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return zone, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE ZONE
+ */
+
+// UpdateZoneOptions provides the options available for updating a zone; only
+// the given attributes are modified.
+type UpdateZoneOptions struct {
+	Email       *string   `parameter:"-" header:"-" json:"email,omitempty"`
+	TTL         *int      `parameter:"-" header:"-" json:"ttl,omitempty"`
+	Description *string   `parameter:"-" header:"-" json:"description,omitempty"`
+	Masters     *[]string `parameter:"-" header:"-" json:"masters,omitempty"`
+}
+
+// UpdateZone updates the zone with the given ID; the change is applied
+// asynchronously; see also
+// https://developer.openstack.org/api-ref/dns/#update-a-zone
+func (api *DNSV2API) UpdateZone(zoneid string, opts *UpdateZoneOptions) (*Zone, *Result, error) {
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		UpdateZoneOptions
+	}{
+		ID:                zoneid,
+		UpdateZoneOptions: *opts,
+	}
+	zone := &Zone{}
+
+	result, err := api.Invoke(http.MethodPatch, "./v2/zones/{id}", true, StatusCodeIn(200, 202), input, zone, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return zone, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE ZONE
+ */
+
+// DeleteZone deletes the zone with the given ID, along with all its record
+// sets; the deletion is asynchronous: the zone is returned in the PENDING
+// status, with the DELETE action; see also
+// https://developer.openstack.org/api-ref/dns/#delete-a-zone
+func (api *DNSV2API) DeleteZone(zoneid string) (*Zone, *Result, error) {
+	zone := &Zone{}
+	result, err := api.Invoke(http.MethodDelete, "./v2/zones/{id}", true, StatusCodeIn(202, 204), &envelope{ID: zoneid}, zone, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return zone, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * WAIT FOR ZONE ACTIVE
+ */
+
+// WaitForZoneActive waits until the zone with the given ID is ACTIVE, i.e. its
+// latest changes (including the ones to its record sets) have been propagated
+// to the DNS servers, polling it with the given backoff policy; the wait fails
+// if the zone goes into the ERROR status or disappears.
+func (api *DNSV2API) WaitForZoneActive(ctx context.Context, zoneid string, backoff Backoff) (*Zone, error) {
+	var current *Zone
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var result *Result
+		var err error
+		current, result, err = api.RetrieveZone(zoneid)
+		if result != nil && result.Code == http.StatusNotFound {
+			return false, fmt.Errorf("zone %q not found", zoneid)
+		}
+		if err != nil {
+			return false, err
+		}
+		if current == nil || current.Status == nil {
+			return false, fmt.Errorf("error retrieving zone %q: %v", zoneid, result)
+		}
+		log.Debugf("zone %q is %s (action: %s)", zoneid, *current.Status, stringValue(current.Action))
+		switch *current.Status {
+		case DNSStatusActive:
+			return true, nil
+		case DNSStatusError:
+			return false, fmt.Errorf("zone %q is in %s status (action: %s)", zoneid, *current.Status, stringValue(current.Action))
+		}
+		return false, nil
+	})
+	return current, err
+}
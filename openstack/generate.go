@@ -0,0 +1,13 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+// The bindings described in the bindings directory are generated by bindgen,
+// through a go:generate line for each description below: edit the
+// descriptions and run "go generate" rather than editing the generated files.
+//go:generate go run github.com/dihedron/go-openstack/tools/bindgen -output identity_v3_projects.go bindings/identity_v3_projects.json
+//go:generate go run github.com/dihedron/go-openstack/tools/bindgen -output identity_v3_grants.go bindings/identity_v3_grants.json
+//go:generate go run github.com/dihedron/go-openstack/tools/bindgen -output compute_v2_quotas.go bindings/compute_v2_quotas.json
+//go:generate go run github.com/dihedron/go-openstack/tools/bindgen -output blockstorage_v3_quotas.go bindings/blockstorage_v3_quotas.json
@@ -28,6 +28,7 @@ type CreateTokenOptions struct {
 	ScopeProjectName *string
 	ScopeDomainID    *string
 	ScopeDomainName  *string
+	ScopeTrustID     *string
 	UnscopedToken    *bool
 	NoCatalog        *bool
 	Authenticated    bool
@@ -50,6 +51,7 @@ func (api *IdentityV3API) CreateToken(opts *CreateTokenOptions) (*Token, *Result
 	input := &struct {
 		NoCatalog *bool           `parameter:"nocatalog,omitempty" header:"-" json:"-"`
 		Auth      *Authentication `parameter:"-" header:"-" json:"auth,omitempty"`
+		Secrets   secrets         `parameter:"-" header:"-" json:"-"`
 	}{
 		NoCatalog: opts.NoCatalog,
 	}
@@ -66,10 +68,7 @@ func (api *IdentityV3API) CreateToken(opts *CreateTokenOptions) (*Token, *Result
 						ID:       opts.UserID,
 						Name:     opts.UserName,
 						Password: opts.UserPassword,
-						Domain: &Domain{
-							ID:   opts.UserDomainID,
-							Name: opts.UserDomainName,
-						},
+						Domain:   userDomain(opts),
 					},
 				},
 			},
@@ -94,14 +93,12 @@ func (api *IdentityV3API) CreateToken(opts *CreateTokenOptions) (*Token, *Result
 					"application_credential",
 				},
 				AppCredential: &AppCredential{
-					ID: opts.AppCredentialID,
+					ID:     opts.AppCredentialID,
+					Secret: opts.Secret,
 					User: &User{
-						ID:   opts.UserID,
-						Name: opts.UserName,
-						Domain: &Domain{
-							ID:   opts.UserDomainID,
-							Name: opts.UserDomainName,
-						},
+						ID:     opts.UserID,
+						Name:   opts.UserName,
+						Domain: userDomain(opts),
 					},
 				},
 			},
@@ -119,19 +116,38 @@ func (api *IdentityV3API) CreateToken(opts *CreateTokenOptions) (*Token, *Result
 
 	failure := String("")
 	result, err := api.Invoke(http.MethodPost, "./v3/auth/tokens", opts.Authenticated, StatusCodeIn(201), input, output, failure)
-	log.Debugf("result is %q (%v, %t)", result, err, result.OK)
-	if output.SubjectToken != nil {
+	log.Debugf("result is %v (%v)", result, err)
+	if output.SubjectToken != nil && output.Token != nil {
 		output.Token.Value = output.SubjectToken
 	}
 	return output.Token, result, err
 }
 
+// userDomain returns the domain of the authenticating user, or nil if neither
+// its ID nor its name is given (e.g. because the user is identified by its ID).
+func userDomain(opts *CreateTokenOptions) *Domain {
+	if opts.UserDomainID == nil && opts.UserDomainName == nil {
+		return nil
+	}
+	return &Domain{
+		ID:   opts.UserDomainID,
+		Name: opts.UserDomainName,
+	}
+}
+
 // initCreateTokenOptionsScope initialises the Scope section of the Authentication
 // object in the HTTP request entity; there are a few priority rules for scoping:
 // for details see the OpenStack Identity v3 documentation.
 func initCreateTokenOptionsScope(opts *CreateTokenOptions) interface{} {
-	// manage scoped/unscoped token requests
-	if opts.ScopeProjectID != nil && len(strings.TrimSpace(*opts.ScopeProjectID)) > 0 {
+	// manage scoped/unscoped token requests; trust scope takes precedence since
+	// the project is implied by the trust itself
+	if opts.ScopeTrustID != nil && len(strings.TrimSpace(*opts.ScopeTrustID)) > 0 {
+		return &Scope{
+			Trust: &Trust{
+				ID: opts.ScopeTrustID,
+			},
+		}
+	} else if opts.ScopeProjectID != nil && len(strings.TrimSpace(*opts.ScopeProjectID)) > 0 {
 		return &Scope{
 			Project: &Project{
 				ID: opts.ScopeProjectID,
@@ -216,13 +232,10 @@ func (api *IdentityV3API) RetrieveToken(opts *RetrieveTokenOptions) (*Token, *Re
 
 	result, err := api.Invoke(http.MethodGet, "./v3/auth/tokens", true, StatusCodeIn(200), opts, output, nil)
 	log.Debugf("result is %v (%v)", result, err)
-	if result.Code == 200 {
+	if result != nil && result.Code == 200 && output.Token != nil {
 		output.Token.Value = output.SubjectToken
 		return output.Token, result, err
 	}
-
-	log.Debugf("header is %s\n", *output.SubjectToken)
-
 	return nil, result, err
 }
 
@@ -241,9 +254,9 @@ type CheckTokenOptions struct {
 // information about it from the Identity server; this API requires a valid admin
 // token.
 func (api *IdentityV3API) CheckToken(opts *CheckTokenOptions) (bool, *Result, error) {
-	result, err := api.Invoke(http.MethodHead, "./v3/auth/tokens", true, StatusCodeIn(200), opts, nil, nil)
+	result, err := api.Invoke(http.MethodHead, "./v3/auth/tokens", true, StatusCodeIn(200, 204), opts, nil, nil)
 	log.Debugf("result is %v (%v)", result, err)
-	if result.Code == 200 || result.Code == 204 {
+	if result != nil && (result.Code == 200 || result.Code == 204) {
 		return true, result, err
 	}
 	return false, result, err
@@ -263,10 +276,9 @@ type DeleteTokenOptions struct {
 // is immediately invalid regardless of the value in the expires_at attribute;
 // this API requires a valid admin token.
 func (api *IdentityV3API) DeleteToken(opts *DeleteTokenOptions) (bool, *Result, error) {
-	// TODO: Docs state it's a 201 (created) upon success, weird! Shouldn't it be 204?
-	result, err := api.Invoke(http.MethodDelete, "./v3/auth/tokens", true, StatusCodeIn(201), opts, nil, nil)
+	result, err := api.Invoke(http.MethodDelete, "./v3/auth/tokens", true, StatusCodeIn(200, 204), opts, nil, nil)
 	log.Debugf("result is %v (%v)", result, err)
-	if result.Code == 200 || result.Code == 204 {
+	if result != nil && (result.Code == 200 || result.Code == 204) {
 		return true, result, err
 	}
 	return false, result, err
@@ -540,8 +552,9 @@ func (api *IdentityV3API) ListUserProjects(userid string) (*[]Project, *Result,
 // https://developer.openstack.org/api-ref/identity/v3/#change-password-for-user
 func (api *IdentityV3API) ChangeUserPassword(userid, oldPassword, newPassword string) (bool, *Result, error) {
 	input := &struct {
-		UserID string `parameter:"-" header:"-" variable:"userid" json:"-"`
-		User   *User  `parameter:"-" header:"-" variable:"-" json:"user,omitmepty"`
+		UserID  string  `parameter:"-" header:"-" variable:"userid" json:"-"`
+		User    *User   `parameter:"-" header:"-" variable:"-" json:"user,omitmepty"`
+		Secrets secrets `parameter:"-" header:"-" json:"-"`
 	}{
 		UserID: userid,
 		User: &User{
@@ -0,0 +1,126 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// ExtensionTrusts is the name of the Identity trusts extension.
+	ExtensionTrusts = "OS-TRUST"
+	// ExtensionFederation is the name of the Identity federation extension.
+	ExtensionFederation = "OS-FEDERATION"
+	// ExtensionOAuth1 is the name of the Identity OAuth 1.0a extension.
+	ExtensionOAuth1 = "OS-OAUTH1"
+	// ExtensionEndpointFilter is the name of the Identity endpoint filter extension.
+	ExtensionEndpointFilter = "OS-EP-FILTER"
+	// ExtensionInherit is the name of the Identity inherited role assignments
+	// extension.
+	ExtensionInherit = "OS-INHERIT"
+	// ExtensionAppCredentials is the relation name of application credentials;
+	// they are a core feature since Queens, not an extension, but clouds running
+	// earlier releases do not expose them.
+	ExtensionAppCredentials = "application_credentials"
+)
+
+/*
+ * LIST VERSIONS
+ */
+
+// ListVersions returns the list of Identity API versions available at the
+// service root; see also
+// https://developer.openstack.org/api-ref/identity/v3/#versions
+func (api *IdentityV3API) ListVersions() (*[]Version, *Result, error) {
+	output := &struct {
+		Versions *struct {
+			Values *[]Version `json:"values,omitempty"`
+		} `header:"-" json:"versions,omitempty"`
+	}{}
+
+	// the root of the service replies "300 Multiple Choices"
+	result, err := api.Invoke(http.MethodGet, "./", false, StatusCodeIn(200, 300), nil, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK && output.Versions != nil {
+		return output.Versions.Values, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE VERSION
+ */
+
+// RetrieveVersion returns the version document of the Identity v3 API, with its
+// status, its links and the supported media types; see also
+// https://developer.openstack.org/api-ref/identity/v3/#versions
+func (api *IdentityV3API) RetrieveVersion() (*Version, *Result, error) {
+	output := &struct {
+		Version *Version `header:"-" json:"version,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v3", false, StatusCodeIn(200), nil, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Version, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE JSON HOME
+ */
+
+// RetrieveHome returns the JSON-Home document of the Identity v3 API, which
+// lists all the resource relations available on this cloud, including those
+// provided by extensions.
+func (api *IdentityV3API) RetrieveHome() (*JSONHome, *Result, error) {
+	input := &struct {
+		Accept string `parameter:"-" header:"Accept" json:"-"`
+	}{
+		Accept: "application/json-home",
+	}
+	output := &JSONHome{}
+
+	result, err := api.Invoke(http.MethodGet, "./v3", false, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// HasExtension checks whether the Identity service supports the given extension
+// (e.g. ExtensionTrusts) by looking it up in the JSON-Home document; it can be
+// used to feature-detect trusts, federation or application credentials before
+// using them.
+func (api *IdentityV3API) HasExtension(name string) (bool, error) {
+	home, result, err := api.RetrieveHome()
+	if err != nil {
+		log.Errorf("error retrieving JSON-Home document: %v", err)
+		return false, err
+	}
+	if home == nil {
+		log.Warnf("no JSON-Home document available: %v", result)
+		return false, nil
+	}
+	return home.HasExtension(name), nil
+}
+
+// HasExtension checks whether any of the resource relations in the JSON-Home
+// document belongs to the given extension; relations have the form
+// https://docs.openstack.org/api/openstack-identity/3/ext/OS-TRUST/1.0/rel/trusts
+// for extensions and .../3/rel/application_credentials for core resources.
+func (home *JSONHome) HasExtension(name string) bool {
+	for relation := range home.Resources {
+		if strings.Contains(relation, "/ext/"+name+"/") || strings.HasSuffix(relation, "/rel/"+name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package openstack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscoveryUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	client := NewDefaultClient(server.URL)
+	api := client.Authenticator.Identity
+	// with the server gone the calls fail with no result, and must not panic
+	server.Close()
+
+	if versions, _, err := api.ListVersions(); versions != nil || err == nil {
+		t.Errorf("Identity.TestDiscoveryUnreachable: ListVersions succeeded with no server")
+	}
+	if version, _, err := api.RetrieveVersion(); version != nil || err == nil {
+		t.Errorf("Identity.TestDiscoveryUnreachable: RetrieveVersion succeeded with no server")
+	}
+	if ok, err := api.HasExtension(ExtensionTrusts); ok || err == nil {
+		t.Errorf("Identity.TestDiscoveryUnreachable: HasExtension succeeded with no server")
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// EnforcementModel is the model used by the unified limits API to enforce
+// limits across the project hierarchy.
+type EnforcementModel string
+
+const (
+	// FlatEnforcementModel means that limits only apply to the project they are
+	// set on: there is no relation between parent and child limits and usage.
+	FlatEnforcementModel EnforcementModel = "flat"
+	// StrictTwoLevelEnforcementModel means that the project hierarchy is at most
+	// two levels deep and that the usage of all children counts against the
+	// limit of the parent, whose limit cannot be exceeded by the sum of them.
+	StrictTwoLevelEnforcementModel EnforcementModel = "strict-two-level"
+)
+
+// IsHierarchical returns whether the usage of child projects must be taken
+// into account when checking the limits of their parent.
+func (m EnforcementModel) IsHierarchical() bool {
+	return m == StrictTwoLevelEnforcementModel
+}
+
+/*
+ * RETRIEVE ENFORCEMENT MODEL
+ */
+
+// RetrieveLimitModel returns the limit enforcement model configured on this
+// cloud, so that quota tooling can adapt its logic accordingly; see also
+// https://developer.openstack.org/api-ref/identity/v3/#get-enforcement-model
+func (api *IdentityV3API) RetrieveLimitModel() (*LimitModel, *Result, error) {
+	output := &struct {
+		Model *LimitModel `header:"-" json:"model,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v3/limits/model", true, StatusCodeIn(200), nil, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Model, result, err
+	}
+	return nil, result, err
+}
@@ -0,0 +1,364 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dihedron/go-log"
+)
+
+// AccessSource describes how a role was granted to a user.
+type AccessSource string
+
+const (
+	// AccessDirect is used for roles assigned directly to the user on the scope.
+	AccessDirect AccessSource = "direct"
+	// AccessGroup is used for roles that the user gets through a group membership.
+	AccessGroup AccessSource = "group"
+	// AccessInherited is used for roles inherited from a parent domain or project.
+	AccessInherited AccessSource = "inherited"
+	// AccessImplied is used for roles implied by another role (see RoleInference).
+	AccessImplied AccessSource = "implied"
+)
+
+// AccessGrant is a single cell in the access matrix: the user holds the given
+// role on the given scope; Source and Via explain why, e.g. Source is "group"
+// and Via is the name of the group through which the role is granted.
+type AccessGrant struct {
+	ScopeType *string      `json:"scope_type,omitempty"`
+	ScopeID   *string      `json:"scope_id,omitempty"`
+	ScopeName *string      `json:"scope_name,omitempty"`
+	RoleID    *string      `json:"role_id,omitempty"`
+	RoleName  *string      `json:"role_name,omitempty"`
+	Source    AccessSource `json:"source,omitempty"`
+	Via       *string      `json:"via,omitempty"`
+}
+
+// UserAccess is the row of the access matrix pertaining to a single user: it
+// lists the groups the user belongs to and all the effective grants.
+type UserAccess struct {
+	User   *User          `json:"user,omitempty"`
+	Groups *[]Group       `json:"groups,omitempty"`
+	Grants *[]AccessGrant `json:"grants,omitempty"`
+}
+
+// AccessReport is the consolidated, per-user access matrix; it is built by
+// combining effective role assignments, group memberships and implied roles
+// and can be exported as JSON or CSV for compliance purposes.
+type AccessReport struct {
+	GeneratedAt *string       `json:"generated_at,omitempty"`
+	Users       *[]UserAccess `json:"users,omitempty"`
+}
+
+// AccessReportOptions specifies which users should be included in the report:
+// if UserIDs is provided, only those users are reported, otherwise all users
+// matching the (optional) Users filter are.
+type AccessReportOptions struct {
+	UserIDs *[]string
+	Users   *ListUsersOptions
+}
+
+// BuildAccessReport retrieves users, their group memberships, their effective
+// role assignments and the implied role rules, and aggregates them into an
+// AccessReport; this API requires a valid admin token.
+func (api *IdentityV3API) BuildAccessReport(opts *AccessReportOptions) (*AccessReport, error) {
+	if opts == nil {
+		opts = &AccessReportOptions{}
+	}
+
+	users, err := api.collectReportUsers(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	inferences, result, err := api.ListRoleInferences()
+	if err != nil {
+		log.Errorf("error listing role inferences: %v", err)
+		return nil, err
+	}
+	if !result.OK {
+		// implied roles are an optional feature, the report can do without
+		log.Warnf("no role inference rules available: %v", result)
+	}
+	implied := map[string][]Role{}
+	if inferences != nil {
+		for _, inference := range *inferences {
+			if inference.PriorRole != nil && inference.PriorRole.ID != nil && inference.Implies != nil {
+				implied[*inference.PriorRole.ID] = append(implied[*inference.PriorRole.ID], *inference.Implies...)
+			}
+		}
+	}
+
+	report := &AccessReport{
+		GeneratedAt: String(time.Now().UTC().Format(ISO8601)),
+		Users:       &[]UserAccess{},
+	}
+	for _, user := range users {
+		if user.ID == nil {
+			continue
+		}
+		access, err := api.buildUserAccess(user, implied)
+		if err != nil {
+			return nil, err
+		}
+		*report.Users = append(*report.Users, *access)
+	}
+	return report, nil
+}
+
+// collectReportUsers retrieves the set of users to be included in the report.
+func (api *IdentityV3API) collectReportUsers(opts *AccessReportOptions) ([]User, error) {
+	users := []User{}
+	if opts.UserIDs != nil {
+		for _, id := range *opts.UserIDs {
+			user, result, err := api.RetrieveUser(id)
+			if err != nil {
+				log.Errorf("error retrieving user %q: %v", id, err)
+				return nil, err
+			}
+			if user == nil {
+				return nil, fmt.Errorf("error retrieving user %q: %v", id, result)
+			}
+			users = append(users, *user)
+		}
+		return users, nil
+	}
+	list, result, err := api.ListUsers(opts.Users)
+	if err != nil {
+		log.Errorf("error listing users: %v", err)
+		return nil, err
+	}
+	if list == nil {
+		return nil, fmt.Errorf("error listing users: %v", result)
+	}
+	return append(users, *list...), nil
+}
+
+// buildUserAccess aggregates the group memberships and the effective role
+// assignments of a single user into a row of the access matrix.
+func (api *IdentityV3API) buildUserAccess(user User, implied map[string][]Role) (*UserAccess, error) {
+	groups, result, err := api.ListUserGroups(*user.ID)
+	if err != nil {
+		log.Errorf("error listing groups for user %q: %v", *user.ID, err)
+		return nil, err
+	}
+	if groups == nil {
+		return nil, fmt.Errorf("error listing groups for user %q: %v", *user.ID, result)
+	}
+	names := map[string]string{}
+	for _, group := range *groups {
+		if group.ID != nil && group.Name != nil {
+			names[*group.ID] = *group.Name
+		}
+	}
+
+	opts := &ListRoleAssignmentsOptions{
+		Effective:    Bool(true),
+		IncludeNames: Bool(true),
+		UserID:       user.ID,
+	}
+	assignments, result, err := api.ListRoleAssignments(opts)
+	if err != nil {
+		log.Errorf("error listing role assignments for user %q: %v", *user.ID, err)
+		return nil, err
+	}
+	if assignments == nil {
+		return nil, fmt.Errorf("error listing role assignments for user %q: %v", *user.ID, result)
+	}
+
+	grants := []AccessGrant{}
+	seen := map[string]bool{}
+	for _, assignment := range *assignments {
+		grant := newAccessGrant(assignment, names)
+		if grant == nil {
+			continue
+		}
+		grants = appendAccessGrant(grants, seen, *grant)
+		// keystone may or may not expand implied roles depending on its version,
+		// so make sure they are all there (duplicates are discarded)
+		grants = appendImpliedGrants(grants, seen, *grant, implied)
+	}
+	sort.SliceStable(grants, func(i, j int) bool {
+		return grantKey(grants[i]) < grantKey(grants[j])
+	})
+
+	return &UserAccess{
+		User:   &user,
+		Groups: groups,
+		Grants: &grants,
+	}, nil
+}
+
+// newAccessGrant converts an effective role assignment into an AccessGrant,
+// working out the source of the assignment from its links.
+func newAccessGrant(assignment RoleAssignment, groups map[string]string) *AccessGrant {
+	if assignment.Role == nil || assignment.Scope == nil {
+		return nil
+	}
+	grant := &AccessGrant{
+		RoleID:   assignment.Role.ID,
+		RoleName: assignment.Role.Name,
+		Source:   AccessDirect,
+	}
+	switch {
+	case assignment.Scope.Project != nil:
+		grant.ScopeType = String("project")
+		grant.ScopeID = assignment.Scope.Project.ID
+		grant.ScopeName = assignment.Scope.Project.Name
+	case assignment.Scope.Domain != nil:
+		grant.ScopeType = String("domain")
+		grant.ScopeID = assignment.Scope.Domain.ID
+		grant.ScopeName = assignment.Scope.Domain.Name
+	case assignment.Scope.System != nil:
+		grant.ScopeType = String("system")
+		grant.ScopeID = String("all")
+		grant.ScopeName = String("all")
+	default:
+		return nil
+	}
+	if links := assignment.Links; links != nil {
+		switch {
+		case links.PriorRole != nil:
+			grant.Source = AccessImplied
+			grant.Via = String(lastPathSegment(*links.PriorRole))
+		case links.Membership != nil:
+			grant.Source = AccessGroup
+			// the membership link has the form .../groups/{group_id}/users/{user_id}
+			segments := strings.Split(strings.TrimSuffix(*links.Membership, "/"), "/")
+			if len(segments) >= 3 {
+				id := segments[len(segments)-3]
+				if name, ok := groups[id]; ok {
+					grant.Via = String(name)
+				} else {
+					grant.Via = String(id)
+				}
+			}
+		case links.Assignment != nil && strings.Contains(*links.Assignment, "OS-INHERIT"):
+			grant.Source = AccessInherited
+		}
+	}
+	return grant
+}
+
+// appendImpliedGrants recursively adds the grants for all the roles implied by
+// the role in the given grant.
+func appendImpliedGrants(grants []AccessGrant, seen map[string]bool, grant AccessGrant, implied map[string][]Role) []AccessGrant {
+	if grant.RoleID == nil {
+		return grants
+	}
+	for _, role := range implied[*grant.RoleID] {
+		if role.ID == nil {
+			continue
+		}
+		via := grant.RoleID
+		if grant.RoleName != nil {
+			via = grant.RoleName
+		}
+		child := AccessGrant{
+			ScopeType: grant.ScopeType,
+			ScopeID:   grant.ScopeID,
+			ScopeName: grant.ScopeName,
+			RoleID:    role.ID,
+			RoleName:  role.Name,
+			Source:    AccessImplied,
+			Via:       via,
+		}
+		if seen[grantKey(child)] {
+			continue
+		}
+		grants = appendAccessGrant(grants, seen, child)
+		grants = appendImpliedGrants(grants, seen, child, implied)
+	}
+	return grants
+}
+
+// appendAccessGrant adds the grant unless the same role is already granted on
+// the same scope.
+func appendAccessGrant(grants []AccessGrant, seen map[string]bool, grant AccessGrant) []AccessGrant {
+	key := grantKey(grant)
+	if seen[key] {
+		return grants
+	}
+	seen[key] = true
+	return append(grants, grant)
+}
+
+// grantKey returns a key identifying the role and the scope of a grant.
+func grantKey(grant AccessGrant) string {
+	return fmt.Sprintf("%s/%s/%s", stringValue(grant.ScopeType), stringValue(grant.ScopeID), stringValue(grant.RoleID))
+}
+
+// WriteJSON writes the access report to the given writer as a JSON document.
+func (r *AccessReport) WriteJSON(writer io.Writer) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		log.Errorf("error marshalling access report to JSON: %v", err)
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// WriteCSV writes the access report to the given writer in CSV format, one row
+// per user grant; users without any grant are reported in a single row with
+// empty scope and role columns, so that they are not missing from the export.
+func (r *AccessReport) WriteCSV(writer io.Writer) error {
+	w := csv.NewWriter(writer)
+	header := []string{
+		"user_id", "user_name", "user_domain_id", "groups",
+		"scope_type", "scope_id", "scope_name",
+		"role_id", "role_name", "source", "via",
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	if r.Users != nil {
+		for _, access := range *r.Users {
+			user := []string{"", "", "", ""}
+			if access.User != nil {
+				user[0] = stringValue(access.User.ID)
+				user[1] = stringValue(access.User.Name)
+				user[2] = stringValue(access.User.DomainID)
+			}
+			if access.Groups != nil {
+				names := []string{}
+				for _, group := range *access.Groups {
+					names = append(names, stringValue(group.Name))
+				}
+				user[3] = strings.Join(names, ";")
+			}
+			if access.Grants == nil || len(*access.Grants) == 0 {
+				if err := w.Write(append(user, "", "", "", "", "", "", "")); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, grant := range *access.Grants {
+				row := append([]string{}, user...)
+				row = append(row,
+					stringValue(grant.ScopeType),
+					stringValue(grant.ScopeID),
+					stringValue(grant.ScopeName),
+					stringValue(grant.RoleID),
+					stringValue(grant.RoleName),
+					string(grant.Source),
+					stringValue(grant.Via),
+				)
+				if err := w.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,101 @@
+package openstack
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBuildAccessReport(t *testing.T) {
+	responses := map[string]string{
+		"/v3/users/u1":        `{"user": {"id": "u1", "name": "alice", "domain_id": "default"}}`,
+		"/v3/users/u1/groups": `{"groups": [{"id": "g1", "name": "admins"}]}`,
+		"/v3/role_inferences": `{"role_inferences": [
+			{"prior_role": {"id": "r-admin", "name": "admin"}, "implies": [{"id": "r-member", "name": "member"}]},
+			{"prior_role": {"id": "r-member", "name": "member"}, "implies": [{"id": "r-reader", "name": "reader"}]}
+		]}`,
+		"/v3/role_assignments": `{"role_assignments": [
+			{
+				"role": {"id": "r-admin", "name": "admin"},
+				"user": {"id": "u1", "name": "alice"},
+				"scope": {"project": {"id": "p1", "name": "prod"}},
+				"links": {
+					"assignment": "http://keystone/v3/projects/p1/groups/g1/roles/r-admin",
+					"membership": "http://keystone/v3/groups/g1/users/u1"
+				}
+			},
+			{
+				"role": {"id": "r-reader", "name": "reader"},
+				"user": {"id": "u1", "name": "alice"},
+				"scope": {"domain": {"id": "default", "name": "Default"}},
+				"links": {"assignment": "http://keystone/v3/domains/default/users/u1/roles/r-reader"}
+			}
+		]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/role_assignments" && (r.URL.Query().Get("effective") != "true" || r.URL.Query().Get("user.id") != "u1") {
+			t.Errorf("unexpected role assignments query: %q", r.URL.RawQuery)
+		}
+		if body, ok := responses[r.URL.Path]; ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(body))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewDefaultClient(server.URL)
+	client.Authenticator.SetToken(&Token{Value: String("admin-token")})
+
+	report, err := client.Authenticator.Identity.BuildAccessReport(&AccessReportOptions{
+		UserIDs: StringSlice([]string{"u1"}),
+	})
+	if err != nil {
+		t.Fatalf("Identity.TestBuildAccessReport: unexpected error: %v", err)
+	}
+	if report.Users == nil || len(*report.Users) != 1 {
+		t.Fatalf("Identity.TestBuildAccessReport: expected 1 user in report")
+	}
+
+	grants := map[string]AccessGrant{}
+	for _, grant := range *(*report.Users)[0].Grants {
+		grants[*grant.ScopeID+"/"+*grant.RoleName] = grant
+	}
+	expected := map[string]AccessSource{
+		"p1/admin":       AccessGroup,
+		"p1/member":      AccessImplied,
+		"p1/reader":      AccessImplied,
+		"default/reader": AccessDirect,
+	}
+	if len(grants) != len(expected) {
+		t.Errorf("Identity.TestBuildAccessReport: expected %d grants, got %d", len(expected), len(grants))
+	}
+	for key, source := range expected {
+		grant, ok := grants[key]
+		if !ok {
+			t.Errorf("Identity.TestBuildAccessReport: missing grant %q", key)
+			continue
+		}
+		if grant.Source != source {
+			t.Errorf("Identity.TestBuildAccessReport: grant %q has source %q, expected %q", key, grant.Source, source)
+		}
+	}
+	if via := grants["p1/admin"].Via; via == nil || *via != "admins" {
+		t.Errorf("Identity.TestBuildAccessReport: group grant should be via \"admins\"")
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := report.WriteCSV(buffer); err != nil {
+		t.Fatalf("Identity.TestBuildAccessReport: error writing CSV: %v", err)
+	}
+	rows, err := csv.NewReader(buffer).ReadAll()
+	if err != nil {
+		t.Fatalf("Identity.TestBuildAccessReport: invalid CSV: %v", err)
+	}
+	if len(rows) != len(expected)+1 {
+		t.Errorf("Identity.TestBuildAccessReport: expected %d CSV rows, got %d", len(expected)+1, len(rows))
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST REVOCATION EVENTS
+ */
+
+// ListRevocationEvents lists the token revocation events that occurred since the
+// given time (or all events if since is nil); tokens are revoked on the server
+// by deleting them (see DeleteToken), which generates an event carrying the
+// audit ID of the token; see also
+// https://developer.openstack.org/api-ref/identity/v3-ext/#list-revocation-events
+func (api *IdentityV3API) ListRevocationEvents(since *time.Time) (*[]RevocationEvent, *Result, error) {
+	input := &struct {
+		Since *string `parameter:"since,omitempty" header:"-" json:"-"`
+	}{}
+	if since != nil {
+		input.Since = String(since.UTC().Format(ISO8601))
+	}
+	output := &struct {
+		Events *[]RevocationEvent `header:"-" json:"events,omitempty"`
+		Links  *Links             `header:"-" json:"links,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v3/OS-REVOKE/events", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Events, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE TOKEN BY AUDIT ID
+ */
+
+// DeleteTokenByAuditID deletes the token with the given audit ID, generating a
+// revocation event for it; it returns false if no token matches. The Identity
+// service only revokes tokens by value and cannot look them up by audit ID, so
+// the token is searched among the given ones: the caller must hold the values
+// of all the candidate tokens (e.g. a service keeping track of the tokens of
+// its own clients), and each of them is retrieved, and thus validated, until
+// the matching one is found. This API requires a valid admin token.
+func (api *IdentityV3API) DeleteTokenByAuditID(auditID string, tokens ...string) (bool, *Result, error) {
+	for _, value := range tokens {
+		token, result, err := api.RetrieveToken(&RetrieveTokenOptions{
+			NoCatalog:    Bool(true),
+			AllowExpired: Bool(true),
+			SubjectToken: value,
+		})
+		if err != nil {
+			return false, result, err
+		}
+		if token != nil && token.AuditIDs != nil && len(*token.AuditIDs) > 0 && (*token.AuditIDs)[0] == auditID {
+			return api.DeleteToken(&DeleteTokenOptions{SubjectToken: value})
+		}
+	}
+	log.Debugf("no token with audit ID %q", auditID)
+	return false, nil, nil
+}
+
+// RevocationList is a local copy of the revocation events, which can be used by
+// token-validating services to check tokens offline; it must be kept in sync by
+// periodically calling Sync, which only retrieves the events that occurred since
+// the previous synchronisation. It is safe for concurrent use.
+type RevocationList struct {
+	api    *IdentityV3API
+	mutex  sync.RWMutex
+	events []RevocationEvent
+	since  *time.Time
+}
+
+// NewRevocationList returns a new, empty revocation list that will be kept in
+// sync using the given Identity service.
+func NewRevocationList(api *IdentityV3API) *RevocationList {
+	return &RevocationList{
+		api:    api,
+		events: []RevocationEvent{},
+	}
+}
+
+// Sync retrieves the revocation events that occurred since the last call and
+// adds them to the local list.
+func (l *RevocationList) Sync() error {
+	l.mutex.RLock()
+	since := l.since
+	l.mutex.RUnlock()
+
+	events, result, err := l.api.ListRevocationEvents(since)
+	if err != nil {
+		log.Errorf("error synchronising revocation events: %v", err)
+		return err
+	}
+	if events == nil {
+		log.Errorf("error synchronising revocation events: %v", result)
+		return fmt.Errorf("error listing revocation events: %v", result)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.events = append(l.events, *events...)
+	// the next synchronisation starts from the most recent event received, so
+	// that it only depends on the server's clock
+	for _, event := range *events {
+		if event.RevokedAt == nil {
+			continue
+		}
+		if revoked, err := parseTimestamp(*event.RevokedAt); err == nil && (l.since == nil || revoked.After(*l.since)) {
+			l.since = &revoked
+		}
+	}
+	log.Debugf("%d new revocation events, %d in total", len(*events), len(l.events))
+	return nil
+}
+
+// Len returns the number of events in the local revocation list.
+func (l *RevocationList) Len() int {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return len(l.events)
+}
+
+// IsRevokedByAuditID checks whether there is a revocation event for the given
+// audit ID, either as the token's own audit ID or as its audit chain ID.
+func (l *RevocationList) IsRevokedByAuditID(auditID string) bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	for _, event := range l.events {
+		if stringValue(event.AuditID) == auditID || stringValue(event.AuditChainID) == auditID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRevoked checks whether the given token matches any of the events in the
+// local revocation list; an event matches a token if the token was issued
+// before the event and all the attributes set in the event match the token.
+func (l *RevocationList) IsRevoked(token *Token) bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	for _, event := range l.events {
+		if event.Matches(token) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks whether the revocation event applies to the given token.
+func (event RevocationEvent) Matches(token *Token) bool {
+	if token == nil {
+		return false
+	}
+
+	if event.IssuedBefore != nil && token.IssuedAt != nil {
+		before, err1 := parseTimestamp(*event.IssuedBefore)
+		issued, err2 := parseTimestamp(*token.IssuedAt)
+		if err1 == nil && err2 == nil && issued.After(before) {
+			return false
+		}
+	}
+
+	var auditID, auditChainID string
+	if token.AuditIDs != nil && len(*token.AuditIDs) > 0 {
+		auditID = (*token.AuditIDs)[0]
+		auditChainID = (*token.AuditIDs)[len(*token.AuditIDs)-1]
+	}
+	if event.AuditID != nil && *event.AuditID != auditID {
+		return false
+	}
+	if event.AuditChainID != nil && *event.AuditChainID != auditChainID {
+		return false
+	}
+
+	if event.UserID != nil {
+		ids := []string{}
+		if token.User != nil {
+			ids = append(ids, stringValue(token.User.ID))
+		}
+		if token.Trust != nil {
+			if token.Trust.TrustorUser != nil {
+				ids = append(ids, stringValue(token.Trust.TrustorUser.ID))
+			}
+			if token.Trust.TrusteeUser != nil {
+				ids = append(ids, stringValue(token.Trust.TrusteeUser.ID))
+			}
+		}
+		if !containsString(ids, *event.UserID) {
+			return false
+		}
+	}
+	if event.ProjectID != nil && (token.Project == nil || stringValue(token.Project.ID) != *event.ProjectID) {
+		return false
+	}
+	if event.DomainScopeID != nil && (token.Domain == nil || stringValue(token.Domain.ID) != *event.DomainScopeID) {
+		return false
+	}
+	if event.DomainID != nil {
+		var id string
+		if token.User != nil && token.User.Domain != nil {
+			id = stringValue(token.User.Domain.ID)
+		}
+		if id != *event.DomainID {
+			return false
+		}
+	}
+	if event.TrustID != nil && (token.Trust == nil || stringValue(token.Trust.ID) != *event.TrustID) {
+		return false
+	}
+	if event.RoleID != nil {
+		ids := []string{}
+		if token.Roles != nil {
+			for _, role := range *token.Roles {
+				ids = append(ids, stringValue(role.ID))
+			}
+		}
+		if !containsString(ids, *event.RoleID) {
+			return false
+		}
+	}
+	if event.ExpiresAt != nil && token.ExpiresAt != nil {
+		expires1, err1 := parseTimestamp(*event.ExpiresAt)
+		expires2, err2 := parseTimestamp(*token.ExpiresAt)
+		if err1 == nil && err2 == nil && !expires1.Equal(expires2) {
+			return false
+		}
+	}
+	return true
+}
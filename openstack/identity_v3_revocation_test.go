@@ -0,0 +1,82 @@
+package openstack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeleteTokenByAuditID(t *testing.T) {
+	deleted := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject := r.Header.Get("X-Subject-Token")
+		switch {
+		case r.URL.Path != "/v3/auth/tokens":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Subject-Token", subject)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"token": {"audit_ids": ["audit-` + subject + `", "chain"]}}`))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, subject)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	client := NewDefaultClient(server.URL)
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+	api := client.Authenticator.Identity
+
+	if ok, result, err := api.DeleteTokenByAuditID("audit-t2", "t1", "t2", "t3"); !ok || err != nil {
+		t.Errorf("Identity.TestDeleteTokenByAuditID: deletion failed: %v (%v)", result, err)
+	}
+	if ok, _, err := api.DeleteTokenByAuditID("chain", "t1", "t3"); ok || err != nil {
+		t.Errorf("Identity.TestDeleteTokenByAuditID: token deleted by audit chain ID (%v)", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "t2" {
+		t.Errorf("Identity.TestDeleteTokenByAuditID: unexpected tokens deleted: %v", deleted)
+	}
+}
+
+func TestRevocationListSync(t *testing.T) {
+	since := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/OS-REVOKE/events" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		since = append(since, r.URL.Query().Get("since"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if len(since) == 1 {
+			w.Write([]byte(`{"events": [
+				{"audit_id": "a1", "revoked_at": "2017-03-01T10:00:00.000000Z"},
+				{"audit_id": "a2", "revoked_at": "2017-03-01T12:30:00.500000Z"},
+				{"audit_id": "a3", "revoked_at": "2017-03-01T11:00:00.000000Z"}
+			]}`))
+		} else {
+			w.Write([]byte(`{"events": []}`))
+		}
+	}))
+	defer server.Close()
+	client := NewDefaultClient(server.URL)
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+	list := NewRevocationList(client.Authenticator.Identity)
+
+	for i := 0; i < 3; i++ {
+		if err := list.Sync(); err != nil {
+			t.Fatalf("Identity.TestRevocationListSync: sync failed: %v", err)
+		}
+	}
+	// an empty synchronisation must not move the starting point
+	expected := []string{"", "2017-03-01T12:30:00.500000Z", "2017-03-01T12:30:00.500000Z"}
+	for i := range expected {
+		if since[i] != expected[i] {
+			t.Errorf("Identity.TestRevocationListSync: sync %d: expected since %q, got %q", i, expected[i], since[i])
+		}
+	}
+	if list.Len() != 3 || !list.IsRevokedByAuditID("a2") {
+		t.Errorf("Identity.TestRevocationListSync: unexpected events in list (%d)", list.Len())
+	}
+}
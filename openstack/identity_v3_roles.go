@@ -0,0 +1,96 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST ROLE ASSIGNMENTS
+ */
+
+// ListRoleAssignmentsOptions provides all the options available for filtering
+// the list of role assignments (see
+// https://developer.openstack.org/api-ref/identity/v3/#list-role-assignments).
+type ListRoleAssignmentsOptions struct {
+	Effective      *bool   `parameter:"effective,omitempty" header:"-" json:"-"`
+	IncludeNames   *bool   `parameter:"include_names,omitempty" header:"-" json:"-"`
+	IncludeSubtree *bool   `parameter:"include_subtree,omitempty" header:"-" json:"-"`
+	GroupID        *string `parameter:"group.id,omitempty" header:"-" json:"-"`
+	RoleID         *string `parameter:"role.id,omitempty" header:"-" json:"-"`
+	ScopeDomainID  *string `parameter:"scope.domain.id,omitempty" header:"-" json:"-"`
+	ScopeProjectID *string `parameter:"scope.project.id,omitempty" header:"-" json:"-"`
+	ScopeSystem    *string `parameter:"scope.system,omitempty" header:"-" json:"-"`
+	UserID         *string `parameter:"user.id,omitempty" header:"-" json:"-"`
+	InheritedTo    *string `parameter:"scope.OS-INHERIT:inherited_to,omitempty" header:"-" json:"-"`
+}
+
+// ListRoleAssignments returns the list of role assignments matching the given
+// filters; in effective mode group assignments are expanded into the equivalent
+// user assignments, inherited assignments are propagated to the projects in the
+// subtree and implied roles are added; see also
+// https://developer.openstack.org/api-ref/identity/v3/#list-role-assignments
+func (api *IdentityV3API) ListRoleAssignments(opts *ListRoleAssignmentsOptions) (*[]RoleAssignment, *Result, error) {
+	output := &struct {
+		RoleAssignments *[]RoleAssignment `header:"-" json:"role_assignments,omitempty"`
+		Links           *Links            `header:"-" json:"links,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v3/role_assignments", true, StatusCodeIn(200), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.RoleAssignments, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * LIST ROLE INFERENCE RULES
+ */
+
+// ListRoleInferences returns the list of all implied role rules, grouped by
+// prior role; see also
+// https://developer.openstack.org/api-ref/identity/v3/#list-all-role-inference-rules
+func (api *IdentityV3API) ListRoleInferences() (*[]RoleInference, *Result, error) {
+	output := &struct {
+		RoleInferences *[]RoleInference `header:"-" json:"role_inferences,omitempty"`
+		Links          *Links           `header:"-" json:"links,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v3/role_inferences", true, StatusCodeIn(200), nil, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.RoleInferences, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * LIST IMPLIED ROLES
+ */
+
+// ListImpliedRoles lists the roles implied by the given prior role; see also
+// https://developer.openstack.org/api-ref/identity/v3/#list-implied-roles-for-role
+func (api *IdentityV3API) ListImpliedRoles(priorRoleID string) (*RoleInference, *Result, error) {
+	input := &struct {
+		PriorRoleID string `parameter:"-" header:"-" variable:"priorroleid" json:"-"`
+	}{
+		PriorRoleID: priorRoleID,
+	}
+	output := &struct {
+		RoleInference *RoleInference `header:"-" json:"role_inference,omitempty"`
+		Links         *Links         `header:"-" json:"links,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v3/roles/{priorroleid}/implies", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.RoleInference, result, err
+	}
+	return nil, result, err
+}
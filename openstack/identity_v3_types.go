@@ -48,17 +48,35 @@ type Domain struct {
 	Links       *Links  `json:"links,omitempty"`
 }
 
+// InterfaceType is the kind of usage to which an endpoint is devoted.
+type InterfaceType string
+
+const (
+	// InterfacePublic endpoints can be accessed by everyone, including the
+	// subscribers of a public cloud.
+	InterfacePublic InterfaceType = "public"
+	// InterfaceInternal endpoints are technical endpoints, devoted to
+	// inter-service communications.
+	InterfaceInternal InterfaceType = "internal"
+	// InterfaceAdmin endpoints can only be accessed by cloud administrators.
+	InterfaceAdmin InterfaceType = "admin"
+)
+
+// Ptr returns a pointer to the interface type, to be used in OpenStack API
+// structs.
+func (t InterfaceType) Ptr() *InterfaceType {
+	return &t
+}
+
 // Endpoint is the address of a specific interface to a service; the Interface
-// field specifies the kind of usage to which the endpoint is devoted, i.e.
-// "public" (everyone can access it, including subscribers), "admin" (only cloud
-// administators have access to it) and "internal" (technical endpoint, devoted
-// to inter-service communications).
+// field specifies the kind of usage to which the endpoint is devoted (see
+// InterfaceType).
 type Endpoint struct {
-	ID        *string `json:"id,omitempty"`
-	Interface *string `json:"interface,omitempty"`
-	Region    *string `json:"region,omitempty"`
-	RegionID  *string `json:"region_id,omitempty"`
-	URL       *string `json:"url,omitempty"`
+	ID        *string        `json:"id,omitempty"`
+	Interface *InterfaceType `json:"interface,omitempty"`
+	Region    *string        `json:"region,omitempty"`
+	RegionID  *string        `json:"region_id,omitempty"`
+	URL       *string        `json:"url,omitempty"`
 }
 
 // Group is a collection of users. Each group is owned by a domain. You can use
@@ -84,6 +102,29 @@ type Identity struct {
 	AppCredential *AppCredential `json:"application_credential,omitempty"`
 }
 
+// JSONHome is the JSON-Home document describing all the resources (and their
+// relations) exposed by the Identity service, including those provided by its
+// extensions (e.g. OS-TRUST or OS-FEDERATION); see https://mnot.github.io/I-D/json-home/.
+type JSONHome struct {
+	Resources map[string]JSONHomeResource `json:"resources,omitempty"`
+}
+
+// JSONHomeResource is a resource in a JSON-Home document; it is identified
+// either by an Href or by an HrefTemplate and the associated HrefVars.
+type JSONHomeResource struct {
+	Href         *string                `json:"href,omitempty"`
+	HrefTemplate *string                `json:"href-template,omitempty"`
+	HrefVars     map[string]string      `json:"href-vars,omitempty"`
+	Hints        map[string]interface{} `json:"hints,omitempty"`
+}
+
+// LimitModel describes the enforcement model used by the unified limits API
+// on this cloud.
+type LimitModel struct {
+	Name        *EnforcementModel `json:"name,omitempty"`
+	Description *string           `json:"description,omitempty"`
+}
+
 // Links represents the links to the resource itself and its immediate siblings
 // if available; it is used for embedding inside other resources as a rudimentary
 // support for HATEOAS.
@@ -104,14 +145,37 @@ type Password struct {
 // Project and act as a Domain; most services are associated with tokens issued
 // with Project scope.
 type Project struct {
-	ID          *string `json:"id,omitempty"`
-	Name        *string `json:"name,omitempty"`
-	Domain      *Domain `json:"domain,omitempty"`
-	DomainID    *string `json:"domain_id,omitempty"`
-	ParentID    *string `json:"parent_id,omitempty"`
-	Description *string `json:"description,omitempty"`
-	Enabled     *bool   `json:"enabled,omitempty"`
-	Links       *Links  `json:"links,omitempty"`
+	ID          *string   `json:"id,omitempty"`
+	Name        *string   `json:"name,omitempty"`
+	Domain      *Domain   `json:"domain,omitempty"`
+	DomainID    *string   `json:"domain_id,omitempty"`
+	ParentID    *string   `json:"parent_id,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Enabled     *bool     `json:"enabled,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`
+	Links       *Links    `json:"links,omitempty"`
+}
+
+// RevocationEvent describes a set of revoked tokens: all tokens issued before
+// IssuedBefore and matching all the other non-empty attributes are invalid; an
+// event carrying an AuditID revokes a single token, one carrying an AuditChainID
+// revokes a token and all those that have been obtained by rescoping it.
+type RevocationEvent struct {
+	IssuedBefore  *string `json:"issued_before,omitempty"`
+	RevokedAt     *string `json:"revoked_at,omitempty"`
+	ExpiresAt     *string `json:"expires_at,omitempty"`
+	AuditID       *string `json:"audit_id,omitempty"`
+	AuditChainID  *string `json:"audit_chain_id,omitempty"`
+	UserID        *string `json:"user_id,omitempty"`
+	ProjectID     *string `json:"project_id,omitempty"`
+	DomainID      *string `json:"domain_id,omitempty"`
+	DomainScopeID *string `json:"domain_scope_id,omitempty"`
+	RoleID        *string `json:"role_id,omitempty"`
+	TrustID       *string `json:"trust_id,omitempty"`
+	TrustorID     *string `json:"trustor_id,omitempty"`
+	TrusteeID     *string `json:"trustee_id,omitempty"`
+	ConsumerID    *string `json:"consumer_id,omitempty"`
+	AccessTokenID *string `json:"access_token_id,omitempty"`
 }
 
 // Role is a personality that a user assumes to perform a specific set of
@@ -124,11 +188,51 @@ type Role struct {
 	Links    *Links  `json:"links,omitempty"`
 }
 
+// RoleAssignment represents the assignment of a Role to an actor (either a User
+// or a Group) on a target (a Project, a Domain or the System); when retrieved
+// in "effective" mode, group assignments are expanded into user assignments and
+// the originating group membership is reported in the Links.
+type RoleAssignment struct {
+	Role  *Role                `json:"role,omitempty"`
+	User  *User                `json:"user,omitempty"`
+	Group *Group               `json:"group,omitempty"`
+	Scope *RoleAssignmentScope `json:"scope,omitempty"`
+	Links *RoleAssignmentLinks `json:"links,omitempty"`
+}
+
+// RoleAssignmentLinks contains the links to the assignment itself and, when the
+// assignment is effective, to the group membership and to the prior role that
+// originated it.
+type RoleAssignmentLinks struct {
+	Assignment *string `json:"assignment,omitempty"`
+	Membership *string `json:"membership,omitempty"`
+	PriorRole  *string `json:"prior_role,omitempty"`
+}
+
+// RoleAssignmentScope is the target of a RoleAssignment; only one of Project,
+// Domain and System is set; InheritedTo is set when the assignment is inherited
+// by the projects in the subtree of the target.
+type RoleAssignmentScope struct {
+	Project     *Project `json:"project,omitempty"`
+	Domain      *Domain  `json:"domain,omitempty"`
+	System      *System  `json:"system,omitempty"`
+	InheritedTo *string  `json:"OS-INHERIT:inherited_to,omitempty"`
+}
+
+// RoleInference represents an implied role rule: any actor that is assigned the
+// PriorRole is implicitly granted all the Implies roles as well.
+type RoleInference struct {
+	PriorRole *Role   `json:"prior_role,omitempty"`
+	Implies   *[]Role `json:"implies,omitempty"`
+}
+
 // Scope represents the scope of a Token; a Token can be issued either at Project
-// or at Domain scope, but not both (mutually exclusive).
+// or at Domain scope, but not both (mutually exclusive); a trustee can also
+// request a Token scoped to a Trust, to act on behalf of the trustor.
 type Scope struct {
 	Project *Project `json:"project,omitempty"`
 	Domain  *Domain  `json:"domain,omitempty"` // either one or the other: if both, BadRequest!
+	Trust   *Trust   `json:"OS-TRUST:trust,omitempty"`
 }
 
 // Service represents an OpenStack service, such as Compute (nova), Object Storage
@@ -165,12 +269,32 @@ type Token struct {
 	Methods      *[]string  `json:"methods,omitempty"`
 	AuditIDs     *[]string  `json:"audit_ids,omitempty"`
 	Project      *Project   `json:"project,omitempty"`
+	Domain       *Domain    `json:"domain,omitempty"`
 	IsDomain     *bool      `json:"is_domain,omitempty"`
 	IsAdminToken *bool      `json:"is_admin_token,omitempty"`
 	Catalog      *[]Service `json:"catalog,omitempty"`
+	Trust        *Trust     `json:"OS-TRUST:trust,omitempty"`
 	Value        *string    `json:"-"`
 }
 
+// Trust represents a delegation of roles on a project from a trustor (the user
+// creating the trust) to a trustee (e.g. a service user), who can then obtain
+// Trust-scoped tokens to act on behalf of the trustor; when Impersonation is
+// set, such tokens carry the trustor's identity rather than the trustee's.
+type Trust struct {
+	ID            *string `json:"id,omitempty"`
+	Impersonation *bool   `json:"impersonation,omitempty"`
+	TrustorUser   *User   `json:"trustor_user,omitempty"`
+	TrustorUserID *string `json:"trustor_user_id,omitempty"`
+	TrusteeUser   *User   `json:"trustee_user,omitempty"`
+	TrusteeUserID *string `json:"trustee_user_id,omitempty"`
+	ProjectID     *string `json:"project_id,omitempty"`
+	ExpiresAt     *string `json:"expires_at,omitempty"`
+	RemainingUses *int    `json:"remaining_uses,omitempty"`
+	Roles         *[]Role `json:"roles,omitempty"`
+	Links         *Links  `json:"links,omitempty"`
+}
+
 // User is a digital representation of a person, system, or service that uses
 // OpenStack cloud services. The Identity service validates that incoming requests
 // are made by the user who claims to be making the call. Users have a login and
@@ -0,0 +1,29 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ImageV2API represents the image API ver. 2 (Glance), providing support for
+// the management of images, of their data and of the metadata definitions
+// catalog.
+// See https://developer.openstack.org/api-ref/image/v2/
+type ImageV2API struct {
+	API
+}
+
+// imageNextLink returns the link to the next page under "next", which in Glance
+// is absolute (e.g. "/v2/images?marker=...") but relative to the endpoint,
+// which may have a path of its own.
+func imageNextLink(values map[string]json.RawMessage, collection string) (string, error) {
+	next, err := nextField(values, collection)
+	if err != nil || next == "" {
+		return next, err
+	}
+	return "./" + strings.TrimPrefix(next, "/"), nil
+}
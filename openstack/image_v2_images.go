@@ -0,0 +1,507 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// ImageVisibility is the visibility of an image, i.e. which projects can see
+// and use it; see https://docs.openstack.org/glance/latest/user/glanceapi.html.
+type ImageVisibility string
+
+const (
+	// ImageVisibilityPublic is the visibility of images available to all projects.
+	ImageVisibilityPublic ImageVisibility = "public"
+	// ImageVisibilityPrivate is the visibility of images only available to the
+	// owner project.
+	ImageVisibilityPrivate ImageVisibility = "private"
+	// ImageVisibilityShared is the visibility of images available to the owner
+	// and to the member projects.
+	ImageVisibilityShared ImageVisibility = "shared"
+	// ImageVisibilityCommunity is the visibility of images available to all
+	// projects, but not listed by default.
+	ImageVisibilityCommunity ImageVisibility = "community"
+	// ImageVisibilityAll lists images regardless of their visibility (only as a
+	// filter).
+	ImageVisibilityAll ImageVisibility = "all"
+)
+
+// Ptr returns a pointer to the image visibility, to be used in OpenStack API
+// structs.
+func (v ImageVisibility) Ptr() *ImageVisibility {
+	return &v
+}
+
+// ImageStatus is the status of an image, as reported by the Image service; see
+// https://docs.openstack.org/glance/latest/user/statuses.html.
+type ImageStatus string
+
+const (
+	// ImageStatusQueued is the status of images whose data has not been uploaded.
+	ImageStatusQueued ImageStatus = "queued"
+	// ImageStatusSaving is the status of images whose data is being uploaded.
+	ImageStatusSaving ImageStatus = "saving"
+	// ImageStatusUploading is the status of images whose data is being staged.
+	ImageStatusUploading ImageStatus = "uploading"
+	// ImageStatusImporting is the status of images being imported.
+	ImageStatusImporting ImageStatus = "importing"
+	// ImageStatusActive is the status of images available for use.
+	ImageStatusActive ImageStatus = "active"
+	// ImageStatusDeactivated is the status of images whose data cannot be
+	// downloaded by non-administrators.
+	ImageStatusDeactivated ImageStatus = "deactivated"
+	// ImageStatusKilled is the status of images whose data upload failed.
+	ImageStatusKilled ImageStatus = "killed"
+	// ImageStatusDeleted is the status of deleted images.
+	ImageStatusDeleted ImageStatus = "deleted"
+)
+
+// Ptr returns a pointer to the image status, to be used in OpenStack API
+// structs.
+func (s ImageStatus) Ptr() *ImageStatus {
+	return &s
+}
+
+/*
+ * LIST IMAGES
+ */
+
+// ListImagesOptions provides the options available for filtering the list of
+// images: only the images having all the given Tags are returned; MemberStatus
+// ("accepted", "pending", "rejected" or "all") applies to shared images;
+// CreatedAt and UpdatedAt are compared with the given operator (e.g. GT);
+// Limit sets the page size, all pages are retrieved anyway.
+type ListImagesOptions struct {
+	ID              *string          `parameter:"id,omitempty" header:"-" json:"-"`
+	Name            *string          `parameter:"name,omitempty" header:"-" json:"-"`
+	Status          *ImageStatus     `parameter:"status,omitempty" header:"-" json:"-"`
+	Visibility      *ImageVisibility `parameter:"visibility,omitempty" header:"-" json:"-"`
+	Owner           *string          `parameter:"owner,omitempty" header:"-" json:"-"`
+	MemberStatus    *string          `parameter:"member_status,omitempty" header:"-" json:"-"`
+	Hidden          *bool            `parameter:"os_hidden,omitempty" header:"-" json:"-"`
+	Protected       *bool            `parameter:"protected,omitempty" header:"-" json:"-"`
+	DiskFormat      *string          `parameter:"disk_format,omitempty" header:"-" json:"-"`
+	ContainerFormat *string          `parameter:"container_format,omitempty" header:"-" json:"-"`
+	SizeMin         *int64           `parameter:"size_min,omitempty" header:"-" json:"-"`
+	SizeMax         *int64           `parameter:"size_max,omitempty" header:"-" json:"-"`
+	CreatedAt       *TimeFilter      `parameter:"created_at,omitempty" header:"-" variable:"-" json:"-"`
+	UpdatedAt       *TimeFilter      `parameter:"updated_at,omitempty" header:"-" variable:"-" json:"-"`
+	Tags            *[]string        `parameter:"-" header:"-" json:"-"`
+	Sort            *string          `parameter:"sort,omitempty" header:"-" json:"-"`
+	SortKey         *string          `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir         *string          `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit           *int             `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker          *string          `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListImages returns the list of images visible to the current project; see
+// also https://developer.openstack.org/api-ref/image/v2/#list-images
+func (api *ImageV2API) ListImages(opts *ListImagesOptions) (*[]Image, *Result, error) {
+	path := "./v2/images"
+	if opts != nil && opts.Tags != nil && len(*opts.Tags) > 0 {
+		// the tag filter is repeated once per tag
+		query := url.Values{}
+		for _, tag := range *opts.Tags {
+			query.Add("tag", tag)
+		}
+		path = path + "?" + query.Encode()
+	}
+	images := []Image{}
+	result, err := api.listResources(path, "images", opts, imageNextLink,
+		func() interface{} { return &[]Image{} },
+		func(page interface{}) { images = append(images, *page.(*[]Image)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &images, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE IMAGE
+ */
+
+// CreateImageOptions provides the options available for creating an image
+// record; the image data is uploaded separately (see UploadImageData and
+// ImportImage); Properties are additional, free-form image properties (e.g.
+// "hw_disk_bus", "os_distro").
+type CreateImageOptions struct {
+	ID              *string                `json:"id,omitempty"`
+	Name            *string                `json:"name,omitempty"`
+	Visibility      *ImageVisibility       `json:"visibility,omitempty"`
+	Protected       *bool                  `json:"protected,omitempty"`
+	Hidden          *bool                  `json:"os_hidden,omitempty"`
+	DiskFormat      *string                `json:"disk_format,omitempty"`
+	ContainerFormat *string                `json:"container_format,omitempty"`
+	MinDisk         *int                   `json:"min_disk,omitempty"`
+	MinRAM          *int                   `json:"min_ram,omitempty"`
+	Tags            *[]string              `json:"tags,omitempty"`
+	Properties      map[string]interface{} `json:"-"`
+}
+
+// createImageOptions has the same fields as CreateImageOptions, but none of its
+// methods.
+type createImageOptions CreateImageOptions
+
+// MarshalJSON encodes the options, with the additional properties at the same
+// level as the other fields.
+func (opts CreateImageOptions) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(createImageOptions(opts))
+	if err != nil || len(opts.Properties) == 0 {
+		return data, err
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	for key, value := range opts.Properties {
+		if _, ok := values[key]; !ok {
+			values[key] = value
+		}
+	}
+	return json.Marshal(values)
+}
+
+// CreateImage creates a new image record, in the "queued" status until its data
+// is uploaded; see also
+// https://developer.openstack.org/api-ref/image/v2/#create-image
+func (api *ImageV2API) CreateImage(opts *CreateImageOptions) (*Image, *Result, error) {
+	output := &Image{}
+
+	result, err := api.Invoke(http.MethodPost, "./v2/images", true, StatusCodeIn(201), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE IMAGE
+ */
+
+// RetrieveImage retrieves the details of the image identified by the given id;
+// see also https://developer.openstack.org/api-ref/image/v2/#show-image
+func (api *ImageV2API) RetrieveImage(imageid string) (*Image, *Result, error) {
+	input := &struct {
+		ImageID string `parameter:"-" header:"-" variable:"imageid" json:"-"`
+	}{
+		ImageID: imageid,
+	}
+	output := &Image{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/images/{imageid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE IMAGE
+ */
+
+const (
+	// ImagePatchAdd is the JSON Patch operation adding a property, or replacing
+	// it if it already exists.
+	ImagePatchAdd = "add"
+	// ImagePatchReplace is the JSON Patch operation replacing an existing
+	// property.
+	ImagePatchReplace = "replace"
+	// ImagePatchRemove is the JSON Patch operation removing a property.
+	ImagePatchRemove = "remove"
+)
+
+// ImagePatchOperation is a JSON Patch (RFC 6902) operation on an image; Path is
+// a JSON pointer to the property (e.g. "/name", "/hw_disk_bus"); see the
+// AddImageProperty, ReplaceImageProperty and RemoveImageProperty helpers.
+type ImagePatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// AddImageProperty returns the operation adding the given property (either a
+// core one, like "min_disk", or a free-form one) with the given value.
+func AddImageProperty(name string, value interface{}) ImagePatchOperation {
+	return ImagePatchOperation{Op: ImagePatchAdd, Path: imagePropertyPath(name), Value: value}
+}
+
+// ReplaceImageProperty returns the operation replacing the value of the given
+// property, which must exist.
+func ReplaceImageProperty(name string, value interface{}) ImagePatchOperation {
+	return ImagePatchOperation{Op: ImagePatchReplace, Path: imagePropertyPath(name), Value: value}
+}
+
+// RemoveImageProperty returns the operation removing the given property.
+func RemoveImageProperty(name string) ImagePatchOperation {
+	return ImagePatchOperation{Op: ImagePatchRemove, Path: imagePropertyPath(name)}
+}
+
+// imagePropertyPath returns the JSON pointer to the given image property, with
+// "~" and "/" escaped as per RFC 6901.
+func imagePropertyPath(name string) string {
+	return "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// imagePatch is the request entity of UpdateImage: it is sent as a bare array of
+// operations, with the JSON Patch media type required by Glance.
+type imagePatch struct {
+	ImageID     string                `parameter:"-" header:"-" variable:"imageid" json:"-"`
+	ContentType string                `parameter:"-" header:"Content-Type" json:"-"`
+	Operations  []ImagePatchOperation `parameter:"-" header:"-" json:"-"`
+}
+
+// MarshalJSON encodes the patch as the array of its operations.
+func (patch imagePatch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(patch.Operations)
+}
+
+// UpdateImage applies the given operations, in order, to the image identified
+// by the given id and returns the updated image; the update is atomic: if any of
+// the operations fails, none is applied; see also
+// https://developer.openstack.org/api-ref/image/v2/#update-image
+func (api *ImageV2API) UpdateImage(imageid string, operations ...ImagePatchOperation) (*Image, *Result, error) {
+	input := &imagePatch{
+		ImageID:     imageid,
+		ContentType: "application/openstack-images-v2.1-json-patch",
+		Operations:  operations,
+	}
+	if input.Operations == nil {
+		input.Operations = []ImagePatchOperation{}
+	}
+	output := &Image{}
+
+	result, err := api.Invoke(http.MethodPatch, "./v2/images/{imageid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE IMAGE
+ */
+
+// DeleteImage deletes the image identified by the given id, along with its
+// data; protected images cannot be deleted; see also
+// https://developer.openstack.org/api-ref/image/v2/#delete-image
+func (api *ImageV2API) DeleteImage(imageid string) (bool, *Result, error) {
+	input := &struct {
+		ImageID string `parameter:"-" header:"-" variable:"imageid" json:"-"`
+	}{
+		ImageID: imageid,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v2/images/{imageid}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * DEACTIVATE/REACTIVATE IMAGE
+ */
+
+// DeactivateImage deactivates the image identified by the given id, so that
+// its data can no longer be downloaded (nor new servers booted from it) by
+// non-administrators, e.g. while investigating a compromised image; it is an
+// administrative operation by default; see also
+// https://developer.openstack.org/api-ref/image/v2/#deactivate-image
+func (api *ImageV2API) DeactivateImage(imageid string) (bool, *Result, error) {
+	return api.imageAction(imageid, "deactivate")
+}
+
+// ReactivateImage reactivates the image identified by the given id, which
+// must have been deactivated; see also
+// https://developer.openstack.org/api-ref/image/v2/#reactivate-image
+func (api *ImageV2API) ReactivateImage(imageid string) (bool, *Result, error) {
+	return api.imageAction(imageid, "reactivate")
+}
+
+// imageAction performs the given action on the image identified by the given
+// id.
+func (api *ImageV2API) imageAction(imageid string, action string) (bool, *Result, error) {
+	input := &struct {
+		ImageID string `parameter:"-" header:"-" variable:"imageid" json:"-"`
+		Action  string `parameter:"-" header:"-" variable:"action" json:"-"`
+	}{
+		ImageID: imageid,
+		Action:  action,
+	}
+
+	result, err := api.Invoke(http.MethodPost, "./v2/images/{imageid}/actions/{action}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * UPLOAD IMAGE DATA
+ */
+
+// UploadImageDataOptions provides the options available for uploading the data
+// of an image: Progress, if not nil, is called as the data is sent;
+// SkipVerification disables the verification of the data against the checksums
+// computed by the server.
+type UploadImageDataOptions struct {
+	Progress         ProgressFunc
+	SkipVerification bool
+}
+
+// UploadImageData uploads the data of the image identified by the given id,
+// which must be in the "queued" status, streaming it from the given reader
+// (e.g. an open file) so that images of any size can be uploaded without being
+// buffered in memory; unless opts.SkipVerification is set, the checksums of the
+// data are computed as it is sent and compared with those computed by the
+// server, and an *ImageIntegrityError is returned if they do not match, in
+// which case the image should be deleted; opts can be nil; see also
+// https://developer.openstack.org/api-ref/image/v2/#upload-binary-image-data
+func (api *ImageV2API) UploadImageData(imageid string, data io.Reader, opts *UploadImageDataOptions) (bool, *Result, error) {
+	if opts == nil {
+		opts = &UploadImageDataOptions{}
+	}
+
+	var checksums imageChecksums
+	if !opts.SkipVerification {
+		// the algorithm used by the server is only known afterwards: use the
+		// default one and MD5 for the legacy checksum, which is always there
+		checksums = newImageChecksums("sha512", "md5")
+		data = io.TeeReader(data, checksums.writer())
+	}
+
+	input := &struct {
+		ImageID string `parameter:"-" header:"-" variable:"imageid" json:"-"`
+	}{
+		ImageID: imageid,
+	}
+
+	result, err := api.InvokeStream(http.MethodPut, "./v2/images/{imageid}/file", true, StatusCodeIn(204), input, data, -1, "application/octet-stream", opts.Progress, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result == nil || result.Code != 204 {
+		return false, result, err
+	}
+
+	if checksums != nil {
+		image, _, err := api.RetrieveImage(imageid)
+		if image == nil {
+			log.Errorf("error retrieving image %q for verification: %v", imageid, err)
+			return true, result, fmt.Errorf("cannot verify image %q: %v", imageid, err)
+		}
+		if err := checksums.verify(image); err != nil {
+			return false, result, err
+		}
+	}
+	return true, result, err
+}
+
+/*
+ * DOWNLOAD IMAGE DATA
+ */
+
+// DownloadImageDataOptions provides the options available for downloading the
+// data of an image: Resume, if not nil, is the data already downloaded by an
+// earlier, interrupted attempt, so that only the rest is requested (via a Range
+// request) and written out; unless verification is disabled, Resume is read to
+// compute the checksum of the whole data; Progress, if not nil, is called as
+// the data is received; SkipVerification disables the verification of the data
+// against the checksum in the image metadata.
+type DownloadImageDataOptions struct {
+	Resume           io.Reader
+	Progress         ProgressFunc
+	SkipVerification bool
+}
+
+// DownloadImageData downloads the data of the image identified by the given id,
+// streaming it into the given writer (e.g. an open file) so that images of any
+// size can be downloaded without being buffered in memory; unless
+// opts.SkipVerification is set, the checksum of the data is computed as it is
+// written out and compared with the one in the image metadata, and an
+// *ImageIntegrityError is returned if they do not match, in which case the data
+// must not be used; opts can be nil; see also
+// https://developer.openstack.org/api-ref/image/v2/#download-binary-image-data
+func (api *ImageV2API) DownloadImageData(imageid string, data io.Writer, opts *DownloadImageDataOptions) (bool, *Result, error) {
+	if opts == nil {
+		opts = &DownloadImageDataOptions{}
+	}
+
+	var image *Image
+	var checksums imageChecksums
+	if !opts.SkipVerification {
+		var result *Result
+		var err error
+		if image, result, err = api.RetrieveImage(imageid); image == nil {
+			log.Errorf("error retrieving image %q for verification: %v", imageid, err)
+			return false, result, err
+		}
+		if image.HashAlgorithm != nil && imageHashAlgorithms[*image.HashAlgorithm] != nil {
+			checksums = newImageChecksums(*image.HashAlgorithm)
+		} else {
+			checksums = newImageChecksums("md5")
+		}
+		// the checksum is computed over all the data, as it is written out
+		data = io.MultiWriter(data, checksums.writer())
+	}
+
+	var offset int64
+	if opts.Resume != nil {
+		var err error
+		var seen io.Writer = ioutil.Discard
+		if checksums != nil {
+			seen = checksums.writer()
+		}
+		if offset, err = io.Copy(seen, opts.Resume); err != nil {
+			log.Errorf("error reading data already downloaded: %v", err)
+			return false, nil, err
+		}
+	}
+
+	input := &struct {
+		ImageID string  `parameter:"-" header:"-" variable:"imageid" json:"-"`
+		Range   *string `parameter:"-" header:"Range,omitempty" json:"-"`
+	}{
+		ImageID: imageid,
+	}
+	// a server ignoring the Range header would send all the data again, so only
+	// a partial response is acceptable when resuming
+	checker := StatusCodeIn(200)
+	if offset > 0 {
+		input.Range = String(fmt.Sprintf("bytes=%d-", offset))
+		checker = StatusCodeIn(206)
+	}
+
+	progress := opts.Progress
+	if progress != nil && offset > 0 {
+		progress = func(transferred int64) { opts.Progress(offset + transferred) }
+	}
+
+	result, err := api.InvokeDownload(http.MethodGet, "./v2/images/{imageid}/file", true, checker, input, data, progress, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result == nil || !result.OK || err != nil {
+		return false, result, err
+	}
+
+	if checksums != nil {
+		if err := checksums.verify(image); err != nil {
+			return false, result, err
+		}
+	}
+	return true, result, err
+}
@@ -0,0 +1,120 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// ImageImportGlanceDirect is the import method for image data previously
+	// staged with StageImageData.
+	ImageImportGlanceDirect = "glance-direct"
+	// ImageImportWebDownload is the import method for image data that Glance
+	// downloads from a URI.
+	ImageImportWebDownload = "web-download"
+	// ImageImportCopyImage is the import method for copying the data of an active
+	// image into further stores.
+	ImageImportCopyImage = "copy-image"
+)
+
+/*
+ * RETRIEVE IMPORT METHODS
+ */
+
+// RetrieveImportMethods returns the list of import methods enabled on this cloud
+// (e.g. ImageImportWebDownload); see also
+// https://developer.openstack.org/api-ref/image/v2/#import-methods-and-values-discovery
+func (api *ImageV2API) RetrieveImportMethods() (*[]string, *Result, error) {
+	output := &struct {
+		ImportMethods *struct {
+			Description *string   `json:"description,omitempty"`
+			Type        *string   `json:"type,omitempty"`
+			Value       *[]string `json:"value,omitempty"`
+		} `header:"-" json:"import-methods,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/info/import", true, StatusCodeIn(200), nil, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 && output.ImportMethods != nil {
+		return output.ImportMethods.Value, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * STAGE IMAGE DATA
+ */
+
+// StageImageData uploads the data of the image identified by the given id into
+// the staging area, from which it is then imported with ImportImage and the
+// ImageImportGlanceDirect method; the data is streamed from the given reader,
+// like in UploadImageData, but it is not verified, since the checksums are only
+// computed by the import; opts can be nil; see also
+// https://developer.openstack.org/api-ref/image/v2/#stage-binary-image-data
+func (api *ImageV2API) StageImageData(imageid string, data io.Reader, opts *UploadImageDataOptions) (bool, *Result, error) {
+	if opts == nil {
+		opts = &UploadImageDataOptions{}
+	}
+	input := &struct {
+		ImageID string `parameter:"-" header:"-" variable:"imageid" json:"-"`
+	}{
+		ImageID: imageid,
+	}
+
+	result, err := api.InvokeStream(http.MethodPut, "./v2/images/{imageid}/stage", true, StatusCodeIn(204), input, data, -1, "application/octet-stream", opts.Progress, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * IMPORT IMAGE
+ */
+
+// ImportImageOptions provides the options available for importing the data of
+// an image: Method is one of the ImageImport* methods, URI is the location of
+// the data for ImageImportWebDownload; Stores lists the stores where the data
+// should go, unless AllStores is set; AllStoresMustSucceed makes the import
+// fail if any of the stores fails.
+type ImportImageOptions struct {
+	Method               string    `parameter:"-" header:"-" json:"-"`
+	URI                  *string   `parameter:"-" header:"-" json:"-"`
+	Stores               *[]string `parameter:"-" header:"-" json:"stores,omitempty"`
+	AllStores            *bool     `parameter:"-" header:"-" json:"all_stores,omitempty"`
+	AllStoresMustSucceed *bool     `parameter:"-" header:"-" json:"all_stores_must_succeed,omitempty"`
+}
+
+// ImportImage starts the asynchronous import of the data of the image identified
+// by the given id, according to the given method; the image status goes to
+// ImageStatusImporting and then ImageStatusActive when done; see also
+// https://developer.openstack.org/api-ref/image/v2/#import-an-image
+func (api *ImageV2API) ImportImage(imageid string, opts *ImportImageOptions) (bool, *Result, error) {
+	input := &struct {
+		ImageID string `parameter:"-" header:"-" variable:"imageid" json:"-"`
+		Method  struct {
+			Name string  `json:"name"`
+			URI  *string `json:"uri,omitempty"`
+		} `parameter:"-" header:"-" json:"method"`
+		*ImportImageOptions
+	}{
+		ImageID:            imageid,
+		ImportImageOptions: opts,
+	}
+	input.Method.Name = opts.Method
+	input.Method.URI = opts.URI
+
+	result, err := api.Invoke(http.MethodPost, "./v2/images/{imageid}/import", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,104 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/dihedron/go-log"
+)
+
+// ImageIntegrityError is returned when the data of an image, as uploaded or
+// downloaded, does not match the checksum in the image metadata (i.e. the
+// os_hash_value multihash or the legacy MD5 checksum); the data may have been
+// corrupted or tampered with in transit or at rest.
+type ImageIntegrityError struct {
+	ImageID   string
+	Algorithm string
+	Expected  string
+	Actual    string
+}
+
+// Error returns a description of the integrity error.
+func (e *ImageIntegrityError) Error() string {
+	return fmt.Sprintf("integrity check failed for image %q: expected %s %q, got %q", e.ImageID, e.Algorithm, e.Expected, e.Actual)
+}
+
+// imageHashAlgorithms are the supported algorithms of the image checksums, by
+// their Glance name (as in os_hash_algo).
+var imageHashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// imageChecksums are the checksums of the data of an image, computed with
+// different algorithms as the data is transferred.
+type imageChecksums map[string]hash.Hash
+
+// newImageChecksums returns the checksums with the given (supported)
+// algorithms.
+func newImageChecksums(algorithms ...string) imageChecksums {
+	checksums := imageChecksums{}
+	for _, algorithm := range algorithms {
+		checksums[algorithm] = imageHashAlgorithms[algorithm]()
+	}
+	return checksums
+}
+
+// writer returns the writer updating all the checksums.
+func (c imageChecksums) writer() io.Writer {
+	writers := []io.Writer{}
+	for _, checksum := range c {
+		writers = append(writers, checksum)
+	}
+	return io.MultiWriter(writers...)
+}
+
+// verify compares the checksums with those in the metadata of the given image:
+// the multihash is preferred, the legacy MD5 checksum is used if the former is
+// not available or has not been computed; if neither can be verified, only a
+// warning is logged.
+func (c imageChecksums) verify(image *Image) error {
+	id := ""
+	if image.ID != nil {
+		id = *image.ID
+	}
+	if image.HashAlgorithm != nil && image.HashValue != nil {
+		if checksum, ok := c[*image.HashAlgorithm]; ok {
+			return c.compare(id, *image.HashAlgorithm, *image.HashValue, checksum)
+		}
+	}
+	if image.Checksum != nil {
+		if checksum, ok := c["md5"]; ok {
+			return c.compare(id, "md5", *image.Checksum, checksum)
+		}
+	}
+	log.Warnf("no usable checksum in the metadata of image %q, data not verified", id)
+	return nil
+}
+
+// compare compares the given checksum with the expected value.
+func (c imageChecksums) compare(id string, algorithm string, expected string, checksum hash.Hash) error {
+	actual := hex.EncodeToString(checksum.Sum(nil))
+	if actual != expected {
+		log.Errorf("%s checksum mismatch for image %q: expected %q, got %q", algorithm, id, expected, actual)
+		return &ImageIntegrityError{
+			ImageID:   id,
+			Algorithm: algorithm,
+			Expected:  expected,
+			Actual:    actual,
+		}
+	}
+	log.Debugf("%s checksum of image %q verified", algorithm, id)
+	return nil
+}
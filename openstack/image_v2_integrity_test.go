@@ -0,0 +1,65 @@
+package openstack
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImageDataIntegrity(t *testing.T) {
+	content := strings.Repeat("image data", 1000)
+	sum := sha512.Sum512([]byte(content))
+	hash := hex.EncodeToString(sum[:])
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut:
+			data, _ := ioutil.ReadAll(r.Body)
+			if string(data) != content {
+				t.Errorf("Image.TestImageDataIntegrity: unexpected upload of %d bytes", len(data))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/v2/images/good" || r.URL.Path == "/v2/images/bad":
+			value := hash
+			if strings.HasSuffix(r.URL.Path, "bad") {
+				value = strings.Repeat("0", len(hash))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "` + r.URL.Path[len("/v2/images/"):] + `", "os_hash_algo": "sha512", "os_hash_value": "` + value + `"}`))
+		default:
+			http.ServeContent(w, r, "file", time.Time{}, strings.NewReader(content))
+		}
+	}))
+	defer server.Close()
+
+	client := NewDefaultClient(server.URL)
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+	api := &ImageV2API{client.Authenticator.Identity.API}
+
+	if ok, _, err := api.UploadImageData("good", strings.NewReader(content), nil); !ok || err != nil {
+		t.Errorf("Image.TestImageDataIntegrity: upload failed verification: %v", err)
+	}
+
+	buffer := &bytes.Buffer{}
+	ok, _, err := api.DownloadImageData("good", buffer, &DownloadImageDataOptions{Resume: strings.NewReader(content[:100])})
+	if !ok || err != nil {
+		t.Fatalf("Image.TestImageDataIntegrity: resumed download failed: %v", err)
+	}
+	if buffer.String() != content[100:] {
+		t.Errorf("Image.TestImageDataIntegrity: resumed download returned %d bytes, expected %d", buffer.Len(), len(content)-100)
+	}
+
+	ok, _, err = api.DownloadImageData("bad", ioutil.Discard, nil)
+	if integrity, isIntegrity := err.(*ImageIntegrityError); ok || !isIntegrity || integrity.Algorithm != "sha512" {
+		t.Errorf("Image.TestImageDataIntegrity: expected sha512 integrity error, got %v", err)
+	}
+
+	if ok, _, err := api.DownloadImageData("bad", ioutil.Discard, &DownloadImageDataOptions{SkipVerification: true}); !ok || err != nil {
+		t.Errorf("Image.TestImageDataIntegrity: download without verification failed: %v", err)
+	}
+}
@@ -0,0 +1,471 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/dihedron/go-log"
+)
+
+// metadefRequest is the request entity of the metadata definitions calls, which
+// address a namespace or one of its objects, properties or tags, by name.
+type metadefRequest struct {
+	Namespace string `parameter:"-" header:"-" variable:"namespace" json:"-"`
+	Item      string `parameter:"-" header:"-" variable:"item" json:"-"`
+}
+
+// newMetadefRequest returns the request entity addressing the given namespace
+// and, if not empty, the given item in it.
+func newMetadefRequest(namespace string, item string) metadefRequest {
+	return metadefRequest{
+		Namespace: url.PathEscape(namespace),
+		Item:      url.PathEscape(item),
+	}
+}
+
+/*
+ * LIST NAMESPACES
+ */
+
+// ListMetadefNamespacesOptions provides the options available for filtering the
+// list of metadata definitions namespaces: ResourceTypes restricts it to the
+// namespaces associated with any of the given resource types (e.g.
+// "OS::Glance::Image"); Limit sets the page size, all pages are retrieved
+// anyway.
+type ListMetadefNamespacesOptions struct {
+	ResourceTypes *CommaSeparatedList `parameter:"resource_types,omitempty" header:"-" json:"-"`
+	Visibility    *string             `parameter:"visibility,omitempty" header:"-" json:"-"`
+	SortKey       *string             `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir       *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit         *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker        *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListMetadefNamespaces returns the list of metadata definitions namespaces; see
+// also https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#list-namespaces
+func (api *ImageV2API) ListMetadefNamespaces(opts *ListMetadefNamespacesOptions) (*[]MetadefNamespace, *Result, error) {
+	namespaces := []MetadefNamespace{}
+	result, err := api.listResources("./v2/metadefs/namespaces", "namespaces", opts, imageNextLink,
+		func() interface{} { return &[]MetadefNamespace{} },
+		func(page interface{}) { namespaces = append(namespaces, *page.(*[]MetadefNamespace)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &namespaces, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE NAMESPACE
+ */
+
+// CreateMetadefNamespaceOptions provides the options available for creating a
+// metadata definitions namespace; its objects, properties and tags can be
+// created along with it.
+type CreateMetadefNamespaceOptions struct {
+	Namespace                string                            `parameter:"-" header:"-" json:"namespace"`
+	DisplayName              *string                           `parameter:"-" header:"-" json:"display_name,omitempty"`
+	Description              *string                           `parameter:"-" header:"-" json:"description,omitempty"`
+	Visibility               *string                           `parameter:"-" header:"-" json:"visibility,omitempty"`
+	Protected                *bool                             `parameter:"-" header:"-" json:"protected,omitempty"`
+	ResourceTypeAssociations *[]MetadefResourceTypeAssociation `parameter:"-" header:"-" json:"resource_type_associations,omitempty"`
+	Properties               *map[string]MetadefProperty       `parameter:"-" header:"-" json:"properties,omitempty"`
+	Objects                  *[]MetadefObject                  `parameter:"-" header:"-" json:"objects,omitempty"`
+	Tags                     *[]MetadefTag                     `parameter:"-" header:"-" json:"tags,omitempty"`
+}
+
+// CreateMetadefNamespace creates a new metadata definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#create-namespace
+func (api *ImageV2API) CreateMetadefNamespace(opts *CreateMetadefNamespaceOptions) (*MetadefNamespace, *Result, error) {
+	output := &MetadefNamespace{}
+
+	result, err := api.Invoke(http.MethodPost, "./v2/metadefs/namespaces", true, StatusCodeIn(201), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE NAMESPACE
+ */
+
+// RetrieveMetadefNamespace retrieves the metadata definitions namespace with the
+// given name, including its objects, properties and tags; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#get-namespace-details
+func (api *ImageV2API) RetrieveMetadefNamespace(namespace string) (*MetadefNamespace, *Result, error) {
+	input := newMetadefRequest(namespace, "")
+	output := &MetadefNamespace{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/metadefs/namespaces/{namespace}", true, StatusCodeIn(200), &input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE NAMESPACE
+ */
+
+// UpdateMetadefNamespaceOptions provides the options available for updating a
+// metadata definitions namespace; Namespace is its (possibly new) name.
+type UpdateMetadefNamespaceOptions struct {
+	Namespace   string  `parameter:"-" header:"-" json:"namespace"`
+	DisplayName *string `parameter:"-" header:"-" json:"display_name,omitempty"`
+	Description *string `parameter:"-" header:"-" json:"description,omitempty"`
+	Visibility  *string `parameter:"-" header:"-" json:"visibility,omitempty"`
+	Protected   *bool   `parameter:"-" header:"-" json:"protected,omitempty"`
+}
+
+// UpdateMetadefNamespace updates the metadata definitions namespace with the
+// given name; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#update-namespace
+func (api *ImageV2API) UpdateMetadefNamespace(namespace string, opts *UpdateMetadefNamespaceOptions) (*MetadefNamespace, *Result, error) {
+	input := &struct {
+		Request metadefRequest `json:"-"`
+		*UpdateMetadefNamespaceOptions
+	}{
+		Request:                       newMetadefRequest(namespace, ""),
+		UpdateMetadefNamespaceOptions: opts,
+	}
+	output := &MetadefNamespace{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2/metadefs/namespaces/{namespace}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE NAMESPACE
+ */
+
+// DeleteMetadefNamespace deletes the metadata definitions namespace with the
+// given name, along with all its objects, properties and tags; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#delete-namespace
+func (api *ImageV2API) DeleteMetadefNamespace(namespace string) (bool, *Result, error) {
+	return api.deleteMetadef("./v2/metadefs/namespaces/{namespace}", namespace, "")
+}
+
+/*
+ * OBJECTS
+ */
+
+// MetadefObjectOptions provides the options available for creating or updating
+// a metadata definitions object; Name is its (possibly new) name.
+type MetadefObjectOptions struct {
+	Name        string                      `parameter:"-" header:"-" json:"name"`
+	Description *string                     `parameter:"-" header:"-" json:"description,omitempty"`
+	Required    *[]string                   `parameter:"-" header:"-" json:"required,omitempty"`
+	Properties  *map[string]MetadefProperty `parameter:"-" header:"-" json:"properties,omitempty"`
+}
+
+// ListMetadefObjects returns the objects in the given metadata definitions
+// namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#list-objects
+func (api *ImageV2API) ListMetadefObjects(namespace string) (*[]MetadefObject, *Result, error) {
+	input := newMetadefRequest(namespace, "")
+	output := &struct {
+		Objects *[]MetadefObject `header:"-" json:"objects,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/metadefs/namespaces/{namespace}/objects", true, StatusCodeIn(200), &input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		if output.Objects == nil {
+			output.Objects = &[]MetadefObject{}
+		}
+		return output.Objects, result, err
+	}
+	return nil, result, err
+}
+
+// CreateMetadefObject creates a new object in the given metadata definitions
+// namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#create-object
+func (api *ImageV2API) CreateMetadefObject(namespace string, opts *MetadefObjectOptions) (*MetadefObject, *Result, error) {
+	input := &struct {
+		Request metadefRequest `json:"-"`
+		*MetadefObjectOptions
+	}{
+		Request:              newMetadefRequest(namespace, ""),
+		MetadefObjectOptions: opts,
+	}
+	output := &MetadefObject{}
+
+	result, err := api.Invoke(http.MethodPost, "./v2/metadefs/namespaces/{namespace}/objects", true, StatusCodeIn(201), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveMetadefObject retrieves the object with the given name in the given
+// metadata definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#show-object
+func (api *ImageV2API) RetrieveMetadefObject(namespace string, name string) (*MetadefObject, *Result, error) {
+	input := newMetadefRequest(namespace, name)
+	output := &MetadefObject{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/metadefs/namespaces/{namespace}/objects/{item}", true, StatusCodeIn(200), &input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// UpdateMetadefObject replaces the object with the given name in the given
+// metadata definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#update-object
+func (api *ImageV2API) UpdateMetadefObject(namespace string, name string, opts *MetadefObjectOptions) (*MetadefObject, *Result, error) {
+	input := &struct {
+		Request metadefRequest `json:"-"`
+		*MetadefObjectOptions
+	}{
+		Request:              newMetadefRequest(namespace, name),
+		MetadefObjectOptions: opts,
+	}
+	output := &MetadefObject{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2/metadefs/namespaces/{namespace}/objects/{item}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// DeleteMetadefObject deletes the object with the given name from the given
+// metadata definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#delete-object
+func (api *ImageV2API) DeleteMetadefObject(namespace string, name string) (bool, *Result, error) {
+	return api.deleteMetadef("./v2/metadefs/namespaces/{namespace}/objects/{item}", namespace, name)
+}
+
+/*
+ * PROPERTIES
+ */
+
+// ListMetadefProperties returns the property definitions in the given metadata
+// definitions namespace, by name; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#list-properties
+func (api *ImageV2API) ListMetadefProperties(namespace string) (*map[string]MetadefProperty, *Result, error) {
+	input := newMetadefRequest(namespace, "")
+	output := &struct {
+		Properties *map[string]MetadefProperty `header:"-" json:"properties,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/metadefs/namespaces/{namespace}/properties", true, StatusCodeIn(200), &input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		if output.Properties == nil {
+			output.Properties = &map[string]MetadefProperty{}
+		}
+		return output.Properties, result, err
+	}
+	return nil, result, err
+}
+
+// CreateMetadefProperty creates a new property definition in the given metadata
+// definitions namespace; the property Name, Title and Type are required; see
+// also https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#create-property
+func (api *ImageV2API) CreateMetadefProperty(namespace string, property *MetadefProperty) (*MetadefProperty, *Result, error) {
+	input := &struct {
+		Request metadefRequest `json:"-"`
+		*MetadefProperty
+	}{
+		Request:         newMetadefRequest(namespace, ""),
+		MetadefProperty: property,
+	}
+	output := &MetadefProperty{}
+
+	result, err := api.Invoke(http.MethodPost, "./v2/metadefs/namespaces/{namespace}/properties", true, StatusCodeIn(201), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveMetadefProperty retrieves the property definition with the given name
+// in the given metadata definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#show-property-definition
+func (api *ImageV2API) RetrieveMetadefProperty(namespace string, name string) (*MetadefProperty, *Result, error) {
+	input := newMetadefRequest(namespace, name)
+	output := &MetadefProperty{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/metadefs/namespaces/{namespace}/properties/{item}", true, StatusCodeIn(200), &input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// UpdateMetadefProperty replaces the property definition with the given name in
+// the given metadata definitions namespace; the property Name can be used to
+// rename it; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#update-property-definition
+func (api *ImageV2API) UpdateMetadefProperty(namespace string, name string, property *MetadefProperty) (*MetadefProperty, *Result, error) {
+	input := &struct {
+		Request metadefRequest `json:"-"`
+		*MetadefProperty
+	}{
+		Request:         newMetadefRequest(namespace, name),
+		MetadefProperty: property,
+	}
+	output := &MetadefProperty{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2/metadefs/namespaces/{namespace}/properties/{item}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// DeleteMetadefProperty deletes the property definition with the given name
+// from the given metadata definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#remove-property-definition
+func (api *ImageV2API) DeleteMetadefProperty(namespace string, name string) (bool, *Result, error) {
+	return api.deleteMetadef("./v2/metadefs/namespaces/{namespace}/properties/{item}", namespace, name)
+}
+
+/*
+ * TAGS
+ */
+
+// ListMetadefTags returns the tag definitions in the given metadata definitions
+// namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#list-tags
+func (api *ImageV2API) ListMetadefTags(namespace string) (*[]MetadefTag, *Result, error) {
+	input := newMetadefRequest(namespace, "")
+	output := &struct {
+		Tags *[]MetadefTag `header:"-" json:"tags,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/metadefs/namespaces/{namespace}/tags", true, StatusCodeIn(200), &input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		if output.Tags == nil {
+			output.Tags = &[]MetadefTag{}
+		}
+		return output.Tags, result, err
+	}
+	return nil, result, err
+}
+
+// CreateMetadefTag creates a new tag definition with the given name in the
+// given metadata definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#add-tag-definition
+func (api *ImageV2API) CreateMetadefTag(namespace string, name string) (*MetadefTag, *Result, error) {
+	input := newMetadefRequest(namespace, name)
+	output := &MetadefTag{}
+
+	result, err := api.Invoke(http.MethodPost, "./v2/metadefs/namespaces/{namespace}/tags/{item}", true, StatusCodeIn(201), &input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// CreateMetadefTags creates tag definitions with the given names in the given
+// metadata definitions namespace, replacing all the existing ones; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#create-tags
+func (api *ImageV2API) CreateMetadefTags(namespace string, names []string) (*[]MetadefTag, *Result, error) {
+	input := &struct {
+		Request metadefRequest `json:"-"`
+		Tags    []MetadefTag   `parameter:"-" header:"-" json:"tags"`
+	}{
+		Request: newMetadefRequest(namespace, ""),
+		Tags:    []MetadefTag{},
+	}
+	for _, name := range names {
+		input.Tags = append(input.Tags, MetadefTag{Name: String(name)})
+	}
+	output := &struct {
+		Tags *[]MetadefTag `header:"-" json:"tags,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPost, "./v2/metadefs/namespaces/{namespace}/tags", true, StatusCodeIn(201), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return output.Tags, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveMetadefTag retrieves the tag definition with the given name in the
+// given metadata definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#get-tag-definition
+func (api *ImageV2API) RetrieveMetadefTag(namespace string, name string) (*MetadefTag, *Result, error) {
+	input := newMetadefRequest(namespace, name)
+	output := &MetadefTag{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/metadefs/namespaces/{namespace}/tags/{item}", true, StatusCodeIn(200), &input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// RenameMetadefTag renames the tag definition with the given name in the given
+// metadata definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#update-tag-definition
+func (api *ImageV2API) RenameMetadefTag(namespace string, name string, newname string) (*MetadefTag, *Result, error) {
+	input := &struct {
+		Request metadefRequest `json:"-"`
+		Name    string         `parameter:"-" header:"-" json:"name"`
+	}{
+		Request: newMetadefRequest(namespace, name),
+		Name:    newname,
+	}
+	output := &MetadefTag{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2/metadefs/namespaces/{namespace}/tags/{item}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// DeleteMetadefTag deletes the tag definition with the given name from the given
+// metadata definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#delete-tag-definition
+func (api *ImageV2API) DeleteMetadefTag(namespace string, name string) (bool, *Result, error) {
+	return api.deleteMetadef("./v2/metadefs/namespaces/{namespace}/tags/{item}", namespace, name)
+}
+
+// DeleteMetadefTags deletes all the tag definitions from the given metadata
+// definitions namespace; see also
+// https://developer.openstack.org/api-ref/image/v2/metadefs-index.html#delete-all-tag-definitions
+func (api *ImageV2API) DeleteMetadefTags(namespace string) (bool, *Result, error) {
+	return api.deleteMetadef("./v2/metadefs/namespaces/{namespace}/tags", namespace, "")
+}
+
+// deleteMetadef deletes the metadata definition at the given path, addressing
+// the given namespace and item.
+func (api *ImageV2API) deleteMetadef(path string, namespace string, item string) (bool, *Result, error) {
+	input := newMetadefRequest(namespace, item)
+
+	result, err := api.Invoke(http.MethodDelete, path, true, StatusCodeIn(204), &input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,61 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/dihedron/go-log"
+)
+
+// imageTagRequest is the request entity of the image tag calls, which address
+// one of the tags of the image with the given id.
+type imageTagRequest struct {
+	ImageID string `parameter:"-" header:"-" variable:"imageid" json:"-"`
+	Tag     string `parameter:"-" header:"-" variable:"tag" json:"-"`
+}
+
+/*
+ * ADD IMAGE TAG
+ */
+
+// AddImageTag adds the given tag to the image identified by the given id; adding
+// a tag the image already has is not an error; see also
+// https://developer.openstack.org/api-ref/image/v2/#add-image-tag
+func (api *ImageV2API) AddImageTag(imageid string, tag string) (bool, *Result, error) {
+	input := &imageTagRequest{
+		ImageID: imageid,
+		Tag:     url.PathEscape(tag),
+	}
+
+	result, err := api.Invoke(http.MethodPut, "./v2/images/{imageid}/tags/{tag}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * DELETE IMAGE TAG
+ */
+
+// DeleteImageTag removes the given tag from the image identified by the given
+// id; see also
+// https://developer.openstack.org/api-ref/image/v2/#delete-image-tag
+func (api *ImageV2API) DeleteImageTag(imageid string, tag string) (bool, *Result, error) {
+	input := &imageTagRequest{
+		ImageID: imageid,
+		Tag:     url.PathEscape(tag),
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v2/images/{imageid}/tags/{tag}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,31 @@
+package openstack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListImagesPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.String() {
+		case "/image/v2/images?limit=1":
+			// the link is relative to the endpoint, not to its host
+			w.Write([]byte(`{"images": [{"id": "i1"}], "next": "/v2/images?limit=1&marker=i1"}`))
+		case "/image/v2/images?limit=1&marker=i1":
+			w.Write([]byte(`{"images": [{"id": "i2"}]}`))
+		default:
+			t.Errorf("Image.TestListImagesPagination: unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := NewDefaultClient(server.URL + "/image/")
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+
+	images, result, err := (&ImageV2API{client.Authenticator.Identity.API}).ListImages(&ListImagesOptions{Limit: Int(1)})
+	if err != nil || images == nil || len(*images) != 2 || stringValue((*images)[1].ID) != "i2" {
+		t.Errorf("Image.TestListImagesPagination: expected 2 images across 2 pages, got %v (%v)", result, err)
+	}
+}
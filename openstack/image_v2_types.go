@@ -0,0 +1,198 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+/*
+ * IMAGES
+ */
+
+// Image is a virtual machine image; besides the properties defined by the
+// Glance image schema, images can have any number of additional properties
+// (e.g. "hw_disk_bus", "os_distro"), which are collected in Properties, as
+// allowed by the "additionalProperties" clause of the schema.
+type Image struct {
+	ID              *string                   `json:"id,omitempty"`
+	Name            *string                   `json:"name,omitempty"`
+	Status          *ImageStatus              `json:"status,omitempty"`
+	Visibility      *ImageVisibility          `json:"visibility,omitempty"`
+	Protected       *bool                     `json:"protected,omitempty"`
+	Hidden          *bool                     `json:"os_hidden,omitempty"`
+	Owner           *string                   `json:"owner,omitempty"`
+	Size            *int64                    `json:"size,omitempty"`
+	VirtualSize     *int64                    `json:"virtual_size,omitempty"`
+	MinRAM          *int                      `json:"min_ram,omitempty"`
+	MinDisk         *int                      `json:"min_disk,omitempty"`
+	DiskFormat      *string                   `json:"disk_format,omitempty"`
+	ContainerFormat *string                   `json:"container_format,omitempty"`
+	Checksum        *string                   `json:"checksum,omitempty"`
+	HashAlgorithm   *string                   `json:"os_hash_algo,omitempty"`
+	HashValue       *string                   `json:"os_hash_value,omitempty"`
+	Tags            *[]string                 `json:"tags,omitempty"`
+	Stores          *string                   `json:"stores,omitempty"`
+	DirectURL       *string                   `json:"direct_url,omitempty"`
+	Locations       *[]map[string]interface{} `json:"locations,omitempty"`
+	CreatedAt       *string                   `json:"created_at,omitempty"`
+	UpdatedAt       *string                   `json:"updated_at,omitempty"`
+	File            *string                   `json:"file,omitempty"`
+	Self            *string                   `json:"self,omitempty"`
+	Schema          *string                   `json:"schema,omitempty"`
+	Properties      map[string]interface{}    `json:"-"`
+}
+
+// image has the same fields as Image, but none of its methods, so that it can
+// be marshalled and unmarshalled with the default encoding.
+type image Image
+
+// imageFields is the set of the JSON names of the fields of Image.
+var imageFields = jsonFieldNames(reflect.TypeOf(Image{}))
+
+// UnmarshalJSON decodes the image, collecting the properties that do not match
+// any field of Image into Properties.
+func (i *Image) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*image)(i)); err != nil {
+		return err
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	i.Properties = nil
+	for key, value := range values {
+		if !imageFields[key] {
+			if i.Properties == nil {
+				i.Properties = map[string]interface{}{}
+			}
+			i.Properties[key] = value
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes the image, with the additional properties at the same
+// level as the other fields.
+func (i Image) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(image(i))
+	if err != nil || len(i.Properties) == 0 {
+		return data, err
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	for key, value := range i.Properties {
+		if _, ok := values[key]; !ok {
+			values[key] = value
+		}
+	}
+	return json.Marshal(values)
+}
+
+// jsonFieldNames returns the set of the JSON names of the fields of the given
+// struct type.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+/*
+ * METADATA DEFINITIONS
+ */
+
+// MetadefNamespace is a container of metadata definitions (objects, properties
+// and tags) in the metadata definitions catalog, e.g. the hardware traits or
+// the hypervisor-specific properties that can be set on images, flavors and
+// volumes; the resource type associations tell which resources the definitions
+// apply to.
+type MetadefNamespace struct {
+	Namespace                *string                           `json:"namespace,omitempty"`
+	DisplayName              *string                           `json:"display_name,omitempty"`
+	Description              *string                           `json:"description,omitempty"`
+	Visibility               *string                           `json:"visibility,omitempty"`
+	Protected                *bool                             `json:"protected,omitempty"`
+	Owner                    *string                           `json:"owner,omitempty"`
+	ResourceTypeAssociations *[]MetadefResourceTypeAssociation `json:"resource_type_associations,omitempty"`
+	Properties               *map[string]MetadefProperty       `json:"properties,omitempty"`
+	Objects                  *[]MetadefObject                  `json:"objects,omitempty"`
+	Tags                     *[]MetadefTag                     `json:"tags,omitempty"`
+	CreatedAt                *string                           `json:"created_at,omitempty"`
+	UpdatedAt                *string                           `json:"updated_at,omitempty"`
+	Self                     *string                           `json:"self,omitempty"`
+	Schema                   *string                           `json:"schema,omitempty"`
+}
+
+// MetadefResourceTypeAssociation associates a namespace with a resource type
+// (e.g. "OS::Glance::Image", "OS::Nova::Flavor"); Prefix is prepended to the
+// names of the properties when they are applied to the resource, and
+// PropertiesTarget tells which of its attributes they go into (e.g. "image").
+type MetadefResourceTypeAssociation struct {
+	Name             *string `json:"name,omitempty"`
+	Prefix           *string `json:"prefix,omitempty"`
+	PropertiesTarget *string `json:"properties_target,omitempty"`
+	CreatedAt        *string `json:"created_at,omitempty"`
+	UpdatedAt        *string `json:"updated_at,omitempty"`
+}
+
+// MetadefProperty is the definition of a property, as a JSON schema fragment:
+// Type is one of "string", "integer", "number", "boolean" or "array"; Enum,
+// Minimum, Maximum etc. constrain its values, Items the values of arrays.
+type MetadefProperty struct {
+	Name            *string               `json:"name,omitempty"`
+	Title           *string               `json:"title,omitempty"`
+	Description     *string               `json:"description,omitempty"`
+	Type            *string               `json:"type,omitempty"`
+	Default         interface{}           `json:"default,omitempty"`
+	Enum            *[]interface{}        `json:"enum,omitempty"`
+	Operators       *[]string             `json:"operators,omitempty"`
+	Minimum         *float64              `json:"minimum,omitempty"`
+	Maximum         *float64              `json:"maximum,omitempty"`
+	MinLength       *int                  `json:"minLength,omitempty"`
+	MaxLength       *int                  `json:"maxLength,omitempty"`
+	Pattern         *string               `json:"pattern,omitempty"`
+	Items           *MetadefPropertyItems `json:"items,omitempty"`
+	MinItems        *int                  `json:"minItems,omitempty"`
+	MaxItems        *int                  `json:"maxItems,omitempty"`
+	UniqueItems     *bool                 `json:"uniqueItems,omitempty"`
+	AdditionalItems *bool                 `json:"additionalItems,omitempty"`
+	ReadOnly        *bool                 `json:"readonly,omitempty"`
+}
+
+// MetadefPropertyItems is the definition of the items of an array property.
+type MetadefPropertyItems struct {
+	Type *string        `json:"type,omitempty"`
+	Enum *[]interface{} `json:"enum,omitempty"`
+}
+
+// MetadefObject is a group of related property definitions, e.g. the CPU
+// pinning properties; Required lists the properties that must be given
+// together.
+type MetadefObject struct {
+	Name        *string                     `json:"name,omitempty"`
+	Description *string                     `json:"description,omitempty"`
+	Required    *[]string                   `json:"required,omitempty"`
+	Properties  *map[string]MetadefProperty `json:"properties,omitempty"`
+	CreatedAt   *string                     `json:"created_at,omitempty"`
+	UpdatedAt   *string                     `json:"updated_at,omitempty"`
+	Self        *string                     `json:"self,omitempty"`
+	Schema      *string                     `json:"schema,omitempty"`
+}
+
+// MetadefTag is a tag definition, i.e. a tag that can be applied to resources.
+type MetadefTag struct {
+	Name      *string `json:"name,omitempty"`
+	CreatedAt *string `json:"created_at,omitempty"`
+	UpdatedAt *string `json:"updated_at,omitempty"`
+}
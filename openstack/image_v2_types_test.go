@@ -0,0 +1,27 @@
+package openstack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestImageProperties(t *testing.T) {
+	data := []byte(`{"id": "i1", "name": "cirros", "size": 12345, "status": "active", "visibility": "public", "hw_disk_bus": "scsi", "os_distro": "cirros"}`)
+	image := Image{}
+	if err := json.Unmarshal(data, &image); err != nil {
+		t.Fatalf("Image.TestImageProperties: unexpected error: %v", err)
+	}
+	if *image.ID != "i1" || *image.Size != 12345 || *image.Status != ImageStatusActive || *image.Visibility != ImageVisibilityPublic || len(image.Properties) != 2 || image.Properties["hw_disk_bus"] != "scsi" {
+		t.Fatalf("Image.TestImageProperties: invalid image %+v", image)
+	}
+
+	data, err := json.Marshal(image)
+	if err != nil {
+		t.Fatalf("Image.TestImageProperties: unexpected error: %v", err)
+	}
+	values := map[string]interface{}{}
+	json.Unmarshal(data, &values)
+	if values["os_distro"] != "cirros" || values["name"] != "cirros" || values["visibility"] != "public" || values["Properties"] != nil {
+		t.Fatalf("Image.TestImageProperties: invalid encoding %s", data)
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"strings"
+)
+
+// KeyManagerV1API represents the key manager API ver. 1 (Barbican), providing
+// support for the secure storage of secrets (keys, passphrases, certificates),
+// of containers grouping them, of orders for the generation of new keys and
+// of the registration of their consumers.
+// Barbican identifies its resources by reference, i.e. by their full URL (e.g.
+// https://barbican.example.com:9311/v1/secrets/<uuid>): all methods accept
+// either the reference or the bare UUID.
+// See https://docs.openstack.org/barbican/latest/api/
+type KeyManagerV1API struct {
+	API
+}
+
+// keyManagerID returns the UUID of the resource with the given reference, or
+// the reference itself if it is already a bare UUID.
+func keyManagerID(ref string) string {
+	ref = strings.TrimSuffix(ref, "/")
+	if i := strings.LastIndex(ref, "/"); i >= 0 {
+		return ref[i+1:]
+	}
+	return ref
+}
@@ -0,0 +1,271 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Container types: generic containers hold any secrets under any name, while
+// certificate and RSA containers hold the ones listed below.
+const (
+	ContainerTypeGeneric     string = "generic"
+	ContainerTypeCertificate string = "certificate"
+	ContainerTypeRSA         string = "rsa"
+)
+
+// Names of the secrets in certificate ("certificate", "private_key",
+// "private_key_passphrase", "intermediates") and RSA ("public_key",
+// "private_key", "private_key_passphrase") containers.
+const (
+	ContainerSecretCertificate          string = "certificate"
+	ContainerSecretIntermediates        string = "intermediates"
+	ContainerSecretPublicKey            string = "public_key"
+	ContainerSecretPrivateKey           string = "private_key"
+	ContainerSecretPrivateKeyPassphrase string = "private_key_passphrase"
+)
+
+/*
+ * LIST CONTAINERS
+ */
+
+// ListSecretContainersOptions provides the options available for filtering the
+// list of containers; Limit sets the page size, all pages are retrieved anyway.
+type ListSecretContainersOptions struct {
+	Name   *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Type   *string `parameter:"type,omitempty" header:"-" json:"-"`
+	Limit  *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Offset *int    `parameter:"offset,omitempty" header:"-" json:"-"`
+}
+
+// ListSecretContainers returns the containers of the current project; opts
+// can be nil; see also
+// https://docs.openstack.org/barbican/latest/api/reference/containers.html#get-v1-containers
+func (api *KeyManagerV1API) ListSecretContainers(opts *ListSecretContainersOptions) (*[]SecretContainer, *Result, error) {
+	if opts == nil {
+		opts = &ListSecretContainersOptions{}
+	}
+
+	containers := []SecretContainer{}
+	result, err := api.listResources("./v1/containers", "containers", opts, nextField,
+		func() interface{} { return &[]SecretContainer{} },
+		func(page interface{}) {
+			containers = append(containers, *page.(*[]SecretContainer)...)
+		})
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &containers, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE CONTAINER
+ */
+
+// CreateSecretContainerOptions provides the options available for creating a
+// container: Type is one of the ContainerType* constants, SecretRefs the
+// secrets it holds, by name; containers cannot be updated afterwards, except
+// for adding and removing secrets to and from generic containers.
+type CreateSecretContainerOptions struct {
+	Name       *string      `json:"name,omitempty"`
+	Type       string       `json:"type"`
+	SecretRefs *[]SecretRef `json:"secret_refs,omitempty"`
+}
+
+// CreateSecretContainer creates a container and returns its reference; see also
+// https://docs.openstack.org/barbican/latest/api/reference/containers.html#post-v1-containers
+func (api *KeyManagerV1API) CreateSecretContainer(opts *CreateSecretContainerOptions) (*string, *Result, error) {
+	output := &struct {
+		ContainerRef *string `json:"container_ref,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPost, "./v1/containers", true, StatusCodeIn(201), opts, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return output.ContainerRef, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE CONTAINER
+ */
+
+// RetrieveSecretContainer retrieves the container with the given reference or
+// UUID, with the references to its secrets and its consumers; see also
+// https://docs.openstack.org/barbican/latest/api/reference/containers.html#get-v1-containers-uuid
+func (api *KeyManagerV1API) RetrieveSecretContainer(container string) (*SecretContainer, *Result, error) {
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+	}{
+		ID: keyManagerID(container),
+	}
+	output := &SecretContainer{}
+
+	result, err := api.Invoke(http.MethodGet, "./v1/containers/{id}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE CONTAINER
+ */
+
+// DeleteSecretContainer deletes the container with the given reference or
+// UUID; the secrets it references are not deleted; see also
+// https://docs.openstack.org/barbican/latest/api/reference/containers.html#delete-v1-containers-uuid
+func (api *KeyManagerV1API) DeleteSecretContainer(container string) (bool, *Result, error) {
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+	}{
+		ID: keyManagerID(container),
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v1/containers/{id}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * CONTAINER SECRETS
+ */
+
+// containerSecretRequest is the request entity of the calls adding and removing
+// a secret to and from a generic container.
+type containerSecretRequest struct {
+	ID        string `parameter:"-" header:"-" variable:"id" json:"-"`
+	Name      string `parameter:"-" header:"-" json:"name,omitempty"`
+	SecretRef string `parameter:"-" header:"-" json:"secret_ref"`
+}
+
+// AddContainerSecret adds the secret with the given reference to the generic
+// container with the given reference or UUID, under the given name (which can
+// be empty); see also
+// https://docs.openstack.org/barbican/latest/api/reference/containers.html#post-v1-containers-container-uuid-secrets
+func (api *KeyManagerV1API) AddContainerSecret(container string, name string, secret string) (bool, *Result, error) {
+	input := &containerSecretRequest{
+		ID:        keyManagerID(container),
+		Name:      name,
+		SecretRef: secret,
+	}
+
+	result, err := api.Invoke(http.MethodPost, "./v1/containers/{id}/secrets", true, StatusCodeIn(201), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// RemoveContainerSecret removes the secret with the given reference and name
+// from the generic container with the given reference or UUID; the secret
+// itself is not deleted; see also
+// https://docs.openstack.org/barbican/latest/api/reference/containers.html#delete-v1-containers-container-uuid-secrets
+func (api *KeyManagerV1API) RemoveContainerSecret(container string, name string, secret string) (bool, *Result, error) {
+	input := &containerSecretRequest{
+		ID:        keyManagerID(container),
+		Name:      name,
+		SecretRef: secret,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v1/containers/{id}/secrets", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * CONSUMERS
+ */
+
+// ListContainerConsumersOptions provides the options available for listing the
+// consumers of a container; Limit sets the page size, all pages are retrieved
+// anyway.
+type ListContainerConsumersOptions struct {
+	Limit  *int `parameter:"limit,omitempty" header:"-" json:"-"`
+	Offset *int `parameter:"offset,omitempty" header:"-" json:"-"`
+}
+
+// ListContainerConsumers returns the consumers registered on the container with
+// the given reference or UUID; opts can be nil; see also
+// https://docs.openstack.org/barbican/latest/api/reference/consumers.html#get-v1-containers-containeruuid-consumers
+func (api *KeyManagerV1API) ListContainerConsumers(container string, opts *ListContainerConsumersOptions) (*[]Consumer, *Result, error) {
+	if opts == nil {
+		opts = &ListContainerConsumersOptions{}
+	}
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		ListContainerConsumersOptions
+	}{
+		ID:                            keyManagerID(container),
+		ListContainerConsumersOptions: *opts,
+	}
+
+	consumers := []Consumer{}
+	result, err := api.listResources("./v1/containers/{id}/consumers", "consumers", input, nextField,
+		func() interface{} { return &[]Consumer{} },
+		func(page interface{}) {
+			consumers = append(consumers, *page.(*[]Consumer)...)
+		})
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &consumers, result, err
+	}
+	return nil, result, err
+}
+
+// consumerRequest is the request entity of the calls registering and
+// unregistering a consumer.
+type consumerRequest struct {
+	ID   string `parameter:"-" header:"-" variable:"id" json:"-"`
+	Name string `parameter:"-" header:"-" json:"name"`
+	URL  string `parameter:"-" header:"-" json:"URL"`
+}
+
+// RegisterContainerConsumer registers the resource with the given URL, of the
+// service with the given name, as a consumer of the container with the given
+// reference or UUID, and returns the updated container; registering the same
+// consumer twice has no effect; see also
+// https://docs.openstack.org/barbican/latest/api/reference/consumers.html#post-v1-containers-containeruuid-consumers
+func (api *KeyManagerV1API) RegisterContainerConsumer(container string, name string, url string) (*SecretContainer, *Result, error) {
+	return api.updateContainerConsumers(http.MethodPost, container, name, url)
+}
+
+// UnregisterContainerConsumer unregisters the resource with the given URL, of
+// the service with the given name, as a consumer of the container with the
+// given reference or UUID, and returns the updated container; see also
+// https://docs.openstack.org/barbican/latest/api/reference/consumers.html#delete-v1-containers-containeruuid-consumers
+func (api *KeyManagerV1API) UnregisterContainerConsumer(container string, name string, url string) (*SecretContainer, *Result, error) {
+	return api.updateContainerConsumers(http.MethodDelete, container, name, url)
+}
+
+// updateContainerConsumers registers or unregisters a consumer, according to
+// the method.
+func (api *KeyManagerV1API) updateContainerConsumers(method string, container string, name string, url string) (*SecretContainer, *Result, error) {
+	input := &consumerRequest{
+		ID:   keyManagerID(container),
+		Name: name,
+		URL:  url,
+	}
+	output := &SecretContainer{}
+
+	result, err := api.Invoke(method, "./v1/containers/{id}/consumers", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
@@ -0,0 +1,170 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Order types: key orders generate a symmetric key stored as a secret,
+// asymmetric orders a key pair stored in an RSA container.
+const (
+	OrderTypeKey        string = "key"
+	OrderTypeAsymmetric string = "asymmetric"
+)
+
+// Order status values; orders are processed asynchronously.
+const (
+	OrderStatusPending string = "PENDING"
+	OrderStatusActive  string = "ACTIVE"
+	OrderStatusError   string = "ERROR"
+)
+
+/*
+ * LIST ORDERS
+ */
+
+// ListOrdersOptions provides the options available for listing the orders;
+// Limit sets the page size, all pages are retrieved anyway.
+type ListOrdersOptions struct {
+	Limit  *int `parameter:"limit,omitempty" header:"-" json:"-"`
+	Offset *int `parameter:"offset,omitempty" header:"-" json:"-"`
+}
+
+// ListOrders returns the orders of the current project; opts can be nil; see
+// also
+// https://docs.openstack.org/barbican/latest/api/reference/orders.html#get-v1-orders
+func (api *KeyManagerV1API) ListOrders(opts *ListOrdersOptions) (*[]Order, *Result, error) {
+	if opts == nil {
+		opts = &ListOrdersOptions{}
+	}
+
+	orders := []Order{}
+	result, err := api.listResources("./v1/orders", "orders", opts, nextField,
+		func() interface{} { return &[]Order{} },
+		func(page interface{}) {
+			orders = append(orders, *page.(*[]Order)...)
+		})
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &orders, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE ORDER
+ */
+
+// OrderMeta describes the key(s) to be generated by an order, e.g. an AES key
+// of 256 bits in CBC mode, or an RSA key pair of 2048 bits; the payload content
+// type of the generated secrets is usually PayloadContentTypeBinary.
+type OrderMeta struct {
+	Name               *string `json:"name,omitempty"`
+	Algorithm          string  `json:"algorithm"`
+	BitLength          int     `json:"bit_length"`
+	Mode               *string `json:"mode,omitempty"`
+	PayloadContentType *string `json:"payload_content_type,omitempty"`
+	Expiration         *string `json:"expiration,omitempty"`
+}
+
+// CreateOrder submits an order of the given type (one of the OrderType*
+// constants) for the generation of the given key(s), and returns its reference;
+// the order is processed asynchronously (see WaitForOrder); see also
+// https://docs.openstack.org/barbican/latest/api/reference/orders.html#post-v1-orders
+func (api *KeyManagerV1API) CreateOrder(orderType string, meta *OrderMeta) (*string, *Result, error) {
+	input := &struct {
+		Type string     `parameter:"-" header:"-" json:"type"`
+		Meta *OrderMeta `parameter:"-" header:"-" json:"meta"`
+	}{
+		Type: orderType,
+		Meta: meta,
+	}
+	output := &struct {
+		OrderRef *string `json:"order_ref,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPost, "./v1/orders", true, StatusCodeIn(202), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return output.OrderRef, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE ORDER
+ */
+
+// RetrieveOrder retrieves the order with the given reference or UUID; see also
+// https://docs.openstack.org/barbican/latest/api/reference/orders.html#get-v1-orders-uuid
+func (api *KeyManagerV1API) RetrieveOrder(order string) (*Order, *Result, error) {
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+	}{
+		ID: keyManagerID(order),
+	}
+	output := &Order{}
+
+	result, err := api.Invoke(http.MethodGet, "./v1/orders/{id}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// WaitForOrder polls the order with the given reference or UUID until it is
+// ACTIVE, and returns it, or it fails, in which case the error reported by
+// Barbican is returned.
+func (api *KeyManagerV1API) WaitForOrder(ctx context.Context, order string, backoff Backoff) (*Order, error) {
+	var current *Order
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var err error
+		if current, _, err = api.RetrieveOrder(order); current == nil {
+			if err == nil {
+				err = fmt.Errorf("cannot retrieve order %q", order)
+			}
+			return false, err
+		}
+		switch stringValue(current.Status) {
+		case OrderStatusActive:
+			return true, nil
+		case OrderStatusError:
+			return false, fmt.Errorf("order %q failed: %s (%s)", order, stringValue(current.ErrorReason), stringValue(current.ErrorStatusCode))
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+/*
+ * DELETE ORDER
+ */
+
+// DeleteOrder deletes the order with the given reference or UUID; the generated
+// secrets are not deleted; see also
+// https://docs.openstack.org/barbican/latest/api/reference/orders.html#delete-v1-orders-uuid
+func (api *KeyManagerV1API) DeleteOrder(order string) (bool, *Result, error) {
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+	}{
+		ID: keyManagerID(order),
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v1/orders/{id}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,267 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// Secret types, describing what the payload of a secret is.
+const (
+	SecretTypeSymmetric   string = "symmetric"
+	SecretTypePublic      string = "public"
+	SecretTypePrivate     string = "private"
+	SecretTypePassphrase  string = "passphrase"
+	SecretTypeCertificate string = "certificate"
+	SecretTypeOpaque      string = "opaque"
+)
+
+const (
+	// PayloadContentTypeText is the content type of text secrets (e.g.
+	// passphrases, PEM-encoded certificates), which are stored as they are.
+	PayloadContentTypeText string = "text/plain"
+
+	// PayloadContentTypeBinary is the content type of binary secrets (e.g.
+	// symmetric keys), which are transferred base64-encoded in JSON entities.
+	PayloadContentTypeBinary string = "application/octet-stream"
+)
+
+/*
+ * LIST SECRETS
+ */
+
+// ListSecretsOptions provides the options available for filtering the list of
+// secrets; Limit sets the page size, all pages are retrieved anyway.
+type ListSecretsOptions struct {
+	Name       *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Algorithm  *string `parameter:"alg,omitempty" header:"-" json:"-"`
+	Mode       *string `parameter:"mode,omitempty" header:"-" json:"-"`
+	BitLength  *int    `parameter:"bits,omitempty" header:"-" json:"-"`
+	SecretType *string `parameter:"secret_type,omitempty" header:"-" json:"-"`
+	ACLOnly    *bool   `parameter:"acl_only,omitempty" header:"-" json:"-"`
+	Sort       *string `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit      *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Offset     *int    `parameter:"offset,omitempty" header:"-" json:"-"`
+}
+
+// ListSecrets returns the metadata of the secrets of the current project; opts
+// can be nil; see also
+// https://docs.openstack.org/barbican/latest/api/reference/secrets.html#get-v1-secrets
+func (api *KeyManagerV1API) ListSecrets(opts *ListSecretsOptions) (*[]Secret, *Result, error) {
+	if opts == nil {
+		opts = &ListSecretsOptions{}
+	}
+
+	secrets := []Secret{}
+	result, err := api.listResources("./v1/secrets", "secrets", opts, nextField,
+		func() interface{} { return &[]Secret{} },
+		func(page interface{}) {
+			secrets = append(secrets, *page.(*[]Secret)...)
+		})
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &secrets, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE SECRET
+ */
+
+// CreateSecretOptions provides the options available for creating a secret:
+// if Payload is given, the secret is stored at once, otherwise only its
+// metadata is and the payload must be uploaded afterwards (see
+// UploadSecretPayload). PayloadContentType defaults to PayloadContentTypeText
+// for a passphrase and to PayloadContentTypeBinary otherwise; binary payloads
+// are base64-encoded transparently. Expiration is in ISO 8601 format.
+type CreateSecretOptions struct {
+	Name               *string `json:"name,omitempty"`
+	SecretType         *string `json:"secret_type,omitempty"`
+	Algorithm          *string `json:"algorithm,omitempty"`
+	BitLength          *int    `json:"bit_length,omitempty"`
+	Mode               *string `json:"mode,omitempty"`
+	Expiration         *string `json:"expiration,omitempty"`
+	Payload            []byte  `json:"-"`
+	PayloadContentType *string `json:"-"`
+}
+
+// CreateSecret creates a secret and returns its reference; opts can be nil, in
+// which case an empty secret is created, whose payload is to be uploaded
+// afterwards; see also
+// https://docs.openstack.org/barbican/latest/api/reference/secrets.html#post-v1-secrets
+func (api *KeyManagerV1API) CreateSecret(opts *CreateSecretOptions) (*string, *Result, error) {
+	if opts == nil {
+		opts = &CreateSecretOptions{}
+	}
+	input := &struct {
+		CreateSecretOptions
+		Payload                *string `json:"payload,omitempty"`
+		PayloadContentType     *string `json:"payload_content_type,omitempty"`
+		PayloadContentEncoding *string `json:"payload_content_encoding,omitempty"`
+	}{
+		CreateSecretOptions: *opts,
+	}
+	if opts.Payload != nil {
+		contentType := secretContentType(opts.SecretType, opts.PayloadContentType)
+		input.PayloadContentType = String(contentType)
+		if strings.HasPrefix(contentType, PayloadContentTypeText) {
+			input.Payload = String(string(opts.Payload))
+		} else {
+			input.Payload = String(base64.StdEncoding.EncodeToString(opts.Payload))
+			input.PayloadContentEncoding = String("base64")
+		}
+	}
+	output := &struct {
+		SecretRef *string `json:"secret_ref,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPost, "./v1/secrets", true, StatusCodeIn(201), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return output.SecretRef, result, err
+	}
+	return nil, result, err
+}
+
+// secretContentType returns the content type of the payload of a secret of the
+// given type, unless explicitly set.
+func secretContentType(secretType *string, contentType *string) string {
+	if contentType != nil && *contentType != "" {
+		return *contentType
+	}
+	if stringValue(secretType) == SecretTypePassphrase {
+		return PayloadContentTypeText
+	}
+	return PayloadContentTypeBinary
+}
+
+/*
+ * UPLOAD SECRET PAYLOAD
+ */
+
+// UploadSecretPayload uploads the payload of a secret created without one (the
+// second step of a two-step creation), as is, with the given content type (see
+// the PayloadContentType* constants); a secret payload can only be set once;
+// see also
+// https://docs.openstack.org/barbican/latest/api/reference/secrets.html#put-v1-secrets-uuid
+func (api *KeyManagerV1API) UploadSecretPayload(secret string, payload []byte, contentType string) (bool, *Result, error) {
+	if contentType == "" {
+		contentType = PayloadContentTypeBinary
+	}
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+	}{
+		ID: keyManagerID(secret),
+	}
+
+	result, err := api.InvokeStream(http.MethodPut, "./v1/secrets/{id}", true, StatusCodeIn(204), input, bytes.NewReader(payload), int64(len(payload)), contentType, nil, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * RETRIEVE SECRET
+ */
+
+// RetrieveSecret retrieves the metadata of the secret with the given reference
+// or UUID; see also
+// https://docs.openstack.org/barbican/latest/api/reference/secrets.html#get-v1-secrets-uuid
+func (api *KeyManagerV1API) RetrieveSecret(secret string) (*Secret, *Result, error) {
+	input := &struct {
+		ID     string `parameter:"-" header:"-" variable:"id" json:"-"`
+		Accept string `parameter:"-" header:"Accept" json:"-"`
+	}{
+		ID:     keyManagerID(secret),
+		Accept: "application/json",
+	}
+	output := &Secret{}
+
+	result, err := api.Invoke(http.MethodGet, "./v1/secrets/{id}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveSecretPayload retrieves the payload of the secret with the given
+// reference or UUID, decoded, in the given content type; if contentType is
+// empty, the one the secret was stored with is used; see also
+// https://docs.openstack.org/barbican/latest/api/reference/secrets.html#get-v1-secrets-uuid-payload
+func (api *KeyManagerV1API) RetrieveSecretPayload(secret string, contentType string) ([]byte, *Result, error) {
+	if contentType == "" {
+		metadata, result, err := api.RetrieveSecret(secret)
+		if metadata == nil {
+			return nil, result, err
+		}
+		if metadata.ContentTypes == nil || (*metadata.ContentTypes)["default"] == "" {
+			log.Errorf("secret %q has no payload", secret)
+			return nil, result, fmt.Errorf("secret %q has no payload", secret)
+		}
+		contentType = (*metadata.ContentTypes)["default"]
+	}
+	input := &struct {
+		ID     string `parameter:"-" header:"-" variable:"id" json:"-"`
+		Accept string `parameter:"-" header:"Accept" json:"-"`
+	}{
+		ID:     keyManagerID(secret),
+		Accept: contentType,
+	}
+	output := &struct {
+		ContentEncoding *string `parameter:"-" header:"Content-Encoding,omitempty" json:"-"`
+	}{}
+
+	body, result, err := api.InvokeReader(http.MethodGet, "./v1/secrets/{id}/payload", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if body == nil {
+		return nil, result, err
+	}
+	defer body.Close()
+	payload, err := ioutil.ReadAll(body)
+	if err != nil {
+		log.Errorf("error reading payload of secret %q: %v", secret, err)
+		return nil, result, err
+	}
+	if strings.EqualFold(stringValue(output.ContentEncoding), "base64") {
+		if payload, err = base64.StdEncoding.DecodeString(string(payload)); err != nil {
+			log.Errorf("error decoding payload of secret %q: %v", secret, err)
+			return nil, result, err
+		}
+	}
+	return payload, result, nil
+}
+
+/*
+ * DELETE SECRET
+ */
+
+// DeleteSecret deletes the secret with the given reference or UUID, along with
+// its payload; see also
+// https://docs.openstack.org/barbican/latest/api/reference/secrets.html#delete-v1-secrets-uuid
+func (api *KeyManagerV1API) DeleteSecret(secret string) (bool, *Result, error) {
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+	}{
+		ID: keyManagerID(secret),
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v1/secrets/{id}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
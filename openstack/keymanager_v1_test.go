@@ -0,0 +1,64 @@
+package openstack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestKeyManagerID(t *testing.T) {
+	refs := map[string]string{
+		"https://barbican:9311/v1/secrets/abc":  "abc",
+		"https://barbican:9311/v1/secrets/abc/": "abc",
+		"abc":                                   "abc",
+	}
+	for ref, expected := range refs {
+		if actual := keyManagerID(ref); actual != expected {
+			t.Errorf("KeyManager.TestKeyManagerID: expected %q for %q, got %q", expected, ref, actual)
+		}
+	}
+}
+
+func TestSecretContentType(t *testing.T) {
+	if actual := secretContentType(String(SecretTypePassphrase), nil); actual != PayloadContentTypeText {
+		t.Errorf("KeyManager.TestSecretContentType: expected %q for a passphrase, got %q", PayloadContentTypeText, actual)
+	}
+	if actual := secretContentType(String(SecretTypeSymmetric), nil); actual != PayloadContentTypeBinary {
+		t.Errorf("KeyManager.TestSecretContentType: expected %q for a symmetric key, got %q", PayloadContentTypeBinary, actual)
+	}
+	if actual := secretContentType(nil, String("application/pkcs8")); actual != "application/pkcs8" {
+		t.Errorf("KeyManager.TestSecretContentType: expected explicit content type, got %q", actual)
+	}
+}
+
+func TestListSecretsPagination(t *testing.T) {
+	var url string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/v1/secrets" || r.URL.Query().Get("name") != "db" || r.URL.Query().Get("limit") != "2" {
+			t.Errorf("KeyManager.TestListSecretsPagination: unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("offset") {
+		case "":
+			w.Write([]byte(`{"secrets": [{"name": "db"}, {"name": "db"}], "total": 3, "next": "` + url + `/v1/secrets?limit=2&name=db&offset=2"}`))
+		case "2":
+			w.Write([]byte(`{"secrets": [{"name": "db"}], "total": 3, "previous": "` + url + `/v1/secrets?limit=2&name=db&offset=0"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	url = server.URL
+	client := NewDefaultClient(server.URL)
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+
+	secrets, result, err := (&KeyManagerV1API{client.Authenticator.Identity.API}).ListSecrets(&ListSecretsOptions{
+		Name:  String("db"),
+		Limit: Int(2),
+	})
+	if err != nil || secrets == nil || len(*secrets) != 3 {
+		t.Errorf("KeyManager.TestListSecretsPagination: expected 3 secrets across 2 pages, got %v (%v)", result, err)
+	}
+}
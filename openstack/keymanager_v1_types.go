@@ -0,0 +1,85 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * SECRETS
+ */
+
+// Secret is the metadata of a secret stored in Barbican; the secret payload is
+// retrieved separately (see RetrieveSecretPayload), in one of the content types
+// listed in ContentTypes ("default" is the one it was stored with).
+type Secret struct {
+	SecretRef    *string            `json:"secret_ref,omitempty"`
+	Name         *string            `json:"name,omitempty"`
+	Status       *string            `json:"status,omitempty"`
+	SecretType   *string            `json:"secret_type,omitempty"`
+	Algorithm    *string            `json:"algorithm,omitempty"`
+	BitLength    *int               `json:"bit_length,omitempty"`
+	Mode         *string            `json:"mode,omitempty"`
+	Expiration   *string            `json:"expiration,omitempty"`
+	ContentTypes *map[string]string `json:"content_types,omitempty"`
+	CreatorID    *string            `json:"creator_id,omitempty"`
+	Created      *string            `json:"created,omitempty"`
+	Updated      *string            `json:"updated,omitempty"`
+}
+
+/*
+ * CONTAINERS
+ */
+
+// SecretContainer is a named group of references to secrets, e.g. the
+// certificate, private key and intermediates making up a TLS certificate; the
+// names of the secrets in certificate and RSA containers are fixed (see the
+// ContainerSecret* constants).
+type SecretContainer struct {
+	ContainerRef *string      `json:"container_ref,omitempty"`
+	Name         *string      `json:"name,omitempty"`
+	Type         *string      `json:"type,omitempty"`
+	Status       *string      `json:"status,omitempty"`
+	SecretRefs   *[]SecretRef `json:"secret_refs,omitempty"`
+	Consumers    *[]Consumer  `json:"consumers,omitempty"`
+	CreatorID    *string      `json:"creator_id,omitempty"`
+	Created      *string      `json:"created,omitempty"`
+	Updated      *string      `json:"updated,omitempty"`
+}
+
+// SecretRef is a reference to a secret in a container, under the given name.
+type SecretRef struct {
+	Name      *string `json:"name,omitempty"`
+	SecretRef *string `json:"secret_ref,omitempty"`
+}
+
+// Consumer is a service resource using a container (e.g. an Octavia listener
+// using a TLS certificate), registered so that the container is not deleted
+// while in use; URL identifies the resource.
+type Consumer struct {
+	Name    *string `json:"name,omitempty"`
+	URL     *string `json:"URL,omitempty"`
+	Created *string `json:"created,omitempty"`
+}
+
+/*
+ * ORDERS
+ */
+
+// Order is a request for Barbican to generate a secret (a symmetric key, or an
+// asymmetric key pair stored in a container); once the order is ACTIVE,
+// SecretRef or ContainerRef point to the generated key(s).
+type Order struct {
+	OrderRef         *string                 `json:"order_ref,omitempty"`
+	Type             *string                 `json:"type,omitempty"`
+	Status           *string                 `json:"status,omitempty"`
+	SubStatus        *string                 `json:"sub_status,omitempty"`
+	SubStatusMessage *string                 `json:"sub_status_message,omitempty"`
+	Meta             *map[string]interface{} `json:"meta,omitempty"`
+	SecretRef        *string                 `json:"secret_ref,omitempty"`
+	ContainerRef     *string                 `json:"container_ref,omitempty"`
+	ErrorStatusCode  *string                 `json:"error_status_code,omitempty"`
+	ErrorReason      *string                 `json:"error_reason,omitempty"`
+	CreatorID        *string                 `json:"creator_id,omitempty"`
+	Created          *string                 `json:"created,omitempty"`
+	Updated          *string                 `json:"updated,omitempty"`
+}
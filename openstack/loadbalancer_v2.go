@@ -0,0 +1,16 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+// LoadBalancerV2API represents the load balancing API ver. 2 (Octavia),
+// providing support for the management of load balancers and for the discovery
+// of the providers and flavors available to create them.
+// Octavia follows the conventions of Neutron: resources are wrapped in an
+// envelope named after them (e.g. "loadbalancer") and collections are paginated
+// through links (e.g. "loadbalancers_links").
+// See https://developer.openstack.org/api-ref/load-balancer/v2/
+type LoadBalancerV2API struct {
+	API
+}
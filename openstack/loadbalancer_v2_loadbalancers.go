@@ -0,0 +1,186 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST LOAD BALANCERS
+ */
+
+// ListLoadBalancersOptions provides the options available for filtering the
+// list of load balancers.
+type ListLoadBalancersOptions struct {
+	ID                 *string             `parameter:"id,omitempty" header:"-" json:"-"`
+	Name               *string             `parameter:"name,omitempty" header:"-" json:"-"`
+	Description        *string             `parameter:"description,omitempty" header:"-" json:"-"`
+	ProjectID          *string             `parameter:"project_id,omitempty" header:"-" json:"-"`
+	AdminStateUp       *bool               `parameter:"admin_state_up,omitempty" header:"-" json:"-"`
+	ProvisioningStatus *string             `parameter:"provisioning_status,omitempty" header:"-" json:"-"`
+	OperatingStatus    *string             `parameter:"operating_status,omitempty" header:"-" json:"-"`
+	VIPAddress         *string             `parameter:"vip_address,omitempty" header:"-" json:"-"`
+	VIPPortID          *string             `parameter:"vip_port_id,omitempty" header:"-" json:"-"`
+	VIPSubnetID        *string             `parameter:"vip_subnet_id,omitempty" header:"-" json:"-"`
+	VIPNetworkID       *string             `parameter:"vip_network_id,omitempty" header:"-" json:"-"`
+	Provider           *string             `parameter:"provider,omitempty" header:"-" json:"-"`
+	FlavorID           *string             `parameter:"flavor_id,omitempty" header:"-" json:"-"`
+	AvailabilityZone   *string             `parameter:"availability_zone,omitempty" header:"-" json:"-"`
+	Tags               *CommaSeparatedList `parameter:"tags,omitempty" header:"-" json:"-"`
+	TagsAny            *CommaSeparatedList `parameter:"tags-any,omitempty" header:"-" json:"-"`
+	NotTags            *CommaSeparatedList `parameter:"not-tags,omitempty" header:"-" json:"-"`
+	NotTagsAny         *CommaSeparatedList `parameter:"not-tags-any,omitempty" header:"-" json:"-"`
+	SortKey            *string             `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir            *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit              *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker             *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListLoadBalancers returns the list of load balancers of the current project;
+// see also
+// https://developer.openstack.org/api-ref/load-balancer/v2/#list-load-balancers
+func (api *LoadBalancerV2API) ListLoadBalancers(opts *ListLoadBalancersOptions) (*[]LoadBalancer, *Result, error) {
+	loadbalancers := []LoadBalancer{}
+	result, err := api.listResources("./v2/lbaas/loadbalancers", "loadbalancers", opts, collectionLinks,
+		func() interface{} { return &[]LoadBalancer{} },
+		func(page interface{}) { loadbalancers = append(loadbalancers, *page.(*[]LoadBalancer)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &loadbalancers, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE LOAD BALANCER
+ */
+
+// CreateLoadBalancerOptions provides the options available for creating a load
+// balancer: at least one of VIPSubnetID, VIPNetworkID and VIPPortID must be
+// given, to place its virtual IP; VIPAddress requests a specific address in the
+// subnet. Provider and FlavorID default to the ones configured by the operator.
+type CreateLoadBalancerOptions struct {
+	Name             *string   `json:"name,omitempty"`
+	Description      *string   `json:"description,omitempty"`
+	ProjectID        *string   `json:"project_id,omitempty"`
+	AdminStateUp     *bool     `json:"admin_state_up,omitempty"`
+	VIPAddress       *string   `json:"vip_address,omitempty"`
+	VIPPortID        *string   `json:"vip_port_id,omitempty"`
+	VIPSubnetID      *string   `json:"vip_subnet_id,omitempty"`
+	VIPNetworkID     *string   `json:"vip_network_id,omitempty"`
+	VIPQoSPolicyID   *string   `json:"vip_qos_policy_id,omitempty"`
+	Provider         *string   `json:"provider,omitempty"`
+	FlavorID         *string   `json:"flavor_id,omitempty"`
+	AvailabilityZone *string   `json:"availability_zone,omitempty"`
+	Tags             *[]string `json:"tags,omitempty"`
+}
+
+// CreateLoadBalancer creates a load balancer; the creation is asynchronous:
+// the load balancer is returned in the PENDING_CREATE provisioning status and
+// becomes ACTIVE once its VIP and backends are in place; see also
+// https://developer.openstack.org/api-ref/load-balancer/v2/#create-a-load-balancer
+func (api *LoadBalancerV2API) CreateLoadBalancer(opts *CreateLoadBalancerOptions) (*LoadBalancer, *Result, error) {
+	if opts == nil || (opts.VIPSubnetID == nil && opts.VIPNetworkID == nil && opts.VIPPortID == nil) {
+		log.Errorf("no VIP subnet, network or port for load balancer")
+		return nil, nil, errors.New("a VIP subnet, network or port is required to create a load balancer")
+	}
+	loadbalancer := &LoadBalancer{}
+	result, err := api.createResource("./v2/lbaas/loadbalancers", "loadbalancer", opts, loadbalancer)
+	if result != nil && result.Code == 201 {
+		return loadbalancer, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE LOAD BALANCER
+ */
+
+// RetrieveLoadBalancer retrieves the load balancer with the given ID; see also
+// https://developer.openstack.org/api-ref/load-balancer/v2/#show-load-balancer-details
+func (api *LoadBalancerV2API) RetrieveLoadBalancer(loadbalancerid string) (*LoadBalancer, *Result, error) {
+	loadbalancer := &LoadBalancer{}
+	result, err := api.retrieveResource("./v2/lbaas/loadbalancers/{id}", loadbalancerid, "loadbalancer", loadbalancer)
+	if result != nil && result.Code == 200 {
+		return loadbalancer, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE LOAD BALANCER
+ */
+
+// UpdateLoadBalancerOptions provides the options available for updating a load
+// balancer; only the given attributes are modified.
+type UpdateLoadBalancerOptions struct {
+	Name           *string   `json:"name,omitempty"`
+	Description    *string   `json:"description,omitempty"`
+	AdminStateUp   *bool     `json:"admin_state_up,omitempty"`
+	VIPQoSPolicyID *string   `json:"vip_qos_policy_id,omitempty"`
+	Tags           *[]string `json:"tags,omitempty"`
+}
+
+// UpdateLoadBalancer updates the load balancer with the given ID; the update is
+// asynchronous (PENDING_UPDATE); see also
+// https://developer.openstack.org/api-ref/load-balancer/v2/#update-a-load-balancer
+func (api *LoadBalancerV2API) UpdateLoadBalancer(loadbalancerid string, opts *UpdateLoadBalancerOptions) (*LoadBalancer, *Result, error) {
+	loadbalancer := &LoadBalancer{}
+	result, err := api.updateResource("./v2/lbaas/loadbalancers/{id}", loadbalancerid, "loadbalancer", opts, loadbalancer)
+	if result != nil && result.Code == 200 {
+		return loadbalancer, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE LOAD BALANCER
+ */
+
+// DeleteLoadBalancer deletes the load balancer with the given ID; unless cascade
+// is set, the load balancer must have no listeners and pools left, otherwise
+// they are deleted along with it; the deletion is asynchronous
+// (PENDING_DELETE); see also
+// https://developer.openstack.org/api-ref/load-balancer/v2/#remove-a-load-balancer
+func (api *LoadBalancerV2API) DeleteLoadBalancer(loadbalancerid string, cascade bool) (bool, *Result, error) {
+	input := &struct {
+		ID      string `parameter:"-" header:"-" variable:"id" json:"-"`
+		Cascade *bool  `parameter:"cascade,omitempty" header:"-" json:"-"`
+	}{
+		ID: loadbalancerid,
+	}
+	if cascade {
+		input.Cascade = Bool(true)
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v2/lbaas/loadbalancers/{id}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * FAILOVER LOAD BALANCER
+ */
+
+// FailoverLoadBalancer triggers the failover of the load balancer with the given
+// ID, i.e. the replacement of its amphorae (or the equivalent for the
+// provider), e.g. to recover them or to apply a new image; it requires the
+// admin role by default; see also
+// https://developer.openstack.org/api-ref/load-balancer/v2/#failover-a-load-balancer
+func (api *LoadBalancerV2API) FailoverLoadBalancer(loadbalancerid string) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodPut, "./v2/lbaas/loadbalancers/{id}/failover", true, StatusCodeIn(202), &envelope{ID: loadbalancerid}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
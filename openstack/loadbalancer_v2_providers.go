@@ -0,0 +1,90 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * PROVIDERS
+ */
+
+// ListLoadBalancerProviders returns the list of the provider drivers enabled
+// by the operator; see also
+// https://developer.openstack.org/api-ref/load-balancer/v2/#list-providers
+func (api *LoadBalancerV2API) ListLoadBalancerProviders() (*[]LoadBalancerProvider, *Result, error) {
+	providers := []LoadBalancerProvider{}
+	result, err := api.listResources("./v2/lbaas/providers", "providers", nil, collectionLinks,
+		func() interface{} { return &[]LoadBalancerProvider{} },
+		func(page interface{}) { providers = append(providers, *page.(*[]LoadBalancerProvider)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &providers, result, err
+	}
+	return nil, result, err
+}
+
+// ListLoadBalancerFlavorCapabilities returns the list of the capabilities of
+// the provider driver with the given name that can be set in flavor profiles;
+// see also
+// https://developer.openstack.org/api-ref/load-balancer/v2/#show-provider-flavor-capabilities
+func (api *LoadBalancerV2API) ListLoadBalancerFlavorCapabilities(provider string) (*[]LoadBalancerFlavorCapability, *Result, error) {
+	capabilities := &[]LoadBalancerFlavorCapability{}
+	input := &struct {
+		Provider string `parameter:"-" header:"-" variable:"provider" json:"-"`
+	}{
+		Provider: provider,
+	}
+
+	result, err := api.Invoke(http.MethodGet, "./v2/lbaas/providers/{provider}/flavor_capabilities", true, StatusCodeIn(200), input, &envelope{Name: "flavor_capabilities", Body: capabilities}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return capabilities, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * FLAVORS
+ */
+
+// ListLoadBalancerFlavorsOptions provides the options available for filtering
+// the list of load balancer flavors.
+type ListLoadBalancerFlavorsOptions struct {
+	Name    *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Enabled *bool   `parameter:"enabled,omitempty" header:"-" json:"-"`
+	Limit   *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker  *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListLoadBalancerFlavors returns the list of the load balancer flavors offered
+// by the operator; see also
+// https://developer.openstack.org/api-ref/load-balancer/v2/#list-flavors
+func (api *LoadBalancerV2API) ListLoadBalancerFlavors(opts *ListLoadBalancerFlavorsOptions) (*[]LoadBalancerFlavor, *Result, error) {
+	flavors := []LoadBalancerFlavor{}
+	result, err := api.listResources("./v2/lbaas/flavors", "flavors", opts, collectionLinks,
+		func() interface{} { return &[]LoadBalancerFlavor{} },
+		func(page interface{}) { flavors = append(flavors, *page.(*[]LoadBalancerFlavor)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &flavors, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveLoadBalancerFlavor retrieves the load balancer flavor with the given
+// ID; see also
+// https://developer.openstack.org/api-ref/load-balancer/v2/#show-flavor-details
+func (api *LoadBalancerV2API) RetrieveLoadBalancerFlavor(flavorid string) (*LoadBalancerFlavor, *Result, error) {
+	flavor := &LoadBalancerFlavor{}
+	result, err := api.retrieveResource("./v2/lbaas/flavors/{id}", flavorid, "flavor", flavor)
+	if result != nil && result.Code == 200 {
+		return flavor, result, err
+	}
+	return nil, result, err
+}
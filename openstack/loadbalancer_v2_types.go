@@ -0,0 +1,71 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * LOAD BALANCERS
+ */
+
+// LoadBalancer is an Octavia load balancer: its virtual IP (VIP) is allocated
+// on a port in the given subnet or network; ProvisioningStatus reports the
+// progress of the asynchronous operations on it (e.g. "PENDING_CREATE",
+// "ACTIVE", "ERROR"), OperatingStatus the health of its members.
+type LoadBalancer struct {
+	ID                 *string                 `json:"id,omitempty"`
+	Name               *string                 `json:"name,omitempty"`
+	Description        *string                 `json:"description,omitempty"`
+	ProjectID          *string                 `json:"project_id,omitempty"`
+	AdminStateUp       *bool                   `json:"admin_state_up,omitempty"`
+	ProvisioningStatus *string                 `json:"provisioning_status,omitempty"`
+	OperatingStatus    *string                 `json:"operating_status,omitempty"`
+	VIPAddress         *string                 `json:"vip_address,omitempty"`
+	VIPPortID          *string                 `json:"vip_port_id,omitempty"`
+	VIPSubnetID        *string                 `json:"vip_subnet_id,omitempty"`
+	VIPNetworkID       *string                 `json:"vip_network_id,omitempty"`
+	VIPQoSPolicyID     *string                 `json:"vip_qos_policy_id,omitempty"`
+	Provider           *string                 `json:"provider,omitempty"`
+	FlavorID           *string                 `json:"flavor_id,omitempty"`
+	AvailabilityZone   *string                 `json:"availability_zone,omitempty"`
+	Listeners          *[]LoadBalancerResource `json:"listeners,omitempty"`
+	Pools              *[]LoadBalancerResource `json:"pools,omitempty"`
+	Tags               *[]string               `json:"tags,omitempty"`
+	CreatedAt          *string                 `json:"created_at,omitempty"`
+	UpdatedAt          *string                 `json:"updated_at,omitempty"`
+}
+
+// LoadBalancerResource is a reference to a resource (e.g. a listener or a pool)
+// of a load balancer.
+type LoadBalancerResource struct {
+	ID *string `json:"id,omitempty"`
+}
+
+/*
+ * PROVIDERS AND FLAVORS
+ */
+
+// LoadBalancerProvider is a load balancer provider driver (e.g. "amphora",
+// "ovn").
+type LoadBalancerProvider struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// LoadBalancerFlavorCapability is a capability of a provider driver that can
+// be set in the flavor profiles using it (e.g. "loadbalancer_topology").
+type LoadBalancerFlavorCapability struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// LoadBalancerFlavor is a set of provider-specific options (a flavor profile)
+// offered by the operator under a name, to be chosen when creating a load
+// balancer.
+type LoadBalancerFlavor struct {
+	ID              *string `json:"id,omitempty"`
+	Name            *string `json:"name,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	Enabled         *bool   `json:"enabled,omitempty"`
+	FlavorProfileID *string `json:"flavor_profile_id,omitempty"`
+}
@@ -0,0 +1,61 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// MetricV1API represents the Metric API ver. 1 (Gnocchi), providing support for
+// the management of resources, of their metrics and of the measures stored in
+// them, and for the retrieval of measures aggregated across metrics.
+// Gnocchi returns collections as bare JSON arrays and paginates them by marker
+// (the ID of the last item of the previous page); measures are stored and
+// aggregated asynchronously, as per the archive policy of each metric.
+// See https://gnocchi.osci.io/rest.html
+type MetricV1API struct {
+	API
+}
+
+// MetricResourceTypeGeneric is the resource type having no attributes other
+// than the common ones; it is used when no resource type is given.
+const MetricResourceTypeGeneric string = "generic"
+
+// metricResourceType returns the given resource type, or the generic one if
+// none is given.
+func metricResourceType(resourcetype string) string {
+	if resourcetype == "" {
+		return MetricResourceTypeGeneric
+	}
+	return resourcetype
+}
+
+// measuresInput is the request entity of the calls posting measures, which is
+// a bare JSON array or object; the metric ID, if any, is bound to the {id}
+// variable in the path.
+type measuresInput struct {
+	ID            string      `parameter:"-" header:"-" variable:"id" json:"-"`
+	CreateMetrics *bool       `parameter:"create_metrics,omitempty" header:"-" variable:"-" json:"-"`
+	Measures      interface{} `parameter:"-" header:"-" variable:"-" json:"-"`
+}
+
+// MarshalJSON encodes the measures as they are.
+func (m measuresInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Measures)
+}
+
+// postMeasures posts the given measures to the given path; Gnocchi accepts them
+// for asynchronous processing.
+func (api *MetricV1API) postMeasures(path string, input *measuresInput) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodPost, path, true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,278 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST METRICS
+ */
+
+// ListMetricsOptions provides the options available for listing the metrics;
+// Limit sets the page size, all pages are retrieved anyway; Sort is a
+// "key:direction" pair (e.g. "name:asc").
+type ListMetricsOptions struct {
+	Name   *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Unit   *string `parameter:"unit,omitempty" header:"-" json:"-"`
+	Sort   *string `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit  *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListMetrics returns the list of metrics; opts can be nil; see also
+// https://gnocchi.osci.io/rest.html#metrics
+func (api *MetricV1API) ListMetrics(opts *ListMetricsOptions) (*[]Metric, *Result, error) {
+	if opts == nil {
+		opts = &ListMetricsOptions{}
+	}
+	input := *opts
+
+	metrics := []Metric{}
+	for {
+		page := []Metric{}
+		result, err := api.Invoke(http.MethodGet, "./v1/metric", true, StatusCodeIn(200), &input, &bareList{items: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		metrics = append(metrics, page...)
+		if input.Limit == nil || len(page) < *input.Limit || len(page) == 0 {
+			return &metrics, result, err
+		}
+		// the next page starts after the last metric of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE METRIC
+ */
+
+// CreateMetricOptions provides the options available for creating a metric;
+// the archive policy is chosen by the archive policy rules unless
+// ArchivePolicyName is given; the metric belongs to the resource with
+// ResourceID, under Name, if given.
+type CreateMetricOptions struct {
+	Name              *string `parameter:"-" header:"-" json:"name,omitempty"`
+	Unit              *string `parameter:"-" header:"-" json:"unit,omitempty"`
+	ArchivePolicyName *string `parameter:"-" header:"-" json:"archive_policy_name,omitempty"`
+	ResourceID        *string `parameter:"-" header:"-" json:"resource_id,omitempty"`
+}
+
+// CreateMetric creates a metric; see also
+// https://gnocchi.osci.io/rest.html#metrics
+func (api *MetricV1API) CreateMetric(opts *CreateMetricOptions) (*Metric, *Result, error) {
+	metric := &Metric{}
+	result, err := api.Invoke(http.MethodPost, "./v1/metric", true, StatusCodeIn(201), opts, metric, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return metric, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE METRIC
+ */
+
+// RetrieveMetric retrieves the metric with the given ID, along with its archive
+// policy; see also
+// https://gnocchi.osci.io/rest.html#metrics
+func (api *MetricV1API) RetrieveMetric(metricid string) (*Metric, *Result, error) {
+	metric := &Metric{}
+	result, err := api.Invoke(http.MethodGet, "./v1/metric/{id}", true, StatusCodeIn(200), &envelope{ID: metricid}, metric, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return metric, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE METRIC
+ */
+
+// DeleteMetric deletes the metric with the given ID, along with its measures;
+// see also
+// https://gnocchi.osci.io/rest.html#metrics
+func (api *MetricV1API) DeleteMetric(metricid string) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodDelete, "./v1/metric/{id}", true, StatusCodeIn(204), &envelope{ID: metricid}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * MEASURES
+ */
+
+// AddMeasures posts the given measures to the metric with the given ID; the
+// measures are aggregated asynchronously; see also
+// https://gnocchi.osci.io/rest.html#measures
+func (api *MetricV1API) AddMeasures(metricid string, measures []Measure) (bool, *Result, error) {
+	return api.postMeasures("./v1/metric/{id}/measures", &measuresInput{ID: metricid, Measures: measures})
+}
+
+// BatchMetricMeasures posts measures to several metrics at once, in a single
+// call: measures maps metric IDs to their new measures; see also
+// https://gnocchi.osci.io/rest.html#batch
+func (api *MetricV1API) BatchMetricMeasures(measures map[string][]Measure) (bool, *Result, error) {
+	return api.postMeasures("./v1/batch/metrics/measures", &measuresInput{Measures: measures})
+}
+
+// BatchResourceMetricMeasures posts measures to the metrics of several
+// resources at once, in a single call: measures maps resource IDs to the names
+// of their metrics, and these to their new measures; if createMetrics is set,
+// the metrics that do not exist are created, with the archive policy chosen by
+// the archive policy rules, which is the usual way exporters push measures;
+// see also
+// https://gnocchi.osci.io/rest.html#batch
+func (api *MetricV1API) BatchResourceMetricMeasures(measures map[string]map[string][]Measure, createMetrics bool) (bool, *Result, error) {
+	input := &measuresInput{Measures: measures}
+	if createMetrics {
+		input.CreateMetrics = Bool(true)
+	}
+	return api.postMeasures("./v1/batch/resources/metrics/measures", input)
+}
+
+// RetrieveMeasuresOptions provides the options available for retrieving the
+// measures of a metric: Start and Stop (ISO 8601 timestamps) limit the time
+// range, Granularity (in seconds) and Aggregation (e.g. "mean", "max", the
+// default being "mean") select the aggregates to return among those kept by
+// the archive policy, Resample (in seconds) aggregates them again at a coarser
+// granularity; Refresh forces the aggregation of the measures not yet
+// processed.
+type RetrieveMeasuresOptions struct {
+	Start       *string `parameter:"start,omitempty" header:"-" json:"-"`
+	Stop        *string `parameter:"stop,omitempty" header:"-" json:"-"`
+	Granularity *string `parameter:"granularity,omitempty" header:"-" json:"-"`
+	Aggregation *string `parameter:"aggregation,omitempty" header:"-" json:"-"`
+	Resample    *string `parameter:"resample,omitempty" header:"-" json:"-"`
+	Refresh     *bool   `parameter:"refresh,omitempty" header:"-" json:"-"`
+}
+
+// RetrieveMeasures returns the aggregated measures of the metric with the given
+// ID; opts can be nil; see also
+// https://gnocchi.osci.io/rest.html#measures
+func (api *MetricV1API) RetrieveMeasures(metricid string, opts *RetrieveMeasuresOptions) (*[]Measure, *Result, error) {
+	if opts == nil {
+		opts = &RetrieveMeasuresOptions{}
+	}
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		RetrieveMeasuresOptions
+	}{
+		ID:                      metricid,
+		RetrieveMeasuresOptions: *opts,
+	}
+	measures := &[]Measure{}
+
+	result, err := api.Invoke(http.MethodGet, "./v1/metric/{id}/measures", true, StatusCodeIn(200), input, &bareList{items: measures}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return measures, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * AGGREGATES
+ */
+
+// AggregateMeasuresOptions provides the options available for aggregating
+// measures across metrics: Aggregation (e.g. "mean", "sum", "max", the default
+// being "mean") is both the aggregation of each metric to use and the one
+// applied across them; Granularity (in seconds) selects the aggregates of the
+// metrics, which must all have it, Start and Stop (ISO 8601 timestamps) limit
+// the time range; NeededOverlap is the percentage of timestamps that must be
+// shared by the metrics (100 by default), unless Fill ("null", "dropna" or a
+// number) fills the missing points.
+type AggregateMeasuresOptions struct {
+	Aggregation   *string  `parameter:"-" header:"-" json:"-"`
+	Granularity   *string  `parameter:"granularity,omitempty" header:"-" json:"-"`
+	Start         *string  `parameter:"start,omitempty" header:"-" json:"-"`
+	Stop          *string  `parameter:"stop,omitempty" header:"-" json:"-"`
+	NeededOverlap *float64 `parameter:"needed_overlap,omitempty" header:"-" json:"-"`
+	Fill          *string  `parameter:"fill,omitempty" header:"-" json:"-"`
+}
+
+// aggregation returns the aggregation method of the options, "mean" by
+// default.
+func (opts *AggregateMeasuresOptions) aggregation() string {
+	if opts == nil || opts.Aggregation == nil || *opts.Aggregation == "" {
+		return "mean"
+	}
+	return *opts.Aggregation
+}
+
+// AggregateMetricMeasures returns the measures of the metrics with the given
+// IDs, aggregated across them (e.g. the average CPU utilisation of a group of
+// instances); opts can be nil; see also
+// https://gnocchi.osci.io/rest.html#dynamic-aggregates
+func (api *MetricV1API) AggregateMetricMeasures(metricids []string, opts *AggregateMeasuresOptions) (*[]Measure, *Result, error) {
+	if len(metricids) == 0 {
+		log.Errorf("no metrics to aggregate")
+		return nil, nil, fmt.Errorf("no metrics to aggregate")
+	}
+	aggregation := opts.aggregation()
+	references := []string{}
+	for _, metricid := range metricids {
+		references = append(references, fmt.Sprintf("(%s %s)", metricid, aggregation))
+	}
+	operations := fmt.Sprintf("(aggregate %s (metric %s))", aggregation, strings.Join(references, " "))
+	return api.aggregateMeasures(operations, "", nil, opts)
+}
+
+// AggregateResourceMeasures returns the measures of the metric with the given
+// name of all the resources of the given type ("generic" if empty) matching
+// the given search filter (e.g. {"=": {"server_group": "web"}} or the
+// equivalent "server_group='web'"), aggregated across them; opts can be nil;
+// see also
+// https://gnocchi.osci.io/rest.html#dynamic-aggregates
+func (api *MetricV1API) AggregateResourceMeasures(resourcetype string, search interface{}, metric string, opts *AggregateMeasuresOptions) (*[]Measure, *Result, error) {
+	aggregation := opts.aggregation()
+	operations := fmt.Sprintf("(aggregate %s (metric %s %s))", aggregation, metric, aggregation)
+	return api.aggregateMeasures(operations, metricResourceType(resourcetype), search, opts)
+}
+
+// aggregateMeasures evaluates the given operations, which must have an
+// aggregate at the top level, over the metrics given in the operations or
+// over those of the resources of the given type matching the search filter.
+func (api *MetricV1API) aggregateMeasures(operations string, resourcetype string, search interface{}, opts *AggregateMeasuresOptions) (*[]Measure, *Result, error) {
+	if opts == nil {
+		opts = &AggregateMeasuresOptions{}
+	}
+	input := &struct {
+		AggregateMeasuresOptions
+		Operations   string      `parameter:"-" header:"-" json:"operations"`
+		ResourceType string      `parameter:"-" header:"-" json:"resource_type,omitempty"`
+		Search       interface{} `parameter:"-" header:"-" json:"search,omitempty"`
+	}{
+		AggregateMeasuresOptions: *opts,
+		Operations:               operations,
+		ResourceType:             resourcetype,
+		Search:                   search,
+	}
+	output := &struct {
+		Measures struct {
+			Aggregated []Measure `json:"aggregated"`
+		} `json:"measures"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPost, "./v1/aggregates", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &output.Measures.Aggregated, result, err
+	}
+	return nil, result, err
+}
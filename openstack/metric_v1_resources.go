@@ -0,0 +1,220 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// metricResourceReference identifies a resource by its type and ID in the
+// path.
+type metricResourceReference struct {
+	Type string `parameter:"-" header:"-" variable:"type" json:"-"`
+	ID   string `parameter:"-" header:"-" variable:"id" json:"-"`
+}
+
+/*
+ * LIST RESOURCES
+ */
+
+// ListMetricResourcesOptions provides the options available for listing the
+// resources; Limit sets the page size, all pages are retrieved anyway; Sort
+// is a "key:direction" pair (e.g. "started_at:desc"); Details includes the
+// attributes specific to the resource type.
+type ListMetricResourcesOptions struct {
+	Details *bool   `parameter:"details,omitempty" header:"-" json:"-"`
+	History *bool   `parameter:"history,omitempty" header:"-" json:"-"`
+	Sort    *string `parameter:"sort,omitempty" header:"-" json:"-"`
+	Limit   *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker  *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListMetricResources returns the list of resources of the given type (all
+// resources if the type is empty or "generic"); opts can be nil; see also
+// https://gnocchi.osci.io/rest.html#resources
+func (api *MetricV1API) ListMetricResources(resourcetype string, opts *ListMetricResourcesOptions) (*[]MetricResource, *Result, error) {
+	if opts == nil {
+		opts = &ListMetricResourcesOptions{}
+	}
+	input := &struct {
+		Type string `parameter:"-" header:"-" variable:"type" json:"-"`
+		ListMetricResourcesOptions
+	}{
+		Type:                       metricResourceType(resourcetype),
+		ListMetricResourcesOptions: *opts,
+	}
+
+	resources := []MetricResource{}
+	for {
+		page := []MetricResource{}
+		result, err := api.Invoke(http.MethodGet, "./v1/resource/{type}", true, StatusCodeIn(200), input, &bareList{items: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		resources = append(resources, page...)
+		if input.Limit == nil || len(page) < *input.Limit || len(page) == 0 {
+			return &resources, result, err
+		}
+		// the next page starts after the last resource of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE RESOURCE
+ */
+
+// CreateMetricResourceOptions provides the options available for creating a
+// resource: ID is required and, if it is not a UUID, it is mapped to one and
+// kept as the original resource ID; Metrics maps the names of the metrics to
+// create along with the resource to their definitions (e.g.
+// {"archive_policy_name": "low"}) or to the IDs of existing metrics;
+// Attributes are the attributes specific to the resource type.
+type CreateMetricResourceOptions struct {
+	ID         string                  `json:"id"`
+	ProjectID  *string                 `json:"project_id,omitempty"`
+	UserID     *string                 `json:"user_id,omitempty"`
+	StartedAt  *string                 `json:"started_at,omitempty"`
+	EndedAt    *string                 `json:"ended_at,omitempty"`
+	Metrics    *map[string]interface{} `json:"metrics,omitempty"`
+	Attributes map[string]interface{}  `json:"-"`
+}
+
+// createMetricResourceOptions has the same fields as
+// CreateMetricResourceOptions, but none of its methods.
+type createMetricResourceOptions CreateMetricResourceOptions
+
+// MarshalJSON encodes the options, with the attributes at the same level as
+// the other fields.
+func (opts CreateMetricResourceOptions) MarshalJSON() ([]byte, error) {
+	return marshalMetricResourceAttributes(createMetricResourceOptions(opts), opts.Attributes)
+}
+
+// CreateMetricResource creates a resource of the given type ("generic" if
+// empty); see also
+// https://gnocchi.osci.io/rest.html#resources
+func (api *MetricV1API) CreateMetricResource(resourcetype string, opts *CreateMetricResourceOptions) (*MetricResource, *Result, error) {
+	input := &struct {
+		Type string `parameter:"-" header:"-" variable:"type" json:"-"`
+		CreateMetricResourceOptions
+	}{
+		Type:                        metricResourceType(resourcetype),
+		CreateMetricResourceOptions: *opts,
+	}
+	resource := &MetricResource{}
+
+	result, err := api.Invoke(http.MethodPost, "./v1/resource/{type}", true, StatusCodeIn(201), input, resource, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return resource, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE RESOURCE
+ */
+
+// RetrieveMetricResource retrieves the resource of the given type ("generic"
+// if empty) with the given ID; see also
+// https://gnocchi.osci.io/rest.html#resources
+func (api *MetricV1API) RetrieveMetricResource(resourcetype string, resourceid string) (*MetricResource, *Result, error) {
+	input := &metricResourceReference{Type: metricResourceType(resourcetype), ID: resourceid}
+	resource := &MetricResource{}
+
+	result, err := api.Invoke(http.MethodGet, "./v1/resource/{type}/{id}", true, StatusCodeIn(200), input, resource, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return resource, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE RESOURCE
+ */
+
+// UpdateMetricResourceOptions provides the options available for updating a
+// resource; only the given attributes are modified; the metrics in Metrics
+// are added to the resource.
+type UpdateMetricResourceOptions struct {
+	EndedAt    *string                 `json:"ended_at,omitempty"`
+	Metrics    *map[string]interface{} `json:"metrics,omitempty"`
+	Attributes map[string]interface{}  `json:"-"`
+}
+
+// updateMetricResourceOptions has the same fields as
+// UpdateMetricResourceOptions, but none of its methods.
+type updateMetricResourceOptions UpdateMetricResourceOptions
+
+// MarshalJSON encodes the options, with the attributes at the same level as
+// the other fields.
+func (opts UpdateMetricResourceOptions) MarshalJSON() ([]byte, error) {
+	return marshalMetricResourceAttributes(updateMetricResourceOptions(opts), opts.Attributes)
+}
+
+// UpdateMetricResource updates the resource of the given type ("generic" if
+// empty) with the given ID; see also
+// https://gnocchi.osci.io/rest.html#resources
+func (api *MetricV1API) UpdateMetricResource(resourcetype string, resourceid string, opts *UpdateMetricResourceOptions) (*MetricResource, *Result, error) {
+	input := &struct {
+		Type string `parameter:"-" header:"-" variable:"type" json:"-"`
+		ID   string `parameter:"-" header:"-" variable:"id" json:"-"`
+		UpdateMetricResourceOptions
+	}{
+		Type:                        metricResourceType(resourcetype),
+		ID:                          resourceid,
+		UpdateMetricResourceOptions: *opts,
+	}
+	resource := &MetricResource{}
+
+	result, err := api.Invoke(http.MethodPatch, "./v1/resource/{type}/{id}", true, StatusCodeIn(200), input, resource, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return resource, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE RESOURCE
+ */
+
+// DeleteMetricResource deletes the resource of the given type ("generic" if
+// empty) with the given ID, along with its metrics; see also
+// https://gnocchi.osci.io/rest.html#resources
+func (api *MetricV1API) DeleteMetricResource(resourcetype string, resourceid string) (bool, *Result, error) {
+	input := &metricResourceReference{Type: metricResourceType(resourcetype), ID: resourceid}
+
+	result, err := api.Invoke(http.MethodDelete, "./v1/resource/{type}/{id}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// marshalMetricResourceAttributes encodes the given options, with the given
+// attributes at the same level as the other fields.
+func marshalMetricResourceAttributes(opts interface{}, attributes map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(opts)
+	if err != nil || len(attributes) == 0 {
+		return data, err
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	for key, value := range attributes {
+		if _, ok := values[key]; !ok {
+			values[key] = value
+		}
+	}
+	return json.Marshal(values)
+}
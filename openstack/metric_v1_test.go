@@ -0,0 +1,26 @@
+package openstack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMeasureUnmarshalJSON(t *testing.T) {
+	measures := []Measure{}
+	data := `[["2020-01-01T00:00:00+00:00", 60.0, 1.5], {"timestamp": "2020-01-01T00:01:00+00:00", "value": 2}]`
+	if err := json.Unmarshal([]byte(data), &measures); err != nil {
+		t.Fatalf("Metric.TestMeasureUnmarshalJSON: unexpected error: %v", err)
+	}
+	if len(measures) != 2 {
+		t.Fatalf("Metric.TestMeasureUnmarshalJSON: expected 2 measures, got %d", len(measures))
+	}
+	if measures[0].Timestamp != "2020-01-01T00:00:00+00:00" || measures[0].Granularity == nil || *measures[0].Granularity != 60 || measures[0].Value != 1.5 {
+		t.Errorf("Metric.TestMeasureUnmarshalJSON: unexpected aggregated measure %+v", measures[0])
+	}
+	if measures[1].Timestamp != "2020-01-01T00:01:00+00:00" || measures[1].Granularity != nil || measures[1].Value != 2 {
+		t.Errorf("Metric.TestMeasureUnmarshalJSON: unexpected raw measure %+v", measures[1])
+	}
+	if err := json.Unmarshal([]byte(`["2020-01-01T00:00:00+00:00", 1.5]`), &Measure{}); err == nil {
+		t.Errorf("Metric.TestMeasureUnmarshalJSON: expected error for a measure with 2 items")
+	}
+}
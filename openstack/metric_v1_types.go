@@ -0,0 +1,137 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+/*
+ * RESOURCES
+ */
+
+// MetricResource is a Gnocchi resource (e.g. an instance or a volume), which
+// groups the metrics measured on it under their names (Metrics maps names to
+// metric IDs); the attributes specific to the resource type (e.g. "host" or
+// "flavor_id" for instances) are collected in Attributes.
+type MetricResource struct {
+	ID                 *string                `json:"id,omitempty"`
+	Type               *string                `json:"type,omitempty"`
+	OriginalResourceID *string                `json:"original_resource_id,omitempty"`
+	ProjectID          *string                `json:"project_id,omitempty"`
+	UserID             *string                `json:"user_id,omitempty"`
+	CreatedByProjectID *string                `json:"created_by_project_id,omitempty"`
+	CreatedByUserID    *string                `json:"created_by_user_id,omitempty"`
+	Creator            *string                `json:"creator,omitempty"`
+	StartedAt          *string                `json:"started_at,omitempty"`
+	EndedAt            *string                `json:"ended_at,omitempty"`
+	RevisionStart      *string                `json:"revision_start,omitempty"`
+	RevisionEnd        *string                `json:"revision_end,omitempty"`
+	Metrics            *map[string]string     `json:"metrics,omitempty"`
+	Attributes         map[string]interface{} `json:"-"`
+}
+
+// metricResource has the same fields as MetricResource, but none of its
+// methods.
+type metricResource MetricResource
+
+var metricResourceFields = jsonFieldNames(reflect.TypeOf(MetricResource{}))
+
+// UnmarshalJSON decodes the resource, collecting the attributes that do not
+// match any field of MetricResource into Attributes.
+func (r *MetricResource) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*metricResource)(r)); err != nil {
+		return err
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	r.Attributes = nil
+	for key, value := range values {
+		if !metricResourceFields[key] {
+			if r.Attributes == nil {
+				r.Attributes = map[string]interface{}{}
+			}
+			r.Attributes[key] = value
+		}
+	}
+	return nil
+}
+
+/*
+ * METRICS
+ */
+
+// Metric is a time series of measures, stored and aggregated as per its
+// archive policy; it may belong to a resource, under a name.
+type Metric struct {
+	ID                 *string              `json:"id,omitempty"`
+	Name               *string              `json:"name,omitempty"`
+	Unit               *string              `json:"unit,omitempty"`
+	ResourceID         *string              `json:"resource_id,omitempty"`
+	ArchivePolicyName  *string              `json:"archive_policy_name,omitempty"`
+	ArchivePolicy      *MetricArchivePolicy `json:"archive_policy,omitempty"`
+	CreatedByProjectID *string              `json:"created_by_project_id,omitempty"`
+	CreatedByUserID    *string              `json:"created_by_user_id,omitempty"`
+	Creator            *string              `json:"creator,omitempty"`
+}
+
+// MetricArchivePolicy defines how the measures of a metric are aggregated
+// (AggregationMethods, e.g. "mean" or "max") and for how long they are kept at
+// each granularity (Definition).
+type MetricArchivePolicy struct {
+	Name               *string                          `json:"name,omitempty"`
+	BackWindow         *int                             `json:"back_window,omitempty"`
+	AggregationMethods *[]string                        `json:"aggregation_methods,omitempty"`
+	Definition         *[]MetricArchivePolicyDefinition `json:"definition,omitempty"`
+}
+
+// MetricArchivePolicyDefinition is the number of points kept by an archive
+// policy at a given granularity, and the timespan they cover.
+type MetricArchivePolicyDefinition struct {
+	Granularity *string `json:"granularity,omitempty"`
+	Points      *int    `json:"points,omitempty"`
+	Timespan    *string `json:"timespan,omitempty"`
+}
+
+/*
+ * MEASURES
+ */
+
+// Measure is a value at a given time (an ISO 8601 timestamp); aggregated
+// measures also have the granularity (in seconds) they have been aggregated
+// at, and are returned by Gnocchi as [timestamp, granularity, value] arrays.
+type Measure struct {
+	Timestamp   string   `json:"timestamp"`
+	Granularity *float64 `json:"granularity,omitempty"`
+	Value       float64  `json:"value"`
+}
+
+// UnmarshalJSON decodes a measure, either as an object or as an array of the
+// timestamp, the granularity and the value.
+func (m *Measure) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || data[0] != '[' {
+		type measure Measure
+		return json.Unmarshal(data, (*measure)(m))
+	}
+	values := []json.RawMessage{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if len(values) != 3 {
+		return fmt.Errorf("invalid measure: %s", data)
+	}
+	*m = Measure{}
+	if err := json.Unmarshal(values[0], &m.Timestamp); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(values[1], &m.Granularity); err != nil {
+		return err
+	}
+	return json.Unmarshal(values[2], &m.Value)
+}
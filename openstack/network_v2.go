@@ -0,0 +1,13 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+// NetworkV2API represents the networking API ver. 2.0 (Neutron), providing
+// support for the management of networks, subnets, ports, routers, floating IPs
+// and security groups, and of the service plugins that extend them.
+// See https://developer.openstack.org/api-ref/network/v2/
+type NetworkV2API struct {
+	API
+}
@@ -0,0 +1,170 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST ADDRESS SCOPES
+ */
+
+// ListAddressScopesOptions provides the options available for filtering the
+// list of address scopes; Limit sets the page size, all pages are retrieved
+// anyway.
+type ListAddressScopesOptions struct {
+	ID        *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name      *string `parameter:"name,omitempty" header:"-" json:"-"`
+	IPVersion *int    `parameter:"ip_version,omitempty" header:"-" json:"-"`
+	Shared    *bool   `parameter:"shared,omitempty" header:"-" json:"-"`
+	ProjectID *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey   *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir   *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListAddressScopes returns the list of address scopes; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-address-scopes
+func (api *NetworkV2API) ListAddressScopes(opts *ListAddressScopesOptions) (*[]AddressScope, *Result, error) {
+	scopes := []AddressScope{}
+	result, err := api.listResources("./v2.0/address-scopes", "address_scopes", opts, collectionLinks,
+		func() interface{} { return &[]AddressScope{} },
+		func(page interface{}) { scopes = append(scopes, *page.(*[]AddressScope)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &scopes, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE ADDRESS SCOPE
+ */
+
+// CreateAddressScopeOptions provides the options available for creating an
+// address scope: Name and IPVersion (4 or 6) are mandatory; only administrators
+// can create shared scopes.
+type CreateAddressScopeOptions struct {
+	Name      *string `json:"name,omitempty"`
+	IPVersion *int    `json:"ip_version,omitempty"`
+	Shared    *bool   `json:"shared,omitempty"`
+	ProjectID *string `json:"project_id,omitempty"`
+}
+
+// CreateAddressScope creates a new address scope; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-an-address-scope
+func (api *NetworkV2API) CreateAddressScope(opts *CreateAddressScopeOptions) (*AddressScope, *Result, error) {
+	scope := &AddressScope{}
+	result, err := api.createResource("./v2.0/address-scopes", "address_scope", opts, scope)
+	if result != nil && result.Code == 201 {
+		return scope, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE ADDRESS SCOPE
+ */
+
+// RetrieveAddressScope retrieves the details of the address scope identified by
+// the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-address-scope
+func (api *NetworkV2API) RetrieveAddressScope(scopeid string) (*AddressScope, *Result, error) {
+	scope := &AddressScope{}
+	result, err := api.retrieveResource("./v2.0/address-scopes/{id}", scopeid, "address_scope", scope)
+	if result != nil && result.Code == 200 {
+		return scope, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE ADDRESS SCOPE
+ */
+
+// UpdateAddressScopeOptions provides the options available for updating an
+// address scope; a shared scope cannot be made private again.
+type UpdateAddressScopeOptions struct {
+	Name   *string `json:"name,omitempty"`
+	Shared *bool   `json:"shared,omitempty"`
+}
+
+// UpdateAddressScope updates the address scope identified by the given id; see
+// also https://developer.openstack.org/api-ref/network/v2/#update-an-address-scope
+func (api *NetworkV2API) UpdateAddressScope(scopeid string, opts *UpdateAddressScopeOptions) (*AddressScope, *Result, error) {
+	scope := &AddressScope{}
+	result, err := api.updateResource("./v2.0/address-scopes/{id}", scopeid, "address_scope", opts, scope)
+	if result != nil && result.Code == 200 {
+		return scope, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE ADDRESS SCOPE
+ */
+
+// DeleteAddressScope deletes the address scope identified by the given id; it
+// fails if subnet pools are still associated with it; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-an-address-scope
+func (api *NetworkV2API) DeleteAddressScope(scopeid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/address-scopes/{id}", scopeid)
+}
+
+/*
+ * SUBNET POOLS ASSOCIATION
+ */
+
+// ListSubnetPoolsOptions provides the options available for filtering the list
+// of subnet pools; Limit sets the page size, all pages are retrieved anyway.
+type ListSubnetPoolsOptions struct {
+	ID             *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name           *string `parameter:"name,omitempty" header:"-" json:"-"`
+	AddressScopeID *string `parameter:"address_scope_id,omitempty" header:"-" json:"-"`
+	IPVersion      *int    `parameter:"ip_version,omitempty" header:"-" json:"-"`
+	Shared         *bool   `parameter:"shared,omitempty" header:"-" json:"-"`
+	IsDefault      *bool   `parameter:"is_default,omitempty" header:"-" json:"-"`
+	ProjectID      *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey        *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir        *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit          *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker         *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListSubnetPools returns the list of subnet pools, e.g. those associated with
+// an address scope; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-subnet-pools
+func (api *NetworkV2API) ListSubnetPools(opts *ListSubnetPoolsOptions) (*[]SubnetPool, *Result, error) {
+	pools := []SubnetPool{}
+	result, err := api.listResources("./v2.0/subnetpools", "subnetpools", opts, collectionLinks,
+		func() interface{} { return &[]SubnetPool{} },
+		func(page interface{}) { pools = append(pools, *page.(*[]SubnetPool)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &pools, result, err
+	}
+	return nil, result, err
+}
+
+// SetSubnetPoolAddressScope associates the subnet pool identified by the given
+// id with the given address scope, or removes it from its address scope if
+// scopeid is nil; the pool prefixes must not overlap with those of the other
+// pools in the scope, and the IP versions must match; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-subnet-pool
+func (api *NetworkV2API) SetSubnetPoolAddressScope(poolid string, scopeid *string) (*SubnetPool, *Result, error) {
+	body := &struct {
+		AddressScopeID *string `json:"address_scope_id"`
+	}{
+		AddressScopeID: scopeid,
+	}
+	pool := &SubnetPool{}
+	result, err := api.updateResource("./v2.0/subnetpools/{id}", poolid, "subnetpool", body, pool)
+	if result != nil && result.Code == 200 {
+		return pool, result, err
+	}
+	return nil, result, err
+}
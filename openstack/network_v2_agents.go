@@ -0,0 +1,224 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST AGENTS
+ */
+
+// ListAgentsOptions provides the options available for filtering the list of
+// Networking agents.
+type ListAgentsOptions struct {
+	ID               *string `parameter:"id,omitempty" header:"-" json:"-"`
+	AgentType        *string `parameter:"agent_type,omitempty" header:"-" json:"-"`
+	Binary           *string `parameter:"binary,omitempty" header:"-" json:"-"`
+	Host             *string `parameter:"host,omitempty" header:"-" json:"-"`
+	Topic            *string `parameter:"topic,omitempty" header:"-" json:"-"`
+	AdminStateUp     *bool   `parameter:"admin_state_up,omitempty" header:"-" json:"-"`
+	Alive            *bool   `parameter:"alive,omitempty" header:"-" json:"-"`
+	AvailabilityZone *string `parameter:"availability_zone,omitempty" header:"-" json:"-"`
+	SortKey          *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir          *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit            *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker           *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListAgents returns the list of Networking agents (e.g. the Open vSwitch, DHCP
+// and L3 agents on each host), with their liveness; it requires administrative
+// privileges; see also https://developer.openstack.org/api-ref/network/v2/#list-all-agents
+func (api *NetworkV2API) ListAgents(opts *ListAgentsOptions) (*[]Agent, *Result, error) {
+	agents := []Agent{}
+	result, err := api.listResources("./v2.0/agents", "agents", opts, collectionLinks,
+		func() interface{} { return &[]Agent{} },
+		func(page interface{}) { agents = append(agents, *page.(*[]Agent)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &agents, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE AGENT
+ */
+
+// RetrieveAgent retrieves the details of the Networking agent identified by the
+// given id; see also https://developer.openstack.org/api-ref/network/v2/#show-agent-details
+func (api *NetworkV2API) RetrieveAgent(agentid string) (*Agent, *Result, error) {
+	agent := &Agent{}
+	result, err := api.retrieveResource("./v2.0/agents/{id}", agentid, "agent", agent)
+	if result != nil && result.Code == 200 {
+		return agent, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE AGENT
+ */
+
+// UpdateAgentOptions provides the options available for updating a Networking
+// agent: an agent whose admin state is down is not scheduled new resources.
+type UpdateAgentOptions struct {
+	AdminStateUp *bool   `json:"admin_state_up,omitempty"`
+	Description  *string `json:"description,omitempty"`
+}
+
+// UpdateAgent updates the Networking agent identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-agent
+func (api *NetworkV2API) UpdateAgent(agentid string, opts *UpdateAgentOptions) (*Agent, *Result, error) {
+	agent := &Agent{}
+	result, err := api.updateResource("./v2.0/agents/{id}", agentid, "agent", opts, agent)
+	if result != nil && result.Code == 200 {
+		return agent, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE AGENT
+ */
+
+// DeleteAgent deletes the Networking agent identified by the given id, e.g. an
+// agent that is no longer alive after its host has been decommissioned; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-agent
+func (api *NetworkV2API) DeleteAgent(agentid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/agents/{id}", agentid)
+}
+
+/*
+ * DHCP AGENT SCHEDULING
+ */
+
+// ListDHCPAgentNetworks returns the networks hosted by the DHCP agent identified
+// by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-networks-hosted-by-a-dhcp-agent
+func (api *NetworkV2API) ListDHCPAgentNetworks(agentid string) (*[]Network, *Result, error) {
+	networks := &[]Network{}
+	result, err := api.retrieveResource("./v2.0/agents/{id}/dhcp-networks", agentid, "networks", networks)
+	if result != nil && result.Code == 200 {
+		return networks, result, err
+	}
+	return nil, result, err
+}
+
+// AddNetworkToDHCPAgent schedules the network identified by the given id on the
+// given DHCP agent; see also
+// https://developer.openstack.org/api-ref/network/v2/#schedule-a-network-to-a-dhcp-agent
+func (api *NetworkV2API) AddNetworkToDHCPAgent(agentid string, networkid string) (bool, *Result, error) {
+	input := &struct {
+		AgentID   string `parameter:"-" header:"-" variable:"agentid" json:"-"`
+		NetworkID string `parameter:"-" header:"-" json:"network_id"`
+	}{
+		AgentID:   agentid,
+		NetworkID: networkid,
+	}
+
+	result, err := api.Invoke(http.MethodPost, "./v2.0/agents/{agentid}/dhcp-networks", true, StatusCodeIn(201), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// RemoveNetworkFromDHCPAgent removes the network identified by the given id from
+// the given DHCP agent; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-network-from-a-dhcp-agent
+func (api *NetworkV2API) RemoveNetworkFromDHCPAgent(agentid string, networkid string) (bool, *Result, error) {
+	return api.removeFromAgent(agentid, "dhcp-networks", networkid)
+}
+
+// ListNetworkDHCPAgents returns the DHCP agents hosting the network identified
+// by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-dhcp-agents-hosting-a-network
+func (api *NetworkV2API) ListNetworkDHCPAgents(networkid string) (*[]Agent, *Result, error) {
+	agents := &[]Agent{}
+	result, err := api.retrieveResource("./v2.0/networks/{id}/dhcp-agents", networkid, "agents", agents)
+	if result != nil && result.Code == 200 {
+		return agents, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * L3 AGENT SCHEDULING
+ */
+
+// ListL3AgentRouters returns the routers hosted by the L3 agent identified by
+// the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-routers-hosted-by-an-l3-agent
+func (api *NetworkV2API) ListL3AgentRouters(agentid string) (*[]Router, *Result, error) {
+	routers := &[]Router{}
+	result, err := api.retrieveResource("./v2.0/agents/{id}/l3-routers", agentid, "routers", routers)
+	if result != nil && result.Code == 200 {
+		return routers, result, err
+	}
+	return nil, result, err
+}
+
+// AddRouterToL3Agent schedules the router identified by the given id on the
+// given L3 agent; see also
+// https://developer.openstack.org/api-ref/network/v2/#schedule-router-to-an-l3-agent
+func (api *NetworkV2API) AddRouterToL3Agent(agentid string, routerid string) (bool, *Result, error) {
+	input := &struct {
+		AgentID  string `parameter:"-" header:"-" variable:"agentid" json:"-"`
+		RouterID string `parameter:"-" header:"-" json:"router_id"`
+	}{
+		AgentID:  agentid,
+		RouterID: routerid,
+	}
+
+	result, err := api.Invoke(http.MethodPost, "./v2.0/agents/{agentid}/l3-routers", true, StatusCodeIn(201), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// RemoveRouterFromL3Agent removes the router identified by the given id from the
+// given L3 agent; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-l3-router-from-an-l3-agent
+func (api *NetworkV2API) RemoveRouterFromL3Agent(agentid string, routerid string) (bool, *Result, error) {
+	return api.removeFromAgent(agentid, "l3-routers", routerid)
+}
+
+// ListRouterL3Agents returns the L3 agents hosting the router identified by the
+// given id (more than one for HA routers); see also
+// https://developer.openstack.org/api-ref/network/v2/#list-l3-agents-hosting-a-router
+func (api *NetworkV2API) ListRouterL3Agents(routerid string) (*[]Agent, *Result, error) {
+	agents := &[]Agent{}
+	result, err := api.retrieveResource("./v2.0/routers/{id}/l3-agents", routerid, "agents", agents)
+	if result != nil && result.Code == 200 {
+		return agents, result, err
+	}
+	return nil, result, err
+}
+
+// removeFromAgent removes the resource with the given id from the given
+// scheduling collection (e.g. "l3-routers") of an agent.
+func (api *NetworkV2API) removeFromAgent(agentid string, collection string, resourceid string) (bool, *Result, error) {
+	input := &struct {
+		AgentID    string `parameter:"-" header:"-" variable:"agentid" json:"-"`
+		ResourceID string `parameter:"-" header:"-" variable:"resourceid" json:"-"`
+	}{
+		AgentID:    agentid,
+		ResourceID: resourceid,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v2.0/agents/{agentid}/"+collection+"/{resourceid}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,67 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * RETRIEVE AUTO-ALLOCATED TOPOLOGY
+ */
+
+// RetrieveAutoAllocatedTopology returns the network automatically allocated to
+// the project identified by the given id, creating it (along with a subnet from
+// the default subnet pool and a router to the default external network) if it
+// does not exist yet; the returned ID is that of the network, which can then be
+// used to boot servers ("get me a network"); see also
+// https://developer.openstack.org/api-ref/network/v2/#show-auto-allocated-topology-details
+func (api *NetworkV2API) RetrieveAutoAllocatedTopology(projectid string) (*AutoAllocatedTopology, *Result, error) {
+	topology := &AutoAllocatedTopology{}
+	result, err := api.retrieveResource("./v2.0/auto-allocated-topology/{id}", projectid, "auto_allocated_topology", topology)
+	if result != nil && result.Code == 200 {
+		return topology, result, err
+	}
+	return nil, result, err
+}
+
+// ValidateAutoAllocatedTopology checks whether the requirements for the
+// automatic allocation of a network to the project identified by the given id
+// are met (i.e. a default external network and default subnet pools exist),
+// without allocating anything; the reason of a failure is in the result.
+func (api *NetworkV2API) ValidateAutoAllocatedTopology(projectid string) (bool, *Result, error) {
+	input := &struct {
+		ProjectID string `parameter:"-" header:"-" variable:"projectid" json:"-"`
+		Fields    string `parameter:"fields" header:"-" json:"-"`
+	}{
+		ProjectID: projectid,
+		Fields:    "dry-run",
+	}
+	output := &struct {
+		Topology *struct {
+			DryRun *string `json:"dry-run,omitempty"`
+		} `header:"-" json:"auto_allocated_topology,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2.0/auto-allocated-topology/{projectid}", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 && output.Topology != nil {
+		return stringValue(output.Topology.DryRun) == "pass", result, err
+	}
+	return false, result, err
+}
+
+/*
+ * DELETE AUTO-ALLOCATED TOPOLOGY
+ */
+
+// DeleteAutoAllocatedTopology deletes the network, subnets and router
+// automatically allocated to the project identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-the-auto-allocated-topology
+func (api *NetworkV2API) DeleteAutoAllocatedTopology(projectid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/auto-allocated-topology/{id}", projectid)
+}
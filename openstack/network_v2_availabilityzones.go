@@ -0,0 +1,38 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST AVAILABILITY ZONES
+ */
+
+// ListNetworkAvailabilityZonesOptions provides the options available for
+// filtering the list of Networking availability zones: Resource is either
+// "network" or "router", State either "available" or "unavailable".
+type ListNetworkAvailabilityZonesOptions struct {
+	Name     *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Resource *string `parameter:"resource,omitempty" header:"-" json:"-"`
+	State    *string `parameter:"state,omitempty" header:"-" json:"-"`
+}
+
+// ListNetworkAvailabilityZones returns the availability zones of the Networking
+// service, which can be given as hints when creating networks and routers, so
+// that their DHCP and L3 agents are spread across zones; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-all-availability-zones
+func (api *NetworkV2API) ListNetworkAvailabilityZones(opts *ListNetworkAvailabilityZonesOptions) (*[]NetworkAvailabilityZone, *Result, error) {
+	zones := []NetworkAvailabilityZone{}
+	result, err := api.listResources("./v2.0/availability_zones", "availability_zones", opts, collectionLinks,
+		func() interface{} { return &[]NetworkAvailabilityZone{} },
+		func(page interface{}) { zones = append(zones, *page.(*[]NetworkAvailabilityZone)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &zones, result, err
+	}
+	return nil, result, err
+}
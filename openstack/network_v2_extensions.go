@@ -0,0 +1,97 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// NetworkExtensionTrunk is the alias of the trunk ports extension.
+	NetworkExtensionTrunk = "trunk"
+	// NetworkExtensionQoS is the alias of the quality of service extension.
+	NetworkExtensionQoS = "qos"
+	// NetworkExtensionFWaaSV2 is the alias of the firewall (v2) extension.
+	NetworkExtensionFWaaSV2 = "fwaas_v2"
+	// NetworkExtensionVPNaaS is the alias of the VPN extension.
+	NetworkExtensionVPNaaS = "vpnaas"
+	// NetworkExtensionTags is the alias of the resource tags extension.
+	NetworkExtensionTags = "standard-attr-tag"
+	// NetworkExtensionPortBindings is the alias of the extended port bindings
+	// extension, providing the bindings sub-resource of ports.
+	NetworkExtensionPortBindings = "binding-extended"
+	// NetworkExtensionAllowedAddressPairs is the alias of the allowed address
+	// pairs extension.
+	NetworkExtensionAllowedAddressPairs = "allowed-address-pairs"
+	// NetworkExtensionAddressScope is the alias of the address scopes extension.
+	NetworkExtensionAddressScope = "address-scope"
+	// NetworkExtensionAvailabilityZone is the alias of the availability zones
+	// extension.
+	NetworkExtensionAvailabilityZone = "availability_zone"
+	// NetworkExtensionAutoAllocatedTopology is the alias of the auto-allocated
+	// topology extension.
+	NetworkExtensionAutoAllocatedTopology = "auto-allocated-topology"
+	// NetworkExtensionRevisionIfMatch is the alias of the extension allowing
+	// conditional updates on the revision number of resources.
+	NetworkExtensionRevisionIfMatch = "revision-if-match"
+)
+
+/*
+ * LIST EXTENSIONS
+ */
+
+// ListExtensions returns the list of the extensions enabled on the Networking
+// service; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-extensions
+func (api *NetworkV2API) ListExtensions() (*[]NetworkExtension, *Result, error) {
+	output := &struct {
+		Extensions *[]NetworkExtension `header:"-" json:"extensions,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2.0/extensions", true, StatusCodeIn(200), nil, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output.Extensions, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE EXTENSION
+ */
+
+// RetrieveExtension retrieves the details of the extension with the given alias;
+// see also https://developer.openstack.org/api-ref/network/v2/#show-extension-details
+func (api *NetworkV2API) RetrieveExtension(alias string) (*NetworkExtension, *Result, error) {
+	extension := &NetworkExtension{}
+	result, err := api.retrieveResource("./v2.0/extensions/{id}", alias, "extension", extension)
+	if result != nil && result.Code == 200 {
+		return extension, result, err
+	}
+	return nil, result, err
+}
+
+// HasExtension checks whether the Networking service has the extension with the
+// given alias (e.g. NetworkExtensionTrunk) enabled, so that callers can detect
+// optional features before using them.
+func (api *NetworkV2API) HasExtension(alias string) (bool, error) {
+	extensions, result, err := api.ListExtensions()
+	if err != nil {
+		log.Errorf("error listing Networking extensions: %v", err)
+		return false, err
+	}
+	if extensions == nil {
+		log.Warnf("no Networking extensions available: %v", result)
+		return false, nil
+	}
+	for _, extension := range *extensions {
+		if stringValue(extension.Alias) == alias {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"fmt"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST FLOATING IPS
+ */
+
+// ListFloatingIPsOptions provides the options available for filtering the list
+// of floating IPs; Limit sets the page size, all pages are retrieved anyway.
+type ListFloatingIPsOptions struct {
+	ID                *string `parameter:"id,omitempty" header:"-" json:"-"`
+	FloatingIPAddress *string `parameter:"floating_ip_address,omitempty" header:"-" json:"-"`
+	FloatingNetworkID *string `parameter:"floating_network_id,omitempty" header:"-" json:"-"`
+	FixedIPAddress    *string `parameter:"fixed_ip_address,omitempty" header:"-" json:"-"`
+	PortID            *string `parameter:"port_id,omitempty" header:"-" json:"-"`
+	RouterID          *string `parameter:"router_id,omitempty" header:"-" json:"-"`
+	Status            *string `parameter:"status,omitempty" header:"-" json:"-"`
+	Description       *string `parameter:"description,omitempty" header:"-" json:"-"`
+	ProjectID         *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey           *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir           *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit             *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker            *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListFloatingIPs returns the list of floating IPs; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-floating-ips
+func (api *NetworkV2API) ListFloatingIPs(opts *ListFloatingIPsOptions) (*[]FloatingIP, *Result, error) {
+	fips := []FloatingIP{}
+	result, err := api.listResources("./v2.0/floatingips", "floatingips", opts, collectionLinks,
+		func() interface{} { return &[]FloatingIP{} },
+		func(page interface{}) { fips = append(fips, *page.(*[]FloatingIP)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &fips, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE FLOATING IP
+ */
+
+// CreateFloatingIPOptions provides the options available for creating a floating
+// IP; FloatingNetworkID (the external network) is mandatory; a specific address
+// or subnet can be requested; if PortID is given the floating IP is immediately
+// associated to the port (to FixedIPAddress if the port has more than one).
+type CreateFloatingIPOptions struct {
+	FloatingNetworkID *string `json:"floating_network_id,omitempty"`
+	FloatingIPAddress *string `json:"floating_ip_address,omitempty"`
+	SubnetID          *string `json:"subnet_id,omitempty"`
+	PortID            *string `json:"port_id,omitempty"`
+	FixedIPAddress    *string `json:"fixed_ip_address,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	ProjectID         *string `json:"project_id,omitempty"`
+	DNSDomain         *string `json:"dns_domain,omitempty"`
+	DNSName           *string `json:"dns_name,omitempty"`
+	QoSPolicyID       *string `json:"qos_policy_id,omitempty"`
+}
+
+// CreateFloatingIP allocates a new floating IP from an external network; see
+// also https://developer.openstack.org/api-ref/network/v2/#create-floating-ip
+func (api *NetworkV2API) CreateFloatingIP(opts *CreateFloatingIPOptions) (*FloatingIP, *Result, error) {
+	fip := &FloatingIP{}
+	result, err := api.createResource("./v2.0/floatingips", "floatingip", opts, fip)
+	if result != nil && result.Code == 201 {
+		return fip, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE FLOATING IP
+ */
+
+// RetrieveFloatingIP retrieves the details of the floating IP identified by the
+// given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-floating-ip-details
+func (api *NetworkV2API) RetrieveFloatingIP(floatingipid string) (*FloatingIP, *Result, error) {
+	fip := &FloatingIP{}
+	result, err := api.retrieveResource("./v2.0/floatingips/{id}", floatingipid, "floatingip", fip)
+	if result != nil && result.Code == 200 {
+		return fip, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE FLOATING IP
+ */
+
+// UpdateFloatingIPOptions provides the options available for updating a
+// floating IP; use AssociateFloatingIP and DisassociateFloatingIP to change the
+// port it is associated to.
+type UpdateFloatingIPOptions struct {
+	Description *string `json:"description,omitempty"`
+	QoSPolicyID *string `json:"qos_policy_id,omitempty"`
+}
+
+// UpdateFloatingIP updates the floating IP identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-floating-ip
+func (api *NetworkV2API) UpdateFloatingIP(floatingipid string, opts *UpdateFloatingIPOptions) (*FloatingIP, *Result, error) {
+	fip := &FloatingIP{}
+	result, err := api.updateResource("./v2.0/floatingips/{id}", floatingipid, "floatingip", opts, fip)
+	if result != nil && result.Code == 200 {
+		return fip, result, err
+	}
+	return nil, result, err
+}
+
+// AssociateFloatingIP associates the floating IP to the given port, and to the
+// given fixed IP address on it if the port has more than one (fixedaddress can
+// be nil otherwise); see UpdateFloatingIP.
+func (api *NetworkV2API) AssociateFloatingIP(floatingipid string, portid string, fixedaddress *string) (*FloatingIP, *Result, error) {
+	body := &struct {
+		PortID         *string `json:"port_id"`
+		FixedIPAddress *string `json:"fixed_ip_address,omitempty"`
+	}{
+		PortID:         String(portid),
+		FixedIPAddress: fixedaddress,
+	}
+	fip := &FloatingIP{}
+	result, err := api.updateResource("./v2.0/floatingips/{id}", floatingipid, "floatingip", body, fip)
+	if result != nil && result.Code == 200 {
+		return fip, result, err
+	}
+	return nil, result, err
+}
+
+// DisassociateFloatingIP disassociates the floating IP from its port; the
+// floating IP remains allocated to the project; see UpdateFloatingIP.
+func (api *NetworkV2API) DisassociateFloatingIP(floatingipid string) (*FloatingIP, *Result, error) {
+	body := &struct {
+		PortID *string `json:"port_id"`
+	}{}
+	fip := &FloatingIP{}
+	result, err := api.updateResource("./v2.0/floatingips/{id}", floatingipid, "floatingip", body, fip)
+	if result != nil && result.Code == 200 {
+		return fip, result, err
+	}
+	return nil, result, err
+}
+
+// AssociateFloatingIPWithServer associates the floating IP to the port of the
+// given server carrying the given fixed address (or to its first port with a
+// fixed address, if fixedaddress is nil); the port is looked up through the
+// Compute service; this is the replacement for the addFloatingIp server action,
+// which is deprecated since Compute microversion 2.44.
+func (api *NetworkV2API) AssociateFloatingIPWithServer(compute *ComputeV2API, floatingipid string, serverid string, fixedaddress *string) (*FloatingIP, *Result, error) {
+	port, err := compute.FindServerPort(serverid, fixedaddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	if port == nil || port.PortID == nil {
+		log.Errorf("no suitable port found on server %q", serverid)
+		return nil, nil, fmt.Errorf("no suitable port found on server %q", serverid)
+	}
+	return api.AssociateFloatingIP(floatingipid, *port.PortID, fixedaddress)
+}
+
+/*
+ * DELETE FLOATING IP
+ */
+
+// DeleteFloatingIP releases the floating IP identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-floating-ip
+func (api *NetworkV2API) DeleteFloatingIP(floatingipid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/floatingips/{id}", floatingipid)
+}
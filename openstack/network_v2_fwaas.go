@@ -0,0 +1,431 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// FirewallActionAllow is the action of firewall rules letting traffic through.
+	FirewallActionAllow = "allow"
+	// FirewallActionDeny is the action of firewall rules silently dropping traffic.
+	FirewallActionDeny = "deny"
+	// FirewallActionReject is the action of firewall rules rejecting traffic.
+	FirewallActionReject = "reject"
+)
+
+/*
+ * LIST FIREWALL GROUPS
+ */
+
+// ListFirewallGroupsOptions provides the options available for filtering the
+// list of firewall groups; Limit sets the page size, all pages are retrieved
+// anyway.
+type ListFirewallGroupsOptions struct {
+	ID                      *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name                    *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Status                  *string `parameter:"status,omitempty" header:"-" json:"-"`
+	IngressFirewallPolicyID *string `parameter:"ingress_firewall_policy_id,omitempty" header:"-" json:"-"`
+	EgressFirewallPolicyID  *string `parameter:"egress_firewall_policy_id,omitempty" header:"-" json:"-"`
+	ProjectID               *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey                 *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir                 *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit                   *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker                  *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListFirewallGroups returns the list of firewall groups; it requires the
+// fwaas_v2 extension; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-firewall-groups
+func (api *NetworkV2API) ListFirewallGroups(opts *ListFirewallGroupsOptions) (*[]FirewallGroup, *Result, error) {
+	groups := []FirewallGroup{}
+	result, err := api.listResources("./v2.0/fwaas/firewall_groups", "firewall_groups", opts, collectionLinks,
+		func() interface{} { return &[]FirewallGroup{} },
+		func(page interface{}) { groups = append(groups, *page.(*[]FirewallGroup)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &groups, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE FIREWALL GROUP
+ */
+
+// CreateFirewallGroupOptions provides the options available for creating a
+// firewall group; the ports must be router or server ports.
+type CreateFirewallGroupOptions struct {
+	Name                    *string   `json:"name,omitempty"`
+	Description             *string   `json:"description,omitempty"`
+	AdminStateUp            *bool     `json:"admin_state_up,omitempty"`
+	IngressFirewallPolicyID *string   `json:"ingress_firewall_policy_id,omitempty"`
+	EgressFirewallPolicyID  *string   `json:"egress_firewall_policy_id,omitempty"`
+	Ports                   *[]string `json:"ports,omitempty"`
+	Shared                  *bool     `json:"shared,omitempty"`
+	ProjectID               *string   `json:"project_id,omitempty"`
+}
+
+// CreateFirewallGroup creates a new firewall group; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-firewall-group
+func (api *NetworkV2API) CreateFirewallGroup(opts *CreateFirewallGroupOptions) (*FirewallGroup, *Result, error) {
+	group := &FirewallGroup{}
+	result, err := api.createResource("./v2.0/fwaas/firewall_groups", "firewall_group", opts, group)
+	if result != nil && result.Code == 201 {
+		return group, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE FIREWALL GROUP
+ */
+
+// RetrieveFirewallGroup retrieves the details of the firewall group identified
+// by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-firewall-group-details
+func (api *NetworkV2API) RetrieveFirewallGroup(groupid string) (*FirewallGroup, *Result, error) {
+	group := &FirewallGroup{}
+	result, err := api.retrieveResource("./v2.0/fwaas/firewall_groups/{id}", groupid, "firewall_group", group)
+	if result != nil && result.Code == 200 {
+		return group, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE FIREWALL GROUP
+ */
+
+// UpdateFirewallGroupOptions provides the options available for updating a
+// firewall group; only the given attributes are modified, and Ports replaces
+// the ports in the group.
+type UpdateFirewallGroupOptions struct {
+	Name                    *string   `json:"name,omitempty"`
+	Description             *string   `json:"description,omitempty"`
+	AdminStateUp            *bool     `json:"admin_state_up,omitempty"`
+	IngressFirewallPolicyID *string   `json:"ingress_firewall_policy_id,omitempty"`
+	EgressFirewallPolicyID  *string   `json:"egress_firewall_policy_id,omitempty"`
+	Ports                   *[]string `json:"ports,omitempty"`
+	Shared                  *bool     `json:"shared,omitempty"`
+}
+
+// UpdateFirewallGroup updates the firewall group identified by the given id; see
+// also https://developer.openstack.org/api-ref/network/v2/#update-firewall-group
+func (api *NetworkV2API) UpdateFirewallGroup(groupid string, opts *UpdateFirewallGroupOptions) (*FirewallGroup, *Result, error) {
+	group := &FirewallGroup{}
+	result, err := api.updateResource("./v2.0/fwaas/firewall_groups/{id}", groupid, "firewall_group", opts, group)
+	if result != nil && result.Code == 200 {
+		return group, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE FIREWALL GROUP
+ */
+
+// DeleteFirewallGroup deletes the firewall group identified by the given id; see
+// also https://developer.openstack.org/api-ref/network/v2/#delete-firewall-group
+func (api *NetworkV2API) DeleteFirewallGroup(groupid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/fwaas/firewall_groups/{id}", groupid)
+}
+
+/*
+ * LIST FIREWALL POLICIES
+ */
+
+// ListFirewallPoliciesOptions provides the options available for filtering the
+// list of firewall policies; Limit sets the page size, all pages are retrieved
+// anyway.
+type ListFirewallPoliciesOptions struct {
+	ID        *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name      *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Audited   *bool   `parameter:"audited,omitempty" header:"-" json:"-"`
+	Shared    *bool   `parameter:"shared,omitempty" header:"-" json:"-"`
+	ProjectID *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey   *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir   *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListFirewallPolicies returns the list of firewall policies; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-firewall-policies
+func (api *NetworkV2API) ListFirewallPolicies(opts *ListFirewallPoliciesOptions) (*[]FirewallPolicy, *Result, error) {
+	policies := []FirewallPolicy{}
+	result, err := api.listResources("./v2.0/fwaas/firewall_policies", "firewall_policies", opts, collectionLinks,
+		func() interface{} { return &[]FirewallPolicy{} },
+		func(page interface{}) { policies = append(policies, *page.(*[]FirewallPolicy)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &policies, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE FIREWALL POLICY
+ */
+
+// CreateFirewallPolicyOptions provides the options available for creating a
+// firewall policy; FirewallRules is the ordered list of the rule IDs.
+type CreateFirewallPolicyOptions struct {
+	Name          *string   `json:"name,omitempty"`
+	Description   *string   `json:"description,omitempty"`
+	FirewallRules *[]string `json:"firewall_rules,omitempty"`
+	Audited       *bool     `json:"audited,omitempty"`
+	Shared        *bool     `json:"shared,omitempty"`
+	ProjectID     *string   `json:"project_id,omitempty"`
+}
+
+// CreateFirewallPolicy creates a new firewall policy; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-firewall-policy
+func (api *NetworkV2API) CreateFirewallPolicy(opts *CreateFirewallPolicyOptions) (*FirewallPolicy, *Result, error) {
+	policy := &FirewallPolicy{}
+	result, err := api.createResource("./v2.0/fwaas/firewall_policies", "firewall_policy", opts, policy)
+	if result != nil && result.Code == 201 {
+		return policy, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE FIREWALL POLICY
+ */
+
+// RetrieveFirewallPolicy retrieves the details of the firewall policy identified
+// by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-firewall-policy-details
+func (api *NetworkV2API) RetrieveFirewallPolicy(policyid string) (*FirewallPolicy, *Result, error) {
+	policy := &FirewallPolicy{}
+	result, err := api.retrieveResource("./v2.0/fwaas/firewall_policies/{id}", policyid, "firewall_policy", policy)
+	if result != nil && result.Code == 200 {
+		return policy, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE FIREWALL POLICY
+ */
+
+// UpdateFirewallPolicyOptions provides the options available for updating a
+// firewall policy; FirewallRules replaces the whole list of rules, see also
+// InsertFirewallPolicyRule and RemoveFirewallPolicyRule.
+type UpdateFirewallPolicyOptions struct {
+	Name          *string   `json:"name,omitempty"`
+	Description   *string   `json:"description,omitempty"`
+	FirewallRules *[]string `json:"firewall_rules,omitempty"`
+	Audited       *bool     `json:"audited,omitempty"`
+	Shared        *bool     `json:"shared,omitempty"`
+}
+
+// UpdateFirewallPolicy updates the firewall policy identified by the given id;
+// see also https://developer.openstack.org/api-ref/network/v2/#update-firewall-policy
+func (api *NetworkV2API) UpdateFirewallPolicy(policyid string, opts *UpdateFirewallPolicyOptions) (*FirewallPolicy, *Result, error) {
+	policy := &FirewallPolicy{}
+	result, err := api.updateResource("./v2.0/fwaas/firewall_policies/{id}", policyid, "firewall_policy", opts, policy)
+	if result != nil && result.Code == 200 {
+		return policy, result, err
+	}
+	return nil, result, err
+}
+
+// InsertFirewallPolicyRule inserts the firewall rule identified by the given id
+// in the given policy, before the rule identified by before or after the rule
+// identified by after (both can be nil, in which case the rule is inserted at
+// the top of the list), returning the updated policy; see also
+// https://developer.openstack.org/api-ref/network/v2/#insert-rule-into-a-firewall-policy
+func (api *NetworkV2API) InsertFirewallPolicyRule(policyid string, ruleid string, before *string, after *string) (*FirewallPolicy, *Result, error) {
+	input := &struct {
+		PolicyID     string  `parameter:"-" header:"-" variable:"policyid" json:"-"`
+		RuleID       string  `parameter:"-" header:"-" json:"firewall_rule_id"`
+		InsertBefore *string `parameter:"-" header:"-" json:"insert_before,omitempty"`
+		InsertAfter  *string `parameter:"-" header:"-" json:"insert_after,omitempty"`
+	}{
+		PolicyID:     policyid,
+		RuleID:       ruleid,
+		InsertBefore: before,
+		InsertAfter:  after,
+	}
+	output := &FirewallPolicy{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2.0/fwaas/firewall_policies/{policyid}/insert_rule", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+// RemoveFirewallPolicyRule removes the firewall rule identified by the given id
+// from the given policy, returning the updated policy; the rule itself is not
+// deleted; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-rule-from-firewall-policy
+func (api *NetworkV2API) RemoveFirewallPolicyRule(policyid string, ruleid string) (*FirewallPolicy, *Result, error) {
+	input := &struct {
+		PolicyID string `parameter:"-" header:"-" variable:"policyid" json:"-"`
+		RuleID   string `parameter:"-" header:"-" json:"firewall_rule_id"`
+	}{
+		PolicyID: policyid,
+		RuleID:   ruleid,
+	}
+	output := &FirewallPolicy{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2.0/fwaas/firewall_policies/{policyid}/remove_rule", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE FIREWALL POLICY
+ */
+
+// DeleteFirewallPolicy deletes the firewall policy identified by the given id;
+// it fails if the policy is in use by a firewall group; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-firewall-policy
+func (api *NetworkV2API) DeleteFirewallPolicy(policyid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/fwaas/firewall_policies/{id}", policyid)
+}
+
+/*
+ * LIST FIREWALL RULES
+ */
+
+// ListFirewallRulesOptions provides the options available for filtering the
+// list of firewall rules; Limit sets the page size, all pages are retrieved
+// anyway.
+type ListFirewallRulesOptions struct {
+	ID        *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name      *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Action    *string `parameter:"action,omitempty" header:"-" json:"-"`
+	Enabled   *bool   `parameter:"enabled,omitempty" header:"-" json:"-"`
+	Protocol  *string `parameter:"protocol,omitempty" header:"-" json:"-"`
+	IPVersion *int    `parameter:"ip_version,omitempty" header:"-" json:"-"`
+	Shared    *bool   `parameter:"shared,omitempty" header:"-" json:"-"`
+	ProjectID *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey   *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir   *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListFirewallRules returns the list of firewall rules; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-firewall-rules
+func (api *NetworkV2API) ListFirewallRules(opts *ListFirewallRulesOptions) (*[]FirewallRule, *Result, error) {
+	rules := []FirewallRule{}
+	result, err := api.listResources("./v2.0/fwaas/firewall_rules", "firewall_rules", opts, collectionLinks,
+		func() interface{} { return &[]FirewallRule{} },
+		func(page interface{}) { rules = append(rules, *page.(*[]FirewallRule)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &rules, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE FIREWALL RULE
+ */
+
+// CreateFirewallRuleOptions provides the options available for creating a
+// firewall rule; Action is one of FirewallActionAllow, FirewallActionDeny and
+// FirewallActionReject (the default is deny); ports are given as a number or
+// a range (e.g. "8000:8080").
+type CreateFirewallRuleOptions struct {
+	Name                       *string `json:"name,omitempty"`
+	Description                *string `json:"description,omitempty"`
+	Action                     *string `json:"action,omitempty"`
+	Enabled                    *bool   `json:"enabled,omitempty"`
+	IPVersion                  *int    `json:"ip_version,omitempty"`
+	Protocol                   *string `json:"protocol,omitempty"`
+	SourceIPAddress            *string `json:"source_ip_address,omitempty"`
+	DestinationIPAddress       *string `json:"destination_ip_address,omitempty"`
+	SourcePort                 *string `json:"source_port,omitempty"`
+	DestinationPort            *string `json:"destination_port,omitempty"`
+	SourceFirewallGroupID      *string `json:"source_firewall_group_id,omitempty"`
+	DestinationFirewallGroupID *string `json:"destination_firewall_group_id,omitempty"`
+	Shared                     *bool   `json:"shared,omitempty"`
+	ProjectID                  *string `json:"project_id,omitempty"`
+}
+
+// CreateFirewallRule creates a new firewall rule, which applies once inserted
+// in a firewall policy; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-firewall-rule
+func (api *NetworkV2API) CreateFirewallRule(opts *CreateFirewallRuleOptions) (*FirewallRule, *Result, error) {
+	rule := &FirewallRule{}
+	result, err := api.createResource("./v2.0/fwaas/firewall_rules", "firewall_rule", opts, rule)
+	if result != nil && result.Code == 201 {
+		return rule, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE FIREWALL RULE
+ */
+
+// RetrieveFirewallRule retrieves the details of the firewall rule identified by
+// the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-firewall-rule-details
+func (api *NetworkV2API) RetrieveFirewallRule(ruleid string) (*FirewallRule, *Result, error) {
+	rule := &FirewallRule{}
+	result, err := api.retrieveResource("./v2.0/fwaas/firewall_rules/{id}", ruleid, "firewall_rule", rule)
+	if result != nil && result.Code == 200 {
+		return rule, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE FIREWALL RULE
+ */
+
+// UpdateFirewallRuleOptions provides the options available for updating a
+// firewall rule; only the given attributes are modified.
+type UpdateFirewallRuleOptions struct {
+	Name                       *string `json:"name,omitempty"`
+	Description                *string `json:"description,omitempty"`
+	Action                     *string `json:"action,omitempty"`
+	Enabled                    *bool   `json:"enabled,omitempty"`
+	IPVersion                  *int    `json:"ip_version,omitempty"`
+	Protocol                   *string `json:"protocol,omitempty"`
+	SourceIPAddress            *string `json:"source_ip_address,omitempty"`
+	DestinationIPAddress       *string `json:"destination_ip_address,omitempty"`
+	SourcePort                 *string `json:"source_port,omitempty"`
+	DestinationPort            *string `json:"destination_port,omitempty"`
+	SourceFirewallGroupID      *string `json:"source_firewall_group_id,omitempty"`
+	DestinationFirewallGroupID *string `json:"destination_firewall_group_id,omitempty"`
+	Shared                     *bool   `json:"shared,omitempty"`
+}
+
+// UpdateFirewallRule updates the firewall rule identified by the given id; see
+// also https://developer.openstack.org/api-ref/network/v2/#update-firewall-rule
+func (api *NetworkV2API) UpdateFirewallRule(ruleid string, opts *UpdateFirewallRuleOptions) (*FirewallRule, *Result, error) {
+	rule := &FirewallRule{}
+	result, err := api.updateResource("./v2.0/fwaas/firewall_rules/{id}", ruleid, "firewall_rule", opts, rule)
+	if result != nil && result.Code == 200 {
+		return rule, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE FIREWALL RULE
+ */
+
+// DeleteFirewallRule deletes the firewall rule identified by the given id; it
+// fails if the rule is in use by a firewall policy; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-firewall-rule
+func (api *NetworkV2API) DeleteFirewallRule(ruleid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/fwaas/firewall_rules/{id}", ruleid)
+}
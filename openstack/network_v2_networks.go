@@ -0,0 +1,167 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST NETWORKS
+ */
+
+// ListNetworksOptions provides the options available for filtering the list of
+// networks; Limit sets the page size, all pages are retrieved anyway; see
+// https://developer.openstack.org/api-ref/network/v2/#list-networks.
+type ListNetworksOptions struct {
+	ID              *string             `parameter:"id,omitempty" header:"-" json:"-"`
+	Name            *string             `parameter:"name,omitempty" header:"-" json:"-"`
+	Description     *string             `parameter:"description,omitempty" header:"-" json:"-"`
+	AdminStateUp    *bool               `parameter:"admin_state_up,omitempty" header:"-" json:"-"`
+	Status          *string             `parameter:"status,omitempty" header:"-" json:"-"`
+	Shared          *bool               `parameter:"shared,omitempty" header:"-" json:"-"`
+	External        *bool               `parameter:"router:external,omitempty" header:"-" json:"-"`
+	IsDefault       *bool               `parameter:"is_default,omitempty" header:"-" json:"-"`
+	MTU             *int                `parameter:"mtu,omitempty" header:"-" json:"-"`
+	ProjectID       *string             `parameter:"project_id,omitempty" header:"-" json:"-"`
+	NetworkType     *string             `parameter:"provider:network_type,omitempty" header:"-" json:"-"`
+	PhysicalNetwork *string             `parameter:"provider:physical_network,omitempty" header:"-" json:"-"`
+	SegmentationID  *int                `parameter:"provider:segmentation_id,omitempty" header:"-" json:"-"`
+	Tags            *CommaSeparatedList `parameter:"tags,omitempty" header:"-" json:"-"`
+	TagsAny         *CommaSeparatedList `parameter:"tags-any,omitempty" header:"-" json:"-"`
+	NotTags         *CommaSeparatedList `parameter:"not-tags,omitempty" header:"-" json:"-"`
+	NotTagsAny      *CommaSeparatedList `parameter:"not-tags-any,omitempty" header:"-" json:"-"`
+	SortKey         *string             `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir         *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit           *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker          *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListNetworks returns the list of networks visible to the current project,
+// including shared and external networks; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-networks
+func (api *NetworkV2API) ListNetworks(opts *ListNetworksOptions) (*[]Network, *Result, error) {
+	networks := []Network{}
+	result, err := api.listResources("./v2.0/networks", "networks", opts, collectionLinks,
+		func() interface{} { return &[]Network{} },
+		func(page interface{}) { networks = append(networks, *page.(*[]Network)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &networks, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE NETWORK
+ */
+
+// CreateNetworkOptions provides the options available for creating a network;
+// the provider attributes and Shared are reserved to administrators by default;
+// either the provider attributes or Segments can be given, not both;
+// AvailabilityZoneHints lists the zones where the DHCP agents of the network
+// should be (see ListNetworkAvailabilityZones).
+type CreateNetworkOptions struct {
+	Name                  *string           `json:"name,omitempty"`
+	Description           *string           `json:"description,omitempty"`
+	AdminStateUp          *bool             `json:"admin_state_up,omitempty"`
+	Shared                *bool             `json:"shared,omitempty"`
+	External              *bool             `json:"router:external,omitempty"`
+	IsDefault             *bool             `json:"is_default,omitempty"`
+	MTU                   *int              `json:"mtu,omitempty"`
+	PortSecurityEnabled   *bool             `json:"port_security_enabled,omitempty"`
+	ProjectID             *string           `json:"project_id,omitempty"`
+	NetworkType           *string           `json:"provider:network_type,omitempty"`
+	PhysicalNetwork       *string           `json:"provider:physical_network,omitempty"`
+	SegmentationID        *int              `json:"provider:segmentation_id,omitempty"`
+	Segments              *[]NetworkSegment `json:"segments,omitempty"`
+	QoSPolicyID           *string           `json:"qos_policy_id,omitempty"`
+	DNSDomain             *string           `json:"dns_domain,omitempty"`
+	AvailabilityZoneHints *[]string         `json:"availability_zone_hints,omitempty"`
+}
+
+// CreateNetwork creates a new network; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-network
+func (api *NetworkV2API) CreateNetwork(opts *CreateNetworkOptions) (*Network, *Result, error) {
+	network := &Network{}
+	result, err := api.createResource("./v2.0/networks", "network", opts, network)
+	if result != nil && result.Code == 201 {
+		return network, result, err
+	}
+	return nil, result, err
+}
+
+// CreateNetworks creates multiple networks in a single request, which is much
+// faster than creating them one by one; the operation is atomic: either all the
+// networks are created or none is; see also
+// https://developer.openstack.org/api-ref/network/v2/#bulk-create-networks
+func (api *NetworkV2API) CreateNetworks(opts []CreateNetworkOptions) (*[]Network, *Result, error) {
+	networks := &[]Network{}
+	result, err := api.bulkCreateResources("./v2.0/networks", "networks", opts, networks)
+	if result != nil && result.Code == 201 {
+		return networks, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE NETWORK
+ */
+
+// RetrieveNetwork retrieves the details of the network identified by the given
+// id; see also https://developer.openstack.org/api-ref/network/v2/#show-network-details
+func (api *NetworkV2API) RetrieveNetwork(networkid string) (*Network, *Result, error) {
+	network := &Network{}
+	result, err := api.retrieveResource("./v2.0/networks/{id}", networkid, "network", network)
+	if result != nil && result.Code == 200 {
+		return network, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE NETWORK
+ */
+
+// UpdateNetworkOptions provides the options available for updating a network;
+// only the given attributes are modified.
+type UpdateNetworkOptions struct {
+	Name                *string           `json:"name,omitempty"`
+	Description         *string           `json:"description,omitempty"`
+	AdminStateUp        *bool             `json:"admin_state_up,omitempty"`
+	Shared              *bool             `json:"shared,omitempty"`
+	External            *bool             `json:"router:external,omitempty"`
+	IsDefault           *bool             `json:"is_default,omitempty"`
+	MTU                 *int              `json:"mtu,omitempty"`
+	PortSecurityEnabled *bool             `json:"port_security_enabled,omitempty"`
+	NetworkType         *string           `json:"provider:network_type,omitempty"`
+	PhysicalNetwork     *string           `json:"provider:physical_network,omitempty"`
+	SegmentationID      *int              `json:"provider:segmentation_id,omitempty"`
+	Segments            *[]NetworkSegment `json:"segments,omitempty"`
+	QoSPolicyID         *string           `json:"qos_policy_id,omitempty"`
+	DNSDomain           *string           `json:"dns_domain,omitempty"`
+}
+
+// UpdateNetwork updates the network identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-network
+func (api *NetworkV2API) UpdateNetwork(networkid string, opts *UpdateNetworkOptions) (*Network, *Result, error) {
+	network := &Network{}
+	result, err := api.updateResource("./v2.0/networks/{id}", networkid, "network", opts, network)
+	if result != nil && result.Code == 200 {
+		return network, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE NETWORK
+ */
+
+// DeleteNetwork deletes the network identified by the given id, along with its
+// subnets; it fails if there are ports in use on the network; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-network
+func (api *NetworkV2API) DeleteNetwork(networkid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/networks/{id}", networkid)
+}
@@ -0,0 +1,366 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// VNICTypeNormal is the VNIC type of ports plugged into a virtual switch.
+	VNICTypeNormal = "normal"
+	// VNICTypeDirect is the VNIC type of SR-IOV virtual functions passed through
+	// to the server.
+	VNICTypeDirect = "direct"
+	// VNICTypeDirectPhysical is the VNIC type of SR-IOV physical functions passed
+	// through to the server.
+	VNICTypeDirectPhysical = "direct-physical"
+	// VNICTypeMacvtap is the VNIC type of SR-IOV virtual functions attached via
+	// macvtap.
+	VNICTypeMacvtap = "macvtap"
+	// VNICTypeBaremetal is the VNIC type of the ports of bare-metal servers.
+	VNICTypeBaremetal = "baremetal"
+	// VNICTypeSmartNIC is the VNIC type of ports on smart NICs.
+	VNICTypeSmartNIC = "smart-nic"
+)
+
+/*
+ * LIST PORTS
+ */
+
+// ListPortsOptions provides the options available for filtering the list of
+// ports; Limit sets the page size, all pages are retrieved anyway.
+type ListPortsOptions struct {
+	ID            *string             `parameter:"id,omitempty" header:"-" json:"-"`
+	Name          *string             `parameter:"name,omitempty" header:"-" json:"-"`
+	Description   *string             `parameter:"description,omitempty" header:"-" json:"-"`
+	NetworkID     *string             `parameter:"network_id,omitempty" header:"-" json:"-"`
+	DeviceID      *string             `parameter:"device_id,omitempty" header:"-" json:"-"`
+	DeviceOwner   *string             `parameter:"device_owner,omitempty" header:"-" json:"-"`
+	MACAddress    *string             `parameter:"mac_address,omitempty" header:"-" json:"-"`
+	Status        *string             `parameter:"status,omitempty" header:"-" json:"-"`
+	AdminStateUp  *bool               `parameter:"admin_state_up,omitempty" header:"-" json:"-"`
+	BindingHostID *string             `parameter:"binding:host_id,omitempty" header:"-" json:"-"`
+	ProjectID     *string             `parameter:"project_id,omitempty" header:"-" json:"-"`
+	Tags          *CommaSeparatedList `parameter:"tags,omitempty" header:"-" json:"-"`
+	TagsAny       *CommaSeparatedList `parameter:"tags-any,omitempty" header:"-" json:"-"`
+	NotTags       *CommaSeparatedList `parameter:"not-tags,omitempty" header:"-" json:"-"`
+	NotTagsAny    *CommaSeparatedList `parameter:"not-tags-any,omitempty" header:"-" json:"-"`
+	SortKey       *string             `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir       *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit         *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker        *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListPorts returns the list of ports; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-ports
+func (api *NetworkV2API) ListPorts(opts *ListPortsOptions) (*[]Port, *Result, error) {
+	ports := []Port{}
+	result, err := api.listResources("./v2.0/ports", "ports", opts, collectionLinks,
+		func() interface{} { return &[]Port{} },
+		func(page interface{}) { ports = append(ports, *page.(*[]Port)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &ports, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE PORT
+ */
+
+// CreatePortOptions provides the options available for creating a port:
+// NetworkID is mandatory; the "binding:" attributes can only be set by
+// administrators (or by the services acting on their behalf, e.g. Nova and
+// Ironic): BindingHostID is the host where the port is bound, BindingVNICType
+// the kind of interface (e.g. VNICTypeDirect for SR-IOV) and BindingProfile
+// driver-specific information (e.g. the PCI slot of a virtual function, or the
+// local link information of a bare-metal port).
+type CreatePortOptions struct {
+	NetworkID           *string                 `json:"network_id,omitempty"`
+	Name                *string                 `json:"name,omitempty"`
+	Description         *string                 `json:"description,omitempty"`
+	AdminStateUp        *bool                   `json:"admin_state_up,omitempty"`
+	MACAddress          *string                 `json:"mac_address,omitempty"`
+	FixedIPs            *[]FixedIP              `json:"fixed_ips,omitempty"`
+	DeviceID            *string                 `json:"device_id,omitempty"`
+	DeviceOwner         *string                 `json:"device_owner,omitempty"`
+	SecurityGroups      *[]string               `json:"security_groups,omitempty"`
+	PortSecurityEnabled *bool                   `json:"port_security_enabled,omitempty"`
+	AllowedAddressPairs *[]AllowedAddressPair   `json:"allowed_address_pairs,omitempty"`
+	BindingHostID       *string                 `json:"binding:host_id,omitempty"`
+	BindingVNICType     *string                 `json:"binding:vnic_type,omitempty"`
+	BindingProfile      *map[string]interface{} `json:"binding:profile,omitempty"`
+	DNSName             *string                 `json:"dns_name,omitempty"`
+	QoSPolicyID         *string                 `json:"qos_policy_id,omitempty"`
+	ProjectID           *string                 `json:"project_id,omitempty"`
+}
+
+// CreatePort creates a new port; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-port
+func (api *NetworkV2API) CreatePort(opts *CreatePortOptions) (*Port, *Result, error) {
+	port := &Port{}
+	result, err := api.createResource("./v2.0/ports", "port", opts, port)
+	if result != nil && result.Code == 201 {
+		return port, result, err
+	}
+	return nil, result, err
+}
+
+// CreatePorts creates multiple ports in a single request, which is much faster
+// than creating them one by one; the operation is atomic: either all the ports
+// are created or none is; see also
+// https://developer.openstack.org/api-ref/network/v2/#bulk-create-ports
+func (api *NetworkV2API) CreatePorts(opts []CreatePortOptions) (*[]Port, *Result, error) {
+	ports := &[]Port{}
+	result, err := api.bulkCreateResources("./v2.0/ports", "ports", opts, ports)
+	if result != nil && result.Code == 201 {
+		return ports, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE PORT
+ */
+
+// RetrievePort retrieves the details of the port identified by the given id;
+// see also https://developer.openstack.org/api-ref/network/v2/#show-port-details
+func (api *NetworkV2API) RetrievePort(portid string) (*Port, *Result, error) {
+	port := &Port{}
+	result, err := api.retrieveResource("./v2.0/ports/{id}", portid, "port", port)
+	if result != nil && result.Code == 200 {
+		return port, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE PORT
+ */
+
+// UpdatePortOptions provides the options available for updating a port; only
+// the given attributes are modified; the "binding:" attributes are admin-only,
+// as in CreatePortOptions.
+type UpdatePortOptions struct {
+	Name                *string                 `json:"name,omitempty"`
+	Description         *string                 `json:"description,omitempty"`
+	AdminStateUp        *bool                   `json:"admin_state_up,omitempty"`
+	MACAddress          *string                 `json:"mac_address,omitempty"`
+	FixedIPs            *[]FixedIP              `json:"fixed_ips,omitempty"`
+	DeviceID            *string                 `json:"device_id,omitempty"`
+	DeviceOwner         *string                 `json:"device_owner,omitempty"`
+	SecurityGroups      *[]string               `json:"security_groups,omitempty"`
+	PortSecurityEnabled *bool                   `json:"port_security_enabled,omitempty"`
+	AllowedAddressPairs *[]AllowedAddressPair   `json:"allowed_address_pairs,omitempty"`
+	BindingHostID       *string                 `json:"binding:host_id,omitempty"`
+	BindingVNICType     *string                 `json:"binding:vnic_type,omitempty"`
+	BindingProfile      *map[string]interface{} `json:"binding:profile,omitempty"`
+	DNSName             *string                 `json:"dns_name,omitempty"`
+	QoSPolicyID         *string                 `json:"qos_policy_id,omitempty"`
+}
+
+// UpdatePort updates the port identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-port
+func (api *NetworkV2API) UpdatePort(portid string, opts *UpdatePortOptions) (*Port, *Result, error) {
+	port := &Port{}
+	result, err := api.updateResource("./v2.0/ports/{id}", portid, "port", opts, port)
+	if result != nil && result.Code == 200 {
+		return port, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * ALLOWED ADDRESS PAIRS
+ */
+
+// AddAllowedAddressPair adds the given address pair to the allowed address pairs
+// of the port identified by the given id, unless already there; see
+// updateAllowedAddressPairs for how concurrent updates are handled.
+func (api *NetworkV2API) AddAllowedAddressPair(portid string, pair AllowedAddressPair) (*Port, error) {
+	return api.updateAllowedAddressPairs(portid, func(pairs []AllowedAddressPair) []AllowedAddressPair {
+		for _, p := range pairs {
+			if sameAllowedAddressPair(p, pair) {
+				return nil
+			}
+		}
+		return append(pairs, pair)
+	})
+}
+
+// RemoveAllowedAddressPair removes the given address pair from the allowed
+// address pairs of the port identified by the given id, if there; a pair with
+// no MAC address matches any pair with the same IP address; see
+// updateAllowedAddressPairs for how concurrent updates are handled.
+func (api *NetworkV2API) RemoveAllowedAddressPair(portid string, pair AllowedAddressPair) (*Port, error) {
+	return api.updateAllowedAddressPairs(portid, func(pairs []AllowedAddressPair) []AllowedAddressPair {
+		kept := []AllowedAddressPair{}
+		for _, p := range pairs {
+			if !sameAllowedAddressPair(p, pair) {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == len(pairs) {
+			return nil
+		}
+		return kept
+	})
+}
+
+// maxAllowedAddressPairsAttempts is the number of times the allowed address
+// pairs of a port are read and written before giving up because of concurrent
+// updates.
+const maxAllowedAddressPairsAttempts = 5
+
+// updateAllowedAddressPairs reads the port, computes its new allowed address
+// pairs with the given function (which returns nil if no change is needed) and
+// writes them back, only if the port has not been modified in the meantime (using
+// the revision number of the port as a precondition, as per the
+// revision-if-match extension); if it has, the cycle is repeated.
+func (api *NetworkV2API) updateAllowedAddressPairs(portid string, update func([]AllowedAddressPair) []AllowedAddressPair) (*Port, error) {
+	for attempt := 1; attempt <= maxAllowedAddressPairsAttempts; attempt++ {
+		port, result, err := api.RetrievePort(portid)
+		if err != nil {
+			log.Errorf("error retrieving port %q: %v", portid, err)
+			return nil, err
+		}
+		if port == nil {
+			log.Errorf("error retrieving port %q: %v", portid, result)
+			return nil, fmt.Errorf("error retrieving port %q: %v", portid, result)
+		}
+		pairs := []AllowedAddressPair{}
+		if port.AllowedAddressPairs != nil {
+			pairs = *port.AllowedAddressPairs
+		}
+		pairs = update(pairs)
+		if pairs == nil {
+			log.Debugf("no change to the allowed address pairs of port %q", portid)
+			return port, nil
+		}
+
+		input := &struct {
+			PortID  string  `parameter:"-" header:"-" variable:"portid" json:"-"`
+			IfMatch *string `parameter:"-" header:"If-Match,omitempty" json:"-"`
+			Port    struct {
+				AllowedAddressPairs []AllowedAddressPair `json:"allowed_address_pairs"`
+			} `parameter:"-" header:"-" json:"port"`
+		}{
+			PortID: portid,
+		}
+		if port.RevisionNumber != nil {
+			input.IfMatch = String(fmt.Sprintf("revision_number=%d", *port.RevisionNumber))
+		}
+		input.Port.AllowedAddressPairs = pairs
+		updated := &Port{}
+
+		result, err = api.Invoke(http.MethodPut, "./v2.0/ports/{portid}", true, StatusCodeIn(200, 412), input, &envelope{Name: "port", Body: updated}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil && result.Code == 200 {
+			return updated, nil
+		}
+		// only a failed precondition means that the port has been modified
+		if result == nil || result.Code != 412 {
+			log.Errorf("error updating the allowed address pairs of port %q: %v", portid, result)
+			return nil, fmt.Errorf("error updating the allowed address pairs of port %q: %v", portid, result)
+		}
+		log.Debugf("port %q modified concurrently (attempt %d of %d), retrying", portid, attempt, maxAllowedAddressPairsAttempts)
+	}
+	log.Errorf("error updating the allowed address pairs of port %q: too many concurrent modifications", portid)
+	return nil, fmt.Errorf("error updating the allowed address pairs of port %q: too many concurrent modifications", portid)
+}
+
+// sameAllowedAddressPair returns whether the given pair matches the reference one:
+// the IP addresses must be the same and, if the reference has a MAC address, the
+// MAC addresses too (case insensitive).
+func sameAllowedAddressPair(pair AllowedAddressPair, reference AllowedAddressPair) bool {
+	if stringValue(pair.IPAddress) != stringValue(reference.IPAddress) {
+		return false
+	}
+	return reference.MACAddress == nil || strings.EqualFold(stringValue(pair.MACAddress), stringValue(reference.MACAddress))
+}
+
+/*
+ * DELETE PORT
+ */
+
+// DeletePort deletes the port identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-port
+func (api *NetworkV2API) DeletePort(portid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/ports/{id}", portid)
+}
+
+/*
+ * PORT BINDINGS
+ */
+
+// ListPortBindings returns the bindings of the port identified by the given id;
+// it requires the port-bindings-extended extension; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-port-binding-of-a-port
+func (api *NetworkV2API) ListPortBindings(portid string) (*[]PortBinding, *Result, error) {
+	bindings := &[]PortBinding{}
+	result, err := api.retrieveResource("./v2.0/ports/{id}/bindings", portid, "bindings", bindings)
+	if result != nil && result.Code == 200 {
+		return bindings, result, err
+	}
+	return nil, result, err
+}
+
+// CreatePortBindingOptions provides the options available for binding a port to
+// a host: Host is mandatory.
+type CreatePortBindingOptions struct {
+	Host     *string                 `json:"host,omitempty"`
+	VNICType *string                 `json:"vnic_type,omitempty"`
+	Profile  *map[string]interface{} `json:"profile,omitempty"`
+}
+
+// CreatePortBinding creates an inactive binding of the port identified by the
+// given id to another host, e.g. the destination of a live migration, which is
+// then activated with ActivatePortBinding; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-port-binding
+func (api *NetworkV2API) CreatePortBinding(portid string, opts *CreatePortBindingOptions) (*PortBinding, *Result, error) {
+	binding := &PortBinding{}
+	result, err := api.createResource("./v2.0/ports/"+portid+"/bindings", "binding", opts, binding)
+	if result != nil && result.Code == 201 {
+		return binding, result, err
+	}
+	return nil, result, err
+}
+
+// ActivatePortBinding activates the binding of the port identified by the given
+// id to the given host, deactivating the currently active one; see also
+// https://developer.openstack.org/api-ref/network/v2/#activate-port-binding
+func (api *NetworkV2API) ActivatePortBinding(portid string, host string) (*PortBinding, *Result, error) {
+	input := &struct {
+		PortID string `parameter:"-" header:"-" variable:"portid" json:"-"`
+		Host   string `parameter:"-" header:"-" variable:"host" json:"-"`
+	}{
+		PortID: portid,
+		Host:   host,
+	}
+	binding := &PortBinding{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2.0/ports/{portid}/bindings/{host}/activate", true, StatusCodeIn(200), input, &envelope{Name: "binding", Body: binding}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return binding, result, err
+	}
+	return nil, result, err
+}
+
+// DeletePortBinding deletes the inactive binding of the port identified by the
+// given id to the given host; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-port-binding
+func (api *NetworkV2API) DeletePortBinding(portid string, host string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/ports/"+portid+"/bindings/{id}", host)
+}
@@ -0,0 +1,53 @@
+package openstack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAddAllowedAddressPair(t *testing.T) {
+	var status int
+	updates := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"port": {"id": "p1", "revision_number": 3, "allowed_address_pairs": []}}`))
+		case http.MethodPut:
+			updates++
+			if r.Header.Get("If-Match") != "revision_number=3" {
+				t.Errorf("Network.TestAddAllowedAddressPair: unexpected precondition %q", r.Header.Get("If-Match"))
+			}
+			w.WriteHeader(status)
+			if status == http.StatusOK {
+				w.Write([]byte(`{"port": {"id": "p1", "revision_number": 4, "allowed_address_pairs": [{"ip_address": "10.0.0.5"}]}}`))
+			}
+		}
+	}))
+	defer server.Close()
+	client := NewDefaultClient(server.URL)
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+	api := &NetworkV2API{client.Authenticator.Identity.API}
+	pair := AllowedAddressPair{IPAddress: String("10.0.0.5")}
+
+	// a failed precondition is retried, any other failure is not
+	for _, test := range []struct {
+		status  int
+		updates int
+	}{
+		{http.StatusOK, 1},
+		{http.StatusPreconditionFailed, maxAllowedAddressPairsAttempts},
+		{http.StatusNotFound, 1},
+		{http.StatusConflict, 1},
+	} {
+		status, updates = test.status, 0
+		port, err := api.AddAllowedAddressPair("p1", pair)
+		if (err == nil) != (test.status == http.StatusOK) || (err == nil && port == nil) {
+			t.Errorf("Network.TestAddAllowedAddressPair: unexpected outcome on %d: %v (%v)", test.status, port, err)
+		}
+		if updates != test.updates {
+			t.Errorf("Network.TestAddAllowedAddressPair: expected %d updates on %d, got %d", test.updates, test.status, updates)
+		}
+	}
+}
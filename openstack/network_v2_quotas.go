@@ -0,0 +1,117 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST QUOTAS
+ */
+
+// ListNetworkQuotas returns the quotas of all the projects having non-default
+// quotas; it requires administrative privileges; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-quotas-for-projects-with-non-default-quota-values
+func (api *NetworkV2API) ListNetworkQuotas() (*[]NetworkQuota, *Result, error) {
+	quotas := []NetworkQuota{}
+	result, err := api.listResources("./v2.0/quotas", "quotas", nil, collectionLinks,
+		func() interface{} { return &[]NetworkQuota{} },
+		func(page interface{}) { quotas = append(quotas, *page.(*[]NetworkQuota)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &quotas, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE QUOTAS
+ */
+
+// RetrieveNetworkQuota retrieves the quotas of the project identified by the
+// given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-quotas-for-a-project
+func (api *NetworkV2API) RetrieveNetworkQuota(projectid string) (*NetworkQuota, *Result, error) {
+	quota := &NetworkQuota{}
+	result, err := api.retrieveResource("./v2.0/quotas/{id}", projectid, "quota", quota)
+	if result != nil && result.Code == 200 {
+		return quota, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveDefaultNetworkQuota retrieves the default quotas that apply to the
+// project identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-default-quotas-for-a-project
+func (api *NetworkV2API) RetrieveDefaultNetworkQuota(projectid string) (*NetworkQuota, *Result, error) {
+	quota := &NetworkQuota{}
+	result, err := api.retrieveResource("./v2.0/quotas/{id}/default", projectid, "quota", quota)
+	if result != nil && result.Code == 200 {
+		return quota, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveNetworkQuotaDetails retrieves the usage of each Networking resource
+// (e.g. "network", "port", "floatingip") by the project identified by the
+// given id, with the number of used and reserved resources and the quota limit;
+// see also
+// https://developer.openstack.org/api-ref/network/v2/#show-quota-details-for-a-tenant
+func (api *NetworkV2API) RetrieveNetworkQuotaDetails(projectid string) (*map[string]NetworkQuotaUsage, *Result, error) {
+	details := &map[string]NetworkQuotaUsage{}
+	result, err := api.retrieveResource("./v2.0/quotas/{id}/details.json", projectid, "quota", details)
+	if result != nil && result.Code == 200 {
+		return details, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE QUOTAS
+ */
+
+// UpdateNetworkQuotaOptions provides the options available for updating the
+// quotas of a project; only the given limits are modified; -1 means unlimited;
+// a limit lower than the current usage is rejected if CheckLimit is true (with
+// the quota-check-limit extension), Force skips the check (with the
+// quota-check-limit-default extension).
+type UpdateNetworkQuotaOptions struct {
+	Network           *int  `json:"network,omitempty"`
+	Subnet            *int  `json:"subnet,omitempty"`
+	SubnetPool        *int  `json:"subnetpool,omitempty"`
+	Port              *int  `json:"port,omitempty"`
+	Router            *int  `json:"router,omitempty"`
+	FloatingIP        *int  `json:"floatingip,omitempty"`
+	SecurityGroup     *int  `json:"security_group,omitempty"`
+	SecurityGroupRule *int  `json:"security_group_rule,omitempty"`
+	RBACPolicy        *int  `json:"rbac_policy,omitempty"`
+	Trunk             *int  `json:"trunk,omitempty"`
+	CheckLimit        *bool `json:"check_limit,omitempty"`
+	Force             *bool `json:"force,omitempty"`
+}
+
+// UpdateNetworkQuota updates the quotas of the project identified by the given
+// id, returning the new quotas; it requires administrative privileges; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-quota-for-a-project
+func (api *NetworkV2API) UpdateNetworkQuota(projectid string, opts *UpdateNetworkQuotaOptions) (*NetworkQuota, *Result, error) {
+	quota := &NetworkQuota{}
+	result, err := api.updateResource("./v2.0/quotas/{id}", projectid, "quota", opts, quota)
+	if result != nil && result.Code == 200 {
+		return quota, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RESET QUOTAS
+ */
+
+// ResetNetworkQuota resets the quotas of the project identified by the given id
+// to their default values; it requires administrative privileges; see also
+// https://developer.openstack.org/api-ref/network/v2/#reset-quota-for-a-project
+func (api *NetworkV2API) ResetNetworkQuota(projectid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/quotas/{id}", projectid)
+}
@@ -0,0 +1,197 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST ROUTERS
+ */
+
+// ListRoutersOptions provides the options available for filtering the list of
+// routers; Limit sets the page size, all pages are retrieved anyway.
+type ListRoutersOptions struct {
+	ID           *string             `parameter:"id,omitempty" header:"-" json:"-"`
+	Name         *string             `parameter:"name,omitempty" header:"-" json:"-"`
+	Description  *string             `parameter:"description,omitempty" header:"-" json:"-"`
+	AdminStateUp *bool               `parameter:"admin_state_up,omitempty" header:"-" json:"-"`
+	Status       *string             `parameter:"status,omitempty" header:"-" json:"-"`
+	ProjectID    *string             `parameter:"project_id,omitempty" header:"-" json:"-"`
+	Tags         *CommaSeparatedList `parameter:"tags,omitempty" header:"-" json:"-"`
+	TagsAny      *CommaSeparatedList `parameter:"tags-any,omitempty" header:"-" json:"-"`
+	NotTags      *CommaSeparatedList `parameter:"not-tags,omitempty" header:"-" json:"-"`
+	NotTagsAny   *CommaSeparatedList `parameter:"not-tags-any,omitempty" header:"-" json:"-"`
+	SortKey      *string             `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir      *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit        *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker       *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListRouters returns the list of routers; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-routers
+func (api *NetworkV2API) ListRouters(opts *ListRoutersOptions) (*[]Router, *Result, error) {
+	routers := []Router{}
+	result, err := api.listResources("./v2.0/routers", "routers", opts, collectionLinks,
+		func() interface{} { return &[]Router{} },
+		func(page interface{}) { routers = append(routers, *page.(*[]Router)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &routers, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE ROUTER
+ */
+
+// CreateRouterOptions provides the options available for creating a router;
+// Distributed and HA are reserved to administrators by default;
+// AvailabilityZoneHints lists the zones where the L3 agents of the router should
+// be (see ListNetworkAvailabilityZones).
+type CreateRouterOptions struct {
+	Name                  *string            `json:"name,omitempty"`
+	Description           *string            `json:"description,omitempty"`
+	AdminStateUp          *bool              `json:"admin_state_up,omitempty"`
+	ProjectID             *string            `json:"project_id,omitempty"`
+	ExternalGatewayInfo   *RouterGatewayInfo `json:"external_gateway_info,omitempty"`
+	Distributed           *bool              `json:"distributed,omitempty"`
+	HA                    *bool              `json:"ha,omitempty"`
+	FlavorID              *string            `json:"flavor_id,omitempty"`
+	AvailabilityZoneHints *[]string          `json:"availability_zone_hints,omitempty"`
+}
+
+// CreateRouter creates a new router; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-router
+func (api *NetworkV2API) CreateRouter(opts *CreateRouterOptions) (*Router, *Result, error) {
+	router := &Router{}
+	result, err := api.createResource("./v2.0/routers", "router", opts, router)
+	if result != nil && result.Code == 201 {
+		return router, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE ROUTER
+ */
+
+// RetrieveRouter retrieves the details of the router identified by the given
+// id; see also https://developer.openstack.org/api-ref/network/v2/#show-router-details
+func (api *NetworkV2API) RetrieveRouter(routerid string) (*Router, *Result, error) {
+	router := &Router{}
+	result, err := api.retrieveResource("./v2.0/routers/{id}", routerid, "router", router)
+	if result != nil && result.Code == 200 {
+		return router, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE ROUTER
+ */
+
+// UpdateRouterOptions provides the options available for updating a router;
+// only the given attributes are modified; a non-nil but empty
+// ExternalGatewayInfo clears the gateway, a non-nil but empty Routes removes all
+// static routes.
+type UpdateRouterOptions struct {
+	Name                *string            `json:"name,omitempty"`
+	Description         *string            `json:"description,omitempty"`
+	AdminStateUp        *bool              `json:"admin_state_up,omitempty"`
+	ExternalGatewayInfo *RouterGatewayInfo `json:"external_gateway_info,omitempty"`
+	Routes              *[]RouterRoute     `json:"routes,omitempty"`
+	Distributed         *bool              `json:"distributed,omitempty"`
+	HA                  *bool              `json:"ha,omitempty"`
+}
+
+// UpdateRouter updates the router identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-router
+func (api *NetworkV2API) UpdateRouter(routerid string, opts *UpdateRouterOptions) (*Router, *Result, error) {
+	router := &Router{}
+	result, err := api.updateResource("./v2.0/routers/{id}", routerid, "router", opts, router)
+	if result != nil && result.Code == 200 {
+		return router, result, err
+	}
+	return nil, result, err
+}
+
+// SetRouterGateway sets the external gateway of the router; see UpdateRouter.
+func (api *NetworkV2API) SetRouterGateway(routerid string, gateway *RouterGatewayInfo) (*Router, *Result, error) {
+	return api.UpdateRouter(routerid, &UpdateRouterOptions{
+		ExternalGatewayInfo: gateway,
+	})
+}
+
+// ClearRouterGateway removes the external gateway of the router; see
+// UpdateRouter.
+func (api *NetworkV2API) ClearRouterGateway(routerid string) (*Router, *Result, error) {
+	return api.UpdateRouter(routerid, &UpdateRouterOptions{
+		ExternalGatewayInfo: &RouterGatewayInfo{},
+	})
+}
+
+// UpdateRouterRoutes replaces the static routes of the router with the given
+// ones (an empty list removes all routes); see UpdateRouter.
+func (api *NetworkV2API) UpdateRouterRoutes(routerid string, routes []RouterRoute) (*Router, *Result, error) {
+	return api.UpdateRouter(routerid, &UpdateRouterOptions{
+		Routes: &routes,
+	})
+}
+
+/*
+ * DELETE ROUTER
+ */
+
+// DeleteRouter deletes the router identified by the given id; its interfaces
+// must have been removed first; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-router
+func (api *NetworkV2API) DeleteRouter(routerid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/routers/{id}", routerid)
+}
+
+/*
+ * ADD/REMOVE ROUTER INTERFACE
+ */
+
+// AddRouterInterface adds an interface to the router, either on the given
+// subnet (the router takes the subnet gateway IP) or on the given port; exactly
+// one of subnetid and portid must be given; see also
+// https://developer.openstack.org/api-ref/network/v2/#add-interface-to-router
+func (api *NetworkV2API) AddRouterInterface(routerid string, subnetid *string, portid *string) (*RouterInterface, *Result, error) {
+	return api.updateRouterInterface(routerid, "add_router_interface", subnetid, portid)
+}
+
+// RemoveRouterInterface removes the interface of the router on the given subnet
+// or port; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-interface-from-router
+func (api *NetworkV2API) RemoveRouterInterface(routerid string, subnetid *string, portid *string) (*RouterInterface, *Result, error) {
+	return api.updateRouterInterface(routerid, "remove_router_interface", subnetid, portid)
+}
+
+// updateRouterInterface adds or removes an interface of the router.
+func (api *NetworkV2API) updateRouterInterface(routerid string, action string, subnetid *string, portid *string) (*RouterInterface, *Result, error) {
+	input := &struct {
+		RouterID string  `parameter:"-" header:"-" variable:"routerid" json:"-"`
+		SubnetID *string `parameter:"-" header:"-" json:"subnet_id,omitempty"`
+		PortID   *string `parameter:"-" header:"-" json:"port_id,omitempty"`
+	}{
+		RouterID: routerid,
+		SubnetID: subnetid,
+		PortID:   portid,
+	}
+	output := &RouterInterface{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2.0/routers/{routerid}/"+action, true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
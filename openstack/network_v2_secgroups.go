@@ -0,0 +1,391 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST SECURITY GROUPS
+ */
+
+// ListSecurityGroupsOptions provides the options available for filtering the
+// list of security groups; Limit sets the page size, all pages are retrieved
+// anyway.
+type ListSecurityGroupsOptions struct {
+	ID          *string             `parameter:"id,omitempty" header:"-" json:"-"`
+	Name        *string             `parameter:"name,omitempty" header:"-" json:"-"`
+	Description *string             `parameter:"description,omitempty" header:"-" json:"-"`
+	ProjectID   *string             `parameter:"project_id,omitempty" header:"-" json:"-"`
+	Stateful    *bool               `parameter:"stateful,omitempty" header:"-" json:"-"`
+	Tags        *CommaSeparatedList `parameter:"tags,omitempty" header:"-" json:"-"`
+	TagsAny     *CommaSeparatedList `parameter:"tags-any,omitempty" header:"-" json:"-"`
+	NotTags     *CommaSeparatedList `parameter:"not-tags,omitempty" header:"-" json:"-"`
+	NotTagsAny  *CommaSeparatedList `parameter:"not-tags-any,omitempty" header:"-" json:"-"`
+	SortKey     *string             `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir     *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit       *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker      *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListSecurityGroups returns the list of security groups, with their rules; see
+// also https://developer.openstack.org/api-ref/network/v2/#list-security-groups
+func (api *NetworkV2API) ListSecurityGroups(opts *ListSecurityGroupsOptions) (*[]SecurityGroup, *Result, error) {
+	groups := []SecurityGroup{}
+	result, err := api.listResources("./v2.0/security-groups", "security_groups", opts, collectionLinks,
+		func() interface{} { return &[]SecurityGroup{} },
+		func(page interface{}) { groups = append(groups, *page.(*[]SecurityGroup)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &groups, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE SECURITY GROUP
+ */
+
+// CreateSecurityGroupOptions provides the options available for creating a
+// security group; Stateful requires the stateful-security-group extension, Tags
+// the tag-creation extension.
+type CreateSecurityGroupOptions struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	ProjectID   *string   `json:"project_id,omitempty"`
+	Stateful    *bool     `json:"stateful,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`
+}
+
+// CreateSecurityGroup creates a new security group, which comes with the default
+// egress rules; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-security-group
+func (api *NetworkV2API) CreateSecurityGroup(opts *CreateSecurityGroupOptions) (*SecurityGroup, *Result, error) {
+	group := &SecurityGroup{}
+	result, err := api.createResource("./v2.0/security-groups", "security_group", opts, group)
+	if result != nil && result.Code == 201 {
+		return group, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SECURITY GROUP
+ */
+
+// RetrieveSecurityGroup retrieves the details of the security group identified
+// by the given id, with its rules; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-security-group
+func (api *NetworkV2API) RetrieveSecurityGroup(groupid string) (*SecurityGroup, *Result, error) {
+	group := &SecurityGroup{}
+	result, err := api.retrieveResource("./v2.0/security-groups/{id}", groupid, "security_group", group)
+	if result != nil && result.Code == 200 {
+		return group, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveSecurityGroupWithRules retrieves the security group identified by the
+// given id and all its rules, which are listed separately so that they are all
+// included even when the cloud (or a policy) does not embed them in the group.
+func (api *NetworkV2API) RetrieveSecurityGroupWithRules(groupid string) (*SecurityGroup, error) {
+	group, result, err := api.RetrieveSecurityGroup(groupid)
+	if err != nil {
+		log.Errorf("error retrieving security group %q: %v", groupid, err)
+		return nil, err
+	}
+	if group == nil {
+		log.Errorf("error retrieving security group %q: %v", groupid, result)
+		return nil, fmt.Errorf("error retrieving security group %q: %v", groupid, result)
+	}
+	rules, result, err := api.ListSecurityGroupRules(&ListSecurityGroupRulesOptions{
+		SecurityGroupID: String(groupid),
+	})
+	if err != nil {
+		log.Errorf("error listing rules of security group %q: %v", groupid, err)
+		return nil, err
+	}
+	if rules == nil {
+		log.Errorf("error listing rules of security group %q: %v", groupid, result)
+		return nil, fmt.Errorf("error listing rules of security group %q: %v", groupid, result)
+	}
+	group.Rules = rules
+	return group, nil
+}
+
+/*
+ * UPDATE SECURITY GROUP
+ */
+
+// UpdateSecurityGroupOptions provides the options available for updating a
+// security group; only the given attributes are modified.
+type UpdateSecurityGroupOptions struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Stateful    *bool   `json:"stateful,omitempty"`
+}
+
+// UpdateSecurityGroup updates the security group identified by the given id; see
+// also https://developer.openstack.org/api-ref/network/v2/#update-security-group
+func (api *NetworkV2API) UpdateSecurityGroup(groupid string, opts *UpdateSecurityGroupOptions) (*SecurityGroup, *Result, error) {
+	group := &SecurityGroup{}
+	result, err := api.updateResource("./v2.0/security-groups/{id}", groupid, "security_group", opts, group)
+	if result != nil && result.Code == 200 {
+		return group, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE SECURITY GROUP
+ */
+
+// DeleteSecurityGroup deletes the security group identified by the given id,
+// along with its rules; it fails if the group is in use; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-security-group
+func (api *NetworkV2API) DeleteSecurityGroup(groupid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/security-groups/{id}", groupid)
+}
+
+/*
+ * LIST SECURITY GROUP RULES
+ */
+
+// ListSecurityGroupRulesOptions provides the options available for filtering
+// the list of security group rules; Limit sets the page size, all pages are
+// retrieved anyway.
+type ListSecurityGroupRulesOptions struct {
+	ID              *string `parameter:"id,omitempty" header:"-" json:"-"`
+	SecurityGroupID *string `parameter:"security_group_id,omitempty" header:"-" json:"-"`
+	Direction       *string `parameter:"direction,omitempty" header:"-" json:"-"`
+	EtherType       *string `parameter:"ethertype,omitempty" header:"-" json:"-"`
+	Protocol        *string `parameter:"protocol,omitempty" header:"-" json:"-"`
+	RemoteGroupID   *string `parameter:"remote_group_id,omitempty" header:"-" json:"-"`
+	RemoteIPPrefix  *string `parameter:"remote_ip_prefix,omitempty" header:"-" json:"-"`
+	ProjectID       *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey         *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir         *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit           *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker          *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListSecurityGroupRules returns the list of security group rules; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-security-group-rules
+func (api *NetworkV2API) ListSecurityGroupRules(opts *ListSecurityGroupRulesOptions) (*[]SecurityGroupRule, *Result, error) {
+	rules := []SecurityGroupRule{}
+	result, err := api.listResources("./v2.0/security-group-rules", "security_group_rules", opts, collectionLinks,
+		func() interface{} { return &[]SecurityGroupRule{} },
+		func(page interface{}) { rules = append(rules, *page.(*[]SecurityGroupRule)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &rules, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE SECURITY GROUP RULE
+ */
+
+const (
+	// SecurityGroupRuleIngress is the direction of rules applying to the traffic
+	// entering the ports.
+	SecurityGroupRuleIngress = "ingress"
+	// SecurityGroupRuleEgress is the direction of rules applying to the traffic
+	// leaving the ports.
+	SecurityGroupRuleEgress = "egress"
+	// EtherTypeIPv4 is the ether type of IPv4 rules.
+	EtherTypeIPv4 = "IPv4"
+	// EtherTypeIPv6 is the ether type of IPv6 rules.
+	EtherTypeIPv6 = "IPv6"
+)
+
+// CreateSecurityGroupRuleOptions provides the options available for creating a
+// security group rule: SecurityGroupID and Direction are mandatory, EtherType
+// defaults to IPv4; the protocol can be a name (e.g. "tcp", "icmp") or a number,
+// the port range only applies to TCP, UDP and similar protocols (for ICMP the
+// minimum and maximum are the type and code); at most one of RemoteIPPrefix,
+// RemoteGroupID and RemoteAddressGroupID can be given.
+type CreateSecurityGroupRuleOptions struct {
+	SecurityGroupID      *string `json:"security_group_id,omitempty"`
+	Direction            *string `json:"direction,omitempty"`
+	EtherType            *string `json:"ethertype,omitempty"`
+	Protocol             *string `json:"protocol,omitempty"`
+	PortRangeMin         *int    `json:"port_range_min,omitempty"`
+	PortRangeMax         *int    `json:"port_range_max,omitempty"`
+	RemoteIPPrefix       *string `json:"remote_ip_prefix,omitempty"`
+	RemoteGroupID        *string `json:"remote_group_id,omitempty"`
+	RemoteAddressGroupID *string `json:"remote_address_group_id,omitempty"`
+	Description          *string `json:"description,omitempty"`
+	ProjectID            *string `json:"project_id,omitempty"`
+}
+
+// CreateSecurityGroupRule creates a new rule in a security group; Neutron
+// rejects duplicate rules with a 409 Conflict; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-security-group-rule
+func (api *NetworkV2API) CreateSecurityGroupRule(opts *CreateSecurityGroupRuleOptions) (*SecurityGroupRule, *Result, error) {
+	rule := &SecurityGroupRule{}
+	result, err := api.createResource("./v2.0/security-group-rules", "security_group_rule", opts, rule)
+	if result != nil && result.Code == 201 {
+		return rule, result, err
+	}
+	return nil, result, err
+}
+
+// CreateSecurityGroupRules creates multiple security group rules in a single
+// request; the operation is atomic: either all the rules are created or none is;
+// see also https://developer.openstack.org/api-ref/network/v2/#create-security-group-rule
+func (api *NetworkV2API) CreateSecurityGroupRules(opts []CreateSecurityGroupRuleOptions) (*[]SecurityGroupRule, *Result, error) {
+	rules := &[]SecurityGroupRule{}
+	result, err := api.bulkCreateResources("./v2.0/security-group-rules", "security_group_rules", opts, rules)
+	if result != nil && result.Code == 201 {
+		return rules, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SECURITY GROUP RULE
+ */
+
+// RetrieveSecurityGroupRule retrieves the details of the security group rule
+// identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-security-group-rule
+func (api *NetworkV2API) RetrieveSecurityGroupRule(ruleid string) (*SecurityGroupRule, *Result, error) {
+	rule := &SecurityGroupRule{}
+	result, err := api.retrieveResource("./v2.0/security-group-rules/{id}", ruleid, "security_group_rule", rule)
+	if result != nil && result.Code == 200 {
+		return rule, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE SECURITY GROUP RULE
+ */
+
+// DeleteSecurityGroupRule deletes the security group rule identified by the given
+// id; rules cannot be updated, only deleted and created anew; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-security-group-rule
+func (api *NetworkV2API) DeleteSecurityGroupRule(ruleid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/security-group-rules/{id}", ruleid)
+}
+
+/*
+ * ENSURE SECURITY GROUP RULES
+ */
+
+// EnsureSecurityGroupRules brings the rules of the security group identified by
+// the given id in line with the desired ones: the desired rules that have no
+// equivalent in the group are created and, if prune is true, the rules of the
+// group that are not desired are deleted (including the default egress rules,
+// if not among the desired ones); two rules are equivalent if they have the same
+// direction, ether type, protocol, port range and remote, regardless of their
+// descriptions. It returns the rules created and the rules deleted; on error,
+// the changes applied so far are returned along with it.
+func (api *NetworkV2API) EnsureSecurityGroupRules(groupid string, desired []CreateSecurityGroupRuleOptions, prune bool) ([]SecurityGroupRule, []SecurityGroupRule, error) {
+	actual, result, err := api.ListSecurityGroupRules(&ListSecurityGroupRulesOptions{
+		SecurityGroupID: String(groupid),
+	})
+	if err != nil {
+		log.Errorf("error listing rules of security group %q: %v", groupid, err)
+		return nil, nil, err
+	}
+	if actual == nil {
+		log.Errorf("error listing rules of security group %q: %v", groupid, result)
+		return nil, nil, fmt.Errorf("error listing rules of security group %q: %v", groupid, result)
+	}
+
+	missing, unwanted := diffSecurityGroupRules(desired, *actual)
+	created := []SecurityGroupRule{}
+	deleted := []SecurityGroupRule{}
+	for _, opts := range missing {
+		opts.SecurityGroupID = String(groupid)
+		rule, result, err := api.CreateSecurityGroupRule(&opts)
+		if err != nil {
+			log.Errorf("error creating rule in security group %q: %v", groupid, err)
+			return created, deleted, err
+		}
+		if rule == nil {
+			log.Errorf("error creating rule in security group %q: %v", groupid, result)
+			return created, deleted, fmt.Errorf("error creating rule in security group %q: %v", groupid, result)
+		}
+		log.Debugf("created rule %q in security group %q", stringValue(rule.ID), groupid)
+		created = append(created, *rule)
+	}
+	if prune {
+		for _, rule := range unwanted {
+			ok, result, err := api.DeleteSecurityGroupRule(stringValue(rule.ID))
+			if err != nil {
+				log.Errorf("error deleting rule %q from security group %q: %v", stringValue(rule.ID), groupid, err)
+				return created, deleted, err
+			}
+			if !ok {
+				log.Errorf("error deleting rule %q from security group %q: %v", stringValue(rule.ID), groupid, result)
+				return created, deleted, fmt.Errorf("error deleting rule %q from security group %q: %v", stringValue(rule.ID), groupid, result)
+			}
+			log.Debugf("deleted rule %q from security group %q", stringValue(rule.ID), groupid)
+			deleted = append(deleted, rule)
+		}
+	}
+	return created, deleted, nil
+}
+
+// diffSecurityGroupRules returns the desired rules that have no equivalent among
+// the actual ones, and the actual rules that have no equivalent among the desired
+// ones; duplicates in the desired rules are only considered once.
+func diffSecurityGroupRules(desired []CreateSecurityGroupRuleOptions, actual []SecurityGroupRule) ([]CreateSecurityGroupRuleOptions, []SecurityGroupRule) {
+	existing := map[string]bool{}
+	for _, rule := range actual {
+		existing[securityGroupRuleKey(rule.Direction, rule.EtherType, rule.Protocol, rule.PortRangeMin, rule.PortRangeMax, rule.RemoteIPPrefix, rule.RemoteGroupID, rule.RemoteAddressGroupID)] = true
+	}
+	wanted := map[string]bool{}
+	missing := []CreateSecurityGroupRuleOptions{}
+	for _, opts := range desired {
+		key := securityGroupRuleKey(opts.Direction, opts.EtherType, opts.Protocol, opts.PortRangeMin, opts.PortRangeMax, opts.RemoteIPPrefix, opts.RemoteGroupID, opts.RemoteAddressGroupID)
+		if !existing[key] && !wanted[key] {
+			missing = append(missing, opts)
+		}
+		wanted[key] = true
+	}
+	unwanted := []SecurityGroupRule{}
+	for _, rule := range actual {
+		if !wanted[securityGroupRuleKey(rule.Direction, rule.EtherType, rule.Protocol, rule.PortRangeMin, rule.PortRangeMax, rule.RemoteIPPrefix, rule.RemoteGroupID, rule.RemoteAddressGroupID)] {
+			unwanted = append(unwanted, rule)
+		}
+	}
+	return missing, unwanted
+}
+
+// securityGroupRuleKey returns a string identifying a rule by its matching
+// criteria, normalised the way Neutron does: the ether type defaults to IPv4, the
+// protocol names are case insensitive ("any" meaning no protocol) and the prefixes
+// matching any address are the same as no prefix.
+func securityGroupRuleKey(direction, ethertype, protocol *string, min, max *int, prefix, group, addressgroup *string) string {
+	e := stringValue(ethertype)
+	if e == "" {
+		e = EtherTypeIPv4
+	}
+	p := strings.ToLower(stringValue(protocol))
+	if p == "any" {
+		p = ""
+	}
+	r := stringValue(prefix)
+	if r == "0.0.0.0/0" || r == "::/0" {
+		r = ""
+	}
+	port := func(value *int) string {
+		if value == nil {
+			return ""
+		}
+		return strconv.Itoa(*value)
+	}
+	return strings.Join([]string{stringValue(direction), e, p, port(min), port(max), r, stringValue(group), stringValue(addressgroup)}, "|")
+}
@@ -0,0 +1,26 @@
+package openstack
+
+import (
+	"testing"
+)
+
+func TestDiffSecurityGroupRules(t *testing.T) {
+	actual := []SecurityGroupRule{
+		{ID: String("egress-v4"), Direction: String(SecurityGroupRuleEgress), EtherType: String(EtherTypeIPv4)},
+		{ID: String("egress-v6"), Direction: String(SecurityGroupRuleEgress), EtherType: String(EtherTypeIPv6)},
+		{ID: String("ssh"), Direction: String(SecurityGroupRuleIngress), EtherType: String(EtherTypeIPv4), Protocol: String("tcp"), PortRangeMin: Int(22), PortRangeMax: Int(22), RemoteIPPrefix: String("0.0.0.0/0")},
+	}
+	desired := []CreateSecurityGroupRuleOptions{
+		{Direction: String(SecurityGroupRuleEgress)},
+		{Direction: String(SecurityGroupRuleIngress), Protocol: String("TCP"), PortRangeMin: Int(22), PortRangeMax: Int(22)},
+		{Direction: String(SecurityGroupRuleIngress), Protocol: String("tcp"), PortRangeMin: Int(443), PortRangeMax: Int(443)},
+		{Direction: String(SecurityGroupRuleIngress), Protocol: String("tcp"), PortRangeMin: Int(443), PortRangeMax: Int(443), Description: String("duplicate")},
+	}
+	missing, unwanted := diffSecurityGroupRules(desired, actual)
+	if len(missing) != 1 || *missing[0].PortRangeMin != 443 {
+		t.Fatalf("Network.TestDiffSecurityGroupRules: expected the HTTPS rule to be missing, got %+v", missing)
+	}
+	if len(unwanted) != 1 || *unwanted[0].ID != "egress-v6" {
+		t.Fatalf("Network.TestDiffSecurityGroupRules: expected the IPv6 egress rule to be unwanted, got %+v", unwanted)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST SUBNETS
+ */
+
+// ListSubnetsOptions provides the options available for filtering the list of
+// subnets; Limit sets the page size, all pages are retrieved anyway; see
+// https://developer.openstack.org/api-ref/network/v2/#list-subnets.
+type ListSubnetsOptions struct {
+	ID           *string             `parameter:"id,omitempty" header:"-" json:"-"`
+	Name         *string             `parameter:"name,omitempty" header:"-" json:"-"`
+	NetworkID    *string             `parameter:"network_id,omitempty" header:"-" json:"-"`
+	IPVersion    *int                `parameter:"ip_version,omitempty" header:"-" json:"-"`
+	CIDR         *string             `parameter:"cidr,omitempty" header:"-" json:"-"`
+	GatewayIP    *string             `parameter:"gateway_ip,omitempty" header:"-" json:"-"`
+	EnableDHCP   *bool               `parameter:"enable_dhcp,omitempty" header:"-" json:"-"`
+	SubnetPoolID *string             `parameter:"subnetpool_id,omitempty" header:"-" json:"-"`
+	ProjectID    *string             `parameter:"project_id,omitempty" header:"-" json:"-"`
+	Tags         *CommaSeparatedList `parameter:"tags,omitempty" header:"-" json:"-"`
+	TagsAny      *CommaSeparatedList `parameter:"tags-any,omitempty" header:"-" json:"-"`
+	NotTags      *CommaSeparatedList `parameter:"not-tags,omitempty" header:"-" json:"-"`
+	NotTagsAny   *CommaSeparatedList `parameter:"not-tags-any,omitempty" header:"-" json:"-"`
+	SortKey      *string             `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir      *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit        *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker       *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListSubnets returns the list of subnets visible to the current project; see
+// also https://developer.openstack.org/api-ref/network/v2/#list-subnets
+func (api *NetworkV2API) ListSubnets(opts *ListSubnetsOptions) (*[]Subnet, *Result, error) {
+	subnets := []Subnet{}
+	result, err := api.listResources("./v2.0/subnets", "subnets", opts, collectionLinks,
+		func() interface{} { return &[]Subnet{} },
+		func(page interface{}) { subnets = append(subnets, *page.(*[]Subnet)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &subnets, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE SUBNET
+ */
+
+// CreateSubnetOptions provides the options available for creating a subnet:
+// NetworkID and IPVersion are mandatory, and so is CIDR unless the subnet is
+// allocated from SubnetPoolID; GatewayIP defaults to the first address of the
+// CIDR; ProjectID is reserved to administrators by default.
+type CreateSubnetOptions struct {
+	Name            *string           `json:"name,omitempty"`
+	Description     *string           `json:"description,omitempty"`
+	NetworkID       *string           `json:"network_id,omitempty"`
+	IPVersion       *int              `json:"ip_version,omitempty"`
+	CIDR            *string           `json:"cidr,omitempty"`
+	GatewayIP       *string           `json:"gateway_ip,omitempty"`
+	EnableDHCP      *bool             `json:"enable_dhcp,omitempty"`
+	AllocationPools *[]AllocationPool `json:"allocation_pools,omitempty"`
+	DNSNameservers  *[]string         `json:"dns_nameservers,omitempty"`
+	HostRoutes      *[]HostRoute      `json:"host_routes,omitempty"`
+	IPv6AddressMode *string           `json:"ipv6_address_mode,omitempty"`
+	IPv6RAMode      *string           `json:"ipv6_ra_mode,omitempty"`
+	SubnetPoolID    *string           `json:"subnetpool_id,omitempty"`
+	PrefixLength    *int              `json:"prefixlen,omitempty"`
+	SegmentID       *string           `json:"segment_id,omitempty"`
+	ProjectID       *string           `json:"project_id,omitempty"`
+}
+
+// CreateSubnet creates a new subnet on a network; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-subnet
+func (api *NetworkV2API) CreateSubnet(opts *CreateSubnetOptions) (*Subnet, *Result, error) {
+	subnet := &Subnet{}
+	result, err := api.createResource("./v2.0/subnets", "subnet", opts, subnet)
+	if result != nil && result.Code == 201 {
+		return subnet, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SUBNET
+ */
+
+// RetrieveSubnet retrieves the details of the subnet identified by the given
+// id; see also https://developer.openstack.org/api-ref/network/v2/#show-subnet-details
+func (api *NetworkV2API) RetrieveSubnet(subnetid string) (*Subnet, *Result, error) {
+	subnet := &Subnet{}
+	result, err := api.retrieveResource("./v2.0/subnets/{id}", subnetid, "subnet", subnet)
+	if result != nil && result.Code == 200 {
+		return subnet, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE SUBNET
+ */
+
+// UpdateSubnetOptions provides the options available for updating a subnet;
+// only the given attributes are modified.
+type UpdateSubnetOptions struct {
+	Name            *string           `json:"name,omitempty"`
+	Description     *string           `json:"description,omitempty"`
+	GatewayIP       *string           `json:"gateway_ip,omitempty"`
+	EnableDHCP      *bool             `json:"enable_dhcp,omitempty"`
+	AllocationPools *[]AllocationPool `json:"allocation_pools,omitempty"`
+	DNSNameservers  *[]string         `json:"dns_nameservers,omitempty"`
+	HostRoutes      *[]HostRoute      `json:"host_routes,omitempty"`
+	SegmentID       *string           `json:"segment_id,omitempty"`
+}
+
+// UpdateSubnet updates the subnet identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-subnet
+func (api *NetworkV2API) UpdateSubnet(subnetid string, opts *UpdateSubnetOptions) (*Subnet, *Result, error) {
+	subnet := &Subnet{}
+	result, err := api.updateResource("./v2.0/subnets/{id}", subnetid, "subnet", opts, subnet)
+	if result != nil && result.Code == 200 {
+		return subnet, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE SUBNET
+ */
+
+// DeleteSubnet deletes the subnet identified by the given id; it fails if there
+// are ports with addresses on the subnet; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-subnet
+func (api *NetworkV2API) DeleteSubnet(subnetid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/subnets/{id}", subnetid)
+}
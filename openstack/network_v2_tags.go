@@ -0,0 +1,177 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// TagResourceNetworks is the resource type of network tags.
+	TagResourceNetworks = "networks"
+	// TagResourceSubnets is the resource type of subnet tags.
+	TagResourceSubnets = "subnets"
+	// TagResourcePorts is the resource type of port tags.
+	TagResourcePorts = "ports"
+	// TagResourceRouters is the resource type of router tags.
+	TagResourceRouters = "routers"
+	// TagResourceSecurityGroups is the resource type of security group tags.
+	TagResourceSecurityGroups = "security-groups"
+	// TagResourceFloatingIPs is the resource type of floating IP tags.
+	TagResourceFloatingIPs = "floatingips"
+	// TagResourceTrunks is the resource type of trunk tags.
+	TagResourceTrunks = "trunks"
+)
+
+// tagsRequest is the request entity of the tag calls, which address the tags
+// of a resource of the given type (e.g. TagResourceNetworks) and id, or one of
+// its tags.
+type tagsRequest struct {
+	ResourceType string    `parameter:"-" header:"-" variable:"resourcetype" json:"-"`
+	ResourceID   string    `parameter:"-" header:"-" variable:"resourceid" json:"-"`
+	Tag          string    `parameter:"-" header:"-" variable:"tag" json:"-"`
+	Tags         *[]string `parameter:"-" header:"-" json:"tags,omitempty"`
+}
+
+/*
+ * LIST TAGS
+ */
+
+// ListTags returns the tags of the resource of the given type (e.g.
+// TagResourcePorts) identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#obtain-tag-list
+func (api *NetworkV2API) ListTags(resourcetype string, resourceid string) (*[]string, *Result, error) {
+	input := &tagsRequest{
+		ResourceType: resourcetype,
+		ResourceID:   resourceid,
+	}
+	output := &struct {
+		Tags *[]string `header:"-" json:"tags,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodGet, "./v2.0/{resourcetype}/{resourceid}/tags", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		if output.Tags == nil {
+			output.Tags = &[]string{}
+		}
+		return output.Tags, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CHECK TAG
+ */
+
+// HasTag checks whether the resource of the given type identified by the given
+// id has the given tag; see also
+// https://developer.openstack.org/api-ref/network/v2/#confirm-a-tag
+func (api *NetworkV2API) HasTag(resourcetype string, resourceid string, tag string) (bool, *Result, error) {
+	input := &tagsRequest{
+		ResourceType: resourcetype,
+		ResourceID:   resourceid,
+		Tag:          url.PathEscape(tag),
+	}
+
+	result, err := api.Invoke(http.MethodGet, "./v2.0/{resourcetype}/{resourceid}/tags/{tag}", true, StatusCodeIn(204, 404), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * ADD TAG
+ */
+
+// AddTag adds the given tag to the resource of the given type identified by
+// the given id; adding an existing tag is not an error; see also
+// https://developer.openstack.org/api-ref/network/v2/#add-a-tag
+func (api *NetworkV2API) AddTag(resourcetype string, resourceid string, tag string) (bool, *Result, error) {
+	input := &tagsRequest{
+		ResourceType: resourcetype,
+		ResourceID:   resourceid,
+		Tag:          url.PathEscape(tag),
+	}
+
+	result, err := api.Invoke(http.MethodPut, "./v2.0/{resourcetype}/{resourceid}/tags/{tag}", true, StatusCodeIn(201), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * REPLACE TAGS
+ */
+
+// ReplaceTags replaces all the tags of the resource of the given type identified
+// by the given id with the given ones, returning the new tags; see also
+// https://developer.openstack.org/api-ref/network/v2/#replace-all-tags
+func (api *NetworkV2API) ReplaceTags(resourcetype string, resourceid string, tags []string) (*[]string, *Result, error) {
+	input := &tagsRequest{
+		ResourceType: resourcetype,
+		ResourceID:   resourceid,
+		Tags:         &tags,
+	}
+	output := &struct {
+		Tags *[]string `header:"-" json:"tags,omitempty"`
+	}{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2.0/{resourcetype}/{resourceid}/tags", true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		if output.Tags == nil {
+			output.Tags = &[]string{}
+		}
+		return output.Tags, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * REMOVE TAGS
+ */
+
+// RemoveTag removes the given tag from the resource of the given type identified
+// by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-a-tag
+func (api *NetworkV2API) RemoveTag(resourcetype string, resourceid string, tag string) (bool, *Result, error) {
+	input := &tagsRequest{
+		ResourceType: resourcetype,
+		ResourceID:   resourceid,
+		Tag:          url.PathEscape(tag),
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v2.0/{resourcetype}/{resourceid}/tags/{tag}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// RemoveAllTags removes all the tags of the resource of the given type
+// identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-all-tags
+func (api *NetworkV2API) RemoveAllTags(resourcetype string, resourceid string) (bool, *Result, error) {
+	input := &tagsRequest{
+		ResourceType: resourcetype,
+		ResourceID:   resourceid,
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./v2.0/{resourcetype}/{resourceid}/tags", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
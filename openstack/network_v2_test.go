@@ -0,0 +1,73 @@
+package openstack
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestNetworkV2API(url string) *NetworkV2API {
+	client := NewDefaultClient(url)
+	client.Authenticator.SetToken(&Token{Value: String("test-token")})
+	return &NetworkV2API{client.Authenticator.Identity.API}
+}
+
+func TestListPortsPagination(t *testing.T) {
+	var url string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("marker") {
+		case "":
+			if r.URL.Query().Get("network_id") != "n1" || r.URL.Query().Get("limit") != "2" {
+				t.Errorf("unexpected query: %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"ports": [{"id": "p1"}, {"id": "p2"}], "ports_links": [{"rel": "next", "href": "` + url + `/v2.0/ports?limit=2&marker=p2&network_id=n1"}]}`))
+		case "p2":
+			w.Write([]byte(`{"ports": [{"id": "p3"}], "ports_links": [{"rel": "previous", "href": "` + url + `/v2.0/ports?limit=2&marker=p3&page_reverse=True&network_id=n1"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	url = server.URL
+
+	ports, _, err := newTestNetworkV2API(server.URL).ListPorts(&ListPortsOptions{
+		NetworkID: String("n1"),
+		Limit:     Int(2),
+	})
+	if err != nil {
+		t.Fatalf("Network.TestListPortsPagination: unexpected error: %v", err)
+	}
+	if ports == nil || len(*ports) != 3 || *(*ports)[2].ID != "p3" {
+		t.Fatalf("Network.TestListPortsPagination: expected 3 ports across 2 pages, got %v", ports)
+	}
+}
+
+func TestCreatePorts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			Ports []map[string]interface{} `json:"ports"`
+		}{}
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &request); err != nil || r.Method != http.MethodPost || len(request.Ports) != 2 {
+			t.Errorf("unexpected bulk request: %s %s", r.Method, body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"ports": [{"id": "p1", "network_id": "n1"}, {"id": "p2", "network_id": "n1"}]}`))
+	}))
+	defer server.Close()
+
+	ports, _, err := newTestNetworkV2API(server.URL).CreatePorts([]CreatePortOptions{
+		{NetworkID: String("n1"), Name: String("a")},
+		{NetworkID: String("n1"), Name: String("b")},
+	})
+	if err != nil {
+		t.Fatalf("Network.TestCreatePorts: unexpected error: %v", err)
+	}
+	if ports == nil || len(*ports) != 2 || *(*ports)[1].ID != "p2" {
+		t.Fatalf("Network.TestCreatePorts: expected 2 ports, got %v", ports)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// SegmentationTypeVLAN is the segmentation type of subports tagged with a
+	// VLAN ID.
+	SegmentationTypeVLAN = "vlan"
+	// SegmentationTypeInherit is the segmentation type of subports taking the
+	// segmentation type and ID from their network.
+	SegmentationTypeInherit = "inherit"
+)
+
+/*
+ * LIST TRUNKS
+ */
+
+// ListTrunksOptions provides the options available for filtering the list of
+// trunks; Limit sets the page size, all pages are retrieved anyway.
+type ListTrunksOptions struct {
+	ID           *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name         *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Description  *string `parameter:"description,omitempty" header:"-" json:"-"`
+	PortID       *string `parameter:"port_id,omitempty" header:"-" json:"-"`
+	Status       *string `parameter:"status,omitempty" header:"-" json:"-"`
+	AdminStateUp *bool   `parameter:"admin_state_up,omitempty" header:"-" json:"-"`
+	ProjectID    *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey      *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir      *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit        *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker       *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListTrunks returns the list of trunks, with their subports; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-trunks
+func (api *NetworkV2API) ListTrunks(opts *ListTrunksOptions) (*[]Trunk, *Result, error) {
+	trunks := []Trunk{}
+	result, err := api.listResources("./v2.0/trunks", "trunks", opts, collectionLinks,
+		func() interface{} { return &[]Trunk{} },
+		func(page interface{}) { trunks = append(trunks, *page.(*[]Trunk)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &trunks, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE TRUNK
+ */
+
+// CreateTrunkOptions provides the options available for creating a trunk:
+// PortID, the parent port, is mandatory and must not be bound to a server yet
+// with most drivers; subports can be given right away or added later.
+type CreateTrunkOptions struct {
+	PortID       *string         `json:"port_id,omitempty"`
+	Name         *string         `json:"name,omitempty"`
+	Description  *string         `json:"description,omitempty"`
+	AdminStateUp *bool           `json:"admin_state_up,omitempty"`
+	SubPorts     *[]TrunkSubPort `json:"sub_ports,omitempty"`
+	ProjectID    *string         `json:"project_id,omitempty"`
+}
+
+// CreateTrunk creates a new trunk on the given parent port; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-trunk
+func (api *NetworkV2API) CreateTrunk(opts *CreateTrunkOptions) (*Trunk, *Result, error) {
+	trunk := &Trunk{}
+	result, err := api.createResource("./v2.0/trunks", "trunk", opts, trunk)
+	if result != nil && result.Code == 201 {
+		return trunk, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE TRUNK
+ */
+
+// RetrieveTrunk retrieves the details of the trunk identified by the given id;
+// see also https://developer.openstack.org/api-ref/network/v2/#show-trunk
+func (api *NetworkV2API) RetrieveTrunk(trunkid string) (*Trunk, *Result, error) {
+	trunk := &Trunk{}
+	result, err := api.retrieveResource("./v2.0/trunks/{id}", trunkid, "trunk", trunk)
+	if result != nil && result.Code == 200 {
+		return trunk, result, err
+	}
+	return nil, result, err
+}
+
+// ListTrunkSubPorts returns the subports of the trunk identified by the given
+// id; see also https://developer.openstack.org/api-ref/network/v2/#list-subports-for-trunk
+func (api *NetworkV2API) ListTrunkSubPorts(trunkid string) (*[]TrunkSubPort, *Result, error) {
+	subports := &[]TrunkSubPort{}
+	result, err := api.retrieveResource("./v2.0/trunks/{id}/get_subports", trunkid, "sub_ports", subports)
+	if result != nil && result.Code == 200 {
+		return subports, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE TRUNK
+ */
+
+// UpdateTrunkOptions provides the options available for updating a trunk; the
+// subports are managed with AddTrunkSubPorts and RemoveTrunkSubPorts.
+type UpdateTrunkOptions struct {
+	Name         *string `json:"name,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	AdminStateUp *bool   `json:"admin_state_up,omitempty"`
+}
+
+// UpdateTrunk updates the trunk identified by the given id; a trunk whose admin
+// state is down cannot have its subports changed; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-trunk
+func (api *NetworkV2API) UpdateTrunk(trunkid string, opts *UpdateTrunkOptions) (*Trunk, *Result, error) {
+	trunk := &Trunk{}
+	result, err := api.updateResource("./v2.0/trunks/{id}", trunkid, "trunk", opts, trunk)
+	if result != nil && result.Code == 200 {
+		return trunk, result, err
+	}
+	return nil, result, err
+}
+
+// AddTrunkSubPorts adds the given subports, each with its segmentation type
+// and ID, to the trunk identified by the given id, returning the updated trunk;
+// see also https://developer.openstack.org/api-ref/network/v2/#add-subports-to-trunk
+func (api *NetworkV2API) AddTrunkSubPorts(trunkid string, subports []TrunkSubPort) (*Trunk, *Result, error) {
+	return api.updateTrunkSubPorts(trunkid, "add_subports", subports)
+}
+
+// RemoveTrunkSubPorts removes the subports with the given port ids from the
+// trunk identified by the given id, returning the updated trunk; the ports
+// themselves are not deleted; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-subports-from-trunk
+func (api *NetworkV2API) RemoveTrunkSubPorts(trunkid string, portids []string) (*Trunk, *Result, error) {
+	subports := []TrunkSubPort{}
+	for _, portid := range portids {
+		subports = append(subports, TrunkSubPort{PortID: String(portid)})
+	}
+	return api.updateTrunkSubPorts(trunkid, "remove_subports", subports)
+}
+
+// updateTrunkSubPorts adds subports to or removes subports from a trunk; the
+// response is the trunk itself, not wrapped in an envelope.
+func (api *NetworkV2API) updateTrunkSubPorts(trunkid string, action string, subports []TrunkSubPort) (*Trunk, *Result, error) {
+	input := &struct {
+		TrunkID  string         `parameter:"-" header:"-" variable:"trunkid" json:"-"`
+		SubPorts []TrunkSubPort `parameter:"-" header:"-" json:"sub_ports"`
+	}{
+		TrunkID:  trunkid,
+		SubPorts: subports,
+	}
+	output := &Trunk{}
+
+	result, err := api.Invoke(http.MethodPut, "./v2.0/trunks/{trunkid}/"+action, true, StatusCodeIn(200), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE TRUNK
+ */
+
+// DeleteTrunk deletes the trunk identified by the given id; the parent port and
+// the subports are not deleted; see also
+// https://developer.openstack.org/api-ref/network/v2/#delete-trunk
+func (api *NetworkV2API) DeleteTrunk(trunkid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/trunks/{id}", trunkid)
+}
@@ -0,0 +1,619 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * NETWORKS
+ */
+
+// Network is an isolated layer-2 network segment; attributes with the
+// "provider:" prefix describe the physical implementation of the network and are
+// only visible to administrators; networks with multiple segments (e.g. routed
+// provider networks) report them in Segments instead.
+type Network struct {
+	ID                    *string           `json:"id,omitempty"`
+	Name                  *string           `json:"name,omitempty"`
+	Description           *string           `json:"description,omitempty"`
+	AdminStateUp          *bool             `json:"admin_state_up,omitempty"`
+	Status                *string           `json:"status,omitempty"`
+	Shared                *bool             `json:"shared,omitempty"`
+	External              *bool             `json:"router:external,omitempty"`
+	IsDefault             *bool             `json:"is_default,omitempty"`
+	MTU                   *int              `json:"mtu,omitempty"`
+	PortSecurityEnabled   *bool             `json:"port_security_enabled,omitempty"`
+	ProjectID             *string           `json:"project_id,omitempty"`
+	TenantID              *string           `json:"tenant_id,omitempty"`
+	Subnets               *[]string         `json:"subnets,omitempty"`
+	NetworkType           *string           `json:"provider:network_type,omitempty"`
+	PhysicalNetwork       *string           `json:"provider:physical_network,omitempty"`
+	SegmentationID        *int              `json:"provider:segmentation_id,omitempty"`
+	Segments              *[]NetworkSegment `json:"segments,omitempty"`
+	QoSPolicyID           *string           `json:"qos_policy_id,omitempty"`
+	DNSDomain             *string           `json:"dns_domain,omitempty"`
+	IPv4AddressScope      *string           `json:"ipv4_address_scope,omitempty"`
+	IPv6AddressScope      *string           `json:"ipv6_address_scope,omitempty"`
+	L2Adjacency           *bool             `json:"l2_adjacency,omitempty"`
+	AvailabilityZones     *[]string         `json:"availability_zones,omitempty"`
+	AvailabilityZoneHints *[]string         `json:"availability_zone_hints,omitempty"`
+	Tags                  *[]string         `json:"tags,omitempty"`
+	CreatedAt             *string           `json:"created_at,omitempty"`
+	UpdatedAt             *string           `json:"updated_at,omitempty"`
+	RevisionNumber        *int              `json:"revision_number,omitempty"`
+}
+
+// NetworkSegment is one of the segments of a multi-segment network, e.g. a VLAN
+// on a given physical network.
+type NetworkSegment struct {
+	NetworkType     *string `json:"provider:network_type,omitempty"`
+	PhysicalNetwork *string `json:"provider:physical_network,omitempty"`
+	SegmentationID  *int    `json:"provider:segmentation_id,omitempty"`
+}
+
+/*
+ * SUBNETS
+ */
+
+// Subnet is a block of IPv4 or IPv6 addresses on a network, from which the
+// ports on the network get their fixed IP addresses; GatewayIP is the address
+// of the router interface on the subnet, if any.
+type Subnet struct {
+	ID              *string           `json:"id,omitempty"`
+	Name            *string           `json:"name,omitempty"`
+	Description     *string           `json:"description,omitempty"`
+	NetworkID       *string           `json:"network_id,omitempty"`
+	IPVersion       *int              `json:"ip_version,omitempty"`
+	CIDR            *string           `json:"cidr,omitempty"`
+	GatewayIP       *string           `json:"gateway_ip,omitempty"`
+	EnableDHCP      *bool             `json:"enable_dhcp,omitempty"`
+	AllocationPools *[]AllocationPool `json:"allocation_pools,omitempty"`
+	DNSNameservers  *[]string         `json:"dns_nameservers,omitempty"`
+	HostRoutes      *[]HostRoute      `json:"host_routes,omitempty"`
+	IPv6AddressMode *string           `json:"ipv6_address_mode,omitempty"`
+	IPv6RAMode      *string           `json:"ipv6_ra_mode,omitempty"`
+	SubnetPoolID    *string           `json:"subnetpool_id,omitempty"`
+	SegmentID       *string           `json:"segment_id,omitempty"`
+	ProjectID       *string           `json:"project_id,omitempty"`
+	TenantID        *string           `json:"tenant_id,omitempty"`
+	Tags            *[]string         `json:"tags,omitempty"`
+	CreatedAt       *string           `json:"created_at,omitempty"`
+	UpdatedAt       *string           `json:"updated_at,omitempty"`
+	RevisionNumber  *int              `json:"revision_number,omitempty"`
+}
+
+// AllocationPool is a range of addresses of a subnet that are automatically
+// assigned to the ports on its network.
+type AllocationPool struct {
+	Start *string `json:"start,omitempty"`
+	End   *string `json:"end,omitempty"`
+}
+
+// HostRoute is a static route pushed through DHCP to the servers on a subnet.
+type HostRoute struct {
+	Destination *string `json:"destination,omitempty"`
+	NextHop     *string `json:"nexthop,omitempty"`
+}
+
+/*
+ * PORTS
+ */
+
+// Port is a connection point for a device (e.g. a server interface or a router
+// interface) on a network; the "binding:" attributes describe how the port is
+// bound to the host where the device is, and are only visible to administrators.
+type Port struct {
+	ID                  *string                 `json:"id,omitempty"`
+	Name                *string                 `json:"name,omitempty"`
+	Description         *string                 `json:"description,omitempty"`
+	NetworkID           *string                 `json:"network_id,omitempty"`
+	AdminStateUp        *bool                   `json:"admin_state_up,omitempty"`
+	Status              *string                 `json:"status,omitempty"`
+	MACAddress          *string                 `json:"mac_address,omitempty"`
+	FixedIPs            *[]FixedIP              `json:"fixed_ips,omitempty"`
+	DeviceID            *string                 `json:"device_id,omitempty"`
+	DeviceOwner         *string                 `json:"device_owner,omitempty"`
+	SecurityGroups      *[]string               `json:"security_groups,omitempty"`
+	PortSecurityEnabled *bool                   `json:"port_security_enabled,omitempty"`
+	AllowedAddressPairs *[]AllowedAddressPair   `json:"allowed_address_pairs,omitempty"`
+	BindingHostID       *string                 `json:"binding:host_id,omitempty"`
+	BindingVNICType     *string                 `json:"binding:vnic_type,omitempty"`
+	BindingProfile      *map[string]interface{} `json:"binding:profile,omitempty"`
+	BindingVIFType      *string                 `json:"binding:vif_type,omitempty"`
+	BindingVIFDetails   *map[string]interface{} `json:"binding:vif_details,omitempty"`
+	DNSName             *string                 `json:"dns_name,omitempty"`
+	QoSPolicyID         *string                 `json:"qos_policy_id,omitempty"`
+	ProjectID           *string                 `json:"project_id,omitempty"`
+	TenantID            *string                 `json:"tenant_id,omitempty"`
+	Tags                *[]string               `json:"tags,omitempty"`
+	CreatedAt           *string                 `json:"created_at,omitempty"`
+	UpdatedAt           *string                 `json:"updated_at,omitempty"`
+	RevisionNumber      *int                    `json:"revision_number,omitempty"`
+}
+
+// PortBinding is one of the bindings of a port to a host: a port has one active
+// binding, plus an inactive one on the destination host during a live migration.
+type PortBinding struct {
+	Host       *string                 `json:"host,omitempty"`
+	VNICType   *string                 `json:"vnic_type,omitempty"`
+	Profile    *map[string]interface{} `json:"profile,omitempty"`
+	VIFType    *string                 `json:"vif_type,omitempty"`
+	VIFDetails *map[string]interface{} `json:"vif_details,omitempty"`
+	Status     *string                 `json:"status,omitempty"`
+}
+
+// AllowedAddressPair is an additional IP address (or CIDR) and, optionally, MAC
+// address that the port is allowed to send traffic from, despite the anti-spoofing
+// rules, e.g. a virtual IP address shared by VRRP peers; the MAC address defaults
+// to that of the port.
+type AllowedAddressPair struct {
+	IPAddress  *string `json:"ip_address,omitempty"`
+	MACAddress *string `json:"mac_address,omitempty"`
+}
+
+/*
+ * ROUTERS
+ */
+
+// Router is a logical router, forwarding packets between the networks it has
+// interfaces on and, through its external gateway, to an external network.
+type Router struct {
+	ID                    *string            `json:"id,omitempty"`
+	Name                  *string            `json:"name,omitempty"`
+	Description           *string            `json:"description,omitempty"`
+	AdminStateUp          *bool              `json:"admin_state_up,omitempty"`
+	Status                *string            `json:"status,omitempty"`
+	ProjectID             *string            `json:"project_id,omitempty"`
+	TenantID              *string            `json:"tenant_id,omitempty"`
+	ExternalGatewayInfo   *RouterGatewayInfo `json:"external_gateway_info,omitempty"`
+	Routes                *[]RouterRoute     `json:"routes,omitempty"`
+	Distributed           *bool              `json:"distributed,omitempty"`
+	HA                    *bool              `json:"ha,omitempty"`
+	FlavorID              *string            `json:"flavor_id,omitempty"`
+	AvailabilityZones     *[]string          `json:"availability_zones,omitempty"`
+	AvailabilityZoneHints *[]string          `json:"availability_zone_hints,omitempty"`
+	Tags                  *[]string          `json:"tags,omitempty"`
+	CreatedAt             *string            `json:"created_at,omitempty"`
+	UpdatedAt             *string            `json:"updated_at,omitempty"`
+	RevisionNumber        *int               `json:"revision_number,omitempty"`
+}
+
+// RouterGatewayInfo describes the external gateway of a router: the external
+// network, whether SNAT is enabled (admin-only by default) and the IP addresses
+// of the router on the external network.
+type RouterGatewayInfo struct {
+	NetworkID        *string    `json:"network_id,omitempty"`
+	EnableSNAT       *bool      `json:"enable_snat,omitempty"`
+	ExternalFixedIPs *[]FixedIP `json:"external_fixed_ips,omitempty"`
+}
+
+// RouterRoute is a static route on a router.
+type RouterRoute struct {
+	Destination *string `json:"destination,omitempty"`
+	NextHop     *string `json:"nexthop,omitempty"`
+}
+
+// RouterInterface is an interface of a router on a subnet, as returned when the
+// interface is added or removed.
+type RouterInterface struct {
+	ID        *string   `json:"id,omitempty"`
+	SubnetID  *string   `json:"subnet_id,omitempty"`
+	SubnetIDs *[]string `json:"subnet_ids,omitempty"`
+	PortID    *string   `json:"port_id,omitempty"`
+	NetworkID *string   `json:"network_id,omitempty"`
+	ProjectID *string   `json:"project_id,omitempty"`
+	TenantID  *string   `json:"tenant_id,omitempty"`
+	Tags      *[]string `json:"tags,omitempty"`
+}
+
+/*
+ * FLOATING IPS
+ */
+
+// FloatingIP is a public IP address allocated on an external network that can
+// be associated to a port (and hence to a server) on a network connected to the
+// external network by a router; FloatingIPAddress is the public address, while
+// FixedIPAddress is the private address on the associated port.
+type FloatingIP struct {
+	ID                *string   `json:"id,omitempty"`
+	FloatingIPAddress *string   `json:"floating_ip_address,omitempty"`
+	FloatingNetworkID *string   `json:"floating_network_id,omitempty"`
+	FixedIPAddress    *string   `json:"fixed_ip_address,omitempty"`
+	PortID            *string   `json:"port_id,omitempty"`
+	RouterID          *string   `json:"router_id,omitempty"`
+	Status            *string   `json:"status,omitempty"`
+	Description       *string   `json:"description,omitempty"`
+	ProjectID         *string   `json:"project_id,omitempty"`
+	TenantID          *string   `json:"tenant_id,omitempty"`
+	DNSDomain         *string   `json:"dns_domain,omitempty"`
+	DNSName           *string   `json:"dns_name,omitempty"`
+	QoSPolicyID       *string   `json:"qos_policy_id,omitempty"`
+	Tags              *[]string `json:"tags,omitempty"`
+	CreatedAt         *string   `json:"created_at,omitempty"`
+	UpdatedAt         *string   `json:"updated_at,omitempty"`
+	RevisionNumber    *int      `json:"revision_number,omitempty"`
+}
+
+/*
+ * SECURITY GROUPS
+ */
+
+// SecurityGroup is a named set of rules filtering the traffic to and from the
+// ports it is applied to; stateless groups (Stateful false) require the
+// stateful-security-group extension.
+type SecurityGroup struct {
+	ID             *string              `json:"id,omitempty"`
+	Name           *string              `json:"name,omitempty"`
+	Description    *string              `json:"description,omitempty"`
+	ProjectID      *string              `json:"project_id,omitempty"`
+	TenantID       *string              `json:"tenant_id,omitempty"`
+	Stateful       *bool                `json:"stateful,omitempty"`
+	Shared         *bool                `json:"shared,omitempty"`
+	Rules          *[]SecurityGroupRule `json:"security_group_rules,omitempty"`
+	Tags           *[]string            `json:"tags,omitempty"`
+	CreatedAt      *string              `json:"created_at,omitempty"`
+	UpdatedAt      *string              `json:"updated_at,omitempty"`
+	RevisionNumber *int                 `json:"revision_number,omitempty"`
+}
+
+// SecurityGroupRule is a rule of a security group, allowing the traffic in the
+// given direction ("ingress" or "egress") for the given ether type ("IPv4" or
+// "IPv6"), protocol and port range, from or to the given remote IP prefix or
+// security group; a nil protocol or port range means any.
+type SecurityGroupRule struct {
+	ID                   *string `json:"id,omitempty"`
+	SecurityGroupID      *string `json:"security_group_id,omitempty"`
+	Direction            *string `json:"direction,omitempty"`
+	EtherType            *string `json:"ethertype,omitempty"`
+	Protocol             *string `json:"protocol,omitempty"`
+	PortRangeMin         *int    `json:"port_range_min,omitempty"`
+	PortRangeMax         *int    `json:"port_range_max,omitempty"`
+	RemoteIPPrefix       *string `json:"remote_ip_prefix,omitempty"`
+	RemoteGroupID        *string `json:"remote_group_id,omitempty"`
+	RemoteAddressGroupID *string `json:"remote_address_group_id,omitempty"`
+	Description          *string `json:"description,omitempty"`
+	ProjectID            *string `json:"project_id,omitempty"`
+	TenantID             *string `json:"tenant_id,omitempty"`
+	CreatedAt            *string `json:"created_at,omitempty"`
+	UpdatedAt            *string `json:"updated_at,omitempty"`
+	RevisionNumber       *int    `json:"revision_number,omitempty"`
+}
+
+/*
+ * QUOTAS
+ */
+
+// NetworkQuota is the set of limits on the number of Networking resources a
+// project can own; a value of -1 means unlimited; ProjectID is only set when
+// listing the quotas of all projects.
+type NetworkQuota struct {
+	ProjectID         *string `json:"project_id,omitempty"`
+	Network           *int    `json:"network,omitempty"`
+	Subnet            *int    `json:"subnet,omitempty"`
+	SubnetPool        *int    `json:"subnetpool,omitempty"`
+	Port              *int    `json:"port,omitempty"`
+	Router            *int    `json:"router,omitempty"`
+	FloatingIP        *int    `json:"floatingip,omitempty"`
+	SecurityGroup     *int    `json:"security_group,omitempty"`
+	SecurityGroupRule *int    `json:"security_group_rule,omitempty"`
+	RBACPolicy        *int    `json:"rbac_policy,omitempty"`
+	Trunk             *int    `json:"trunk,omitempty"`
+}
+
+// NetworkQuotaUsage is the usage of a Networking resource by a project, against
+// its quota limit.
+type NetworkQuotaUsage struct {
+	Used     *int `json:"used,omitempty"`
+	Reserved *int `json:"reserved,omitempty"`
+	Limit    *int `json:"limit,omitempty"`
+}
+
+/*
+ * TRUNKS
+ */
+
+// Trunk is a parent port carrying the traffic of several networks to a server,
+// each on its own subport tagged with a segmentation ID (e.g. a VLAN ID), so
+// that the guest sees one interface with VLAN sub-interfaces.
+type Trunk struct {
+	ID             *string         `json:"id,omitempty"`
+	Name           *string         `json:"name,omitempty"`
+	Description    *string         `json:"description,omitempty"`
+	PortID         *string         `json:"port_id,omitempty"`
+	AdminStateUp   *bool           `json:"admin_state_up,omitempty"`
+	Status         *string         `json:"status,omitempty"`
+	SubPorts       *[]TrunkSubPort `json:"sub_ports,omitempty"`
+	ProjectID      *string         `json:"project_id,omitempty"`
+	TenantID       *string         `json:"tenant_id,omitempty"`
+	Tags           *[]string       `json:"tags,omitempty"`
+	CreatedAt      *string         `json:"created_at,omitempty"`
+	UpdatedAt      *string         `json:"updated_at,omitempty"`
+	RevisionNumber *int            `json:"revision_number,omitempty"`
+}
+
+// TrunkSubPort is a port attached to a trunk, whose traffic is tagged with the
+// given segmentation type (e.g. "vlan", or "inherit" to use that of the subport
+// network) and ID; only the port ID is needed when removing a subport.
+type TrunkSubPort struct {
+	PortID           *string `json:"port_id,omitempty"`
+	SegmentationType *string `json:"segmentation_type,omitempty"`
+	SegmentationID   *int    `json:"segmentation_id,omitempty"`
+}
+
+/*
+ * AGENTS
+ */
+
+// Agent is a Networking agent running on a host (e.g. "Open vSwitch agent",
+// "DHCP agent", "L3 agent"); Alive reports whether its heartbeats are being
+// received; Configurations is agent-specific.
+type Agent struct {
+	ID                 *string                 `json:"id,omitempty"`
+	AgentType          *string                 `json:"agent_type,omitempty"`
+	Binary             *string                 `json:"binary,omitempty"`
+	Host               *string                 `json:"host,omitempty"`
+	Topic              *string                 `json:"topic,omitempty"`
+	Description        *string                 `json:"description,omitempty"`
+	AdminStateUp       *bool                   `json:"admin_state_up,omitempty"`
+	Alive              *bool                   `json:"alive,omitempty"`
+	AvailabilityZone   *string                 `json:"availability_zone,omitempty"`
+	Configurations     *map[string]interface{} `json:"configurations,omitempty"`
+	ResourceVersions   *map[string]interface{} `json:"resource_versions,omitempty"`
+	ResourcesSynced    *bool                   `json:"resources_synced,omitempty"`
+	HAState            *string                 `json:"ha_state,omitempty"`
+	CreatedAt          *string                 `json:"created_at,omitempty"`
+	StartedAt          *string                 `json:"started_at,omitempty"`
+	HeartbeatTimestamp *string                 `json:"heartbeat_timestamp,omitempty"`
+}
+
+/*
+ * ADDRESS SCOPES
+ */
+
+// AddressScope is a range of addresses (of a given IP version) within which
+// the addresses are unique and routable without NAT; the subnet pools
+// associated with the scope draw their prefixes from it.
+type AddressScope struct {
+	ID        *string `json:"id,omitempty"`
+	Name      *string `json:"name,omitempty"`
+	IPVersion *int    `json:"ip_version,omitempty"`
+	Shared    *bool   `json:"shared,omitempty"`
+	ProjectID *string `json:"project_id,omitempty"`
+	TenantID  *string `json:"tenant_id,omitempty"`
+}
+
+// SubnetPool is a pool of prefixes from which subnets can be allocated, possibly
+// belonging to an address scope.
+type SubnetPool struct {
+	ID               *string   `json:"id,omitempty"`
+	Name             *string   `json:"name,omitempty"`
+	Description      *string   `json:"description,omitempty"`
+	Prefixes         *[]string `json:"prefixes,omitempty"`
+	DefaultPrefixLen *int      `json:"default_prefixlen,omitempty"`
+	MinPrefixLen     *int      `json:"min_prefixlen,omitempty"`
+	MaxPrefixLen     *int      `json:"max_prefixlen,omitempty"`
+	DefaultQuota     *int      `json:"default_quota,omitempty"`
+	AddressScopeID   *string   `json:"address_scope_id,omitempty"`
+	IPVersion        *int      `json:"ip_version,omitempty"`
+	Shared           *bool     `json:"shared,omitempty"`
+	IsDefault        *bool     `json:"is_default,omitempty"`
+	ProjectID        *string   `json:"project_id,omitempty"`
+	TenantID         *string   `json:"tenant_id,omitempty"`
+	Tags             *[]string `json:"tags,omitempty"`
+	CreatedAt        *string   `json:"created_at,omitempty"`
+	UpdatedAt        *string   `json:"updated_at,omitempty"`
+	RevisionNumber   *int      `json:"revision_number,omitempty"`
+}
+
+/*
+ * AVAILABILITY ZONES
+ */
+
+// NetworkAvailabilityZone is an availability zone of the Networking service
+// for a given kind of resource ("network", served by the DHCP agents, or
+// "router", served by the L3 agents).
+type NetworkAvailabilityZone struct {
+	Name     *string `json:"name,omitempty"`
+	Resource *string `json:"resource,omitempty"`
+	State    *string `json:"state,omitempty"`
+}
+
+/*
+ * FIREWALLS (FWAAS V2)
+ */
+
+// FirewallGroup applies a firewall policy to the traffic entering (ingress) and
+// one to the traffic leaving (egress) the ports in the group.
+type FirewallGroup struct {
+	ID                      *string   `json:"id,omitempty"`
+	Name                    *string   `json:"name,omitempty"`
+	Description             *string   `json:"description,omitempty"`
+	AdminStateUp            *bool     `json:"admin_state_up,omitempty"`
+	Status                  *string   `json:"status,omitempty"`
+	IngressFirewallPolicyID *string   `json:"ingress_firewall_policy_id,omitempty"`
+	EgressFirewallPolicyID  *string   `json:"egress_firewall_policy_id,omitempty"`
+	Ports                   *[]string `json:"ports,omitempty"`
+	Shared                  *bool     `json:"shared,omitempty"`
+	ProjectID               *string   `json:"project_id,omitempty"`
+	TenantID                *string   `json:"tenant_id,omitempty"`
+}
+
+// FirewallPolicy is an ordered list of firewall rules; Audited is reset to false
+// whenever the policy or its rules change.
+type FirewallPolicy struct {
+	ID            *string   `json:"id,omitempty"`
+	Name          *string   `json:"name,omitempty"`
+	Description   *string   `json:"description,omitempty"`
+	FirewallRules *[]string `json:"firewall_rules,omitempty"`
+	Audited       *bool     `json:"audited,omitempty"`
+	Shared        *bool     `json:"shared,omitempty"`
+	ProjectID     *string   `json:"project_id,omitempty"`
+	TenantID      *string   `json:"tenant_id,omitempty"`
+}
+
+// FirewallRule matches traffic by protocol, addresses and ports (a port or a
+// range, e.g. "8000:8080") and allows, denies or rejects it.
+type FirewallRule struct {
+	ID                         *string   `json:"id,omitempty"`
+	Name                       *string   `json:"name,omitempty"`
+	Description                *string   `json:"description,omitempty"`
+	Action                     *string   `json:"action,omitempty"`
+	Enabled                    *bool     `json:"enabled,omitempty"`
+	IPVersion                  *int      `json:"ip_version,omitempty"`
+	Protocol                   *string   `json:"protocol,omitempty"`
+	SourceIPAddress            *string   `json:"source_ip_address,omitempty"`
+	DestinationIPAddress       *string   `json:"destination_ip_address,omitempty"`
+	SourcePort                 *string   `json:"source_port,omitempty"`
+	DestinationPort            *string   `json:"destination_port,omitempty"`
+	SourceFirewallGroupID      *string   `json:"source_firewall_group_id,omitempty"`
+	DestinationFirewallGroupID *string   `json:"destination_firewall_group_id,omitempty"`
+	FirewallPolicyID           *[]string `json:"firewall_policy_id,omitempty"`
+	Shared                     *bool     `json:"shared,omitempty"`
+	ProjectID                  *string   `json:"project_id,omitempty"`
+	TenantID                   *string   `json:"tenant_id,omitempty"`
+}
+
+/*
+ * VPN (VPNAAS)
+ */
+
+// VPNService is the VPN endpoint on a router, to which IPsec site connections
+// are attached.
+type VPNService struct {
+	ID           *string `json:"id,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	RouterID     *string `json:"router_id,omitempty"`
+	SubnetID     *string `json:"subnet_id,omitempty"`
+	FlavorID     *string `json:"flavor_id,omitempty"`
+	AdminStateUp *bool   `json:"admin_state_up,omitempty"`
+	Status       *string `json:"status,omitempty"`
+	ExternalV4IP *string `json:"external_v4_ip,omitempty"`
+	ExternalV6IP *string `json:"external_v6_ip,omitempty"`
+	ProjectID    *string `json:"project_id,omitempty"`
+	TenantID     *string `json:"tenant_id,omitempty"`
+}
+
+// VPNLifetime is the lifetime of a security association, in the given units
+// (e.g. "seconds").
+type VPNLifetime struct {
+	Units *string `json:"units,omitempty"`
+	Value *int    `json:"value,omitempty"`
+}
+
+// IKEPolicy is the Internet Key Exchange policy (phase 1) of IPsec site
+// connections.
+type IKEPolicy struct {
+	ID                    *string      `json:"id,omitempty"`
+	Name                  *string      `json:"name,omitempty"`
+	Description           *string      `json:"description,omitempty"`
+	AuthAlgorithm         *string      `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm   *string      `json:"encryption_algorithm,omitempty"`
+	PFS                   *string      `json:"pfs,omitempty"`
+	IKEVersion            *string      `json:"ike_version,omitempty"`
+	Phase1NegotiationMode *string      `json:"phase1_negotiation_mode,omitempty"`
+	Lifetime              *VPNLifetime `json:"lifetime,omitempty"`
+	ProjectID             *string      `json:"project_id,omitempty"`
+	TenantID              *string      `json:"tenant_id,omitempty"`
+}
+
+// IPSecPolicy is the IPsec policy (phase 2) of IPsec site connections.
+type IPSecPolicy struct {
+	ID                  *string      `json:"id,omitempty"`
+	Name                *string      `json:"name,omitempty"`
+	Description         *string      `json:"description,omitempty"`
+	AuthAlgorithm       *string      `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm *string      `json:"encryption_algorithm,omitempty"`
+	EncapsulationMode   *string      `json:"encapsulation_mode,omitempty"`
+	TransformProtocol   *string      `json:"transform_protocol,omitempty"`
+	PFS                 *string      `json:"pfs,omitempty"`
+	Lifetime            *VPNLifetime `json:"lifetime,omitempty"`
+	ProjectID           *string      `json:"project_id,omitempty"`
+	TenantID            *string      `json:"tenant_id,omitempty"`
+}
+
+// VPNEndpointGroup is a group of local subnets (Type "subnet") or of peer CIDRs
+// (Type "cidr") connected by IPsec site connections.
+type VPNEndpointGroup struct {
+	ID          *string   `json:"id,omitempty"`
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Type        *string   `json:"type,omitempty"`
+	Endpoints   *[]string `json:"endpoints,omitempty"`
+	ProjectID   *string   `json:"project_id,omitempty"`
+	TenantID    *string   `json:"tenant_id,omitempty"`
+}
+
+// VPNDeadPeerDetection describes how a dead peer is detected (after Timeout
+// seconds without answers to the probes sent every Interval seconds) and what
+// is done then (e.g. "hold", "clear", "restart").
+type VPNDeadPeerDetection struct {
+	Action   *string `json:"action,omitempty"`
+	Interval *int    `json:"interval,omitempty"`
+	Timeout  *int    `json:"timeout,omitempty"`
+}
+
+// IPSecSiteConnection is an IPsec tunnel between a VPN service and a peer
+// gateway; the peer networks are given either as endpoint groups or (legacy)
+// as PeerCIDRs.
+type IPSecSiteConnection struct {
+	ID             *string               `json:"id,omitempty"`
+	Name           *string               `json:"name,omitempty"`
+	Description    *string               `json:"description,omitempty"`
+	VPNServiceID   *string               `json:"vpnservice_id,omitempty"`
+	IKEPolicyID    *string               `json:"ikepolicy_id,omitempty"`
+	IPSecPolicyID  *string               `json:"ipsecpolicy_id,omitempty"`
+	LocalEPGroupID *string               `json:"local_ep_group_id,omitempty"`
+	PeerEPGroupID  *string               `json:"peer_ep_group_id,omitempty"`
+	LocalID        *string               `json:"local_id,omitempty"`
+	PeerAddress    *string               `json:"peer_address,omitempty"`
+	PeerID         *string               `json:"peer_id,omitempty"`
+	PeerCIDRs      *[]string             `json:"peer_cidrs,omitempty"`
+	PSK            *string               `json:"psk,omitempty"`
+	MTU            *int                  `json:"mtu,omitempty"`
+	Initiator      *string               `json:"initiator,omitempty"`
+	DPD            *VPNDeadPeerDetection `json:"dpd,omitempty"`
+	AdminStateUp   *bool                 `json:"admin_state_up,omitempty"`
+	Status         *string               `json:"status,omitempty"`
+	RouteMode      *string               `json:"route_mode,omitempty"`
+	AuthMode       *string               `json:"auth_mode,omitempty"`
+	ProjectID      *string               `json:"project_id,omitempty"`
+	TenantID       *string               `json:"tenant_id,omitempty"`
+}
+
+/*
+ * AUTO-ALLOCATED TOPOLOGY
+ */
+
+// AutoAllocatedTopology is the network automatically allocated to a project,
+// connected through a router to the default external network.
+type AutoAllocatedTopology struct {
+	ID        *string `json:"id,omitempty"`
+	ProjectID *string `json:"project_id,omitempty"`
+	TenantID  *string `json:"tenant_id,omitempty"`
+}
+
+/*
+ * EXTENSIONS
+ */
+
+// NetworkExtension is an extension of the Networking API enabled on this cloud,
+// identified by its alias (e.g. "trunk").
+type NetworkExtension struct {
+	Alias       *string `json:"alias,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Updated     *string `json:"updated,omitempty"`
+	Links       *[]Link `json:"links,omitempty"`
+}
+
+/*
+ * COMMON
+ */
+
+// FixedIP is an IP address on a subnet, e.g. assigned to a port or to the
+// external gateway of a router.
+type FixedIP struct {
+	SubnetID  *string `json:"subnet_id,omitempty"`
+	IPAddress *string `json:"ip_address,omitempty"`
+}
@@ -0,0 +1,599 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST VPN SERVICES
+ */
+
+// ListVPNServicesOptions provides the options available for filtering the list
+// of VPN services; Limit sets the page size, all pages are retrieved anyway.
+type ListVPNServicesOptions struct {
+	ID        *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name      *string `parameter:"name,omitempty" header:"-" json:"-"`
+	RouterID  *string `parameter:"router_id,omitempty" header:"-" json:"-"`
+	SubnetID  *string `parameter:"subnet_id,omitempty" header:"-" json:"-"`
+	Status    *string `parameter:"status,omitempty" header:"-" json:"-"`
+	ProjectID *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey   *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir   *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListVPNServices returns the list of VPN services; it requires the vpnaas
+// extension; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-vpn-services
+func (api *NetworkV2API) ListVPNServices(opts *ListVPNServicesOptions) (*[]VPNService, *Result, error) {
+	services := []VPNService{}
+	result, err := api.listResources("./v2.0/vpn/vpnservices", "vpnservices", opts, collectionLinks,
+		func() interface{} { return &[]VPNService{} },
+		func(page interface{}) { services = append(services, *page.(*[]VPNService)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &services, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE VPN SERVICE
+ */
+
+// CreateVPNServiceOptions provides the options available for creating a VPN
+// service: RouterID is mandatory; SubnetID is only needed by the legacy site
+// connections using PeerCIDRs instead of endpoint groups.
+type CreateVPNServiceOptions struct {
+	Name         *string `json:"name,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	RouterID     *string `json:"router_id,omitempty"`
+	SubnetID     *string `json:"subnet_id,omitempty"`
+	FlavorID     *string `json:"flavor_id,omitempty"`
+	AdminStateUp *bool   `json:"admin_state_up,omitempty"`
+	ProjectID    *string `json:"project_id,omitempty"`
+}
+
+// CreateVPNService creates a new VPN service on a router; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-vpn-service
+func (api *NetworkV2API) CreateVPNService(opts *CreateVPNServiceOptions) (*VPNService, *Result, error) {
+	service := &VPNService{}
+	result, err := api.createResource("./v2.0/vpn/vpnservices", "vpnservice", opts, service)
+	if result != nil && result.Code == 201 {
+		return service, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE VPN SERVICE
+ */
+
+// RetrieveVPNService retrieves the details of the VPN service identified by the
+// given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-vpn-service-details
+func (api *NetworkV2API) RetrieveVPNService(serviceid string) (*VPNService, *Result, error) {
+	service := &VPNService{}
+	result, err := api.retrieveResource("./v2.0/vpn/vpnservices/{id}", serviceid, "vpnservice", service)
+	if result != nil && result.Code == 200 {
+		return service, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE VPN SERVICE
+ */
+
+// UpdateVPNServiceOptions provides the options available for updating a VPN
+// service; only the given attributes are modified.
+type UpdateVPNServiceOptions struct {
+	Name         *string `json:"name,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	AdminStateUp *bool   `json:"admin_state_up,omitempty"`
+}
+
+// UpdateVPNService updates the VPN service identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-vpn-service
+func (api *NetworkV2API) UpdateVPNService(serviceid string, opts *UpdateVPNServiceOptions) (*VPNService, *Result, error) {
+	service := &VPNService{}
+	result, err := api.updateResource("./v2.0/vpn/vpnservices/{id}", serviceid, "vpnservice", opts, service)
+	if result != nil && result.Code == 200 {
+		return service, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE VPN SERVICE
+ */
+
+// DeleteVPNService deletes the VPN service identified by the given id; it fails
+// if site connections are still attached to it; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-vpn-service
+func (api *NetworkV2API) DeleteVPNService(serviceid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/vpn/vpnservices/{id}", serviceid)
+}
+
+/*
+ * LIST IKE POLICIES
+ */
+
+// ListIKEPoliciesOptions provides the options available for filtering the list
+// of IKE policies; Limit sets the page size, all pages are retrieved anyway.
+type ListIKEPoliciesOptions struct {
+	ID        *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name      *string `parameter:"name,omitempty" header:"-" json:"-"`
+	ProjectID *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey   *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir   *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListIKEPolicies returns the list of IKE policies; it requires the vpnaas
+// extension; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-ike-policies
+func (api *NetworkV2API) ListIKEPolicies(opts *ListIKEPoliciesOptions) (*[]IKEPolicy, *Result, error) {
+	policies := []IKEPolicy{}
+	result, err := api.listResources("./v2.0/vpn/ikepolicies", "ikepolicies", opts, collectionLinks,
+		func() interface{} { return &[]IKEPolicy{} },
+		func(page interface{}) { policies = append(policies, *page.(*[]IKEPolicy)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &policies, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE IKE POLICY
+ */
+
+// CreateIKEPolicyOptions provides the options available for creating an IKE
+// policy; the algorithms default to SHA1 and AES-128, PFS to group5 and the IKE
+// version to v1.
+type CreateIKEPolicyOptions struct {
+	Name                  *string      `json:"name,omitempty"`
+	Description           *string      `json:"description,omitempty"`
+	AuthAlgorithm         *string      `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm   *string      `json:"encryption_algorithm,omitempty"`
+	PFS                   *string      `json:"pfs,omitempty"`
+	IKEVersion            *string      `json:"ike_version,omitempty"`
+	Phase1NegotiationMode *string      `json:"phase1_negotiation_mode,omitempty"`
+	Lifetime              *VPNLifetime `json:"lifetime,omitempty"`
+	ProjectID             *string      `json:"project_id,omitempty"`
+}
+
+// CreateIKEPolicy creates a new IKE policy; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-ike-policy
+func (api *NetworkV2API) CreateIKEPolicy(opts *CreateIKEPolicyOptions) (*IKEPolicy, *Result, error) {
+	policy := &IKEPolicy{}
+	result, err := api.createResource("./v2.0/vpn/ikepolicies", "ikepolicy", opts, policy)
+	if result != nil && result.Code == 201 {
+		return policy, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE IKE POLICY
+ */
+
+// RetrieveIKEPolicy retrieves the details of the IKE policy identified by the
+// given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-ike-policy-details
+func (api *NetworkV2API) RetrieveIKEPolicy(policyid string) (*IKEPolicy, *Result, error) {
+	policy := &IKEPolicy{}
+	result, err := api.retrieveResource("./v2.0/vpn/ikepolicies/{id}", policyid, "ikepolicy", policy)
+	if result != nil && result.Code == 200 {
+		return policy, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE IKE POLICY
+ */
+
+// UpdateIKEPolicyOptions provides the options available for updating an IKE
+// policy; only the given attributes are modified.
+type UpdateIKEPolicyOptions struct {
+	Name                  *string      `json:"name,omitempty"`
+	Description           *string      `json:"description,omitempty"`
+	AuthAlgorithm         *string      `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm   *string      `json:"encryption_algorithm,omitempty"`
+	PFS                   *string      `json:"pfs,omitempty"`
+	IKEVersion            *string      `json:"ike_version,omitempty"`
+	Phase1NegotiationMode *string      `json:"phase1_negotiation_mode,omitempty"`
+	Lifetime              *VPNLifetime `json:"lifetime,omitempty"`
+}
+
+// UpdateIKEPolicy updates the IKE policy identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-ike-policy
+func (api *NetworkV2API) UpdateIKEPolicy(policyid string, opts *UpdateIKEPolicyOptions) (*IKEPolicy, *Result, error) {
+	policy := &IKEPolicy{}
+	result, err := api.updateResource("./v2.0/vpn/ikepolicies/{id}", policyid, "ikepolicy", opts, policy)
+	if result != nil && result.Code == 200 {
+		return policy, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE IKE POLICY
+ */
+
+// DeleteIKEPolicy deletes the IKE policy identified by the given id; it fails
+// if the policy is in use by a site connection; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-ike-policy
+func (api *NetworkV2API) DeleteIKEPolicy(policyid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/vpn/ikepolicies/{id}", policyid)
+}
+
+/*
+ * LIST IPSEC POLICIES
+ */
+
+// ListIPSecPoliciesOptions provides the options available for filtering the
+// list of IPsec policies; Limit sets the page size, all pages are retrieved
+// anyway.
+type ListIPSecPoliciesOptions struct {
+	ID        *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name      *string `parameter:"name,omitempty" header:"-" json:"-"`
+	ProjectID *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey   *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir   *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListIPSecPolicies returns the list of IPsec policies; it requires the vpnaas
+// extension; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-ipsec-policies
+func (api *NetworkV2API) ListIPSecPolicies(opts *ListIPSecPoliciesOptions) (*[]IPSecPolicy, *Result, error) {
+	policies := []IPSecPolicy{}
+	result, err := api.listResources("./v2.0/vpn/ipsecpolicies", "ipsecpolicies", opts, collectionLinks,
+		func() interface{} { return &[]IPSecPolicy{} },
+		func(page interface{}) { policies = append(policies, *page.(*[]IPSecPolicy)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &policies, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE IPSEC POLICY
+ */
+
+// CreateIPSecPolicyOptions provides the options available for creating an IPsec
+// policy; the encapsulation mode defaults to "tunnel" and the transform
+// protocol to "esp".
+type CreateIPSecPolicyOptions struct {
+	Name                *string      `json:"name,omitempty"`
+	Description         *string      `json:"description,omitempty"`
+	AuthAlgorithm       *string      `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm *string      `json:"encryption_algorithm,omitempty"`
+	EncapsulationMode   *string      `json:"encapsulation_mode,omitempty"`
+	TransformProtocol   *string      `json:"transform_protocol,omitempty"`
+	PFS                 *string      `json:"pfs,omitempty"`
+	Lifetime            *VPNLifetime `json:"lifetime,omitempty"`
+	ProjectID           *string      `json:"project_id,omitempty"`
+}
+
+// CreateIPSecPolicy creates a new IPsec policy; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-ipsec-policy
+func (api *NetworkV2API) CreateIPSecPolicy(opts *CreateIPSecPolicyOptions) (*IPSecPolicy, *Result, error) {
+	policy := &IPSecPolicy{}
+	result, err := api.createResource("./v2.0/vpn/ipsecpolicies", "ipsecpolicy", opts, policy)
+	if result != nil && result.Code == 201 {
+		return policy, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE IPSEC POLICY
+ */
+
+// RetrieveIPSecPolicy retrieves the details of the IPsec policy identified by
+// the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-ipsec-policy
+func (api *NetworkV2API) RetrieveIPSecPolicy(policyid string) (*IPSecPolicy, *Result, error) {
+	policy := &IPSecPolicy{}
+	result, err := api.retrieveResource("./v2.0/vpn/ipsecpolicies/{id}", policyid, "ipsecpolicy", policy)
+	if result != nil && result.Code == 200 {
+		return policy, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE IPSEC POLICY
+ */
+
+// UpdateIPSecPolicyOptions provides the options available for updating an IPsec
+// policy; only the given attributes are modified.
+type UpdateIPSecPolicyOptions struct {
+	Name                *string      `json:"name,omitempty"`
+	Description         *string      `json:"description,omitempty"`
+	AuthAlgorithm       *string      `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm *string      `json:"encryption_algorithm,omitempty"`
+	EncapsulationMode   *string      `json:"encapsulation_mode,omitempty"`
+	TransformProtocol   *string      `json:"transform_protocol,omitempty"`
+	PFS                 *string      `json:"pfs,omitempty"`
+	Lifetime            *VPNLifetime `json:"lifetime,omitempty"`
+}
+
+// UpdateIPSecPolicy updates the IPsec policy identified by the given id; see
+// also https://developer.openstack.org/api-ref/network/v2/#update-ipsec-policy
+func (api *NetworkV2API) UpdateIPSecPolicy(policyid string, opts *UpdateIPSecPolicyOptions) (*IPSecPolicy, *Result, error) {
+	policy := &IPSecPolicy{}
+	result, err := api.updateResource("./v2.0/vpn/ipsecpolicies/{id}", policyid, "ipsecpolicy", opts, policy)
+	if result != nil && result.Code == 200 {
+		return policy, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE IPSEC POLICY
+ */
+
+// DeleteIPSecPolicy deletes the IPsec policy identified by the given id; it
+// fails if the policy is in use by a site connection; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-ipsec-policy
+func (api *NetworkV2API) DeleteIPSecPolicy(policyid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/vpn/ipsecpolicies/{id}", policyid)
+}
+
+/*
+ * LIST ENDPOINT GROUPS
+ */
+
+// ListVPNEndpointGroupsOptions provides the options available for filtering the
+// list of endpoint groups; Limit sets the page size, all pages are retrieved
+// anyway.
+type ListVPNEndpointGroupsOptions struct {
+	ID        *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name      *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Type      *string `parameter:"type,omitempty" header:"-" json:"-"`
+	ProjectID *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey   *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir   *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListVPNEndpointGroups returns the list of endpoint groups; it requires the
+// vpnaas extension; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-vpn-endpoint-groups
+func (api *NetworkV2API) ListVPNEndpointGroups(opts *ListVPNEndpointGroupsOptions) (*[]VPNEndpointGroup, *Result, error) {
+	groups := []VPNEndpointGroup{}
+	result, err := api.listResources("./v2.0/vpn/endpoint-groups", "endpoint_groups", opts, collectionLinks,
+		func() interface{} { return &[]VPNEndpointGroup{} },
+		func(page interface{}) { groups = append(groups, *page.(*[]VPNEndpointGroup)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &groups, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE ENDPOINT GROUP
+ */
+
+// CreateVPNEndpointGroupOptions provides the options available for creating a
+// VPN endpoint group: Type is either "subnet" (with subnet IDs as endpoints) or
+// "cidr" (with peer CIDRs as endpoints); the endpoints cannot be changed later.
+type CreateVPNEndpointGroupOptions struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Type        *string   `json:"type,omitempty"`
+	Endpoints   *[]string `json:"endpoints,omitempty"`
+	ProjectID   *string   `json:"project_id,omitempty"`
+}
+
+// CreateVPNEndpointGroup creates a new VPN endpoint group; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-vpn-endpoint-group
+func (api *NetworkV2API) CreateVPNEndpointGroup(opts *CreateVPNEndpointGroupOptions) (*VPNEndpointGroup, *Result, error) {
+	group := &VPNEndpointGroup{}
+	result, err := api.createResource("./v2.0/vpn/endpoint-groups", "endpoint_group", opts, group)
+	if result != nil && result.Code == 201 {
+		return group, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE ENDPOINT GROUP
+ */
+
+// RetrieveVPNEndpointGroup retrieves the details of the endpoint group
+// identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-vpn-endpoint-group
+func (api *NetworkV2API) RetrieveVPNEndpointGroup(groupid string) (*VPNEndpointGroup, *Result, error) {
+	group := &VPNEndpointGroup{}
+	result, err := api.retrieveResource("./v2.0/vpn/endpoint-groups/{id}", groupid, "endpoint_group", group)
+	if result != nil && result.Code == 200 {
+		return group, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE ENDPOINT GROUP
+ */
+
+// UpdateVPNEndpointGroupOptions provides the options available for updating an
+// endpoint group; only the given attributes are modified.
+type UpdateVPNEndpointGroupOptions struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// UpdateVPNEndpointGroup updates the endpoint group identified by the given id;
+// see also
+// https://developer.openstack.org/api-ref/network/v2/#update-vpn-endpoint-group
+func (api *NetworkV2API) UpdateVPNEndpointGroup(groupid string, opts *UpdateVPNEndpointGroupOptions) (*VPNEndpointGroup, *Result, error) {
+	group := &VPNEndpointGroup{}
+	result, err := api.updateResource("./v2.0/vpn/endpoint-groups/{id}", groupid, "endpoint_group", opts, group)
+	if result != nil && result.Code == 200 {
+		return group, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE ENDPOINT GROUP
+ */
+
+// DeleteVPNEndpointGroup deletes the VPN endpoint group identified by the given
+// id; it fails if the group is in use by a site connection; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-vpn-endpoint-group
+func (api *NetworkV2API) DeleteVPNEndpointGroup(groupid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/vpn/endpoint-groups/{id}", groupid)
+}
+
+/*
+ * LIST IPSEC SITE CONNECTIONS
+ */
+
+// ListIPSecSiteConnectionsOptions provides the options available for filtering
+// the list of IPsec site connections; Limit sets the page size, all pages are
+// retrieved anyway.
+type ListIPSecSiteConnectionsOptions struct {
+	ID           *string `parameter:"id,omitempty" header:"-" json:"-"`
+	Name         *string `parameter:"name,omitempty" header:"-" json:"-"`
+	VPNServiceID *string `parameter:"vpnservice_id,omitempty" header:"-" json:"-"`
+	PeerAddress  *string `parameter:"peer_address,omitempty" header:"-" json:"-"`
+	Status       *string `parameter:"status,omitempty" header:"-" json:"-"`
+	ProjectID    *string `parameter:"project_id,omitempty" header:"-" json:"-"`
+	SortKey      *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir      *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit        *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker       *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListIPSecSiteConnections returns the list of IPsec site connections; it
+// requires the vpnaas extension; see also
+// https://developer.openstack.org/api-ref/network/v2/#list-ipsec-site-connections
+func (api *NetworkV2API) ListIPSecSiteConnections(opts *ListIPSecSiteConnectionsOptions) (*[]IPSecSiteConnection, *Result, error) {
+	connections := []IPSecSiteConnection{}
+	result, err := api.listResources("./v2.0/vpn/ipsec-site-connections", "ipsec_site_connections", opts, collectionLinks,
+		func() interface{} { return &[]IPSecSiteConnection{} },
+		func(page interface{}) { connections = append(connections, *page.(*[]IPSecSiteConnection)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &connections, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE IPSEC SITE CONNECTION
+ */
+
+// CreateIPSecSiteConnectionOptions provides the options available for creating
+// an IPsec site connection: VPNServiceID, IKEPolicyID, IPSecPolicyID,
+// PeerAddress, PeerID and PSK (the pre-shared key) are mandatory, as are either
+// LocalEPGroupID and PeerEPGroupID or (legacy) PeerCIDRs.
+type CreateIPSecSiteConnectionOptions struct {
+	Name           *string               `json:"name,omitempty"`
+	Description    *string               `json:"description,omitempty"`
+	VPNServiceID   *string               `json:"vpnservice_id,omitempty"`
+	IKEPolicyID    *string               `json:"ikepolicy_id,omitempty"`
+	IPSecPolicyID  *string               `json:"ipsecpolicy_id,omitempty"`
+	LocalEPGroupID *string               `json:"local_ep_group_id,omitempty"`
+	PeerEPGroupID  *string               `json:"peer_ep_group_id,omitempty"`
+	LocalID        *string               `json:"local_id,omitempty"`
+	PeerAddress    *string               `json:"peer_address,omitempty"`
+	PeerID         *string               `json:"peer_id,omitempty"`
+	PeerCIDRs      *[]string             `json:"peer_cidrs,omitempty"`
+	PSK            *string               `json:"psk,omitempty"`
+	MTU            *int                  `json:"mtu,omitempty"`
+	Initiator      *string               `json:"initiator,omitempty"`
+	DPD            *VPNDeadPeerDetection `json:"dpd,omitempty"`
+	AdminStateUp   *bool                 `json:"admin_state_up,omitempty"`
+	ProjectID      *string               `json:"project_id,omitempty"`
+}
+
+// CreateIPSecSiteConnection creates a new IPsec site connection, i.e. a tunnel
+// to the peer gateway; see also
+// https://developer.openstack.org/api-ref/network/v2/#create-ipsec-connection
+func (api *NetworkV2API) CreateIPSecSiteConnection(opts *CreateIPSecSiteConnectionOptions) (*IPSecSiteConnection, *Result, error) {
+	connection := &IPSecSiteConnection{}
+	result, err := api.createResource("./v2.0/vpn/ipsec-site-connections", "ipsec_site_connection", opts, connection)
+	if result != nil && result.Code == 201 {
+		return connection, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE IPSEC SITE CONNECTION
+ */
+
+// RetrieveIPSecSiteConnection retrieves the details of the IPsec site
+// connection identified by the given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#show-ipsec-connection
+func (api *NetworkV2API) RetrieveIPSecSiteConnection(connectionid string) (*IPSecSiteConnection, *Result, error) {
+	connection := &IPSecSiteConnection{}
+	result, err := api.retrieveResource("./v2.0/vpn/ipsec-site-connections/{id}", connectionid, "ipsec_site_connection", connection)
+	if result != nil && result.Code == 200 {
+		return connection, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE IPSEC SITE CONNECTION
+ */
+
+// UpdateIPSecSiteConnectionOptions provides the options available for updating
+// an IPsec site connection; only the given attributes are modified.
+type UpdateIPSecSiteConnectionOptions struct {
+	Name           *string               `json:"name,omitempty"`
+	Description    *string               `json:"description,omitempty"`
+	LocalEPGroupID *string               `json:"local_ep_group_id,omitempty"`
+	PeerEPGroupID  *string               `json:"peer_ep_group_id,omitempty"`
+	LocalID        *string               `json:"local_id,omitempty"`
+	PeerAddress    *string               `json:"peer_address,omitempty"`
+	PeerID         *string               `json:"peer_id,omitempty"`
+	PeerCIDRs      *[]string             `json:"peer_cidrs,omitempty"`
+	PSK            *string               `json:"psk,omitempty"`
+	MTU            *int                  `json:"mtu,omitempty"`
+	Initiator      *string               `json:"initiator,omitempty"`
+	DPD            *VPNDeadPeerDetection `json:"dpd,omitempty"`
+	AdminStateUp   *bool                 `json:"admin_state_up,omitempty"`
+}
+
+// UpdateIPSecSiteConnection updates the IPsec site connection identified by the
+// given id; see also
+// https://developer.openstack.org/api-ref/network/v2/#update-ipsec-connection
+func (api *NetworkV2API) UpdateIPSecSiteConnection(connectionid string, opts *UpdateIPSecSiteConnectionOptions) (*IPSecSiteConnection, *Result, error) {
+	connection := &IPSecSiteConnection{}
+	result, err := api.updateResource("./v2.0/vpn/ipsec-site-connections/{id}", connectionid, "ipsec_site_connection", opts, connection)
+	if result != nil && result.Code == 200 {
+		return connection, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE IPSEC SITE CONNECTION
+ */
+
+// DeleteIPSecSiteConnection deletes the IPsec site connection identified by the
+// given id, tearing down the tunnel; see also
+// https://developer.openstack.org/api-ref/network/v2/#remove-ipsec-connection
+func (api *NetworkV2API) DeleteIPSecSiteConnection(connectionid string) (bool, *Result, error) {
+	return api.deleteResource("./v2.0/vpn/ipsec-site-connections/{id}", connectionid)
+}
@@ -0,0 +1,35 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ObjectStoreV1API represents the object storage API ver. 1 (Swift), providing
+// support for the management of the account, of its containers and of the
+// objects in them. Unlike the other services, Swift conveys most information
+// (e.g. metadata, counters and ACLs) in headers rather than in JSON entities:
+// request and response structs use fields tagged with `header` for single
+// headers and maps tagged with `meta` for sets of headers with a common prefix
+// (e.g. `meta:"X-Container-Meta-"`).
+// The endpoint in the catalog is the account URL, e.g.
+// https://swift.example.com/v1/AUTH_<project id>.
+// See https://developer.openstack.org/api-ref/object-store/
+type ObjectStoreV1API struct {
+	API
+}
+
+// objectPath escapes the given container or object name so that it can be used
+// as a path variable; object names can contain slashes, which are preserved as
+// path separators.
+func objectPath(name string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,66 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * RETRIEVE ACCOUNT METADATA
+ */
+
+// RetrieveAccountMetadata retrieves the metadata of the account, including the
+// number of containers and objects and the bytes they use, overall and by
+// storage policy, e.g. for quota and chargeback reporting; see also
+// https://developer.openstack.org/api-ref/object-store/#show-account-metadata
+func (api *ObjectStoreV1API) RetrieveAccountMetadata() (*AccountMetadata, *Result, error) {
+	input := &struct {
+		NoEntity headersOnly
+	}{}
+	output := &AccountMetadata{}
+
+	result, err := api.Invoke(http.MethodHead, "./", true, StatusCodeIn(200, 204), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE ACCOUNT METADATA
+ */
+
+// UpdateAccountMetadataOptions provides the options available for updating the
+// metadata of the account: only the given Metadata items are modified;
+// RemoveMetadata lists the names of the custom metadata items to remove.
+type UpdateAccountMetadataOptions struct {
+	Metadata       map[string]string `parameter:"-" header:"-" meta:"X-Account-Meta-" json:"-"`
+	RemoveMetadata []string          `parameter:"-" header:"-" json:"-"`
+}
+
+// UpdateAccountMetadata updates the metadata of the account; setting the quota
+// (i.e. the "Quota-Bytes" item) requires the reseller admin role; see also
+// https://developer.openstack.org/api-ref/object-store/#create-update-or-delete-account-metadata
+func (api *ObjectStoreV1API) UpdateAccountMetadata(opts *UpdateAccountMetadataOptions) (bool, *Result, error) {
+	input := &struct {
+		NoEntity headersOnly
+		Remove   map[string]string `parameter:"-" header:"-" meta:"X-Remove-Account-" json:"-"`
+		UpdateAccountMetadataOptions
+	}{
+		Remove:                       removalHeaders(opts.RemoveMetadata),
+		UpdateAccountMetadataOptions: *opts,
+	}
+
+	result, err := api.Invoke(http.MethodPost, "./", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,194 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// ACLPublicRead grants read access to the objects of a container to anyone,
+	// without authentication.
+	ACLPublicRead string = ".r:*"
+
+	// ACLListings grants the listing of the objects of a container to those who
+	// have been granted read access by a referrer grant (e.g. ACLPublicRead).
+	ACLListings string = ".rlistings"
+
+	// ACLAnyone grants access to any authenticated user, in any project.
+	ACLAnyone string = "*:*"
+)
+
+// ContainerACL is an access control list of a container (read or write), as a
+// list of grants: referrer grants (".r:<referrer>", see ACLReferrer), which
+// give access without authentication and are only valid in read ACLs along
+// with ACLListings, and grants to users by project and/or user id (see
+// ACLProject and ACLUser) or by role name.
+type ContainerACL []string
+
+// ParseContainerACL parses the given ACL string, as found in the metadata of a
+// container, into its grants.
+func ParseContainerACL(acl string) ContainerACL {
+	grants := ContainerACL{}
+	for _, grant := range strings.Split(acl, ",") {
+		if grant = strings.TrimSpace(grant); grant != "" {
+			grants = append(grants, grant)
+		}
+	}
+	return grants
+}
+
+// String returns the ACL string to be set on a container.
+func (acl ContainerACL) String() string {
+	return strings.Join(acl, ",")
+}
+
+// ACLReferrer returns a grant for requests whose Referer header matches the
+// given host (e.g. ".example.com" for all its subdomains), or denies it if
+// deny is set.
+func ACLReferrer(host string, deny bool) string {
+	if deny {
+		return ".r:-" + host
+	}
+	return ".r:" + host
+}
+
+// ACLProject returns a grant for all the users in the project with the given id.
+func ACLProject(projectid string) string {
+	return projectid + ":*"
+}
+
+// ACLUser returns a grant for the user with the given id, as a member of the
+// project with the given id, or of any project if projectid is "*".
+func ACLUser(projectid string, userid string) string {
+	return projectid + ":" + userid
+}
+
+// ValidateRead checks that the ACL is a valid read ACL: all grants must be
+// well-formed, and listings are only granted along with a referrer grant.
+func (acl ContainerACL) ValidateRead() error {
+	referrers := false
+	for _, grant := range acl {
+		if err := validateGrant(grant); err != nil {
+			return err
+		}
+		if strings.HasPrefix(grant, ".r:") && !strings.HasPrefix(grant, ".r:-") {
+			referrers = true
+		}
+	}
+	if !referrers && acl.contains(ACLListings) {
+		return fmt.Errorf("invalid read ACL %q: %q requires a referrer grant", acl.String(), ACLListings)
+	}
+	return nil
+}
+
+// ValidateWrite checks that the ACL is a valid write ACL: all grants must be
+// well-formed, and refer to users, projects or roles.
+func (acl ContainerACL) ValidateWrite() error {
+	for _, grant := range acl {
+		if err := validateGrant(grant); err != nil {
+			return err
+		}
+		if strings.HasPrefix(grant, ".") {
+			return fmt.Errorf("invalid write ACL %q: %q is only valid in read ACLs", acl.String(), grant)
+		}
+	}
+	return nil
+}
+
+// validateGrant checks that the given grant is well-formed.
+func validateGrant(grant string) error {
+	if grant == "" || strings.ContainsAny(grant, ", \t\r\n") {
+		return fmt.Errorf("invalid ACL grant %q", grant)
+	}
+	switch {
+	case grant == ACLListings:
+	case strings.HasPrefix(grant, ".r:"):
+		if host := strings.TrimPrefix(strings.TrimPrefix(grant, ".r:"), "-"); host == "" {
+			return fmt.Errorf("invalid ACL referrer grant %q: no host", grant)
+		}
+	case strings.HasPrefix(grant, "."):
+		return fmt.Errorf("invalid ACL grant %q: unknown directive", grant)
+	case strings.Contains(grant, ":"):
+		parts := strings.Split(grant, ":")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid ACL grant %q: expected <project>:<user>", grant)
+		}
+	}
+	return nil
+}
+
+// contains returns whether the ACL contains the given grant.
+func (acl ContainerACL) contains(grant string) bool {
+	for _, g := range acl {
+		if g == grant {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ * SET CONTAINER ACLS
+ */
+
+// SetContainerACLs sets the read and write ACLs of the container with the given
+// name, after validating them; a nil ACL is left unchanged, an empty one is
+// removed.
+func (api *ObjectStoreV1API) SetContainerACLs(container string, read ContainerACL, write ContainerACL) (bool, *Result, error) {
+	opts := &UpdateContainerMetadataOptions{}
+	if read != nil {
+		if err := read.ValidateRead(); err != nil {
+			log.Errorf("invalid read ACL for container %q: %v", container, err)
+			return false, nil, err
+		}
+		opts.Read = String(read.String())
+	}
+	if write != nil {
+		if err := write.ValidateWrite(); err != nil {
+			log.Errorf("invalid write ACL for container %q: %v", container, err)
+			return false, nil, err
+		}
+		opts.Write = String(write.String())
+	}
+	return api.UpdateContainerMetadata(container, opts)
+}
+
+// MakePublic grants read access to the objects of the container with the given
+// name, and their listing, to anyone without authentication; the existing
+// grants to users and projects are preserved.
+func (api *ObjectStoreV1API) MakePublic(container string) (bool, *Result, error) {
+	metadata, result, err := api.RetrieveContainerMetadata(container)
+	if metadata == nil {
+		return false, result, err
+	}
+	read := ContainerACL{ACLPublicRead, ACLListings}
+	for _, grant := range ParseContainerACL(stringValue(metadata.Read)) {
+		if !strings.HasPrefix(grant, ".") {
+			read = append(read, grant)
+		}
+	}
+	return api.SetContainerACLs(container, read, nil)
+}
+
+// MakePrivate revokes the referrer grants (including public access) and the
+// listing grant of the container with the given name, so that only the users
+// and projects explicitly granted read access retain it.
+func (api *ObjectStoreV1API) MakePrivate(container string) (bool, *Result, error) {
+	metadata, result, err := api.RetrieveContainerMetadata(container)
+	if metadata == nil {
+		return false, result, err
+	}
+	read := ContainerACL{}
+	for _, grant := range ParseContainerACL(stringValue(metadata.Read)) {
+		if !strings.HasPrefix(grant, ".") {
+			read = append(read, grant)
+		}
+	}
+	return api.SetContainerACLs(container, read, nil)
+}
@@ -0,0 +1,150 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// MaxBulkDeletes is the default maximum number of objects and containers
+	// that can be deleted in a single bulk delete request.
+	MaxBulkDeletes int = 10000
+
+	// ArchiveFormatTar is the format of uncompressed tar archives.
+	ArchiveFormatTar string = "tar"
+	// ArchiveFormatTarGz is the format of gzip-compressed tar archives.
+	ArchiveFormatTarGz string = "tar.gz"
+	// ArchiveFormatTarBz2 is the format of bzip2-compressed tar archives.
+	ArchiveFormatTarBz2 string = "tar.bz2"
+)
+
+/*
+ * BULK DELETE
+ */
+
+// BulkDelete deletes the objects and containers with the given names, either
+// "<container>/<object>" or "<container>" (which must be empty, or emptied
+// earlier in the list), in as few requests as possible, up to MaxBulkDeletes
+// items each; the reports of all the requests are merged into one. If any item
+// could not be deleted, a *BulkError listing them is returned along with the
+// report; items that do not exist are counted as not found, not as errors; see
+// also https://docs.openstack.org/swift/latest/middleware.html#bulk-delete
+func (api *ObjectStoreV1API) BulkDelete(names []string) (*BulkDeleteReport, *Result, error) {
+	total := &BulkDeleteReport{
+		NumberDeleted:  Int(0),
+		NumberNotFound: Int(0),
+	}
+	input := &struct {
+		NoEntity   headersOnly
+		BulkDelete bool   `parameter:"bulk-delete" header:"-" json:"-"`
+		Accept     string `parameter:"-" header:"Accept" json:"-"`
+	}{
+		BulkDelete: true,
+		Accept:     "application/json",
+	}
+
+	var result *Result
+	for len(names) > 0 {
+		batch := names
+		if len(batch) > MaxBulkDeletes {
+			batch = batch[:MaxBulkDeletes]
+		}
+		names = names[len(batch):]
+
+		// one URL-encoded name per line
+		entity := &bytes.Buffer{}
+		for _, name := range batch {
+			entity.WriteString("/" + objectPath(strings.TrimPrefix(name, "/")) + "\n")
+		}
+		report := &BulkDeleteReport{}
+
+		var err error
+		result, err = api.InvokeStream(http.MethodPost, "./", true, StatusCodeIn(200), input, entity, int64(entity.Len()), "text/plain", nil, report, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+
+		if report.NumberDeleted != nil {
+			*total.NumberDeleted += *report.NumberDeleted
+		}
+		if report.NumberNotFound != nil {
+			*total.NumberNotFound += *report.NumberNotFound
+		}
+		total.ResponseStatus = report.ResponseStatus
+		total.ResponseBody = report.ResponseBody
+		total.Errors = append(total.Errors, report.Errors...)
+		if err := newBulkError(report.ResponseStatus, report.ResponseBody, report.Errors); err != nil && len(report.Errors) == 0 {
+			// the request failed as a whole, do not go on with the next ones
+			log.Errorf("error deleting objects in bulk: %v", err)
+			return total, result, err
+		}
+	}
+
+	if err := newBulkError(total.ResponseStatus, total.ResponseBody, total.Errors); err != nil {
+		log.Errorf("error deleting objects in bulk: %v", err)
+		return total, result, err
+	}
+	return total, result, nil
+}
+
+/*
+ * BULK UPLOAD
+ */
+
+// ExtractArchiveOptions provides the options available for extracting an
+// archive: ContentLength is the size of the archive, if known, otherwise it is
+// sent using chunked transfer encoding; Progress, if not nil, is called as the
+// archive is sent.
+type ExtractArchiveOptions struct {
+	ContentLength *int64
+	Progress      ProgressFunc
+}
+
+// ExtractArchive uploads the given archive, in the given format (one of the
+// ArchiveFormat* constants), streaming it from the given reader, and has the
+// server expand it into objects, one per file: if the container is not empty,
+// the files are created in it, with their paths in the archive as object names;
+// otherwise, the top-level directories of the archive become containers, which
+// are created if needed. If any file could not be created, a *BulkError
+// listing them is returned along with the report; opts can be nil; see also
+// https://docs.openstack.org/swift/latest/middleware.html#extract-archive
+func (api *ObjectStoreV1API) ExtractArchive(container string, format string, data io.Reader, opts *ExtractArchiveOptions) (*BulkUploadReport, *Result, error) {
+	if opts == nil {
+		opts = &ExtractArchiveOptions{}
+	}
+	input := &struct {
+		NoEntity       headersOnly
+		Container      string `parameter:"-" header:"-" variable:"container" json:"-"`
+		ExtractArchive string `parameter:"extract-archive" header:"-" json:"-"`
+		Accept         string `parameter:"-" header:"Accept" json:"-"`
+	}{
+		Container:      objectPath(container),
+		ExtractArchive: format,
+		Accept:         "application/json",
+	}
+	length := int64(-1)
+	if opts.ContentLength != nil {
+		length = *opts.ContentLength
+	}
+	report := &BulkUploadReport{}
+
+	result, err := api.InvokeStream(http.MethodPut, "./{container}", true, StatusCodeIn(200, 201), input, data, length, "", opts.Progress, report, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result == nil || !result.OK {
+		return nil, result, err
+	}
+	if err := newBulkError(report.ResponseStatus, report.ResponseBody, report.Errors); err != nil {
+		log.Errorf("error extracting archive into container %q: %v", container, err)
+		return report, result, err
+	}
+	return report, result, err
+}
@@ -0,0 +1,214 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// containerRequest is the request entity of the container calls that carry no
+// options, which address the container with the given name.
+type containerRequest struct {
+	NoEntity  headersOnly
+	Container string `parameter:"-" header:"-" variable:"container" json:"-"`
+}
+
+/*
+ * LIST CONTAINERS
+ */
+
+// ListContainersOptions provides the options available for filtering the list
+// of containers: only those whose name starts with Prefix, or comes after
+// Marker and before EndMarker, are returned; Limit sets the page size, all
+// pages are retrieved anyway.
+type ListContainersOptions struct {
+	Prefix    *string `parameter:"prefix,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+	EndMarker *string `parameter:"end_marker,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Reverse   *bool   `parameter:"reverse,omitempty" header:"-" json:"-"`
+}
+
+// ListContainers returns the list of containers in the account, in (reverse)
+// alphabetical order; see also
+// https://developer.openstack.org/api-ref/object-store/#show-account-details-and-list-containers
+func (api *ObjectStoreV1API) ListContainers(opts *ListContainersOptions) (*[]Container, *Result, error) {
+	if opts == nil {
+		opts = &ListContainersOptions{}
+	}
+	input := &struct {
+		NoEntity headersOnly
+		Format   string `parameter:"format" header:"-" json:"-"`
+		ListContainersOptions
+	}{
+		Format:                "json",
+		ListContainersOptions: *opts,
+	}
+
+	containers := []Container{}
+	for {
+		page := []Container{}
+		result, err := api.Invoke(http.MethodGet, "./", true, StatusCodeIn(200, 204), input, &listing{items: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || !result.OK {
+			return nil, result, err
+		}
+		containers = append(containers, page...)
+		if len(page) == 0 || (input.Limit != nil && len(page) < *input.Limit) {
+			return &containers, result, err
+		}
+		// the next page starts after the last container of this one
+		input.Marker = page[len(page)-1].Name
+	}
+}
+
+// listing is the response entity of the Object Storage list calls, which is a
+// bare JSON array of entries.
+type listing struct {
+	items interface{}
+}
+
+// UnmarshalJSON decodes the entries into the items.
+func (l *listing) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, l.items)
+}
+
+/*
+ * CREATE CONTAINER
+ */
+
+// CreateContainerOptions provides the options available for creating a
+// container: Read and Write are its access control lists (see ContainerACL);
+// StoragePolicy cannot be changed afterwards; Metadata is its custom metadata.
+type CreateContainerOptions struct {
+	Read          *string           `parameter:"-" header:"X-Container-Read,omitempty" json:"-"`
+	Write         *string           `parameter:"-" header:"X-Container-Write,omitempty" json:"-"`
+	StoragePolicy *string           `parameter:"-" header:"X-Storage-Policy,omitempty" json:"-"`
+	Metadata      map[string]string `parameter:"-" header:"-" meta:"X-Container-Meta-" json:"-"`
+}
+
+// CreateContainer creates a container with the given name, or updates its
+// metadata if it already exists; opts can be nil; see also
+// https://developer.openstack.org/api-ref/object-store/#create-container
+func (api *ObjectStoreV1API) CreateContainer(container string, opts *CreateContainerOptions) (bool, *Result, error) {
+	if opts == nil {
+		opts = &CreateContainerOptions{}
+	}
+	input := &struct {
+		NoEntity  headersOnly
+		Container string `parameter:"-" header:"-" variable:"container" json:"-"`
+		CreateContainerOptions
+	}{
+		Container:              objectPath(container),
+		CreateContainerOptions: *opts,
+	}
+
+	result, err := api.Invoke(http.MethodPut, "./{container}", true, StatusCodeIn(201, 202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * RETRIEVE CONTAINER METADATA
+ */
+
+// RetrieveContainerMetadata retrieves the metadata of the container with the
+// given name, including the number of objects and the bytes they use; see also
+// https://developer.openstack.org/api-ref/object-store/#show-container-metadata
+func (api *ObjectStoreV1API) RetrieveContainerMetadata(container string) (*ContainerMetadata, *Result, error) {
+	input := &containerRequest{
+		Container: objectPath(container),
+	}
+	output := &ContainerMetadata{}
+
+	result, err := api.Invoke(http.MethodHead, "./{container}", true, StatusCodeIn(200, 204), input, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE CONTAINER METADATA
+ */
+
+// UpdateContainerMetadataOptions provides the options available for updating
+// the metadata of a container: only the given ACLs and Metadata items are
+// modified; an empty ACL removes it; RemoveMetadata lists the names of the
+// custom metadata items to remove.
+type UpdateContainerMetadataOptions struct {
+	Read           *string           `parameter:"-" header:"X-Container-Read,omitempty" json:"-"`
+	Write          *string           `parameter:"-" header:"X-Container-Write,omitempty" json:"-"`
+	Metadata       map[string]string `parameter:"-" header:"-" meta:"X-Container-Meta-" json:"-"`
+	RemoveMetadata []string          `parameter:"-" header:"-" json:"-"`
+}
+
+// UpdateContainerMetadata updates the metadata of the container with the given
+// name; see also
+// https://developer.openstack.org/api-ref/object-store/#create-update-or-delete-container-metadata
+func (api *ObjectStoreV1API) UpdateContainerMetadata(container string, opts *UpdateContainerMetadataOptions) (bool, *Result, error) {
+	input := &struct {
+		NoEntity  headersOnly
+		Container string            `parameter:"-" header:"-" variable:"container" json:"-"`
+		Remove    map[string]string `parameter:"-" header:"-" meta:"X-Remove-Container-" json:"-"`
+		UpdateContainerMetadataOptions
+	}{
+		Container:                      objectPath(container),
+		Remove:                         removalHeaders(opts.RemoveMetadata),
+		UpdateContainerMetadataOptions: *opts,
+	}
+	// empty headers are never sent, ACLs are removed with X-Remove-* headers
+	if opts.Read != nil && *opts.Read == "" {
+		input.Remove["Read"] = "x"
+	}
+	if opts.Write != nil && *opts.Write == "" {
+		input.Remove["Write"] = "x"
+	}
+
+	result, err := api.Invoke(http.MethodPost, "./{container}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// removalHeaders returns the values of the X-Remove-*-Meta-* headers removing
+// the metadata items with the given names, by the suffix of their name after
+// "X-Remove-*-".
+func removalHeaders(names []string) map[string]string {
+	headers := map[string]string{}
+	for _, name := range names {
+		headers["Meta-"+name] = "x"
+	}
+	return headers
+}
+
+/*
+ * DELETE CONTAINER
+ */
+
+// DeleteContainer deletes the container with the given name, which must be
+// empty; see also
+// https://developer.openstack.org/api-ref/object-store/#delete-container
+func (api *ObjectStoreV1API) DeleteContainer(container string) (bool, *Result, error) {
+	input := &containerRequest{
+		Container: objectPath(container),
+	}
+
+	result, err := api.Invoke(http.MethodDelete, "./{container}", true, StatusCodeIn(204), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
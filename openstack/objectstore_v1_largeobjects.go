@@ -0,0 +1,461 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dihedron/go-log"
+)
+
+const (
+	// DefaultSegmentSize is the default size of the segments of large objects
+	// (100 MiB); the maximum size of a single object is 5 GiB, unless it has
+	// been configured otherwise.
+	DefaultSegmentSize int64 = 100 * 1024 * 1024
+
+	// DefaultSegmentConcurrency is the default number of segments of large
+	// objects that are uploaded concurrently.
+	DefaultSegmentConcurrency int = 4
+)
+
+/*
+ * STATIC LARGE OBJECTS
+ */
+
+// PutLargeObjectOptions provides the options available for uploading a large
+// object: the data is split into segments of SegmentSize bytes (by default,
+// DefaultSegmentSize), which are stored in SegmentContainer (by default, the
+// container name followed by "_segments"), Concurrency at a time (by default,
+// DefaultSegmentConcurrency); ContentType and Metadata apply to the manifest,
+// i.e. to the object as a whole.
+type PutLargeObjectOptions struct {
+	SegmentSize      int64
+	SegmentContainer *string
+	Concurrency      int
+	ContentType      *string
+	Metadata         map[string]string
+}
+
+// sloSegment is an entry of the manifest of a static large object.
+type sloSegment struct {
+	Path string `json:"path"`
+	ETag string `json:"etag"`
+	Size int64  `json:"size_bytes"`
+}
+
+// PutLargeObject creates or replaces the object with the given name in the
+// given container, reading its data from the given reader; if the data is
+// larger than the segment size, it is uploaded as a static large object, i.e.
+// split into segments that are uploaded concurrently and then tied together by
+// a manifest, which is the only way to store objects larger than 5 GiB;
+// otherwise it is uploaded as a plain object. Since the segments are buffered
+// in memory as they are uploaded, up to Concurrency + 1 times the segment size
+// bytes are in use at any time. If any segment fails to upload, the segments
+// already uploaded are deleted. Segments are verified against the ETags returned
+// by the server, as with PutObject, and so is the manifest: on a mismatch both
+// the manifest and the segments are deleted, and an ObjectIntegrityError is
+// returned. On success, the ETag of the object is
+// returned; opts can be nil; see also
+// https://docs.openstack.org/swift/latest/overview_large_objects.html
+func (api *ObjectStoreV1API) PutLargeObject(container string, object string, data io.Reader, opts *PutLargeObjectOptions) (*string, *Result, error) {
+	if opts == nil {
+		opts = &PutLargeObjectOptions{}
+	}
+	size := opts.SegmentSize
+	if size <= 0 {
+		size = DefaultSegmentSize
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultSegmentConcurrency
+	}
+	segments := container + "_segments"
+	if opts.SegmentContainer != nil {
+		segments = *opts.SegmentContainer
+	}
+
+	segment, err := readSegment(data, size)
+	if err != nil {
+		log.Errorf("error reading data of object %q: %v", object, err)
+		return nil, nil, err
+	}
+	if int64(len(segment)) < size {
+		log.Debugf("object %q is smaller than segment size, uploading as plain object", object)
+		return api.PutObject(container, object, bytes.NewReader(segment), &PutObjectOptions{
+			ContentType:   opts.ContentType,
+			ContentLength: Int64(int64(len(segment))),
+			Metadata:      opts.Metadata,
+		})
+	}
+
+	if ok, result, err := api.CreateContainer(segments, nil); !ok {
+		log.Errorf("error creating segment container %q: %v", segments, err)
+		return nil, result, fmt.Errorf("cannot create segment container %q: %v (%v)", segments, err, result)
+	}
+
+	// segment names are unique to this upload and sort in upload order
+	prefix := fmt.Sprintf("%s/slo/%d/%d/", object, time.Now().UnixNano(), size)
+	var (
+		manifest []sloSegment
+		etags    = map[int]string{}
+		failure  error
+		mutex    sync.Mutex
+		group    sync.WaitGroup
+		tokens   = make(chan struct{}, concurrency)
+	)
+	for index := 0; len(segment) > 0; index++ {
+		name := fmt.Sprintf("%s%08d", prefix, index)
+		manifest = append(manifest, sloSegment{Path: "/" + segments + "/" + name, Size: int64(len(segment))})
+
+		tokens <- struct{}{}
+		group.Add(1)
+		go func(index int, name string, segment []byte) {
+			defer func() {
+				<-tokens
+				group.Done()
+			}()
+			etag, result, err := api.PutObject(segments, name, bytes.NewReader(segment), &PutObjectOptions{
+				ContentLength: Int64(int64(len(segment))),
+			})
+			mutex.Lock()
+			defer mutex.Unlock()
+			if etag != nil {
+				etags[index] = *etag
+			} else if failure == nil {
+				log.Errorf("error uploading segment %q: %v (%v)", name, err, result)
+				failure = fmt.Errorf("cannot upload segment %q: %v (%v)", name, err, result)
+			}
+		}(index, name, segment)
+
+		mutex.Lock()
+		failed := failure != nil
+		mutex.Unlock()
+		if failed {
+			break
+		}
+		if segment, err = readSegment(data, size); err != nil {
+			log.Errorf("error reading data of object %q: %v", object, err)
+			mutex.Lock()
+			failure = err
+			mutex.Unlock()
+			break
+		}
+	}
+	group.Wait()
+
+	if failure != nil {
+		api.deleteSegments(segments, etags, prefix)
+		return nil, nil, failure
+	}
+
+	// the ETag of the manifest is the MD5 checksum of the segment ETags
+	checksum := md5.New()
+	for index := range manifest {
+		manifest[index].ETag = etags[index]
+		io.WriteString(checksum, etags[index])
+	}
+	entity, err := json.Marshal(manifest)
+	if err != nil {
+		log.Errorf("error encoding manifest of object %q: %v", object, err)
+		return nil, nil, err
+	}
+
+	input := &struct {
+		NoEntity          headersOnly
+		Container         string            `parameter:"-" header:"-" variable:"container" json:"-"`
+		Object            string            `parameter:"-" header:"-" variable:"object" json:"-"`
+		MultipartManifest string            `parameter:"multipart-manifest" header:"-" json:"-"`
+		Metadata          map[string]string `parameter:"-" header:"-" meta:"X-Object-Meta-" json:"-"`
+	}{
+		Container:         objectPath(container),
+		Object:            objectPath(object),
+		MultipartManifest: "put",
+		Metadata:          opts.Metadata,
+	}
+	contentType := ""
+	if opts.ContentType != nil {
+		contentType = *opts.ContentType
+	}
+
+	result, err := api.InvokeStream(http.MethodPut, "./{container}/{object}", true, StatusCodeIn(201), input, bytes.NewReader(entity), int64(len(entity)), contentType, nil, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result == nil || result.Code != 201 {
+		api.deleteSegments(segments, etags, prefix)
+		return nil, result, err
+	}
+
+	etag := unquoteETag(result.Headers.Get("ETag"))
+	if actual := hex.EncodeToString(checksum.Sum(nil)); etag != actual {
+		log.Errorf("ETag mismatch for manifest of object %q in container %q: expected %q, got %q", object, container, etag, actual)
+		// the manifest cannot be trusted, so it is deleted along with the segments
+		if ok, _, err := api.DeleteObject(container, object, nil); !ok {
+			log.Warnf("error deleting manifest of object %q after ETag mismatch: %v", object, err)
+		}
+		api.deleteSegments(segments, etags, prefix)
+		return nil, result, &ObjectIntegrityError{
+			Container: container,
+			Object:    object,
+			Expected:  etag,
+			Actual:    actual,
+		}
+	}
+	return String(etag), result, err
+}
+
+// readSegment reads up to size bytes from the given reader; it returns fewer
+// bytes only at the end of the data, and no bytes at all after that.
+func readSegment(data io.Reader, size int64) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	if _, err := io.CopyN(buffer, data, size); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// deleteSegments deletes the segments with the given indexes and prefix from
+// the given container, on a best effort basis, after a failed upload.
+func (api *ObjectStoreV1API) deleteSegments(container string, indexes map[int]string, prefix string) {
+	for index := range indexes {
+		name := fmt.Sprintf("%s%08d", prefix, index)
+		if ok, _, err := api.DeleteObject(container, name, nil); !ok {
+			log.Warnf("error deleting segment %q of failed upload: %v", name, err)
+		}
+	}
+}
+
+/*
+ * DYNAMIC LARGE OBJECTS
+ */
+
+// PutDynamicManifestOptions provides the options available for creating the
+// manifest of a dynamic large object: ContentType and Metadata apply to the
+// object as a whole.
+type PutDynamicManifestOptions struct {
+	ContentType *string           `parameter:"-" header:"Content-Type,omitempty" json:"-"`
+	Metadata    map[string]string `parameter:"-" header:"-" meta:"X-Object-Meta-" json:"-"`
+}
+
+// PutDynamicManifest creates or replaces the object with the given name in the
+// given container as the manifest of a dynamic large object, whose data is the
+// concatenation of the objects in the segment container whose names start with
+// the given prefix, in alphabetical order, as they are when the object is read;
+// segments can thus be added afterwards (see AppendSegment), e.g. for logs or
+// backups that grow over time, but there is no end-to-end integrity check;
+// opts can be nil; see also
+// https://docs.openstack.org/swift/latest/overview_large_objects.html
+func (api *ObjectStoreV1API) PutDynamicManifest(container string, object string, segmentContainer string, prefix string, opts *PutDynamicManifestOptions) (bool, *Result, error) {
+	if opts == nil {
+		opts = &PutDynamicManifestOptions{}
+	}
+	input := &struct {
+		NoEntity  headersOnly
+		Container string `parameter:"-" header:"-" variable:"container" json:"-"`
+		Object    string `parameter:"-" header:"-" variable:"object" json:"-"`
+		Manifest  string `parameter:"-" header:"X-Object-Manifest" json:"-"`
+		PutDynamicManifestOptions
+	}{
+		Container:                 objectPath(container),
+		Object:                    objectPath(object),
+		Manifest:                  segmentContainer + "/" + prefix,
+		PutDynamicManifestOptions: *opts,
+	}
+
+	result, err := api.Invoke(http.MethodPut, "./{container}/{object}", true, StatusCodeIn(201), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// AppendSegment uploads the given data as the next segment of the dynamic large
+// objects whose manifests refer to the given segment container and prefix; the
+// segment is named after the prefix followed by a zero-padded sequence number,
+// one past the highest among the existing segments (so that segments can be
+// deleted, and other objects can share the prefix, without the new segment
+// replacing an existing one); all the segments with that prefix must be
+// uploaded this way, and not concurrently; the segment container must exist.
+// The upload is otherwise the same as with PutObject; opts can be nil.
+func (api *ObjectStoreV1API) AppendSegment(segmentContainer string, prefix string, data io.Reader, opts *PutObjectOptions) (*string, *Result, error) {
+	segments, result, err := api.ListObjects(segmentContainer, &ListObjectsOptions{Prefix: String(prefix)})
+	if segments == nil {
+		log.Errorf("error listing segments with prefix %q in container %q: %v", prefix, segmentContainer, err)
+		return nil, result, err
+	}
+	return api.PutObject(segmentContainer, fmt.Sprintf("%s%08d", prefix, nextSegmentIndex(*segments, prefix)), data, opts)
+}
+
+// nextSegmentIndex returns the sequence number following the highest among the
+// objects named after the given prefix followed by a sequence number, or 0 if
+// there is none.
+func nextSegmentIndex(objects []Object, prefix string) int64 {
+	next := int64(0)
+	for _, object := range objects {
+		suffix := strings.TrimPrefix(stringValue(object.Name), prefix)
+		if suffix == "" || strings.Trim(suffix, "0123456789") != "" {
+			continue
+		}
+		if index, err := strconv.ParseInt(suffix, 10, 64); err == nil && index >= next {
+			next = index + 1
+		}
+	}
+	return next
+}
+
+// deleteDynamicSegments deletes the segments of a dynamic large object, given
+// the value of its manifest header (i.e. "<container>/<prefix>").
+func (api *ObjectStoreV1API) deleteDynamicSegments(manifest string) (*Result, error) {
+	container, prefix := splitManifest(manifest)
+	segments, result, err := api.ListObjects(container, &ListObjectsOptions{Prefix: String(prefix)})
+	if segments == nil {
+		log.Errorf("error listing segments with prefix %q in container %q: %v", prefix, container, err)
+		return result, fmt.Errorf("cannot list segments of %q: %v (%v)", manifest, err, result)
+	}
+	for _, segment := range *segments {
+		if ok, result, err := api.DeleteObject(container, *segment.Name, nil); !ok && (result == nil || result.Code != 404) {
+			log.Errorf("error deleting segment %q in container %q: %v", *segment.Name, container, err)
+			return result, fmt.Errorf("cannot delete segment %q in container %q: %v (%v)", *segment.Name, container, err, result)
+		}
+	}
+	return result, nil
+}
+
+// splitManifest splits the value of the manifest header of a dynamic large
+// object into the segment container and the segment prefix, which the server
+// may return escaped.
+func splitManifest(manifest string) (string, string) {
+	if unescaped, err := url.PathUnescape(manifest); err == nil {
+		manifest = unescaped
+	}
+	if i := strings.Index(manifest, "/"); i >= 0 {
+		return manifest[:i], manifest[i+1:]
+	}
+	return manifest, ""
+}
+
+/*
+ * ORPHANED SEGMENTS
+ */
+
+// ListOrphanedSegments returns the objects in the segment container that are
+// not segments of any of the large objects (static or dynamic) whose manifests
+// are in the given container, e.g. those left over by interrupted uploads or
+// by manifests deleted without their segments; since the metadata of each
+// object in the container must be retrieved to find the manifests, this takes
+// one request per object.
+func (api *ObjectStoreV1API) ListOrphanedSegments(container string, segmentContainer string) (*[]Object, *Result, error) {
+	objects, result, err := api.ListObjects(container, nil)
+	if objects == nil {
+		log.Errorf("error listing objects in container %q: %v", container, err)
+		return nil, result, err
+	}
+
+	prefixes := []string{}
+	referenced := map[string]bool{}
+	for _, object := range *objects {
+		if object.Name == nil {
+			continue
+		}
+		metadata, result, err := api.RetrieveObjectMetadata(container, *object.Name)
+		if metadata == nil {
+			if result != nil && result.Code == 404 {
+				// deleted in the meantime
+				continue
+			}
+			return nil, result, err
+		}
+		if stringValue(metadata.ObjectManifest) != "" {
+			segments, prefix := splitManifest(*metadata.ObjectManifest)
+			prefixes = append(prefixes, segments+"/"+prefix)
+		} else if metadata.StaticLargeObject != nil && *metadata.StaticLargeObject {
+			segments, result, err := api.retrieveStaticManifest(container, *object.Name)
+			if segments == nil {
+				return nil, result, err
+			}
+			for _, segment := range *segments {
+				if segment.Name != nil {
+					referenced[strings.TrimPrefix(*segment.Name, "/")] = true
+				}
+			}
+		}
+	}
+
+	segments, result, err := api.ListObjects(segmentContainer, nil)
+	if segments == nil {
+		log.Errorf("error listing objects in container %q: %v", segmentContainer, err)
+		return nil, result, err
+	}
+	orphans := []Object{}
+outer:
+	for _, segment := range *segments {
+		path := segmentContainer + "/" + *segment.Name
+		if referenced[path] {
+			continue
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(path, prefix) {
+				continue outer
+			}
+		}
+		orphans = append(orphans, segment)
+	}
+	return &orphans, result, err
+}
+
+// DeleteOrphanedSegments deletes the objects in the segment container that are
+// not segments of any of the large objects whose manifests are in the given
+// container (see ListOrphanedSegments), returning the number of segments that
+// were deleted.
+func (api *ObjectStoreV1API) DeleteOrphanedSegments(container string, segmentContainer string) (int, *Result, error) {
+	orphans, result, err := api.ListOrphanedSegments(container, segmentContainer)
+	if orphans == nil {
+		return 0, result, err
+	}
+	deleted := 0
+	for _, orphan := range *orphans {
+		ok, result, err := api.DeleteObject(segmentContainer, *orphan.Name, nil)
+		if !ok && (result == nil || result.Code != 404) {
+			log.Errorf("error deleting orphaned segment %q in container %q: %v", *orphan.Name, segmentContainer, err)
+			return deleted, result, fmt.Errorf("cannot delete segment %q in container %q: %v (%v)", *orphan.Name, segmentContainer, err, result)
+		}
+		deleted++
+	}
+	return deleted, result, err
+}
+
+// retrieveStaticManifest retrieves the list of segments of a static large
+// object, as recorded in its manifest; their names include the container.
+func (api *ObjectStoreV1API) retrieveStaticManifest(container string, object string) (*[]Object, *Result, error) {
+	input := &struct {
+		NoEntity          headersOnly
+		Container         string `parameter:"-" header:"-" variable:"container" json:"-"`
+		Object            string `parameter:"-" header:"-" variable:"object" json:"-"`
+		MultipartManifest string `parameter:"multipart-manifest" header:"-" json:"-"`
+	}{
+		Container:         objectPath(container),
+		Object:            objectPath(object),
+		MultipartManifest: "get",
+	}
+	segments := []Object{}
+
+	result, err := api.Invoke(http.MethodGet, "./{container}/{object}", true, StatusCodeIn(200), input, &listing{items: &segments}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return &segments, result, err
+	}
+	return nil, result, err
+}
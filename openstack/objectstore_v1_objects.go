@@ -0,0 +1,336 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// ObjectIntegrityError is returned when the ETag of an object, as computed by
+// the server, does not match the MD5 checksum of the data as it was sent or
+// received; the data may have been corrupted in transit.
+type ObjectIntegrityError struct {
+	Container string
+	Object    string
+	Expected  string
+	Actual    string
+}
+
+// Error returns a description of the integrity error.
+func (e *ObjectIntegrityError) Error() string {
+	return fmt.Sprintf("integrity check failed for object %q in container %q: expected ETag %q, got %q", e.Object, e.Container, e.Expected, e.Actual)
+}
+
+// objectRequest is the request entity of the object calls that carry no
+// options, which address the object with the given name in the given container.
+type objectRequest struct {
+	NoEntity  headersOnly
+	Container string `parameter:"-" header:"-" variable:"container" json:"-"`
+	Object    string `parameter:"-" header:"-" variable:"object" json:"-"`
+}
+
+/*
+ * LIST OBJECTS
+ */
+
+// ListObjectsOptions provides the options available for filtering the list of
+// objects in a container: only those whose name starts with Prefix, or comes
+// after Marker and before EndMarker, are returned; with a Delimiter (e.g. "/"),
+// the names sharing a prefix up to the delimiter are rolled up into a single
+// entry with just Subdir set, as if they were in a directory; Limit sets the
+// page size, all pages are retrieved anyway.
+type ListObjectsOptions struct {
+	Prefix    *string `parameter:"prefix,omitempty" header:"-" json:"-"`
+	Delimiter *string `parameter:"delimiter,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+	EndMarker *string `parameter:"end_marker,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Reverse   *bool   `parameter:"reverse,omitempty" header:"-" json:"-"`
+}
+
+// ListObjects returns the list of objects in the container with the given
+// name, in (reverse) alphabetical order; opts can be nil; see also
+// https://developer.openstack.org/api-ref/object-store/#show-container-details-and-list-objects
+func (api *ObjectStoreV1API) ListObjects(container string, opts *ListObjectsOptions) (*[]Object, *Result, error) {
+	if opts == nil {
+		opts = &ListObjectsOptions{}
+	}
+	input := &struct {
+		NoEntity  headersOnly
+		Container string `parameter:"-" header:"-" variable:"container" json:"-"`
+		Format    string `parameter:"format" header:"-" json:"-"`
+		ListObjectsOptions
+	}{
+		Container:          objectPath(container),
+		Format:             "json",
+		ListObjectsOptions: *opts,
+	}
+
+	objects := []Object{}
+	for {
+		page := []Object{}
+		result, err := api.Invoke(http.MethodGet, "./{container}", true, StatusCodeIn(200, 204), input, &listing{items: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || !result.OK {
+			return nil, result, err
+		}
+		objects = append(objects, page...)
+		if len(page) == 0 || (input.Limit != nil && len(page) < *input.Limit) {
+			return &objects, result, err
+		}
+		// the next page starts after the last object (or subdir) of this one
+		last := page[len(page)-1]
+		input.Marker = last.Name
+		if last.Name == nil {
+			input.Marker = last.Subdir
+		}
+	}
+}
+
+/*
+ * PUT OBJECT
+ */
+
+// PutObjectOptions provides the options available for uploading an object:
+// ContentLength is the size of the data, if known, otherwise it is sent using
+// chunked transfer encoding; ETag is the MD5 checksum of the data, if known in
+// advance, which the server verifies before storing the object; DeleteAfter is
+// the number of seconds after which the object expires; Metadata is its custom
+// metadata; Progress, if not nil, is called as the data is sent;
+// SkipVerification disables the verification of the data against the ETag
+// returned by the server.
+type PutObjectOptions struct {
+	ContentType        *string           `parameter:"-" header:"Content-Type,omitempty" json:"-"`
+	ContentLength      *int64            `parameter:"-" header:"-" json:"-"`
+	ContentEncoding    *string           `parameter:"-" header:"Content-Encoding,omitempty" json:"-"`
+	ContentDisposition *string           `parameter:"-" header:"Content-Disposition,omitempty" json:"-"`
+	ETag               *string           `parameter:"-" header:"ETag,omitempty" json:"-"`
+	DeleteAfter        *int              `parameter:"-" header:"X-Delete-After,omitempty" json:"-"`
+	DeleteAt           *int64            `parameter:"-" header:"X-Delete-At,omitempty" json:"-"`
+	Metadata           map[string]string `parameter:"-" header:"-" meta:"X-Object-Meta-" json:"-"`
+	Progress           ProgressFunc      `parameter:"-" header:"-" json:"-"`
+	SkipVerification   bool              `parameter:"-" header:"-" json:"-"`
+}
+
+// PutObject creates or replaces the object with the given name in the given
+// container, streaming its data from the given reader (e.g. an open file) so
+// that objects of any size can be uploaded without being buffered in memory;
+// unless opts.SkipVerification is set, the MD5 checksum of the data is computed
+// as it is sent and compared with the ETag returned by the server, and an
+// *ObjectIntegrityError is returned if they do not match; on success, the ETag
+// of the object is returned; opts can be nil; see also
+// https://developer.openstack.org/api-ref/object-store/#create-or-replace-object
+func (api *ObjectStoreV1API) PutObject(container string, object string, data io.Reader, opts *PutObjectOptions) (*string, *Result, error) {
+	if opts == nil {
+		opts = &PutObjectOptions{}
+	}
+
+	checksum := md5.New()
+	if !opts.SkipVerification {
+		data = io.TeeReader(data, checksum)
+	}
+
+	input := &struct {
+		NoEntity  headersOnly
+		Container string `parameter:"-" header:"-" variable:"container" json:"-"`
+		Object    string `parameter:"-" header:"-" variable:"object" json:"-"`
+		PutObjectOptions
+	}{
+		Container:        objectPath(container),
+		Object:           objectPath(object),
+		PutObjectOptions: *opts,
+	}
+	length := int64(-1)
+	if opts.ContentLength != nil {
+		length = *opts.ContentLength
+	}
+	contentType := ""
+	if opts.ContentType != nil {
+		contentType = *opts.ContentType
+	}
+
+	result, err := api.InvokeStream(http.MethodPut, "./{container}/{object}", true, StatusCodeIn(201), input, data, length, contentType, opts.Progress, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result == nil || result.Code != 201 {
+		return nil, result, err
+	}
+
+	etag := unquoteETag(result.Headers.Get("ETag"))
+	if !opts.SkipVerification {
+		actual := hex.EncodeToString(checksum.Sum(nil))
+		if etag != actual {
+			log.Errorf("ETag mismatch for object %q in container %q: expected %q, got %q", object, container, etag, actual)
+			return nil, result, &ObjectIntegrityError{
+				Container: container,
+				Object:    object,
+				Expected:  etag,
+				Actual:    actual,
+			}
+		}
+	}
+	return String(etag), result, err
+}
+
+// unquoteETag removes the quotes that the server may put around ETag values
+// (e.g. for manifests).
+func unquoteETag(etag string) string {
+	return strings.Trim(etag, "\"")
+}
+
+/*
+ * GET OBJECT
+ */
+
+// GetObjectOptions provides the options available for downloading an object:
+// Range requests only part of the data, e.g. "bytes=1024-" to resume an
+// interrupted download after its first 1024 bytes (see ObjectRange);
+// IfNoneMatch is the ETag of a copy of the object the client already has, so
+// that the data is only returned if it has changed; IfMatch, conversely, only
+// returns the data if its ETag matches.
+type GetObjectOptions struct {
+	Range           *string `parameter:"-" header:"Range,omitempty" json:"-"`
+	IfMatch         *string `parameter:"-" header:"If-Match,omitempty" json:"-"`
+	IfNoneMatch     *string `parameter:"-" header:"If-None-Match,omitempty" json:"-"`
+	IfModifiedSince *string `parameter:"-" header:"If-Modified-Since,omitempty" json:"-"`
+}
+
+// ObjectRange returns the value of the Range header requesting the bytes of
+// the object data from first to last (both included); if last is negative,
+// the data is requested up to its end.
+func ObjectRange(first int64, last int64) string {
+	if last < 0 {
+		return fmt.Sprintf("bytes=%d-", first)
+	}
+	return fmt.Sprintf("bytes=%d-%d", first, last)
+}
+
+// GetObject downloads the object with the given name in the given container,
+// returning its metadata and its data as a reader, which the caller must close
+// after reading it, so that objects of any size can be downloaded without being
+// buffered in memory; for a Range request, the result code is 206 and only the
+// requested part of the data is returned; if opts.IfNoneMatch matches the ETag
+// of the object, the result code is 304 and only the metadata is returned, with
+// a nil reader; opts can be nil; see also
+// https://developer.openstack.org/api-ref/object-store/#get-object-content-and-metadata
+func (api *ObjectStoreV1API) GetObject(container string, object string, opts *GetObjectOptions) (io.ReadCloser, *ObjectMetadata, *Result, error) {
+	if opts == nil {
+		opts = &GetObjectOptions{}
+	}
+	input := &struct {
+		NoEntity  headersOnly
+		Container string `parameter:"-" header:"-" variable:"container" json:"-"`
+		Object    string `parameter:"-" header:"-" variable:"object" json:"-"`
+		GetObjectOptions
+	}{
+		Container:        objectPath(container),
+		Object:           objectPath(object),
+		GetObjectOptions: *opts,
+	}
+	metadata := &ObjectMetadata{}
+
+	body, result, err := api.InvokeReader(http.MethodGet, "./{container}/{object}", true, StatusCodeIn(200, 206, 304), input, metadata, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result == nil || !result.OK {
+		return nil, nil, result, err
+	}
+	if metadata.ETag != nil {
+		metadata.ETag = String(unquoteETag(*metadata.ETag))
+	}
+	if result.Code == 304 {
+		body.Close()
+		return nil, metadata, result, err
+	}
+	return body, metadata, result, err
+}
+
+/*
+ * RETRIEVE OBJECT METADATA
+ */
+
+// RetrieveObjectMetadata retrieves the metadata of the object with the given
+// name in the given container, without its data; see also
+// https://developer.openstack.org/api-ref/object-store/#show-object-metadata
+func (api *ObjectStoreV1API) RetrieveObjectMetadata(container string, object string) (*ObjectMetadata, *Result, error) {
+	input := &objectRequest{
+		Container: objectPath(container),
+		Object:    objectPath(object),
+	}
+	metadata := &ObjectMetadata{}
+
+	result, err := api.Invoke(http.MethodHead, "./{container}/{object}", true, StatusCodeIn(200), input, metadata, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		if metadata.ETag != nil {
+			metadata.ETag = String(unquoteETag(*metadata.ETag))
+		}
+		return metadata, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE OBJECT
+ */
+
+// DeleteObjectOptions provides the options available for deleting an object:
+// Segments, for the manifest of a static or dynamic large object, deletes its
+// segments along with the manifest, otherwise only the manifest is deleted.
+type DeleteObjectOptions struct {
+	Segments bool `parameter:"-" header:"-" json:"-"`
+}
+
+// DeleteObject deletes the object with the given name in the given container;
+// opts can be nil; see also
+// https://developer.openstack.org/api-ref/object-store/#delete-object
+func (api *ObjectStoreV1API) DeleteObject(container string, object string, opts *DeleteObjectOptions) (bool, *Result, error) {
+	if opts == nil {
+		opts = &DeleteObjectOptions{}
+	}
+	input := &struct {
+		NoEntity          headersOnly
+		Container         string  `parameter:"-" header:"-" variable:"container" json:"-"`
+		Object            string  `parameter:"-" header:"-" variable:"object" json:"-"`
+		MultipartManifest *string `parameter:"multipart-manifest,omitempty" header:"-" json:"-"`
+		Accept            *string `parameter:"-" header:"Accept,omitempty" json:"-"`
+	}{
+		Container: objectPath(container),
+		Object:    objectPath(object),
+	}
+	if opts.Segments {
+		metadata, result, err := api.RetrieveObjectMetadata(container, object)
+		if metadata == nil {
+			return false, result, err
+		}
+		if stringValue(metadata.ObjectManifest) != "" {
+			// the segments of dynamic large objects must be deleted one by one
+			if result, err := api.deleteDynamicSegments(*metadata.ObjectManifest); err != nil {
+				return false, result, err
+			}
+			return api.DeleteObject(container, object, nil)
+		}
+		// the segments are deleted in bulk, and a report is returned
+		input.MultipartManifest = String("delete")
+		input.Accept = String("application/json")
+	}
+	report := &BulkDeleteReport{}
+
+	result, err := api.Invoke(http.MethodDelete, "./{container}/{object}", true, StatusCodeIn(200, 204), input, report, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result == nil || !result.OK {
+		return false, result, err
+	}
+	if err := newBulkError(report.ResponseStatus, report.ResponseBody, report.Errors); err != nil {
+		log.Errorf("error deleting segments of object %q in container %q: %v", object, container, err)
+		return false, result, err
+	}
+	return true, result, err
+}
@@ -0,0 +1,407 @@
+package openstack
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestObjectStoreV1API(url string) *ObjectStoreV1API {
+	client := NewDefaultClient(url)
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+	return &ObjectStoreV1API{client.Authenticator.Identity.API}
+}
+
+func TestContainerMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v1/AUTH_p/my%20container" {
+			t.Errorf("ObjectStore.TestContainerMetadata: unexpected path %q", r.URL.EscapedPath())
+		}
+		switch r.Method {
+		case http.MethodHead:
+			w.Header().Set("X-Container-Object-Count", "42")
+			w.Header().Set("X-Container-Bytes-Used", "1024")
+			w.Header().Set("X-Container-Read", ".r:*")
+			w.Header().Set("X-Container-Meta-Owner", "alice")
+		case http.MethodPost:
+			if r.ContentLength > 0 || r.Header.Get("Content-Type") != "" {
+				t.Errorf("ObjectStore.TestContainerMetadata: unexpected entity in metadata update")
+			}
+			if r.Header.Get("X-Container-Meta-Tier") != "gold" || r.Header.Get("X-Remove-Container-Meta-Owner") == "" || r.Header.Get("X-Remove-Container-Read") == "" {
+				t.Errorf("ObjectStore.TestContainerMetadata: unexpected headers %v", r.Header)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	api := newTestObjectStoreV1API(server.URL + "/v1/AUTH_p")
+	metadata, _, err := api.RetrieveContainerMetadata("my container")
+	if err != nil || metadata == nil {
+		t.Fatalf("ObjectStore.TestContainerMetadata: error retrieving metadata: %v", err)
+	}
+	if metadata.ObjectCount == nil || *metadata.ObjectCount != 42 || metadata.BytesUsed == nil || *metadata.BytesUsed != 1024 {
+		t.Errorf("ObjectStore.TestContainerMetadata: unexpected counters %v, %v", metadata.ObjectCount, metadata.BytesUsed)
+	}
+	if stringValue(metadata.Read) != ".r:*" || metadata.Metadata["owner"] != "alice" {
+		t.Errorf("ObjectStore.TestContainerMetadata: unexpected metadata %v", metadata.Metadata)
+	}
+
+	ok, _, err := api.UpdateContainerMetadata("my container", &UpdateContainerMetadataOptions{
+		Read:           String(""),
+		Metadata:       map[string]string{"tier": "gold"},
+		RemoveMetadata: []string{"owner"},
+	})
+	if !ok || err != nil {
+		t.Errorf("ObjectStore.TestContainerMetadata: error updating metadata: %v", err)
+	}
+}
+
+func TestPutObject(t *testing.T) {
+	etag, chunked := "9e107d9d372bb6826bd81d3542a419d6", false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.EscapedPath() != "/v1/AUTH_p/photos/2018/my%20cat.jpg" {
+			t.Errorf("ObjectStore.TestPutObject: unexpected request %s %q", r.Method, r.URL.EscapedPath())
+		}
+		if r.Header.Get("Content-Type") != "image/jpeg" || r.Header.Get("X-Object-Meta-Author") != "alice" {
+			t.Errorf("ObjectStore.TestPutObject: unexpected headers %v", r.Header)
+		}
+		if !chunked && r.ContentLength != 43 {
+			t.Errorf("ObjectStore.TestPutObject: unexpected content length %d", r.ContentLength)
+		}
+		if chunked && (r.ContentLength != -1 || len(r.TransferEncoding) == 0) {
+			t.Errorf("ObjectStore.TestPutObject: data not sent in chunks")
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		if string(data) != "The quick brown fox jumps over the lazy dog" {
+			t.Errorf("ObjectStore.TestPutObject: unexpected data %q", data)
+		}
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	api := newTestObjectStoreV1API(server.URL + "/v1/AUTH_p")
+	opts := &PutObjectOptions{
+		ContentType:   String("image/jpeg"),
+		ContentLength: Int64(43),
+		Metadata:      map[string]string{"Author": "alice"},
+	}
+	tag, _, err := api.PutObject("photos", "2018/my cat.jpg", strings.NewReader("The quick brown fox jumps over the lazy dog"), opts)
+	if err != nil || tag == nil || *tag != etag {
+		t.Errorf("ObjectStore.TestPutObject: error uploading object: %v", err)
+	}
+
+	// the data is corrupted in transit
+	opts.ContentLength = nil
+	etag, chunked = "d41d8cd98f00b204e9800998ecf8427e", true
+	_, _, err = api.PutObject("photos", "2018/my cat.jpg", strings.NewReader("The quick brown fox jumps over the lazy dog"), opts)
+	if _, ok := err.(*ObjectIntegrityError); !ok {
+		t.Errorf("ObjectStore.TestPutObject: expected integrity error, got %v", err)
+	}
+}
+
+func TestGetObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.EscapedPath() != "/v1/AUTH_p/photos/my%20cat.jpg" {
+			t.Errorf("ObjectStore.TestGetObject: unexpected request %s %q", r.Method, r.URL.EscapedPath())
+		}
+		w.Header().Set("ETag", "\"9e107d9d372bb6826bd81d3542a419d6\"")
+		w.Header().Set("X-Object-Meta-Author", "alice")
+		if r.Header.Get("If-None-Match") == "9e107d9d372bb6826bd81d3542a419d6" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Header.Get("Range") != "bytes=4-" {
+			t.Errorf("ObjectStore.TestGetObject: unexpected range %q", r.Header.Get("Range"))
+		}
+		w.Header().Set("Content-Range", "bytes 4-42/43")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte("quick brown fox jumps over the lazy dog"))
+	}))
+	defer server.Close()
+
+	api := newTestObjectStoreV1API(server.URL + "/v1/AUTH_p")
+	body, metadata, result, err := api.GetObject("photos", "my cat.jpg", &GetObjectOptions{Range: String(ObjectRange(4, -1))})
+	if err != nil || body == nil || result.Code != 206 {
+		t.Fatalf("ObjectStore.TestGetObject: error downloading object: %v (%v)", err, result)
+	}
+	data, _ := ioutil.ReadAll(body)
+	body.Close()
+	if string(data) != "quick brown fox jumps over the lazy dog" || stringValue(metadata.ContentRange) != "bytes 4-42/43" {
+		t.Errorf("ObjectStore.TestGetObject: unexpected data %q", data)
+	}
+	if stringValue(metadata.ETag) != "9e107d9d372bb6826bd81d3542a419d6" || metadata.Metadata["author"] != "alice" {
+		t.Errorf("ObjectStore.TestGetObject: unexpected metadata %v, %v", metadata.ETag, metadata.Metadata)
+	}
+
+	body, metadata, result, err = api.GetObject("photos", "my cat.jpg", &GetObjectOptions{IfNoneMatch: metadata.ETag})
+	if err != nil || body != nil || metadata == nil || result.Code != 304 {
+		t.Errorf("ObjectStore.TestGetObject: unexpected conditional download result %v (%v)", result, err)
+	}
+}
+
+func TestPutLargeObject(t *testing.T) {
+	var mutex sync.Mutex
+	objects := map[string]string{}
+	corrupt := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodDelete:
+			mutex.Lock()
+			delete(objects, strings.TrimPrefix(r.URL.Path, "/v1/AUTH_p"))
+			mutex.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/AUTH_p/backups_segments":
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPut && r.URL.Query().Get("multipart-manifest") == "put":
+			manifest := []sloSegment{}
+			if err := json.Unmarshal(data, &manifest); err != nil || len(manifest) != 3 {
+				t.Errorf("ObjectStore.TestPutLargeObject: unexpected manifest %s", data)
+			}
+			checksum := md5.New()
+			mutex.Lock()
+			for _, segment := range manifest {
+				if _, ok := objects[segment.Path]; !ok || segment.ETag != objects[segment.Path] {
+					t.Errorf("ObjectStore.TestPutLargeObject: unexpected segment %v", segment)
+				}
+				io.WriteString(checksum, segment.ETag)
+			}
+			if corrupt {
+				io.WriteString(checksum, "corrupt")
+			}
+			objects[strings.TrimPrefix(r.URL.Path, "/v1/AUTH_p")] = hex.EncodeToString(checksum.Sum(nil))
+			mutex.Unlock()
+			w.Header().Set("ETag", "\""+hex.EncodeToString(checksum.Sum(nil))+"\"")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut:
+			checksum := md5.Sum(data)
+			mutex.Lock()
+			objects[strings.TrimPrefix(r.URL.Path, "/v1/AUTH_p")] = hex.EncodeToString(checksum[:])
+			mutex.Unlock()
+			w.Header().Set("ETag", hex.EncodeToString(checksum[:]))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("ObjectStore.TestPutLargeObject: unexpected request %s %q", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	api := newTestObjectStoreV1API(server.URL + "/v1/AUTH_p")
+	data := strings.NewReader("The quick brown fox jumps over the lazy dog")
+	etag, _, err := api.PutLargeObject("backups", "fox.txt", data, &PutLargeObjectOptions{SegmentSize: 16, Concurrency: 2})
+	if err != nil || etag == nil {
+		t.Fatalf("ObjectStore.TestPutLargeObject: error uploading object: %v", err)
+	}
+	if len(objects) != 4 {
+		t.Errorf("ObjectStore.TestPutLargeObject: unexpected objects %v", objects)
+	}
+
+	// a manifest with an unexpected ETag is deleted along with its segments
+	corrupt = true
+	data = strings.NewReader("The quick brown fox jumps over the lazy dog")
+	etag, _, err = api.PutLargeObject("backups", "cat.txt", data, &PutLargeObjectOptions{SegmentSize: 16})
+	if _, ok := err.(*ObjectIntegrityError); !ok || etag != nil {
+		t.Errorf("ObjectStore.TestPutLargeObject: unexpected result for corrupt manifest: %v", err)
+	}
+	if len(objects) != 4 {
+		t.Errorf("ObjectStore.TestPutLargeObject: unexpected objects after corrupt manifest %v", objects)
+	}
+	corrupt = false
+
+	// small objects are uploaded as they are
+	etag, _, err = api.PutLargeObject("backups", "dog.txt", strings.NewReader("lazy dog"), &PutLargeObjectOptions{SegmentSize: 16})
+	if err != nil || etag == nil || objects["/backups/dog.txt"] != *etag {
+		t.Errorf("ObjectStore.TestPutLargeObject: error uploading small object: %v", err)
+	}
+}
+
+func TestOrphanedSegments(t *testing.T) {
+	deleted := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/AUTH_p/logs":
+			if r.URL.Query().Get("marker") == "" {
+				w.Write([]byte(`[{"name": "app.log", "bytes": 0}, {"name": "big.tar"}, {"name": "plain.txt"}]`))
+			} else {
+				w.Write([]byte(`[]`))
+			}
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/AUTH_p/logs_segments":
+			if r.URL.Query().Get("marker") != "" {
+				w.Write([]byte(`[]`))
+			} else if r.URL.Query().Get("prefix") == "app/" {
+				// segment 1 was deleted: the next one is named after the highest
+				w.Write([]byte(`[{"name": "app/00000000"}, {"name": "app/00000002"}]`))
+			} else {
+				w.Write([]byte(`[{"name": "app/00000000"}, {"name": "app/00000002"}, {"name": "big.tar/slo/1/16/00000000"}, {"name": "stale/00000000"}]`))
+			}
+		case r.Method == http.MethodHead && r.URL.Path == "/v1/AUTH_p/logs/app.log":
+			w.Header().Set("X-Object-Manifest", "logs_segments/app/")
+		case r.Method == http.MethodHead && r.URL.Path == "/v1/AUTH_p/logs/big.tar":
+			w.Header().Set("X-Static-Large-Object", "True")
+		case r.Method == http.MethodHead:
+		case r.Method == http.MethodGet && r.URL.Query().Get("multipart-manifest") == "get":
+			w.Write([]byte(`[{"name": "/logs_segments/big.tar/slo/1/16/00000000", "hash": "x", "bytes": 16}]`))
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/AUTH_p/logs_segments/app/00000003":
+			w.Header().Set("ETag", "d41d8cd98f00b204e9800998ecf8427e")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("ObjectStore.TestOrphanedSegments: unexpected request %s %q", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	api := newTestObjectStoreV1API(server.URL + "/v1/AUTH_p")
+	orphans, _, err := api.ListOrphanedSegments("logs", "logs_segments")
+	if err != nil || orphans == nil || len(*orphans) != 1 || stringValue((*orphans)[0].Name) != "stale/00000000" {
+		t.Fatalf("ObjectStore.TestOrphanedSegments: unexpected orphans %v (%v)", orphans, err)
+	}
+	n, _, err := api.DeleteOrphanedSegments("logs", "logs_segments")
+	if n != 1 || err != nil || len(deleted) != 1 || deleted[0] != "/v1/AUTH_p/logs_segments/stale/00000000" {
+		t.Errorf("ObjectStore.TestOrphanedSegments: unexpected deletions %v (%v)", deleted, err)
+	}
+
+	if _, _, err := api.AppendSegment("logs_segments", "app/", strings.NewReader(""), nil); err != nil {
+		t.Errorf("ObjectStore.TestOrphanedSegments: error appending segment: %v", err)
+	}
+
+	deleted = []string{}
+	if ok, _, err := api.DeleteObject("logs", "app.log", &DeleteObjectOptions{Segments: true}); !ok || len(deleted) != 3 {
+		t.Errorf("ObjectStore.TestOrphanedSegments: unexpected deletions %v (%v)", deleted, err)
+	}
+}
+
+func TestBulkOperations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Query().Get("bulk-delete") != "":
+			if string(data) != "/photos/my%20cat.jpg\n/photos\n" || r.Header.Get("Content-Type") != "text/plain" {
+				t.Errorf("ObjectStore.TestBulkOperations: unexpected bulk delete entity %q", data)
+			}
+			w.Write([]byte(`{"Number Deleted": 1, "Number Not Found": 0, "Response Status": "400 Bad Request", "Response Body": "", "Errors": [["/photos", "409 Conflict"]]}`))
+		case r.Method == http.MethodPut && r.URL.Query().Get("extract-archive") == "tar.gz":
+			if r.URL.Path != "/v1/AUTH_p/backups" || string(data) != "archive" {
+				t.Errorf("ObjectStore.TestBulkOperations: unexpected bulk upload %q", r.URL.Path)
+			}
+			w.Write([]byte(`{"Number Files Created": 10, "Response Status": "201 Created", "Response Body": "", "Errors": []}`))
+		default:
+			t.Errorf("ObjectStore.TestBulkOperations: unexpected request %s %q", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	api := newTestObjectStoreV1API(server.URL + "/v1/AUTH_p")
+	report, _, err := api.BulkDelete([]string{"photos/my cat.jpg", "photos"})
+	bulk, ok := err.(*BulkError)
+	if !ok || len(bulk.Errors) != 1 || bulk.Errors[0].Name != "/photos" || bulk.Errors[0].Status != "409 Conflict" {
+		t.Errorf("ObjectStore.TestBulkOperations: unexpected bulk delete error %v", err)
+	}
+	if report == nil || *report.NumberDeleted != 1 {
+		t.Errorf("ObjectStore.TestBulkOperations: unexpected bulk delete report %v", report)
+	}
+
+	upload, _, err := api.ExtractArchive("backups", ArchiveFormatTarGz, strings.NewReader("archive"), nil)
+	if err != nil || upload == nil || *upload.NumberFilesCreated != 10 {
+		t.Errorf("ObjectStore.TestBulkOperations: unexpected bulk upload report %v (%v)", upload, err)
+	}
+}
+
+func TestAccountMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodHead:
+			w.Header().Set("X-Account-Container-Count", "3")
+			w.Header().Set("X-Account-Object-Count", "42")
+			w.Header().Set("X-Account-Bytes-Used", "1024")
+			w.Header().Set("X-Account-Storage-Policy-Gold-Bytes-Used", "512")
+			w.Header().Set("X-Account-Meta-Quota-Bytes", "2048")
+		case http.MethodPost:
+			if r.Header.Get("X-Account-Meta-Department") != "sales" || r.Header.Get("X-Remove-Account-Meta-Owner") == "" {
+				t.Errorf("ObjectStore.TestAccountMetadata: unexpected headers %v", r.Header)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	api := newTestObjectStoreV1API(server.URL + "/v1/AUTH_p")
+	metadata, _, err := api.RetrieveAccountMetadata()
+	if err != nil || metadata == nil {
+		t.Fatalf("ObjectStore.TestAccountMetadata: error retrieving metadata: %v", err)
+	}
+	if *metadata.ContainerCount != 3 || *metadata.ObjectCount != 42 || *metadata.BytesUsed != 1024 {
+		t.Errorf("ObjectStore.TestAccountMetadata: unexpected counters %v", metadata)
+	}
+	if metadata.StoragePolicies["gold-bytes-used"] != "512" || metadata.Metadata["quota-bytes"] != "2048" {
+		t.Errorf("ObjectStore.TestAccountMetadata: unexpected metadata %v, %v", metadata.StoragePolicies, metadata.Metadata)
+	}
+
+	ok, _, err := api.UpdateAccountMetadata(&UpdateAccountMetadataOptions{
+		Metadata:       map[string]string{"Department": "sales"},
+		RemoveMetadata: []string{"Owner"},
+	})
+	if !ok || err != nil {
+		t.Errorf("ObjectStore.TestAccountMetadata: error updating metadata: %v", err)
+	}
+}
+
+func TestContainerACLs(t *testing.T) {
+	acls := []struct {
+		acl   ContainerACL
+		read  bool
+		write bool
+	}{
+		{ContainerACL{ACLPublicRead, ACLListings}, true, false},
+		{ContainerACL{ACLListings}, false, false},
+		{ContainerACL{ACLProject("p1"), ACLUser("*", "u1"), "admin"}, true, true},
+		{ContainerACL{".r:"}, false, false},
+		{ContainerACL{"p1:"}, false, false},
+		{ContainerACL{".w:*"}, false, false},
+	}
+	for _, test := range acls {
+		if err := test.acl.ValidateRead(); (err == nil) != test.read {
+			t.Errorf("ObjectStore.TestContainerACLs: unexpected read validation of %q: %v", test.acl.String(), err)
+		}
+		if err := test.acl.ValidateWrite(); (err == nil) != test.write {
+			t.Errorf("ObjectStore.TestContainerACLs: unexpected write validation of %q: %v", test.acl.String(), err)
+		}
+	}
+
+	read := "p1:*,.r:.example.com"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodHead:
+			w.Header().Set("X-Container-Read", read)
+		case http.MethodPost:
+			read = r.Header.Get("X-Container-Read")
+			if r.Header.Get("X-Remove-Container-Read") != "" {
+				read = ""
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	api := newTestObjectStoreV1API(server.URL + "/v1/AUTH_p")
+	if ok, _, err := api.MakePublic("photos"); !ok || read != ".r:*,.rlistings,p1:*" {
+		t.Errorf("ObjectStore.TestContainerACLs: unexpected public ACL %q (%v)", read, err)
+	}
+	if ok, _, err := api.MakePrivate("photos"); !ok || read != "p1:*" {
+		t.Errorf("ObjectStore.TestContainerACLs: unexpected private ACL %q (%v)", read, err)
+	}
+	if ok, _, _ := api.SetContainerACLs("photos", nil, ContainerACL{ACLPublicRead}); ok {
+		t.Errorf("ObjectStore.TestContainerACLs: invalid write ACL accepted")
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+/*
+ * ACCOUNTS
+ */
+
+// AccountMetadata is the metadata of an account, as returned in the headers of
+// HEAD requests, including its usage: StoragePolicies holds the usage by
+// storage policy, by lowercase name and counter (e.g. "gold-bytes-used");
+// Metadata holds the custom metadata (X-Account-Meta-*), by lowercase name,
+// including the quota (i.e. "quota-bytes"), if any.
+type AccountMetadata struct {
+	ContainerCount  *int64            `header:"X-Account-Container-Count" json:"-"`
+	ObjectCount     *int64            `header:"X-Account-Object-Count" json:"-"`
+	BytesUsed       *int64            `header:"X-Account-Bytes-Used" json:"-"`
+	Timestamp       *string           `header:"X-Timestamp" json:"-"`
+	StoragePolicies map[string]string `header:"-" meta:"X-Account-Storage-Policy-" json:"-"`
+	Metadata        map[string]string `header:"-" meta:"X-Account-Meta-" json:"-"`
+}
+
+/*
+ * CONTAINERS
+ */
+
+// Container is an entry in the list of containers of an account.
+type Container struct {
+	Name         *string `json:"name,omitempty"`
+	Count        *int64  `json:"count,omitempty"`
+	Bytes        *int64  `json:"bytes,omitempty"`
+	LastModified *string `json:"last_modified,omitempty"`
+}
+
+// ContainerMetadata is the metadata of a container, as returned in the headers
+// of HEAD requests: Read and Write are its access control lists (see also
+// ContainerACL); Metadata holds the custom metadata (X-Container-Meta-*), by
+// lowercase name.
+type ContainerMetadata struct {
+	ObjectCount   *int64            `header:"X-Container-Object-Count" json:"-"`
+	BytesUsed     *int64            `header:"X-Container-Bytes-Used" json:"-"`
+	Read          *string           `header:"X-Container-Read" json:"-"`
+	Write         *string           `header:"X-Container-Write" json:"-"`
+	StoragePolicy *string           `header:"X-Storage-Policy" json:"-"`
+	Timestamp     *string           `header:"X-Timestamp" json:"-"`
+	Metadata      map[string]string `header:"-" meta:"X-Container-Meta-" json:"-"`
+}
+
+/*
+ * OBJECTS
+ */
+
+// Object is an entry in the list of objects of a container: Hash is the MD5
+// checksum of its data; if the list was requested with a delimiter, entries
+// rolling up the objects under a common prefix only have Subdir set.
+type Object struct {
+	Name         *string `json:"name,omitempty"`
+	Hash         *string `json:"hash,omitempty"`
+	Bytes        *int64  `json:"bytes,omitempty"`
+	ContentType  *string `json:"content_type,omitempty"`
+	LastModified *string `json:"last_modified,omitempty"`
+	Subdir       *string `json:"subdir,omitempty"`
+}
+
+// ObjectMetadata is the metadata of an object, as returned in the headers of
+// GET and HEAD requests: ContentRange is only set for partial downloads, and
+// ETag is the MD5 checksum of the data (of the concatenated segment ETags for
+// static large objects); DeleteAt is the expiration time, as a Unix epoch;
+// Metadata holds the custom metadata (X-Object-Meta-*), by lowercase name.
+type ObjectMetadata struct {
+	ContentType        *string           `header:"Content-Type" json:"-"`
+	ContentLength      *int64            `header:"Content-Length" json:"-"`
+	ContentRange       *string           `header:"Content-Range" json:"-"`
+	ContentEncoding    *string           `header:"Content-Encoding" json:"-"`
+	ContentDisposition *string           `header:"Content-Disposition" json:"-"`
+	ETag               *string           `header:"ETag" json:"-"`
+	LastModified       *string           `header:"Last-Modified" json:"-"`
+	Timestamp          *string           `header:"X-Timestamp" json:"-"`
+	DeleteAt           *int64            `header:"X-Delete-At" json:"-"`
+	StaticLargeObject  *bool             `header:"X-Static-Large-Object" json:"-"`
+	ObjectManifest     *string           `header:"X-Object-Manifest" json:"-"`
+	Metadata           map[string]string `header:"-" meta:"X-Object-Meta-" json:"-"`
+}
+
+/*
+ * BULK OPERATIONS
+ */
+
+// BulkDeleteReport is the report of the deletion of multiple objects or
+// containers in a single request (see BulkDelete), e.g. of the segments of a
+// static large object: its ResponseStatus is the overall outcome (e.g.
+// "200 OK"), and Errors lists the items that could not be deleted.
+type BulkDeleteReport struct {
+	NumberDeleted  *int            `json:"Number Deleted,omitempty"`
+	NumberNotFound *int            `json:"Number Not Found,omitempty"`
+	ResponseStatus *string         `json:"Response Status,omitempty"`
+	ResponseBody   *string         `json:"Response Body,omitempty"`
+	Errors         []BulkItemError `json:"Errors,omitempty"`
+}
+
+// BulkUploadReport is the report of the extraction of an archive into objects
+// (see ExtractArchive): its ResponseStatus is the overall outcome (e.g.
+// "201 Created"), and Errors lists the files that could not be created.
+type BulkUploadReport struct {
+	NumberFilesCreated *int            `json:"Number Files Created,omitempty"`
+	ResponseStatus     *string         `json:"Response Status,omitempty"`
+	ResponseBody       *string         `json:"Response Body,omitempty"`
+	Errors             []BulkItemError `json:"Errors,omitempty"`
+}
+
+// BulkItemError is the failure of a single item (an object, a container or a
+// file in an archive) of a bulk operation, with its HTTP status (e.g.
+// "409 Conflict" for a container that is not empty).
+type BulkItemError struct {
+	Name   string
+	Status string
+}
+
+// UnmarshalJSON decodes the item error from the [name, status] pair used in
+// the reports of bulk operations.
+func (e *BulkItemError) UnmarshalJSON(data []byte) error {
+	pair := []string{}
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("invalid bulk operation error: %s", data)
+	}
+	e.Name, e.Status = pair[0], pair[1]
+	return nil
+}
+
+// Error returns a description of the item error.
+func (e BulkItemError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Name, e.Status)
+}
+
+// BulkError is returned when a bulk operation fails, as a whole or for some of
+// its items: Status and Body are the overall outcome, and Errors lists the
+// items that failed.
+type BulkError struct {
+	Status string
+	Body   string
+	Errors []BulkItemError
+}
+
+// Error returns a description of the bulk operation error.
+func (e *BulkError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("bulk operation failed: %s %s", e.Status, e.Body)
+	}
+	return fmt.Sprintf("bulk operation failed: %s (%d errors, first is %v)", e.Status, len(e.Errors), e.Errors[0])
+}
+
+// newBulkError returns a *BulkError if the given outcome and item errors of a
+// bulk operation report a failure, nil otherwise.
+func newBulkError(status *string, body *string, errors []BulkItemError) error {
+	if len(errors) == 0 && (status == nil || strings.HasPrefix(*status, "2")) {
+		return nil
+	}
+	return &BulkError{
+		Status: stringValue(status),
+		Body:   stringValue(body),
+		Errors: errors,
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"strings"
+)
+
+// OrchestrationV1API represents the orchestration API ver. 1 (Heat), providing
+// support for the management of stacks, their resources and events, templates
+// and software configurations.
+// Unlike most services, the Heat endpoint is scoped to a project, e.g.
+// https://heat.example.com:8004/v1/<project id>: some catalogs list it with an
+// unresolved placeholder (e.g. "%(tenant_id)s") or without the project id at
+// all, in which case the project the token is scoped to is used.
+// See https://developer.openstack.org/api-ref/orchestration/v1/
+type OrchestrationV1API struct {
+	API
+}
+
+// orchestrationProjectPlaceholders are the placeholders for the project id
+// that may be left unresolved in the Heat endpoint URL in the catalog.
+var orchestrationProjectPlaceholders = []string{
+	"%(tenant_id)s",
+	"%(project_id)s",
+	"$(tenant_id)s",
+	"$(project_id)s",
+}
+
+// orchestrationURL returns the project-scoped Heat endpoint URL from the one in
+// the catalog, which may lack the project id or contain a placeholder for it.
+func orchestrationURL(endpoint string, projectid string) string {
+	for _, placeholder := range orchestrationProjectPlaceholders {
+		endpoint = strings.Replace(endpoint, placeholder, projectid, -1)
+	}
+	if projectid != "" && strings.HasSuffix(strings.TrimSuffix(endpoint, "/"), "/v1") {
+		endpoint = strings.TrimSuffix(endpoint, "/") + "/" + projectid
+	}
+	return endpoint
+}
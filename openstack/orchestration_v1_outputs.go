@@ -0,0 +1,82 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * STACK OUTPUTS
+ */
+
+// ListStackOutputs returns the list of outputs of the stack with the given
+// name, id or full identity ("<name>/<id>"), with their keys and descriptions
+// but without their values; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-outputs
+func (api *OrchestrationV1API) ListStackOutputs(stack string) (*[]StackOutput, *Result, error) {
+	input := &struct {
+		Stack string `parameter:"-" header:"-" variable:"stack" json:"-"`
+	}{
+		Stack: stack,
+	}
+	outputs := &[]StackOutput{}
+
+	result, err := api.Invoke(http.MethodGet, "./stacks/{stack}/outputs", true, StatusCodeIn(200), input, &envelope{Name: "outputs", Body: outputs}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return outputs, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveStackOutput retrieves the output with the given key of the stack with
+// the given name, id or full identity; if value is not nil, the value of the
+// output is also decoded into it, so that it can be retrieved as the right type
+// (e.g. a *string for an IP address, a *[]string for a list of them, a pointer
+// to a struct for a map); if the output could not be resolved, an error with
+// the reason is returned; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#show-output
+func (api *OrchestrationV1API) RetrieveStackOutput(stack string, key string, value interface{}) (*StackOutput, *Result, error) {
+	input := &struct {
+		Stack string `parameter:"-" header:"-" variable:"stack" json:"-"`
+		Key   string `parameter:"-" header:"-" variable:"key" json:"-"`
+	}{
+		Stack: stack,
+		Key:   key,
+	}
+	output := &StackOutput{}
+
+	result, err := api.Invoke(http.MethodGet, "./stacks/{stack}/outputs/{key}", true, StatusCodeIn(200), input, &envelope{Name: "output", Body: output}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result == nil || result.Code != 200 {
+		return nil, result, err
+	}
+	if output.OutputError != nil {
+		log.Errorf("error resolving output %q of stack %q: %s", key, stack, *output.OutputError)
+		return output, result, fmt.Errorf("output %q of stack %q could not be resolved: %s", key, stack, *output.OutputError)
+	}
+	if value != nil {
+		if err := decodeStackOutput(output.OutputValue, value); err != nil {
+			log.Errorf("error decoding output %q of stack %q: %v", key, stack, err)
+			return output, result, err
+		}
+	}
+	return output, result, err
+}
+
+// decodeStackOutput decodes the (already decoded) value of an output into the
+// given value, which must be a pointer.
+func decodeStackOutput(output interface{}, value interface{}) error {
+	data, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
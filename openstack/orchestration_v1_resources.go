@@ -0,0 +1,230 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST STACK EVENTS
+ */
+
+// ListStackEventsOptions provides the options available for filtering the list
+// of events of a stack: ResourceName restricts it to the events of a resource;
+// NestedDepth includes the events of the nested stacks up to the given depth;
+// events come after Marker (an event id) in the list, e.g. to only retrieve
+// new events while a stack operation is in progress; Limit sets the page size,
+// all pages are retrieved anyway; see
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-stack-events.
+type ListStackEventsOptions struct {
+	ResourceName   *string `parameter:"resource_name,omitempty" header:"-" json:"-"`
+	ResourceType   *string `parameter:"resource_type,omitempty" header:"-" json:"-"`
+	ResourceAction *string `parameter:"resource_action,omitempty" header:"-" json:"-"`
+	ResourceStatus *string `parameter:"resource_status,omitempty" header:"-" json:"-"`
+	NestedDepth    *int    `parameter:"nested_depth,omitempty" header:"-" json:"-"`
+	SortKeys       *string `parameter:"sort_keys,omitempty" header:"-" json:"-"`
+	SortDir        *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit          *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker         *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListStackEvents returns the list of events of the stack with the given name,
+// id or full identity ("<name>/<id>"), oldest first unless sorted otherwise;
+// opts can be nil; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-stack-events
+func (api *OrchestrationV1API) ListStackEvents(stack string, opts *ListStackEventsOptions) (*[]StackEvent, *Result, error) {
+	if opts == nil {
+		opts = &ListStackEventsOptions{}
+	}
+	input := &struct {
+		Stack string `parameter:"-" header:"-" variable:"stack" json:"-"`
+		ListStackEventsOptions
+	}{
+		Stack:                  stack,
+		ListStackEventsOptions: *opts,
+	}
+	return api.listStackEvents("./stacks/{stack}/events", input, &input.Marker, &input.Limit)
+}
+
+// ListStackResourceEvents returns the list of events of the resource with the
+// given name in the stack with the given name, id or full identity; opts can
+// be nil; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-resource-events
+func (api *OrchestrationV1API) ListStackResourceEvents(stack string, resource string, opts *ListStackEventsOptions) (*[]StackEvent, *Result, error) {
+	if opts == nil {
+		opts = &ListStackEventsOptions{}
+	}
+	input := &struct {
+		Stack    string `parameter:"-" header:"-" variable:"stack" json:"-"`
+		Resource string `parameter:"-" header:"-" variable:"resource" json:"-"`
+		ListStackEventsOptions
+	}{
+		Stack:                  stack,
+		Resource:               resource,
+		ListStackEventsOptions: *opts,
+	}
+	return api.listStackEvents("./stacks/{stack}/resources/{resource}/events", input, &input.Marker, &input.Limit)
+}
+
+// listStackEvents retrieves all the pages of events at the given path, setting
+// the marker in the input to the id of the last event of each full page.
+func (api *OrchestrationV1API) listStackEvents(path string, input interface{}, marker **string, limit **int) (*[]StackEvent, *Result, error) {
+	events := []StackEvent{}
+	for {
+		page := []StackEvent{}
+		result, err := api.Invoke(http.MethodGet, path, true, StatusCodeIn(200), input, &envelope{Name: "events", Body: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		events = append(events, page...)
+		if *limit == nil || len(page) < **limit || len(page) == 0 {
+			return &events, result, err
+		}
+		// the next page starts after the last event of this one
+		*marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * LIST STACK RESOURCES
+ */
+
+// ListStackResourcesOptions provides the options available for filtering the
+// list of resources of a stack: NestedDepth includes the resources of the
+// nested stacks up to the given depth; WithDetail includes their attributes.
+type ListStackResourcesOptions struct {
+	Name        *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Type        *string `parameter:"type,omitempty" header:"-" json:"-"`
+	Status      *string `parameter:"status,omitempty" header:"-" json:"-"`
+	Action      *string `parameter:"action,omitempty" header:"-" json:"-"`
+	ID          *string `parameter:"id,omitempty" header:"-" json:"-"`
+	NestedDepth *int    `parameter:"nested_depth,omitempty" header:"-" json:"-"`
+	WithDetail  *bool   `parameter:"with_detail,omitempty" header:"-" json:"-"`
+}
+
+// ListStackResources returns the list of resources of the stack with the given
+// name, id or full identity; opts can be nil; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-stack-resources
+func (api *OrchestrationV1API) ListStackResources(stack string, opts *ListStackResourcesOptions) (*[]StackResource, *Result, error) {
+	if opts == nil {
+		opts = &ListStackResourcesOptions{}
+	}
+	input := &struct {
+		Stack string `parameter:"-" header:"-" variable:"stack" json:"-"`
+		ListStackResourcesOptions
+	}{
+		Stack:                     stack,
+		ListStackResourcesOptions: *opts,
+	}
+	resources := &[]StackResource{}
+
+	result, err := api.Invoke(http.MethodGet, "./stacks/{stack}/resources", true, StatusCodeIn(200), input, &envelope{Name: "resources", Body: resources}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return resources, result, err
+	}
+	return nil, result, err
+}
+
+// stackResourceRequest is the request entity of the calls on a resource of a
+// stack, which address the resource with the given name in the given stack.
+type stackResourceRequest struct {
+	Stack    string `parameter:"-" header:"-" variable:"stack" json:"-"`
+	Resource string `parameter:"-" header:"-" variable:"resource" json:"-"`
+}
+
+/*
+ * RETRIEVE STACK RESOURCE
+ */
+
+// RetrieveStackResource retrieves the details of the resource with the given
+// name in the stack with the given name, id or full identity, including its
+// attributes; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#show-resource-data
+func (api *OrchestrationV1API) RetrieveStackResource(stack string, resource string) (*StackResource, *Result, error) {
+	input := &stackResourceRequest{
+		Stack:    stack,
+		Resource: resource,
+	}
+	output := &StackResource{}
+
+	result, err := api.Invoke(http.MethodGet, "./stacks/{stack}/resources/{resource}", true, StatusCodeIn(200), input, &envelope{Name: "resource", Body: output}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * STACK RESOURCE METADATA
+ */
+
+// RetrieveStackResourceMetadata retrieves the metadata of the resource with
+// the given name in the stack with the given name, id or full identity, e.g.
+// the software configuration of a server; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#show-resource-metadata
+func (api *OrchestrationV1API) RetrieveStackResourceMetadata(stack string, resource string) (*map[string]interface{}, *Result, error) {
+	input := &stackResourceRequest{
+		Stack:    stack,
+		Resource: resource,
+	}
+	metadata := &map[string]interface{}{}
+
+	result, err := api.Invoke(http.MethodGet, "./stacks/{stack}/resources/{resource}/metadata", true, StatusCodeIn(200), input, &envelope{Name: "metadata", Body: metadata}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return metadata, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * SIGNAL STACK RESOURCE
+ */
+
+// SignalStackResource sends a signal, with the given data (if any), to the
+// resource with the given name in the stack with the given name, id or full
+// identity, e.g. to a wait condition handle or to a scaling policy; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#send-a-signal-to-a-resource
+func (api *OrchestrationV1API) SignalStackResource(stack string, resource string, data interface{}) (bool, *Result, error) {
+	identity, result, err := api.stackIdentity(stack)
+	if identity == "" {
+		return false, result, err
+	}
+	input := &stackSignal{
+		Stack:    identity,
+		Resource: resource,
+		Data:     data,
+	}
+
+	result, err = api.Invoke(http.MethodPost, "./stacks/{stack}/resources/{resource}/signal", true, StatusCodeIn(200), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// stackSignal is the request entity of resource signals, whose data is sent as
+// the request body.
+type stackSignal struct {
+	Stack    string      `parameter:"-" header:"-" variable:"stack" json:"-"`
+	Resource string      `parameter:"-" header:"-" variable:"resource" json:"-"`
+	Data     interface{} `parameter:"-" header:"-" json:"-"`
+}
+
+// MarshalJSON encodes the signal data, or an empty object if there is none.
+func (s stackSignal) MarshalJSON() ([]byte, error) {
+	if s.Data == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(s.Data)
+}
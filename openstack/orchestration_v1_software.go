@@ -0,0 +1,257 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Software deployment status values, as reported by the agents on the servers.
+const (
+	SoftwareDeploymentStatusInProgress string = "IN_PROGRESS"
+	SoftwareDeploymentStatusComplete   string = "COMPLETE"
+	SoftwareDeploymentStatusFailed     string = "FAILED"
+)
+
+/*
+ * LIST SOFTWARE CONFIGS
+ */
+
+// ListSoftwareConfigsOptions provides the options available for listing the
+// software configurations; Limit sets the page size, all pages are retrieved
+// anyway.
+type ListSoftwareConfigsOptions struct {
+	Limit  *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListSoftwareConfigs returns the list of software configurations of the
+// current project, without their contents; opts can be nil; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-configs
+func (api *OrchestrationV1API) ListSoftwareConfigs(opts *ListSoftwareConfigsOptions) (*[]SoftwareConfig, *Result, error) {
+	if opts == nil {
+		opts = &ListSoftwareConfigsOptions{}
+	}
+	input := *opts
+
+	configs := []SoftwareConfig{}
+	for {
+		page := []SoftwareConfig{}
+		result, err := api.Invoke(http.MethodGet, "./software_configs", true, StatusCodeIn(200), &input, &envelope{Name: "software_configs", Body: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		configs = append(configs, page...)
+		if input.Limit == nil || len(page) < *input.Limit || len(page) == 0 {
+			return &configs, result, err
+		}
+		// the next page starts after the last configuration of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE SOFTWARE CONFIG
+ */
+
+// CreateSoftwareConfigOptions provides the options available for creating a
+// software configuration: Config is its contents (e.g. a script), Group the
+// tool applying it; software configurations cannot be updated afterwards.
+type CreateSoftwareConfigOptions struct {
+	Name    *string                 `parameter:"-" header:"-" json:"name,omitempty"`
+	Group   *string                 `parameter:"-" header:"-" json:"group,omitempty"`
+	Config  *string                 `parameter:"-" header:"-" json:"config,omitempty"`
+	Inputs  *[]SoftwareConfigIO     `parameter:"-" header:"-" json:"inputs,omitempty"`
+	Outputs *[]SoftwareConfigIO     `parameter:"-" header:"-" json:"outputs,omitempty"`
+	Options *map[string]interface{} `parameter:"-" header:"-" json:"options,omitempty"`
+}
+
+// CreateSoftwareConfig creates a software configuration; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#create-configuration
+func (api *OrchestrationV1API) CreateSoftwareConfig(opts *CreateSoftwareConfigOptions) (*SoftwareConfig, *Result, error) {
+	config := &SoftwareConfig{}
+	result, err := api.Invoke(http.MethodPost, "./software_configs", true, StatusCodeIn(200), opts, &envelope{Name: "software_config", Body: config}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return config, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SOFTWARE CONFIG
+ */
+
+// RetrieveSoftwareConfig retrieves the software configuration with the given
+// id, including its contents; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#show-configuration-details
+func (api *OrchestrationV1API) RetrieveSoftwareConfig(configid string) (*SoftwareConfig, *Result, error) {
+	config := &SoftwareConfig{}
+	result, err := api.Invoke(http.MethodGet, "./software_configs/{id}", true, StatusCodeIn(200), &envelope{ID: configid}, &envelope{Name: "software_config", Body: config}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return config, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE SOFTWARE CONFIG
+ */
+
+// DeleteSoftwareConfig deletes the software configuration with the given id;
+// see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#delete-config
+func (api *OrchestrationV1API) DeleteSoftwareConfig(configid string) (bool, *Result, error) {
+	return api.deleteOrchestrationResource("./software_configs/{id}", configid)
+}
+
+/*
+ * LIST SOFTWARE DEPLOYMENTS
+ */
+
+// ListSoftwareDeploymentsOptions provides the options available for filtering
+// the list of software deployments.
+type ListSoftwareDeploymentsOptions struct {
+	ServerID *string `parameter:"server_id,omitempty" header:"-" json:"-"`
+}
+
+// ListSoftwareDeployments returns the list of software deployments of the
+// current project, or of the given server; opts can be nil; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-deployments
+func (api *OrchestrationV1API) ListSoftwareDeployments(opts *ListSoftwareDeploymentsOptions) (*[]SoftwareDeployment, *Result, error) {
+	deployments := &[]SoftwareDeployment{}
+	result, err := api.Invoke(http.MethodGet, "./software_deployments", true, StatusCodeIn(200), opts, &envelope{Name: "software_deployments", Body: deployments}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return deployments, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE SOFTWARE DEPLOYMENT
+ */
+
+// CreateSoftwareDeploymentOptions provides the options available for creating
+// a software deployment, i.e. for applying the software configuration with the
+// given id to the server with the given id: Action is the stack action it
+// belongs to (e.g. "CREATE"), InputValues the values of the configuration
+// inputs; StackUserProjectID is the project of the user the agent on the server
+// uses to report back.
+type CreateSoftwareDeploymentOptions struct {
+	ConfigID           string                  `parameter:"-" header:"-" json:"config_id"`
+	ServerID           string                  `parameter:"-" header:"-" json:"server_id"`
+	Action             *string                 `parameter:"-" header:"-" json:"action,omitempty"`
+	Status             *string                 `parameter:"-" header:"-" json:"status,omitempty"`
+	StatusReason       *string                 `parameter:"-" header:"-" json:"status_reason,omitempty"`
+	InputValues        *map[string]interface{} `parameter:"-" header:"-" json:"input_values,omitempty"`
+	StackUserProjectID *string                 `parameter:"-" header:"-" json:"stack_user_project_id,omitempty"`
+}
+
+// CreateSoftwareDeployment creates a software deployment; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#create-deployment
+func (api *OrchestrationV1API) CreateSoftwareDeployment(opts *CreateSoftwareDeploymentOptions) (*SoftwareDeployment, *Result, error) {
+	deployment := &SoftwareDeployment{}
+	result, err := api.Invoke(http.MethodPost, "./software_deployments", true, StatusCodeIn(200), opts, &envelope{Name: "software_deployment", Body: deployment}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return deployment, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SOFTWARE DEPLOYMENT
+ */
+
+// RetrieveSoftwareDeployment retrieves the software deployment with the given
+// id; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#show-deployment-details
+func (api *OrchestrationV1API) RetrieveSoftwareDeployment(deploymentid string) (*SoftwareDeployment, *Result, error) {
+	deployment := &SoftwareDeployment{}
+	result, err := api.Invoke(http.MethodGet, "./software_deployments/{id}", true, StatusCodeIn(200), &envelope{ID: deploymentid}, &envelope{Name: "software_deployment", Body: deployment}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return deployment, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveServerSoftwareMetadata retrieves the deployment metadata of the
+// server with the given id, i.e. the configurations to be applied to it with
+// their input values, as polled by the agent on the server; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#show-server-configuration-metadata
+func (api *OrchestrationV1API) RetrieveServerSoftwareMetadata(serverid string) (*[]SoftwareConfig, *Result, error) {
+	metadata := &[]SoftwareConfig{}
+	result, err := api.Invoke(http.MethodGet, "./software_deployments/metadata/{id}", true, StatusCodeIn(200), &envelope{ID: serverid}, &envelope{Name: "metadata", Body: metadata}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return metadata, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE SOFTWARE DEPLOYMENT
+ */
+
+// UpdateSoftwareDeploymentOptions provides the options available for updating
+// a software deployment, e.g. to apply a new configuration or, from the agent
+// on the server, to report its status and output values.
+type UpdateSoftwareDeploymentOptions struct {
+	ConfigID     *string                 `parameter:"-" header:"-" json:"config_id,omitempty"`
+	Action       *string                 `parameter:"-" header:"-" json:"action,omitempty"`
+	Status       *string                 `parameter:"-" header:"-" json:"status,omitempty"`
+	StatusReason *string                 `parameter:"-" header:"-" json:"status_reason,omitempty"`
+	InputValues  *map[string]interface{} `parameter:"-" header:"-" json:"input_values,omitempty"`
+	OutputValues *map[string]interface{} `parameter:"-" header:"-" json:"output_values,omitempty"`
+}
+
+// UpdateSoftwareDeployment updates the software deployment with the given id;
+// see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#update-deployment
+func (api *OrchestrationV1API) UpdateSoftwareDeployment(deploymentid string, opts *UpdateSoftwareDeploymentOptions) (*SoftwareDeployment, *Result, error) {
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		UpdateSoftwareDeploymentOptions
+	}{
+		ID:                              deploymentid,
+		UpdateSoftwareDeploymentOptions: *opts,
+	}
+	deployment := &SoftwareDeployment{}
+
+	result, err := api.Invoke(http.MethodPut, "./software_deployments/{id}", true, StatusCodeIn(200), input, &envelope{Name: "software_deployment", Body: deployment}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return deployment, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE SOFTWARE DEPLOYMENT
+ */
+
+// DeleteSoftwareDeployment deletes the software deployment with the given id;
+// see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#delete-deployment
+func (api *OrchestrationV1API) DeleteSoftwareDeployment(deploymentid string) (bool, *Result, error) {
+	return api.deleteOrchestrationResource("./software_deployments/{id}", deploymentid)
+}
+
+// deleteOrchestrationResource deletes the resource with the given id at the
+// given path, which must contain the {id} variable.
+func (api *OrchestrationV1API) deleteOrchestrationResource(path string, id string) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodDelete, path, true, StatusCodeIn(204), &envelope{ID: id}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
@@ -0,0 +1,305 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// Stack status values, made of the stack action and its progress.
+const (
+	StackStatusCreateInProgress   string = "CREATE_IN_PROGRESS"
+	StackStatusCreateComplete     string = "CREATE_COMPLETE"
+	StackStatusCreateFailed       string = "CREATE_FAILED"
+	StackStatusUpdateInProgress   string = "UPDATE_IN_PROGRESS"
+	StackStatusUpdateComplete     string = "UPDATE_COMPLETE"
+	StackStatusUpdateFailed       string = "UPDATE_FAILED"
+	StackStatusDeleteInProgress   string = "DELETE_IN_PROGRESS"
+	StackStatusDeleteComplete     string = "DELETE_COMPLETE"
+	StackStatusDeleteFailed       string = "DELETE_FAILED"
+	StackStatusRollbackInProgress string = "ROLLBACK_IN_PROGRESS"
+	StackStatusRollbackComplete   string = "ROLLBACK_COMPLETE"
+	StackStatusRollbackFailed     string = "ROLLBACK_FAILED"
+	StackStatusAdoptInProgress    string = "ADOPT_IN_PROGRESS"
+	StackStatusAdoptComplete      string = "ADOPT_COMPLETE"
+	StackStatusAdoptFailed        string = "ADOPT_FAILED"
+)
+
+// ReadTemplate reads a template (or an environment) from the given reader, to
+// be used in the options of stack calls.
+func ReadTemplate(reader io.Reader) (*string, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		log.Errorf("error reading template: %v", err)
+		return nil, err
+	}
+	return String(string(data)), nil
+}
+
+// ReadTemplateFile reads a template (or an environment) from the file at the
+// given path, to be used in the options of stack calls.
+func ReadTemplateFile(path string) (*string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Errorf("error opening template file %q: %v", path, err)
+		return nil, err
+	}
+	defer file.Close()
+	return ReadTemplate(file)
+}
+
+// stackIdentity returns the full identity (i.e. "<name>/<id>") of the stack
+// with the given name or id, as required by the calls that modify a stack: the
+// short forms are only resolved by Heat, through a redirect, for GET requests.
+func (api *OrchestrationV1API) stackIdentity(stack string) (string, *Result, error) {
+	if strings.Contains(stack, "/") {
+		return stack, nil, nil
+	}
+	resolved, result, err := api.RetrieveStack(stack)
+	if resolved == nil {
+		return "", result, err
+	}
+	return stringValue(resolved.StackName) + "/" + stringValue(resolved.ID), result, err
+}
+
+/*
+ * LIST STACKS
+ */
+
+// ListStacksOptions provides the options available for filtering the list of
+// stacks; Limit sets the page size, all pages are retrieved anyway; the
+// GlobalTenant and Tenant filters are reserved to administrators; see
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-stacks.
+type ListStacksOptions struct {
+	ID           *string             `parameter:"id,omitempty" header:"-" json:"-"`
+	Name         *string             `parameter:"name,omitempty" header:"-" json:"-"`
+	Status       *string             `parameter:"status,omitempty" header:"-" json:"-"`
+	Action       *string             `parameter:"action,omitempty" header:"-" json:"-"`
+	Tenant       *string             `parameter:"tenant,omitempty" header:"-" json:"-"`
+	Username     *string             `parameter:"username,omitempty" header:"-" json:"-"`
+	OwnerID      *string             `parameter:"owner_id,omitempty" header:"-" json:"-"`
+	Tags         *CommaSeparatedList `parameter:"tags,omitempty" header:"-" json:"-"`
+	TagsAny      *CommaSeparatedList `parameter:"tags_any,omitempty" header:"-" json:"-"`
+	NotTags      *CommaSeparatedList `parameter:"not_tags,omitempty" header:"-" json:"-"`
+	NotTagsAny   *CommaSeparatedList `parameter:"not_tags_any,omitempty" header:"-" json:"-"`
+	GlobalTenant *bool               `parameter:"global_tenant,omitempty" header:"-" json:"-"`
+	ShowDeleted  *bool               `parameter:"show_deleted,omitempty" header:"-" json:"-"`
+	ShowNested   *bool               `parameter:"show_nested,omitempty" header:"-" json:"-"`
+	ShowHidden   *bool               `parameter:"show_hidden,omitempty" header:"-" json:"-"`
+	SortKeys     *string             `parameter:"sort_keys,omitempty" header:"-" json:"-"`
+	SortDir      *string             `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit        *int                `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker       *string             `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListStacks returns the list of stacks in the current project (or in all the
+// projects, for administrators); opts can be nil; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-stacks
+func (api *OrchestrationV1API) ListStacks(opts *ListStacksOptions) (*[]Stack, *Result, error) {
+	if opts == nil {
+		opts = &ListStacksOptions{}
+	}
+	input := *opts
+
+	stacks := []Stack{}
+	for {
+		page := []Stack{}
+		result, err := api.Invoke(http.MethodGet, "./stacks", true, StatusCodeIn(200), &input, &envelope{Name: "stacks", Body: &page}, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return nil, result, err
+		}
+		stacks = append(stacks, page...)
+		if input.Limit == nil || len(page) < *input.Limit || len(page) == 0 {
+			return &stacks, result, err
+		}
+		// the next page starts after the last stack of this one
+		input.Marker = page[len(page)-1].ID
+	}
+}
+
+/*
+ * CREATE STACK
+ */
+
+// CreateStackOptions provides the options available for creating a stack: the
+// template is given either as a string (see ReadTemplate and ReadTemplateFile)
+// or by URL; Files maps the names of the files referenced by the template (e.g.
+// nested templates, scripts) to their contents; Environment is given as a map
+// or as a string; Tags is a comma-separated list; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#create-stack.
+type CreateStackOptions struct {
+	StackName        string                 `parameter:"-" header:"-" json:"stack_name"`
+	Template         *string                `parameter:"-" header:"-" json:"template,omitempty"`
+	TemplateURL      *string                `parameter:"-" header:"-" json:"template_url,omitempty"`
+	Files            map[string]string      `parameter:"-" header:"-" json:"files,omitempty"`
+	Environment      interface{}            `parameter:"-" header:"-" json:"environment,omitempty"`
+	EnvironmentFiles *[]string              `parameter:"-" header:"-" json:"environment_files,omitempty"`
+	Parameters       map[string]interface{} `parameter:"-" header:"-" json:"parameters,omitempty"`
+	TimeoutMins      *int                   `parameter:"-" header:"-" json:"timeout_mins,omitempty"`
+	DisableRollback  *bool                  `parameter:"-" header:"-" json:"disable_rollback,omitempty"`
+	Tags             *string                `parameter:"-" header:"-" json:"tags,omitempty"`
+}
+
+// CreateStack creates a stack, asynchronously: only its id and links are
+// returned, its status can then be polled with RetrieveStack; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#create-stack
+func (api *OrchestrationV1API) CreateStack(opts *CreateStackOptions) (*Stack, *Result, error) {
+	stack := &Stack{}
+	result, err := api.Invoke(http.MethodPost, "./stacks", true, StatusCodeIn(201), opts, &envelope{Name: "stack", Body: stack}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return stack, result, err
+	}
+	return nil, result, err
+}
+
+// AdoptStack creates a stack from existing resources, as described by the data
+// returned by AbandonStack, asynchronously; the template, environment and
+// parameters in opts must be those of the abandoned stack; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#adopt-stack
+func (api *OrchestrationV1API) AdoptStack(data string, opts *CreateStackOptions) (*Stack, *Result, error) {
+	input := &struct {
+		CreateStackOptions
+		AdoptStackData string `parameter:"-" header:"-" json:"adopt_stack_data"`
+	}{
+		CreateStackOptions: *opts,
+		AdoptStackData:     data,
+	}
+	stack := &Stack{}
+	result, err := api.Invoke(http.MethodPost, "./stacks", true, StatusCodeIn(201), input, &envelope{Name: "stack", Body: stack}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return stack, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE STACK
+ */
+
+// RetrieveStack retrieves the details of the stack with the given name, id or
+// full identity ("<name>/<id>"), including its outputs; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#show-stack-details
+func (api *OrchestrationV1API) RetrieveStack(stack string) (*Stack, *Result, error) {
+	output := &Stack{}
+	result, err := api.Invoke(http.MethodGet, "./stacks/{id}", true, StatusCodeIn(200), &envelope{ID: stack}, &envelope{Name: "stack", Body: output}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE STACK
+ */
+
+// UpdateStackOptions provides the options available for updating a stack:
+// with UpdateStack, the template, environment and parameters replace the
+// existing ones; with PatchStack, the missing ones are kept and ClearParameters
+// lists the parameters to reset to their defaults; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#update-stack.
+type UpdateStackOptions struct {
+	Template         *string                `parameter:"-" header:"-" json:"template,omitempty"`
+	TemplateURL      *string                `parameter:"-" header:"-" json:"template_url,omitempty"`
+	Files            map[string]string      `parameter:"-" header:"-" json:"files,omitempty"`
+	Environment      interface{}            `parameter:"-" header:"-" json:"environment,omitempty"`
+	EnvironmentFiles *[]string              `parameter:"-" header:"-" json:"environment_files,omitempty"`
+	Parameters       map[string]interface{} `parameter:"-" header:"-" json:"parameters,omitempty"`
+	ClearParameters  *[]string              `parameter:"-" header:"-" json:"clear_parameters,omitempty"`
+	TimeoutMins      *int                   `parameter:"-" header:"-" json:"timeout_mins,omitempty"`
+	DisableRollback  *bool                  `parameter:"-" header:"-" json:"disable_rollback,omitempty"`
+	Tags             *string                `parameter:"-" header:"-" json:"tags,omitempty"`
+}
+
+// UpdateStack updates the stack with the given name, id or full identity,
+// asynchronously, replacing its template, environment and parameters; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#update-stack
+func (api *OrchestrationV1API) UpdateStack(stack string, opts *UpdateStackOptions) (bool, *Result, error) {
+	return api.updateStack(http.MethodPut, stack, opts)
+}
+
+// PatchStack updates the stack with the given name, id or full identity,
+// asynchronously, keeping its existing template, environment and parameters
+// unless given in opts (i.e. "update-existing"); see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#update-stack-patch
+func (api *OrchestrationV1API) PatchStack(stack string, opts *UpdateStackOptions) (bool, *Result, error) {
+	return api.updateStack(http.MethodPatch, stack, opts)
+}
+
+// updateStack updates a stack with the given method.
+func (api *OrchestrationV1API) updateStack(method string, stack string, opts *UpdateStackOptions) (bool, *Result, error) {
+	identity, result, err := api.stackIdentity(stack)
+	if identity == "" {
+		return false, result, err
+	}
+	input := &struct {
+		Stack string `parameter:"-" header:"-" variable:"stack" json:"-"`
+		UpdateStackOptions
+	}{
+		Stack:              identity,
+		UpdateStackOptions: *opts,
+	}
+
+	result, err = api.Invoke(method, "./stacks/{stack}", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * DELETE STACK
+ */
+
+// DeleteStack deletes the stack with the given name, id or full identity,
+// along with its resources, asynchronously; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#delete-stack
+func (api *OrchestrationV1API) DeleteStack(stack string) (bool, *Result, error) {
+	identity, result, err := api.stackIdentity(stack)
+	if identity == "" {
+		return false, result, err
+	}
+
+	result, err = api.Invoke(http.MethodDelete, "./stacks/{id}", true, StatusCodeIn(204), &envelope{ID: identity}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * ABANDON STACK
+ */
+
+// AbandonStack deletes the stack with the given name, id or full identity,
+// leaving its resources in place, and returns the data describing them (as a
+// JSON document), which can be used to adopt them into a new stack (see
+// AdoptStack); this must be enabled in the Heat configuration; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#abandon-stack
+func (api *OrchestrationV1API) AbandonStack(stack string) (*string, *Result, error) {
+	identity, result, err := api.stackIdentity(stack)
+	if identity == "" {
+		return nil, result, err
+	}
+
+	data := String("")
+	result, err = api.Invoke(http.MethodDelete, "./stacks/{id}/abandon", true, StatusCodeIn(200), &envelope{ID: identity}, data, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return data, result, err
+	}
+	return nil, result, err
+}
@@ -0,0 +1,116 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// TemplateValidationError is returned when Heat finds a template invalid:
+// Type is the kind of error (e.g. "StackValidationFailed") and Message
+// describes the problem, usually including its location in the template.
+type TemplateValidationError struct {
+	Type    string
+	Message string
+}
+
+// Error returns a description of the validation error.
+func (e *TemplateValidationError) Error() string {
+	return fmt.Sprintf("invalid template: %s: %s", e.Type, e.Message)
+}
+
+/*
+ * VALIDATE TEMPLATE
+ */
+
+// ValidateTemplateOptions provides the options available for validating a
+// template, which are the same as for creating a stack (see
+// CreateStackOptions); ShowNested also validates the nested templates and
+// reports their parameters; IgnoreErrors lists error codes to be ignored.
+type ValidateTemplateOptions struct {
+	Template         *string                `parameter:"-" header:"-" json:"template,omitempty"`
+	TemplateURL      *string                `parameter:"-" header:"-" json:"template_url,omitempty"`
+	Files            map[string]string      `parameter:"-" header:"-" json:"files,omitempty"`
+	Environment      interface{}            `parameter:"-" header:"-" json:"environment,omitempty"`
+	EnvironmentFiles *[]string              `parameter:"-" header:"-" json:"environment_files,omitempty"`
+	Parameters       map[string]interface{} `parameter:"-" header:"-" json:"parameters,omitempty"`
+	ShowNested       *bool                  `parameter:"show_nested,omitempty" header:"-" json:"-"`
+	IgnoreErrors     *CommaSeparatedList    `parameter:"ignore_errors,omitempty" header:"-" json:"-"`
+}
+
+// ValidateTemplate validates a template against the target cloud, e.g. its
+// syntax, the resource types and the functions it uses, and the values of its
+// parameters in the environment, if any; if the template is invalid, a
+// *TemplateValidationError is returned; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#validate-template
+func (api *OrchestrationV1API) ValidateTemplate(opts *ValidateTemplateOptions) (*TemplateValidation, *Result, error) {
+	output := &TemplateValidation{}
+	failure := &OrchestrationFault{}
+
+	result, err := api.Invoke(http.MethodPost, "./validate", true, StatusCodeIn(200), opts, output, failure)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	if err == nil && result != nil && result.Code == 400 && failure.Error != nil {
+		err = &TemplateValidationError{
+			Type:    stringValue(failure.Error.Type),
+			Message: stringValue(failure.Error.Message),
+		}
+	}
+	return nil, result, err
+}
+
+/*
+ * TEMPLATE VERSIONS AND FUNCTIONS
+ */
+
+// ListTemplateVersions returns the list of template format versions supported
+// by Heat; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-template-versions
+func (api *OrchestrationV1API) ListTemplateVersions() (*[]TemplateVersion, *Result, error) {
+	versions := &[]TemplateVersion{}
+	result, err := api.Invoke(http.MethodGet, "./template_versions", true, StatusCodeIn(200), nil, &envelope{Name: "template_versions", Body: versions}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return versions, result, err
+	}
+	return nil, result, err
+}
+
+// ListTemplateFunctionsOptions provides the options available for listing the
+// functions of a template version: WithConditionFunctions also lists those
+// that can only be used in conditions.
+type ListTemplateFunctionsOptions struct {
+	WithConditionFunctions *bool `parameter:"with_condition_func,omitempty" header:"-" json:"-"`
+}
+
+// ListTemplateFunctions returns the list of the intrinsic functions available
+// in templates of the given version (e.g. "heat_template_version.2018-08-31");
+// opts can be nil; see also
+// https://developer.openstack.org/api-ref/orchestration/v1/#list-template-functions
+func (api *OrchestrationV1API) ListTemplateFunctions(version string, opts *ListTemplateFunctionsOptions) (*[]TemplateFunction, *Result, error) {
+	if opts == nil {
+		opts = &ListTemplateFunctionsOptions{}
+	}
+	input := &struct {
+		Version string `parameter:"-" header:"-" variable:"version" json:"-"`
+		ListTemplateFunctionsOptions
+	}{
+		Version:                      version,
+		ListTemplateFunctionsOptions: *opts,
+	}
+	functions := &[]TemplateFunction{}
+
+	result, err := api.Invoke(http.MethodGet, "./template_versions/{version}/functions", true, StatusCodeIn(200), input, &envelope{Name: "template_functions", Body: functions}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return functions, result, err
+	}
+	return nil, result, err
+}
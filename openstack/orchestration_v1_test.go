@@ -0,0 +1,65 @@
+package openstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOrchestrationURL(t *testing.T) {
+	urls := map[string]string{
+		"https://heat:8004/v1/p1":             "https://heat:8004/v1/p1",
+		"https://heat:8004/v1/%(tenant_id)s":  "https://heat:8004/v1/p1",
+		"https://heat:8004/v1/$(project_id)s": "https://heat:8004/v1/p1",
+		"https://heat:8004/v1":                "https://heat:8004/v1/p1",
+		"https://heat:8004/v1/":               "https://heat:8004/v1/p1",
+	}
+	for endpoint, expected := range urls {
+		if actual := orchestrationURL(endpoint, "p1"); actual != expected {
+			t.Errorf("Orchestration.TestOrchestrationURL: expected %q for %q, got %q", expected, endpoint, actual)
+		}
+	}
+}
+
+func TestWaitForStackStatus(t *testing.T) {
+	statuses := []string{"CREATE_IN_PROGRESS", "CREATE_IN_PROGRESS", "ROLLBACK_COMPLETE"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/p1/stacks/web/s1":
+			status := statuses[0]
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			w.Write([]byte(`{"stack": {"id": "s1", "stack_name": "web", "stack_status": "` + status + `", "stack_status_reason": "rolled back"}}`))
+		case "/v1/p1/stacks/web/s1/events":
+			if r.URL.Query().Get("resource_status") != "FAILED" || r.URL.Query().Get("sort_dir") != "desc" {
+				t.Errorf("Wait.TestWaitForStackStatus: unexpected events query: %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"events": [{"id": "e1", "resource_name": "server", "resource_status": "CREATE_FAILED", "resource_status_reason": "No valid host was found"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDefaultClient(server.URL + "/v1/p1")
+	client.Authenticator.SetToken(&Token{Value: String("token")})
+	api := &OrchestrationV1API{client.Authenticator.Identity.API}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := api.WaitForStackStatus(ctx, "web/s1", StackStatusCreateComplete, Backoff{Initial: time.Millisecond})
+	failure, ok := err.(*StackStatusError)
+	if !ok {
+		t.Fatalf("Wait.TestWaitForStackStatus: expected status error, got %v", err)
+	}
+	if failure.Status != StackStatusRollbackComplete || failure.Event == nil || stringValue(failure.Event.ResourceName) != "server" {
+		t.Errorf("Wait.TestWaitForStackStatus: unexpected error: %v", failure)
+	}
+
+	if _, err := api.WaitForStackStatus(ctx, "web/s2", StackStatusDeleteComplete, Backoff{Initial: time.Millisecond}); err != nil {
+		t.Errorf("Wait.TestWaitForStackStatus: expected deleted stack, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * STACKS
+ */
+
+// Stack is a set of resources created and managed together by Heat, according
+// to a template; Parameters holds the values of the template parameters (and
+// of some pseudo-parameters, e.g. "OS::stack_id"), Outputs those of the
+// template outputs, which are only returned when retrieving a single stack.
+type Stack struct {
+	ID                  *string                 `json:"id,omitempty"`
+	StackName           *string                 `json:"stack_name,omitempty"`
+	Description         *string                 `json:"description,omitempty"`
+	TemplateDescription *string                 `json:"template_description,omitempty"`
+	StackStatus         *string                 `json:"stack_status,omitempty"`
+	StackStatusReason   *string                 `json:"stack_status_reason,omitempty"`
+	CreationTime        *string                 `json:"creation_time,omitempty"`
+	UpdatedTime         *string                 `json:"updated_time,omitempty"`
+	DeletionTime        *string                 `json:"deletion_time,omitempty"`
+	TimeoutMins         *int                    `json:"timeout_mins,omitempty"`
+	DisableRollback     *bool                   `json:"disable_rollback,omitempty"`
+	Parent              *string                 `json:"parent,omitempty"`
+	StackOwner          *string                 `json:"stack_owner,omitempty"`
+	StackUserProjectID  *string                 `json:"stack_user_project_id,omitempty"`
+	Parameters          *map[string]interface{} `json:"parameters,omitempty"`
+	Outputs             *[]StackOutput          `json:"outputs,omitempty"`
+	Capabilities        *[]interface{}          `json:"capabilities,omitempty"`
+	NotificationTopics  *[]interface{}          `json:"notification_topics,omitempty"`
+	Tags                *[]string               `json:"tags,omitempty"`
+	Links               *[]Link                 `json:"links,omitempty"`
+}
+
+// StackOutput is the value of an output of a stack, as defined in its
+// template; OutputError is set if the value could not be resolved.
+type StackOutput struct {
+	OutputKey   *string     `json:"output_key,omitempty"`
+	OutputValue interface{} `json:"output_value,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	OutputError *string     `json:"output_error,omitempty"`
+}
+
+/*
+ * EVENTS AND RESOURCES
+ */
+
+// StackEvent is an event in the life cycle of a stack or of one of its
+// resources, e.g. the start or the completion of its creation; for events of
+// the stack itself, ResourceName is the stack name.
+type StackEvent struct {
+	ID                   *string `json:"id,omitempty"`
+	EventTime            *string `json:"event_time,omitempty"`
+	ResourceName         *string `json:"resource_name,omitempty"`
+	ResourceType         *string `json:"resource_type,omitempty"`
+	ResourceStatus       *string `json:"resource_status,omitempty"`
+	ResourceStatusReason *string `json:"resource_status_reason,omitempty"`
+	LogicalResourceID    *string `json:"logical_resource_id,omitempty"`
+	PhysicalResourceID   *string `json:"physical_resource_id,omitempty"`
+	Links                *[]Link `json:"links,omitempty"`
+}
+
+// StackResource is a resource of a stack, as defined in its template:
+// LogicalResourceID is its name in the template, PhysicalResourceID the id of
+// the actual resource (e.g. a server) in the service managing it; Attributes
+// are only returned when retrieving a single resource.
+type StackResource struct {
+	ResourceName         *string                 `json:"resource_name,omitempty"`
+	ResourceType         *string                 `json:"resource_type,omitempty"`
+	ResourceStatus       *string                 `json:"resource_status,omitempty"`
+	ResourceStatusReason *string                 `json:"resource_status_reason,omitempty"`
+	LogicalResourceID    *string                 `json:"logical_resource_id,omitempty"`
+	PhysicalResourceID   *string                 `json:"physical_resource_id,omitempty"`
+	Description          *string                 `json:"description,omitempty"`
+	CreationTime         *string                 `json:"creation_time,omitempty"`
+	UpdatedTime          *string                 `json:"updated_time,omitempty"`
+	RequiredBy           *[]string               `json:"required_by,omitempty"`
+	ParentResource       *string                 `json:"parent_resource,omitempty"`
+	StackName            *string                 `json:"stack_name,omitempty"`
+	Attributes           *map[string]interface{} `json:"attributes,omitempty"`
+	Links                *[]Link                 `json:"links,omitempty"`
+}
+
+/*
+ * TEMPLATES
+ */
+
+// TemplateValidation is the outcome of the successful validation of a template:
+// its description, parameters (by name) and parameter groups.
+type TemplateValidation struct {
+	Description     *string                       `json:"Description,omitempty"`
+	Parameters      *map[string]TemplateParameter `json:"Parameters,omitempty"`
+	ParameterGroups *[]TemplateParameterGroup     `json:"ParameterGroups,omitempty"`
+}
+
+// TemplateParameter is a parameter of a template, as reported by its
+// validation; the value given in the environment, if any, is in Value.
+type TemplateParameter struct {
+	Type                  *string        `json:"Type,omitempty"`
+	Label                 *string        `json:"Label,omitempty"`
+	Description           *string        `json:"Description,omitempty"`
+	Default               interface{}    `json:"Default,omitempty"`
+	Value                 interface{}    `json:"Value,omitempty"`
+	NoEcho                *string        `json:"NoEcho,omitempty"`
+	AllowedValues         *[]interface{} `json:"AllowedValues,omitempty"`
+	AllowedPattern        *string        `json:"AllowedPattern,omitempty"`
+	MinLength             *int           `json:"MinLength,omitempty"`
+	MaxLength             *int           `json:"MaxLength,omitempty"`
+	MinValue              *float64       `json:"MinValue,omitempty"`
+	MaxValue              *float64       `json:"MaxValue,omitempty"`
+	CustomConstraint      *string        `json:"CustomConstraint,omitempty"`
+	ConstraintDescription *string        `json:"ConstraintDescription,omitempty"`
+}
+
+// TemplateParameterGroup is a group of parameters of a template, for display.
+type TemplateParameterGroup struct {
+	Label       *string   `json:"label,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Parameters  *[]string `json:"parameters,omitempty"`
+}
+
+// TemplateVersion is a template format version supported by Heat, e.g.
+// "heat_template_version.2018-08-31" (of type "hot"), with its aliases (e.g.
+// "heat_template_version.rocky").
+type TemplateVersion struct {
+	Version *string   `json:"version,omitempty"`
+	Type    *string   `json:"type,omitempty"`
+	Aliases *[]string `json:"aliases,omitempty"`
+}
+
+// TemplateFunction is an intrinsic function available in templates of a given
+// version, e.g. "get_attr".
+type TemplateFunction struct {
+	Functions   *string `json:"functions,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// OrchestrationFault is the entity returned by Heat when a request fails,
+// e.g. because a template is invalid.
+type OrchestrationFault struct {
+	Code        *int    `json:"code,omitempty"`
+	Title       *string `json:"title,omitempty"`
+	Explanation *string `json:"explanation,omitempty"`
+	Error       *struct {
+		Message   *string `json:"message,omitempty"`
+		Type      *string `json:"type,omitempty"`
+		Traceback *string `json:"traceback,omitempty"`
+	} `json:"error,omitempty"`
+}
+
+/*
+ * SOFTWARE CONFIGURATIONS
+ */
+
+// SoftwareConfig is an immutable software configuration, e.g. a script or a
+// configuration management manifest, to be applied to servers by the agents
+// running on them (e.g. os-collect-config); Group identifies the tool applying
+// it (e.g. "script", "ansible", "puppet").
+type SoftwareConfig struct {
+	ID           *string                 `json:"id,omitempty"`
+	Name         *string                 `json:"name,omitempty"`
+	Group        *string                 `json:"group,omitempty"`
+	Config       *string                 `json:"config,omitempty"`
+	Inputs       *[]SoftwareConfigIO     `json:"inputs,omitempty"`
+	Outputs      *[]SoftwareConfigIO     `json:"outputs,omitempty"`
+	Options      *map[string]interface{} `json:"options,omitempty"`
+	CreationTime *string                 `json:"creation_time,omitempty"`
+}
+
+// SoftwareConfigIO is an input or an output of a software configuration; an
+// output with ErrorOutput set signals the failure of the deployment if it is
+// not empty.
+type SoftwareConfigIO struct {
+	Name            *string     `json:"name,omitempty"`
+	Type            *string     `json:"type,omitempty"`
+	Description     *string     `json:"description,omitempty"`
+	Default         interface{} `json:"default,omitempty"`
+	Value           interface{} `json:"value,omitempty"`
+	ErrorOutput     *bool       `json:"error_output,omitempty"`
+	ReplaceOnChange *bool       `json:"replace_on_change,omitempty"`
+}
+
+// SoftwareDeployment is the association of a software configuration with a
+// server, with the input values to apply it with and, once the agent on the
+// server has reported back, its status and output values.
+type SoftwareDeployment struct {
+	ID           *string                 `json:"id,omitempty"`
+	ConfigID     *string                 `json:"config_id,omitempty"`
+	ServerID     *string                 `json:"server_id,omitempty"`
+	Action       *string                 `json:"action,omitempty"`
+	Status       *string                 `json:"status,omitempty"`
+	StatusReason *string                 `json:"status_reason,omitempty"`
+	InputValues  *map[string]interface{} `json:"input_values,omitempty"`
+	OutputValues *map[string]interface{} `json:"output_values,omitempty"`
+	CreationTime *string                 `json:"creation_time,omitempty"`
+	UpdatedTime  *string                 `json:"updated_time,omitempty"`
+}
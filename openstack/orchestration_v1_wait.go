@@ -0,0 +1,107 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// StackStatusError is returned when a stack fails to reach the status it was
+// waited for, e.g. because its creation failed or was rolled back: Reason is
+// the stack status reason, and Event the latest failure event of the stack or
+// of one of its resources, if any, which usually identifies the culprit.
+type StackStatusError struct {
+	Stack  string
+	Status string
+	Reason string
+	Event  *StackEvent
+}
+
+// Error returns a description of the failure.
+func (e *StackStatusError) Error() string {
+	if e.Event != nil {
+		return fmt.Sprintf("stack %q is in %s status: %s (resource %q: %s)", e.Stack, e.Status, e.Reason, stringValue(e.Event.ResourceName), stringValue(e.Event.ResourceStatusReason))
+	}
+	return fmt.Sprintf("stack %q is in %s status: %s", e.Stack, e.Status, e.Reason)
+}
+
+/*
+ * WAIT FOR STACK STATUS
+ */
+
+// WaitForStackStatus waits until the stack with the given name, id or full
+// identity reaches the given status (e.g. StackStatusCreateComplete,
+// StackStatusUpdateComplete), polling it with the given backoff policy; the
+// wait fails with a *StackStatusError, with the failure reason from the events
+// of the stack, if the operation fails (i.e. the status ends in "_FAILED") or is
+// rolled back (unless that is the expected status); when waiting for
+// StackStatusDeleteComplete, the stack disappearing is not an error, and nil is
+// returned.
+func (api *OrchestrationV1API) WaitForStackStatus(ctx context.Context, stack string, status string, backoff Backoff) (*Stack, error) {
+	var current *Stack
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var result *Result
+		var err error
+		current, result, err = api.RetrieveStack(stack)
+		if result != nil && result.Code == http.StatusNotFound {
+			if status == StackStatusDeleteComplete {
+				return true, nil
+			}
+			return false, fmt.Errorf("stack %q not found", stack)
+		}
+		if err != nil {
+			return false, err
+		}
+		if current == nil || current.StackStatus == nil {
+			return false, fmt.Errorf("error retrieving stack %q: %v", stack, result)
+		}
+		log.Debugf("stack %q is %s, waiting for %s", stack, *current.StackStatus, status)
+		if *current.StackStatus == status {
+			return true, nil
+		}
+		if strings.HasSuffix(*current.StackStatus, "_FAILED") || (strings.HasPrefix(*current.StackStatus, "ROLLBACK_") && *current.StackStatus != StackStatusRollbackInProgress) {
+			return false, &StackStatusError{
+				Stack:  stack,
+				Status: *current.StackStatus,
+				Reason: stringValue(current.StackStatusReason),
+				Event:  api.latestFailureEvent(stack),
+			}
+		}
+		return false, nil
+	})
+	return current, err
+}
+
+// latestFailureEvent returns the latest event of the stack with the given name,
+// id or full identity, or of its resources (including those of nested stacks),
+// reporting a failure, or nil if there is none.
+func (api *OrchestrationV1API) latestFailureEvent(stack string) *StackEvent {
+	input := &struct {
+		Stack       string `parameter:"-" header:"-" variable:"stack" json:"-"`
+		Status      string `parameter:"resource_status" header:"-" json:"-"`
+		SortDir     string `parameter:"sort_dir" header:"-" json:"-"`
+		NestedDepth int    `parameter:"nested_depth" header:"-" json:"-"`
+		Limit       int    `parameter:"limit" header:"-" json:"-"`
+	}{
+		Stack:       stack,
+		Status:      "FAILED",
+		SortDir:     "desc",
+		NestedDepth: 5,
+		Limit:       1,
+	}
+	events := []StackEvent{}
+
+	result, err := api.Invoke(http.MethodGet, "./stacks/{stack}/events", true, StatusCodeIn(200), input, &envelope{Name: "events", Body: &events}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if err != nil || len(events) == 0 {
+		return nil
+	}
+	return &events[0]
+}
@@ -45,7 +45,7 @@ type Filter struct {
 	// - "internal", that is used by services to connect to each other.
 	// The characteristics of these endpoints may lead to different network
 	// configurations and security considerations.
-	Interface *string `json:"interface,omitempty"`
+	Interface *InterfaceType `json:"interface,omitempty"`
 	// Version represents the service version (e.g. "v2" or "v3") for the given
 	// service (e.g. "keystone").
 	Version *string `json:"version,omitempty"`
@@ -56,6 +56,36 @@ type Filter struct {
 	EndpointURL *string `json:"url,omitempty"`
 }
 
+// Matches checks whether the given endpoint of the given service matches any
+// of the filters in the profile.
+func (p *Profile) Matches(service Service, endpoint Endpoint) bool {
+	for _, filter := range p.Filters {
+		if filter.Matches(service, endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks whether the given endpoint of the given service matches the
+// filter; fields left empty in the filter (e.g. Region) match any value, so
+// that filters can be made broader by removing fields.
+func (f Filter) Matches(service Service, endpoint Endpoint) bool {
+	switch {
+	case f.Type != nil && *f.Type != stringValue(service.Type):
+		return false
+	case f.Name != nil && *f.Name != stringValue(service.Name):
+		return false
+	case f.Region != nil && *f.Region != stringValue(endpoint.Region):
+		return false
+	case f.Interface != nil && *f.Interface != interfaceValue(endpoint.Interface):
+		return false
+	case f.EndpointURL != nil && NormaliseURL(*f.EndpointURL) != NormaliseURL(stringValue(endpoint.URL)):
+		return false
+	}
+	return true
+}
+
 // InitProfile initialises the Client's profile using all the information
 // available in the catalog as per the identity service; once initialised
 // and saved to disk, the user can edit it and reload it so that the Client
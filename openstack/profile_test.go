@@ -0,0 +1,31 @@
+package openstack
+
+import (
+	"testing"
+)
+
+func TestProfileMatches(t *testing.T) {
+	service := Service{Type: String("compute"), Name: String("nova")}
+	endpoint := Endpoint{Interface: InterfacePublic.Ptr(), Region: String("RegionOne"), URL: String("http://cloud:8774/v2.1")}
+	tests := []struct {
+		filter   Filter
+		expected bool
+	}{
+		{Filter{}, true},
+		{Filter{Type: String("compute"), Interface: InterfacePublic.Ptr()}, true},
+		{Filter{Type: String("compute"), Name: String("nova"), Region: String("RegionOne"), Interface: InterfacePublic.Ptr(), EndpointURL: String("http://cloud:8774/v2.1/")}, true},
+		{Filter{Type: String("image")}, false},
+		{Filter{Type: String("compute"), Interface: InterfaceInternal.Ptr()}, false},
+		{Filter{Type: String("compute"), Region: String("RegionTwo")}, false},
+		{Filter{EndpointURL: String("http://other:8774/v2.1")}, false},
+	}
+	for i, test := range tests {
+		profile := &Profile{Filters: []Filter{test.filter}}
+		if actual := profile.Matches(service, endpoint); actual != test.expected {
+			t.Errorf("Profile.TestProfileMatches: case %d: expected %t, got %t", i, test.expected, actual)
+		}
+	}
+	if (&Profile{}).Matches(service, endpoint) {
+		t.Errorf("Profile.TestProfileMatches: an empty profile should match nothing")
+	}
+}
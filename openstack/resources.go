@@ -0,0 +1,229 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * RESOURCES
+ */
+
+// envelope is the request or response entity of most calls on resources: a
+// JSON object having the resource name (e.g. "network") as its only key, or the
+// collection name (e.g. "networks") for bulk operations; the resource ID, if
+// any, is bound to the {id} variable in the path.
+type envelope struct {
+	ID   string      `parameter:"-" header:"-" variable:"id" json:"-"`
+	Name string      `parameter:"-" header:"-" variable:"-" json:"-"`
+	Body interface{} `parameter:"-" header:"-" variable:"-" json:"-"`
+}
+
+// MarshalJSON encodes the envelope as a JSON object with the resource name as
+// its only key and the resource (or null) as its value, or as an empty object
+// if there is no resource name (e.g. in retrieve and delete calls).
+func (e envelope) MarshalJSON() ([]byte, error) {
+	if e.Name == "" {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]interface{}{
+		e.Name: e.Body,
+	})
+}
+
+// UnmarshalJSON decodes the value under the resource name into the body, which
+// must be a pointer; other keys are ignored.
+func (e *envelope) UnmarshalJSON(data []byte) error {
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if value, ok := values[e.Name]; ok && e.Body != nil {
+		return json.Unmarshal(value, e.Body)
+	}
+	return nil
+}
+
+// successCodes returns a checker accepting the given status codes, or the
+// default ones if none is given.
+func successCodes(codes []int, defaults ...int) Checker {
+	if len(codes) == 0 {
+		codes = defaults
+	}
+	return StatusCodeIn(codes...)
+}
+
+// createResource creates a resource with a POST on the given path; the body
+// is wrapped in an envelope with the given name, and the created resource is
+// unwrapped from the response into output; the call succeeds with the given
+// status codes, by default "201 Created" (services creating resources
+// asynchronously reply "202 Accepted" instead).
+func (api *API) createResource(path string, name string, body interface{}, output interface{}, codes ...int) (*Result, error) {
+	return api.sendResource(http.MethodPost, path, "", name, body, output, successCodes(codes, 201))
+}
+
+// bulkCreateResources creates multiple resources in a single POST on the given
+// path; the items (a slice) are wrapped in an envelope with the collection name
+// and the created resources are unwrapped from the response into output (a
+// pointer to a slice); the operation is atomic: either all resources are created
+// or none is.
+func (api *API) bulkCreateResources(path string, collection string, items interface{}, output interface{}) (*Result, error) {
+	return api.sendResource(http.MethodPost, path, "", collection, items, output, StatusCodeIn(201))
+}
+
+// retrieveResource retrieves the resource with the given ID at the given path,
+// which must contain the {id} variable, and unwraps it into output.
+func (api *API) retrieveResource(path string, id string, name string, output interface{}) (*Result, error) {
+	result, err := api.Invoke(http.MethodGet, path, true, StatusCodeIn(200), &envelope{ID: id}, &envelope{Name: name, Body: output}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	return result, err
+}
+
+// updateResource updates the resource with the given ID with a PUT on the given
+// path, which must contain the {id} variable, and unwraps the updated resource
+// into output; the call succeeds with the given status codes, by default
+// "200 OK".
+func (api *API) updateResource(path string, id string, name string, body interface{}, output interface{}, codes ...int) (*Result, error) {
+	return api.sendResource(http.MethodPut, path, id, name, body, output, successCodes(codes, 200))
+}
+
+// patchResource is like updateResource, but it uses a PATCH, for services
+// where only the attributes in the body are updated.
+func (api *API) patchResource(path string, id string, name string, body interface{}, output interface{}, codes ...int) (*Result, error) {
+	return api.sendResource(http.MethodPatch, path, id, name, body, output, successCodes(codes, 200))
+}
+
+// sendResource sends the body, wrapped in an envelope with the given name, to
+// the given path with the given method, and unwraps the resource in the
+// response into output.
+func (api *API) sendResource(method string, path string, id string, name string, body interface{}, output interface{}, checker Checker) (*Result, error) {
+	input := &envelope{ID: id, Name: name, Body: body}
+	result, err := api.Invoke(method, path, true, checker, input, &envelope{Name: name, Body: output}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	return result, err
+}
+
+// deleteResource deletes the resource with the given ID at the given path, which
+// must contain the {id} variable; the call succeeds with the given status codes,
+// by default "204 No Content" (services deleting resources asynchronously reply
+// "202 Accepted" instead).
+func (api *API) deleteResource(path string, id string, codes ...int) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodDelete, path, true, successCodes(codes, 204), &envelope{ID: id}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+/*
+ * COLLECTIONS
+ */
+
+// nextLink extracts the link to the next page from a page of the collection
+// with the given name, given as the values of its top-level JSON object; it
+// returns an empty string on the last page. Each service has its own way of
+// returning the link.
+type nextLink func(values map[string]json.RawMessage, collection string) (string, error)
+
+// collectionLinks returns the link to the next page among those under the
+// collection name followed by "_links" (e.g. "networks_links"), as in Neutron,
+// Cinder, Octavia and Manila.
+func collectionLinks(values map[string]json.RawMessage, collection string) (string, error) {
+	value, ok := values[collection+"_links"]
+	if !ok {
+		return "", nil
+	}
+	links := []Link{}
+	if err := json.Unmarshal(value, &links); err != nil {
+		return "", err
+	}
+	for _, link := range links {
+		if stringValue(link.Rel) == "next" && link.Href != nil {
+			return *link.Href, nil
+		}
+	}
+	return "", nil
+}
+
+// nextField returns the link to the next page under "next", as in Glance and
+// most of the services that came after it.
+func nextField(values map[string]json.RawMessage, collection string) (string, error) {
+	var next *string
+	if value, ok := values["next"]; ok {
+		if err := json.Unmarshal(value, &next); err != nil {
+			return "", err
+		}
+	}
+	return stringValue(next), nil
+}
+
+// listResources retrieves all the pages of the collection with the given name
+// at the given path, following the links to the next pages returned when the
+// results are paginated (i.e. when a limit is given or the server enforces a
+// maximum page size), which are extracted from each page by next; the items of
+// each page are decoded into a new value returned by items (a pointer to a
+// slice), which is then passed to collect.
+func (api *API) listResources(path string, collection string, opts interface{}, next nextLink, items func() interface{}, collect func(page interface{})) (*Result, error) {
+	url := path
+	input := opts
+	visited := map[string]bool{}
+	for {
+		output := &listPage{
+			name:     collection,
+			items:    items(),
+			nextLink: next,
+		}
+		result, err := api.Invoke(http.MethodGet, url, true, StatusCodeIn(200), input, output, nil)
+		log.Debugf("result is %v (%v)", result, err)
+		if err != nil || result == nil || result.Code != 200 {
+			return result, err
+		}
+		collect(output.items)
+
+		if output.next == "" {
+			return result, nil
+		}
+		if visited[output.next] {
+			log.Errorf("pagination loop detected at %q", output.next)
+			return result, fmt.Errorf("pagination loop detected at %q", output.next)
+		}
+		visited[output.next] = true
+		log.Debugf("following link to next page: %q", output.next)
+		// the link to the next page already carries all the query parameters
+		url = output.next
+		input = nil
+	}
+}
+
+// listPage is a page of a collection: the items are under the collection name,
+// the link to the next page (if any) is extracted by nextLink.
+type listPage struct {
+	name     string
+	items    interface{}
+	nextLink nextLink
+	next     string
+}
+
+// UnmarshalJSON decodes both the items and the link to the next page.
+func (p *listPage) UnmarshalJSON(data []byte) error {
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if value, ok := values[p.name]; ok {
+		if err := json.Unmarshal(value, p.items); err != nil {
+			return err
+		}
+	}
+	var err error
+	p.next, err = p.nextLink(values, p.name)
+	return err
+}
@@ -15,6 +15,7 @@ type Result struct {
 	Code        int
 	Status      string
 	Description string
+	Headers     http.Header
 	Data        []byte
 }
 
@@ -24,7 +25,8 @@ func (r Result) String() string {
 }
 
 // NewResult maps the status code in an HTTP Response to the corresponding
-// API result.
+// API result; the response headers are made available as well, since some APIs
+// return relevant information (e.g. the Location of a new resource) in them.
 func NewResult(response *http.Response, data []byte) *Result {
 	var r Result
 	switch response.StatusCode {
@@ -32,15 +34,21 @@ func NewResult(response *http.Response, data []byte) *Result {
 		r = Success
 	case http.StatusCreated: // 201
 		r = Created
-	// case http.StatusAccepted: // 202
+	case http.StatusAccepted: // 202
+		r = Accepted
 	// case http.StatusNonAuthoritativeInfo: // 203
 	case http.StatusNoContent: // 204
 		r = NoContent
 	// case http.ResetContent: // 205
-	// case http.StatusPartialContent: // 206
+	case http.StatusPartialContent: // 206
+		r = PartialContent
 	// case http.StatusMulticase: // 207
 	// case http.StatusAlreadyReported : // 208
 	// case http.StatusIMUsed: // 226
+	case http.StatusMultipleChoices: // 300
+		r = MultipleChoices
+	case http.StatusNotModified: // 304
+		r = NotModified
 	case 400:
 		r = BadRequest
 	case 401:
@@ -57,13 +65,18 @@ func NewResult(response *http.Response, data []byte) *Result {
 		r = RequestEntityTooLarge
 	case 415:
 		r = UnsupportedMediaType
+	case 416:
+		r = RangeNotSatisfiable
 	case 503:
 		r = ServiceUnavailable
 	default:
 		r = Result{
+			Code:        response.StatusCode,
+			Status:      http.StatusText(response.StatusCode),
 			Description: "Unknown error.",
 		}
 	}
+	r.Headers = response.Header
 	r.Data = data
 	return &r
 }
@@ -119,6 +132,14 @@ var (
 		Description: "Resource was created and is ready to use.",
 	}
 
+	// Accepted means that the request was accepted and is being processed
+	// asynchronously; this is typical of actions on servers.
+	Accepted = Result{
+		Code:        202,
+		Status:      "Accepted",
+		Description: "Request was accepted for asynchronous processing.",
+	}
+
 	// NoContent means that there is no data associated with the requested resource;
 	// this is typical with HEAD requests.
 	NoContent = Result{
@@ -127,6 +148,30 @@ var (
 		Description: "There is no data associated with the requested resource.",
 	}
 
+	// PartialContent means that only the requested range of the resource data
+	// is returned; this is typical of resumed downloads.
+	PartialContent = Result{
+		Code:        206,
+		Status:      "Partial Content",
+		Description: "Only the requested range of the resource data is returned.",
+	}
+
+	// MultipleChoices means that the resource has multiple representations; this
+	// is typical of the unversioned root of services, listing the API versions.
+	MultipleChoices = Result{
+		Code:        300,
+		Status:      "Multiple Choices",
+		Description: "The resource has multiple representations.",
+	}
+
+	// NotModified means that the resource has not changed since the version the
+	// client already has; this is typical of conditional requests.
+	NotModified = Result{
+		Code:        304,
+		Status:      "Not Modified",
+		Description: "The resource has not been modified.",
+	}
+
 	// BadRequest means that some content in the HTTP API request was invalid.
 	BadRequest = Result{
 		Code:        400,
@@ -189,6 +234,14 @@ var (
 		Description: "The request entity has a media type which the server or resource does not support.",
 	}
 
+	// RangeNotSatisfiable means that the requested range of the resource data
+	// is not available, e.g. because it starts past its end.
+	RangeNotSatisfiable = Result{
+		Code:        416,
+		Status:      "Range Not Satisfiable",
+		Description: "The requested range of the resource data is not available.",
+	}
+
 	// ServiceUnavailable is a server-side error that is mostly caused by service configuration
 	// errors which prevents the service from successful start up.
 	ServiceUnavailable = Result{
@@ -0,0 +1,68 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"strings"
+)
+
+// SharedFileSystemV2API represents the shared file systems API ver. 2 (Manila),
+// providing support for the management of shares (e.g. NFS exports, CephFS
+// volumes), of the rules granting access to them, of their snapshots and of
+// the share networks they are exported on.
+// Manila uses microversions: by default requests are served with the base
+// version 2.0, a later one can be requested for all calls through
+// WithMicroversion, or the latest one supported by the server through
+// NegotiateMicroversion. Like Heat, older deployments list the endpoint with an
+// unresolved placeholder for the project id (e.g. "%(project_id)s"), which is
+// replaced with the project the token is scoped to.
+// See https://developer.openstack.org/api-ref/shared-file-system/
+type SharedFileSystemV2API struct {
+	API
+}
+
+// sharedFileSystemURL returns the Manila endpoint URL from the one in the
+// catalog, with the placeholder for the project id, if any, resolved.
+func sharedFileSystemURL(endpoint string, projectid string) string {
+	for _, placeholder := range orchestrationProjectPlaceholders {
+		endpoint = strings.Replace(endpoint, placeholder, projectid, -1)
+	}
+	return endpoint
+}
+
+// sharedFileSystemMicroversion is the header through which Manila is told the
+// microversion requested.
+var sharedFileSystemMicroversion = microversionHeader{name: "X-OpenStack-Manila-API-Version", base: "2.0"}
+
+// WithMicroversion returns a copy of the API that requests the given
+// microversion (e.g. "2.51") in all its calls, through the
+// X-OpenStack-Manila-API-Version header; the receiver is left unchanged.
+func (api SharedFileSystemV2API) WithMicroversion(microversion string) *SharedFileSystemV2API {
+	return &SharedFileSystemV2API{API: api.withMicroversion(sharedFileSystemMicroversion, microversion)}
+}
+
+// NegotiateMicroversion returns a copy of the API that requests, in all its
+// calls, the microversion pinned for its endpoint in the client profile, or
+// the latest one supported by the server, as advertised in its version
+// document (see SelectMicroversion); the receiver is left unchanged.
+func (api SharedFileSystemV2API) NegotiateMicroversion() (*SharedFileSystemV2API, *Result, error) {
+	microversion, result, err := api.negotiateMicroversion()
+	if err != nil {
+		return nil, result, err
+	}
+	return api.WithMicroversion(microversion), result, nil
+}
+
+// Microversion returns the microversion requested in all calls, or "2.0" if
+// none was set.
+func (api *SharedFileSystemV2API) Microversion() string {
+	return api.requestedMicroversion(sharedFileSystemMicroversion)
+}
+
+// requireMicroversion checks that the microversion requested in all calls is
+// at least the given one, which is needed by the given feature.
+func (api *SharedFileSystemV2API) requireMicroversion(minimum string, feature string) error {
+	return api.API.requireMicroversion(sharedFileSystemMicroversion, minimum, feature)
+}
@@ -0,0 +1,89 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST SHARE NETWORKS
+ */
+
+// ListShareNetworksOptions provides the options available for filtering the
+// list of share networks.
+type ListShareNetworksOptions struct {
+	Name            *string `parameter:"name,omitempty" header:"-" json:"-"`
+	NeutronNetID    *string `parameter:"neutron_net_id,omitempty" header:"-" json:"-"`
+	NeutronSubnetID *string `parameter:"neutron_subnet_id,omitempty" header:"-" json:"-"`
+	AllTenants      *bool   `parameter:"all_tenants,omitempty" header:"-" json:"-"`
+	Limit           *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Offset          *int    `parameter:"offset,omitempty" header:"-" json:"-"`
+}
+
+// ListShareNetworks returns the list of share networks of the current project,
+// with their details; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#list-share-networks-with-details
+func (api *SharedFileSystemV2API) ListShareNetworks(opts *ListShareNetworksOptions) (*[]ShareNetwork, *Result, error) {
+	networks := []ShareNetwork{}
+	result, err := api.listResources("./share-networks/detail", "share_networks", opts, collectionLinks,
+		func() interface{} { return &[]ShareNetwork{} },
+		func(page interface{}) { networks = append(networks, *page.(*[]ShareNetwork)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &networks, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE SHARE NETWORK
+ */
+
+// CreateShareNetworkOptions provides the options available for creating a share
+// network on the given Neutron network and subnet.
+type CreateShareNetworkOptions struct {
+	Name            *string `json:"name,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	NeutronNetID    *string `json:"neutron_net_id,omitempty"`
+	NeutronSubnetID *string `json:"neutron_subnet_id,omitempty"`
+}
+
+// CreateShareNetwork creates a share network; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#create-share-network
+func (api *SharedFileSystemV2API) CreateShareNetwork(opts *CreateShareNetworkOptions) (*ShareNetwork, *Result, error) {
+	network := &ShareNetwork{}
+	result, err := api.createResource("./share-networks", "share_network", opts, network, 200, 202)
+	if result != nil && result.OK {
+		return network, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SHARE NETWORK
+ */
+
+// RetrieveShareNetwork retrieves the share network with the given ID; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#show-share-network-details
+func (api *SharedFileSystemV2API) RetrieveShareNetwork(networkid string) (*ShareNetwork, *Result, error) {
+	network := &ShareNetwork{}
+	result, err := api.retrieveResource("./share-networks/{id}", networkid, "share_network", network)
+	if result != nil && result.Code == 200 {
+		return network, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE SHARE NETWORK
+ */
+
+// DeleteShareNetwork deletes the share network with the given ID, which must
+// not be in use by any share; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#delete-share-network
+func (api *SharedFileSystemV2API) DeleteShareNetwork(networkid string) (bool, *Result, error) {
+	return api.deleteResource("./share-networks/{id}", networkid, 202, 204)
+}
@@ -0,0 +1,224 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Share access types and levels.
+const (
+	ShareAccessTypeIP    string = "ip"
+	ShareAccessTypeCert  string = "cert"
+	ShareAccessTypeUser  string = "user"
+	ShareAccessTypeCephX string = "cephx"
+
+	ShareAccessLevelReadWrite string = "rw"
+	ShareAccessLevelReadOnly  string = "ro"
+)
+
+/*
+ * LIST SHARES
+ */
+
+// ListSharesOptions provides the options available for filtering the list of
+// shares.
+type ListSharesOptions struct {
+	Name             *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Status           *string `parameter:"status,omitempty" header:"-" json:"-"`
+	ShareTypeID      *string `parameter:"share_type_id,omitempty" header:"-" json:"-"`
+	ShareNetworkID   *string `parameter:"share_network_id,omitempty" header:"-" json:"-"`
+	SnapshotID       *string `parameter:"snapshot_id,omitempty" header:"-" json:"-"`
+	AvailabilityZone *string `parameter:"availability_zone,omitempty" header:"-" json:"-"`
+	IsPublic         *bool   `parameter:"is_public,omitempty" header:"-" json:"-"`
+	AllTenants       *bool   `parameter:"all_tenants,omitempty" header:"-" json:"-"`
+	SortKey          *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir          *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit            *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Offset           *int    `parameter:"offset,omitempty" header:"-" json:"-"`
+}
+
+// ListShares returns the list of shares of the current project, with their
+// details; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#list-shares-with-details
+func (api *SharedFileSystemV2API) ListShares(opts *ListSharesOptions) (*[]Share, *Result, error) {
+	shares := []Share{}
+	result, err := api.listResources("./shares/detail", "shares", opts, collectionLinks,
+		func() interface{} { return &[]Share{} },
+		func(page interface{}) { shares = append(shares, *page.(*[]Share)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &shares, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE SHARE
+ */
+
+// CreateShareOptions provides the options available for creating a share:
+// ShareProto (e.g. "NFS", "CEPHFS") and Size (in GiB) are required; the share
+// is empty unless created from SnapshotID; ShareNetworkID is required by
+// back ends that create a share server per network.
+type CreateShareOptions struct {
+	ShareProto       string             `json:"share_proto"`
+	Size             int                `json:"size"`
+	Name             *string            `json:"name,omitempty"`
+	Description      *string            `json:"description,omitempty"`
+	ShareType        *string            `json:"share_type,omitempty"`
+	ShareNetworkID   *string            `json:"share_network_id,omitempty"`
+	SnapshotID       *string            `json:"snapshot_id,omitempty"`
+	AvailabilityZone *string            `json:"availability_zone,omitempty"`
+	Metadata         *map[string]string `json:"metadata,omitempty"`
+	IsPublic         *bool              `json:"is_public,omitempty"`
+}
+
+// CreateShare creates a share; the creation is asynchronous: the share is in
+// the "creating" status until it is "available"; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#create-share
+func (api *SharedFileSystemV2API) CreateShare(opts *CreateShareOptions) (*Share, *Result, error) {
+	share := &Share{}
+	result, err := api.createResource("./shares", "share", opts, share, 200, 202)
+	if result != nil && result.OK {
+		return share, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SHARE
+ */
+
+// RetrieveShare retrieves the share with the given ID; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#show-share-details
+func (api *SharedFileSystemV2API) RetrieveShare(shareid string) (*Share, *Result, error) {
+	share := &Share{}
+	result, err := api.retrieveResource("./shares/{id}", shareid, "share", share)
+	if result != nil && result.Code == 200 {
+		return share, result, err
+	}
+	return nil, result, err
+}
+
+// ListShareExportLocations returns the paths the share with the given ID can be
+// mounted from; it requires microversion 2.9; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#list-export-locations
+func (api *SharedFileSystemV2API) ListShareExportLocations(shareid string) (*[]ShareExportLocation, *Result, error) {
+	if err := api.requireMicroversion("2.9", "listing export locations"); err != nil {
+		log.Errorf("cannot list export locations of share %q: %v", shareid, err)
+		return nil, nil, err
+	}
+	locations := &[]ShareExportLocation{}
+	result, err := api.retrieveResource("./shares/{id}/export_locations", shareid, "export_locations", locations)
+	if result != nil && result.Code == 200 {
+		return locations, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE SHARE
+ */
+
+// DeleteShare deletes the share with the given ID, which must have no snapshots;
+// the deletion is asynchronous; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#delete-share
+func (api *SharedFileSystemV2API) DeleteShare(shareid string) (bool, *Result, error) {
+	return api.deleteResource("./shares/{id}", shareid, 202, 204)
+}
+
+/*
+ * ACCESS RULES
+ */
+
+// shareActionName returns the name of the given share action as expected by
+// the microversion in use: before 2.7, actions have the "os-" prefix.
+func (api *SharedFileSystemV2API) shareActionName(action string) string {
+	if compareMicroversions(api.Microversion(), "2.7") < 0 {
+		return "os-" + action
+	}
+	return action
+}
+
+// AllowShareAccessOptions provides the options available for granting access to
+// a share: AccessType is one of the ShareAccessType* constants ("cephx"
+// requires microversion 2.13), AccessTo the client (e.g. "10.0.0.0/24" or
+// "alice"), AccessLevel one of the ShareAccessLevel* constants, read-write by
+// default.
+type AllowShareAccessOptions struct {
+	AccessType  string  `json:"access_type"`
+	AccessTo    string  `json:"access_to"`
+	AccessLevel *string `json:"access_level,omitempty"`
+}
+
+// AllowShareAccess grants access to the share with the given ID and returns the
+// new access rule; the rule is applied asynchronously (its state goes from
+// "queued_to_apply" to "active"); see also
+// https://developer.openstack.org/api-ref/shared-file-system/#grant-access
+func (api *SharedFileSystemV2API) AllowShareAccess(shareid string, opts *AllowShareAccessOptions) (*ShareAccessRule, *Result, error) {
+	if opts.AccessType == ShareAccessTypeCephX {
+		if err := api.requireMicroversion("2.13", "cephx access rules"); err != nil {
+			log.Errorf("cannot grant access to share %q: %v", shareid, err)
+			return nil, nil, err
+		}
+	}
+	input := &envelope{ID: shareid, Name: api.shareActionName("allow_access"), Body: opts}
+	rule := &ShareAccessRule{}
+
+	result, err := api.Invoke(http.MethodPost, "./shares/{id}/action", true, StatusCodeIn(200), input, &envelope{Name: "access", Body: rule}, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return rule, result, err
+	}
+	return nil, result, err
+}
+
+// DenyShareAccess revokes the access rule with the given ID from the share with
+// the given ID; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#revoke-access
+func (api *SharedFileSystemV2API) DenyShareAccess(shareid string, accessid string) (bool, *Result, error) {
+	body := &struct {
+		AccessID string `json:"access_id"`
+	}{
+		AccessID: accessid,
+	}
+	input := &envelope{ID: shareid, Name: api.shareActionName("deny_access"), Body: body}
+
+	result, err := api.Invoke(http.MethodPost, "./shares/{id}/action", true, StatusCodeIn(202), input, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 202 {
+		return true, result, err
+	}
+	return false, result, err
+}
+
+// ListShareAccessRules returns the access rules of the share with the given ID;
+// with microversion 2.45 or later, the dedicated share access rules API is
+// used, otherwise the share action; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#list-share-access-rules
+func (api *SharedFileSystemV2API) ListShareAccessRules(shareid string) (*[]ShareAccessRule, *Result, error) {
+	rules := &[]ShareAccessRule{}
+	var result *Result
+	var err error
+	if compareMicroversions(api.Microversion(), "2.45") >= 0 {
+		input := &struct {
+			ShareID string `parameter:"share_id" header:"-" json:"-"`
+		}{
+			ShareID: shareid,
+		}
+		result, err = api.Invoke(http.MethodGet, "./share-access-rules", true, StatusCodeIn(200), input, &envelope{Name: "access_list", Body: rules}, nil)
+	} else {
+		input := &envelope{ID: shareid, Name: api.shareActionName("access_list"), Body: nil}
+		result, err = api.Invoke(http.MethodPost, "./shares/{id}/action", true, StatusCodeIn(200), input, &envelope{Name: "access_list", Body: rules}, nil)
+	}
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return rules, result, err
+	}
+	return nil, result, err
+}
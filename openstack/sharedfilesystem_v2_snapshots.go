@@ -0,0 +1,91 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"github.com/dihedron/go-log"
+)
+
+/*
+ * LIST SHARE SNAPSHOTS
+ */
+
+// ListShareSnapshotsOptions provides the options available for filtering the
+// list of share snapshots.
+type ListShareSnapshotsOptions struct {
+	Name    *string `parameter:"name,omitempty" header:"-" json:"-"`
+	ShareID *string `parameter:"share_id,omitempty" header:"-" json:"-"`
+	Status  *string `parameter:"status,omitempty" header:"-" json:"-"`
+	SortKey *string `parameter:"sort_key,omitempty" header:"-" json:"-"`
+	SortDir *string `parameter:"sort_dir,omitempty" header:"-" json:"-"`
+	Limit   *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Offset  *int    `parameter:"offset,omitempty" header:"-" json:"-"`
+}
+
+// ListShareSnapshots returns the list of share snapshots of the current
+// project, with their details; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#list-share-snapshots-with-details
+func (api *SharedFileSystemV2API) ListShareSnapshots(opts *ListShareSnapshotsOptions) (*[]ShareSnapshot, *Result, error) {
+	snapshots := []ShareSnapshot{}
+	result, err := api.listResources("./snapshots/detail", "snapshots", opts, collectionLinks,
+		func() interface{} { return &[]ShareSnapshot{} },
+		func(page interface{}) { snapshots = append(snapshots, *page.(*[]ShareSnapshot)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &snapshots, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE SHARE SNAPSHOT
+ */
+
+// CreateShareSnapshotOptions provides the options available for creating a
+// snapshot of a share; Force allows taking it while the share is in use.
+type CreateShareSnapshotOptions struct {
+	ShareID     string  `json:"share_id"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Force       *bool   `json:"force,omitempty"`
+}
+
+// CreateShareSnapshot creates a snapshot of a share; the creation is
+// asynchronous; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#create-share-snapshot
+func (api *SharedFileSystemV2API) CreateShareSnapshot(opts *CreateShareSnapshotOptions) (*ShareSnapshot, *Result, error) {
+	snapshot := &ShareSnapshot{}
+	result, err := api.createResource("./snapshots", "snapshot", opts, snapshot, 200, 202)
+	if result != nil && result.OK {
+		return snapshot, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE SHARE SNAPSHOT
+ */
+
+// RetrieveShareSnapshot retrieves the share snapshot with the given ID; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#show-share-snapshot-details
+func (api *SharedFileSystemV2API) RetrieveShareSnapshot(snapshotid string) (*ShareSnapshot, *Result, error) {
+	snapshot := &ShareSnapshot{}
+	result, err := api.retrieveResource("./snapshots/{id}", snapshotid, "snapshot", snapshot)
+	if result != nil && result.Code == 200 {
+		return snapshot, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE SHARE SNAPSHOT
+ */
+
+// DeleteShareSnapshot deletes the share snapshot with the given ID; the
+// deletion is asynchronous; see also
+// https://developer.openstack.org/api-ref/shared-file-system/#delete-share-snapshot
+func (api *SharedFileSystemV2API) DeleteShareSnapshot(snapshotid string) (bool, *Result, error) {
+	return api.deleteResource("./snapshots/{id}", snapshotid, 202, 204)
+}
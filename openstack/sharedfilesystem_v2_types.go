@@ -0,0 +1,103 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * SHARES
+ */
+
+// Share is a shared file system, exported with a given protocol (e.g. "NFS",
+// "CEPHFS"); Status is "available" once it has been created, and clients can
+// mount it at one of its export locations once granted access.
+type Share struct {
+	ID                             *string            `json:"id,omitempty"`
+	Name                           *string            `json:"name,omitempty"`
+	Description                    *string            `json:"description,omitempty"`
+	ShareProto                     *string            `json:"share_proto,omitempty"`
+	Size                           *int               `json:"size,omitempty"`
+	Status                         *string            `json:"status,omitempty"`
+	ShareType                      *string            `json:"share_type,omitempty"`
+	ShareTypeName                  *string            `json:"share_type_name,omitempty"`
+	ShareNetworkID                 *string            `json:"share_network_id,omitempty"`
+	SnapshotID                     *string            `json:"snapshot_id,omitempty"`
+	AvailabilityZone               *string            `json:"availability_zone,omitempty"`
+	ExportLocation                 *string            `json:"export_location,omitempty"`
+	ExportLocations                *[]string          `json:"export_locations,omitempty"`
+	Metadata                       *map[string]string `json:"metadata,omitempty"`
+	IsPublic                       *bool              `json:"is_public,omitempty"`
+	Host                           *string            `json:"host,omitempty"`
+	ProjectID                      *string            `json:"project_id,omitempty"`
+	UserID                         *string            `json:"user_id,omitempty"`
+	AccessRulesStatus              *string            `json:"access_rules_status,omitempty"`
+	SnapshotSupport                *bool              `json:"snapshot_support,omitempty"`
+	CreateShareFromSnapshotSupport *bool              `json:"create_share_from_snapshot_support,omitempty"`
+	CreatedAt                      *string            `json:"created_at,omitempty"`
+}
+
+// ShareExportLocation is a path a share can be mounted from (e.g.
+// "10.0.0.10:/shares/share-1234" for NFS); Preferred marks the one to be used
+// when several are available.
+type ShareExportLocation struct {
+	ID              *string `json:"id,omitempty"`
+	Path            *string `json:"path,omitempty"`
+	Preferred       *bool   `json:"preferred,omitempty"`
+	IsAdminOnly     *bool   `json:"is_admin_only,omitempty"`
+	ShareInstanceID *string `json:"share_instance_id,omitempty"`
+}
+
+// ShareAccessRule grants access to a share to the clients matching AccessTo,
+// according to AccessType (e.g. an IP address or CIDR for "ip", a Ceph user for
+// "cephx"); for "cephx" rules, AccessKey is the secret key the client must use.
+type ShareAccessRule struct {
+	ID          *string `json:"id,omitempty"`
+	ShareID     *string `json:"share_id,omitempty"`
+	AccessType  *string `json:"access_type,omitempty"`
+	AccessTo    *string `json:"access_to,omitempty"`
+	AccessLevel *string `json:"access_level,omitempty"`
+	AccessKey   *string `json:"access_key,omitempty"`
+	State       *string `json:"state,omitempty"`
+	CreatedAt   *string `json:"created_at,omitempty"`
+	UpdatedAt   *string `json:"updated_at,omitempty"`
+}
+
+/*
+ * SHARE NETWORKS
+ */
+
+// ShareNetwork is the Neutron network and subnet a share server is attached to,
+// so that the shares it exports are reachable from the servers on it.
+type ShareNetwork struct {
+	ID              *string `json:"id,omitempty"`
+	Name            *string `json:"name,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	NeutronNetID    *string `json:"neutron_net_id,omitempty"`
+	NeutronSubnetID *string `json:"neutron_subnet_id,omitempty"`
+	NetworkType     *string `json:"network_type,omitempty"`
+	SegmentationID  *int    `json:"segmentation_id,omitempty"`
+	CIDR            *string `json:"cidr,omitempty"`
+	IPVersion       *int    `json:"ip_version,omitempty"`
+	ProjectID       *string `json:"project_id,omitempty"`
+	CreatedAt       *string `json:"created_at,omitempty"`
+	UpdatedAt       *string `json:"updated_at,omitempty"`
+}
+
+/*
+ * SNAPSHOTS
+ */
+
+// ShareSnapshot is a point-in-time copy of a share, from which new shares can
+// be created.
+type ShareSnapshot struct {
+	ID          *string `json:"id,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ShareID     *string `json:"share_id,omitempty"`
+	ShareProto  *string `json:"share_proto,omitempty"`
+	ShareSize   *int    `json:"share_size,omitempty"`
+	Size        *int    `json:"size,omitempty"`
+	Status      *string `json:"status,omitempty"`
+	ProjectID   *string `json:"project_id,omitempty"`
+	CreatedAt   *string `json:"created_at,omitempty"`
+}
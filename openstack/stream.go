@@ -0,0 +1,31 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"io"
+)
+
+// ProgressFunc is called as data is transferred, with the number of bytes
+// transferred so far.
+type ProgressFunc func(transferred int64)
+
+// progressReader is an io.Reader that reports the progress of the reads from
+// the underlying reader.
+type progressReader struct {
+	reader      io.Reader
+	progress    ProgressFunc
+	transferred int64
+}
+
+// Read reads from the underlying reader and reports the progress.
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.transferred += int64(n)
+		r.progress(r.transferred)
+	}
+	return n, err
+}
@@ -5,7 +5,9 @@
 package openstack
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -187,3 +189,69 @@ type TimeFilter struct {
 func (tf TimeFilter) String() string {
 	return fmt.Sprintf("%v:%v", tf.Operator, tf.Timestamp.Format(ISO8601))
 }
+
+// Link is a hypermedia link to a related resource, as used in version documents.
+type Link struct {
+	Href *string `json:"href,omitempty"`
+	Rel  *string `json:"rel,omitempty"`
+	Type *string `json:"type,omitempty"`
+}
+
+// MediaType describes a media type supported by a given API version.
+type MediaType struct {
+	Base *string `json:"base,omitempty"`
+	Type *string `json:"type,omitempty"`
+}
+
+// Version describes an API version as reported by the unversioned or versioned
+// root endpoint of an OpenStack service; status is one of "stable", "current",
+// "supported" or "deprecated"; services supporting microversions also report
+// the range of microversions they accept, from MinVersion to Version (or to
+// MaxVersion, for those reporting it so).
+type Version struct {
+	ID         *string      `json:"id,omitempty"`
+	Status     *string      `json:"status,omitempty"`
+	Version    *string      `json:"version,omitempty"`
+	MinVersion *string      `json:"min_version,omitempty"`
+	MaxVersion *string      `json:"max_version,omitempty"`
+	Updated    *string      `json:"updated,omitempty"`
+	Links      *[]Link      `json:"links,omitempty"`
+	MediaTypes *[]MediaType `json:"media-types,omitempty"`
+}
+
+// CommaSeparatedList is a list of values that is sent as a single query
+// parameter, with values separated by commas (e.g. "tags=foo,bar").
+type CommaSeparatedList []string
+
+// String returns the list as a comma-separated string.
+func (l CommaSeparatedList) String() string {
+	return strings.Join(l, ",")
+}
+
+// FlexibleID is an identifier that is reported as a number by some APIs (or by
+// older microversions) and as a string by others, e.g. hypervisor and service
+// IDs in the Compute API, which are UUIDs since microversion 2.53.
+type FlexibleID string
+
+// UnmarshalJSON unmarshals the identifier from either a JSON string or number.
+func (id *FlexibleID) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*id = FlexibleID(value)
+		return nil
+	}
+	var value json.Number
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*id = FlexibleID(value.String())
+	return nil
+}
+
+// String returns the identifier as a string.
+func (id FlexibleID) String() string {
+	return string(id)
+}
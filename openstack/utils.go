@@ -3,6 +3,7 @@ package openstack
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // NormaliseURL ensures that the returned URL always ends with a "/"; this
@@ -16,9 +17,12 @@ func NormaliseURL(url string) string {
 }
 
 // ZipString returns a truncated version of the input string, with the given
-// length, where the middle characters are replaced with three dots ("...").
+// length, where the middle characters are replaced with three dots ("...");
+// strings no longer than the given length are returned as they are.
 func ZipString(s string, length int) string {
 	switch {
+	case len(s) <= length:
+		return s
 	case length <= 2:
 		return ""
 	case length == 3:
@@ -34,6 +38,74 @@ func ZipString(s string, length int) string {
 	return ""
 }
 
+// stringValue returns the value of a string pointer, or the empty string if the
+// pointer is nil.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// interfaceValue returns the value of an interface type pointer, or the empty
+// interface type if the pointer is nil.
+func interfaceValue(t *InterfaceType) InterfaceType {
+	if t == nil {
+		return ""
+	}
+	return *t
+}
+
+// containsString returns whether the given value is in the slice.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// lastPathSegment returns the last element of a URL path, e.g. the resource ID
+// at the end of a "self" link.
+func lastPathSegment(url string) string {
+	url = strings.TrimSuffix(url, "/")
+	if index := strings.LastIndex(url, "/"); index >= 0 {
+		return url[index+1:]
+	}
+	return url
+}
+
+// parseTimestamp parses an OpenStack timestamp; services are not consistent in
+// the number of fractional digits (or in the presence of the timezone), so the
+// canonical ISO8601 format is tried first, then RFC3339 and a few variations.
+func parseTimestamp(value string) (time.Time, error) {
+	var err error
+	for _, layout := range []string{ISO8601, time.RFC3339Nano, "2006-01-02T15:04:05.999999", "2006-01-02 15:04:05"} {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// compareMicroversions compares two microversions in "major.minor" format (e.g.
+// "3.10" and "3.9") numerically, returning -1, 0 or 1 as the first is lower
+// than, equal to or greater than the second.
+func compareMicroversions(a string, b string) int {
+	var amajor, aminor, bmajor, bminor int
+	fmt.Sscanf(a, "%d.%d", &amajor, &aminor)
+	fmt.Sscanf(b, "%d.%d", &bmajor, &bminor)
+	switch {
+	case amajor < bmajor || (amajor == bmajor && aminor < bminor):
+		return -1
+	case amajor > bmajor || (amajor == bmajor && aminor > bminor):
+		return 1
+	}
+	return 0
+}
+
 // func ISO8601ToTime(date string) (time.Time, error) {
 // 	return time.Parse(ISO8601, date)
 // }
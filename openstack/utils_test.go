@@ -0,0 +1,27 @@
+package openstack
+
+import (
+	"testing"
+)
+
+func TestZipString(t *testing.T) {
+	tests := []struct {
+		s        string
+		length   int
+		expected string
+	}{
+		{"abcdefghijklmnopqrstuvwxyz", 5, "a...z"},
+		{"abcdefghijklmnopqrstuvwxyz", 6, "ab...z"},
+		{"abcdefghijklmnopqrstuvwxyz", 3, "..."},
+		{"abcdefghijklmnopqrstuvwxyz", 2, ""},
+		// short strings are not truncated
+		{"abcdefghij", 10, "abcdefghij"},
+		{"abc", 16, "abc"},
+		{"", 0, ""},
+	}
+	for _, test := range tests {
+		if actual := ZipString(test.s, test.length); actual != test.expected {
+			t.Errorf("Utils.TestZipString: expected %q for %q (%d), got %q", test.expected, test.s, test.length, actual)
+		}
+	}
+}
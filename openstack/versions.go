@@ -0,0 +1,270 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// versionSegment matches the path segment holding the major version of an API
+// in an endpoint URL (e.g. "v2.1" or "v3").
+var versionSegment = regexp.MustCompile(`^v[0-9]+(\.[0-9]+)?$`)
+
+// serviceRoot returns the unversioned root of the given endpoint URL, where
+// the version document of the service is served, along with the version
+// segment stripped from it (e.g. "v2.1" for "https://nova/v2.1/project"); an
+// endpoint with no version segment is already unversioned (e.g. Ironic's).
+func serviceRoot(endpoint string) (string, string) {
+	prefix := ""
+	if index := strings.Index(endpoint, "://"); index >= 0 {
+		prefix = endpoint[:index+3]
+		endpoint = endpoint[index+3:]
+	}
+	segments := strings.Split(strings.TrimSuffix(endpoint, "/"), "/")
+	for i := len(segments) - 1; i > 0; i-- {
+		if versionSegment.MatchString(segments[i]) {
+			return prefix + strings.Join(segments[:i], "/") + "/", segments[i]
+		}
+	}
+	return prefix + strings.Join(segments, "/") + "/", ""
+}
+
+// versionsDocument is the version document of a service, which lists the
+// versions either directly (e.g. Nova) or under "values" (Keystone), or only
+// describes the current one.
+type versionsDocument struct {
+	versions []Version
+}
+
+// UnmarshalJSON decodes the versions in any of the supported layouts.
+func (d *versionsDocument) UnmarshalJSON(data []byte) error {
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if value, ok := values["versions"]; ok {
+		if err := json.Unmarshal(value, &d.versions); err == nil {
+			return nil
+		}
+		wrapper := struct {
+			Values []Version `json:"values"`
+		}{}
+		if err := json.Unmarshal(value, &wrapper); err != nil {
+			return err
+		}
+		d.versions = wrapper.Values
+		return nil
+	}
+	if value, ok := values["version"]; ok {
+		version := Version{}
+		if err := json.Unmarshal(value, &version); err != nil {
+			return err
+		}
+		d.versions = []Version{version}
+	}
+	return nil
+}
+
+// MicroversionRange is the range of microversions supported by a service,
+// from Min to Max; both are empty if the service does not support them.
+type MicroversionRange struct {
+	Min string
+	Max string
+}
+
+// Contains checks whether the given microversion is in the range.
+func (r MicroversionRange) Contains(microversion string) bool {
+	return r.Max != "" && compareMicroversions(microversion, r.Min) >= 0 && compareMicroversions(microversion, r.Max) <= 0
+}
+
+/*
+ * VERSIONS
+ */
+
+// endpoint returns the base URL of the API.
+func (api *API) endpoint() (string, error) {
+	request, err := api.PrepareRequest(http.MethodGet, "./", false, nil)
+	if err != nil {
+		log.Errorf("error preparing request: %v", err)
+		return "", err
+	}
+	return request.URL.String(), nil
+}
+
+// Versions returns the list of API versions available at the unversioned root
+// of the service, as reported by its version document; it is available on all
+// services, since the root is derived from their endpoint URL (e.g.
+// "https://nova:8774/" for "https://nova:8774/v2.1/project").
+func (api *API) Versions() (*[]Version, *Result, error) {
+	endpoint, err := api.endpoint()
+	if err != nil {
+		return nil, nil, err
+	}
+	root, _ := serviceRoot(endpoint)
+	output := &versionsDocument{}
+
+	// the root of most services replies "300 Multiple Choices"
+	result, err := api.Invoke(http.MethodGet, root, false, StatusCodeIn(200, 300), nil, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.OK {
+		return &output.versions, result, err
+	}
+	return nil, result, err
+}
+
+// SupportedMicroversions returns the range of microversions supported by the
+// version of the service the API is bound to, that is the one matching the
+// version in its endpoint URL, or the current one if the URL has none; the
+// range is empty if the service does not support microversions.
+func (api *API) SupportedMicroversions() (*MicroversionRange, *Result, error) {
+	endpoint, err := api.endpoint()
+	if err != nil {
+		return nil, nil, err
+	}
+	versions, result, err := api.Versions()
+	if versions == nil {
+		return nil, result, err
+	}
+	_, segment := serviceRoot(endpoint)
+	var selected *Version
+	for i, version := range *versions {
+		id := strings.TrimSuffix(stringValue(version.ID), ".0")
+		if segment != "" && id == strings.TrimSuffix(segment, ".0") {
+			selected = &(*versions)[i]
+			break
+		}
+		if segment == "" && strings.EqualFold(stringValue(version.Status), "current") {
+			selected = &(*versions)[i]
+		}
+	}
+	if selected == nil {
+		log.Errorf("no version matching endpoint %q", endpoint)
+		return nil, result, fmt.Errorf("no version matching endpoint %q", endpoint)
+	}
+	microversions := &MicroversionRange{
+		Min: stringValue(selected.MinVersion),
+		Max: stringValue(selected.Version),
+	}
+	if microversions.Max == "" {
+		// some services (e.g. Magnum, Zun) advertise the range as min/max_version
+		microversions.Max = stringValue(selected.MaxVersion)
+	}
+	log.Debugf("version %s supports microversions %q to %q", stringValue(selected.ID), microversions.Min, microversions.Max)
+	return microversions, result, nil
+}
+
+// SelectMicroversion returns the microversion to use with the service among
+// those it supports: the preferred one if given, otherwise the one pinned for
+// the endpoint of the API in the client profile (see Filter.APIVersion), or
+// the latest one supported if neither is ("latest" can also be given
+// explicitly); it fails if the chosen microversion is not supported, and
+// returns an empty string if the service does not support microversions.
+func (api *API) SelectMicroversion(preferred string) (string, *Result, error) {
+	microversions, result, err := api.SupportedMicroversions()
+	if microversions == nil {
+		return "", result, err
+	}
+	if preferred == "" {
+		preferred = api.pinnedMicroversion()
+	}
+	if preferred == "" || preferred == "latest" {
+		return microversions.Max, result, nil
+	}
+	if !microversions.Contains(preferred) {
+		log.Errorf("microversion %s not supported (range is %q to %q)", preferred, microversions.Min, microversions.Max)
+		return "", result, fmt.Errorf("microversion %s not supported, the service accepts %q to %q", preferred, microversions.Min, microversions.Max)
+	}
+	return preferred, result, nil
+}
+
+// pinnedMicroversion returns the microversion pinned in the client profile
+// for the endpoint of the API, if any.
+func (api *API) pinnedMicroversion() string {
+	if api.client == nil || api.client.Profile == nil {
+		return ""
+	}
+	endpoint, err := api.endpoint()
+	if err != nil {
+		return ""
+	}
+	for _, filter := range api.client.Profile.Filters {
+		if filter.EndpointURL != nil && filter.APIVersion != nil && NormaliseURL(*filter.EndpointURL) == endpoint {
+			return *filter.APIVersion
+		}
+	}
+	return ""
+}
+
+/*
+ * MICROVERSIONS
+ */
+
+// microversionHeader describes how a service supporting microversions is told
+// the one requested: the name of the header, the service type prefixing the
+// value if the header is the common OpenStack-API-Version one (e.g. "volume"),
+// and the base microversion, which is served when none is requested.
+type microversionHeader struct {
+	name    string
+	service string
+	base    string
+}
+
+// withMicroversion returns a copy of the API that requests the given
+// microversion in all its calls, through the given header; it backs the
+// WithMicroversion methods of the services supporting microversions.
+func (api API) withMicroversion(header microversionHeader, microversion string) API {
+	value := microversion
+	if header.service != "" {
+		value = header.service + " " + microversion
+	}
+	builder := api.builder.New("", "")
+	builder.Set().Header(header.name, value)
+	return API{
+		client:       api.client,
+		builder:      builder,
+		microversion: microversion,
+	}
+}
+
+// negotiateMicroversion returns the microversion to request in all the calls
+// to the service, as chosen by SelectMicroversion; it fails if the service
+// advertises no microversions. It backs the NegotiateMicroversion methods of
+// the services supporting microversions.
+func (api *API) negotiateMicroversion() (string, *Result, error) {
+	microversion, result, err := api.SelectMicroversion("")
+	if err != nil {
+		return "", result, err
+	}
+	if microversion == "" {
+		endpoint, _ := api.endpoint()
+		log.Errorf("no microversion advertised by the service at %q", endpoint)
+		return "", result, fmt.Errorf("no microversion advertised by the service at %q", endpoint)
+	}
+	return microversion, result, nil
+}
+
+// requestedMicroversion returns the microversion requested in all calls, or
+// the base one of the service if none was set.
+func (api *API) requestedMicroversion(header microversionHeader) string {
+	if api.microversion == "" {
+		return header.base
+	}
+	return api.microversion
+}
+
+// requireMicroversion checks that the microversion requested in all calls is
+// at least the given one, which is needed by the given feature.
+func (api *API) requireMicroversion(header microversionHeader, minimum string, feature string) error {
+	if current := api.requestedMicroversion(header); compareMicroversions(current, minimum) < 0 {
+		return fmt.Errorf("%s requires microversion %s or later (see WithMicroversion), current is %s", feature, minimum, current)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package openstack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServiceRoot(t *testing.T) {
+	endpoints := map[string][2]string{
+		"https://nova:8774/v2.1/p1":         {"https://nova:8774/", "v2.1"},
+		"https://cloud/compute/v2.1/":       {"https://cloud/compute/", "v2.1"},
+		"https://keystone:5000/v3":          {"https://keystone:5000/", "v3"},
+		"https://ironic:6385/":              {"https://ironic:6385/", ""},
+		"https://cloud/placement":           {"https://cloud/placement/", ""},
+		"https://trove:8779/v1.0/p1/":       {"https://trove:8779/", "v1.0"},
+		"https://v2.cloud:9292/v2/images/x": {"https://v2.cloud:9292/", "v2"},
+	}
+	for endpoint, expected := range endpoints {
+		root, version := serviceRoot(endpoint)
+		if root != expected[0] || version != expected[1] {
+			t.Errorf("Versions.TestServiceRoot: expected %q and %q for %q, got %q and %q", expected[0], expected[1], endpoint, root, version)
+		}
+	}
+}
+
+func TestMicroversionRangeContains(t *testing.T) {
+	microversions := MicroversionRange{Min: "2.1", Max: "2.79"}
+	for microversion, expected := range map[string]bool{"2.1": true, "2.10": true, "2.79": true, "2.80": false, "1.9": false} {
+		if actual := microversions.Contains(microversion); actual != expected {
+			t.Errorf("Versions.TestMicroversionRangeContains: expected %v for %q, got %v", expected, microversion, actual)
+		}
+	}
+	if (MicroversionRange{}).Contains("2.1") {
+		t.Errorf("Versions.TestMicroversionRangeContains: empty range contains 2.1")
+	}
+}
+
+func TestNegotiateMicroversion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/compute/":
+			w.WriteHeader(http.StatusMultipleChoices)
+			w.Write([]byte(`{"versions": [{"id": "v2.0", "status": "SUPPORTED"}, {"id": "v2.1", "status": "CURRENT", "version": "2.79", "min_version": "2.1"}]}`))
+		case "/container-infra/":
+			w.Write([]byte(`{"versions": [{"id": "v1", "status": "CURRENT", "max_version": "1.10", "min_version": "1.1"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	api := func(path string) API {
+		client := NewDefaultClient(server.URL + path)
+		client.Authenticator.SetToken(&Token{Value: String("token")})
+		return client.Authenticator.Identity.API
+	}
+
+	compute, result, err := ComputeV2API{API: api("/compute/v2.1/")}.NegotiateMicroversion()
+	if err != nil || compute == nil || compute.Microversion() != "2.79" {
+		t.Fatalf("Versions.TestNegotiateMicroversion: unexpected Compute microversion: %v (%v)", result, err)
+	}
+	// the microversion requested for a single call replaces the negotiated one
+	input := &struct {
+		Microversion *string `parameter:"-" header:"X-OpenStack-Nova-API-Version,omitempty" json:"-"`
+	}{}
+	for _, microversion := range []string{"", "2.60"} {
+		expected := "2.79"
+		if microversion != "" {
+			input.Microversion, expected = String(microversion), microversion
+		}
+		request, err := compute.PrepareRequest(http.MethodGet, "./servers", true, input)
+		if err != nil || len(request.Header["X-Openstack-Nova-Api-Version"]) != 1 || request.Header.Get("X-OpenStack-Nova-API-Version") != expected {
+			t.Errorf("Versions.TestNegotiateMicroversion: expected microversion %s, got %v (%v)", expected, request.Header, err)
+		}
+	}
+
+	magnum, result, err := ContainerInfraV1API{API: api("/container-infra/v1/")}.NegotiateMicroversion()
+	if err != nil || magnum == nil || magnum.Microversion() != "1.10" {
+		t.Errorf("Versions.TestNegotiateMicroversion: unexpected Container Infrastructure microversion: %v (%v)", result, err)
+	}
+	if _, _, err := (BlockStorageV3API{API: api("/volume/v3/")}).NegotiateMicroversion(); err == nil {
+		t.Errorf("Versions.TestNegotiateMicroversion: negotiation succeeded with no version document")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dihedron/go-log"
+)
+
+// Condition is a function that checks whether an asynchronous operation (e.g.
+// a server build) has completed; it returns true when the wait is over and an
+// error if the operation failed or the check could not be performed, in which
+// case the wait is aborted.
+type Condition func() (bool, error)
+
+// Backoff describes how the interval between two checks of a condition grows:
+// the first interval is Initial, then each interval is Factor times the previous
+// one, up to Max (if not zero); a Factor less than or equal to 1 means a fixed
+// interval. An Initial interval less than or equal to zero is replaced by
+// DefaultInterval, so that the condition is never checked in a busy loop.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Factor  float64
+}
+
+// DefaultInterval is the interval between two checks of a condition when no
+// positive one is given.
+const DefaultInterval = time.Second
+
+// next returns the interval following the given one.
+func (b Backoff) next(interval time.Duration) time.Duration {
+	if b.Factor > 1 {
+		interval = time.Duration(float64(interval) * b.Factor)
+	}
+	if b.Max > 0 && interval > b.Max {
+		interval = b.Max
+	}
+	return interval
+}
+
+// WaitFor polls the given condition at the given interval until it is met, it
+// fails or the context is done (e.g. because its deadline expired); the first
+// check is performed immediately.
+func WaitFor(ctx context.Context, interval time.Duration, condition Condition) error {
+	return WaitForWithBackoff(ctx, Backoff{Initial: interval}, condition)
+}
+
+// WaitForWithBackoff polls the given condition until it is met, it fails or the
+// context is done, waiting longer and longer between checks according to the
+// given backoff policy; the first check is performed immediately.
+func WaitForWithBackoff(ctx context.Context, backoff Backoff, condition Condition) error {
+	interval := backoff.Initial
+	if interval <= 0 {
+		log.Debugf("no interval given, checking every %v", DefaultInterval)
+		interval = DefaultInterval
+	}
+	for {
+		done, err := condition()
+		if err != nil {
+			log.Debugf("condition failed: %v", err)
+			return err
+		}
+		if done {
+			log.Debugf("condition met")
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			log.Debugf("stopped waiting: %v", ctx.Err())
+			return fmt.Errorf("condition not met: %v", ctx.Err())
+		case <-time.After(interval):
+		}
+		interval = backoff.next(interval)
+	}
+}
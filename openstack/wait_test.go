@@ -0,0 +1,62 @@
+package openstack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitForWithBackoff(t *testing.T) {
+	backoff := Backoff{Initial: time.Millisecond, Max: 4 * time.Millisecond, Factor: 2}
+	intervals := []time.Duration{}
+	interval := backoff.Initial
+	for i := 0; i < 4; i++ {
+		intervals = append(intervals, interval)
+		interval = backoff.next(interval)
+	}
+	expected := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}
+	for i := range expected {
+		if intervals[i] != expected[i] {
+			t.Fatalf("Wait.TestWaitForWithBackoff: interval %d is %v, expected %v", i, intervals[i], expected[i])
+		}
+	}
+
+	checks := 0
+	err := WaitForWithBackoff(context.Background(), backoff, func() (bool, error) {
+		checks++
+		return checks == 3, nil
+	})
+	if err != nil || checks != 3 {
+		t.Fatalf("Wait.TestWaitForWithBackoff: expected success after 3 checks, got %d (%v)", checks, err)
+	}
+
+	failure := errors.New("failed")
+	err = WaitForWithBackoff(context.Background(), backoff, func() (bool, error) {
+		return false, failure
+	})
+	if err != failure {
+		t.Fatalf("Wait.TestWaitForWithBackoff: expected condition failure, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		return false, nil
+	})
+	if err == nil {
+		t.Fatalf("Wait.TestWaitForWithBackoff: expected timeout")
+	}
+
+	// a zero initial interval must not turn into a busy loop
+	checks = 0
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = WaitForWithBackoff(ctx, Backoff{}, func() (bool, error) {
+		checks++
+		return false, nil
+	})
+	if err == nil || checks != 1 {
+		t.Fatalf("Wait.TestWaitForWithBackoff: expected a single check before timeout, got %d (%v)", checks, err)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/dihedron/go-log"
+)
+
+// WorkflowV2API represents the Workflow API ver. 2 (Mistral), providing support
+// for the management of workflows and workbooks (sets of workflows and
+// actions), written in the Mistral workflow language (YAML), and for running
+// them and monitoring their executions, down to the results of the single
+// actions.
+// Mistral encodes the inputs and outputs of executions as JSON strings, which
+// can be decoded with the DecodeInput and DecodeOutput methods of the
+// executions.
+// See https://docs.openstack.org/mistral/latest/user/rest_api_v2.html
+type WorkflowV2API struct {
+	API
+}
+
+// DefinitionOptions provides the options available for creating or updating
+// workflows and workbooks from their definition: Scope is "private" (the
+// default) or "public", Namespace the namespace of the workflows.
+type DefinitionOptions struct {
+	Scope     *string `parameter:"scope,omitempty" header:"-" json:"-"`
+	Namespace *string `parameter:"namespace,omitempty" header:"-" json:"-"`
+}
+
+// sendDefinition sends the given definition (YAML, in the Mistral workflow
+// language) as a plain text entity to the given path, with the given method.
+func (api *WorkflowV2API) sendDefinition(method string, path string, definition string, opts *DefinitionOptions, checker Checker, output interface{}) (*Result, error) {
+	if opts == nil {
+		opts = &DefinitionOptions{}
+	}
+	result, err := api.InvokeStream(method, path, true, checker, opts, strings.NewReader(definition), int64(len(definition)), "text/plain", nil, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	return result, err
+}
+
+// encodeWorkflowJSON encodes the given values as a JSON string, as expected by
+// Mistral for inputs and parameters; nil is returned if there are no values.
+func encodeWorkflowJSON(values map[string]interface{}) (*string, error) {
+	if values == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return String(string(data)), nil
+}
+
+// decodeWorkflowJSON decodes the given JSON string, as returned by Mistral for
+// inputs and outputs, into the given value; nothing is decoded if the string is
+// nil or empty.
+func decodeWorkflowJSON(value *string, target interface{}) error {
+	if value == nil || *value == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(*value), target)
+}
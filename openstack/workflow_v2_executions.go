@@ -0,0 +1,343 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Workflow execution, task and action execution states.
+const (
+	WorkflowStateIdle      string = "IDLE"
+	WorkflowStateWaiting   string = "WAITING"
+	WorkflowStateRunning   string = "RUNNING"
+	WorkflowStatePaused    string = "PAUSED"
+	WorkflowStateSuccess   string = "SUCCESS"
+	WorkflowStateError     string = "ERROR"
+	WorkflowStateCancelled string = "CANCELLED"
+)
+
+/*
+ * LIST EXECUTIONS
+ */
+
+// ListWorkflowExecutionsOptions provides the options available for listing
+// the workflow executions; Limit sets the page size, all pages are retrieved
+// anyway.
+type ListWorkflowExecutionsOptions struct {
+	WorkflowName *string `parameter:"workflow_name,omitempty" header:"-" json:"-"`
+	WorkflowID   *string `parameter:"workflow_id,omitempty" header:"-" json:"-"`
+	State        *string `parameter:"state,omitempty" header:"-" json:"-"`
+	SortKeys     *string `parameter:"sort_keys,omitempty" header:"-" json:"-"`
+	SortDirs     *string `parameter:"sort_dirs,omitempty" header:"-" json:"-"`
+	Limit        *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker       *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListWorkflowExecutions returns the list of workflow executions of the current
+// project; opts can be nil; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#executions
+func (api *WorkflowV2API) ListWorkflowExecutions(opts *ListWorkflowExecutionsOptions) (*[]WorkflowExecution, *Result, error) {
+	if opts == nil {
+		opts = &ListWorkflowExecutionsOptions{}
+	}
+	executions := []WorkflowExecution{}
+	result, err := api.listResources("./executions", "executions", opts, nextField,
+		func() interface{} { return &[]WorkflowExecution{} },
+		func(page interface{}) { executions = append(executions, *page.(*[]WorkflowExecution)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &executions, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE EXECUTION
+ */
+
+// CreateWorkflowExecutionOptions provides the options available for running a
+// workflow, identified by WorkflowName (and WorkflowNamespace) or by
+// WorkflowID: Input holds the values of the input parameters of the workflow,
+// Params the execution parameters (e.g. "env", or "task_name" for reverse
+// workflows).
+type CreateWorkflowExecutionOptions struct {
+	WorkflowName      *string
+	WorkflowNamespace *string
+	WorkflowID        *string
+	Description       *string
+	Input             map[string]interface{}
+	Params            map[string]interface{}
+}
+
+// CreateWorkflowExecution runs a workflow; the execution is asynchronous: it
+// is RUNNING until it is SUCCESS or ERROR (see WaitForWorkflowExecution); see
+// also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#executions
+func (api *WorkflowV2API) CreateWorkflowExecution(opts *CreateWorkflowExecutionOptions) (*WorkflowExecution, *Result, error) {
+	if opts.WorkflowName == nil && opts.WorkflowID == nil {
+		log.Errorf("no workflow to execute")
+		return nil, nil, fmt.Errorf("either the workflow name or its ID must be given")
+	}
+	input := &struct {
+		WorkflowName      *string `parameter:"-" header:"-" json:"workflow_name,omitempty"`
+		WorkflowNamespace *string `parameter:"-" header:"-" json:"workflow_namespace,omitempty"`
+		WorkflowID        *string `parameter:"-" header:"-" json:"workflow_id,omitempty"`
+		Description       *string `parameter:"-" header:"-" json:"description,omitempty"`
+		Input             *string `parameter:"-" header:"-" json:"input,omitempty"`
+		Params            *string `parameter:"-" header:"-" json:"params,omitempty"`
+	}{
+		WorkflowName:      opts.WorkflowName,
+		WorkflowNamespace: opts.WorkflowNamespace,
+		WorkflowID:        opts.WorkflowID,
+		Description:       opts.Description,
+	}
+	var err error
+	if input.Input, err = encodeWorkflowJSON(opts.Input); err != nil {
+		log.Errorf("error encoding workflow input: %v", err)
+		return nil, nil, err
+	}
+	if input.Params, err = encodeWorkflowJSON(opts.Params); err != nil {
+		log.Errorf("error encoding workflow parameters: %v", err)
+		return nil, nil, err
+	}
+	execution := &WorkflowExecution{}
+
+	result, err := api.Invoke(http.MethodPost, "./executions", true, StatusCodeIn(201), input, execution, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return execution, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE EXECUTION
+ */
+
+// RetrieveWorkflowExecution retrieves the workflow execution with the given ID;
+// see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#executions
+func (api *WorkflowV2API) RetrieveWorkflowExecution(executionid string) (*WorkflowExecution, *Result, error) {
+	execution := &WorkflowExecution{}
+	result, err := api.Invoke(http.MethodGet, "./executions/{id}", true, StatusCodeIn(200), &envelope{ID: executionid}, execution, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return execution, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE EXECUTION
+ */
+
+// UpdateWorkflowExecutionOptions provides the options available for updating a
+// workflow execution: State pauses (PAUSED), resumes (RUNNING) or cancels
+// (CANCELLED) it, or forces its outcome (SUCCESS or ERROR), StateInfo gives the
+// reason.
+type UpdateWorkflowExecutionOptions struct {
+	State       *string `parameter:"-" header:"-" json:"state,omitempty"`
+	StateInfo   *string `parameter:"-" header:"-" json:"state_info,omitempty"`
+	Description *string `parameter:"-" header:"-" json:"description,omitempty"`
+}
+
+// UpdateWorkflowExecution updates the workflow execution with the given ID; see
+// also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#executions
+func (api *WorkflowV2API) UpdateWorkflowExecution(executionid string, opts *UpdateWorkflowExecutionOptions) (*WorkflowExecution, *Result, error) {
+	input := &struct {
+		ID string `parameter:"-" header:"-" variable:"id" json:"-"`
+		UpdateWorkflowExecutionOptions
+	}{
+		ID:                             executionid,
+		UpdateWorkflowExecutionOptions: *opts,
+	}
+	execution := &WorkflowExecution{}
+
+	result, err := api.Invoke(http.MethodPut, "./executions/{id}", true, StatusCodeIn(200), input, execution, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return execution, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE EXECUTION
+ */
+
+// DeleteWorkflowExecution deletes the workflow execution with the given ID,
+// which must not be running; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#executions
+func (api *WorkflowV2API) DeleteWorkflowExecution(executionid string) (bool, *Result, error) {
+	return api.deleteWorkflowResource("./executions/{id}", executionid)
+}
+
+/*
+ * WAIT FOR EXECUTION
+ */
+
+// WaitForWorkflowExecution waits until the workflow execution with the given ID
+// is complete, polling it with the given backoff policy; the wait fails if the
+// execution ends in the ERROR or CANCELLED state, or disappears.
+func (api *WorkflowV2API) WaitForWorkflowExecution(ctx context.Context, executionid string, backoff Backoff) (*WorkflowExecution, error) {
+	var current *WorkflowExecution
+	err := WaitForWithBackoff(ctx, backoff, func() (bool, error) {
+		var result *Result
+		var err error
+		current, result, err = api.RetrieveWorkflowExecution(executionid)
+		if result != nil && result.Code == http.StatusNotFound {
+			return false, fmt.Errorf("workflow execution %q not found", executionid)
+		}
+		if err != nil {
+			return false, err
+		}
+		if current == nil || current.State == nil {
+			return false, fmt.Errorf("error retrieving workflow execution %q: %v", executionid, result)
+		}
+		log.Debugf("workflow execution %q is %s", executionid, *current.State)
+		switch *current.State {
+		case WorkflowStateSuccess:
+			return true, nil
+		case WorkflowStateError, WorkflowStateCancelled:
+			return false, fmt.Errorf("workflow execution %q is in %s state: %s", executionid, *current.State, stringValue(current.StateInfo))
+		}
+		return false, nil
+	})
+	return current, err
+}
+
+/*
+ * TASKS
+ */
+
+// ListWorkflowExecutionTasks returns the tasks of the workflow execution with
+// the given ID; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#tasks
+func (api *WorkflowV2API) ListWorkflowExecutionTasks(executionid string) (*[]WorkflowTask, *Result, error) {
+	tasks := []WorkflowTask{}
+	result, err := api.listResources("./executions/{id}/tasks", "tasks", &envelope{ID: executionid}, nextField,
+		func() interface{} { return &[]WorkflowTask{} },
+		func(page interface{}) { tasks = append(tasks, *page.(*[]WorkflowTask)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &tasks, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * ACTION EXECUTIONS
+ */
+
+// ListActionExecutionsOptions provides the options available for listing the
+// action executions; Limit sets the page size, all pages are retrieved anyway.
+type ListActionExecutionsOptions struct {
+	Name         *string `parameter:"name,omitempty" header:"-" json:"-"`
+	WorkflowName *string `parameter:"workflow_name,omitempty" header:"-" json:"-"`
+	TaskName     *string `parameter:"task_name,omitempty" header:"-" json:"-"`
+	State        *string `parameter:"state,omitempty" header:"-" json:"-"`
+	SortKeys     *string `parameter:"sort_keys,omitempty" header:"-" json:"-"`
+	SortDirs     *string `parameter:"sort_dirs,omitempty" header:"-" json:"-"`
+	Limit        *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker       *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListActionExecutions returns the list of action executions; opts can be nil;
+// see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#action-executions
+func (api *WorkflowV2API) ListActionExecutions(opts *ListActionExecutionsOptions) (*[]ActionExecution, *Result, error) {
+	if opts == nil {
+		opts = &ListActionExecutionsOptions{}
+	}
+	return api.listActionExecutions("./action_executions", opts)
+}
+
+// ListTaskActionExecutions returns the action executions of the task with the
+// given ID, with their results; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#action-executions
+func (api *WorkflowV2API) ListTaskActionExecutions(taskid string) (*[]ActionExecution, *Result, error) {
+	return api.listActionExecutions("./tasks/{id}/action_executions", &envelope{ID: taskid})
+}
+
+// listActionExecutions returns the action executions at the given path.
+func (api *WorkflowV2API) listActionExecutions(path string, input interface{}) (*[]ActionExecution, *Result, error) {
+	executions := []ActionExecution{}
+	result, err := api.listResources(path, "action_executions", input, nextField,
+		func() interface{} { return &[]ActionExecution{} },
+		func(page interface{}) { executions = append(executions, *page.(*[]ActionExecution)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &executions, result, err
+	}
+	return nil, result, err
+}
+
+// RetrieveActionExecution retrieves the action execution with the given ID,
+// with its result; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#action-executions
+func (api *WorkflowV2API) RetrieveActionExecution(executionid string) (*ActionExecution, *Result, error) {
+	execution := &ActionExecution{}
+	result, err := api.Invoke(http.MethodGet, "./action_executions/{id}", true, StatusCodeIn(200), &envelope{ID: executionid}, execution, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return execution, result, err
+	}
+	return nil, result, err
+}
+
+// RunActionOptions provides the options available for running an action ad
+// hoc: Input holds the values of the input parameters of the action; the
+// action is run synchronously, and its result returned, unless Async is set;
+// its execution is only kept if Save is set.
+type RunActionOptions struct {
+	Input       map[string]interface{}
+	Description *string
+	Async       bool
+	Save        bool
+}
+
+// RunAction runs the action with the given name (e.g. "std.echo" or
+// "nova.servers_list") outside of any workflow; opts can be nil; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#action-executions
+func (api *WorkflowV2API) RunAction(name string, opts *RunActionOptions) (*ActionExecution, *Result, error) {
+	if opts == nil {
+		opts = &RunActionOptions{}
+	}
+	input := &struct {
+		Name        string  `parameter:"-" header:"-" json:"name"`
+		Description *string `parameter:"-" header:"-" json:"description,omitempty"`
+		Input       *string `parameter:"-" header:"-" json:"input,omitempty"`
+		Params      *string `parameter:"-" header:"-" json:"params,omitempty"`
+	}{
+		Name:        name,
+		Description: opts.Description,
+	}
+	var err error
+	if input.Input, err = encodeWorkflowJSON(opts.Input); err != nil {
+		log.Errorf("error encoding action input: %v", err)
+		return nil, nil, err
+	}
+	if input.Params, err = encodeWorkflowJSON(map[string]interface{}{
+		"run_sync":    !opts.Async,
+		"save_result": opts.Save,
+	}); err != nil {
+		log.Errorf("error encoding action parameters: %v", err)
+		return nil, nil, err
+	}
+	execution := &ActionExecution{}
+
+	result, err := api.Invoke(http.MethodPost, "./action_executions", true, StatusCodeIn(201), input, execution, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 201 {
+		return execution, result, err
+	}
+	return nil, result, err
+}
@@ -0,0 +1,126 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+/*
+ * WORKFLOWS AND WORKBOOKS
+ */
+
+// Workflow is a Mistral workflow: Definition is its source, in the Mistral
+// workflow language, Input the list of its input parameters (e.g. "vm_name,
+// flavor=small").
+type Workflow struct {
+	ID         *string   `json:"id,omitempty"`
+	Name       *string   `json:"name,omitempty"`
+	Namespace  *string   `json:"namespace,omitempty"`
+	Input      *string   `json:"input,omitempty"`
+	Definition *string   `json:"definition,omitempty"`
+	Tags       *[]string `json:"tags,omitempty"`
+	Scope      *string   `json:"scope,omitempty"`
+	ProjectID  *string   `json:"project_id,omitempty"`
+	CreatedAt  *string   `json:"created_at,omitempty"`
+	UpdatedAt  *string   `json:"updated_at,omitempty"`
+}
+
+// Workbook is a set of workflows and actions defined together: Definition is
+// its source, in the Mistral workflow language.
+type Workbook struct {
+	ID         *string   `json:"id,omitempty"`
+	Name       *string   `json:"name,omitempty"`
+	Namespace  *string   `json:"namespace,omitempty"`
+	Definition *string   `json:"definition,omitempty"`
+	Tags       *[]string `json:"tags,omitempty"`
+	Scope      *string   `json:"scope,omitempty"`
+	ProjectID  *string   `json:"project_id,omitempty"`
+	CreatedAt  *string   `json:"created_at,omitempty"`
+	UpdatedAt  *string   `json:"updated_at,omitempty"`
+}
+
+/*
+ * EXECUTIONS
+ */
+
+// WorkflowExecution is a run of a workflow: State is one of the
+// WorkflowState* constants, StateInfo the reason of the state (e.g. the error);
+// Input, Params and Output are JSON strings (see DecodeInput and
+// DecodeOutput).
+type WorkflowExecution struct {
+	ID                *string `json:"id,omitempty"`
+	WorkflowID        *string `json:"workflow_id,omitempty"`
+	WorkflowName      *string `json:"workflow_name,omitempty"`
+	WorkflowNamespace *string `json:"workflow_namespace,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	State             *string `json:"state,omitempty"`
+	StateInfo         *string `json:"state_info,omitempty"`
+	Input             *string `json:"input,omitempty"`
+	Params            *string `json:"params,omitempty"`
+	Output            *string `json:"output,omitempty"`
+	TaskExecutionID   *string `json:"task_execution_id,omitempty"`
+	RootExecutionID   *string `json:"root_execution_id,omitempty"`
+	ProjectID         *string `json:"project_id,omitempty"`
+	CreatedAt         *string `json:"created_at,omitempty"`
+	UpdatedAt         *string `json:"updated_at,omitempty"`
+}
+
+// DecodeInput decodes the input of the execution into the given value.
+func (e *WorkflowExecution) DecodeInput(target interface{}) error {
+	return decodeWorkflowJSON(e.Input, target)
+}
+
+// DecodeOutput decodes the output of the execution into the given value.
+func (e *WorkflowExecution) DecodeOutput(target interface{}) error {
+	return decodeWorkflowJSON(e.Output, target)
+}
+
+// WorkflowTask is the execution of a task of a workflow: Result and Published
+// (the variables it published to the workflow context) are JSON strings.
+type WorkflowTask struct {
+	ID                  *string `json:"id,omitempty"`
+	Name                *string `json:"name,omitempty"`
+	Type                *string `json:"type,omitempty"`
+	WorkflowID          *string `json:"workflow_id,omitempty"`
+	WorkflowName        *string `json:"workflow_name,omitempty"`
+	WorkflowExecutionID *string `json:"workflow_execution_id,omitempty"`
+	State               *string `json:"state,omitempty"`
+	StateInfo           *string `json:"state_info,omitempty"`
+	Result              *string `json:"result,omitempty"`
+	Published           *string `json:"published,omitempty"`
+	Processed           *bool   `json:"processed,omitempty"`
+	Reset               *bool   `json:"reset,omitempty"`
+	CreatedAt           *string `json:"created_at,omitempty"`
+	UpdatedAt           *string `json:"updated_at,omitempty"`
+}
+
+// ActionExecution is the execution of an action, either by a task of a
+// workflow or ad hoc (see RunAction): Input, Params and Output are JSON strings
+// (see DecodeInput and DecodeOutput).
+type ActionExecution struct {
+	ID              *string   `json:"id,omitempty"`
+	Name            *string   `json:"name,omitempty"`
+	WorkflowName    *string   `json:"workflow_name,omitempty"`
+	TaskName        *string   `json:"task_name,omitempty"`
+	TaskExecutionID *string   `json:"task_execution_id,omitempty"`
+	Description     *string   `json:"description,omitempty"`
+	State           *string   `json:"state,omitempty"`
+	StateInfo       *string   `json:"state_info,omitempty"`
+	Accepted        *bool     `json:"accepted,omitempty"`
+	Tags            *[]string `json:"tags,omitempty"`
+	Input           *string   `json:"input,omitempty"`
+	Params          *string   `json:"params,omitempty"`
+	Output          *string   `json:"output,omitempty"`
+	CreatedAt       *string   `json:"created_at,omitempty"`
+	UpdatedAt       *string   `json:"updated_at,omitempty"`
+}
+
+// DecodeInput decodes the input of the action execution into the given value.
+func (a *ActionExecution) DecodeInput(target interface{}) error {
+	return decodeWorkflowJSON(a.Input, target)
+}
+
+// DecodeOutput decodes the output of the action execution (e.g.
+// {"result": ...}) into the given value.
+func (a *ActionExecution) DecodeOutput(target interface{}) error {
+	return decodeWorkflowJSON(a.Output, target)
+}
@@ -0,0 +1,219 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/dihedron/go-log"
+)
+
+// Workflow and workbook scopes.
+const (
+	WorkflowScopePrivate string = "private"
+	WorkflowScopePublic  string = "public"
+)
+
+/*
+ * LIST WORKFLOWS
+ */
+
+// ListWorkflowsOptions provides the options available for listing the
+// workflows; Limit sets the page size, all pages are retrieved anyway.
+type ListWorkflowsOptions struct {
+	Name      *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Namespace *string `parameter:"namespace,omitempty" header:"-" json:"-"`
+	Scope     *string `parameter:"scope,omitempty" header:"-" json:"-"`
+	Tags      *string `parameter:"tags,omitempty" header:"-" json:"-"`
+	SortKeys  *string `parameter:"sort_keys,omitempty" header:"-" json:"-"`
+	SortDirs  *string `parameter:"sort_dirs,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListWorkflows returns the list of workflows visible to the current project,
+// including public ones; opts can be nil; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#workflows
+func (api *WorkflowV2API) ListWorkflows(opts *ListWorkflowsOptions) (*[]Workflow, *Result, error) {
+	if opts == nil {
+		opts = &ListWorkflowsOptions{}
+	}
+	workflows := []Workflow{}
+	result, err := api.listResources("./workflows", "workflows", opts, nextField,
+		func() interface{} { return &[]Workflow{} },
+		func(page interface{}) { workflows = append(workflows, *page.(*[]Workflow)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &workflows, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE WORKFLOWS
+ */
+
+// CreateWorkflows creates the workflows in the given definition (YAML, in the
+// Mistral workflow language), which can contain more than one; opts can be
+// nil; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#workflows
+func (api *WorkflowV2API) CreateWorkflows(definition string, opts *DefinitionOptions) (*[]Workflow, *Result, error) {
+	workflows := &[]Workflow{}
+	result, err := api.sendDefinition(http.MethodPost, "./workflows", definition, opts, StatusCodeIn(201), &envelope{Name: "workflows", Body: workflows})
+	if result != nil && result.Code == 201 {
+		return workflows, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE WORKFLOW
+ */
+
+// RetrieveWorkflow retrieves the workflow with the given ID or name, along with
+// its definition; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#workflows
+func (api *WorkflowV2API) RetrieveWorkflow(workflow string) (*Workflow, *Result, error) {
+	output := &Workflow{}
+	result, err := api.Invoke(http.MethodGet, "./workflows/{id}", true, StatusCodeIn(200), &envelope{ID: workflow}, output, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return output, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE WORKFLOWS
+ */
+
+// UpdateWorkflows replaces the definitions of the workflows in the given
+// definition, which must already exist; opts can be nil; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#workflows
+func (api *WorkflowV2API) UpdateWorkflows(definition string, opts *DefinitionOptions) (*[]Workflow, *Result, error) {
+	workflows := &[]Workflow{}
+	result, err := api.sendDefinition(http.MethodPut, "./workflows", definition, opts, StatusCodeIn(200), &envelope{Name: "workflows", Body: workflows})
+	if result != nil && result.Code == 200 {
+		return workflows, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE WORKFLOW
+ */
+
+// DeleteWorkflow deletes the workflow with the given ID or name; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#workflows
+func (api *WorkflowV2API) DeleteWorkflow(workflow string) (bool, *Result, error) {
+	return api.deleteWorkflowResource("./workflows/{id}", workflow)
+}
+
+/*
+ * LIST WORKBOOKS
+ */
+
+// ListWorkbooksOptions provides the options available for listing the
+// workbooks; Limit sets the page size, all pages are retrieved anyway.
+type ListWorkbooksOptions struct {
+	Name      *string `parameter:"name,omitempty" header:"-" json:"-"`
+	Namespace *string `parameter:"namespace,omitempty" header:"-" json:"-"`
+	Scope     *string `parameter:"scope,omitempty" header:"-" json:"-"`
+	Tags      *string `parameter:"tags,omitempty" header:"-" json:"-"`
+	SortKeys  *string `parameter:"sort_keys,omitempty" header:"-" json:"-"`
+	SortDirs  *string `parameter:"sort_dirs,omitempty" header:"-" json:"-"`
+	Limit     *int    `parameter:"limit,omitempty" header:"-" json:"-"`
+	Marker    *string `parameter:"marker,omitempty" header:"-" json:"-"`
+}
+
+// ListWorkbooks returns the list of workbooks visible to the current project;
+// opts can be nil; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#workbooks
+func (api *WorkflowV2API) ListWorkbooks(opts *ListWorkbooksOptions) (*[]Workbook, *Result, error) {
+	if opts == nil {
+		opts = &ListWorkbooksOptions{}
+	}
+	workbooks := []Workbook{}
+	result, err := api.listResources("./workbooks", "workbooks", opts, nextField,
+		func() interface{} { return &[]Workbook{} },
+		func(page interface{}) { workbooks = append(workbooks, *page.(*[]Workbook)...) })
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return &workbooks, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * CREATE WORKBOOK
+ */
+
+// CreateWorkbook creates a workbook, along with its workflows and actions, from
+// the given definition (YAML, in the Mistral workflow language); opts can be
+// nil; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#workbooks
+func (api *WorkflowV2API) CreateWorkbook(definition string, opts *DefinitionOptions) (*Workbook, *Result, error) {
+	workbook := &Workbook{}
+	result, err := api.sendDefinition(http.MethodPost, "./workbooks", definition, opts, StatusCodeIn(201), workbook)
+	if result != nil && result.Code == 201 {
+		return workbook, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * RETRIEVE WORKBOOK
+ */
+
+// RetrieveWorkbook retrieves the workbook with the given name, along with its
+// definition; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#workbooks
+func (api *WorkflowV2API) RetrieveWorkbook(name string) (*Workbook, *Result, error) {
+	workbook := &Workbook{}
+	result, err := api.Invoke(http.MethodGet, "./workbooks/{id}", true, StatusCodeIn(200), &envelope{ID: name}, workbook, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 200 {
+		return workbook, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * UPDATE WORKBOOK
+ */
+
+// UpdateWorkbook replaces the definition of the workbook named in the given
+// definition, along with its workflows and actions; opts can be nil; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#workbooks
+func (api *WorkflowV2API) UpdateWorkbook(definition string, opts *DefinitionOptions) (*Workbook, *Result, error) {
+	workbook := &Workbook{}
+	result, err := api.sendDefinition(http.MethodPut, "./workbooks", definition, opts, StatusCodeIn(200), workbook)
+	if result != nil && result.Code == 200 {
+		return workbook, result, err
+	}
+	return nil, result, err
+}
+
+/*
+ * DELETE WORKBOOK
+ */
+
+// DeleteWorkbook deletes the workbook with the given name, along with its
+// workflows and actions; see also
+// https://docs.openstack.org/mistral/latest/user/rest_api_v2.html#workbooks
+func (api *WorkflowV2API) DeleteWorkbook(name string) (bool, *Result, error) {
+	return api.deleteWorkflowResource("./workbooks/{id}", name)
+}
+
+// deleteWorkflowResource deletes the resource at the given path, having the
+// given ID or name.
+func (api *WorkflowV2API) deleteWorkflowResource(path string, id string) (bool, *Result, error) {
+	result, err := api.Invoke(http.MethodDelete, path, true, StatusCodeIn(204), &envelope{ID: id}, nil, nil)
+	log.Debugf("result is %v (%v)", result, err)
+	if result != nil && result.Code == 204 {
+		return true, result, err
+	}
+	return false, result, err
+}
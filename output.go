@@ -0,0 +1,136 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+// printTable prints the given rows as a table with the given headers, boxed
+// as in the OpenStack command line client; multi-line cells span multiple
+// lines.
+func printTable(out io.Writer, headers []string, rows [][]string) {
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			for _, line := range strings.Split(cell, "\n") {
+				if width := utf8.RuneCountInString(line); width > widths[i] {
+					widths[i] = width
+				}
+			}
+		}
+	}
+
+	separator := "+"
+	for _, width := range widths {
+		separator += strings.Repeat("-", width+2) + "+"
+	}
+	printRow := func(row []string) {
+		lines := make([][]string, len(row))
+		height := 1
+		for i, cell := range row {
+			lines[i] = strings.Split(cell, "\n")
+			if len(lines[i]) > height {
+				height = len(lines[i])
+			}
+		}
+		for n := 0; n < height; n++ {
+			fmt.Fprint(out, "|")
+			for i := range row {
+				line := ""
+				if n < len(lines[i]) {
+					line = lines[i][n]
+				}
+				fmt.Fprintf(out, " %s%s |", line, strings.Repeat(" ", widths[i]-utf8.RuneCountInString(line)))
+			}
+			fmt.Fprintln(out)
+		}
+	}
+
+	fmt.Fprintln(out, separator)
+	printRow(headers)
+	fmt.Fprintln(out, separator)
+	for _, row := range rows {
+		printRow(row)
+	}
+	fmt.Fprintln(out, separator)
+}
+
+// printFields prints the given name and value pairs as a two-column table.
+func printFields(out io.Writer, fields [][]string) {
+	printTable(out, []string{"Field", "Value"}, fields)
+}
+
+// value returns the given string, or an empty string if it is nil.
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// interfaceName returns the name of the given endpoint interface type, or an
+// empty string if it is nil.
+func interfaceName(t *openstack.InterfaceType) string {
+	if t == nil {
+		return ""
+	}
+	return string(*t)
+}
+
+// nameAndID returns the given name followed by the given ID in parentheses,
+// or either of them if the other is missing.
+func nameAndID(name *string, id *string) string {
+	switch {
+	case name != nil && id != nil:
+		return fmt.Sprintf("%s (%s)", *name, *id)
+	case name != nil:
+		return *name
+	}
+	return value(id)
+}
+
+// timestamp returns the given RFC 3339 timestamp in the local time zone,
+// along with how long ago or how far in the future it is relative to now; the
+// timestamp is returned as is if it cannot be parsed.
+func timestamp(s *string, now time.Time) string {
+	if s == nil {
+		return ""
+	}
+	t, err := time.Parse(time.RFC3339, *s)
+	if err != nil {
+		return *s
+	}
+	formatted := t.Local().Format("2006-01-02 15:04:05 MST")
+	delta := t.Sub(now)
+	switch {
+	case delta >= time.Second:
+		return fmt.Sprintf("%s (in %s)", formatted, duration(delta))
+	case delta <= -time.Second:
+		return fmt.Sprintf("%s (%s ago)", formatted, duration(-delta))
+	}
+	return formatted
+}
+
+// duration returns the given duration in days if it is longer than two days,
+// to the minute if it is longer than an hour, or else to the second.
+func duration(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%d days", d/(24*time.Hour))
+	case d >= time.Hour:
+		return strings.TrimSuffix(d.Truncate(time.Minute).String(), "0s")
+	}
+	return d.Truncate(time.Second).String()
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestPrintTable(t *testing.T) {
+	out := &bytes.Buffer{}
+	printTable(out, []string{"Name", "Status"}, [][]string{
+		{"vm-1", "ACTIVE"},
+		{"vm-two", "ERROR\nno valid host"},
+	})
+	expected := `+--------+---------------+
+| Name   | Status        |
++--------+---------------+
+| vm-1   | ACTIVE        |
+| vm-two | ERROR         |
+|        | no valid host |
++--------+---------------+
+`
+	if out.String() != expected {
+		t.Errorf("Output.TestPrintTable: expected\n%s\ngot\n%s", expected, out.String())
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{42 * time.Second, "42s"},
+		{90*time.Minute + 30*time.Second, "1h30m"},
+		{3 * time.Hour, "3h0m"},
+		{49 * time.Hour, "2 days"},
+		{1000 * 24 * time.Hour, "1000 days"},
+	}
+	for _, test := range tests {
+		if actual := duration(test.duration); actual != test.expected {
+			t.Errorf("Output.TestDuration: expected %q for %v, got %q", test.expected, test.duration, actual)
+		}
+	}
+}
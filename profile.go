@@ -0,0 +1,177 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+func init() {
+	register(&Command{
+		Path:    []string{"profile", "init"},
+		Summary: "generate a profile with a filter per endpoint in the catalog",
+		Setup: func(flags *flag.FlagSet) Runner {
+			output := flags.String("output", "", "the file to write the profile to, instead of the standard output")
+			force := flags.Bool("force", false, "overwrite the output file if it exists")
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 0 {
+					return fmt.Errorf("usage: %s profile init [flags]", sdk.program)
+				}
+				// start from the whole catalog, not from the profile in use
+				sdk.client.Profile = nil
+				if err := sdk.client.InitProfile(); err != nil {
+					return err
+				}
+				if *output == "" {
+					if err := sdk.client.SaveProfile(sdk.out); err != nil {
+						return err
+					}
+					fmt.Fprintln(sdk.out)
+					return nil
+				}
+				if _, err := os.Stat(*output); err == nil && !*force {
+					return fmt.Errorf("%s already exists, use --force to overwrite it", *output)
+				}
+				if err := sdk.client.SaveProfileTo(*output); err != nil {
+					return err
+				}
+				fmt.Fprintf(sdk.out, "profile with %d filters written to %s: remove the endpoints not to use, then run \"%s profile apply %s\"\n", len(sdk.client.Profile.Filters), *output, sdk.program, *output)
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:    []string{"profile", "show"},
+		Usage:   "[<file>]",
+		Summary: "show the filters of a profile, by default the one in use",
+		Offline: true,
+		Setup: func(flags *flag.FlagSet) Runner {
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) > 1 {
+					return fmt.Errorf("usage: %s profile show [<file>]", sdk.program)
+				}
+				path := sdk.config.EndpointProfile
+				if len(args) == 1 {
+					path = args[0]
+				} else if path == "" {
+					path = appliedProfilePath()
+				}
+				profile, err := readProfile(path)
+				if os.IsNotExist(err) && len(args) == 0 && sdk.config.EndpointProfile == "" {
+					fmt.Fprintf(sdk.out, "no profile applied, all the endpoints in the catalog are used\n")
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(sdk.out, "Profile:          %s\nIdentity service: %s", path, value(profile.AuthURL))
+				if sdk.config.AuthURL != "" && !profileAppliesTo(profile, sdk.config.AuthURL) {
+					fmt.Fprintf(sdk.out, " (not used with %s)", sdk.config.AuthURL)
+				}
+				fmt.Fprintln(sdk.out)
+				rows := [][]string{}
+				for _, filter := range profile.Filters {
+					rows = append(rows, []string{
+						value(filter.Type),
+						value(filter.Name),
+						value(filter.Region),
+						interfaceName(filter.Interface),
+						value(filter.APIVersion),
+						value(filter.EndpointURL),
+					})
+				}
+				printTable(sdk.out, []string{"Type", "Name", "Region", "Interface", "Microversion", "URL"}, rows)
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:    []string{"profile", "apply"},
+		Usage:   "<file>",
+		Summary: "use the given profile from now on, for its Identity service",
+		Offline: true,
+		Setup: func(flags *flag.FlagSet) Runner {
+			clearProfile := flags.Bool("clear", false, "stop using the applied profile, and use all the endpoints in the catalog")
+			return func(sdk *OpenStack, args []string) error {
+				applied := appliedProfilePath()
+				if applied == "" {
+					return fmt.Errorf("cannot apply profiles: no home directory")
+				}
+				if *clearProfile {
+					if len(args) != 0 {
+						return fmt.Errorf("usage: %s profile apply --clear", sdk.program)
+					}
+					if err := os.Remove(applied); err != nil && !os.IsNotExist(err) {
+						return err
+					}
+					fmt.Fprintln(sdk.out, "profile cleared")
+					return nil
+				}
+				if len(args) != 1 {
+					return fmt.Errorf("usage: %s profile apply <file>", sdk.program)
+				}
+				profile, err := readProfile(args[0])
+				if err != nil {
+					return err
+				}
+				if len(profile.Filters) == 0 {
+					return fmt.Errorf("profile %s has no filters: it would hide all the services", args[0])
+				}
+				data, err := json.MarshalIndent(profile, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := os.MkdirAll(filepath.Dir(applied), 0700); err != nil {
+					return err
+				}
+				if err := ioutil.WriteFile(applied, data, 0600); err != nil {
+					return err
+				}
+				fmt.Fprintf(sdk.out, "profile %s applied (%d filters): it is used whenever connecting to %s\n", args[0], len(profile.Filters), value(profile.AuthURL))
+				return nil
+			}
+		},
+	})
+}
+
+// appliedProfilePath returns the path of the profile applied with "profile
+// apply", or an empty string if there is no home directory to keep it in.
+func appliedProfilePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "go-openstack", "profile.json")
+}
+
+// readProfile reads the profile in the file at the given path; the error is
+// returned as is if the file cannot be read, so that it can be checked with
+// os.IsNotExist.
+func readProfile(path string) (*openstack.Profile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	profile := &openstack.Profile{}
+	if err := json.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("error parsing profile %s: %v", path, err)
+	}
+	return profile, nil
+}
+
+// profileAppliesTo checks whether the given profile was generated for the
+// Identity service at the given URL; profiles without one apply to any.
+func profileAppliesTo(profile *openstack.Profile, authURL string) bool {
+	return profile.AuthURL == nil || openstack.NormaliseURL(*profile.AuthURL) == openstack.NormaliseURL(authURL)
+}
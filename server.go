@@ -0,0 +1,505 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+// serverBackoff is how often servers are polled while waiting for them to
+// reach a status: builds and reboots take from a few seconds to minutes.
+var serverBackoff = openstack.Backoff{
+	Initial: 2 * time.Second,
+	Max:     15 * time.Second,
+	Factor:  1.5,
+}
+
+func init() {
+	register(&Command{
+		Path:      []string{"server", "list"},
+		Summary:   "list the servers of the current project",
+		Watchable: true,
+		Setup: func(flags *flag.FlagSet) Runner {
+			allProjects := flags.Bool("all-projects", false, "list the servers of all projects (administrators only)")
+			name := flags.String("name", "", "only list the servers whose name matches the given regular expression")
+			status := flags.String("status", "", "only list the servers with the given status (e.g. ACTIVE, ERROR)")
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 0 {
+					return fmt.Errorf("usage: %s server list [flags]", sdk.program)
+				}
+				compute, err := computeService(sdk)
+				if err != nil {
+					return err
+				}
+				opts := &openstack.ListServersOptions{
+					Name: optional(*name),
+				}
+				if *status != "" {
+					opts.Status = openstack.ServerStatus(strings.ToUpper(*status)).Ptr()
+				}
+				if *allProjects {
+					opts.AllTenants = openstack.Bool(true)
+				}
+				servers, result, err := compute.ListServersDetail(opts)
+				if servers == nil {
+					return failure("listing servers", result, err)
+				}
+				rows := [][]string{}
+				for _, server := range *servers {
+					image := ""
+					if server.Image != nil {
+						image = value(server.Image.ID)
+					}
+					rows = append(rows, []string{
+						value(server.ID),
+						value(server.Name),
+						serverStatus(server.Status),
+						serverAddresses(&server, "; "),
+						image,
+						serverFlavor(&server),
+					})
+				}
+				printTable(sdk.out, []string{"ID", "Name", "Status", "Networks", "Image", "Flavor"}, rows)
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:      []string{"server", "show"},
+		Usage:     "<server>",
+		Summary:   "show the details of a server, by name or ID",
+		Watchable: true,
+		Args:      []Completer{completeServers},
+		Setup: func(flags *flag.FlagSet) Runner {
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 1 {
+					return fmt.Errorf("usage: %s server show <server>", sdk.program)
+				}
+				compute, err := computeService(sdk)
+				if err != nil {
+					return err
+				}
+				server, err := findServer(compute, args[0])
+				if err != nil {
+					return err
+				}
+				printServer(sdk, server)
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:    []string{"server", "create"},
+		Usage:   "<name>",
+		Summary: "create a server from an image",
+		FlagValues: map[string]Completer{
+			"flavor": completeFlavors,
+		},
+		Setup: func(flags *flag.FlagSet) Runner {
+			flavor := flags.String("flavor", "", "the name or ID of the flavor of the server (required)")
+			image := flags.String("image", "", "the name or ID of the image to boot from (required)")
+			networks := &stringList{}
+			flags.Var(networks, "network", "the name or ID of a network to attach the server to (repeatable)")
+			keyName := flags.String("key-name", "", "the name of the keypair to inject into the server")
+			securityGroups := &stringList{}
+			flags.Var(securityGroups, "security-group", "the name of a security group to apply to the server (repeatable)")
+			availabilityZone := flags.String("availability-zone", "", "the availability zone to create the server in")
+			wait, waitTimeout := waitFlags(flags, "for the server to become ACTIVE")
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 1 || *flavor == "" || *image == "" {
+					return fmt.Errorf("usage: %s server create --flavor <flavor> --image <image> [flags] <name>", sdk.program)
+				}
+				compute, err := computeService(sdk)
+				if err != nil {
+					return err
+				}
+				opts := &openstack.CreateServerOptions{
+					Name:             openstack.String(args[0]),
+					KeyName:          optional(*keyName),
+					AvailabilityZone: optional(*availabilityZone),
+				}
+				if opts.FlavorRef, err = findFlavor(compute, *flavor); err != nil {
+					return err
+				}
+				if opts.ImageRef, err = findImage(sdk, *image); err != nil {
+					return err
+				}
+				if len(*networks) > 0 {
+					list := []openstack.ServerNetwork{}
+					for _, network := range *networks {
+						id, err := findNetwork(sdk, network)
+						if err != nil {
+							return err
+						}
+						list = append(list, openstack.ServerNetwork{UUID: id})
+					}
+					opts.Networks = &list
+				}
+				if len(*securityGroups) > 0 {
+					groups := []string(*securityGroups)
+					opts.SecurityGroups = &groups
+				}
+
+				created, result, err := compute.CreateServer(opts)
+				if created == nil || created.ID == nil {
+					return failure("creating server", result, err)
+				}
+				server, err := waitForServer(compute, *created.ID, openstack.ServerStatusActive, *wait, *waitTimeout)
+				if err != nil {
+					return err
+				}
+				// the administrative password is only returned on creation
+				server.AdminPass = created.AdminPass
+				printServer(sdk, server)
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:    []string{"server", "delete"},
+		Usage:   "<server> [<server>...]",
+		Summary: "delete one or more servers, by name or ID",
+		Args:    []Completer{completeServers},
+		Setup: func(flags *flag.FlagSet) Runner {
+			wait, waitTimeout := waitFlags(flags, "for the servers to be deleted")
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) == 0 {
+					return fmt.Errorf("usage: %s server delete [flags] <server> [<server>...]", sdk.program)
+				}
+				compute, err := computeService(sdk)
+				if err != nil {
+					return err
+				}
+				ids := []string{}
+				for _, arg := range args {
+					server, err := findServer(compute, arg)
+					if err != nil {
+						return err
+					}
+					ok, result, err := compute.DeleteServer(*server.ID)
+					if !ok {
+						return failure(fmt.Sprintf("deleting server %q", arg), result, err)
+					}
+					ids = append(ids, *server.ID)
+				}
+				for _, id := range ids {
+					if _, err := waitForServer(compute, id, openstack.ServerStatusDeleted, *wait, *waitTimeout); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:    []string{"server", "reboot"},
+		Usage:   "<server>",
+		Summary: "reboot a server, by name or ID",
+		Args:    []Completer{completeServers},
+		Setup: func(flags *flag.FlagSet) Runner {
+			hard := flags.Bool("hard", false, "power cycle the server instead of restarting its operating system")
+			wait, waitTimeout := waitFlags(flags, "for the server to be ACTIVE again")
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 1 {
+					return fmt.Errorf("usage: %s server reboot [flags] <server>", sdk.program)
+				}
+				compute, err := computeService(sdk)
+				if err != nil {
+					return err
+				}
+				server, err := findServer(compute, args[0])
+				if err != nil {
+					return err
+				}
+				rebootType := openstack.RebootSoft
+				if *hard {
+					rebootType = openstack.RebootHard
+				}
+				ok, result, err := compute.RebootServer(*server.ID, rebootType)
+				if !ok {
+					return failure("rebooting server", result, err)
+				}
+				if *wait {
+					// give the server the time to leave the ACTIVE status first
+					time.Sleep(serverBackoff.Initial)
+				}
+				_, err = waitForServer(compute, *server.ID, openstack.ServerStatusActive, *wait, *waitTimeout)
+				return err
+			}
+		},
+	})
+
+	register(&Command{
+		Path:    []string{"server", "console-log"},
+		Usage:   "<server>",
+		Summary: "show the console output of a server, by name or ID",
+		Args:    []Completer{completeServers},
+		Setup: func(flags *flag.FlagSet) Runner {
+			lines := flags.Int("lines", 0, "only show the given number of trailing lines")
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 1 {
+					return fmt.Errorf("usage: %s server console-log [flags] <server>", sdk.program)
+				}
+				compute, err := computeService(sdk)
+				if err != nil {
+					return err
+				}
+				server, err := findServer(compute, args[0])
+				if err != nil {
+					return err
+				}
+				var length *int
+				if *lines > 0 {
+					length = openstack.Int(*lines)
+				}
+				output, result, err := compute.GetConsoleOutput(*server.ID, length)
+				if output == nil {
+					return failure("retrieving console output", result, err)
+				}
+				fmt.Fprint(sdk.out, *output)
+				return nil
+			}
+		},
+	})
+}
+
+// stringList is a flag that can be repeated, collecting all its values.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// waitFlags registers the flags asking a command to wait for the operation
+// it starts to complete, and for how long at most; what describes what is
+// waited for, in the usage of the flags.
+func waitFlags(flags *flag.FlagSet, what string) (*bool, *time.Duration) {
+	wait := flags.Bool("wait", false, "wait "+what)
+	timeout := flags.Duration("wait-timeout", 10*time.Minute, "how long to wait "+what)
+	return wait, timeout
+}
+
+// waitForServer waits, if requested, until the server with the given ID
+// reaches the given status, and returns it; if waiting is not requested, the
+// server is returned as it is now (or nil if it is being deleted).
+func waitForServer(compute *openstack.ComputeV2API, id string, status openstack.ServerStatus, wait bool, timeout time.Duration) (*openstack.Server, error) {
+	if !wait {
+		if status == openstack.ServerStatusDeleted {
+			return nil, nil
+		}
+		server, result, err := compute.RetrieveServer(id)
+		if server == nil {
+			return nil, failure(fmt.Sprintf("retrieving server %q", id), result, err)
+		}
+		return server, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	server, err := compute.WaitForServerStatus(ctx, id, status, serverBackoff)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("server %q did not become %s within %v", id, status, timeout)
+	}
+	return server, err
+}
+
+// computeService returns the Compute service of the cloud.
+func computeService(sdk *OpenStack) (*openstack.ComputeV2API, error) {
+	compute := sdk.client.ComputeV2()
+	if compute == nil {
+		return nil, fmt.Errorf("no compute service in the catalog")
+	}
+	return compute, nil
+}
+
+// findServer returns the server with the given ID or, failing that, the only
+// server with the given name.
+func findServer(compute *openstack.ComputeV2API, ref string) (*openstack.Server, error) {
+	server, result, err := compute.RetrieveServer(ref)
+	if server != nil {
+		return server, nil
+	}
+	if result == nil || result.Code != http.StatusNotFound {
+		return nil, failure(fmt.Sprintf("retrieving server %q", ref), result, err)
+	}
+	servers, result, err := compute.ListServers(&openstack.ListServersOptions{
+		// the name filter is a regular expression
+		Name: openstack.String("^" + regexp.QuoteMeta(ref) + "$"),
+	})
+	if servers == nil {
+		return nil, failure(fmt.Sprintf("looking up server %q", ref), result, err)
+	}
+	switch len(*servers) {
+	case 0:
+		return nil, fmt.Errorf("no server with name or ID %q", ref)
+	case 1:
+		server, result, err = compute.RetrieveServer(value((*servers)[0].ID))
+		if server == nil {
+			return nil, failure(fmt.Sprintf("retrieving server %q", ref), result, err)
+		}
+		return server, nil
+	}
+	return nil, fmt.Errorf("more than one server named %q, use its ID", ref)
+}
+
+// findFlavor returns the ID of the flavor with the given name or ID.
+func findFlavor(compute *openstack.ComputeV2API, ref string) (*string, error) {
+	flavors, result, err := compute.ListFlavors(nil)
+	if flavors == nil {
+		return nil, failure("listing flavors", result, err)
+	}
+	for _, flavor := range *flavors {
+		if value(flavor.ID) == ref || value(flavor.Name) == ref {
+			return flavor.ID, nil
+		}
+	}
+	return nil, fmt.Errorf("no flavor with name or ID %q", ref)
+}
+
+// findImage returns the ID of the only image with the given name, or the
+// given reference as is, assuming it is an ID, if no image has that name.
+func findImage(sdk *OpenStack, ref string) (*string, error) {
+	image := sdk.client.ImageV2()
+	if image == nil {
+		return openstack.String(ref), nil
+	}
+	images, result, err := image.ListImages(&openstack.ListImagesOptions{
+		Name: openstack.String(ref),
+	})
+	if images == nil {
+		return nil, failure("looking up image", result, err)
+	}
+	switch len(*images) {
+	case 0:
+		return openstack.String(ref), nil
+	case 1:
+		return (*images)[0].ID, nil
+	}
+	return nil, fmt.Errorf("more than one image named %q, use its ID", ref)
+}
+
+// findNetwork returns the ID of the only network with the given name, or the
+// given reference as is, assuming it is an ID, if no network has that name.
+func findNetwork(sdk *OpenStack, ref string) (*string, error) {
+	network := sdk.client.NetworkV2()
+	if network == nil {
+		return openstack.String(ref), nil
+	}
+	networks, result, err := network.ListNetworks(&openstack.ListNetworksOptions{
+		Name: openstack.String(ref),
+	})
+	if networks == nil {
+		return nil, failure("looking up network", result, err)
+	}
+	switch len(*networks) {
+	case 0:
+		return openstack.String(ref), nil
+	case 1:
+		return (*networks)[0].ID, nil
+	}
+	return nil, fmt.Errorf("more than one network named %q, use its ID", ref)
+}
+
+// printServer prints the details of the given server.
+func printServer(sdk *OpenStack, server *openstack.Server) {
+	fields := [][]string{
+		{"ID", value(server.ID)},
+		{"Name", value(server.Name)},
+		{"Status", serverStatus(server.Status)},
+	}
+	if server.TaskState != nil {
+		fields = append(fields, []string{"Task State", *server.TaskState})
+	}
+	if server.Fault != nil {
+		fields = append(fields, []string{"Fault", value(server.Fault.Message)})
+	}
+	if server.AdminPass != nil {
+		fields = append(fields, []string{"Admin Password", *server.AdminPass})
+	}
+	image := ""
+	if server.Image != nil {
+		image = value(server.Image.ID)
+	}
+	groups := []string{}
+	if server.SecurityGroups != nil {
+		for _, group := range *server.SecurityGroups {
+			groups = append(groups, value(group.Name))
+		}
+	}
+	fields = append(fields,
+		[]string{"Flavor", serverFlavor(server)},
+		[]string{"Image", image},
+		[]string{"Addresses", serverAddresses(server, "\n")},
+		[]string{"Key Name", value(server.KeyName)},
+		[]string{"Security Groups", strings.Join(groups, ", ")},
+		[]string{"Availability Zone", value(server.AvailabilityZone)},
+	)
+	if server.Host != nil {
+		fields = append(fields, []string{"Host", *server.Host})
+	}
+	fields = append(fields,
+		[]string{"Created", timestamp(server.Created, time.Now())},
+		[]string{"Updated", timestamp(server.Updated, time.Now())},
+	)
+	printFields(sdk.out, fields)
+}
+
+// serverStatus returns the given server status, or an empty string if it is
+// nil.
+func serverStatus(status *openstack.ServerStatus) string {
+	if status == nil {
+		return ""
+	}
+	return string(*status)
+}
+
+// serverFlavor returns the name of the flavor of the given server or, before
+// microversion 2.47, its ID.
+func serverFlavor(server *openstack.Server) string {
+	if server.Flavor == nil {
+		return ""
+	}
+	if server.Flavor.OriginalName != nil {
+		return *server.Flavor.OriginalName
+	}
+	return value(server.Flavor.ID)
+}
+
+// serverAddresses returns the addresses of the given server as
+// "network=address, address", one network after the other (sorted by name) and
+// separated by the given separator.
+func serverAddresses(server *openstack.Server, separator string) string {
+	names := []string{}
+	for name := range server.Addresses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	networks := []string{}
+	for _, name := range names {
+		addresses := []string{}
+		for _, address := range server.Addresses[name] {
+			addresses = append(addresses, value(address.Address))
+		}
+		networks = append(networks, name+"="+strings.Join(addresses, ", "))
+	}
+	return strings.Join(networks, separator)
+}
@@ -0,0 +1,66 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// promptPassword prints the given prompt on the terminal and reads a password
+// from it, without echoing it; it fails if the standard input is not a
+// terminal.
+func promptPassword(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !isTerminal(fd) {
+		return "", fmt.Errorf("cannot prompt for a password: the standard input is not a terminal")
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	var password string
+	err := withoutEcho(fd, func() error {
+		var err error
+		password, err = readLine(os.Stdin)
+		return err
+	})
+	// the newline typed by the user was not echoed either
+	fmt.Fprintln(os.Stderr)
+	return password, err
+}
+
+// promptYesNo prints the given question on the terminal and reads the answer,
+// which is no unless it starts with "y".
+func promptYesNo(question string) bool {
+	if !isTerminal(int(os.Stdin.Fd())) {
+		return false
+	}
+	fmt.Fprint(os.Stderr, question)
+	answer, err := readLine(os.Stdin)
+	return err == nil && strings.HasPrefix(strings.ToLower(strings.TrimSpace(answer)), "y")
+}
+
+// readLine reads a line from the given reader, one byte at a time so as not to
+// consume anything past its end, and returns it without the line terminator.
+func readLine(reader io.Reader) (string, error) {
+	line := []byte{}
+	b := make([]byte, 1)
+	for {
+		n, err := reader.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				break
+			}
+			line = append(line, b[0])
+		}
+		if err == io.EOF && len(line) > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimSuffix(string(line), "\r"), nil
+}
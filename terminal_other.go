@@ -0,0 +1,24 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package main
+
+import (
+	"fmt"
+)
+
+// isTerminal reports that no file descriptor is a terminal, since the echo of
+// the terminal cannot be turned off on this platform.
+func isTerminal(fd int) bool {
+	return false
+}
+
+// withoutEcho fails, since the echo of the terminal cannot be turned off on
+// this platform.
+func withoutEcho(fd int, f func() error) error {
+	return fmt.Errorf("cannot turn off the echo of the terminal on this platform")
+}
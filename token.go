@@ -0,0 +1,175 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dihedron/go-openstack/openstack"
+)
+
+func init() {
+	register(&Command{
+		Path:    []string{"token", "issue"},
+		Summary: "issue a token with the configured credentials",
+		Setup: func(flags *flag.FlagSet) Runner {
+			return func(sdk *OpenStack, args []string) error {
+				// logging in has just issued the session token
+				token := sdk.client.Authenticator.GetToken()
+				if token == nil {
+					return fmt.Errorf("no token issued")
+				}
+				printToken(sdk.out, token, true)
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:      []string{"token", "show"},
+		Usage:     "<token>",
+		Summary:   "show the expiry, user, scope and roles of a token",
+		Watchable: true,
+		Setup: func(flags *flag.FlagSet) Runner {
+			allowExpired := flags.Bool("allow-expired", false, "show the token even if it has expired")
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 1 {
+					return fmt.Errorf("usage: %s token show <token>", sdk.program)
+				}
+				token, result, err := identityV3(sdk).RetrieveToken(&openstack.RetrieveTokenOptions{
+					AllowExpired: openstack.Bool(*allowExpired),
+					NoCatalog:    openstack.Bool(true),
+					SubjectToken: args[0],
+				})
+				if token == nil {
+					return failure("retrieving token", result, err)
+				}
+				printToken(sdk.out, token, false)
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:    []string{"token", "validate"},
+		Usage:   "<token>",
+		Summary: "check whether a token is valid",
+		Setup: func(flags *flag.FlagSet) Runner {
+			allowExpired := flags.Bool("allow-expired", false, "consider expired tokens valid")
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 1 {
+					return fmt.Errorf("usage: %s token validate <token>", sdk.program)
+				}
+				ok, result, err := identityV3(sdk).CheckToken(&openstack.CheckTokenOptions{
+					AllowExpired: openstack.Bool(*allowExpired),
+					SubjectToken: args[0],
+				})
+				if err != nil || result == nil {
+					return failure("validating token", result, err)
+				}
+				if !ok {
+					return fmt.Errorf("token is not valid (%s)", result.Status)
+				}
+				fmt.Fprintln(sdk.out, "token is valid")
+				return nil
+			}
+		},
+	})
+
+	register(&Command{
+		Path:    []string{"token", "revoke"},
+		Usage:   "<token>",
+		Summary: "revoke a token before it expires",
+		Setup: func(flags *flag.FlagSet) Runner {
+			return func(sdk *OpenStack, args []string) error {
+				if len(args) != 1 {
+					return fmt.Errorf("usage: %s token revoke <token>", sdk.program)
+				}
+				ok, result, err := identityV3(sdk).DeleteToken(&openstack.DeleteTokenOptions{
+					SubjectToken: args[0],
+				})
+				if !ok {
+					return failure("revoking token", result, err)
+				}
+				fmt.Fprintln(sdk.out, "token revoked")
+				return nil
+			}
+		},
+	})
+}
+
+// printToken prints the details of the given token; its value is only
+// printed if requested, since it is a credential.
+func printToken(out io.Writer, token *openstack.Token, withValue bool) {
+	fields := [][]string{}
+	if withValue {
+		fields = append(fields, []string{"Token", value(token.Value)})
+	}
+	fields = append(fields,
+		[]string{"Expires", timestamp(token.ExpiresAt, time.Now())},
+		[]string{"Issued", timestamp(token.IssuedAt, time.Now())},
+	)
+	if token.User != nil {
+		fields = append(fields, []string{"User", nameAndID(token.User.Name, token.User.ID)})
+		if token.User.Domain != nil {
+			fields = append(fields, []string{"User Domain", nameAndID(token.User.Domain.Name, token.User.Domain.ID)})
+		}
+	}
+	fields = append(fields, []string{"Scope", tokenScope(token)})
+	roles := []string{}
+	if token.Roles != nil {
+		for _, role := range *token.Roles {
+			roles = append(roles, value(role.Name))
+		}
+	}
+	fields = append(fields, []string{"Roles", strings.Join(roles, ", ")})
+	if token.Methods != nil {
+		fields = append(fields, []string{"Methods", strings.Join(*token.Methods, ", ")})
+	}
+	printFields(out, fields)
+}
+
+// tokenScope describes the scope of the given token: a project (in a domain),
+// a domain, a trust, or none.
+func tokenScope(token *openstack.Token) string {
+	switch {
+	case token.Trust != nil:
+		return "trust " + value(token.Trust.ID)
+	case token.Project != nil:
+		scope := "project " + nameAndID(token.Project.Name, token.Project.ID)
+		if token.Project.Domain != nil {
+			scope += " in domain " + nameAndID(token.Project.Domain.Name, token.Project.Domain.ID)
+		}
+		return scope
+	case token.Domain != nil:
+		return "domain " + nameAndID(token.Domain.Name, token.Domain.ID)
+	}
+	return "unscoped"
+}
+
+// identityV3 returns the Identity service in the catalog or, if the catalog
+// has none, the one used to log in.
+func identityV3(sdk *OpenStack) *openstack.IdentityV3API {
+	if identity := sdk.client.IdentityV3(); identity != nil {
+		return identity
+	}
+	return sdk.client.Authenticator.Identity
+}
+
+// failure returns the error of a failed call: the given error if any, or one
+// built from the status of the given result.
+func failure(action string, result *openstack.Result, err error) error {
+	switch {
+	case err != nil:
+		return fmt.Errorf("error %s: %v", action, err)
+	case result != nil:
+		return fmt.Errorf("error %s: %s", action, result.Status)
+	}
+	return fmt.Errorf("error %s", action)
+}
@@ -0,0 +1,240 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strings"
+)
+
+// width is the column at which doc comments are wrapped.
+const width = 80
+
+// Generate returns the Go source of the bindings in the given spec, which was
+// read from the given source file, formatted as by gofmt.
+func Generate(spec *Spec, source string) ([]byte, error) {
+	g := &generator{}
+	g.printf("// Copyright 2017-present Andrea Funtò. All rights reserved.\n")
+	g.printf("// Use of this source code is governed by a MIT-style\n")
+	g.printf("// license that can be found in the LICENSE file.\n\n")
+	g.printf("// Code generated by bindgen from %s; DO NOT EDIT.\n\n", source)
+	g.printf("package %s\n\n", spec.Package)
+	g.printf("import (\n\t\"net/http\"\n\n\t\"github.com/dihedron/go-log\"\n)\n")
+
+	if len(spec.Types) > 0 {
+		g.section(spec.Title)
+		for i, t := range spec.Types {
+			if i > 0 {
+				g.printf("\n")
+			}
+			g.comment(t.Name + " " + sentence(t.Doc))
+			g.printf("type %s struct {\n", t.Name)
+			for _, field := range t.Fields {
+				g.printf("\t%s %s `json:\"%s,omitempty\"`\n", field.Name, field.Type, field.Key)
+			}
+			g.printf("}\n")
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, binding := range spec.Bindings {
+		g.binding(spec.API, binding, declared)
+	}
+
+	code, err := format.Source(g.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting the generated code: %v", err)
+	}
+	return code, nil
+}
+
+// generator accumulates the generated source.
+type generator struct {
+	bytes.Buffer
+}
+
+// printf appends formatted text to the source.
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(g, format, args...)
+}
+
+// section starts a new section with the given title.
+func (g *generator) section(title string) {
+	g.printf("\n/*\n * %s\n */\n\n", title)
+}
+
+// comment appends the given text as a doc comment, wrapped at width.
+func (g *generator) comment(text string) {
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > width && line != "//" {
+			g.printf("%s\n", line)
+			line = "//"
+		}
+		line += " " + word
+	}
+	g.printf("%s\n", line)
+}
+
+// binding appends the options struct, if not declared yet, and the method of
+// the given binding.
+func (g *generator) binding(api string, b Binding, declared map[string]bool) {
+	title := b.Title
+	if title == "" {
+		title = strings.ToUpper(words(b.Name))
+	}
+	g.section(title)
+
+	if b.Options != nil && !declared[b.Options.Name] {
+		declared[b.Options.Name] = true
+		g.comment(b.Options.Name + " provides the options available for " + sentence(b.Options.Doc))
+		g.printf("type %s struct {\n", b.Options.Name)
+		for _, field := range b.Options.Fields {
+			g.printf("\t%s %s `%s`\n", field.Name, field.Type, field.tags())
+		}
+		g.printf("}\n\n")
+	}
+
+	// signature
+	params := []string{}
+	for _, variable := range b.Variables {
+		params = append(params, variable+" string")
+	}
+	doc := b.Name + " " + b.Doc
+	if b.Options != nil {
+		params = append(params, "opts *"+b.Options.Name)
+		doc += "; opts can be nil"
+	}
+	returns := "(bool, *Result, error)"
+	if b.Result != nil {
+		if b.Result.List {
+			returns = "(*[]" + b.Result.Type + ", *Result, error)"
+		} else {
+			returns = "(*" + b.Result.Type + ", *Result, error)"
+		}
+	}
+	g.comment(doc + "; see also " + b.Reference)
+	g.printf("func (api *%s) %s(%s) %s {\n", api, b.Name, strings.Join(params, ", "), returns)
+
+	// input
+	input := "nil"
+	if b.Options != nil {
+		g.printf("\tif opts == nil {\n\t\topts = &%s{}\n\t}\n", b.Options.Name)
+		input = "opts"
+	}
+	if len(b.Variables) > 0 || (b.Options != nil && b.Options.Envelope != "") {
+		fields, values := []string{}, []string{}
+		for _, variable := range b.Variables {
+			name := exported(variable)
+			fields = append(fields, fmt.Sprintf("%s string `parameter:\"-\" header:\"-\" variable:\"%s\" json:\"-\"`", name, variable))
+			values = append(values, fmt.Sprintf("%s: %s,", name, variable))
+		}
+		if b.Options != nil {
+			if b.Options.Envelope != "" {
+				// the query parameters and the headers stay out of the envelope
+				for _, field := range b.Options.Fields {
+					if field.In != "body" {
+						fields = append(fields, fmt.Sprintf("%s %s `%s`", field.Name, field.Type, field.tags()))
+						values = append(values, fmt.Sprintf("%s: opts.%s,", field.Name, field.Name))
+					}
+				}
+				name := exported(b.Options.Envelope)
+				fields = append(fields, fmt.Sprintf("%s *%s `parameter:\"-\" header:\"-\" json:\"%s\"`", name, b.Options.Name, b.Options.Envelope))
+				values = append(values, fmt.Sprintf("%s: opts,", name))
+			} else {
+				fields = append(fields, "*"+b.Options.Name)
+				values = append(values, fmt.Sprintf("%s: opts,", b.Options.Name))
+			}
+		}
+		g.printf("\tinput := &struct {\n\t\t%s\n\t}{\n\t\t%s\n\t}\n", strings.Join(fields, "\n\t\t"), strings.Join(values, "\n\t\t"))
+		input = "input"
+	}
+
+	// output
+	output := "nil"
+	if b.Result != nil {
+		name := exported(b.Result.Key)
+		kind := "*" + b.Result.Type
+		if b.Result.List {
+			kind = "*[]" + b.Result.Type
+		}
+		g.printf("\toutput := &struct {\n\t\t%s %s `header:\"-\" json:\"%s,omitempty\"`\n\t}{}\n", name, kind, b.Result.Key)
+		output = "output"
+	}
+
+	// call
+	codes, checks := []string{}, []string{}
+	for _, code := range b.Success {
+		codes = append(codes, fmt.Sprintf("%d", code))
+		checks = append(checks, fmt.Sprintf("result.Code == %d", code))
+	}
+	check := checks[0]
+	if len(checks) > 1 {
+		check = "(" + strings.Join(checks, " || ") + ")"
+	}
+	g.printf("\n\tresult, err := api.Invoke(%s, %q, %t, StatusCodeIn(%s), %s, %s, nil)\n", methods[b.Method], b.Path, !b.Unauthenticated, strings.Join(codes, ", "), input, output)
+	g.printf("\tlog.Debugf(\"result is %%v (%%v)\", result, err)\n")
+	g.printf("\tif result != nil && %s {\n", check)
+	if b.Result != nil {
+		g.printf("\t\treturn output.%s, result, err\n\t}\n\treturn nil, result, err\n}\n", exported(b.Result.Key))
+	} else {
+		g.printf("\t\treturn true, result, err\n\t}\n\treturn false, result, err\n}\n")
+	}
+}
+
+// tags returns the struct tags of an options field, for go-request.
+func (f Field) tags() string {
+	switch f.In {
+	case "query":
+		return fmt.Sprintf("parameter:\"%s,omitempty\" header:\"-\" json:\"-\"", f.Key)
+	case "header":
+		return fmt.Sprintf("parameter:\"-\" header:\"%s,omitempty\" json:\"-\"", f.Key)
+	}
+	return fmt.Sprintf("parameter:\"-\" header:\"-\" json:\"%s,omitempty\"", f.Key)
+}
+
+// initialisms are the words spelt in capitals in exported names.
+var initialisms = map[string]string{"id": "ID", "ip": "IP", "url": "URL", "uuid": "UUID"}
+
+// exported returns the exported Go name for the given JSON attribute or path
+// variable, e.g. "AvailabilityZoneInfo" for "availabilityZoneInfo" and
+// "AggregateID" for "aggregateid" or "aggregate_id".
+func exported(name string) string {
+	result := ""
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		if initialism, ok := initialisms[strings.ToLower(part)]; ok {
+			result += initialism
+			continue
+		}
+		if len(part) > 2 && strings.HasSuffix(part, "id") && part == strings.ToLower(part) {
+			// path variables are lowercase, e.g. "serverid"
+			result += strings.ToUpper(part[:1]) + part[1:len(part)-2] + "ID"
+			continue
+		}
+		result += strings.ToUpper(part[:1]) + part[1:]
+	}
+	return result
+}
+
+// boundary matches the boundaries between the words in a Go name.
+var boundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// words splits the given Go name in words, e.g. "List Aggregates" for
+// "ListAggregates".
+func words(name string) string {
+	return boundary.ReplaceAllString(name, "$1 $2")
+}
+
+// sentence terminates the given text with a full stop, unless it already is.
+func sentence(text string) string {
+	text = strings.TrimSpace(text)
+	if strings.HasSuffix(text, ".") {
+		return text
+	}
+	return text + "."
+}
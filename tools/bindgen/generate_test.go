@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateUpToDate(t *testing.T) {
+	specs, err := filepath.Glob("../../openstack/bindings/*.json")
+	if err != nil {
+		t.Fatalf("Bindgen.TestGenerateUpToDate: error looking for specs: %v", err)
+	}
+	for _, path := range specs {
+		spec, err := ReadSpec(path)
+		if err != nil {
+			t.Errorf("Bindgen.TestGenerateUpToDate: %v", err)
+			continue
+		}
+		name := filepath.Base(path)
+		code, err := Generate(spec, "bindings/"+name)
+		if err != nil {
+			t.Errorf("Bindgen.TestGenerateUpToDate: error generating %s: %v", name, err)
+			continue
+		}
+		generated := filepath.Join("../../openstack", strings.TrimSuffix(name, ".json")+".go")
+		current, err := ioutil.ReadFile(generated)
+		if err != nil || !bytes.Equal(current, code) {
+			t.Errorf("Bindgen.TestGenerateUpToDate: %s is out of date with %s, run go generate (%v)", generated, name, err)
+		}
+	}
+}
+
+func TestGenerateExample(t *testing.T) {
+	spec, err := ReadSpec("testdata/example.json")
+	if err != nil {
+		t.Fatalf("Bindgen.TestGenerateExample: %v", err)
+	}
+	code, err := Generate(spec, "testdata/example.json")
+	if err != nil {
+		t.Fatalf("Bindgen.TestGenerateExample: error generating the example: %v", err)
+	}
+	expected, err := ioutil.ReadFile("testdata/example.go.golden")
+	if err != nil || !bytes.Equal(code, expected) {
+		t.Errorf("Bindgen.TestGenerateExample: unexpected bindings (%v):\n%s", err, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	binding := func() Binding {
+		return Binding{Name: "RetrieveThing", Doc: "retrieves a thing", Reference: "https://docs", Method: "GET", Path: "./things/{thingid}", Success: []int{200}, Variables: []string{"thingid"}}
+	}
+	tests := []struct {
+		change func(*Spec)
+		valid  bool
+	}{
+		{func(s *Spec) {}, true},
+		{func(s *Spec) { s.API = "" }, false},
+		{func(s *Spec) { s.Bindings[0].Method = "FETCH" }, false},
+		{func(s *Spec) { s.Bindings[0].Success = nil }, false},
+		{func(s *Spec) { s.Bindings[0].Variables = nil }, false},
+		{func(s *Spec) { s.Bindings[0].Variables = []string{"thingID"} }, false},
+		{func(s *Spec) { s.Bindings[0].Path, s.Bindings[0].Variables = "./things/{opts}", []string{"opts"} }, false},
+		{func(s *Spec) { s.Bindings[0].Options = &Options{Name: "ThingOptions"} }, false},
+		{func(s *Spec) {
+			s.Bindings[0].Options = &Options{Name: "ThingOptions", Doc: "things", Fields: []Field{{Name: "Limit", Type: "*int", In: "path", Key: "limit"}}}
+		}, false},
+		{func(s *Spec) {
+			s.Bindings[0].Options = &Options{Name: "ThingOptions", Doc: "things", Fields: []Field{{Name: "Limit", Type: "*int", In: "query", Key: "limit"}}}
+			s.Bindings = append(s.Bindings, binding())
+			s.Bindings[1].Options = &Options{Name: "ThingOptions"}
+		}, true},
+		{func(s *Spec) { s.Types = []Type{{Name: "Thing", Doc: "is a thing"}} }, false},
+	}
+	for i, test := range tests {
+		spec := &Spec{API: "ThingV1API", Bindings: []Binding{binding()}}
+		test.change(spec)
+		if err := spec.Validate(); (err == nil) != test.valid {
+			t.Errorf("Bindgen.TestValidate: case %d: expected valid %t, got %v", i, test.valid, err)
+		}
+	}
+}
+
+func TestExported(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"aggregate", "Aggregate"},
+		{"aggregateid", "AggregateID"},
+		{"availability_zone", "AvailabilityZone"},
+		{"availabilityZoneInfo", "AvailabilityZoneInfo"},
+		{"add_host", "AddHost"},
+		{"image_id", "ImageID"},
+		{"id", "ID"},
+	}
+	for _, test := range tests {
+		if actual := exported(test.name); actual != test.expected {
+			t.Errorf("Bindgen.TestExported: expected %q for %q, got %q", test.expected, test.name, actual)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Command bindgen generates OpenStack API bindings from a declarative JSON
+// description of the endpoints (method, path, query, header and body fields,
+// success status codes, result); for each endpoint it emits the options
+// struct, the method wrapping the Invoke call and the typed result, as they
+// are written by hand in the openstack package. It is meant to be run by go
+// generate, e.g.
+//
+//	//go:generate go run github.com/dihedron/go-openstack/tools/bindgen -output compute_v2_things.go bindings/compute_v2_things.json
+//
+// See Spec for the format of the description, and testdata/example.json (and
+// the bindings generated from it, testdata/example.go.golden) for an example.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	output := flag.String("output", "", "the file to write the bindings to, by default the name of the spec with the .go extension")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: bindgen [-output <file>] <spec>\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+	if *output == "" {
+		*output = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)) + ".go"
+	}
+	if err := run(path, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "bindgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the bindings in the spec at the given path into the given
+// output file.
+func run(path string, output string) error {
+	spec, err := ReadSpec(path)
+	if err != nil {
+		return err
+	}
+	code, err := Generate(spec, filepath.ToSlash(path))
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(output, code, 0644)
+}
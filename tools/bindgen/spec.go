@@ -0,0 +1,269 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Spec is the declarative description of a set of API bindings, all methods
+// of the same API type (e.g. ComputeV2API), and of the types they return.
+type Spec struct {
+	// Package is the package of the generated file, by default "openstack".
+	Package string `json:"package,omitempty"`
+	// API is the type the bindings are methods of, e.g. "ComputeV2API".
+	API string `json:"api"`
+	// Title is the title of the section the types are declared in, e.g.
+	// "AGGREGATES"; it is only required if there are types.
+	Title string `json:"title,omitempty"`
+	// Types are the structs returned by the bindings.
+	Types []Type `json:"types,omitempty"`
+	// Bindings are the API calls, in the order they are generated.
+	Bindings []Binding `json:"bindings"`
+}
+
+// Type is a struct returned by the bindings, e.g. a resource.
+type Type struct {
+	// Name is the name of the type, e.g. "Aggregate".
+	Name string `json:"name"`
+	// Doc is its doc comment, without the name, e.g. "is a group of hosts".
+	Doc string `json:"doc"`
+	// Fields are its fields; their In is ignored, they are all in the body.
+	Fields []Field `json:"fields"`
+}
+
+// Field is a field of a type or of an options struct.
+type Field struct {
+	// Name is the name of the field, e.g. "AvailabilityZone".
+	Name string `json:"name"`
+	// Type is its Go type, e.g. "*string"; options are always pointers, so
+	// that unset fields are omitted.
+	Type string `json:"type"`
+	// In is where the field goes in the request: "query", "header" or "body"
+	// (the default).
+	In string `json:"in,omitempty"`
+	// Key is the name of the query parameter, of the header or of the JSON
+	// attribute, e.g. "availability_zone".
+	Key string `json:"key"`
+}
+
+// Binding is an API call, e.g. "GET ./os-aggregates/{aggregateid}".
+type Binding struct {
+	// Name is the name of the method, e.g. "RetrieveAggregate".
+	Name string `json:"name"`
+	// Title is the title of its section, by default the name split in words,
+	// e.g. "RETRIEVE AGGREGATE".
+	Title string `json:"title,omitempty"`
+	// Doc is its doc comment, without the name and the reference, e.g.
+	// "retrieves the host aggregate with the given ID".
+	Doc string `json:"doc"`
+	// Reference is the URL of the API reference of the call.
+	Reference string `json:"reference"`
+	// Method is the HTTP method, e.g. "GET".
+	Method string `json:"method"`
+	// Path is the path of the call relative to the endpoint, with the
+	// variables in braces, e.g. "./os-aggregates/{aggregateid}".
+	Path string `json:"path"`
+	// Unauthenticated is set for calls that do not require a token.
+	Unauthenticated bool `json:"unauthenticated,omitempty"`
+	// Success are the status codes of a successful call, e.g. [200, 201].
+	Success []int `json:"success"`
+	// Variables are the variables in the path, in the order of the method
+	// arguments, e.g. ["aggregateid"]; they are all strings.
+	Variables []string `json:"variables,omitempty"`
+	// Options is the options struct of the call, if any.
+	Options *Options `json:"options,omitempty"`
+	// Result is what the call returns, if anything; calls without a result
+	// return whether they succeeded.
+	Result *Result `json:"result,omitempty"`
+}
+
+// Options is the options struct of a call.
+type Options struct {
+	// Name is the name of the struct, e.g. "CreateAggregateOptions"; a struct
+	// without fields is one declared by a previous binding, which is shared.
+	Name string `json:"name"`
+	// Doc is its doc comment, following "<Name> provides the options available
+	// for", e.g. "creating a host aggregate".
+	Doc string `json:"doc,omitempty"`
+	// Fields are the fields of the struct.
+	Fields []Field `json:"fields,omitempty"`
+	// Envelope is the JSON attribute the body fields are wrapped in, if any,
+	// e.g. "aggregate" for {"aggregate": {"name": ...}}.
+	Envelope string `json:"envelope,omitempty"`
+}
+
+// Result is what a call returns, taken from an attribute of the response.
+type Result struct {
+	// Type is the type of the result, e.g. "Aggregate".
+	Type string `json:"type"`
+	// Key is the JSON attribute holding it, e.g. "aggregate".
+	Key string `json:"key"`
+	// List is set if the attribute holds a list of the type.
+	List bool `json:"list,omitempty"`
+}
+
+// identifier matches Go identifiers.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reserved are the names used by the generated methods, which the variables
+// must not shadow.
+var reserved = map[string]bool{"api": true, "opts": true, "input": true, "output": true, "result": true, "err": true}
+
+// methods are the supported HTTP methods, with the constants naming them.
+var methods = map[string]string{
+	http.MethodGet:    "http.MethodGet",
+	http.MethodHead:   "http.MethodHead",
+	http.MethodPost:   "http.MethodPost",
+	http.MethodPut:    "http.MethodPut",
+	http.MethodPatch:  "http.MethodPatch",
+	http.MethodDelete: "http.MethodDelete",
+}
+
+// ReadSpec reads and validates the spec in the file at the given path.
+func ReadSpec(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid spec %s: %v", path, err)
+	}
+	return spec, nil
+}
+
+// Validate checks that the spec is complete and consistent, and fills in the
+// defaults.
+func (s *Spec) Validate() error {
+	if s.Package == "" {
+		s.Package = "openstack"
+	}
+	if !identifier.MatchString(s.API) {
+		return fmt.Errorf("invalid API type %q", s.API)
+	}
+	if len(s.Types) > 0 && s.Title == "" {
+		return fmt.Errorf("the types need a section title")
+	}
+	for _, t := range s.Types {
+		if !identifier.MatchString(t.Name) {
+			return fmt.Errorf("invalid type name %q", t.Name)
+		}
+		for _, field := range t.Fields {
+			if err := field.validate(); err != nil {
+				return fmt.Errorf("type %s: %v", t.Name, err)
+			}
+		}
+	}
+	if len(s.Bindings) == 0 {
+		return fmt.Errorf("no bindings")
+	}
+	options := map[string]*Options{}
+	for i := range s.Bindings {
+		if err := s.Bindings[i].validate(options); err != nil {
+			return fmt.Errorf("binding %s: %v", s.Bindings[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks the binding, given the options structs declared by the
+// previous ones, and records its own.
+func (b *Binding) validate(options map[string]*Options) error {
+	if !identifier.MatchString(b.Name) {
+		return fmt.Errorf("invalid name")
+	}
+	if b.Doc == "" || b.Reference == "" {
+		return fmt.Errorf("missing doc or reference")
+	}
+	if _, ok := methods[b.Method]; !ok {
+		return fmt.Errorf("unsupported method %q", b.Method)
+	}
+	if len(b.Success) == 0 {
+		return fmt.Errorf("no success status codes")
+	}
+	for _, code := range b.Success {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid status code %d", code)
+		}
+	}
+	path := b.Path
+	for _, variable := range b.Variables {
+		if !identifier.MatchString(variable) || strings.ToLower(variable) != variable {
+			return fmt.Errorf("invalid variable %q: it must be a lowercase identifier", variable)
+		}
+		if reserved[variable] {
+			return fmt.Errorf("invalid variable %q: it is used by the generated code", variable)
+		}
+		placeholder := "{" + variable + "}"
+		if !strings.Contains(path, placeholder) {
+			return fmt.Errorf("variable %q not in path", variable)
+		}
+		path = strings.Replace(path, placeholder, "", -1)
+	}
+	if strings.ContainsAny(path, "{}") {
+		return fmt.Errorf("undeclared variables in path %q", b.Path)
+	}
+	if b.Options != nil {
+		if !identifier.MatchString(b.Options.Name) {
+			return fmt.Errorf("invalid options name %q", b.Options.Name)
+		}
+		if len(b.Options.Fields) == 0 {
+			shared, ok := options[b.Options.Name]
+			if !ok {
+				return fmt.Errorf("options %s have no fields and are not declared by a previous binding", b.Options.Name)
+			}
+			if b.Options.Envelope != shared.Envelope {
+				return fmt.Errorf("options %s are shared with a different envelope", b.Options.Name)
+			}
+			b.Options = shared
+		} else {
+			if _, ok := options[b.Options.Name]; ok {
+				return fmt.Errorf("options %s already declared", b.Options.Name)
+			}
+			if b.Options.Doc == "" {
+				return fmt.Errorf("options %s have no doc", b.Options.Name)
+			}
+			for i := range b.Options.Fields {
+				field := &b.Options.Fields[i]
+				if field.In == "" {
+					field.In = "body"
+				}
+				if err := field.validate(); err != nil {
+					return fmt.Errorf("options %s: %v", b.Options.Name, err)
+				}
+			}
+			options[b.Options.Name] = b.Options
+		}
+	}
+	if b.Result != nil && (b.Result.Type == "" || b.Result.Key == "") {
+		return fmt.Errorf("the result needs a type and a key")
+	}
+	return nil
+}
+
+// validate checks the field.
+func (f *Field) validate() error {
+	if !identifier.MatchString(f.Name) {
+		return fmt.Errorf("invalid field name %q", f.Name)
+	}
+	if f.Type == "" || f.Key == "" {
+		return fmt.Errorf("field %s needs a type and a key", f.Name)
+	}
+	switch f.In {
+	case "", "query", "header", "body":
+	default:
+		return fmt.Errorf("field %s: invalid location %q", f.Name, f.In)
+	}
+	return nil
+}
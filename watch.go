@@ -0,0 +1,113 @@
+// Copyright 2017-present Andrea Funtò. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+const (
+	// clearScreen moves the cursor to the top left corner of the terminal and
+	// clears it.
+	clearScreen = "\033[H\033[2J"
+	// highlightOn and highlightOff delimit text printed in reverse video.
+	highlightOn  = "\033[7m"
+	highlightOff = "\033[0m"
+)
+
+// watchOptions are the flags of the commands that support watch mode.
+type watchOptions struct {
+	Watch     bool
+	Interval  time.Duration
+	Highlight bool
+}
+
+// watchFlags registers the flags of watch mode on the given flag set.
+func watchFlags(flags *flag.FlagSet) *watchOptions {
+	opts := &watchOptions{}
+	flags.BoolVar(&opts.Watch, "watch", false, "run the command again at every interval and redraw its output, until interrupted")
+	flags.DurationVar(&opts.Interval, "interval", 5*time.Second, "the interval between two runs in watch mode")
+	flags.BoolVar(&opts.Highlight, "highlight", false, "highlight the lines that changed since the previous run in watch mode")
+	return opts
+}
+
+// watchCommand runs the given command in watch mode until interrupted by the
+// user, which is not an error.
+func watchCommand(sdk *OpenStack, opts *watchOptions, title string, run Runner, args []string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	go func() {
+		select {
+		case <-interrupts:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	out := sdk.out
+	defer func() {
+		sdk.out = out
+	}()
+	return watch(ctx, out, opts, title, func(w io.Writer) error {
+		sdk.out = w
+		return run(sdk, args)
+	})
+}
+
+// watch runs the given function at the given interval until the context is
+// done, each time clearing the screen and printing its output (or its error)
+// under a header with the given title and the time of the run; the lines that
+// changed since the previous run are highlighted if requested.
+func watch(ctx context.Context, out io.Writer, opts *watchOptions, title string, run func(io.Writer) error) error {
+	if opts.Interval <= 0 {
+		return fmt.Errorf("the watch interval must be positive")
+	}
+	previous := ""
+	for {
+		buffer := &bytes.Buffer{}
+		if err := run(buffer); err != nil {
+			// keep watching: the error may be transient
+			fmt.Fprintf(buffer, "error: %v\n", err)
+		}
+		current := buffer.String()
+		output := current
+		if opts.Highlight && previous != "" {
+			output = highlightChanges(previous, current)
+		}
+		previous = current
+
+		fmt.Fprintf(out, "%sEvery %v: %s    %s\n\n%s", clearScreen, opts.Interval, title, time.Now().Format("2006-01-02 15:04:05"), output)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// highlightChanges returns the current output with the lines that differ from
+// those at the same position in the previous output in reverse video.
+func highlightChanges(previous string, current string) string {
+	before := strings.Split(previous, "\n")
+	after := strings.Split(current, "\n")
+	for i, line := range after {
+		if line != "" && (i >= len(before) || before[i] != line) {
+			after[i] = highlightOn + line + highlightOff
+		}
+	}
+	return strings.Join(after, "\n")
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHighlightChanges(t *testing.T) {
+	previous := "| s1 | BUILD  |\n| s2 | ACTIVE |\n"
+	current := "| s1 | ACTIVE |\n| s2 | ACTIVE |\n| s3 | BUILD  |\n"
+	expected := highlightOn + "| s1 | ACTIVE |" + highlightOff + "\n| s2 | ACTIVE |\n" + highlightOn + "| s3 | BUILD  |" + highlightOff + "\n"
+	if actual := highlightChanges(previous, current); actual != expected {
+		t.Errorf("Watch.TestHighlightChanges: expected %q, got %q", expected, actual)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := 0
+	out := &bytes.Buffer{}
+	err := watch(ctx, out, &watchOptions{Interval: time.Millisecond}, "server list", func(w io.Writer) error {
+		runs++
+		if runs == 2 {
+			return fmt.Errorf("timeout")
+		}
+		if runs == 3 {
+			cancel()
+		}
+		fmt.Fprintf(w, "run %d\n", runs)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Watch.TestWatch: unexpected error %v", err)
+	}
+	if runs != 3 {
+		t.Errorf("Watch.TestWatch: expected 3 runs, got %d", runs)
+	}
+	for _, expected := range []string{"run 1\n", "error: timeout\n", "run 3\n", "Every 1ms: server list"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Watch.TestWatch: expected %q in output %q", expected, out.String())
+		}
+	}
+	if strings.Count(out.String(), clearScreen) != 3 {
+		t.Errorf("Watch.TestWatch: expected the screen to be cleared 3 times in %q", out.String())
+	}
+}